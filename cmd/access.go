@@ -0,0 +1,166 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/cluster"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// restrictedMethods are the methods the access rules apply to.
+var restrictedMethods = []string{"tools/call", "resources/read", "completion/complete"}
+
+// accessMiddleware restricts the tool calls, resource reads and argument
+// completions of each caller to the projects the access rules of the config
+// allow them. Callers matching no rule can't call tools or read resources. The
+// project of a call is its project_id argument, the project of the URI of a
+// GKE cluster resource or the project argument of a completion, or else the
+// default project. The projects named by the other arguments of a tool call
+// must be allowed too, tools whose project can't be resolved are denied to
+// callers not allowed all projects, and kubectl commands are restricted to the
+// GKE clusters of the allowed projects.
+func accessMiddleware(c *config.Config) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if !slices.Contains(restrictedMethods, method) || len(c.Access()) == 0 {
+				return next(ctx, method, req)
+			}
+
+			principal := ""
+			if extra := req.GetExtra(); extra != nil && extra.TokenInfo != nil {
+				principal = extra.TokenInfo.UserID
+			}
+			projects, ok := c.Access().Projects(principal)
+			if !ok {
+				return nil, fmt.Errorf("caller %q is not allowed to call tools or read resources", principal)
+			}
+			allowed := func(projectID string) bool {
+				return config.AllowsProject(projects, projectID)
+			}
+
+			call := callProjects{projectID: resourceProjectID(req)}
+			if method == "tools/call" {
+				var err error
+				if call, err = toolCallProjects(c, req); err != nil {
+					return nil, err
+				}
+			}
+			if call.unresolved != "" && !slices.Contains(projects, "*") {
+				return nil, fmt.Errorf("caller %s is not allowed to use %s, whose project can't be checked", principal, call.unresolved)
+			}
+			if call.projectID == "" {
+				call.projectID = c.DefaultProjectID(ctx)
+			}
+			for _, projectID := range append([]string{call.projectID}, call.others...) {
+				if projectID != "" && !allowed(projectID) {
+					return nil, fmt.Errorf("caller %s is not allowed to use project %s", principal, projectID)
+				}
+			}
+
+			return next(kube.WithProjectCheck(ctx, allowed), method, req)
+		}
+	}
+}
+
+// callProjects are the projects a call uses.
+type callProjects struct {
+	// projectID is the project_id argument of a tool call, or the project of
+	// a resource read or completion, if any.
+	projectID string
+	// others are the projects named by the other arguments of a tool call.
+	others []string
+	// unresolved describes the argument or tool whose project can't be
+	// resolved, which only callers allowed all projects can use.
+	unresolved string
+}
+
+// toolCallProjects returns the projects of a tool call: its project_id
+// argument, the project of its billing_export_table argument and of a
+// subscription argument given as a full name. Cloud Storage destinations and
+// plugins can target any project, so they are reported as unresolved.
+func toolCallProjects(c *config.Config, req mcp.Request) (callProjects, error) {
+	params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+	if !ok {
+		return callProjects{}, nil
+	}
+	for _, p := range c.Plugins() {
+		if p.Name == params.Name {
+			return callProjects{unresolved: "plugin tool " + p.Name}, nil
+		}
+	}
+	if len(params.Arguments) == 0 {
+		return callProjects{}, nil
+	}
+	var args struct {
+		ProjectID          string `json:"project_id"`
+		BillingExportTable string `json:"billing_export_table"`
+		Subscription       string `json:"subscription"`
+		Destination        string `json:"destination"`
+	}
+	if err := json.Unmarshal(params.Arguments, &args); err != nil {
+		return callProjects{}, fmt.Errorf("failed to parse tool arguments: %w", err)
+	}
+
+	call := callProjects{projectID: args.ProjectID}
+	if project := tableProjectID(args.BillingExportTable); project != "" {
+		call.others = append(call.others, project)
+	}
+	if rest, ok := strings.CutPrefix(args.Subscription, "projects/"); ok {
+		project, _, _ := strings.Cut(rest, "/")
+		call.others = append(call.others, project)
+	}
+	if strings.HasPrefix(args.Destination, "gs://") {
+		call.unresolved = "Cloud Storage destination " + args.Destination
+	}
+	return call, nil
+}
+
+// tableProjectID returns the project of a BigQuery table named
+// project.dataset.table, the project possibly containing dots, e.g.
+// example.com:my-project.
+func tableProjectID(table string) string {
+	parts := strings.Split(strings.TrimSpace(table), ".")
+	if len(parts) < 3 {
+		return ""
+	}
+	return strings.Join(parts[:len(parts)-2], ".")
+}
+
+// resourceProjectID returns the project of the URI of a GKE cluster resource
+// read, or the project argument of a completion, if any.
+func resourceProjectID(req mcp.Request) string {
+	switch params := req.GetParams().(type) {
+	case *mcp.ReadResourceParams:
+		projectID, _, _, err := cluster.ParseResourceURI(params.URI)
+		if err != nil {
+			return ""
+		}
+		return projectID
+	case *mcp.CompleteParams:
+		if params.Context == nil {
+			return ""
+		}
+		return params.Context.Arguments["project"]
+	}
+	return ""
+}
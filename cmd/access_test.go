@@ -0,0 +1,178 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/idtoken"
+)
+
+func callToolRequest(principal, arguments string) *mcp.CallToolRequest {
+	return &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{Name: "list_clusters", Arguments: json.RawMessage(arguments)},
+		Extra:  &mcp.RequestExtra{TokenInfo: &auth.TokenInfo{UserID: principal}},
+	}
+}
+
+func TestAccessMiddleware(t *testing.T) {
+	c := config.New("test", config.WithAccess(config.Access{
+		{Principals: []string{"*@example.com"}, Projects: []string{"team-a-*"}},
+	}))
+	var called bool
+	var kubectlErr error
+	next := func(ctx context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+		called = true
+		// The kubectl commands of the call are restricted to the allowed projects.
+		_, kubectlErr = kube.Run(kube.WithKubeContext(ctx, "gke_team-b-prod_us-central1_my-cluster"), "get", "pods")
+		return nil, nil
+	}
+	handler := accessMiddleware(c)(next)
+
+	tests := []struct {
+		name       string
+		principal  string
+		arguments  string
+		wantCalled bool
+	}{
+		{"allowed project", "alice@example.com", `{"project_id":"team-a-prod"}`, true},
+		{"other project", "alice@example.com", `{"project_id":"team-b-prod"}`, false},
+		{"unknown caller", "mallory@example.org", `{"project_id":"team-a-prod"}`, false},
+		{"anonymous caller", "", `{"project_id":"team-a-prod"}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called, kubectlErr = false, nil
+			_, err := handler(context.Background(), "tools/call", callToolRequest(tt.principal, tt.arguments))
+			if called != tt.wantCalled || (err == nil) != tt.wantCalled {
+				t.Errorf("accessMiddleware() called the tool = %t, error = %v, want called %t", called, err, tt.wantCalled)
+			}
+			if called && kubectlErr == nil {
+				t.Errorf("kubectl in another project returned no error")
+			}
+		})
+	}
+
+	for uri, wantCalled := range map[string]bool{
+		"gke://team-a-prod/us-central1/my-cluster": true,
+		"gke://team-b-prod/us-central1/my-cluster": false,
+	} {
+		called = false
+		req := &mcp.ReadResourceRequest{
+			Params: &mcp.ReadResourceParams{URI: uri},
+			Extra:  &mcp.RequestExtra{TokenInfo: &auth.TokenInfo{UserID: "alice@example.com"}},
+		}
+		if _, err := handler(context.Background(), "resources/read", req); called != wantCalled || (err == nil) != wantCalled {
+			t.Errorf("accessMiddleware() read %s = %t, error = %v, want %t", uri, called, err, wantCalled)
+		}
+	}
+
+	if _, err := handler(context.Background(), "tools/list", &mcp.ListToolsRequest{}); err != nil || !called {
+		t.Errorf("accessMiddleware() restricted tools/list, error = %v", err)
+	}
+}
+
+func TestAccessMiddleware_OtherArguments(t *testing.T) {
+	c := config.New("test",
+		config.WithAccess(config.Access{
+			{Principals: []string{"alice@example.com"}, Projects: []string{"team-a-*"}},
+			{Principals: []string{"admin@example.com"}, Projects: []string{"*"}},
+		}),
+		config.WithPlugins(config.Plugins{{Name: "check_org_policies", Command: []string{"true"}}}),
+	)
+	var called bool
+	next := func(context.Context, string, mcp.Request) (mcp.Result, error) {
+		called = true
+		return nil, nil
+	}
+	handler := accessMiddleware(c)(next)
+
+	tests := []struct {
+		name       string
+		principal  string
+		tool       string
+		arguments  string
+		wantCalled bool
+	}{
+		{"billing table of allowed project", "alice@example.com", "get_cluster_costs", `{"project_id":"team-a-prod","billing_export_table":"team-a-billing.export.gcp_billing"}`, true},
+		{"billing table of other project", "alice@example.com", "get_cluster_costs", `{"project_id":"team-a-prod","billing_export_table":"finance.export.gcp_billing"}`, false},
+		{"subscription of other project", "alice@example.com", "get_upgrade_notifications", `{"project_id":"team-a-prod","subscription":"projects/team-b-prod/subscriptions/upgrades"}`, false},
+		{"subscription ID", "alice@example.com", "get_upgrade_notifications", `{"project_id":"team-a-prod","subscription":"upgrades"}`, true},
+		{"local report", "alice@example.com", "save_report", `{"project_id":"team-a-prod","destination":"/tmp/reports"}`, true},
+		{"Cloud Storage report", "alice@example.com", "save_report", `{"project_id":"team-a-prod","destination":"gs://reports"}`, false},
+		{"Cloud Storage report of admin", "admin@example.com", "save_report", `{"project_id":"team-a-prod","destination":"gs://reports"}`, true},
+		{"plugin", "alice@example.com", "check_org_policies", `{"project_id":"team-a-prod"}`, false},
+		{"plugin of admin", "admin@example.com", "check_org_policies", `{"scope":1}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called = false
+			req := &mcp.CallToolRequest{
+				Params: &mcp.CallToolParamsRaw{Name: tt.tool, Arguments: json.RawMessage(tt.arguments)},
+				Extra:  &mcp.RequestExtra{TokenInfo: &auth.TokenInfo{UserID: tt.principal}},
+			}
+			_, err := handler(context.Background(), "tools/call", req)
+			if called != tt.wantCalled || (err == nil) != tt.wantCalled {
+				t.Errorf("accessMiddleware() called the tool = %t, error = %v, want called %t", called, err, tt.wantCalled)
+			}
+		})
+	}
+}
+
+func TestTableProjectID(t *testing.T) {
+	for table, want := range map[string]string{
+		"billing.export.gcp_billing":             "billing",
+		"example.com:billing.export.gcp_billing": "example.com:billing",
+		"export.gcp_billing":                     "",
+		"":                                       "",
+	} {
+		if got := tableProjectID(table); got != want {
+			t.Errorf("tableProjectID(%q) = %q, want %q", table, got, want)
+		}
+	}
+}
+
+func TestGoogleTokenVerifier(t *testing.T) {
+	validate := func(_ context.Context, token, audience string) (*idtoken.Payload, error) {
+		if audience != "gke-mcp" {
+			return nil, errors.New("audience mismatch")
+		}
+		switch token {
+		case "verified":
+			return &idtoken.Payload{Expires: 1, Claims: map[string]any{"email": "alice@example.com", "email_verified": true}}, nil
+		case "unverified":
+			return &idtoken.Payload{Claims: map[string]any{"email": "alice@example.com", "email_verified": false}}, nil
+		}
+		return nil, errors.New("invalid token")
+	}
+	verifier := googleTokenVerifier("gke-mcp", validate)
+
+	info, err := verifier(context.Background(), "verified", nil)
+	if err != nil || info.UserID != "alice@example.com" {
+		t.Errorf("verifier() = %+v, %v, want alice@example.com", info, err)
+	}
+	for _, token := range []string{"unverified", "invalid"} {
+		if _, err := verifier(context.Background(), token, nil); !errors.Is(err, auth.ErrInvalidToken) {
+			t.Errorf("verifier(%q) error = %v, want ErrInvalidToken", token, err)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// removeDisabled removes the tools and prompts disabled in the config from
+// the server, so that they are neither listed nor callable. In read-only
+// mode, the tools without the read-only hint are disabled too.
+func removeDisabled(ctx context.Context, s *mcp.Server, c *config.Config) error {
+	// The server doesn't expose its tools and prompts, list them through an in-memory session.
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	ss, err := s.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+	defer func() { _ = ss.Close() }()
+	client := mcp.NewClient(&mcp.Implementation{Name: "gke-mcp-filter", Version: version}, nil)
+	cs, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+	defer func() { _ = cs.Close() }()
+
+	var tools []string
+	for tool, err := range cs.Tools(ctx, nil) {
+		if err != nil {
+			return fmt.Errorf("failed to list tools: %w", err)
+		}
+		if !c.ToolEnabled(tool.Name) || (c.ReadOnly() && !isReadOnly(tool)) {
+			tools = append(tools, tool.Name)
+		}
+	}
+	var prompts []string
+	for prompt, err := range cs.Prompts(ctx, nil) {
+		if err != nil {
+			return fmt.Errorf("failed to list prompts: %w", err)
+		}
+		if !c.PromptEnabled(prompt.Name) {
+			prompts = append(prompts, prompt.Name)
+		}
+	}
+
+	s.RemoveTools(tools...)
+	s.RemovePrompts(prompts...)
+	return nil
+}
+
+func isReadOnly(tool *mcp.Tool) bool {
+	return tool.Annotations != nil && tool.Annotations.ReadOnlyHint
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type emptyArgs struct{}
+
+func testServer() *mcp.Server {
+	s := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	for _, name := range []string{"list_clusters", "query_logs", "get_log_schema"} {
+		mcp.AddTool(s, &mcp.Tool{Name: name, Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true}}, func(context.Context, *mcp.CallToolRequest, *emptyArgs) (*mcp.CallToolResult, any, error) {
+			return &mcp.CallToolResult{}, nil, nil
+		})
+	}
+	for _, name := range []string{"create_cluster", "get_kubeconfig"} {
+		mcp.AddTool(s, &mcp.Tool{Name: name}, func(context.Context, *mcp.CallToolRequest, *emptyArgs) (*mcp.CallToolResult, any, error) {
+			return &mcp.CallToolResult{}, nil, nil
+		})
+	}
+	for _, name := range []string{"gke:cost", "gke:deploy"} {
+		s.AddPrompt(&mcp.Prompt{Name: name}, func(context.Context, *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			return &mcp.GetPromptResult{}, nil
+		})
+	}
+	return s
+}
+
+// listed returns the names of the tools and prompts of the server.
+func listed(t *testing.T, s *mcp.Server) (tools, prompts []string) {
+	t.Helper()
+	ctx := context.Background()
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	ss, err := s.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = ss.Close() }()
+	cs, err := mcp.NewClient(&mcp.Implementation{Name: "test"}, nil).Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = cs.Close() }()
+
+	for tool, err := range cs.Tools(ctx, nil) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		tools = append(tools, tool.Name)
+	}
+	for prompt, err := range cs.Prompts(ctx, nil) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		prompts = append(prompts, prompt.Name)
+	}
+	return tools, prompts
+}
+
+func TestRemoveDisabled(t *testing.T) {
+	s := testServer()
+	c := config.New("test",
+		config.WithToolFilter(config.Filter{Disabled: []string{"*_logs", "get_log_schema", "get_kubeconfig"}}),
+		config.WithPromptFilter(config.Filter{Enabled: []string{"gke:cost"}}),
+	)
+
+	if err := removeDisabled(context.Background(), s, c); err != nil {
+		t.Fatalf("removeDisabled() error = %v", err)
+	}
+
+	tools, prompts := listed(t, s)
+	if want := []string{"create_cluster", "list_clusters"}; !slices.Equal(tools, want) {
+		t.Errorf("tools = %q, want %q", tools, want)
+	}
+	if want := []string{"gke:cost"}; !slices.Equal(prompts, want) {
+		t.Errorf("prompts = %q, want %q", prompts, want)
+	}
+}
+
+func TestRemoveDisabled_ReadOnly(t *testing.T) {
+	s := testServer()
+	c := config.New("test", config.WithReadOnly(true))
+
+	if err := removeDisabled(context.Background(), s, c); err != nil {
+		t.Fatalf("removeDisabled() error = %v", err)
+	}
+
+	tools, prompts := listed(t, s)
+	if want := []string{"get_log_schema", "list_clusters", "query_logs"}; !slices.Equal(tools, want) {
+		t.Errorf("tools = %q, want %q", tools, want)
+	}
+	if want := []string{"gke:cost", "gke:deploy"}; !slices.Equal(prompts, want) {
+		t.Errorf("prompts = %q, want %q", prompts, want)
+	}
+}
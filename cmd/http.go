@@ -0,0 +1,110 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/metrics"
+	"github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/rs/cors"
+	"google.golang.org/api/idtoken"
+)
+
+// newHTTPHandler returns the handler serving the MCP server over streamable
+// HTTP, or over SSE if serverMode is sse, to the allowed origins. If verifier
+// is not nil, requests must bear a token it accepts, including those of the
+// Prometheus metrics served on /metrics, which reveal the tools called.
+func newHTTPHandler(s *mcp.Server, serverMode string, allowedOrigins []string, verifier auth.TokenVerifier) http.Handler {
+	getServer := func(_ *http.Request) *mcp.Server {
+		return s
+	}
+	var handler http.Handler
+	if serverMode == "sse" {
+		handler = mcp.NewSSEHandler(getServer, nil)
+	} else {
+		handler = mcp.NewStreamableHTTPHandler(getServer, nil)
+	}
+
+	metricsHandler := metrics.Handler()
+	if verifier != nil {
+		handler = auth.RequireBearerToken(verifier, nil)(handler)
+		metricsHandler = auth.RequireBearerToken(verifier, nil)(metricsHandler)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metricsHandler)
+	mux.Handle("/", handler)
+
+	// Create a new CORS handler
+	c := cors.New(cors.Options{
+		AllowedOrigins: allowedOrigins,
+		Debug:          true, // Enable debug logging to see what the library is doing
+	})
+	return c.Handler(mux)
+}
+
+// staticTokenVerifier returns a verifier accepting the bearer token equal to want.
+func staticTokenVerifier(want string) auth.TokenVerifier {
+	return func(_ context.Context, token string, _ *http.Request) (*auth.TokenInfo, error) {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(want)) != 1 {
+			return nil, fmt.Errorf("%w: unknown bearer token", auth.ErrInvalidToken)
+		}
+		// The token doesn't expire, the expiration is only required by the verification.
+		return &auth.TokenInfo{Expiration: time.Now().Add(time.Hour)}, nil
+	}
+}
+
+// validateIDToken validates a Google identity token, see idtoken.Validate.
+type validateIDToken func(ctx context.Context, token, audience string) (*idtoken.Payload, error)
+
+// googleTokenVerifier returns a verifier accepting the Google identity tokens
+// issued for the audience, identifying the callers by their verified email.
+func googleTokenVerifier(audience string, validate validateIDToken) auth.TokenVerifier {
+	return func(ctx context.Context, token string, _ *http.Request) (*auth.TokenInfo, error) {
+		payload, err := validate(ctx, token, audience)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", auth.ErrInvalidToken, err)
+		}
+		email, _ := payload.Claims["email"].(string)
+		if verified, _ := payload.Claims["email_verified"].(bool); email == "" || !verified {
+			return nil, fmt.Errorf("%w: identity token without a verified email", auth.ErrInvalidToken)
+		}
+		return &auth.TokenInfo{
+			UserID:     email,
+			Expiration: time.Unix(payload.Expires, 0),
+		}, nil
+	}
+}
+
+// readAuthToken reads the bearer token from a file, ignoring surrounding whitespace.
+func readAuthToken(name string) (string, error) {
+	// #nosec G304
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return "", fmt.Errorf("failed to read auth token file: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("auth token file %s is empty", name)
+	}
+	return token, nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/session"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const initializeRequest = `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test","version":"1.0.0"}}}`
+
+func postInitialize(t *testing.T, url, token string) int {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(initializeRequest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return resp.StatusCode
+}
+
+func TestNewHTTPHandler_AuthToken(t *testing.T) {
+	s := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	server := httptest.NewServer(newHTTPHandler(s, "http", []string{"http://localhost"}, staticTokenVerifier("secret-token")))
+	defer server.Close()
+
+	if got := postInitialize(t, server.URL, ""); got != http.StatusUnauthorized {
+		t.Errorf("status without token = %d, want %d", got, http.StatusUnauthorized)
+	}
+	if got := postInitialize(t, server.URL, "wrong-token"); got != http.StatusUnauthorized {
+		t.Errorf("status with wrong token = %d, want %d", got, http.StatusUnauthorized)
+	}
+	if got := postInitialize(t, server.URL, "secret-token"); got != http.StatusOK {
+		t.Errorf("status with token = %d, want %d", got, http.StatusOK)
+	}
+
+	// The metrics require the token too.
+	for token, want := range map[string]int{"": http.StatusUnauthorized, "secret-token": http.StatusOK} {
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/metrics", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("metrics status with token %q = %d, want %d", token, resp.StatusCode, want)
+		}
+	}
+}
+
+func TestNewHTTPHandler_NoAuthToken(t *testing.T) {
+	s := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	server := httptest.NewServer(newHTTPHandler(s, "http", []string{"http://localhost"}, nil))
+	defer server.Close()
+
+	if got := postInitialize(t, server.URL, ""); got != http.StatusOK {
+		t.Errorf("status = %d, want %d", got, http.StatusOK)
+	}
+}
+
+type setProjectArgs struct {
+	ProjectID string `json:"project_id,omitempty"`
+}
+
+// TestNewHTTPHandler_SSESessions checks that concurrent SSE sessions keep their own state.
+func TestNewHTTPHandler_SSESessions(t *testing.T) {
+	ctx := context.Background()
+	c := &config.Config{}
+	s := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	s.AddReceivingMiddleware(session.Middleware)
+	mcp.AddTool(s, &mcp.Tool{Name: "set_project"}, func(ctx context.Context, req *mcp.CallToolRequest, args *setProjectArgs) (*mcp.CallToolResult, any, error) {
+		if args.ProjectID != "" {
+			session.Update(req, func(st *session.State) { st.ProjectID = args.ProjectID })
+			return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: args.ProjectID}}}, nil, nil
+		}
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: c.DefaultProjectID(ctx)}}}, nil, nil
+	})
+	server := httptest.NewServer(newHTTPHandler(s, "sse", []string{"http://localhost"}, nil))
+	defer server.Close()
+
+	callTool := func(cs *mcp.ClientSession, projectID string) string {
+		t.Helper()
+		result, err := cs.CallTool(ctx, &mcp.CallToolParams{Name: "set_project", Arguments: map[string]any{"project_id": projectID}})
+		if err != nil {
+			t.Fatalf("CallTool() error = %v", err)
+		}
+		return result.Content[0].(*mcp.TextContent).Text
+	}
+
+	var sessions []*mcp.ClientSession
+	for _, projectID := range []string{"project-a", "project-b"} {
+		client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil)
+		cs, err := client.Connect(ctx, &mcp.SSEClientTransport{Endpoint: server.URL}, nil)
+		if err != nil {
+			t.Fatalf("Connect() error = %v", err)
+		}
+		defer func() { _ = cs.Close() }()
+		callTool(cs, projectID)
+		sessions = append(sessions, cs)
+	}
+
+	if got := callTool(sessions[0], ""); got != "project-a" {
+		t.Errorf("project of the first session = %q, want project-a", got)
+	}
+	if got := callTool(sessions[1], ""); got != "project-b" {
+		t.Errorf("project of the second session = %q, want project-b", got)
+	}
+}
+
+func TestReadAuthToken(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "token")
+	if err := os.WriteFile(name, []byte("  secret-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := readAuthToken(name); err != nil || got != "secret-token" {
+		t.Errorf("readAuthToken() = %q, %v, want secret-token", got, err)
+	}
+
+	empty := filepath.Join(dir, "empty")
+	if err := os.WriteFile(empty, []byte("\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readAuthToken(empty); err == nil {
+		t.Errorf("readAuthToken() of an empty file returned no error")
+	}
+}
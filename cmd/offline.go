@@ -0,0 +1,125 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/gkereleasenotes"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/k8schangelog"
+	"github.com/spf13/cobra"
+)
+
+// defaultOfflineVersions is the number of Kubernetes minor versions, from the
+// newest in the GKE release notes, whose changelogs are downloaded by default.
+const defaultOfflineVersions = 6
+
+var (
+	downloadOfflineSourcesCmd = &cobra.Command{
+		Use:   "download-offline-sources",
+		Short: "Download the Kubernetes changelogs and GKE release notes and known issues into a directory for --offline-dir.",
+		Run:   runDownloadOfflineSourcesCmd,
+	}
+
+	offlineSourcesDir     string
+	offlineVersions       []string
+	offlineLatestVersions int
+)
+
+func init() {
+	downloadOfflineSourcesCmd.Flags().StringVar(&offlineSourcesDir, "dir", "", "directory the changelogs and release notes are written to, created if needed")
+	downloadOfflineSourcesCmd.Flags().StringSliceVar(&offlineVersions, "kubernetes-versions", nil, "comma-separated list of Kubernetes minor versions whose changelogs are downloaded, e.g. 1.32,1.33; defaults to the newest versions of the GKE release notes")
+	downloadOfflineSourcesCmd.Flags().IntVar(&offlineLatestVersions, "latest-versions", defaultOfflineVersions, "number of the newest Kubernetes minor versions of the GKE release notes whose changelogs are downloaded when --kubernetes-versions is not set")
+	downloadOfflineSourcesCmd.Flags().StringVar(&configFile, "config", "", "path of a YAML configuration file whose GitHub token and changelog and release notes mirrors are used")
+	_ = downloadOfflineSourcesCmd.MarkFlagRequired("dir")
+}
+
+func runDownloadOfflineSourcesCmd(cmd *cobra.Command, _ []string) {
+	githubToken := os.Getenv("GITHUB_TOKEN")
+	var sources config.Sources
+	if configFile != "" {
+		f, err := config.LoadFile(configFile)
+		if err != nil {
+			fatal("Failed to load config", "error", err)
+		}
+		sources = f.Sources
+		if f.GitHub.Token != "" {
+			githubToken = f.GitHub.Token
+		}
+	}
+	c := config.New(version, config.WithGitHubToken(githubToken), config.WithSources(sources))
+
+	files, err := downloadOfflineSources(cmd.Context(), c, offlineSourcesDir, offlineVersions, offlineLatestVersions)
+	if err != nil {
+		fatal("Failed to download offline sources", "error", err)
+	}
+	for _, f := range files {
+		fmt.Println(f)
+	}
+	fmt.Printf("Successfully downloaded the offline sources, start the server with --offline-dir %s to use them.\n", offlineSourcesDir)
+}
+
+// downloadOfflineSources writes the GKE release notes and known issues and the
+// changelogs of the Kubernetes minor versions into dir, and returns the written files. The
+// changelogs of the latest minor versions of the release notes are written if
+// no versions are given.
+func downloadOfflineSources(ctx context.Context, c *config.Config, dir string, versions []string, latest int) ([]string, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create offline directory: %w", err)
+	}
+
+	page, err := gkereleasenotes.Download(ctx, c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download GKE release notes: %w", err)
+	}
+	name := filepath.Join(dir, gkereleasenotes.OfflineFileName)
+	if err := os.WriteFile(name, page, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write GKE release notes: %w", err)
+	}
+	files := []string{name}
+
+	knownIssues, err := gkereleasenotes.DownloadKnownIssues(ctx, c)
+	if err != nil {
+		return files, fmt.Errorf("failed to download GKE known issues: %w", err)
+	}
+	name = filepath.Join(dir, gkereleasenotes.KnownIssuesOfflineFileName)
+	if err := os.WriteFile(name, knownIssues, 0600); err != nil {
+		return files, fmt.Errorf("failed to write GKE known issues: %w", err)
+	}
+	files = append(files, name)
+
+	if len(versions) == 0 {
+		versions = gkereleasenotes.MinorVersions(page)
+		if len(versions) > latest {
+			versions = versions[:latest]
+		}
+	}
+	for _, v := range versions {
+		changelog, err := k8schangelog.Download(ctx, c, v)
+		if err != nil {
+			return files, fmt.Errorf("failed to download changelog of Kubernetes %s: %w", v, err)
+		}
+		name := filepath.Join(dir, k8schangelog.OfflineFileName(v))
+		if err := os.WriteFile(name, changelog, 0600); err != nil {
+			return files, fmt.Errorf("failed to write changelog of Kubernetes %s: %w", v, err)
+		}
+		files = append(files, name)
+	}
+	return files, nil
+}
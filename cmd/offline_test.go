@@ -0,0 +1,81 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+)
+
+func TestDownloadOfflineSources(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/release-notes":
+			_, _ = fmt.Fprint(w, "<p>1.34.1-gke.100</p><p>1.33.5-gke.1200</p><p>1.32.9-gke.300</p>")
+		case "/known-issues":
+			_, _ = fmt.Fprint(w, "<table><tr><th>Fixed version</th><th>Issue</th></tr></table>")
+		case "/CHANGELOG-1.34.md", "/CHANGELOG-1.33.md", "/CHANGELOG-1.32.md":
+			_, _ = fmt.Fprint(w, "changelog "+r.URL.Path)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	c := config.New("test", config.WithSources(config.Sources{
+		ChangelogURL:    server.URL + "/CHANGELOG-{version}.md",
+		ReleaseNotesURL: server.URL + "/release-notes",
+		KnownIssuesURL:  server.URL + "/known-issues",
+	}))
+
+	tests := []struct {
+		name     string
+		versions []string
+		want     []string
+	}{
+		{"latest versions", nil, []string{"release-notes.html", "known-issues.html", "CHANGELOG-1.34.md", "CHANGELOG-1.33.md"}},
+		{"given versions", []string{"1.32"}, []string{"release-notes.html", "known-issues.html", "CHANGELOG-1.32.md"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "offline")
+			files, err := downloadOfflineSources(context.Background(), c, dir, tt.versions, 2)
+			if err != nil {
+				t.Fatalf("downloadOfflineSources() error = %v", err)
+			}
+			if len(files) != len(tt.want) {
+				t.Fatalf("downloadOfflineSources() = %q, want %q", files, tt.want)
+			}
+			for i, want := range tt.want {
+				if files[i] != filepath.Join(dir, want) {
+					t.Errorf("downloadOfflineSources()[%d] = %q, want %q", i, files[i], filepath.Join(dir, want))
+				}
+				if _, err := os.Stat(files[i]); err != nil {
+					t.Errorf("file %s not written: %v", files[i], err)
+				}
+			}
+		})
+	}
+
+	if _, err := downloadOfflineSources(context.Background(), c, t.TempDir(), []string{"1.20"}, 2); err == nil {
+		t.Error("downloadOfflineSources() with a missing changelog error = nil, want an error")
+	}
+}
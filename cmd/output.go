@@ -0,0 +1,55 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/output"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// outputMiddleware truncates the text of the tool results larger than the
+// limit of their tool, keeping their head and tail. The limit is shared by
+// all the text contents of a result.
+func outputMiddleware(limits config.OutputLimits) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+			if !ok || params == nil {
+				return next(ctx, method, req)
+			}
+			result, err := next(ctx, method, req)
+			res, ok := result.(*mcp.CallToolResult)
+			maxSize := limits.For(params.Name)
+			if !ok || res == nil || maxSize == 0 {
+				return result, err
+			}
+
+			remaining := maxSize
+			for _, c := range res.Content {
+				text, ok := c.(*mcp.TextContent)
+				if !ok {
+					continue
+				}
+				// Keep room for the truncation marker of the contents past the limit.
+				text.Text, _ = output.Truncate(text.Text, max(remaining, 1))
+				remaining -= len(text.Text)
+			}
+			return result, err
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestOutputMiddleware(t *testing.T) {
+	long := strings.Repeat("line of output\n", 100)
+	next := func(context.Context, string, mcp.Request) (mcp.Result, error) {
+		return &mcp.CallToolResult{Content: []mcp.Content{
+			&mcp.TextContent{Text: long},
+			&mcp.TextContent{Text: long},
+		}}, nil
+	}
+
+	tests := []struct {
+		name   string
+		limits config.OutputLimits
+		want   int
+	}{
+		{"default limit", config.OutputLimits{}, 2 * len(long)},
+		{"tool limit", config.OutputLimits{Tools: map[string]int{"list_clusters": 300}}, 400},
+		{"no limit", config.OutputLimits{MaxSize: 100, Tools: map[string]int{"list_clusters": -1}}, 2 * len(long)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := outputMiddleware(tt.limits)(next)(context.Background(), "tools/call", callToolRequest("", `{}`))
+			if err != nil {
+				t.Fatalf("outputMiddleware() error = %v", err)
+			}
+			size := 0
+			for _, c := range result.(*mcp.CallToolResult).Content {
+				size += len(c.(*mcp.TextContent).Text)
+			}
+			if size > tt.want {
+				t.Errorf("outputMiddleware() returned %d bytes, want at most %d", size, tt.want)
+			}
+			if tt.want < 2*len(long) && !strings.Contains(result.(*mcp.CallToolResult).Content[0].(*mcp.TextContent).Text, "[... truncated ") {
+				t.Errorf("outputMiddleware() returned no truncation marker")
+			}
+		})
+	}
+}
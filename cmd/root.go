@@ -19,23 +19,33 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
+	"path/filepath"
 	"runtime/debug"
 	"strings"
 	"time"
 
 	container "cloud.google.com/go/container/apiv1"
 	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/audit"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/completion"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/install"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/logger"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/metrics"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/progress"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/session"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tracing"
+	"github.com/modelcontextprotocol/go-sdk/auth"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
-	"github.com/rs/cors"
 	"github.com/spf13/cobra"
-	"google.golang.org/api/option"
+	"google.golang.org/api/idtoken"
+	"google.golang.org/api/impersonate"
 )
 
 const (
@@ -50,6 +60,22 @@ var (
 	serverHost     string
 	serverPort     int
 	allowedOrigins []string
+	allowWrite     bool
+	dryRun         bool
+	readOnly       bool
+	configFile     string
+	toolFilter     config.Filter
+	promptFilter   config.Filter
+	impersonateSA  string
+	authTokenFile  string
+	googleAudience string
+	logOptions     logger.Options
+	traceOptions   tracing.Options
+	auditOptions   audit.Options
+	toolTimeout    string
+	maxOutputSize  int
+	offlineDir     string
+	promptsDir     string
 
 	// rootCmd represents the base command when called without any subcommands
 	rootCmd = &cobra.Command{
@@ -104,14 +130,37 @@ func init() {
 	if bi, ok := debug.ReadBuildInfo(); ok {
 		version = bi.Main.Version
 	} else {
-		log.Printf("Failed to read build info to get version.")
+		slog.Warn("Failed to read build info to get version")
 	}
 
-	rootCmd.Flags().StringVar(&serverMode, "server-mode", "stdio", "transport to use for the server: stdio (default) or http")
-	rootCmd.Flags().StringVar(&serverHost, "server-host", "127.0.0.1", "server host to use when server-mode is http; defaults to 127.0.0.1")
-	rootCmd.Flags().IntVar(&serverPort, "server-port", 8080, "server port to use when server-mode is http; defaults to 8080")
+	rootCmd.Flags().StringVar(&serverMode, "server-mode", "stdio", "transport to use for the server: stdio (default), http or sse")
+	rootCmd.Flags().StringVar(&serverHost, "server-host", "127.0.0.1", "server host to use when server-mode is http or sse; defaults to 127.0.0.1")
+	rootCmd.Flags().IntVar(&serverPort, "server-port", 8080, "server port to use when server-mode is http or sse; defaults to 8080")
 	rootCmd.Flags().StringSliceVar(&allowedOrigins, "allowed-origins", []string{"http://localhost"}, "comma-separated list of allowed Origin headers")
+	rootCmd.Flags().StringVar(&authTokenFile, "auth-token-file", "", "path of a file holding the bearer token HTTP clients must send when server-mode is http or sse; no authentication by default")
+	rootCmd.Flags().StringVar(&googleAudience, "google-audience", "", "audience of the Google identity tokens HTTP clients must send when server-mode is http or sse, identifying callers for the access rules of the config file")
+	rootCmd.Flags().BoolVar(&allowWrite, "allow-write", false, "register the tools mutating GKE clusters, such as starting upgrades; disabled by default")
+	rootCmd.Flags().BoolVar(&dryRun, "dry-run", false, "make the mutating tools describe the API calls they would make instead of making them")
+	rootCmd.Flags().BoolVar(&readOnly, "read-only", false, "only register read-only tools and refuse kubectl commands which can modify the cluster; cannot be combined with --allow-write")
+	rootCmd.Flags().StringVar(&impersonateSA, "impersonate-service-account", "", "email of a service account to impersonate for Google API calls and gcloud commands, using Application Default Credentials as the caller")
+	rootCmd.Flags().StringVar(&logOptions.Level, "log-level", "", "minimum level of the logged records: debug, info (default), warn or error; debug also logs the MCP messages")
+	rootCmd.Flags().StringVar(&logOptions.Format, "log-format", "", "format of the logged records: text (default) or json")
+	rootCmd.Flags().StringVar(&logOptions.File, "log-file", "", "file the logged records are appended to; standard error by default")
+	rootCmd.Flags().StringVar(&traceOptions.Exporter, "trace-exporter", "", "exporter of the OpenTelemetry traces of the tool calls: otlp or cloudtrace; no tracing by default")
+	rootCmd.Flags().StringVar(&traceOptions.Endpoint, "trace-endpoint", "", "URL of the OTLP endpoint the traces are exported to; read from OTEL_EXPORTER_OTLP_ENDPOINT by default")
+	rootCmd.Flags().StringVar(&auditOptions.File, "audit-log-file", "", "JSONL file every tool call, with its arguments, caller and truncated result, is appended to")
+	rootCmd.Flags().StringVar(&auditOptions.CloudLoggingLog, "audit-cloud-logging", "", "name of the Cloud Logging log of the default project every tool call is recorded to")
+	rootCmd.Flags().StringVar(&toolTimeout, "tool-timeout", "", "maximum duration of tool calls, e.g. 90s or 15m, after which their commands are stopped; 0 means no timeout; defaults to 10m")
+	rootCmd.Flags().IntVar(&maxOutputSize, "max-output-size", 0, "maximum size of the text of tool results, in bytes, beyond which their middle is cut out; -1 means no limit; defaults to 256 KiB")
+	rootCmd.Flags().StringVar(&offlineDir, "offline-dir", "", "directory the Kubernetes changelogs and GKE release notes are read from instead of the network, created with the download-offline-sources command")
+	rootCmd.Flags().StringVar(&promptsDir, "prompts-dir", defaultPromptsDir(), "directory of the files overriding (<template>.md) or extending (<template>.append.md) the templates of the prompts, e.g. gke-upgrade-risk-report.md")
+	rootCmd.Flags().StringVar(&configFile, "config", "", "path of a YAML configuration file enabling or disabling tools and prompts")
+	rootCmd.Flags().StringSliceVar(&toolFilter.Enabled, "enabled-tools", nil, "comma-separated list of tool names or glob patterns to enable; all tools are enabled by default")
+	rootCmd.Flags().StringSliceVar(&toolFilter.Disabled, "disabled-tools", nil, "comma-separated list of tool names or glob patterns to disable, e.g. query_logs,get_log_schema")
+	rootCmd.Flags().StringSliceVar(&promptFilter.Enabled, "enabled-prompts", nil, "comma-separated list of prompt names or glob patterns to enable; all prompts are enabled by default")
+	rootCmd.Flags().StringSliceVar(&promptFilter.Disabled, "disabled-prompts", nil, "comma-separated list of prompt names or glob patterns to disable")
 	rootCmd.AddCommand(installCmd)
+	rootCmd.AddCommand(downloadOfflineSourcesCmd)
 
 	installCmd.AddCommand(installGeminiCLICmd)
 	installCmd.AddCommand(installCursorCmd)
@@ -130,6 +179,22 @@ type startOptions struct {
 	serverHost     string
 	serverPort     int
 	allowedOrigins []string
+	allowWrite     bool
+	dryRun         bool
+	readOnly       bool
+	configFile     string
+	toolFilter     config.Filter
+	promptFilter   config.Filter
+	impersonateSA  string
+	authTokenFile  string
+	googleAudience string
+	logOptions     logger.Options
+	traceOptions   tracing.Options
+	auditOptions   audit.Options
+	toolTimeout    string
+	maxOutputSize  int
+	offlineDir     string
+	promptsDir     string
 }
 
 func runRootCmd(cmd *cobra.Command, _ []string) {
@@ -138,30 +203,172 @@ func runRootCmd(cmd *cobra.Command, _ []string) {
 		serverHost:     serverHost,
 		serverPort:     serverPort,
 		allowedOrigins: allowedOrigins,
+		allowWrite:     allowWrite,
+		dryRun:         dryRun,
+		readOnly:       readOnly,
+		configFile:     configFile,
+		toolFilter:     toolFilter,
+		promptFilter:   promptFilter,
+		impersonateSA:  impersonateSA,
+		authTokenFile:  authTokenFile,
+		googleAudience: googleAudience,
+		logOptions:     logOptions,
+		traceOptions:   traceOptions,
+		auditOptions:   auditOptions,
+		toolTimeout:    toolTimeout,
+		maxOutputSize:  maxOutputSize,
+		offlineDir:     offlineDir,
+		promptsDir:     promptsDir,
 	}
 	startMCPServer(cmd.Context(), opts)
 }
 
 func startMCPServer(ctx context.Context, opts startOptions) {
-	c := config.New(version)
+	if opts.readOnly && opts.allowWrite {
+		fatal("--read-only cannot be combined with --allow-write")
+	}
+	if opts.googleAudience != "" && opts.authTokenFile != "" {
+		fatal("--google-audience cannot be combined with --auth-token-file")
+	}
+	if opts.googleAudience != "" && opts.serverMode != "http" && opts.serverMode != "sse" {
+		fatal("--google-audience requires --server-mode http or sse")
+	}
+	toolFilter, promptFilter := opts.toolFilter, opts.promptFilter
+	var access config.Access
+	timeouts := config.Timeouts{Default: opts.toolTimeout}
+	outputLimits := config.OutputLimits{MaxSize: opts.maxOutputSize}
+	githubToken := os.Getenv("GITHUB_TOKEN")
+	sources := config.Sources{OfflineDir: opts.offlineDir}
+	var plugins config.Plugins
+	var billing config.Billing
+	var files config.Files
+	logOptions, traceOptions, auditOptions := opts.logOptions, opts.traceOptions, opts.auditOptions
+	if opts.configFile != "" {
+		f, err := config.LoadFile(opts.configFile)
+		if err != nil {
+			fatal("Failed to load config", "error", err)
+		}
+		toolFilter = f.Tools.Merge(toolFilter)
+		promptFilter = f.Prompts.Merge(promptFilter)
+		access = f.Access
+		logOptions = f.Logging.Merge(logOptions)
+		traceOptions = f.Tracing.Merge(traceOptions)
+		auditOptions = f.Audit.Merge(auditOptions)
+		timeouts = f.Timeouts.Merge(timeouts)
+		outputLimits = f.Output.Merge(outputLimits)
+		sources = f.Sources
+		if opts.offlineDir != "" {
+			sources.OfflineDir = opts.offlineDir
+		}
+		if f.GitHub.Token != "" {
+			githubToken = f.GitHub.Token
+		}
+		plugins = f.Plugins
+		billing = f.Billing
+		files = f.Files
+	}
+	l, closer, err := logger.New(logOptions)
+	if err != nil {
+		fatal("Invalid logging configuration", "error", err)
+	}
+	defer func() { _ = closer.Close() }()
+	slog.SetDefault(l)
+
+	if len(access) > 0 && opts.googleAudience == "" {
+		fatal("The access rules of the config file require --google-audience to identify callers")
+	}
+	if err := toolFilter.Validate(); err != nil {
+		fatal("Invalid tool filter", "error", err)
+	}
+	if err := promptFilter.Validate(); err != nil {
+		fatal("Invalid prompt filter", "error", err)
+	}
+	if err := timeouts.Validate(); err != nil {
+		fatal("Invalid tool timeouts", "error", err)
+	}
+	if sources.OfflineDir != "" {
+		if info, err := os.Stat(sources.OfflineDir); err != nil || !info.IsDir() {
+			fatal("The offline directory doesn't exist, create it with gke-mcp download-offline-sources", "dir", sources.OfflineDir)
+		}
+	}
+
+	configOpts := []config.Option{
+		config.WithAllowWrite(opts.allowWrite),
+		config.WithDryRun(opts.dryRun),
+		config.WithReadOnly(opts.readOnly),
+		config.WithToolFilter(toolFilter),
+		config.WithPromptFilter(promptFilter),
+		config.WithAccess(access),
+		config.WithGitHubToken(githubToken),
+		config.WithSources(sources),
+		config.WithPromptsDir(opts.promptsDir),
+		config.WithPlugins(plugins),
+		config.WithBilling(billing),
+		config.WithFiles(files),
+		config.WithShared(opts.serverMode == "http" || opts.serverMode == "sse"),
+	}
+	if opts.impersonateSA != "" {
+		ts, err := impersonate.CredentialsTokenSource(ctx, impersonate.CredentialsConfig{
+			TargetPrincipal: opts.impersonateSA,
+			Scopes:          []string{config.CloudPlatformScope},
+		})
+		if err != nil {
+			fatal("Failed to impersonate service account", "service_account", opts.impersonateSA, "error", err)
+		}
+		configOpts = append(configOpts, config.WithTokenSource(ts))
+		// gcloud, also used by kubectl to get cluster credentials, impersonates the service account too.
+		if err := os.Setenv("CLOUDSDK_AUTH_IMPERSONATE_SERVICE_ACCOUNT", opts.impersonateSA); err != nil {
+			fatal("Failed to configure gcloud impersonation", "error", err)
+		}
+	}
+	c := config.New(version, configOpts...)
+	kube.SetReadOnly(c.ReadOnly())
+
+	shutdownTracing, err := tracing.Setup(ctx, traceOptions, version, c.DefaultProjectID(ctx), c.TokenSource)
+	if err != nil {
+		fatal("Failed to set up tracing", "error", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			slog.Warn("Failed to flush traces", "error", err)
+		}
+	}()
+
+	auditSinks, err := audit.New(ctx, auditOptions, c.DefaultProjectID(ctx), c.ClientOptions()...)
+	if err != nil {
+		fatal("Failed to set up the audit log", "error", err)
+	}
+	defer func() {
+		for _, sink := range auditSinks {
+			if err := sink.Close(); err != nil {
+				slog.Warn("Failed to close the audit log", "error", err)
+			}
+		}
+	}()
 
 	instructions := ""
 	if err := adcAuthCheck(ctx, c); err != nil {
 		if strings.Contains(err.Error(), "Unauthenticated") {
-			log.Printf("GKE API calls requires Application Default Credentials (https://cloud.google.com/docs/authentication/application-default-credentials). Get credentials with `gcloud auth application-default login` before calling MCP tools.")
+			slog.Warn("GKE API calls requires Application Default Credentials (https://cloud.google.com/docs/authentication/application-default-credentials). Get credentials with `gcloud auth application-default login` before calling MCP tools.")
 			instructions += "GKE API calls requires Application Default Credentials (https://cloud.google.com/docs/authentication/application-default-credentials). Get credentials with `gcloud auth application-default login` before calling MCP tools."
 		}
 	}
 
+	completer, err := completion.New(ctx, c)
+	if err != nil {
+		fatal("Failed to set up argument completion", "error", err)
+	}
+
 	s := mcp.NewServer(
 		&mcp.Implementation{
 			Name:    "GKE MCP Server",
 			Version: version,
 		},
 		&mcp.ServerOptions{
-			Instructions: instructions,
-			HasTools:     true,
-			HasResources: true,
+			Instructions:      instructions,
+			HasTools:          true,
+			HasResources:      true,
+			CompletionHandler: completer.Handle,
 		},
 	)
 
@@ -172,6 +379,11 @@ func startMCPServer(ctx context.Context, opts startOptions) {
 		MIMEType:    "text/markdown",
 	}
 
+	// Tools, prompts, completions and resources see the state of their
+	// session, e.g. the selected project, which the access rules then check. Calls denied by the access
+	// rules are audited too.
+	s.AddReceivingMiddleware(session.Middleware, tracing.Middleware, metrics.Middleware, logger.Middleware(l), audit.Middleware(auditSinks, auditOptions.MaxResultSize), outputMiddleware(outputLimits), timeoutMiddleware(timeouts), accessMiddleware(c), progress.Middleware)
+
 	s.AddResource(resource, func(_ context.Context, _ *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
 		return &mcp.ReadResourceResult{
 			Contents: []*mcp.ResourceContents{
@@ -185,78 +397,106 @@ func startMCPServer(ctx context.Context, opts startOptions) {
 	})
 
 	if err := prompts.Install(ctx, s, c); err != nil {
-		log.Fatalf("Failed to install prompts: %v\n", err)
+		fatal("Failed to install prompts", "error", err)
 	}
 
 	if err := tools.Install(ctx, s, c); err != nil {
-		log.Fatalf("Failed to install tools: %v\n", err)
+		fatal("Failed to install tools", "error", err)
+	}
+
+	if err := removeDisabled(ctx, s, c); err != nil {
+		fatal("Failed to remove disabled tools and prompts", "error", err)
 	}
 
 	// start server in the right mode
-	log.Printf("Starting GKE MCP Server (%s) in mode '%s'", version, opts.serverMode)
-	var err error
+	slog.Info("Starting GKE MCP Server", "version", version, "mode", opts.serverMode)
 
 	switch opts.serverMode {
 	case "stdio":
-		tr := &mcp.LoggingTransport{Transport: &mcp.StdioTransport{}, Writer: log.Writer()}
-		err = s.Run(ctx, tr)
-	case "http":
-		mcpHandler := mcp.NewStreamableHTTPHandler(func(_ *http.Request) *mcp.Server {
-			return s
-		}, nil)
-
-		// Create a new CORS handler
-		c := cors.New(cors.Options{
-			AllowedOrigins: allowedOrigins,
-			Debug:          true, // Enable debug logging to see what the library is doing
-		})
-		corsHandler := c.Handler(mcpHandler)
+		err = s.Run(ctx, stdioTransport(l))
+	case "http", "sse":
+		var verifier auth.TokenVerifier
+		switch {
+		case opts.authTokenFile != "":
+			authToken, err := readAuthToken(opts.authTokenFile)
+			if err != nil {
+				fatal("Failed to read auth token", "error", err)
+			}
+			verifier = staticTokenVerifier(authToken)
+		case opts.googleAudience != "":
+			verifier = googleTokenVerifier(opts.googleAudience, idtoken.Validate)
+		case opts.serverHost != "127.0.0.1" && opts.serverHost != "localhost":
+			slog.Warn("Serving without --auth-token-file or --google-audience, any client reaching the server can call its tools", "host", opts.serverHost)
+		}
 
 		addr := fmt.Sprintf("%s:%d", opts.serverHost, opts.serverPort)
-		log.Printf("Listening for HTTP connections on port: %s", addr)
+		slog.Info("Listening for HTTP connections", "address", addr)
 		server := &http.Server{
 			Addr:              addr,
-			Handler:           corsHandler,
+			Handler:           newHTTPHandler(s, opts.serverMode, opts.allowedOrigins, verifier),
 			ReadHeaderTimeout: 5 * time.Second,
 			ReadTimeout:       5 * time.Second,
-			WriteTimeout:      10 * time.Second,
-			IdleTimeout:       120 * time.Second,
+			// No write timeout, tool calls and their response streams can take longer than any fixed timeout.
+			IdleTimeout: 120 * time.Second,
 		}
 		err = server.ListenAndServe()
 	default:
-		log.Printf("Unknown mode '%s', defaulting to 'stdio'", opts.serverMode)
-		tr := &mcp.LoggingTransport{Transport: &mcp.StdioTransport{}, Writer: log.Writer()}
-		err = s.Run(ctx, tr)
+		slog.Warn("Unknown mode, defaulting to stdio", "mode", opts.serverMode)
+		err = s.Run(ctx, stdioTransport(l))
 	}
 	if err != nil {
 		if errors.Is(err, context.Canceled) {
-			log.Printf("Server shutting down.")
+			slog.Info("Server shutting down")
 		} else {
-			log.Printf("Server error: %v\n", err)
+			slog.Error("Server error", "error", err)
 		}
 	}
 }
 
+// stdioTransport returns the stdio transport, logging the MCP messages at debug level.
+func stdioTransport(l *slog.Logger) mcp.Transport {
+	if !l.Enabled(context.Background(), slog.LevelDebug) {
+		return &mcp.StdioTransport{}
+	}
+	return &mcp.LoggingTransport{Transport: &mcp.StdioTransport{}, Writer: slog.NewLogLogger(l.Handler(), slog.LevelDebug).Writer()}
+}
+
+// defaultPromptsDir returns the prompts directory of the user configuration
+// directory, e.g. ~/.config/gke-mcp/prompts on Linux.
+func defaultPromptsDir() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "gke-mcp", "prompts")
+}
+
+// fatal logs the error message with its attributes and exits.
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
+
 func adcAuthCheck(ctx context.Context, c *config.Config) error {
-	projectID := c.DefaultProjectID()
+	projectID := c.DefaultProjectID(ctx)
 	// Can't do a pre-flight check without a default project.
 	if projectID == "" {
 		return nil
 	}
 
-	location := c.DefaultLocation()
+	location := c.DefaultLocation(ctx)
 	// Without a default location try checking us-central1.
 	if location == "" {
 		location = "us-central1"
 	}
 
-	cmClient, err := container.NewClusterManagerClient(ctx, option.WithUserAgent(c.UserAgent()))
+	cmClient, err := container.NewClusterManagerClient(ctx, c.ClientOptions()...)
 	if err != nil {
 		return fmt.Errorf("failed to create cluster manager client: %w", err)
 	}
 	defer func() {
 		if err := cmClient.Close(); err != nil {
-			log.Printf("Failed to close cluster manager client: %v\n", err)
+			slog.Warn("Failed to close cluster manager client", "error", err)
 		}
 	}()
 
@@ -277,11 +517,11 @@ func installOptions() (*install.Options, error) {
 func runInstallGeminiCLICmd(_ *cobra.Command, _ []string) {
 	opts, err := installOptions()
 	if err != nil {
-		log.Fatalf("Failed to get install options: %v", err)
+		fatal("Failed to get install options", "error", err)
 	}
 
 	if err := install.GeminiCLIExtension(opts); err != nil {
-		log.Fatalf("Failed to install for gemini-cli: %v", err)
+		fatal("Failed to install for gemini-cli", "error", err)
 	}
 	fmt.Println("Successfully installed GKE MCP server as a gemini-cli extension.")
 }
@@ -289,11 +529,11 @@ func runInstallGeminiCLICmd(_ *cobra.Command, _ []string) {
 func runInstallCursorCmd(_ *cobra.Command, _ []string) {
 	opts, err := installOptions()
 	if err != nil {
-		log.Fatalf("Failed to get install options: %v", err)
+		fatal("Failed to get install options", "error", err)
 	}
 
 	if err := install.CursorMCPExtension(opts); err != nil {
-		log.Fatalf("Failed to install for cursor: %v", err)
+		fatal("Failed to install for cursor", "error", err)
 	}
 	fmt.Println("Successfully installed GKE MCP server as a cursor MCP server.")
 }
@@ -301,11 +541,11 @@ func runInstallCursorCmd(_ *cobra.Command, _ []string) {
 func runInstallClaudeDesktopCmd(_ *cobra.Command, _ []string) {
 	opts, err := installOptions()
 	if err != nil {
-		log.Fatalf("Failed to get install options: %v", err)
+		fatal("Failed to get install options", "error", err)
 	}
 
 	if err := install.ClaudeDesktopExtension(opts); err != nil {
-		log.Fatalf("Failed to install for Claude Desktop: %v", err)
+		fatal("Failed to install for Claude Desktop", "error", err)
 	}
 	fmt.Println("Successfully installed GKE MCP server in Claude Desktop configuration.")
 }
@@ -313,11 +553,11 @@ func runInstallClaudeDesktopCmd(_ *cobra.Command, _ []string) {
 func runInstallClaudeCodeCmd(_ *cobra.Command, _ []string) {
 	opts, err := installOptions()
 	if err != nil {
-		log.Fatalf("Failed to get install options: %v", err)
+		fatal("Failed to get install options", "error", err)
 	}
 
 	if err := install.ClaudeCodeExtension(opts); err != nil {
-		log.Fatalf("Failed to install for Claude Code: %v", err)
+		fatal("Failed to install for Claude Code", "error", err)
 	}
 
 	fmt.Println("Successfully installed GKE MCP server for Claude Code.")
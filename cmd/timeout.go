@@ -0,0 +1,57 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// timeoutMiddleware cancels the tool calls running longer than their
+// timeout, which stops their external commands and requests. Cancelled MCP
+// requests stop them as well, as their context is cancelled.
+func timeoutMiddleware(timeouts config.Timeouts) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+			if !ok || params == nil {
+				return next(ctx, method, req)
+			}
+			timeout := timeouts.For(params.Name)
+			if timeout == 0 {
+				return next(ctx, method, req)
+			}
+
+			ctx, cancel := context.WithTimeoutCause(ctx, timeout, fmt.Errorf("tool %s timed out after %s", params.Name, timeout))
+			defer cancel()
+			result, err := next(ctx, method, req)
+			if ctx.Err() != context.DeadlineExceeded {
+				return result, err
+			}
+			// Tell the client that the failure comes from the timeout, not
+			// from the command which was killed.
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", context.Cause(ctx), err)
+			}
+			if res, ok := result.(*mcp.CallToolResult); ok && res != nil && res.IsError {
+				res.Content = append([]mcp.Content{&mcp.TextContent{Text: context.Cause(ctx).Error()}}, res.Content...)
+			}
+			return result, err
+		}
+	}
+}
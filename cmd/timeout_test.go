@@ -0,0 +1,68 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestTimeoutMiddleware(t *testing.T) {
+	timeouts := config.Timeouts{Default: "0", Tools: map[string]string{"list_clusters": "100ms"}}
+	// The command of the tool is killed when the call times out.
+	next := func(ctx context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+		return nil, exec.CommandContext(ctx, "sleep", "10").Run()
+	}
+
+	start := time.Now()
+	_, err := timeoutMiddleware(timeouts)(next)(context.Background(), "tools/call", callToolRequest("", `{}`))
+	if err == nil || !strings.Contains(err.Error(), "tool list_clusters timed out after 100ms") {
+		t.Errorf("timeoutMiddleware() error = %v, want a timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("timeoutMiddleware() returned after %v, want the command to be killed", elapsed)
+	}
+
+	toolError := func(ctx context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+		<-ctx.Done()
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "signal: killed"}}}, nil
+	}
+	res, err := timeoutMiddleware(timeouts)(toolError)(context.Background(), "tools/call", callToolRequest("", `{}`))
+	if err != nil {
+		t.Fatalf("timeoutMiddleware() error = %v", err)
+	}
+	if text := res.(*mcp.CallToolResult).Content[0].(*mcp.TextContent).Text; !strings.Contains(text, "timed out") {
+		t.Errorf("timeoutMiddleware() result starts with %q, want the timeout", text)
+	}
+
+	// Tools without a timeout keep the context of the request.
+	noDeadline := func(ctx context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+		if _, ok := ctx.Deadline(); ok {
+			return nil, errors.New("unexpected deadline")
+		}
+		return nil, nil
+	}
+	req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "get_cluster"}}
+	if _, err := timeoutMiddleware(timeouts)(noDeadline)(context.Background(), "tools/call", req); err != nil {
+		t.Errorf("timeoutMiddleware() error = %v for a tool without timeout", err)
+	}
+}
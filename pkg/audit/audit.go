@@ -0,0 +1,238 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audit records every tool call of the server, with its arguments,
+// caller and truncated result, in an append-only audit log.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/logging"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/output"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/option"
+)
+
+// defaultMaxResultSize bounds the size of the results recorded in the audit log, in bytes.
+const defaultMaxResultSize = 4096
+
+// Options configures the audit log.
+type Options struct {
+	// File is the JSONL file the records are appended to.
+	File string `json:"file,omitempty"`
+	// CloudLoggingLog is the name of the Cloud Logging log of the default
+	// project the records are written to.
+	CloudLoggingLog string `json:"cloudLoggingLog,omitempty"`
+	// MaxResultSize bounds the size of the recorded results, in bytes.
+	MaxResultSize int `json:"maxResultSize,omitempty"`
+}
+
+// Merge returns the options of o overridden by the non-empty fields of other.
+func (o Options) Merge(other Options) Options {
+	if other.File != "" {
+		o.File = other.File
+	}
+	if other.CloudLoggingLog != "" {
+		o.CloudLoggingLog = other.CloudLoggingLog
+	}
+	if other.MaxResultSize != 0 {
+		o.MaxResultSize = other.MaxResultSize
+	}
+	return o
+}
+
+// Record is the audit record of a tool call.
+type Record struct {
+	Time      time.Time       `json:"time"`
+	Tool      string          `json:"tool"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+	Session   string          `json:"session,omitempty"`
+	Caller    string          `json:"caller,omitempty"`
+	Duration  string          `json:"duration"`
+	Outcome   string          `json:"outcome"`
+	Error     string          `json:"error,omitempty"`
+	Result    string          `json:"result,omitempty"`
+}
+
+// Sink is the destination of the audit records.
+type Sink interface {
+	Write(ctx context.Context, r *Record) error
+	io.Closer
+}
+
+// New returns the sinks configured by the options. The Cloud Logging sink
+// writes to the log of the project, with the client options.
+func New(ctx context.Context, opts Options, projectID string, clientOpts ...option.ClientOption) ([]Sink, error) {
+	var sinks []Sink
+	if opts.File != "" {
+		s, err := NewFileSink(opts.File)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, s)
+	}
+	if opts.CloudLoggingLog != "" {
+		if projectID == "" {
+			closeAll(sinks)
+			return nil, fmt.Errorf("the Cloud Logging audit log requires a default project")
+		}
+		client, err := logging.NewClient(ctx, "projects/"+projectID, clientOpts...)
+		if err != nil {
+			closeAll(sinks)
+			return nil, fmt.Errorf("failed to create Cloud Logging client: %w", err)
+		}
+		sinks = append(sinks, &cloudLoggingSink{client: client, logger: client.Logger(opts.CloudLoggingLog)})
+	}
+	return sinks, nil
+}
+
+func closeAll(sinks []Sink) {
+	for _, s := range sinks {
+		_ = s.Close()
+	}
+}
+
+// fileSink appends the records to a JSONL file.
+type fileSink struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+// NewFileSink returns a sink appending the records to the JSONL file, which
+// is created if needed.
+func NewFileSink(name string) (Sink, error) {
+	// #nosec G304
+	f, err := os.OpenFile(name, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	return &fileSink{f: f}, nil
+}
+
+func (s *fileSink) Write(_ context.Context, r *Record) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit record: %w", err)
+	}
+	return nil
+}
+
+func (s *fileSink) Close() error {
+	return s.f.Close()
+}
+
+// cloudLoggingSink writes the records to a Cloud Logging log.
+type cloudLoggingSink struct {
+	client *logging.Client
+	logger *logging.Logger
+}
+
+func (s *cloudLoggingSink) Write(_ context.Context, r *Record) error {
+	severity := logging.Info
+	if r.Outcome != outcomeSuccess {
+		severity = logging.Warning
+	}
+	// The logger buffers the entries and reports write errors to the client's OnError.
+	s.logger.Log(logging.Entry{
+		Timestamp: r.Time,
+		Severity:  severity,
+		Payload:   r,
+		Labels:    map[string]string{"tool": r.Tool},
+	})
+	return nil
+}
+
+func (s *cloudLoggingSink) Close() error {
+	return s.client.Close()
+}
+
+const (
+	outcomeSuccess   = "success"
+	outcomeError     = "error"
+	outcomeToolError = "tool_error"
+)
+
+// Middleware records every tool call of the server to the sinks. Results are
+// truncated to maxResultSize bytes, or a default size if it is not positive.
+func Middleware(sinks []Sink, maxResultSize int) mcp.Middleware {
+	if maxResultSize <= 0 {
+		maxResultSize = defaultMaxResultSize
+	}
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+			if !ok || params == nil || len(sinks) == 0 {
+				return next(ctx, method, req)
+			}
+
+			start := time.Now()
+			result, err := next(ctx, method, req)
+
+			r := &Record{
+				Time:      start.UTC(),
+				Tool:      params.Name,
+				Arguments: params.Arguments,
+				Duration:  time.Since(start).String(),
+				Outcome:   outcomeSuccess,
+			}
+			if ss, ok := req.GetSession().(*mcp.ServerSession); ok && ss != nil {
+				r.Session = ss.ID()
+			}
+			if extra := req.GetExtra(); extra != nil && extra.TokenInfo != nil {
+				r.Caller = extra.TokenInfo.UserID
+			}
+			if err != nil {
+				r.Outcome, r.Error = outcomeError, err.Error()
+			}
+			if res, ok := result.(*mcp.CallToolResult); ok && res != nil {
+				if res.IsError {
+					r.Outcome = outcomeToolError
+				}
+				r.Result, _ = output.Truncate(resultText(res), maxResultSize)
+			}
+
+			for _, s := range sinks {
+				if werr := s.Write(ctx, r); werr != nil {
+					slog.Error("Failed to write audit record", "tool", r.Tool, "error", werr)
+				}
+			}
+			return result, err
+		}
+	}
+}
+
+// resultText returns the text content of a tool result.
+func resultText(res *mcp.CallToolResult) string {
+	var parts []string
+	for _, c := range res.Content {
+		if t, ok := c.(*mcp.TextContent); ok {
+			parts = append(parts, t.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
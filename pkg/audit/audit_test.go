@@ -0,0 +1,137 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type memorySink struct {
+	records []*Record
+}
+
+func (s *memorySink) Write(_ context.Context, r *Record) error {
+	s.records = append(s.records, r)
+	return nil
+}
+
+func (s *memorySink) Close() error { return nil }
+
+func TestMiddleware(t *testing.T) {
+	req := &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{Name: "list_clusters", Arguments: json.RawMessage(`{"project_id":"p"}`)},
+		Extra:  &mcp.RequestExtra{TokenInfo: &auth.TokenInfo{UserID: "alice@example.com"}},
+	}
+	long := strings.Repeat("x", 100)
+
+	tests := []struct {
+		name        string
+		result      mcp.Result
+		err         error
+		wantOutcome string
+		wantResult  string
+	}{
+		{"success", &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "ok"}}}, nil, "success", "ok"},
+		{"truncated", &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: long}}}, nil, "success", "xxxxxxx\n[... truncated 90 of 100 bytes ...]\nxxx"},
+		{"truncated multi-byte", &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: strings.Repeat("é", 50)}}}, nil, "success", "ééé\n[... truncated 90 of 100 bytes ...]\néé"},
+		{"tool error", &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "denied"}}}, nil, "tool_error", "denied"},
+		{"error", nil, errors.New("boom"), "error", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := &memorySink{}
+			next := func(context.Context, string, mcp.Request) (mcp.Result, error) {
+				return tt.result, tt.err
+			}
+			if _, err := Middleware([]Sink{sink}, 10)(next)(context.Background(), "tools/call", req); !errors.Is(err, tt.err) {
+				t.Errorf("Middleware() error = %v, want %v", err, tt.err)
+			}
+			if len(sink.records) != 1 {
+				t.Fatalf("Middleware() recorded %d records, want 1", len(sink.records))
+			}
+			r := sink.records[0]
+			if r.Tool != "list_clusters" || string(r.Arguments) != `{"project_id":"p"}` || r.Caller != "alice@example.com" {
+				t.Errorf("record = %+v, want the tool, arguments and caller of the call", r)
+			}
+			if r.Outcome != tt.wantOutcome || r.Result != tt.wantResult {
+				t.Errorf("record outcome, result = %q, %q, want %q, %q", r.Outcome, r.Result, tt.wantOutcome, tt.wantResult)
+			}
+			if tt.err != nil && r.Error != tt.err.Error() {
+				t.Errorf("record error = %q, want %q", r.Error, tt.err)
+			}
+		})
+	}
+
+	sink := &memorySink{}
+	next := func(context.Context, string, mcp.Request) (mcp.Result, error) { return nil, nil }
+	if _, err := Middleware([]Sink{sink}, 0)(next)(context.Background(), "tools/list", &mcp.ListToolsRequest{}); err != nil || len(sink.records) != 0 {
+		t.Errorf("Middleware() recorded %v, %v for tools/list, want nothing", sink.records, err)
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "audit.jsonl")
+	for _, tool := range []string{"list_clusters", "get_cluster"} {
+		sink, err := NewFileSink(name)
+		if err != nil {
+			t.Fatalf("NewFileSink() error = %v", err)
+		}
+		if err := sink.Write(context.Background(), &Record{Tool: tool, Outcome: "success"}); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		if err := sink.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("audit log = %q, want the two records appended", data)
+	}
+	var r Record
+	if err := json.Unmarshal([]byte(lines[1]), &r); err != nil || r.Tool != "get_cluster" {
+		t.Errorf("second record = %q, %v, want get_cluster", lines[1], err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New(context.Background(), Options{CloudLoggingLog: "gke-mcp-audit"}, ""); err == nil {
+		t.Errorf("New() without a project returned no error for Cloud Logging")
+	}
+	sinks, err := New(context.Background(), Options{}, "")
+	if err != nil || len(sinks) != 0 {
+		t.Errorf("New() = %v, %v, want no sinks", sinks, err)
+	}
+}
+
+func TestOptionsMerge(t *testing.T) {
+	got := Options{File: "audit.jsonl", MaxResultSize: 100}.Merge(Options{CloudLoggingLog: "gke-mcp-audit", MaxResultSize: 200})
+	if want := (Options{File: "audit.jsonl", CloudLoggingLog: "gke-mcp-audit", MaxResultSize: 200}); got != want {
+		t.Errorf("Merge() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package completion completes the arguments of prompts and resource
+// templates, such as cluster names, locations and target versions, from the
+// GKE API.
+package completion
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	container "cloud.google.com/go/container/apiv1"
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// maxValues is the maximum number of values of a completion allowed by the
+// MCP specification.
+const maxValues = 100
+
+// Completer completes the arguments of prompts and resource templates.
+type Completer struct {
+	c *config.Config
+	// clusters returns the clusters of a project in a location, or in all
+	// locations with -.
+	clusters func(ctx context.Context, projectID, location string) ([]*containerpb.Cluster, error)
+	// versions returns the valid control plane versions of a location.
+	versions func(ctx context.Context, projectID, location string) ([]string, error)
+}
+
+// New returns a completer reading the clusters and versions from the GKE API.
+func New(ctx context.Context, c *config.Config) (*Completer, error) {
+	cmClient, err := container.NewClusterManagerClient(ctx, c.ClientOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cluster manager client: %w", err)
+	}
+	return &Completer{
+		c: c,
+		clusters: func(ctx context.Context, projectID, location string) ([]*containerpb.Cluster, error) {
+			resp, err := cmClient.ListClusters(ctx, &containerpb.ListClustersRequest{
+				Parent: fmt.Sprintf("projects/%s/locations/%s", projectID, location),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list clusters: %w", err)
+			}
+			return resp.Clusters, nil
+		},
+		versions: func(ctx context.Context, projectID, location string) ([]string, error) {
+			resp, err := cmClient.GetServerConfig(ctx, &containerpb.GetServerConfigRequest{
+				Name: fmt.Sprintf("projects/%s/locations/%s", projectID, location),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get server config: %w", err)
+			}
+			return resp.ValidMasterVersions, nil
+		},
+	}, nil
+}
+
+// Handle completes the cluster_name, cluster_location and target_version
+// arguments of the prompts, and the cluster and location arguments of the
+// resource templates, with the values starting with the typed value.
+func (cp *Completer) Handle(ctx context.Context, req *mcp.CompleteRequest) (*mcp.CompleteResult, error) {
+	var args map[string]string
+	if req.Params.Context != nil {
+		args = req.Params.Context.Arguments
+	}
+	projectID := args["project"]
+	if projectID == "" {
+		projectID = cp.c.DefaultProjectID(ctx)
+	}
+	if projectID == "" {
+		return result(nil, ""), nil
+	}
+	location := args["cluster_location"]
+	if location == "" {
+		location = args["location"]
+	}
+
+	var values []string
+	switch req.Params.Argument.Name {
+	case "cluster_name", "cluster":
+		if location == "" {
+			location = "-"
+		}
+		clusters, err := cp.clusters(ctx, projectID, location)
+		if err != nil {
+			return nil, err
+		}
+		for _, cluster := range clusters {
+			values = append(values, cluster.Name)
+		}
+	case "cluster_location", "location":
+		clusters, err := cp.clusters(ctx, projectID, "-")
+		if err != nil {
+			return nil, err
+		}
+		name := args["cluster_name"]
+		if name == "" {
+			name = args["cluster"]
+		}
+		for _, cluster := range clusters {
+			if name == "" || cluster.Name == name {
+				values = append(values, cluster.Location)
+			}
+		}
+	case "target_version":
+		if location == "" {
+			location = cp.c.DefaultLocation(ctx)
+		}
+		if location == "" {
+			// The versions depend on the location.
+			break
+		}
+		versions, err := cp.versions(ctx, projectID, location)
+		if err != nil {
+			return nil, err
+		}
+		values = versions
+	}
+	return result(values, req.Params.Argument.Value), nil
+}
+
+// result returns the completion of the distinct values starting with the
+// prefix.
+func result(values []string, prefix string) *mcp.CompleteResult {
+	matches := []string{}
+	for _, v := range values {
+		if strings.HasPrefix(v, prefix) && !slices.Contains(matches, v) {
+			matches = append(matches, v)
+		}
+	}
+	r := &mcp.CompleteResult{Completion: mcp.CompletionResultDetails{
+		Values: matches,
+		Total:  len(matches),
+	}}
+	if len(matches) > maxValues {
+		r.Completion.Values = matches[:maxValues]
+		r.Completion.HasMore = true
+	}
+	return r
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package completion
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestHandle(t *testing.T) {
+	t.Setenv("GOOGLE_CLOUD_PROJECT", "my-project")
+	clusters := []*containerpb.Cluster{
+		{Name: "prod", Location: "us-central1"},
+		{Name: "staging", Location: "us-central1"},
+		{Name: "prod-eu", Location: "europe-west1"},
+	}
+	cp := &Completer{
+		c: config.New("test"),
+		clusters: func(_ context.Context, projectID, location string) ([]*containerpb.Cluster, error) {
+			var found []*containerpb.Cluster
+			for _, c := range clusters {
+				if projectID == "my-project" && (location == "-" || c.Location == location) {
+					found = append(found, c)
+				}
+			}
+			return found, nil
+		},
+		versions: func(context.Context, string, string) ([]string, error) {
+			return []string{"1.34.1-gke.100", "1.33.5-gke.1200", "1.33.4-gke.900"}, nil
+		},
+	}
+
+	tests := []struct {
+		name     string
+		argument string
+		value    string
+		context  map[string]string
+		want     []string
+	}{
+		{"cluster names", "cluster_name", "pro", nil, []string{"prod", "prod-eu"}},
+		{"cluster names in a location", "cluster_name", "", map[string]string{"cluster_location": "us-central1"}, []string{"prod", "staging"}},
+		{"resource template clusters", "cluster", "s", map[string]string{"project": "my-project", "location": "us-central1"}, []string{"staging"}},
+		{"other project", "cluster", "", map[string]string{"project": "other-project"}, []string{}},
+		{"locations", "cluster_location", "", nil, []string{"us-central1", "europe-west1"}},
+		{"location of a cluster", "cluster_location", "", map[string]string{"cluster_name": "prod-eu"}, []string{"europe-west1"}},
+		{"target versions", "target_version", "1.33", map[string]string{"cluster_location": "us-central1"}, []string{"1.33.5-gke.1200", "1.33.4-gke.900"}},
+		{"other argument", "user_question", "", nil, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &mcp.CompleteRequest{Params: &mcp.CompleteParams{
+				Ref:      &mcp.CompleteReference{Type: "ref/prompt", Name: "gke:upgrade-risk-report"},
+				Argument: mcp.CompleteParamsArgument{Name: tt.argument, Value: tt.value},
+				Context:  &mcp.CompleteContext{Arguments: tt.context},
+			}}
+			got, err := cp.Handle(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Handle() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Completion.Values, tt.want) {
+				t.Errorf("Handle() = %q, want %q", got.Completion.Values, tt.want)
+			}
+		})
+	}
+}
+
+func TestResultLimit(t *testing.T) {
+	values := make([]string, maxValues+5)
+	for i := range values {
+		values[i] = string(rune('a'+i%26)) + string(rune('a'+i/26))
+	}
+	got := result(values, "")
+	if len(got.Completion.Values) != maxValues || !got.Completion.HasMore || got.Completion.Total != maxValues+5 {
+		t.Errorf("result() returned %d values, has more %t, total %d, want %d values of %d", len(got.Completion.Values), got.Completion.HasMore, got.Completion.Total, maxValues, maxValues+5)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"path"
+)
+
+// AccessRule grants the callers of the server matching Principals access to
+// the projects matching Projects. Both can be glob patterns, e.g.
+// *@example.com or team-a-*.
+type AccessRule struct {
+	// Principals are the emails of the users or service accounts.
+	Principals []string `json:"principals"`
+	// Projects are the IDs of the GCP projects the principals can use.
+	Projects []string `json:"projects"`
+}
+
+// Access restricts the projects each caller of the server can use. An empty
+// Access doesn't restrict callers.
+type Access []AccessRule
+
+// Projects returns the project patterns the rules matching the principal
+// allow, and whether any rule matches it.
+func (a Access) Projects(principal string) ([]string, bool) {
+	var projects []string
+	matched := false
+	for _, rule := range a {
+		if principal != "" && matchesAny(rule.Principals, principal) {
+			projects = append(projects, rule.Projects...)
+			matched = true
+		}
+	}
+	return projects, matched
+}
+
+// Validate checks that each rule lists principals and projects, and the syntax of their patterns.
+func (a Access) Validate() error {
+	for i, rule := range a {
+		if len(rule.Principals) == 0 || len(rule.Projects) == 0 {
+			return fmt.Errorf("access rule %d must list principals and projects", i)
+		}
+		for _, pattern := range append(rule.Principals, rule.Projects...) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid pattern %q in access rule %d: %w", pattern, i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// AllowsProject reports whether the project matches one of the patterns returned by Projects.
+func AllowsProject(projects []string, projectID string) bool {
+	return matchesAny(projects, projectID)
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestAccessProjects(t *testing.T) {
+	a := Access{
+		{Principals: []string{"*@example.com"}, Projects: []string{"shared-project"}},
+		{Principals: []string{"alice@example.com"}, Projects: []string{"team-a-*"}},
+	}
+
+	tests := []struct {
+		principal   string
+		wantMatched bool
+		want        []string
+	}{
+		{"alice@example.com", true, []string{"shared-project", "team-a-*"}},
+		{"bob@example.com", true, []string{"shared-project"}},
+		{"mallory@example.org", false, nil},
+		{"", false, nil},
+	}
+	for _, tt := range tests {
+		got, matched := a.Projects(tt.principal)
+		if matched != tt.wantMatched || !slices.Equal(got, tt.want) {
+			t.Errorf("Projects(%q) = %q, %t, want %q, %t", tt.principal, got, matched, tt.want, tt.wantMatched)
+		}
+	}
+
+	projects, _ := a.Projects("alice@example.com")
+	if !AllowsProject(projects, "team-a-prod") || AllowsProject(projects, "team-b-prod") {
+		t.Errorf("AllowsProject() does not apply the project patterns %q", projects)
+	}
+}
+
+func TestAccessValidate(t *testing.T) {
+	if err := (Access{{Principals: []string{"*@example.com"}, Projects: []string{"*"}}}).Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if err := (Access{{Principals: []string{"*@example.com"}}}).Validate(); err == nil {
+		t.Errorf("Validate() of a rule without projects returned no error")
+	}
+	if err := (Access{{Principals: []string{"[@example.com"}, Projects: []string{"*"}}}).Validate(); err == nil {
+		t.Errorf("Validate() of an invalid pattern returned no error")
+	}
+}
+
+func TestLoadFileAccess(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+access:
+- principals: ["*@example.com"]
+  projects: ["team-a-*"]
+`
+	if err := os.WriteFile(name, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := LoadFile(name)
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	if projects, ok := f.Access.Projects("alice@example.com"); !ok || !slices.Equal(projects, []string{"team-a-*"}) {
+		t.Errorf("LoadFile() access = %+v, want team-a-* for example.com", f.Access)
+	}
+
+	if err := os.WriteFile(name, []byte("access:\n- principals: [\"*@example.com\"]\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFile(name); err == nil {
+		t.Errorf("LoadFile() with a rule without projects returned no error")
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// billingTablePattern matches the project.dataset.table ID of a BigQuery
+// table.
+var billingTablePattern = regexp.MustCompile(`^[a-z][a-z0-9.:-]*[a-z0-9]\.[A-Za-z0-9_]+\.[A-Za-z0-9_$-]+$`)
+
+// Billing configures the cost tools.
+type Billing struct {
+	// ExportTable is the BigQuery table of the detailed Cloud Billing export,
+	// as project.dataset.table, e.g.
+	// my-project.billing.gcp_billing_export_resource_v1_XXXXXX_XXXXXX_XXXXXX.
+	ExportTable string `json:"exportTable,omitempty"`
+}
+
+// Validate checks the syntax of the export table.
+func (b Billing) Validate() error {
+	return ValidateBillingTable(b.ExportTable)
+}
+
+// ValidateBillingTable checks that a BigQuery table ID is of the form
+// project.dataset.table, if not empty.
+func ValidateBillingTable(table string) error {
+	if table != "" && !billingTablePattern.MatchString(table) {
+		return fmt.Errorf("invalid BigQuery table %q, expected project.dataset.table", table)
+	}
+	return nil
+}
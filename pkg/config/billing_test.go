@@ -0,0 +1,38 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "testing"
+
+func TestBillingValidate(t *testing.T) {
+	for _, b := range []Billing{
+		{},
+		{ExportTable: "my-project.billing.gcp_billing_export_resource_v1_0123AB_4567CD_89EF01"},
+		{ExportTable: "example.com:my-project.billing_export.costs"},
+	} {
+		if err := b.Validate(); err != nil {
+			t.Errorf("Validate(%+v) error = %v", b, err)
+		}
+	}
+	for _, b := range []Billing{
+		{ExportTable: "billing.gcp_billing_export"},
+		{ExportTable: "my-project.billing.costs` WHERE TRUE --"},
+		{ExportTable: "My Project.billing.costs"},
+	} {
+		if err := b.Validate(); err == nil {
+			t.Errorf("Validate(%+v) returned no error", b)
+		}
+	}
+}
@@ -12,20 +12,60 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package config loads configuration derived from local gcloud defaults.
+// Package config loads configuration derived from the environment and local
+// gcloud defaults.
 package config
 
 import (
-	"log"
+	"context"
+	"log/slog"
+	"net/http"
+	"os"
 	"os/exec"
 	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/retry"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
+	"google.golang.org/grpc"
 )
 
+// CloudPlatformScope is the OAuth scope of the credentials of the Google API clients.
+const CloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
 // Config contains runtime configuration derived from the environment.
 type Config struct {
 	userAgent        string
 	defaultProjectID string
 	defaultLocation  string
+	defaultCluster   string
+	allowWrite       bool
+	dryRun           bool
+	readOnly         bool
+	toolFilter       Filter
+	promptFilter     Filter
+	tokenSource      oauth2.TokenSource
+	access           Access
+	githubToken      string
+	sources          Sources
+	promptsDir       string
+	plugins          Plugins
+	billing          Billing
+	files            Files
+	shared           bool
+}
+
+// Option customizes a Config.
+type Option func(*Config)
+
+// WithAllowWrite enables the tools mutating GKE resources.
+func WithAllowWrite(allowWrite bool) Option {
+	return func(c *Config) {
+		c.allowWrite = allowWrite
+	}
 }
 
 // UserAgent returns the user agent string for outbound API calls.
@@ -33,49 +73,322 @@ func (c *Config) UserAgent() string {
 	return c.userAgent
 }
 
+// Defaults are the project, location and cluster the tools fall back to when
+// their arguments leave them empty.
+type Defaults struct {
+	ProjectID string
+	Location  string
+	Cluster   string
+}
+
+type defaultsKey struct{}
+
+// WithDefaults returns a context overriding the defaults of the Config with
+// the non-empty fields of d, e.g. the defaults selected by an MCP session.
+func WithDefaults(ctx context.Context, d Defaults) context.Context {
+	return context.WithValue(ctx, defaultsKey{}, d)
+}
+
+func contextDefaults(ctx context.Context) Defaults {
+	d, _ := ctx.Value(defaultsKey{}).(Defaults)
+	return d
+}
+
 // DefaultProjectID returns the default GCP project ID, if set.
-func (c *Config) DefaultProjectID() string {
+func (c *Config) DefaultProjectID(ctx context.Context) string {
+	if p := contextDefaults(ctx).ProjectID; p != "" {
+		return p
+	}
 	return c.defaultProjectID
 }
 
 // DefaultLocation returns the default GCP region or zone, if set.
-func (c *Config) DefaultLocation() string {
+func (c *Config) DefaultLocation(ctx context.Context) string {
+	if l := contextDefaults(ctx).Location; l != "" {
+		return l
+	}
 	return c.defaultLocation
 }
 
-// New constructs a Config populated from gcloud and build version.
-func New(version string) *Config {
-	return &Config{
+// WithDryRun makes the mutating tools describe their changes instead of making them.
+func WithDryRun(dryRun bool) Option {
+	return func(c *Config) {
+		c.dryRun = dryRun
+	}
+}
+
+// WithReadOnly restricts the server to the read-only tools.
+func WithReadOnly(readOnly bool) Option {
+	return func(c *Config) {
+		c.readOnly = readOnly
+	}
+}
+
+// WithTokenSource makes the Google API clients authenticate with the token
+// source instead of Application Default Credentials, e.g. to impersonate a
+// service account.
+func WithTokenSource(ts oauth2.TokenSource) Option {
+	return func(c *Config) {
+		c.tokenSource = ts
+	}
+}
+
+// WithAccess restricts the projects each caller of the server can use.
+func WithAccess(a Access) Option {
+	return func(c *Config) {
+		c.access = a
+	}
+}
+
+// WithGitHubToken authenticates the requests to the GitHub API with the token,
+// raising their rate limit.
+func WithGitHubToken(token string) Option {
+	return func(c *Config) {
+		c.githubToken = token
+	}
+}
+
+// WithSources overrides the URLs of the Kubernetes changelogs and GKE release notes.
+func WithSources(s Sources) Option {
+	return func(c *Config) {
+		c.sources = s
+	}
+}
+
+// WithPlugins registers the tools of an organization alongside the built-in
+// tools.
+func WithPlugins(p Plugins) Option {
+	return func(c *Config) {
+		c.plugins = p
+	}
+}
+
+// WithBilling configures the cost tools.
+func WithBilling(b Billing) Option {
+	return func(c *Config) {
+		c.billing = b
+	}
+}
+
+// WithFiles configures the local directories the tools save files to.
+func WithFiles(f Files) Option {
+	return func(c *Config) {
+		c.files = f
+	}
+}
+
+// WithShared marks the server as shared by the clients of the HTTP or SSE
+// transport, which may be different users.
+func WithShared(shared bool) Option {
+	return func(c *Config) {
+		c.shared = shared
+	}
+}
+
+// WithPromptsDir sets the directory of the files overriding and extending the
+// templates of the prompts.
+func WithPromptsDir(dir string) Option {
+	return func(c *Config) {
+		c.promptsDir = dir
+	}
+}
+
+// WithToolFilter enables or disables tools by name.
+func WithToolFilter(f Filter) Option {
+	return func(c *Config) {
+		c.toolFilter = f
+	}
+}
+
+// WithPromptFilter enables or disables prompts by name.
+func WithPromptFilter(f Filter) Option {
+	return func(c *Config) {
+		c.promptFilter = f
+	}
+}
+
+// AllowWrite reports whether the tools mutating GKE resources are enabled.
+func (c *Config) AllowWrite() bool {
+	return c.allowWrite
+}
+
+// DryRun reports whether the mutating tools only describe their changes.
+func (c *Config) DryRun() bool {
+	return c.dryRun
+}
+
+// ReadOnly reports whether the server is restricted to the read-only tools.
+func (c *Config) ReadOnly() bool {
+	return c.readOnly
+}
+
+// ClientOptions returns the options of the gRPC Google API clients: the user
+// agent, the retries of transient errors of gRPC calls and, if configured,
+// the token source replacing Application Default Credentials.
+func (c *Config) ClientOptions() []option.ClientOption {
+	opts := []option.ClientOption{
+		option.WithUserAgent(c.userAgent),
+		option.WithGRPCDialOption(grpc.WithChainUnaryInterceptor(retry.UnaryClientInterceptor(retry.DefaultPolicy))),
+	}
+	if c.tokenSource != nil {
+		opts = append(opts, option.WithTokenSource(c.tokenSource))
+	}
+	return opts
+}
+
+// RESTClientOptions returns the options of the REST Google API clients, such
+// as compute.NewService, which ignore the gRPC retries of ClientOptions: an
+// authenticated HTTP client retrying the transient errors of GET requests.
+// When the client can't be created, e.g. without credentials, it returns
+// ClientOptions, so that creating the API client fails with the same error.
+func (c *Config) RESTClientOptions(ctx context.Context) []option.ClientOption {
+	rt, err := htransport.NewTransport(ctx, retry.NewClient().Transport, c.ClientOptions()...)
+	if err != nil {
+		return c.ClientOptions()
+	}
+	return []option.ClientOption{option.WithHTTPClient(&http.Client{Transport: rt})}
+}
+
+// TokenSource returns the source of the OAuth tokens of Google APIs: the
+// configured token source, or else Application Default Credentials.
+func (c *Config) TokenSource(ctx context.Context) (oauth2.TokenSource, error) {
+	if c.tokenSource != nil {
+		return c.tokenSource, nil
+	}
+	return google.DefaultTokenSource(ctx, CloudPlatformScope)
+}
+
+// Access returns the rules restricting the projects each caller of the server can use.
+func (c *Config) Access() Access {
+	return c.access
+}
+
+// GitHubToken returns the token of the requests to the GitHub API, if any.
+func (c *Config) GitHubToken() string {
+	return c.githubToken
+}
+
+// Sources returns the overridden URLs of the Kubernetes changelogs and GKE
+// release notes. Empty URLs keep their default.
+func (c *Config) Sources() Sources {
+	return c.sources
+}
+
+// Plugins returns the tools of an organization, if any.
+func (c *Config) Plugins() Plugins {
+	return c.plugins
+}
+
+// Billing returns the configuration of the cost tools.
+func (c *Config) Billing() Billing {
+	return c.billing
+}
+
+// Files returns the local directories the tools save files to.
+func (c *Config) Files() Files {
+	return c.files
+}
+
+// Shared reports whether the server is shared by the clients of the HTTP or
+// SSE transport.
+func (c *Config) Shared() bool {
+	return c.shared
+}
+
+// PromptsDir returns the directory of the files overriding and extending the
+// templates of the prompts, if any.
+func (c *Config) PromptsDir() string {
+	return c.promptsDir
+}
+
+// ToolEnabled reports whether the tool is enabled.
+func (c *Config) ToolEnabled(name string) bool {
+	return c.toolFilter.Allows(name)
+}
+
+// PromptEnabled reports whether the prompt is enabled.
+func (c *Config) PromptEnabled(name string) bool {
+	return c.promptFilter.Allows(name)
+}
+
+// DefaultCluster returns the default GKE cluster name, if set.
+func (c *Config) DefaultCluster(ctx context.Context) string {
+	if n := contextDefaults(ctx).Cluster; n != "" {
+		return n
+	}
+	return c.defaultCluster
+}
+
+// New constructs a Config populated from the environment, gcloud, build
+// version and options.
+func New(version string, opts ...Option) *Config {
+	c := &Config{
 		userAgent:        "gke-mcp/" + version,
 		defaultProjectID: getDefaultProjectID(),
 		defaultLocation:  getDefaultLocation(),
+		defaultCluster:   getDefaultCluster(),
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
+// Environment variables and gcloud config properties the defaults are resolved
+// from, in order of precedence. The environment variables take precedence over
+// the gcloud config, so that the server doesn't depend on gcloud when they are set.
+var (
+	projectEnvVars  = []string{"GOOGLE_CLOUD_PROJECT", "CLOUDSDK_CORE_PROJECT"}
+	projectKeys     = []string{"core/project"}
+	locationEnvVars = []string{"CLOUDSDK_COMPUTE_REGION", "CLOUDSDK_COMPUTE_ZONE"}
+	locationKeys    = []string{"compute/region", "compute/zone"}
+	clusterEnvVars  = []string{"CLOUDSDK_CONTAINER_CLUSTER"}
+	clusterKeys     = []string{"container/cluster"}
+)
+
 func getDefaultProjectID() string {
-	projectID, err := getGcloudConfig("core/project")
-	if err != nil {
-		log.Printf("Failed to get default project: %v", err)
-		return ""
-	}
-	return projectID
+	return resolveDefault(projectEnvVars, projectKeys)
 }
 
 func getDefaultLocation() string {
-	region, err := getGcloudConfig("compute/region")
-	if err == nil {
-		return region
+	return resolveDefault(locationEnvVars, locationKeys)
+}
+
+func getDefaultCluster() string {
+	return resolveDefault(clusterEnvVars, clusterKeys)
+}
+
+// resolveDefault returns the value of the first set environment variable, or
+// else of the first set gcloud config property. The properties gcloud fails
+// to read are skipped.
+func resolveDefault(envVars, gcloudKeys []string) string {
+	for _, env := range envVars {
+		if value := strings.TrimSpace(os.Getenv(env)); value != "" {
+			return value
+		}
 	}
-	zone, err := getGcloudConfig("compute/zone")
-	if err == nil {
-		return zone
+	for _, key := range gcloudKeys {
+		value, err := getGcloudConfig(key)
+		if err != nil {
+			slog.Warn("Failed to get gcloud config", "key", key, "error", err)
+			continue
+		}
+		if value != "" {
+			return value
+		}
 	}
 	return ""
 }
 
+// gcloudConfigTimeout bounds the time to read a gcloud config property, so
+// that a hung gcloud doesn't block the start of the server.
+const gcloudConfigTimeout = 30 * time.Second
+
 func getGcloudConfig(key string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), gcloudConfigTimeout)
+	defer cancel()
 	// #nosec G204
-	out, err := exec.Command("gcloud", "config", "get", key).Output()
+	out, err := exec.CommandContext(ctx, "gcloud", "config", "get", key).Output()
 	if err != nil {
 		return "", err
 	}
@@ -15,7 +15,16 @@
 package config
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
+
+	"golang.org/x/oauth2"
+	htransport "google.golang.org/api/transport/http"
 )
 
 func TestNew(t *testing.T) {
@@ -27,6 +36,131 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestNewWithEnvDefaults(t *testing.T) {
+	t.Setenv("GOOGLE_CLOUD_PROJECT", "")
+	t.Setenv("CLOUDSDK_CORE_PROJECT", "env-project")
+	t.Setenv("CLOUDSDK_COMPUTE_REGION", "")
+	t.Setenv("CLOUDSDK_COMPUTE_ZONE", "us-central1-a")
+	t.Setenv("CLOUDSDK_CONTAINER_CLUSTER", "env-cluster")
+
+	cfg := New("1.0.0")
+	if got := cfg.DefaultProjectID(context.Background()); got != "env-project" {
+		t.Errorf("DefaultProjectID() = %s, want env-project", got)
+	}
+	if got := cfg.DefaultLocation(context.Background()); got != "us-central1-a" {
+		t.Errorf("DefaultLocation() = %s, want us-central1-a", got)
+	}
+	if got := cfg.DefaultCluster(context.Background()); got != "env-cluster" {
+		t.Errorf("DefaultCluster() = %s, want env-cluster", got)
+	}
+
+	t.Setenv("GOOGLE_CLOUD_PROJECT", "google-cloud-project")
+	t.Setenv("CLOUDSDK_COMPUTE_REGION", "us-central1")
+	cfg = New("1.0.0")
+	if got := cfg.DefaultProjectID(context.Background()); got != "google-cloud-project" {
+		t.Errorf("DefaultProjectID() = %s, want GOOGLE_CLOUD_PROJECT to take precedence", got)
+	}
+	if got := cfg.DefaultLocation(context.Background()); got != "us-central1" {
+		t.Errorf("DefaultLocation() = %s, want the region to take precedence over the zone", got)
+	}
+}
+
+func TestNewWithAllowWrite(t *testing.T) {
+	if cfg := New("1.0.0"); cfg.AllowWrite() {
+		t.Errorf("AllowWrite() = true, want false by default")
+	}
+	if cfg := New("1.0.0", WithAllowWrite(true)); !cfg.AllowWrite() {
+		t.Errorf("AllowWrite() = false, want true with WithAllowWrite(true)")
+	}
+}
+
+func TestNewWithDryRun(t *testing.T) {
+	if cfg := New("1.0.0"); cfg.DryRun() {
+		t.Errorf("DryRun() = true, want false by default")
+	}
+	if cfg := New("1.0.0", WithDryRun(true)); !cfg.DryRun() {
+		t.Errorf("DryRun() = false, want true with WithDryRun(true)")
+	}
+}
+
+func TestNewWithReadOnly(t *testing.T) {
+	if cfg := New("1.0.0"); cfg.ReadOnly() {
+		t.Errorf("ReadOnly() = true, want false by default")
+	}
+	if cfg := New("1.0.0", WithReadOnly(true)); !cfg.ReadOnly() {
+		t.Errorf("ReadOnly() = false, want true with WithReadOnly(true)")
+	}
+}
+
+func TestNewWithShared(t *testing.T) {
+	if cfg := New("1.0.0"); cfg.Shared() {
+		t.Errorf("Shared() = true, want false by default")
+	}
+	if cfg := New("1.0.0", WithShared(true)); !cfg.Shared() {
+		t.Errorf("Shared() = false, want true with WithShared(true)")
+	}
+}
+
+func TestNewWithTokenSource(t *testing.T) {
+	if opts := New("1.0.0").ClientOptions(); len(opts) != 2 {
+		t.Errorf("ClientOptions() returned %d options, want only the user agent and the retries", len(opts))
+	}
+
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "impersonated"})
+	cfg := New("1.0.0", WithTokenSource(ts))
+	if opts := cfg.ClientOptions(); len(opts) != 3 {
+		t.Errorf("ClientOptions() returned %d options, want the user agent, the retries and the token source", len(opts))
+	}
+	got, err := cfg.TokenSource(context.Background())
+	if err != nil {
+		t.Fatalf("TokenSource() error = %v", err)
+	}
+	if token, err := got.Token(); err != nil || token.AccessToken != "impersonated" {
+		t.Errorf("TokenSource() token = %v, %v, want the configured token source", token, err)
+	}
+}
+
+func TestRESTClientOptions(t *testing.T) {
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if got := r.Header.Get("Authorization"); got != "Bearer impersonated" {
+			t.Errorf("Authorization = %q, want the token of the token source", got)
+		}
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "impersonated"})
+	ctx := context.Background()
+	client, _, err := htransport.NewClient(ctx, New("1.0.0", WithTokenSource(ts)).RESTClientOptions(ctx)...)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || attempts != 2 {
+		t.Errorf("Get() = %d after %d attempts, want 200 after a retry", resp.StatusCode, attempts)
+	}
+}
+
+func TestNewWithFilters(t *testing.T) {
+	cfg := New("1.0.0", WithToolFilter(Filter{Disabled: []string{"query_logs"}}), WithPromptFilter(Filter{Enabled: []string{"gke:cost"}}))
+	if cfg.ToolEnabled("query_logs") || !cfg.ToolEnabled("list_clusters") {
+		t.Errorf("ToolEnabled() does not apply the tool filter")
+	}
+	if !cfg.PromptEnabled("gke:cost") || cfg.PromptEnabled("gke:deploy") {
+		t.Errorf("PromptEnabled() does not apply the prompt filter")
+	}
+}
+
 func TestConfigGetters(t *testing.T) {
 	cfg := &Config{
 		userAgent:        "test-agent",
@@ -37,14 +171,33 @@ func TestConfigGetters(t *testing.T) {
 	if got := cfg.UserAgent(); got != "test-agent" {
 		t.Errorf("UserAgent() = %s, want test-agent", got)
 	}
-	if got := cfg.DefaultProjectID(); got != "test-project" {
+	if got := cfg.DefaultProjectID(context.Background()); got != "test-project" {
 		t.Errorf("DefaultProjectID() = %s, want test-project", got)
 	}
-	if got := cfg.DefaultLocation(); got != "us-central1" {
+	if got := cfg.DefaultLocation(context.Background()); got != "us-central1" {
 		t.Errorf("DefaultLocation() = %s, want us-central1", got)
 	}
 }
 
+func TestWithDefaults(t *testing.T) {
+	cfg := &Config{
+		defaultProjectID: "test-project",
+		defaultLocation:  "us-central1",
+		defaultCluster:   "test-cluster",
+	}
+	ctx := WithDefaults(context.Background(), Defaults{ProjectID: "session-project", Cluster: "session-cluster"})
+
+	if got := cfg.DefaultProjectID(ctx); got != "session-project" {
+		t.Errorf("DefaultProjectID() = %s, want session-project", got)
+	}
+	if got := cfg.DefaultLocation(ctx); got != "us-central1" {
+		t.Errorf("DefaultLocation() = %s, want the configured location for an empty override", got)
+	}
+	if got := cfg.DefaultCluster(ctx); got != "session-cluster" {
+		t.Errorf("DefaultCluster() = %s, want session-cluster", got)
+	}
+}
+
 func TestNewConfigWithVersion(t *testing.T) {
 	testVersion := "0.1.0"
 	cfg := New(testVersion)
@@ -72,8 +225,8 @@ func TestConfigFields(t *testing.T) {
 		expected string
 	}{
 		{"UserAgent", cfg.UserAgent(), "test-agent"},
-		{"DefaultProjectID", cfg.DefaultProjectID(), "my-project"},
-		{"DefaultLocation", cfg.DefaultLocation(), "us-west1"},
+		{"DefaultProjectID", cfg.DefaultProjectID(context.Background()), "my-project"},
+		{"DefaultLocation", cfg.DefaultLocation(context.Background()), "us-west1"},
 	}
 
 	for _, tt := range tests {
@@ -117,6 +270,24 @@ func TestGetGcloudConfigTrimsWhitespace(t *testing.T) {
 	_ = err
 }
 
+func TestResolveDefaultSkipsFailingGcloudKeys(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake gcloud is a shell script")
+	}
+	dir := t.TempDir()
+	script := "#!/bin/sh\nif [ \"$3\" = compute/region ]; then exit 1; fi\necho us-central1-a\n"
+	if err := os.WriteFile(filepath.Join(dir, "gcloud"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+	t.Setenv("CLOUDSDK_COMPUTE_REGION", "")
+	t.Setenv("CLOUDSDK_COMPUTE_ZONE", "")
+
+	if got := resolveDefault(locationEnvVars, locationKeys); got != "us-central1-a" {
+		t.Errorf("resolveDefault() = %q, want %q", got, "us-central1-a")
+	}
+}
+
 func TestGetDefaultLocationNotPanic(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration test in short mode")
@@ -140,11 +311,11 @@ func TestConfigNilFields(t *testing.T) {
 	if cfg.UserAgent() != "" {
 		t.Errorf("Expected empty UserAgent for empty Config, got %s", cfg.UserAgent())
 	}
-	if cfg.DefaultProjectID() != "" {
-		t.Errorf("Expected empty DefaultProjectID for empty Config, got %s", cfg.DefaultProjectID())
+	if cfg.DefaultProjectID(context.Background()) != "" {
+		t.Errorf("Expected empty DefaultProjectID for empty Config, got %s", cfg.DefaultProjectID(context.Background()))
 	}
-	if cfg.DefaultLocation() != "" {
-		t.Errorf("Expected empty DefaultLocation for empty Config, got %s", cfg.DefaultLocation())
+	if cfg.DefaultLocation(context.Background()) != "" {
+		t.Errorf("Expected empty DefaultLocation for empty Config, got %s", cfg.DefaultLocation(context.Background()))
 	}
 }
 
@@ -0,0 +1,28 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// Files configures the local directories the tools save files to. The tools
+// only write inside them, so that the clients of a shared server can't write
+// anywhere else on its host.
+type Files struct {
+	// ReportsDir is the directory save_report saves the local reports to.
+	// Defaults to the gke-mcp/reports directory of the user cache directory.
+	ReportsDir string `json:"reportsDir,omitempty"`
+	// SnapshotsDir is the directory snapshot_cluster saves the snapshots to,
+	// and diff_cluster_snapshots reads them from. Defaults to the
+	// gke-mcp/snapshots directory of the user cache directory.
+	SnapshotsDir string `json:"snapshotsDir,omitempty"`
+}
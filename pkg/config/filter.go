@@ -0,0 +1,128 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/audit"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/logger"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tracing"
+	"sigs.k8s.io/yaml"
+)
+
+// Filter enables or disables tools or prompts by name. Names can be glob
+// patterns, e.g. *_logs.
+type Filter struct {
+	// Enabled lists the names to enable. All names are enabled when it is empty.
+	Enabled []string `json:"enabled,omitempty"`
+	// Disabled lists the names to disable, even if they are enabled.
+	Disabled []string `json:"disabled,omitempty"`
+}
+
+// Allows reports whether the filter enables the name.
+func (f Filter) Allows(name string) bool {
+	if len(f.Enabled) > 0 && !matchesAny(f.Enabled, name) {
+		return false
+	}
+	return !matchesAny(f.Disabled, name)
+}
+
+// Validate checks the syntax of the name patterns.
+func (f Filter) Validate() error {
+	for _, pattern := range append(f.Enabled, f.Disabled...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid name pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// Merge returns the filter enabling and disabling the names of both filters.
+func (f Filter) Merge(other Filter) Filter {
+	return Filter{
+		Enabled:  append(append([]string{}, f.Enabled...), other.Enabled...),
+		Disabled: append(append([]string{}, f.Disabled...), other.Disabled...),
+	}
+}
+
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// File is the content of a configuration file.
+type File struct {
+	Tools    Filter          `json:"tools,omitempty"`
+	Prompts  Filter          `json:"prompts,omitempty"`
+	Access   Access          `json:"access,omitempty"`
+	Logging  logger.Options  `json:"logging,omitempty"`
+	Tracing  tracing.Options `json:"tracing,omitempty"`
+	Audit    audit.Options   `json:"audit,omitempty"`
+	Timeouts Timeouts        `json:"timeouts,omitempty"`
+	Output   OutputLimits    `json:"output,omitempty"`
+	GitHub   GitHub          `json:"github,omitempty"`
+	Sources  Sources         `json:"sources,omitempty"`
+	Plugins  Plugins         `json:"plugins,omitempty"`
+	Billing  Billing         `json:"billing,omitempty"`
+	Files    Files           `json:"files,omitempty"`
+}
+
+// GitHub configures the requests to the GitHub API.
+type GitHub struct {
+	// Token authenticates the requests, raising their rate limit.
+	Token string `json:"token,omitempty"`
+}
+
+// LoadFile reads a YAML or JSON configuration file.
+func LoadFile(name string) (*File, error) {
+	// #nosec G304
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	f := &File{}
+	if err := yaml.UnmarshalStrict(data, f); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", name, err)
+	}
+	if err := f.Tools.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid tools in config file %s: %w", name, err)
+	}
+	if err := f.Prompts.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid prompts in config file %s: %w", name, err)
+	}
+	if err := f.Access.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid access in config file %s: %w", name, err)
+	}
+	if err := f.Sources.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid sources in config file %s: %w", name, err)
+	}
+	if err := f.Timeouts.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid timeouts in config file %s: %w", name, err)
+	}
+	if err := f.Plugins.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid plugins in config file %s: %w", name, err)
+	}
+	if err := f.Billing.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid billing in config file %s: %w", name, err)
+	}
+	return f, nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFilterAllows(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter Filter
+		allow  []string
+		deny   []string
+	}{
+		{
+			name:  "empty filter",
+			allow: []string{"query_logs", "list_clusters"},
+		},
+		{
+			name:   "disabled patterns",
+			filter: Filter{Disabled: []string{"*_logs", "get_log_schema"}},
+			allow:  []string{"list_clusters"},
+			deny:   []string{"query_logs", "get_log_schema"},
+		},
+		{
+			name:   "enabled patterns",
+			filter: Filter{Enabled: []string{"list_*", "get_cluster"}},
+			allow:  []string{"list_clusters", "get_cluster"},
+			deny:   []string{"create_cluster", "query_logs"},
+		},
+		{
+			name:   "disabled wins over enabled",
+			filter: Filter{Enabled: []string{"list_*"}, Disabled: []string{"list_recommendations"}},
+			allow:  []string{"list_clusters"},
+			deny:   []string{"list_recommendations"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range tt.allow {
+				if !tt.filter.Allows(name) {
+					t.Errorf("Allows(%q) = false, want true", name)
+				}
+			}
+			for _, name := range tt.deny {
+				if tt.filter.Allows(name) {
+					t.Errorf("Allows(%q) = true, want false", name)
+				}
+			}
+		})
+	}
+}
+
+func TestFilterMerge(t *testing.T) {
+	f := Filter{Disabled: []string{"query_logs"}}.Merge(Filter{Enabled: []string{"*"}, Disabled: []string{"get_log_schema"}})
+	if len(f.Enabled) != 1 || len(f.Disabled) != 2 {
+		t.Errorf("Merge() = %+v, want 1 enabled and 2 disabled names", f)
+	}
+	if f.Allows("get_log_schema") || f.Allows("query_logs") || !f.Allows("list_clusters") {
+		t.Errorf("Merge() = %+v does not combine both filters", f)
+	}
+}
+
+func TestFilterValidate(t *testing.T) {
+	if err := (Filter{Enabled: []string{"list_*"}, Disabled: []string{"query_logs"}}).Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if err := (Filter{Disabled: []string{"[query"}}).Validate(); err == nil {
+		t.Errorf("Validate() of an invalid pattern returned no error")
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "config.yaml")
+	content := `
+tools:
+  disabled:
+  - query_logs
+  - get_log_schema
+prompts:
+  enabled:
+  - gke:upgrade-*
+`
+	if err := os.WriteFile(name, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := LoadFile(name)
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	if f.Tools.Allows("query_logs") || !f.Tools.Allows("list_clusters") {
+		t.Errorf("LoadFile() tools = %+v, want query_logs disabled", f.Tools)
+	}
+	if !f.Prompts.Allows("gke:upgrade-risk-report") || f.Prompts.Allows("gke:cost") {
+		t.Errorf("LoadFile() prompts = %+v, want only upgrade prompts enabled", f.Prompts)
+	}
+
+	if err := os.WriteFile(name, []byte("tool:\n  disabled: [query_logs]\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFile(name); err == nil {
+		t.Errorf("LoadFile() with an unknown field returned no error")
+	}
+	if _, err := LoadFile(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Errorf("LoadFile() of a missing file returned no error")
+	}
+}
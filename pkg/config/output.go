@@ -0,0 +1,62 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// DefaultMaxOutputSize bounds the size of tool results, in bytes, when no
+// limit is configured. About 4 bytes make a token of English text.
+const DefaultMaxOutputSize = 256 * 1024
+
+// OutputLimits bounds the size of the text of tool results, in bytes. A
+// negative size means no limit.
+type OutputLimits struct {
+	// MaxSize is the limit of the tools without their own limit.
+	MaxSize int `json:"maxSize,omitempty"`
+	// Tools are the limits of tools by name.
+	Tools map[string]int `json:"tools,omitempty"`
+}
+
+// Merge returns the limits of l overridden by the non-empty fields of other.
+func (l OutputLimits) Merge(other OutputLimits) OutputLimits {
+	if other.MaxSize != 0 {
+		l.MaxSize = other.MaxSize
+	}
+	if len(other.Tools) > 0 {
+		tools := make(map[string]int, len(l.Tools)+len(other.Tools))
+		for name, size := range l.Tools {
+			tools[name] = size
+		}
+		for name, size := range other.Tools {
+			tools[name] = size
+		}
+		l.Tools = tools
+	}
+	return l
+}
+
+// For returns the size limit of the results of the tool, 0 meaning no limit.
+func (l OutputLimits) For(tool string) int {
+	size, ok := l.Tools[tool]
+	if !ok || size == 0 {
+		size = l.MaxSize
+	}
+	switch {
+	case size == 0:
+		return DefaultMaxOutputSize
+	case size < 0:
+		return 0
+	default:
+		return size
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "testing"
+
+func TestOutputLimitsFor(t *testing.T) {
+	limits := OutputLimits{MaxSize: 1000, Tools: map[string]int{"get_k8s_changelog": 5000, "kubectl_get": -1}}
+	tests := map[string]int{
+		"list_clusters":     1000,
+		"get_k8s_changelog": 5000,
+		"kubectl_get":       0,
+	}
+	for tool, want := range tests {
+		if got := limits.For(tool); got != want {
+			t.Errorf("For(%q) = %d, want %d", tool, got, want)
+		}
+	}
+	if got := (OutputLimits{}).For("list_clusters"); got != DefaultMaxOutputSize {
+		t.Errorf("For() without limits = %d, want %d", got, DefaultMaxOutputSize)
+	}
+}
+
+func TestOutputLimitsMerge(t *testing.T) {
+	got := OutputLimits{MaxSize: 1000, Tools: map[string]int{"a": 1}}.Merge(OutputLimits{MaxSize: 2000, Tools: map[string]int{"b": 2}})
+	if got.MaxSize != 2000 || got.Tools["a"] != 1 || got.Tools["b"] != 2 {
+		t.Errorf("Merge() = %+v, want max size 2000 with the limits of a and b", got)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var pluginNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
+// Plugin is a tool of an organization registered alongside the built-in
+// tools, run as a subprocess for each call.
+type Plugin struct {
+	// Name is the name of the tool, e.g. check_org_policies.
+	Name string `json:"name"`
+	// Description tells the model what the tool does and when to use it.
+	Description string `json:"description"`
+	// Command is the executable and its arguments. The arguments of the call
+	// are written to its standard input as a JSON object, and its standard
+	// output is the result of the call.
+	Command []string `json:"command"`
+	// InputSchema is the JSON schema of the arguments of the call, an object
+	// accepting any argument by default.
+	InputSchema map[string]any `json:"inputSchema,omitempty"`
+	// ReadOnly marks the tools which don't modify any resource, the only ones
+	// registered in read-only mode.
+	ReadOnly bool `json:"readOnly,omitempty"`
+}
+
+// Plugins are the tools of an organization.
+type Plugins []Plugin
+
+// Validate checks that the plugins have unique valid names, a description and
+// a command, and that their input schemas describe objects.
+func (p Plugins) Validate() error {
+	names := make(map[string]bool)
+	for _, plugin := range p {
+		if !pluginNameRegexp.MatchString(plugin.Name) {
+			return fmt.Errorf("invalid plugin name %q: want letters, digits, _ and -", plugin.Name)
+		}
+		if names[plugin.Name] {
+			return fmt.Errorf("duplicate plugin %s", plugin.Name)
+		}
+		names[plugin.Name] = true
+		if plugin.Description == "" {
+			return fmt.Errorf("plugin %s has no description", plugin.Name)
+		}
+		if len(plugin.Command) == 0 || plugin.Command[0] == "" {
+			return fmt.Errorf("plugin %s has no command", plugin.Name)
+		}
+		if plugin.InputSchema != nil && plugin.InputSchema["type"] != "object" {
+			return fmt.Errorf("input schema of plugin %s is not of type object", plugin.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "testing"
+
+func TestPluginsValidate(t *testing.T) {
+	valid := Plugins{
+		{Name: "check_org_policies", Description: "Check the policies.", Command: []string{"/usr/local/bin/check-policies", "--json"}},
+		{Name: "open-ticket", Description: "Open a ticket.", Command: []string{"ticket"}, InputSchema: map[string]any{"type": "object"}},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	for _, p := range []Plugins{
+		{{Name: "check policies", Description: "Check.", Command: []string{"check"}}},
+		{{Name: "check", Description: "Check.", Command: []string{"check"}}, {Name: "check", Description: "Check again.", Command: []string{"check"}}},
+		{{Name: "check", Command: []string{"check"}}},
+		{{Name: "check", Description: "Check."}},
+		{{Name: "check", Description: "Check.", Command: []string{"check"}, InputSchema: map[string]any{"type": "string"}}},
+	} {
+		if err := p.Validate(); err == nil {
+			t.Errorf("Validate(%+v) returned no error", p)
+		}
+	}
+}
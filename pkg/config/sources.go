@@ -0,0 +1,82 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// VersionPlaceholder is replaced with the Kubernetes minor version, e.g.
+// 1.33, in the URL of the changelog mirror.
+const VersionPlaceholder = "{version}"
+
+// Sources overrides the URLs the Kubernetes changelogs, GKE release notes and
+// GKE known issues are read from, e.g. with internal mirrors in
+// egress-restricted environments.
+type Sources struct {
+	// ChangelogURL is the URL of the changelog of a Kubernetes minor version,
+	// with a {version} placeholder, e.g.
+	// https://mirror.example.com/kubernetes/CHANGELOG/CHANGELOG-{version}.md.
+	ChangelogURL string `json:"changelogURL,omitempty"`
+	// ReleaseNotesURL is the URL of the HTML page of the GKE release notes, or
+	// of their Atom feed.
+	ReleaseNotesURL string `json:"releaseNotesURL,omitempty"`
+	// ReleaseNotesFeed reads the GKE release notes from their official Atom
+	// feed instead of the HTML page, whose layout changes more often.
+	ReleaseNotesFeed bool `json:"releaseNotesFeed,omitempty"`
+	// KnownIssuesURL is the URL of the HTML page of the GKE known issues.
+	KnownIssuesURL string `json:"knownIssuesURL,omitempty"`
+	// OfflineDir is the directory the changelogs, release notes and known
+	// issues are read from instead of the network, downloaded with the
+	// download-offline-sources command.
+	OfflineDir string `json:"offlineDir,omitempty"`
+}
+
+// Validate checks that the URLs are HTTP URLs, and that the changelog URL
+// has a version placeholder.
+func (s Sources) Validate() error {
+	if s.ChangelogURL != "" {
+		if err := validateHTTPURL(s.ChangelogURL); err != nil {
+			return fmt.Errorf("invalid changelog URL: %w", err)
+		}
+		if !strings.Contains(s.ChangelogURL, VersionPlaceholder) {
+			return fmt.Errorf("changelog URL %s has no %s placeholder", s.ChangelogURL, VersionPlaceholder)
+		}
+	}
+	if s.ReleaseNotesURL != "" {
+		if err := validateHTTPURL(s.ReleaseNotesURL); err != nil {
+			return fmt.Errorf("invalid release notes URL: %w", err)
+		}
+	}
+	if s.KnownIssuesURL != "" {
+		if err := validateHTTPURL(s.KnownIssuesURL); err != nil {
+			return fmt.Errorf("invalid known issues URL: %w", err)
+		}
+	}
+	return nil
+}
+
+func validateHTTPURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%s is not an http or https URL", s)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "testing"
+
+func TestSourcesValidate(t *testing.T) {
+	valid := Sources{
+		ChangelogURL:    "https://mirror.example.com/kubernetes/CHANGELOG-{version}.md",
+		ReleaseNotesURL: "http://proxy.internal/gke/release-notes",
+		KnownIssuesURL:  "http://proxy.internal/gke/known-issues",
+	}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	for _, s := range []Sources{
+		{ChangelogURL: "https://mirror.example.com/kubernetes/CHANGELOG.md"},
+		{ChangelogURL: "ftp://mirror.example.com/CHANGELOG-{version}.md"},
+		{ReleaseNotesURL: "/release-notes"},
+		{KnownIssuesURL: "known-issues.html"},
+	} {
+		if err := s.Validate(); err == nil {
+			t.Errorf("Validate(%+v) returned no error", s)
+		}
+	}
+}
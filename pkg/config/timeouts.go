@@ -0,0 +1,95 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultToolTimeout bounds the duration of tool calls when no timeout is configured.
+const DefaultToolTimeout = 10 * time.Minute
+
+// Timeouts bounds the duration of tool calls, so that the external commands
+// of hung calls are stopped. Durations are Go durations, e.g. 90s or 15m,
+// and 0 means no timeout.
+type Timeouts struct {
+	// Default is the timeout of the tools without their own timeout.
+	Default string `json:"default,omitempty"`
+	// Tools are the timeouts of tools by name.
+	Tools map[string]string `json:"tools,omitempty"`
+}
+
+// Merge returns the timeouts of t overridden by the non-empty fields of other.
+func (t Timeouts) Merge(other Timeouts) Timeouts {
+	if other.Default != "" {
+		t.Default = other.Default
+	}
+	if len(other.Tools) > 0 {
+		tools := make(map[string]string, len(t.Tools)+len(other.Tools))
+		for name, d := range t.Tools {
+			tools[name] = d
+		}
+		for name, d := range other.Tools {
+			tools[name] = d
+		}
+		t.Tools = tools
+	}
+	return t
+}
+
+// Validate checks that the timeouts are valid non-negative durations.
+func (t Timeouts) Validate() error {
+	if _, err := parseTimeout(t.Default); err != nil {
+		return fmt.Errorf("invalid default timeout: %w", err)
+	}
+	for name, d := range t.Tools {
+		if _, err := parseTimeout(d); err != nil {
+			return fmt.Errorf("invalid timeout of tool %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// For returns the timeout of the tool, 0 meaning no timeout. Invalid
+// timeouts, rejected by Validate, are ignored.
+func (t Timeouts) For(tool string) time.Duration {
+	if d, ok := t.Tools[tool]; ok {
+		if timeout, err := parseTimeout(d); err == nil {
+			return timeout
+		}
+	}
+	if t.Default == "" {
+		return DefaultToolTimeout
+	}
+	if timeout, err := parseTimeout(t.Default); err == nil {
+		return timeout
+	}
+	return DefaultToolTimeout
+}
+
+func parseTimeout(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("timeout %s is negative", s)
+	}
+	return d, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeoutsFor(t *testing.T) {
+	timeouts := Timeouts{Default: "2m", Tools: map[string]string{"get_node_sos_report": "15m", "cluster_toolkit_download": "0"}}
+	tests := map[string]time.Duration{
+		"list_clusters":            2 * time.Minute,
+		"get_node_sos_report":      15 * time.Minute,
+		"cluster_toolkit_download": 0,
+	}
+	for tool, want := range tests {
+		if got := timeouts.For(tool); got != want {
+			t.Errorf("For(%q) = %v, want %v", tool, got, want)
+		}
+	}
+	if got := (Timeouts{}).For("list_clusters"); got != DefaultToolTimeout {
+		t.Errorf("For() without timeouts = %v, want %v", got, DefaultToolTimeout)
+	}
+}
+
+func TestTimeoutsValidate(t *testing.T) {
+	if err := (Timeouts{Default: "90s", Tools: map[string]string{"kubectl": "0"}}).Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	for _, timeouts := range []Timeouts{
+		{Default: "ten minutes"},
+		{Tools: map[string]string{"kubectl": "-1m"}},
+	} {
+		if err := timeouts.Validate(); err == nil {
+			t.Errorf("Validate(%+v) returned no error", timeouts)
+		}
+	}
+}
+
+func TestTimeoutsMerge(t *testing.T) {
+	got := Timeouts{Default: "5m", Tools: map[string]string{"a": "1m", "b": "2m"}}.Merge(Timeouts{Tools: map[string]string{"b": "3m"}})
+	if got.Default != "5m" || got.Tools["a"] != "1m" || got.Tools["b"] != "3m" {
+		t.Errorf("Merge() = %+v, want default 5m, a 1m and b 3m", got)
+	}
+}
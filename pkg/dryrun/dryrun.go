@@ -0,0 +1,65 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dryrun describes the changes of the mutating tools when the server
+// runs in dry-run mode, instead of making them.
+package dryrun
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Plan is a change a mutating tool would make.
+type Plan struct {
+	// Method is the API method the tool would call, e.g. ClusterManager.UpdateMaster.
+	Method string
+	// Request is the request the tool would send.
+	Request proto.Message
+	// Gcloud is the equivalent gcloud command.
+	Gcloud string
+	// Effect describes the expected effect of the change.
+	Effect string
+}
+
+// String returns the description of the plan reviewed by operators.
+func (p *Plan) String() string {
+	var b strings.Builder
+	b.WriteString("Dry run: no change was made.\n\n")
+	fmt.Fprintf(&b, "API call: %s\n", p.Method)
+	if p.Request != nil {
+		b.WriteString(protojson.Format(p.Request) + "\n")
+	}
+	if p.Gcloud != "" {
+		fmt.Fprintf(&b, "\ngcloud equivalent:\n  %s\n", p.Gcloud)
+	}
+	if p.Effect != "" {
+		fmt.Fprintf(&b, "\nExpected effect: %s\n", p.Effect)
+	}
+	b.WriteString("\nStart the server without --dry-run to make the change.\n")
+	return b.String()
+}
+
+// Result returns the tool result describing the plan.
+func (p *Plan) Result() *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: p.String()},
+		},
+	}
+}
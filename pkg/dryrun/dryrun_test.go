@@ -0,0 +1,47 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dryrun
+
+import (
+	"strings"
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+)
+
+func TestPlanString(t *testing.T) {
+	p := &Plan{
+		Method: "ClusterManager.UpdateMaster",
+		Request: &containerpb.UpdateMasterRequest{
+			Name:          "projects/my-project/locations/us-central1/clusters/my-cluster",
+			MasterVersion: "1.33",
+		},
+		Gcloud: "gcloud container clusters upgrade my-cluster --master --cluster-version=1.33",
+		Effect: "The control plane is upgraded.",
+	}
+
+	got := p.String()
+	for _, want := range []string{
+		"Dry run: no change was made.",
+		"API call: ClusterManager.UpdateMaster\n",
+		"masterVersion",
+		"gcloud equivalent:\n  gcloud container clusters upgrade my-cluster --master --cluster-version=1.33\n",
+		"Expected effect: The control plane is upgraded.\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("String() = %q, want it to contain %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	container "cloud.google.com/go/container/apiv1"
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+)
+
+// ClusterName returns the resource name of a GKE cluster. Empty projectID,
+// location and name fall back to the configured defaults.
+func ClusterName(ctx context.Context, c *config.Config, projectID, location, name string) (string, error) {
+	if projectID == "" {
+		projectID = c.DefaultProjectID(ctx)
+	}
+	if location == "" {
+		location = c.DefaultLocation(ctx)
+	}
+	if name == "" {
+		name = c.DefaultCluster(ctx)
+	}
+	if name == "" {
+		return "", fmt.Errorf("name argument cannot be empty")
+	}
+	return fmt.Sprintf("projects/%s/locations/%s/clusters/%s", projectID, location, name), nil
+}
+
+// GetCluster fetches a GKE cluster. Empty projectID, location and name fall
+// back to the configured defaults.
+func GetCluster(ctx context.Context, cmClient *container.ClusterManagerClient, c *config.Config, projectID, location, name string) (*containerpb.Cluster, error) {
+	clusterName, err := ClusterName(ctx, c, projectID, location, name)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &containerpb.GetClusterRequest{
+		Name: clusterName,
+	}
+	cluster, err := cmClient.GetCluster(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster %s: %w", clusterName, err)
+	}
+	return cluster, nil
+}
+
+// Region returns the region of a GKE location, which is either a region or a zone.
+func Region(location string) string {
+	// Zones are named <region>-<letter>, e.g. us-central1-a.
+	if i := strings.LastIndex(location, "-"); i > 0 && len(location)-i == 2 {
+		return location[:i]
+	}
+	return location
+}
+
+// KubeContextName returns the name of the kubeconfig context of a GKE
+// cluster, following the naming of the kubeconfigs generated by gcloud and
+// get_kubeconfig.
+func KubeContextName(projectID, location, name string) string {
+	return fmt.Sprintf("gke_%s_%s_%s", projectID, location, name)
+}
+
+// RequireKubeContext returns an error if the current kubectl context is not
+// the one of the cluster, so that in-cluster resources are not read from
+// another cluster.
+func RequireKubeContext(ctx context.Context, projectID string, cluster *containerpb.Cluster) error {
+	wantContext := KubeContextName(projectID, cluster.GetLocation(), cluster.GetName())
+	currentContext, err := kube.CurrentContext(ctx)
+	if err != nil {
+		return err
+	}
+	if currentContext != wantContext {
+		return fmt.Errorf("the current kubectl context is %s, not %s, run get_kubeconfig for the cluster first", currentContext, wantContext)
+	}
+	return nil
+}
+
+// EnabledAddons returns the names of the GKE add-ons enabled on the cluster.
+func EnabledAddons(cluster *containerpb.Cluster) []string {
+	addons := cluster.GetAddonsConfig()
+	var enabled []string
+	for _, addon := range []struct {
+		name    string
+		enabled bool
+	}{
+		// HTTP load balancing, horizontal pod autoscaling and network policy are
+		// configured by a disabled flag, unset meaning enabled for the first two.
+		{"HttpLoadBalancing", !addons.GetHttpLoadBalancing().GetDisabled()},
+		{"HorizontalPodAutoscaling", !addons.GetHorizontalPodAutoscaling().GetDisabled()},
+		{"NetworkPolicy", addons.GetNetworkPolicyConfig() != nil && !addons.GetNetworkPolicyConfig().GetDisabled()},
+		{"CloudRun", addons.GetCloudRunConfig() != nil && !addons.GetCloudRunConfig().GetDisabled()},
+		{"NodeLocalDNSCache", addons.GetDnsCacheConfig().GetEnabled()},
+		{"ConfigConnector", addons.GetConfigConnectorConfig().GetEnabled()},
+		{"GcePersistentDiskCsiDriver", addons.GetGcePersistentDiskCsiDriverConfig().GetEnabled()},
+		{"GcpFilestoreCsiDriver", addons.GetGcpFilestoreCsiDriverConfig().GetEnabled()},
+		{"GcsFuseCsiDriver", addons.GetGcsFuseCsiDriverConfig().GetEnabled()},
+		{"ParallelstoreCsiDriver", addons.GetParallelstoreCsiDriverConfig().GetEnabled()},
+		{"LustreCsiDriver", addons.GetLustreCsiDriverConfig().GetEnabled()},
+		{"GkeBackupAgent", addons.GetGkeBackupAgentConfig().GetEnabled()},
+		{"StatefulHA", addons.GetStatefulHaConfig().GetEnabled()},
+		{"RayOperator", addons.GetRayOperatorConfig().GetEnabled()},
+	} {
+		if addon.enabled {
+			enabled = append(enabled, addon.name)
+		}
+	}
+	return enabled
+}
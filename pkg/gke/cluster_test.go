@@ -0,0 +1,102 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+)
+
+func TestRegion(t *testing.T) {
+	tests := []struct {
+		location string
+		want     string
+	}{
+		{location: "us-central1", want: "us-central1"},
+		{location: "us-central1-a", want: "us-central1"},
+		{location: "europe-west4-b", want: "europe-west4"},
+		{location: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.location, func(t *testing.T) {
+			if got := Region(tt.location); got != tt.want {
+				t.Errorf("Region(%q) = %q, want %q", tt.location, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnabledAddons(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		AddonsConfig: &containerpb.AddonsConfig{
+			HttpLoadBalancing:                &containerpb.HttpLoadBalancing{Disabled: true},
+			NetworkPolicyConfig:              &containerpb.NetworkPolicyConfig{Disabled: true},
+			DnsCacheConfig:                   &containerpb.DnsCacheConfig{Enabled: true},
+			GcePersistentDiskCsiDriverConfig: &containerpb.GcePersistentDiskCsiDriverConfig{Enabled: true},
+		},
+	}
+	want := []string{"HorizontalPodAutoscaling", "NodeLocalDNSCache", "GcePersistentDiskCsiDriver"}
+	if got := EnabledAddons(cluster); !slices.Equal(got, want) {
+		t.Errorf("EnabledAddons() = %v, want %v", got, want)
+	}
+}
+
+func TestClusterName(t *testing.T) {
+	got, err := ClusterName(context.Background(), &config.Config{}, "my-project", "us-central1", "my-cluster")
+	if err != nil {
+		t.Fatalf("ClusterName() error = %v", err)
+	}
+	if want := "projects/my-project/locations/us-central1/clusters/my-cluster"; got != want {
+		t.Errorf("ClusterName() = %q, want %q", got, want)
+	}
+	if _, err := ClusterName(context.Background(), &config.Config{}, "my-project", "us-central1", ""); err == nil {
+		t.Errorf("ClusterName() with an empty name returned no error")
+	}
+
+	t.Setenv("CLOUDSDK_CONTAINER_CLUSTER", "default-cluster")
+	got, err = ClusterName(context.Background(), config.New("test"), "my-project", "us-central1", "")
+	if err != nil {
+		t.Fatalf("ClusterName() error = %v", err)
+	}
+	if want := "projects/my-project/locations/us-central1/clusters/default-cluster"; got != want {
+		t.Errorf("ClusterName() = %q, want %q", got, want)
+	}
+}
+
+func TestKubeContextName(t *testing.T) {
+	if got, want := KubeContextName("my-project", "us-central1", "my-cluster"), "gke_my-project_us-central1_my-cluster"; got != want {
+		t.Errorf("KubeContextName() = %q, want %q", got, want)
+	}
+}
+
+func TestRequireKubeContext(t *testing.T) {
+	cluster := &containerpb.Cluster{Name: "my-cluster", Location: "us-central1"}
+
+	ctx := kube.WithKubeContext(context.Background(), "gke_my-project_us-central1_my-cluster")
+	if err := RequireKubeContext(ctx, "my-project", cluster); err != nil {
+		t.Errorf("RequireKubeContext() error = %v", err)
+	}
+
+	ctx = kube.WithKubeContext(context.Background(), "gke_my-project_us-central1_other-cluster")
+	if err := RequireKubeContext(ctx, "my-project", cluster); err == nil {
+		t.Errorf("RequireKubeContext() with another cluster's context returned no error")
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"strings"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+)
+
+// NodePoolLabel is the node label GKE sets to the name of the node pool.
+const NodePoolLabel = "cloud.google.com/gke-nodepool"
+
+// armMachineFamilies are the Arm machine families available on GKE.
+var armMachineFamilies = []string{"t2a", "c4a", "n4a"}
+
+var taintEffects = map[containerpb.NodeTaint_Effect]string{
+	containerpb.NodeTaint_NO_SCHEDULE:        "NoSchedule",
+	containerpb.NodeTaint_PREFER_NO_SCHEDULE: "PreferNoSchedule",
+	containerpb.NodeTaint_NO_EXECUTE:         "NoExecute",
+}
+
+// IsArmMachineType reports whether the machine type has an Arm CPU.
+func IsArmMachineType(machineType string) bool {
+	family, _, _ := strings.Cut(machineType, "-")
+	for _, arm := range armMachineFamilies {
+		if family == arm {
+			return true
+		}
+	}
+	return false
+}
+
+// IsWindowsImageType reports whether the node image type is a Windows Server image.
+func IsWindowsImageType(imageType string) bool {
+	return strings.HasPrefix(strings.ToUpper(imageType), "WINDOWS")
+}
+
+// NodePoolTaints returns the taints GKE applies to the nodes of the node pool:
+// the configured taints and the taints GKE adds for GPUs, GKE Sandbox, Arm and
+// Windows nodes.
+func NodePoolTaints(pool *containerpb.NodePool) []kube.Taint {
+	config := pool.GetConfig()
+	var taints []kube.Taint
+	for _, t := range config.GetTaints() {
+		taints = append(taints, kube.Taint{Key: t.GetKey(), Value: t.GetValue(), Effect: taintEffects[t.GetEffect()]})
+	}
+	if len(config.GetAccelerators()) > 0 {
+		taints = append(taints, kube.Taint{Key: "nvidia.com/gpu", Value: "present", Effect: "NoSchedule"})
+	}
+	if config.GetSandboxConfig().GetType() == containerpb.SandboxConfig_GVISOR {
+		taints = append(taints, kube.Taint{Key: "sandbox.gke.io/runtime", Value: "gvisor", Effect: "NoSchedule"})
+	}
+	if IsArmMachineType(config.GetMachineType()) {
+		taints = append(taints, kube.Taint{Key: "kubernetes.io/arch", Value: "arm64", Effect: "NoSchedule"})
+	}
+	if IsWindowsImageType(config.GetImageType()) {
+		taints = append(taints, kube.Taint{Key: "node.kubernetes.io/os", Value: "windows", Effect: "NoSchedule"})
+	}
+	return taints
+}
+
+// NodePoolLabels returns the well-known labels of the nodes of the node pool
+// together with the configured node labels.
+func NodePoolLabels(pool *containerpb.NodePool) map[string]string {
+	config := pool.GetConfig()
+	labels := map[string]string{
+		NodePoolLabel:      pool.GetName(),
+		"kubernetes.io/os": "linux",
+	}
+	if IsWindowsImageType(config.GetImageType()) {
+		labels["kubernetes.io/os"] = "windows"
+	}
+	labels["kubernetes.io/arch"] = "amd64"
+	if IsArmMachineType(config.GetMachineType()) {
+		labels["kubernetes.io/arch"] = "arm64"
+	}
+	for k, v := range config.GetLabels() {
+		labels[k] = v
+	}
+	return labels
+}
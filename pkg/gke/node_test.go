@@ -0,0 +1,105 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIsArmMachineType(t *testing.T) {
+	for machineType, want := range map[string]bool{
+		"t2a-standard-4":  true,
+		"c4a-highmem-8":   true,
+		"e2-standard-4":   false,
+		"c4-standard-8":   false,
+		"n2d-standard-2":  false,
+		"":                false,
+		"custom-4-16384":  false,
+		"n4a-standard-16": true,
+	} {
+		if got := IsArmMachineType(machineType); got != want {
+			t.Errorf("IsArmMachineType(%q) = %v, want %v", machineType, got, want)
+		}
+	}
+}
+
+func TestNodePoolTaints(t *testing.T) {
+	tests := []struct {
+		name string
+		pool *containerpb.NodePool
+		want []kube.Taint
+	}{
+		{
+			name: "no taints",
+			pool: &containerpb.NodePool{Config: &containerpb.NodeConfig{MachineType: "e2-standard-4"}},
+		},
+		{
+			name: "configured and GPU taints",
+			pool: &containerpb.NodePool{Config: &containerpb.NodeConfig{
+				MachineType:  "g2-standard-8",
+				Taints:       []*containerpb.NodeTaint{{Key: "dedicated", Value: "ml", Effect: containerpb.NodeTaint_NO_EXECUTE}},
+				Accelerators: []*containerpb.AcceleratorConfig{{AcceleratorCount: 1, AcceleratorType: "nvidia-l4"}},
+			}},
+			want: []kube.Taint{
+				{Key: "dedicated", Value: "ml", Effect: "NoExecute"},
+				{Key: "nvidia.com/gpu", Value: "present", Effect: "NoSchedule"},
+			},
+		},
+		{
+			name: "sandbox on arm",
+			pool: &containerpb.NodePool{Config: &containerpb.NodeConfig{
+				MachineType:   "t2a-standard-4",
+				SandboxConfig: &containerpb.SandboxConfig{Type: containerpb.SandboxConfig_GVISOR},
+			}},
+			want: []kube.Taint{
+				{Key: "sandbox.gke.io/runtime", Value: "gvisor", Effect: "NoSchedule"},
+				{Key: "kubernetes.io/arch", Value: "arm64", Effect: "NoSchedule"},
+			},
+		},
+		{
+			name: "windows",
+			pool: &containerpb.NodePool{Config: &containerpb.NodeConfig{ImageType: "WINDOWS_LTSC_CONTAINERD"}},
+			want: []kube.Taint{{Key: "node.kubernetes.io/os", Value: "windows", Effect: "NoSchedule"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, NodePoolTaints(tt.pool)); diff != "" {
+				t.Errorf("NodePoolTaints() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNodePoolLabels(t *testing.T) {
+	pool := &containerpb.NodePool{
+		Name:   "arm-pool",
+		Config: &containerpb.NodeConfig{MachineType: "c4a-standard-4", Labels: map[string]string{"team": "web"}},
+	}
+	want := map[string]string{
+		NodePoolLabel:        "arm-pool",
+		"kubernetes.io/os":   "linux",
+		"kubernetes.io/arch": "arm64",
+		"team":               "web",
+	}
+	if diff := cmp.Diff(want, NodePoolLabels(pool)); diff != "" {
+		t.Errorf("NodePoolLabels() mismatch (-want +got):\n%s", diff)
+	}
+}
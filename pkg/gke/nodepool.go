@@ -0,0 +1,80 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gke provides helpers shared by tools inspecting GKE clusters and node pools.
+package gke
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	compute "google.golang.org/api/compute/v1"
+)
+
+var instanceGroupManagerURLRegexp = regexp.MustCompile(`projects/([^/]+)/zones/([^/]+)/instanceGroupManagers/([^/]+)$`)
+
+// NodePoolSize returns the current number of nodes in the node pool by summing
+// the target sizes of its managed instance groups.
+func NodePoolSize(ctx context.Context, svc *compute.Service, pool *containerpb.NodePool) (int64, error) {
+	var size int64
+	for _, url := range pool.GetInstanceGroupUrls() {
+		project, zone, name, err := parseInstanceGroupManagerURL(url)
+		if err != nil {
+			return 0, err
+		}
+		igm, err := svc.InstanceGroupManagers.Get(project, zone, name).Context(ctx).Do()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get instance group manager %s: %w", name, err)
+		}
+		size += igm.TargetSize
+	}
+	return size, nil
+}
+
+func parseInstanceGroupManagerURL(url string) (project, zone, name string, err error) {
+	m := instanceGroupManagerURLRegexp.FindStringSubmatch(url)
+	if m == nil {
+		return "", "", "", fmt.Errorf("invalid instance group manager URL: %s", url)
+	}
+	return m[1], m[2], m[3], nil
+}
+
+// SurgeNodes returns how many extra nodes a node pool of the given size
+// temporarily needs while it is being upgraded.
+func SurgeNodes(pool *containerpb.NodePool, size int64) int64 {
+	if size == 0 {
+		return 0
+	}
+	settings := pool.GetUpgradeSettings()
+	if settings.GetStrategy() == containerpb.NodePoolUpdateStrategy_BLUE_GREEN {
+		// The whole green pool is created before the blue pool is drained.
+		return size
+	}
+
+	zones := int64(len(pool.GetLocations()))
+	if zones == 0 {
+		zones = 1
+	}
+	// Surge settings are applied per zone.
+	surge := int64(settings.GetMaxSurge()) * zones
+	if settings == nil {
+		surge = zones
+	}
+	if surge > size {
+		surge = size
+	}
+	return surge
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+)
+
+func TestParseInstanceGroupManagerURL(t *testing.T) {
+	project, zone, name, err := parseInstanceGroupManagerURL("https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a/instanceGroupManagers/gke-my-cluster-default-pool-1234-grp")
+	if err != nil {
+		t.Fatalf("parseInstanceGroupManagerURL() error = %v", err)
+	}
+	if project != "my-project" || zone != "us-central1-a" || name != "gke-my-cluster-default-pool-1234-grp" {
+		t.Errorf("parseInstanceGroupManagerURL() = (%s, %s, %s), want (my-project, us-central1-a, gke-my-cluster-default-pool-1234-grp)", project, zone, name)
+	}
+
+	if _, _, _, err := parseInstanceGroupManagerURL("https://example.com/invalid"); err == nil {
+		t.Error("parseInstanceGroupManagerURL() expected error for invalid URL, got nil")
+	}
+}
+
+func TestSurgeNodes(t *testing.T) {
+	tests := []struct {
+		name string
+		pool *containerpb.NodePool
+		size int64
+		want int64
+	}{
+		{
+			name: "empty pool",
+			pool: &containerpb.NodePool{},
+			size: 0,
+			want: 0,
+		},
+		{
+			name: "default settings",
+			pool: &containerpb.NodePool{Locations: []string{"us-central1-a"}},
+			size: 3,
+			want: 1,
+		},
+		{
+			name: "max surge per zone",
+			pool: &containerpb.NodePool{
+				Locations:       []string{"us-central1-a", "us-central1-b", "us-central1-c"},
+				UpgradeSettings: &containerpb.NodePool_UpgradeSettings{MaxSurge: 2},
+			},
+			size: 9,
+			want: 6,
+		},
+		{
+			name: "surge capped by pool size",
+			pool: &containerpb.NodePool{
+				Locations:       []string{"us-central1-a"},
+				UpgradeSettings: &containerpb.NodePool_UpgradeSettings{MaxSurge: 5},
+			},
+			size: 2,
+			want: 2,
+		},
+		{
+			name: "no surge",
+			pool: &containerpb.NodePool{
+				Locations:       []string{"us-central1-a"},
+				UpgradeSettings: &containerpb.NodePool_UpgradeSettings{MaxSurge: 0, MaxUnavailable: 1},
+			},
+			size: 3,
+			want: 0,
+		},
+		{
+			name: "blue-green",
+			pool: &containerpb.NodePool{
+				UpgradeSettings: &containerpb.NodePool_UpgradeSettings{Strategy: containerpb.NodePoolUpdateStrategy_BLUE_GREEN.Enum()},
+			},
+			size: 4,
+			want: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SurgeNodes(tt.pool, tt.size); got != tt.want {
+				t.Errorf("SurgeNodes() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
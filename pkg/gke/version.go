@@ -0,0 +1,81 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// versionParts returns the dot-separated numbers of a Kubernetes or GKE
+// version, without the leading v and the suffix after the first dash, e.g.
+// 1, 30 and 5 of v1.30.5-gke.1014001.
+func versionParts(version string) []string {
+	core, _, _ := strings.Cut(strings.TrimPrefix(strings.TrimSpace(version), "v"), "-")
+	return strings.Split(core, ".")
+}
+
+// MinorVersion parses the major and minor version of a Kubernetes or GKE
+// version such as 1.30, 1.30.5 or 1.30.5-gke.1014001.
+func MinorVersion(version string) (major, minor int, err error) {
+	parts := versionParts(version)
+	if len(parts) < 2 {
+		return 0, 0, fmt.Errorf("invalid version format: %s", version)
+	}
+	major, err = strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("cannot parse major version of %s: %w", version, err)
+	}
+	minor, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("cannot parse minor version of %s: %w", version, err)
+	}
+	return major, minor, nil
+}
+
+// PatchVersion is a Kubernetes patch version, e.g. 1.31.5.
+type PatchVersion struct {
+	Major, Minor, Patch int
+}
+
+// ParsePatchVersion parses the Kubernetes patch version of a Kubernetes or
+// GKE version such as 1.31.5, v1.31.5 or 1.31.5-gke.1169000.
+func ParsePatchVersion(version string) (PatchVersion, error) {
+	parts := versionParts(version)
+	if len(parts) != 3 {
+		return PatchVersion{}, fmt.Errorf("invalid version, want a patch version like 1.31.5 or 1.31.5-gke.1169000: %s", version)
+	}
+	var numbers [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return PatchVersion{}, fmt.Errorf("cannot parse patch version %s: %w", version, err)
+		}
+		numbers[i] = n
+	}
+	return PatchVersion{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, nil
+}
+
+// Less reports whether v is older than o.
+func (v PatchVersion) Less(o PatchVersion) bool {
+	if v.Major != o.Major {
+		return v.Major < o.Major
+	}
+	if v.Minor != o.Minor {
+		return v.Minor < o.Minor
+	}
+	return v.Patch < o.Patch
+}
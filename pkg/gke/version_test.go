@@ -0,0 +1,84 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import "testing"
+
+func TestMinorVersion(t *testing.T) {
+	tests := []struct {
+		version   string
+		wantMajor int
+		wantMinor int
+		wantErr   bool
+	}{
+		{version: "1.30.5-gke.1014001", wantMajor: 1, wantMinor: 30},
+		{version: "1.31", wantMajor: 1, wantMinor: 31},
+		{version: "v1.29.3", wantMajor: 1, wantMinor: 29},
+		{version: "1.32-gke", wantMajor: 1, wantMinor: 32},
+		{version: "latest", wantErr: true},
+		{version: "1.x.2", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			major, minor, err := MinorVersion(tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MinorVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if major != tt.wantMajor || minor != tt.wantMinor {
+				t.Errorf("MinorVersion() = %d.%d, want %d.%d", major, minor, tt.wantMajor, tt.wantMinor)
+			}
+		})
+	}
+}
+
+func TestParsePatchVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    PatchVersion
+		wantErr bool
+	}{
+		{version: "1.31.5-gke.1169000", want: PatchVersion{1, 31, 5}},
+		{version: "v1.32.2", want: PatchVersion{1, 32, 2}},
+		{version: "1.32.0-rc.0", want: PatchVersion{1, 32, 0}},
+		{version: "1.31", wantErr: true},
+		{version: "1.31.x", wantErr: true},
+		{version: "1.31.5.1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := ParsePatchVersion(tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePatchVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePatchVersion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPatchVersionLess(t *testing.T) {
+	if !(PatchVersion{1, 31, 5}).Less(PatchVersion{1, 31, 6}) {
+		t.Errorf("1.31.5 is not older than 1.31.6")
+	}
+	if (PatchVersion{1, 32, 0}).Less(PatchVersion{1, 31, 9}) {
+		t.Errorf("1.32.0 is older than 1.31.9")
+	}
+	if (PatchVersion{1, 31, 5}).Less(PatchVersion{1, 31, 5}) {
+		t.Errorf("1.31.5 is older than itself")
+	}
+}
@@ -19,7 +19,7 @@ import (
 	"bufio"
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -158,7 +158,7 @@ func ClaudeCodeExtension(opts *Options) error {
 	}
 	defer func() {
 		if err := file.Close(); err != nil {
-			log.Printf("Failed to close CLAUDE.md: %v\n", err)
+			slog.Warn("Failed to close CLAUDE.md", "error", err)
 		}
 	}()
 
@@ -18,7 +18,7 @@ package install
 import (
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"path/filepath"
 )
@@ -73,7 +73,7 @@ func CursorMCPExtension(opts *Options) error {
 	mcpServers, ok := config["mcpServers"].(map[string]interface{})
 	if !ok {
 		// Handle the case where mcpServers is not a map
-		log.Printf("Warning: mcpServers in Cursor MCP config is not a map, creating new one")
+		slog.Warn("mcpServers in Cursor MCP config is not a map, creating new one")
 		config["mcpServers"] = make(map[string]interface{})
 		mcpServers = config["mcpServers"].(map[string]interface{})
 	}
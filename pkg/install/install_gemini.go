@@ -18,7 +18,7 @@ package install
 import (
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
@@ -33,7 +33,7 @@ func GeminiCLIExtension(opts *Options) error {
 		if strings.HasPrefix(opts.exePath, os.TempDir()) {
 			return fmt.Errorf("cannot install in developer mode using `go run`. Try again using `go build` and `./gke-mcp`")
 		}
-		log.Printf("version: %s", opts.version)
+		slog.Info("Installing Gemini CLI extension in developer mode", "version", opts.version)
 		contextFilename = filepath.Join(filepath.Dir(opts.exePath), "pkg", "install", "GEMINI.md")
 		// #nosec G304
 		if _, err := os.ReadFile(contextFilename); err != nil {
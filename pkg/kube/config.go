@@ -0,0 +1,87 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+	"sync"
+)
+
+var (
+	kubeconfigMu sync.RWMutex
+	// kubeconfig is the kubeconfig file used by kubectl commands, the default
+	// kubeconfig of the user when empty.
+	kubeconfig string
+)
+
+// SetKubeconfig sets the kubeconfig file used by the kubectl commands run by
+// the server. An empty path restores the default kubeconfig of the user.
+func SetKubeconfig(path string) {
+	kubeconfigMu.Lock()
+	defer kubeconfigMu.Unlock()
+	kubeconfig = path
+}
+
+// Kubeconfig returns the kubeconfig file used by kubectl commands, empty for
+// the default kubeconfig of the user.
+func Kubeconfig() string {
+	kubeconfigMu.RLock()
+	defer kubeconfigMu.RUnlock()
+	return kubeconfig
+}
+
+type kubeconfigKey struct{}
+
+// WithKubeconfig returns a context making the kubectl commands run with it
+// use the given kubeconfig file instead of the one set with SetKubeconfig.
+func WithKubeconfig(ctx context.Context, path string) context.Context {
+	return context.WithValue(ctx, kubeconfigKey{}, path)
+}
+
+// kubeconfigFor returns the kubeconfig file of the kubectl commands run with
+// ctx, empty for the default kubeconfig of the user.
+func kubeconfigFor(ctx context.Context) string {
+	if path, _ := ctx.Value(kubeconfigKey{}).(string); path != "" {
+		return path
+	}
+	return Kubeconfig()
+}
+
+type kubeContextKey struct{}
+
+// WithKubeContext returns a context making the kubectl commands run with it
+// use the given kubeconfig context instead of the current one.
+func WithKubeContext(ctx context.Context, kubeContext string) context.Context {
+	return context.WithValue(ctx, kubeContextKey{}, kubeContext)
+}
+
+// KubeContext returns the kubeconfig context selected with WithKubeContext, if any.
+func KubeContext(ctx context.Context) string {
+	kubeContext, _ := ctx.Value(kubeContextKey{}).(string)
+	return kubeContext
+}
+
+// kubectlArgs returns the arguments of a kubectl command, selecting the
+// kubeconfig file and the kubeconfig context.
+func kubectlArgs(ctx context.Context, args []string) []string {
+	var global []string
+	if path := kubeconfigFor(ctx); path != "" {
+		global = append(global, "--kubeconfig="+path)
+	}
+	if kubeContext := KubeContext(ctx); kubeContext != "" {
+		global = append(global, "--context="+kubeContext)
+	}
+	return append(global, args...)
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestKubectlArgs(t *testing.T) {
+	t.Cleanup(func() { SetKubeconfig("") })
+	ctx := context.Background()
+
+	if got, want := kubectlArgs(ctx, []string{"get", "pods"}), []string{"get", "pods"}; !slices.Equal(got, want) {
+		t.Errorf("kubectlArgs() = %q, want %q", got, want)
+	}
+	SetKubeconfig("/tmp/gke-mcp/kubeconfig")
+	if got, want := kubectlArgs(ctx, []string{"get", "pods"}), []string{"--kubeconfig=/tmp/gke-mcp/kubeconfig", "get", "pods"}; !slices.Equal(got, want) {
+		t.Errorf("kubectlArgs() = %q, want %q", got, want)
+	}
+}
+
+func TestKubectlArgs_Kubeconfig(t *testing.T) {
+	t.Cleanup(func() { SetKubeconfig("") })
+	SetKubeconfig("/tmp/gke-mcp/kubeconfig")
+
+	ctx := WithKubeconfig(context.Background(), "/tmp/gke-mcp/session/kubeconfig")
+	if got, want := kubectlArgs(ctx, []string{"get", "pods"}), []string{"--kubeconfig=/tmp/gke-mcp/session/kubeconfig", "get", "pods"}; !slices.Equal(got, want) {
+		t.Errorf("kubectlArgs() = %q, want %q", got, want)
+	}
+}
+
+func TestKubectlArgs_KubeContext(t *testing.T) {
+	ctx := WithKubeContext(context.Background(), "gke_my-project_us-central1_my-cluster")
+	if got := KubeContext(ctx); got != "gke_my-project_us-central1_my-cluster" {
+		t.Errorf("KubeContext() = %q, want gke_my-project_us-central1_my-cluster", got)
+	}
+	if got, want := kubectlArgs(ctx, []string{"get", "pods"}), []string{"--context=gke_my-project_us-central1_my-cluster", "get", "pods"}; !slices.Equal(got, want) {
+		t.Errorf("kubectlArgs() = %q, want %q", got, want)
+	}
+	if got, err := CurrentContext(ctx); err != nil || got != "gke_my-project_us-central1_my-cluster" {
+		t.Errorf("CurrentContext() = %q, %v, want the selected context", got, err)
+	}
+}
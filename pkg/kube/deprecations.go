@@ -0,0 +1,123 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+// DeprecatedAPI is a Kubernetes API version of a kind which is removed in a
+// Kubernetes minor version.
+type DeprecatedAPI struct {
+	APIVersion string
+	Kind       string
+	// Resource is the resource name used to list objects of the kind with kubectl.
+	Resource string
+	// RemovedIn is the Kubernetes minor version of the 1.x release removing the API.
+	RemovedIn int
+	// Replacement is the API version to migrate to, empty if there is none.
+	Replacement string
+	// Notes are the notable changes of the migration to the replacement.
+	Notes string
+}
+
+// DeprecatedAPIs are the removed Kubernetes APIs, following the Kubernetes
+// deprecated API migration guide.
+var DeprecatedAPIs = []DeprecatedAPI{
+	{APIVersion: "extensions/v1beta1", Kind: "DaemonSet", Resource: "daemonsets", RemovedIn: 16, Replacement: "apps/v1", Notes: "spec.templateGeneration is removed; spec.selector is required and immutable after creation; spec.updateStrategy.type defaults to RollingUpdate."},
+	{APIVersion: "extensions/v1beta1", Kind: "Deployment", Resource: "deployments", RemovedIn: 16, Replacement: "apps/v1", Notes: "spec.rollbackTo is removed; spec.selector is required and immutable after creation; spec.progressDeadlineSeconds defaults to 600, spec.revisionHistoryLimit to 10 and maxSurge and maxUnavailable to 25%."},
+	{APIVersion: "extensions/v1beta1", Kind: "ReplicaSet", Resource: "replicasets", RemovedIn: 16, Replacement: "apps/v1", Notes: "spec.selector is required and immutable after creation."},
+	{APIVersion: "extensions/v1beta1", Kind: "NetworkPolicy", Resource: "networkpolicies", RemovedIn: 16, Replacement: "networking.k8s.io/v1"},
+	{APIVersion: "apps/v1beta1", Kind: "Deployment", Resource: "deployments", RemovedIn: 16, Replacement: "apps/v1", Notes: "spec.rollbackTo is removed; spec.selector is required and immutable after creation; spec.progressDeadlineSeconds defaults to 600, spec.revisionHistoryLimit to 10 and maxSurge and maxUnavailable to 25%."},
+	{APIVersion: "apps/v1beta1", Kind: "StatefulSet", Resource: "statefulsets", RemovedIn: 16, Replacement: "apps/v1", Notes: "spec.selector is required and immutable after creation; spec.updateStrategy.type defaults to RollingUpdate."},
+	{APIVersion: "apps/v1beta2", Kind: "DaemonSet", Resource: "daemonsets", RemovedIn: 16, Replacement: "apps/v1", Notes: "spec.templateGeneration is removed; spec.selector is required and immutable after creation; spec.updateStrategy.type defaults to RollingUpdate."},
+	{APIVersion: "apps/v1beta2", Kind: "Deployment", Resource: "deployments", RemovedIn: 16, Replacement: "apps/v1", Notes: "spec.rollbackTo is removed; spec.selector is required and immutable after creation; spec.progressDeadlineSeconds defaults to 600, spec.revisionHistoryLimit to 10 and maxSurge and maxUnavailable to 25%."},
+	{APIVersion: "apps/v1beta2", Kind: "ReplicaSet", Resource: "replicasets", RemovedIn: 16, Replacement: "apps/v1", Notes: "spec.selector is required and immutable after creation."},
+	{APIVersion: "apps/v1beta2", Kind: "StatefulSet", Resource: "statefulsets", RemovedIn: 16, Replacement: "apps/v1", Notes: "spec.selector is required and immutable after creation; spec.updateStrategy.type defaults to RollingUpdate."},
+	{APIVersion: "extensions/v1beta1", Kind: "Ingress", Resource: "ingresses", RemovedIn: 22, Replacement: "networking.k8s.io/v1", Notes: "spec.backend is renamed to spec.defaultBackend; the serviceName field of backends is renamed to service.name and servicePort to service.port.name or service.port.number; pathType is required for each path."},
+	{APIVersion: "networking.k8s.io/v1beta1", Kind: "Ingress", Resource: "ingresses", RemovedIn: 22, Replacement: "networking.k8s.io/v1", Notes: "spec.backend is renamed to spec.defaultBackend; the serviceName field of backends is renamed to service.name and servicePort to service.port.name or service.port.number; pathType is required for each path."},
+	{APIVersion: "networking.k8s.io/v1beta1", Kind: "IngressClass", Resource: "ingressclasses", RemovedIn: 22, Replacement: "networking.k8s.io/v1"},
+	{APIVersion: "admissionregistration.k8s.io/v1beta1", Kind: "MutatingWebhookConfiguration", Resource: "mutatingwebhookconfigurations", RemovedIn: 22, Replacement: "admissionregistration.k8s.io/v1", Notes: "failurePolicy defaults to Fail instead of Ignore, matchPolicy to Equivalent instead of Exact and timeoutSeconds to 10s instead of 30s; sideEffects and admissionReviewVersions are required; reinvocationPolicy is added."},
+	{APIVersion: "admissionregistration.k8s.io/v1beta1", Kind: "ValidatingWebhookConfiguration", Resource: "validatingwebhookconfigurations", RemovedIn: 22, Replacement: "admissionregistration.k8s.io/v1", Notes: "failurePolicy defaults to Fail instead of Ignore, matchPolicy to Equivalent instead of Exact and timeoutSeconds to 10s instead of 30s; sideEffects and admissionReviewVersions are required."},
+	{APIVersion: "apiextensions.k8s.io/v1beta1", Kind: "CustomResourceDefinition", Resource: "customresourcedefinitions", RemovedIn: 22, Replacement: "apiextensions.k8s.io/v1", Notes: "spec.scope is required; spec.preserveUnknownFields: true is disallowed; spec.version is removed, use spec.versions; spec.validation, spec.subresources and spec.additionalPrinterColumns move to each item of spec.versions; spec.conversion.webhookClientConfig moves to spec.conversion.webhook.clientConfig and conversionReviewVersions is required for webhook conversion."},
+	{APIVersion: "apiregistration.k8s.io/v1beta1", Kind: "APIService", Resource: "apiservices", RemovedIn: 22, Replacement: "apiregistration.k8s.io/v1"},
+	{APIVersion: "certificates.k8s.io/v1beta1", Kind: "CertificateSigningRequest", Resource: "certificatesigningrequests", RemovedIn: 22, Replacement: "certificates.k8s.io/v1", Notes: "spec.signerName is required, and requests for kubernetes.io/legacy-unknown can't be created; spec.usages is required; status.certificate must be PEM-encoded and contain only CERTIFICATE blocks."},
+	{APIVersion: "coordination.k8s.io/v1beta1", Kind: "Lease", Resource: "leases", RemovedIn: 22, Replacement: "coordination.k8s.io/v1"},
+	{APIVersion: "rbac.authorization.k8s.io/v1beta1", Kind: "ClusterRole", Resource: "clusterroles", RemovedIn: 22, Replacement: "rbac.authorization.k8s.io/v1"},
+	{APIVersion: "rbac.authorization.k8s.io/v1beta1", Kind: "ClusterRoleBinding", Resource: "clusterrolebindings", RemovedIn: 22, Replacement: "rbac.authorization.k8s.io/v1"},
+	{APIVersion: "rbac.authorization.k8s.io/v1beta1", Kind: "Role", Resource: "roles", RemovedIn: 22, Replacement: "rbac.authorization.k8s.io/v1"},
+	{APIVersion: "rbac.authorization.k8s.io/v1beta1", Kind: "RoleBinding", Resource: "rolebindings", RemovedIn: 22, Replacement: "rbac.authorization.k8s.io/v1"},
+	{APIVersion: "scheduling.k8s.io/v1beta1", Kind: "PriorityClass", Resource: "priorityclasses", RemovedIn: 22, Replacement: "scheduling.k8s.io/v1"},
+	{APIVersion: "storage.k8s.io/v1beta1", Kind: "CSIDriver", Resource: "csidrivers", RemovedIn: 22, Replacement: "storage.k8s.io/v1"},
+	{APIVersion: "storage.k8s.io/v1beta1", Kind: "CSINode", Resource: "csinodes", RemovedIn: 22, Replacement: "storage.k8s.io/v1"},
+	{APIVersion: "storage.k8s.io/v1beta1", Kind: "StorageClass", Resource: "storageclasses", RemovedIn: 22, Replacement: "storage.k8s.io/v1"},
+	{APIVersion: "storage.k8s.io/v1beta1", Kind: "VolumeAttachment", Resource: "volumeattachments", RemovedIn: 22, Replacement: "storage.k8s.io/v1"},
+	{APIVersion: "batch/v1beta1", Kind: "CronJob", Resource: "cronjobs", RemovedIn: 25, Replacement: "batch/v1"},
+	{APIVersion: "discovery.k8s.io/v1beta1", Kind: "EndpointSlice", Resource: "endpointslices", RemovedIn: 25, Replacement: "discovery.k8s.io/v1", Notes: "The kubernetes.io/hostname topology key of endpoints is replaced by the nodeName field and the topology.kubernetes.io/zone key by the zone field; topology is replaced by deprecatedTopology, which isn't writable."},
+	{APIVersion: "events.k8s.io/v1beta1", Kind: "Event", Resource: "events", RemovedIn: 25, Replacement: "events.k8s.io/v1", Notes: "type is limited to Normal and Warning; involvedObject is renamed to regarding; action, reason, reportingController and reportingInstance are required when creating events; use eventTime instead of firstTimestamp, series.lastObservedTime instead of lastTimestamp and series.count instead of count; source is replaced by reportingController and reportingInstance."},
+	{APIVersion: "autoscaling/v2beta1", Kind: "HorizontalPodAutoscaler", Resource: "horizontalpodautoscalers", RemovedIn: 25, Replacement: "autoscaling/v2", Notes: "targetAverageUtilization is replaced by target.averageUtilization and target.type: Utilization, and targetAverageValue by target.averageValue and target.type: AverageValue."},
+	{APIVersion: "policy/v1beta1", Kind: "PodDisruptionBudget", Resource: "poddisruptionbudgets", RemovedIn: 25, Replacement: "policy/v1", Notes: "An empty spec.selector selects all the pods of the namespace instead of none."},
+	{APIVersion: "policy/v1beta1", Kind: "PodSecurityPolicy", Resource: "podsecuritypolicies", RemovedIn: 25, Notes: "Removed without a replacement API; migrate to Pod Security Admission or a third-party admission webhook."},
+	{APIVersion: "node.k8s.io/v1beta1", Kind: "RuntimeClass", Resource: "runtimeclasses", RemovedIn: 25, Replacement: "node.k8s.io/v1"},
+	{APIVersion: "flowcontrol.apiserver.k8s.io/v1beta1", Kind: "FlowSchema", Resource: "flowschemas", RemovedIn: 26, Replacement: "flowcontrol.apiserver.k8s.io/v1"},
+	{APIVersion: "flowcontrol.apiserver.k8s.io/v1beta1", Kind: "PriorityLevelConfiguration", Resource: "prioritylevelconfigurations", RemovedIn: 26, Replacement: "flowcontrol.apiserver.k8s.io/v1"},
+	{APIVersion: "autoscaling/v2beta2", Kind: "HorizontalPodAutoscaler", Resource: "horizontalpodautoscalers", RemovedIn: 26, Replacement: "autoscaling/v2"},
+	{APIVersion: "storage.k8s.io/v1beta1", Kind: "CSIStorageCapacity", Resource: "csistoragecapacities", RemovedIn: 27, Replacement: "storage.k8s.io/v1"},
+	{APIVersion: "flowcontrol.apiserver.k8s.io/v1beta2", Kind: "FlowSchema", Resource: "flowschemas", RemovedIn: 29, Replacement: "flowcontrol.apiserver.k8s.io/v1"},
+	{APIVersion: "flowcontrol.apiserver.k8s.io/v1beta2", Kind: "PriorityLevelConfiguration", Resource: "prioritylevelconfigurations", RemovedIn: 29, Replacement: "flowcontrol.apiserver.k8s.io/v1", Notes: "spec.limited.assuredConcurrencyShares is renamed to spec.limited.nominalConcurrencyShares."},
+	{APIVersion: "flowcontrol.apiserver.k8s.io/v1beta3", Kind: "FlowSchema", Resource: "flowschemas", RemovedIn: 32, Replacement: "flowcontrol.apiserver.k8s.io/v1"},
+	{APIVersion: "flowcontrol.apiserver.k8s.io/v1beta3", Kind: "PriorityLevelConfiguration", Resource: "prioritylevelconfigurations", RemovedIn: 32, Replacement: "flowcontrol.apiserver.k8s.io/v1", Notes: "spec.limited.nominalConcurrencyShares defaults to 30 only when unspecified, and an explicit 0 isn't changed to 30."},
+}
+
+// RemovedAPIs returns the deprecated APIs removed after the current minor
+// version, up to and including the target minor version.
+func RemovedAPIs(currentMinor, targetMinor int) []DeprecatedAPI {
+	var removed []DeprecatedAPI
+	for _, api := range DeprecatedAPIs {
+		if api.RemovedIn > currentMinor && api.RemovedIn <= targetMinor {
+			removed = append(removed, api)
+		}
+	}
+	return removed
+}
+
+// InTreeVolumePlugin is a volume plugin built into Kubernetes which is removed
+// in favor of a CSI driver.
+type InTreeVolumePlugin struct {
+	// Name is the volume source field of the plugin in PersistentVolume specs.
+	Name string
+	// Provisioner is the provisioner of StorageClasses using the plugin.
+	Provisioner string
+	// RemovedIn is the Kubernetes minor version of the 1.x release removing the plugin.
+	RemovedIn int
+	// CSIDriver is the CSI driver replacing the plugin.
+	CSIDriver string
+	// Migrated reports whether volumes of the plugin keep working after the
+	// removal through CSI migration, provided the CSI driver is installed.
+	Migrated bool
+}
+
+// InTreeVolumePlugins are the removed in-tree volume plugins, following the
+// Kubernetes CSI migration and in-tree plugin removal announcements.
+var InTreeVolumePlugins = []InTreeVolumePlugin{
+	{Name: "flocker", RemovedIn: 25},
+	{Name: "quobyte", RemovedIn: 25},
+	{Name: "storageos", RemovedIn: 25},
+	{Name: "glusterfs", Provisioner: "kubernetes.io/glusterfs", RemovedIn: 26},
+	{Name: "cinder", Provisioner: "kubernetes.io/cinder", RemovedIn: 26, CSIDriver: "cinder.csi.openstack.org", Migrated: true},
+	{Name: "awsElasticBlockStore", Provisioner: "kubernetes.io/aws-ebs", RemovedIn: 27, CSIDriver: "ebs.csi.aws.com", Migrated: true},
+	{Name: "azureDisk", Provisioner: "kubernetes.io/azure-disk", RemovedIn: 27, CSIDriver: "disk.csi.azure.com", Migrated: true},
+	{Name: "gcePersistentDisk", Provisioner: "kubernetes.io/gce-pd", RemovedIn: 28, CSIDriver: "pd.csi.storage.gke.io", Migrated: true},
+	{Name: "azureFile", Provisioner: "kubernetes.io/azure-file", RemovedIn: 30, CSIDriver: "file.csi.azure.com", Migrated: true},
+	{Name: "vsphereVolume", Provisioner: "kubernetes.io/vsphere-volume", RemovedIn: 30, CSIDriver: "csi.vsphere.vmware.com", Migrated: true},
+	{Name: "cephfs", RemovedIn: 31},
+	{Name: "rbd", Provisioner: "kubernetes.io/rbd", RemovedIn: 31},
+}
@@ -0,0 +1,32 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import "testing"
+
+func TestRemovedAPIs(t *testing.T) {
+	removed := RemovedAPIs(24, 25)
+	if len(removed) == 0 {
+		t.Fatal("RemovedAPIs(24, 25) returned no APIs")
+	}
+	for _, api := range removed {
+		if api.RemovedIn != 25 {
+			t.Errorf("RemovedAPIs(24, 25) returned %s %s removed in 1.%d", api.APIVersion, api.Kind, api.RemovedIn)
+		}
+	}
+	if removed := RemovedAPIs(30, 30); len(removed) != 0 {
+		t.Errorf("RemovedAPIs(30, 30) = %v, want none", removed)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kube provides minimal Kubernetes object types and helpers to read
+// them from the cluster of the current kubectl context.
+package kube
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/metrics"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Run runs kubectl with the given arguments and returns its standard output.
+// The standard error of failed commands is included in the returned error.
+// In read-only mode, commands which can modify the cluster are refused, and
+// so are commands on clusters of projects the context doesn't allow.
+func Run(ctx context.Context, args ...string) ([]byte, error) {
+	if err := checkReadOnly(args); err != nil {
+		return nil, err
+	}
+	if err := checkProject(ctx, args); err != nil {
+		return nil, err
+	}
+	ctx, span := tracing.Start(ctx, "kubectl", attribute.String("kubectl.args", strings.Join(args, " ")))
+	start := time.Now()
+	out, err := run(ctx, args)
+	metrics.ObserveExternal("kubectl", start, err)
+	tracing.End(span, err)
+	return out, err
+}
+
+func run(ctx context.Context, args []string) ([]byte, error) {
+	// #nosec G204
+	out, err := exec.CommandContext(ctx, "kubectl", kubectlArgs(ctx, args)...).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("failed to run kubectl %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("failed to run kubectl %s: %w", strings.Join(args, " "), err)
+	}
+	return out, nil
+}
+
+// Get runs `kubectl get` with the given arguments and decodes the JSON output into v.
+func Get(ctx context.Context, v any, args ...string) error {
+	getArgs := append([]string{"get"}, args...)
+	getArgs = append(getArgs, "-o", "json")
+	out, err := Run(ctx, getArgs...)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(out, v); err != nil {
+		return fmt.Errorf("failed to parse kubectl get %s output: %w", strings.Join(args, " "), err)
+	}
+	return nil
+}
+
+// CurrentContext returns the name of the kubeconfig context kubectl commands
+// run with ctx use.
+func CurrentContext(ctx context.Context) (string, error) {
+	if kubeContext := KubeContext(ctx); kubeContext != "" {
+		return kubeContext, nil
+	}
+	out, err := Run(ctx, "config", "current-context")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
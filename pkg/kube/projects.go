@@ -0,0 +1,79 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// contextOverrideFlags are the kubectl flags selecting another cluster than
+// the one of the kubeconfig context.
+var contextOverrideFlags = []string{"--context", "--kubeconfig", "--cluster", "--server", "-s", "--token", "--user"}
+
+type projectCheckKey struct{}
+
+// WithProjectCheck returns a context restricting the kubectl commands run
+// with it to the GKE clusters of the projects accepted by allowed. The
+// project is read from the name of the kubeconfig context, so only the
+// contexts named by gcloud and get_credentials, gke_<project>_<location>_<cluster>,
+// can be used.
+func WithProjectCheck(ctx context.Context, allowed func(projectID string) bool) context.Context {
+	return context.WithValue(ctx, projectCheckKey{}, allowed)
+}
+
+// ContextProject returns the project of a kubeconfig context named by gcloud,
+// empty if the context is not named after a GKE cluster.
+func ContextProject(kubeContext string) string {
+	parts := strings.SplitN(kubeContext, "_", 4)
+	if len(parts) != 4 || parts[0] != "gke" {
+		return ""
+	}
+	return parts[1]
+}
+
+// checkProject returns an error if the kubectl arguments run against a
+// cluster outside of the projects allowed by the context.
+func checkProject(ctx context.Context, args []string) error {
+	allowed, ok := ctx.Value(projectCheckKey{}).(func(string) bool)
+	if !ok {
+		return nil
+	}
+	// The kubeconfig can be read, reading the current context needs it too.
+	if len(args) >= 2 && args[0] == "config" && slices.Contains([]string{"current-context", "get-contexts", "view"}, args[1]) {
+		return nil
+	}
+	for _, arg := range args {
+		name, _, _ := strings.Cut(arg, "=")
+		if slices.Contains(contextOverrideFlags, name) {
+			return fmt.Errorf("kubectl flag %s is not allowed, select the cluster with use_kube_context or get_credentials", name)
+		}
+	}
+
+	kubeContext, err := CurrentContext(ctx)
+	if err != nil {
+		return err
+	}
+	project := ContextProject(kubeContext)
+	if project == "" {
+		return fmt.Errorf("kubeconfig context %s is not a GKE cluster context, fetch the credentials of the cluster with get_credentials", kubeContext)
+	}
+	if !allowed(project) {
+		return fmt.Errorf("access to the clusters of project %s is not allowed", project)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+	"testing"
+)
+
+func TestContextProject(t *testing.T) {
+	tests := map[string]string{
+		"gke_my-project_us-central1_my-cluster": "my-project",
+		"gke_my-project_us-central1":            "",
+		"minikube":                              "",
+	}
+	for kubeContext, want := range tests {
+		if got := ContextProject(kubeContext); got != want {
+			t.Errorf("ContextProject(%q) = %q, want %q", kubeContext, got, want)
+		}
+	}
+}
+
+func TestCheckProject(t *testing.T) {
+	allowed := func(projectID string) bool { return projectID == "my-project" }
+
+	if err := checkProject(context.Background(), []string{"get", "pods"}); err != nil {
+		t.Errorf("checkProject() without a project check error = %v", err)
+	}
+
+	ctx := WithProjectCheck(WithKubeContext(context.Background(), "gke_my-project_us-central1_my-cluster"), allowed)
+	if err := checkProject(ctx, []string{"get", "pods"}); err != nil {
+		t.Errorf("checkProject() in an allowed project error = %v", err)
+	}
+	if err := checkProject(ctx, []string{"get", "pods", "--context=gke_other-project_us-central1_my-cluster"}); err == nil {
+		t.Errorf("checkProject() with --context returned no error")
+	}
+	if err := checkProject(ctx, []string{"--kubeconfig", "/tmp/kubeconfig", "get", "pods"}); err == nil {
+		t.Errorf("checkProject() with --kubeconfig returned no error")
+	}
+
+	ctx = WithProjectCheck(WithKubeContext(context.Background(), "gke_other-project_us-central1_my-cluster"), allowed)
+	if err := checkProject(ctx, []string{"get", "pods"}); err == nil {
+		t.Errorf("checkProject() in another project returned no error")
+	}
+	if err := checkProject(ctx, []string{"config", "view", "--output=json"}); err != nil {
+		t.Errorf("checkProject() of config view error = %v", err)
+	}
+
+	ctx = WithProjectCheck(WithKubeContext(context.Background(), "minikube"), allowed)
+	if err := checkProject(ctx, []string{"get", "pods"}); err == nil {
+		t.Errorf("checkProject() in a non-GKE context returned no error")
+	}
+}
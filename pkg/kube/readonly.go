@@ -0,0 +1,62 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+// readOnly makes Run refuse the kubectl commands which can modify the cluster or the kubeconfig.
+var readOnly atomic.Bool
+
+// readOnlyCommands are the kubectl commands allowed in read-only mode.
+var readOnlyCommands = [][]string{
+	{"api-resources"},
+	{"api-versions"},
+	{"auth", "can-i"},
+	{"cluster-info"},
+	{"config", "current-context"},
+	{"config", "get-contexts"},
+	{"config", "view"},
+	{"describe"},
+	{"explain"},
+	{"get"},
+	{"logs"},
+	{"top"},
+	{"version"},
+}
+
+// SetReadOnly enables or disables the read-only mode, in which Run only runs
+// the kubectl commands reading the cluster or the kubeconfig.
+func SetReadOnly(enabled bool) {
+	readOnly.Store(enabled)
+}
+
+// checkReadOnly returns an error if the read-only mode is enabled and the
+// kubectl arguments are not a read-only command.
+func checkReadOnly(args []string) error {
+	if !readOnly.Load() {
+		return nil
+	}
+	for _, command := range readOnlyCommands {
+		if len(args) >= len(command) && slices.Equal(args[:len(command)], command) {
+			return nil
+		}
+	}
+	return fmt.Errorf("kubectl %s is not allowed in read-only mode", strings.Join(args, " "))
+}
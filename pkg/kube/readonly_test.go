@@ -0,0 +1,55 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCheckReadOnly(t *testing.T) {
+	t.Cleanup(func() { SetReadOnly(false) })
+
+	if err := checkReadOnly([]string{"delete", "pod", "web"}); err != nil {
+		t.Errorf("checkReadOnly() error = %v, want nil when read-only mode is disabled", err)
+	}
+
+	SetReadOnly(true)
+	for _, args := range [][]string{
+		{"get", "pods", "-o", "json"},
+		{"config", "view", "--output=json"},
+		{"auth", "can-i", "list", "pods"},
+		{"version", "--output=yaml"},
+	} {
+		if err := checkReadOnly(args); err != nil {
+			t.Errorf("checkReadOnly(%q) error = %v, want nil", args, err)
+		}
+	}
+	for _, args := range [][]string{
+		{"delete", "pod", "web"},
+		{"apply", "-f", "manifest.yaml"},
+		{"config", "use-context", "prod"},
+		{"exec", "web", "--", "sh"},
+		{"auth", "reconcile"},
+		{},
+	} {
+		if err := checkReadOnly(args); err == nil {
+			t.Errorf("checkReadOnly(%q) returned no error", args)
+		}
+	}
+	if _, err := Run(context.Background(), "delete", "pod", "web"); err == nil {
+		t.Errorf("Run() of a mutating command returned no error in read-only mode")
+	}
+}
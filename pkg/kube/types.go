@@ -0,0 +1,712 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// List is the result of listing objects with kubectl.
+type List[T any] struct {
+	Items []T `json:"items"`
+}
+
+// Object is a Kubernetes object of any kind.
+type Object struct {
+	APIVersion        string `json:"apiVersion"`
+	Kind              string `json:"kind"`
+	metav1.ObjectMeta `json:"metadata"`
+}
+
+// Secret is a core/v1 Secret.
+type Secret struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Type              string            `json:"type,omitempty"`
+	Data              map[string][]byte `json:"data,omitempty"`
+}
+
+// PodDisruptionBudget is a policy/v1 PodDisruptionBudget.
+type PodDisruptionBudget struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              PodDisruptionBudgetSpec   `json:"spec"`
+	Status            PodDisruptionBudgetStatus `json:"status"`
+}
+
+// PodDisruptionBudgetSpec is the spec of a PodDisruptionBudget.
+type PodDisruptionBudgetSpec struct {
+	Selector       *metav1.LabelSelector `json:"selector,omitempty"`
+	MinAvailable   *intstr.IntOrString   `json:"minAvailable,omitempty"`
+	MaxUnavailable *intstr.IntOrString   `json:"maxUnavailable,omitempty"`
+}
+
+// PodDisruptionBudgetStatus is the status of a PodDisruptionBudget.
+type PodDisruptionBudgetStatus struct {
+	DisruptionsAllowed int32 `json:"disruptionsAllowed"`
+	CurrentHealthy     int32 `json:"currentHealthy"`
+	DesiredHealthy     int32 `json:"desiredHealthy"`
+	ExpectedPods       int32 `json:"expectedPods"`
+}
+
+// Node is a core/v1 Node.
+type Node struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              NodeSpec   `json:"spec"`
+	Status            NodeStatus `json:"status"`
+}
+
+// NodeSpec is the spec of a Node.
+type NodeSpec struct {
+	Taints        []Taint `json:"taints,omitempty"`
+	Unschedulable bool    `json:"unschedulable,omitempty"`
+}
+
+// NodeStatus is the status of a Node.
+type NodeStatus struct {
+	NodeInfo   NodeSystemInfo `json:"nodeInfo"`
+	Conditions []Condition    `json:"conditions,omitempty"`
+}
+
+// NodeSystemInfo describes the software running on a Node.
+type NodeSystemInfo struct {
+	KernelVersion           string `json:"kernelVersion"`
+	OSImage                 string `json:"osImage"`
+	ContainerRuntimeVersion string `json:"containerRuntimeVersion"`
+	KubeletVersion          string `json:"kubeletVersion"`
+	OperatingSystem         string `json:"operatingSystem"`
+	Architecture            string `json:"architecture"`
+}
+
+// Taint is a core/v1 node taint.
+type Taint struct {
+	Key    string `json:"key"`
+	Value  string `json:"value,omitempty"`
+	Effect string `json:"effect"`
+}
+
+// Pod is a core/v1 Pod.
+type Pod struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              PodSpec   `json:"spec"`
+	Status            PodStatus `json:"status"`
+}
+
+// PodSpec is the spec of a Pod.
+type PodSpec struct {
+	NodeName          string            `json:"nodeName,omitempty"`
+	NodeSelector      map[string]string `json:"nodeSelector,omitempty"`
+	InitContainers    []Container       `json:"initContainers,omitempty"`
+	Containers        []Container       `json:"containers,omitempty"`
+	Volumes           []Volume          `json:"volumes,omitempty"`
+	Tolerations       []Toleration      `json:"tolerations,omitempty"`
+	PriorityClassName string            `json:"priorityClassName,omitempty"`
+	RestartPolicy     string            `json:"restartPolicy,omitempty"`
+	HostNetwork       bool              `json:"hostNetwork,omitempty"`
+	HostPID           bool              `json:"hostPID,omitempty"`
+	HostIPC           bool              `json:"hostIPC,omitempty"`
+	// TopologySpreadConstraints spread the pods across topology domains.
+	TopologySpreadConstraints []TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	Affinity                  *Affinity                  `json:"affinity,omitempty"`
+}
+
+// TopologySpreadConstraint limits the skew of the pods across the domains of
+// a topology key, e.g. zones.
+type TopologySpreadConstraint struct {
+	MaxSkew           int32                 `json:"maxSkew"`
+	TopologyKey       string                `json:"topologyKey"`
+	WhenUnsatisfiable string                `json:"whenUnsatisfiable"`
+	LabelSelector     *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	MinDomains        *int32                `json:"minDomains,omitempty"`
+}
+
+// Affinity is the scheduling affinity of a pod. Only pod anti-affinity is
+// decoded.
+type Affinity struct {
+	PodAntiAffinity *PodAntiAffinity `json:"podAntiAffinity,omitempty"`
+}
+
+// PodAntiAffinity keeps pods away from the pods matching its terms.
+type PodAntiAffinity struct {
+	RequiredDuringSchedulingIgnoredDuringExecution []PodAffinityTerm `json:"requiredDuringSchedulingIgnoredDuringExecution,omitempty"`
+}
+
+// PodAffinityTerm selects the pods in the same domain of a topology key.
+type PodAffinityTerm struct {
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	TopologyKey   string                `json:"topologyKey"`
+}
+
+// Container is a core/v1 container.
+type Container struct {
+	Name            string               `json:"name"`
+	Image           string               `json:"image,omitempty"`
+	Resources       ResourceRequirements `json:"resources,omitempty"`
+	Ports           []ContainerPort      `json:"ports,omitempty"`
+	VolumeMounts    []VolumeMount        `json:"volumeMounts,omitempty"`
+	SecurityContext *SecurityContext     `json:"securityContext,omitempty"`
+}
+
+// ContainerPort is a port exposed by a container.
+type ContainerPort struct {
+	ContainerPort int32 `json:"containerPort"`
+	HostPort      int32 `json:"hostPort,omitempty"`
+}
+
+// VolumeMount mounts a volume of the pod into a container.
+type VolumeMount struct {
+	Name      string `json:"name"`
+	MountPath string `json:"mountPath"`
+	ReadOnly  bool   `json:"readOnly,omitempty"`
+}
+
+// SecurityContext is the security context of a container. Only the fields
+// granting host privileges are decoded.
+type SecurityContext struct {
+	Privileged   *bool         `json:"privileged,omitempty"`
+	Capabilities *Capabilities `json:"capabilities,omitempty"`
+}
+
+// Capabilities are the Linux capabilities added to and dropped from a
+// container.
+type Capabilities struct {
+	Add  []string `json:"add,omitempty"`
+	Drop []string `json:"drop,omitempty"`
+}
+
+// ResourceRequirements are the compute resources requested by and limiting a container.
+type ResourceRequirements struct {
+	Limits   map[string]resource.Quantity `json:"limits,omitempty"`
+	Requests map[string]resource.Quantity `json:"requests,omitempty"`
+}
+
+// PodStatus is the status of a Pod.
+type PodStatus struct {
+	Phase      string      `json:"phase,omitempty"`
+	QOSClass   string      `json:"qosClass,omitempty"`
+	Conditions []Condition `json:"conditions,omitempty"`
+	// ContainerStatuses are the statuses of the containers, except the init
+	// containers.
+	ContainerStatuses []ContainerStatus `json:"containerStatuses,omitempty"`
+}
+
+// ContainerStatus is the status of a container of a Pod.
+type ContainerStatus struct {
+	Name         string `json:"name"`
+	Ready        bool   `json:"ready"`
+	RestartCount int32  `json:"restartCount"`
+	State        struct {
+		Waiting *struct {
+			Reason string `json:"reason,omitempty"`
+		} `json:"waiting,omitempty"`
+	} `json:"state"`
+}
+
+// Volume is a core/v1 pod volume. Only the volume sources relevant to node
+// drains are decoded.
+type Volume struct {
+	Name                  string                             `json:"name"`
+	EmptyDir              *EmptyDirVolumeSource              `json:"emptyDir,omitempty"`
+	HostPath              *HostPathVolumeSource              `json:"hostPath,omitempty"`
+	PersistentVolumeClaim *PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"`
+}
+
+// EmptyDirVolumeSource is an emptyDir volume.
+type EmptyDirVolumeSource struct {
+	Medium string `json:"medium,omitempty"`
+}
+
+// HostPathVolumeSource is a hostPath volume.
+type HostPathVolumeSource struct {
+	Path string `json:"path"`
+}
+
+// PersistentVolumeClaimVolumeSource references a PersistentVolumeClaim.
+type PersistentVolumeClaimVolumeSource struct {
+	ClaimName string `json:"claimName"`
+}
+
+// Toleration is a core/v1 pod toleration.
+type Toleration struct {
+	Key      string `json:"key,omitempty"`
+	Operator string `json:"operator,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Effect   string `json:"effect,omitempty"`
+}
+
+// Tolerates reports whether the toleration tolerates the taint.
+func (t Toleration) Tolerates(taint Taint) bool {
+	if t.Effect != "" && t.Effect != taint.Effect {
+		return false
+	}
+	if t.Operator == "Exists" {
+		return t.Key == "" || t.Key == taint.Key
+	}
+	return t.Key == taint.Key && t.Value == taint.Value
+}
+
+// PodTemplateSpec is the pod template of a workload.
+type PodTemplateSpec struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              PodSpec `json:"spec"`
+}
+
+// DaemonSet is an apps/v1 DaemonSet.
+type DaemonSet struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              DaemonSetSpec   `json:"spec"`
+	Status            DaemonSetStatus `json:"status"`
+}
+
+// DaemonSetSpec is the spec of a DaemonSet.
+type DaemonSetSpec struct {
+	Template PodTemplateSpec `json:"template"`
+}
+
+// DaemonSetStatus is the status of a DaemonSet.
+type DaemonSetStatus struct {
+	DesiredNumberScheduled int32 `json:"desiredNumberScheduled"`
+	NumberReady            int32 `json:"numberReady"`
+}
+
+// Deployment is an apps/v1 Deployment.
+type Deployment struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              DeploymentSpec   `json:"spec"`
+	Status            DeploymentStatus `json:"status"`
+}
+
+// DeploymentSpec is the spec of a Deployment.
+type DeploymentSpec struct {
+	// Replicas defaults to 1 when nil.
+	Replicas *int32                `json:"replicas,omitempty"`
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	Template PodTemplateSpec       `json:"template"`
+}
+
+// DeploymentStatus is the status of a Deployment.
+type DeploymentStatus struct {
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+}
+
+// StatefulSet is an apps/v1 StatefulSet.
+type StatefulSet struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              StatefulSetSpec `json:"spec"`
+}
+
+// StatefulSetSpec is the spec of a StatefulSet.
+type StatefulSetSpec struct {
+	// Replicas defaults to 1 when nil.
+	Replicas *int32                `json:"replicas,omitempty"`
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	Template PodTemplateSpec       `json:"template"`
+	// VolumeClaimTemplates are the claims created for each replica.
+	VolumeClaimTemplates []PersistentVolumeClaim `json:"volumeClaimTemplates,omitempty"`
+}
+
+// PersistentVolumeClaim is a core/v1 PersistentVolumeClaim.
+type PersistentVolumeClaim struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		// StorageClassName selects the default StorageClass when nil.
+		StorageClassName *string `json:"storageClassName,omitempty"`
+		VolumeName       string  `json:"volumeName,omitempty"`
+	} `json:"spec"`
+}
+
+// PersistentVolume is a core/v1 PersistentVolume.
+type PersistentVolume struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              PersistentVolumeSpec `json:"spec"`
+}
+
+// PersistentVolumeSpec is the spec of a PersistentVolume.
+type PersistentVolumeSpec struct {
+	StorageClassName string `json:"storageClassName,omitempty"`
+	ClaimRef         *struct {
+		Namespace string `json:"namespace"`
+		Name      string `json:"name"`
+	} `json:"claimRef,omitempty"`
+	CSI *struct {
+		Driver string `json:"driver"`
+	} `json:"csi,omitempty"`
+	// NodeAffinity restricts the nodes the volume can be attached to, e.g. to
+	// the zones of a persistent disk.
+	NodeAffinity *VolumeNodeAffinity `json:"nodeAffinity,omitempty"`
+	// Plugin is the name of the volume source field set in the spec, e.g. csi or gcePersistentDisk.
+	Plugin string `json:"-"`
+}
+
+// VolumeNodeAffinity is the node affinity of a PersistentVolume.
+type VolumeNodeAffinity struct {
+	Required *NodeSelector `json:"required,omitempty"`
+}
+
+// NodeSelector matches the nodes matching any of its terms.
+type NodeSelector struct {
+	NodeSelectorTerms []NodeSelectorTerm `json:"nodeSelectorTerms"`
+}
+
+// NodeSelectorTerm matches the nodes matching all its expressions.
+type NodeSelectorTerm struct {
+	MatchExpressions []NodeSelectorRequirement `json:"matchExpressions,omitempty"`
+}
+
+// NodeSelectorRequirement matches the nodes whose label value is in or out
+// of a set of values.
+type NodeSelectorRequirement struct {
+	Key      string   `json:"key"`
+	Operator string   `json:"operator"`
+	Values   []string `json:"values,omitempty"`
+}
+
+// volumeSources are the volume source fields of a PersistentVolume spec.
+var volumeSources = []string{
+	"awsElasticBlockStore", "azureDisk", "azureFile", "cephfs", "cinder", "csi", "fc", "flexVolume", "flocker",
+	"gcePersistentDisk", "glusterfs", "hostPath", "iscsi", "local", "nfs", "photonPersistentDisk", "portworxVolume",
+	"quobyte", "rbd", "scaleIO", "storageos", "vsphereVolume",
+}
+
+// UnmarshalJSON decodes the spec and records which volume source it uses.
+func (s *PersistentVolumeSpec) UnmarshalJSON(data []byte) error {
+	type spec PersistentVolumeSpec
+	if err := json.Unmarshal(data, (*spec)(s)); err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for _, source := range volumeSources {
+		if _, ok := fields[source]; ok {
+			s.Plugin = source
+			break
+		}
+	}
+	return nil
+}
+
+// StorageClass is a storage.k8s.io/v1 StorageClass.
+type StorageClass struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Provisioner       string            `json:"provisioner"`
+	Parameters        map[string]string `json:"parameters,omitempty"`
+}
+
+// CSINode is a storage.k8s.io/v1 CSINode, listing the CSI drivers of a node.
+type CSINode struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		Drivers []struct {
+			Name        string `json:"name"`
+			Allocatable *struct {
+				// Count is the maximum number of volumes of the driver attachable to the node.
+				Count *int32 `json:"count,omitempty"`
+			} `json:"allocatable,omitempty"`
+		} `json:"drivers"`
+	} `json:"spec"`
+}
+
+// VolumeAttachment is a storage.k8s.io/v1 VolumeAttachment.
+type VolumeAttachment struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		Attacher string `json:"attacher"`
+		NodeName string `json:"nodeName"`
+	} `json:"spec"`
+}
+
+// Service is a core/v1 Service.
+type Service struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              ServiceSpec   `json:"spec"`
+	Status            ServiceStatus `json:"status"`
+}
+
+// ServiceSpec is the spec of a Service.
+type ServiceSpec struct {
+	Type  string        `json:"type,omitempty"`
+	Ports []ServicePort `json:"ports,omitempty"`
+	// ClusterIP is the IP address allocated to the Service from the
+	// Service range, or "None" for headless Services.
+	ClusterIP string `json:"clusterIP,omitempty"`
+	// LoadBalancerIP is the deprecated field requesting the IP address of
+	// the load balancer.
+	LoadBalancerIP string `json:"loadBalancerIP,omitempty"`
+}
+
+// ServiceStatus is the status of a Service.
+type ServiceStatus struct {
+	LoadBalancer struct {
+		Ingress []struct {
+			IP       string `json:"ip,omitempty"`
+			Hostname string `json:"hostname,omitempty"`
+		} `json:"ingress,omitempty"`
+	} `json:"loadBalancer"`
+}
+
+// ServicePort is a port of a Service.
+type ServicePort struct {
+	Name     string `json:"name,omitempty"`
+	Protocol string `json:"protocol,omitempty"`
+	Port     int32  `json:"port"`
+	// TargetPort is the number or the name of the port of the pods, the
+	// same as Port when unset.
+	TargetPort intstr.IntOrString `json:"targetPort,omitempty"`
+}
+
+// Ingress is a networking.k8s.io/v1 Ingress.
+type Ingress struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		IngressClassName *string `json:"ingressClassName,omitempty"`
+	} `json:"spec"`
+}
+
+// Gateway is a gateway.networking.k8s.io Gateway.
+type Gateway struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		GatewayClassName string `json:"gatewayClassName"`
+	} `json:"spec"`
+}
+
+// CustomResourceDefinition is an apiextensions.k8s.io/v1 CustomResourceDefinition.
+type CustomResourceDefinition struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		Group string `json:"group"`
+		Names struct {
+			Kind   string `json:"kind"`
+			Plural string `json:"plural"`
+		} `json:"names"`
+		Versions []struct {
+			Name       string `json:"name"`
+			Served     bool   `json:"served"`
+			Storage    bool   `json:"storage"`
+			Deprecated bool   `json:"deprecated,omitempty"`
+		} `json:"versions"`
+	} `json:"spec"`
+}
+
+// Namespace is a core/v1 Namespace.
+type Namespace struct {
+	metav1.ObjectMeta `json:"metadata"`
+}
+
+// ResourceQuota is a core/v1 ResourceQuota.
+type ResourceQuota struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Status            ResourceQuotaStatus `json:"status"`
+}
+
+// ResourceQuotaStatus is the enforced and used resources of a ResourceQuota.
+type ResourceQuotaStatus struct {
+	Hard map[string]resource.Quantity `json:"hard,omitempty"`
+	Used map[string]resource.Quantity `json:"used,omitempty"`
+}
+
+// PriorityClass is a scheduling.k8s.io/v1 PriorityClass.
+type PriorityClass struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Value             int32  `json:"value"`
+	GlobalDefault     bool   `json:"globalDefault,omitempty"`
+	PreemptionPolicy  string `json:"preemptionPolicy,omitempty"`
+}
+
+// PodSecurityPolicy is a policy/v1beta1 PodSecurityPolicy. Only the fields
+// relevant to Pod Security Standards are decoded.
+type PodSecurityPolicy struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              PodSecurityPolicySpec `json:"spec"`
+}
+
+// PodSecurityPolicySpec is the spec of a PodSecurityPolicy.
+type PodSecurityPolicySpec struct {
+	Privileged               bool     `json:"privileged,omitempty"`
+	HostNetwork              bool     `json:"hostNetwork,omitempty"`
+	HostPID                  bool     `json:"hostPID,omitempty"`
+	HostIPC                  bool     `json:"hostIPC,omitempty"`
+	HostPorts                []any    `json:"hostPorts,omitempty"`
+	AllowedCapabilities      []string `json:"allowedCapabilities,omitempty"`
+	RequiredDropCapabilities []string `json:"requiredDropCapabilities,omitempty"`
+	Volumes                  []string `json:"volumes,omitempty"`
+	AllowPrivilegeEscalation *bool    `json:"allowPrivilegeEscalation,omitempty"`
+	RunAsUser                struct {
+		Rule string `json:"rule"`
+	} `json:"runAsUser"`
+}
+
+// CrossVersionObjectReference identifies the object scaled by an autoscaler.
+type CrossVersionObjectReference struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+}
+
+// Event is a core/v1 Event.
+type Event struct {
+	metav1.ObjectMeta  `json:"metadata"`
+	InvolvedObject     ObjectReference  `json:"involvedObject"`
+	Type               string           `json:"type,omitempty"`
+	Reason             string           `json:"reason,omitempty"`
+	Message            string           `json:"message,omitempty"`
+	Count              int32            `json:"count,omitempty"`
+	FirstTimestamp     metav1.Time      `json:"firstTimestamp,omitempty"`
+	LastTimestamp      metav1.Time      `json:"lastTimestamp,omitempty"`
+	EventTime          metav1.MicroTime `json:"eventTime,omitempty"`
+	Series             *EventSeries     `json:"series,omitempty"`
+	Source             EventSource      `json:"source,omitempty"`
+	ReportingComponent string           `json:"reportingComponent,omitempty"`
+}
+
+// ObjectReference identifies the object an Event is about.
+type ObjectReference struct {
+	Kind      string `json:"kind,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	FieldPath string `json:"fieldPath,omitempty"`
+}
+
+// EventSeries counts the occurrences of an Event reported as a series.
+type EventSeries struct {
+	Count            int32            `json:"count,omitempty"`
+	LastObservedTime metav1.MicroTime `json:"lastObservedTime,omitempty"`
+}
+
+// EventSource is the component and host reporting an Event.
+type EventSource struct {
+	Component string `json:"component,omitempty"`
+	Host      string `json:"host,omitempty"`
+}
+
+// Condition is a status condition of an object.
+type Condition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// HorizontalPodAutoscaler is an autoscaling/v2 HorizontalPodAutoscaler.
+type HorizontalPodAutoscaler struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              HorizontalPodAutoscalerSpec `json:"spec"`
+	Status            struct {
+		CurrentReplicas int32       `json:"currentReplicas"`
+		DesiredReplicas int32       `json:"desiredReplicas"`
+		Conditions      []Condition `json:"conditions,omitempty"`
+	} `json:"status"`
+}
+
+// HorizontalPodAutoscalerSpec is the spec of a HorizontalPodAutoscaler.
+type HorizontalPodAutoscalerSpec struct {
+	ScaleTargetRef CrossVersionObjectReference `json:"scaleTargetRef"`
+	MinReplicas    *int32                      `json:"minReplicas,omitempty"`
+	MaxReplicas    int32                       `json:"maxReplicas"`
+	Metrics        []MetricSpec                `json:"metrics,omitempty"`
+	Behavior       *struct {
+		ScaleUp   *HPAScalingRules `json:"scaleUp,omitempty"`
+		ScaleDown *HPAScalingRules `json:"scaleDown,omitempty"`
+	} `json:"behavior,omitempty"`
+}
+
+// HPAScalingRules configures the scaling behavior in one direction.
+type HPAScalingRules struct {
+	StabilizationWindowSeconds *int32 `json:"stabilizationWindowSeconds,omitempty"`
+	SelectPolicy               string `json:"selectPolicy,omitempty"`
+	Tolerance                  string `json:"tolerance,omitempty"`
+}
+
+// MetricSpec is a metric a HorizontalPodAutoscaler scales on. The source
+// matching Type is set.
+type MetricSpec struct {
+	Type              string                  `json:"type"`
+	Resource          *ResourceMetricSource   `json:"resource,omitempty"`
+	ContainerResource *ResourceMetricSource   `json:"containerResource,omitempty"`
+	Pods              *MetricIdentifierSource `json:"pods,omitempty"`
+	Object            *MetricIdentifierSource `json:"object,omitempty"`
+	External          *MetricIdentifierSource `json:"external,omitempty"`
+}
+
+// ResourceMetricSource is a CPU or memory metric, of the pod or of one container.
+type ResourceMetricSource struct {
+	Name      string `json:"name"`
+	Container string `json:"container,omitempty"`
+}
+
+// MetricIdentifierSource is a custom or external metric.
+type MetricIdentifierSource struct {
+	Metric struct {
+		Name string `json:"name"`
+	} `json:"metric"`
+}
+
+// VerticalPodAutoscaler is an autoscaling.k8s.io/v1 VerticalPodAutoscaler.
+type VerticalPodAutoscaler struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		TargetRef    *CrossVersionObjectReference `json:"targetRef,omitempty"`
+		UpdatePolicy *VPAUpdatePolicy             `json:"updatePolicy,omitempty"`
+	} `json:"spec"`
+}
+
+// VPAUpdatePolicy controls how a VerticalPodAutoscaler applies its recommendations.
+type VPAUpdatePolicy struct {
+	UpdateMode string `json:"updateMode,omitempty"`
+}
+
+// APIService is an apiregistration.k8s.io/v1 APIService.
+type APIService struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		Service *struct {
+			Namespace string `json:"namespace"`
+			Name      string `json:"name"`
+		} `json:"service,omitempty"`
+	} `json:"spec"`
+	Status struct {
+		Conditions []Condition `json:"conditions,omitempty"`
+	} `json:"status"`
+}
+
+// WebhookConfiguration is an admissionregistration.k8s.io/v1
+// MutatingWebhookConfiguration or ValidatingWebhookConfiguration.
+type WebhookConfiguration struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Webhooks          []Webhook `json:"webhooks,omitempty"`
+}
+
+// ServiceReference is the Service serving an admission webhook.
+type ServiceReference struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Port is the port of the Service, 443 when unset.
+	Port *int32 `json:"port,omitempty"`
+}
+
+// Webhook is an admission webhook of a WebhookConfiguration.
+type Webhook struct {
+	Name          string `json:"name"`
+	FailurePolicy string `json:"failurePolicy,omitempty"`
+	ClientConfig  struct {
+		URL     *string           `json:"url,omitempty"`
+		Service *ServiceReference `json:"service,omitempty"`
+	} `json:"clientConfig"`
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPodDisruptionBudgetList(t *testing.T) {
+	out := `{
+  "apiVersion": "v1",
+  "items": [
+    {
+      "apiVersion": "policy/v1",
+      "kind": "PodDisruptionBudget",
+      "metadata": {"name": "web", "namespace": "default"},
+      "spec": {"minAvailable": "50%", "selector": {"matchLabels": {"app": "web"}}},
+      "status": {"currentHealthy": 2, "desiredHealthy": 1, "disruptionsAllowed": 1, "expectedPods": 2}
+    }
+  ],
+  "kind": "List"
+}`
+	var list List[PodDisruptionBudget]
+	if err := json.Unmarshal([]byte(out), &list); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if len(list.Items) != 1 {
+		t.Fatalf("got %d items, want 1", len(list.Items))
+	}
+	pdb := list.Items[0]
+	if pdb.Namespace != "default" || pdb.Name != "web" {
+		t.Errorf("got PodDisruptionBudget %s/%s, want default/web", pdb.Namespace, pdb.Name)
+	}
+	if pdb.Spec.MinAvailable.String() != "50%" {
+		t.Errorf("got minAvailable %s, want 50%%", pdb.Spec.MinAvailable.String())
+	}
+	if pdb.Spec.Selector.MatchLabels["app"] != "web" {
+		t.Errorf("got selector %v, want app=web", pdb.Spec.Selector)
+	}
+	if pdb.Status.DisruptionsAllowed != 1 {
+		t.Errorf("got disruptionsAllowed %d, want 1", pdb.Status.DisruptionsAllowed)
+	}
+}
+
+func TestPersistentVolumeSpecPlugin(t *testing.T) {
+	tests := []struct {
+		spec       string
+		wantPlugin string
+		wantDriver string
+	}{
+		{`{"storageClassName": "standard", "gcePersistentDisk": {"pdName": "disk-1", "fsType": "ext4"}}`, "gcePersistentDisk", ""},
+		{`{"storageClassName": "standard-rwo", "csi": {"driver": "pd.csi.storage.gke.io", "volumeHandle": "disk-2"}}`, "csi", "pd.csi.storage.gke.io"},
+		{`{"storageClassName": "manual"}`, "", ""},
+	}
+	for _, tt := range tests {
+		var spec PersistentVolumeSpec
+		if err := json.Unmarshal([]byte(tt.spec), &spec); err != nil {
+			t.Fatalf("json.Unmarshal() error = %v", err)
+		}
+		if spec.Plugin != tt.wantPlugin {
+			t.Errorf("got plugin %q for %s, want %q", spec.Plugin, tt.spec, tt.wantPlugin)
+		}
+		if spec.StorageClassName == "" {
+			t.Errorf("storageClassName was not decoded for %s", tt.spec)
+		}
+		if tt.wantDriver != "" && (spec.CSI == nil || spec.CSI.Driver != tt.wantDriver) {
+			t.Errorf("got CSI source %v for %s, want driver %q", spec.CSI, tt.spec, tt.wantDriver)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logger configures the structured logger of the server and logs the
+// MCP tool calls.
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Options configures the logger.
+type Options struct {
+	// Level is the minimum level of the logged records: debug, info, warn or error.
+	Level string `json:"level,omitempty"`
+	// Format is the format of the records: text or json.
+	Format string `json:"format,omitempty"`
+	// File is the file the records are appended to, standard error when empty.
+	File string `json:"file,omitempty"`
+}
+
+// Merge returns the options of o overridden by the non-empty fields of other.
+func (o Options) Merge(other Options) Options {
+	if other.Level != "" {
+		o.Level = other.Level
+	}
+	if other.Format != "" {
+		o.Format = other.Format
+	}
+	if other.File != "" {
+		o.File = other.File
+	}
+	return o
+}
+
+// ParseLevel returns the slog level of a level name, info when empty.
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return 0, fmt.Errorf("unknown log level %q, use debug, info, warn or error", name)
+}
+
+// New returns the logger configured by the options, and the closer of its
+// destination file, if any. Records never go to standard output, which the
+// stdio transport uses.
+func New(opts Options) (*slog.Logger, io.Closer, error) {
+	level, err := ParseLevel(opts.Level)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var w io.Writer = os.Stderr
+	var closer io.Closer = io.NopCloser(nil)
+	if opts.File != "" {
+		// #nosec G304
+		f, err := os.OpenFile(opts.File, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open log file: %w", err)
+		}
+		w, closer = f, f
+	}
+
+	handlerOpts := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	switch strings.ToLower(opts.Format) {
+	case "", "text":
+		handler = slog.NewTextHandler(w, handlerOpts)
+	case "json":
+		handler = slog.NewJSONHandler(w, handlerOpts)
+	default:
+		_ = closer.Close()
+		return nil, nil, fmt.Errorf("unknown log format %q, use text or json", opts.Format)
+	}
+	return slog.New(handler), closer, nil
+}
+
+// Middleware logs every tool call of the server with its duration and outcome.
+func Middleware(logger *slog.Logger) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if method != "tools/call" {
+				return next(ctx, method, req)
+			}
+
+			start := time.Now()
+			result, err := next(ctx, method, req)
+
+			attrs := []any{
+				slog.String("tool", toolName(req)),
+				slog.Duration("duration", time.Since(start)),
+			}
+			if ss, ok := req.GetSession().(*mcp.ServerSession); ok && ss != nil && ss.ID() != "" {
+				attrs = append(attrs, slog.String("session", ss.ID()))
+			}
+			switch {
+			case err != nil:
+				logger.WarnContext(ctx, "Tool call failed", append(attrs, slog.Any("error", err))...)
+			case isToolError(result):
+				logger.WarnContext(ctx, "Tool call returned an error", attrs...)
+			default:
+				logger.InfoContext(ctx, "Tool call succeeded", attrs...)
+			}
+			return result, err
+		}
+	}
+}
+
+func toolName(req mcp.Request) string {
+	if params, ok := req.GetParams().(*mcp.CallToolParamsRaw); ok && params != nil {
+		return params.Name
+	}
+	return ""
+}
+
+// isToolError reports whether the result is a tool error, e.g. an error
+// returned by a tool handler, which the SDK reports in the result.
+func isToolError(result mcp.Result) bool {
+	r, ok := result.(*mcp.CallToolResult)
+	return ok && r != nil && r.IsError
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := map[string]slog.Level{
+		"":      slog.LevelInfo,
+		"debug": slog.LevelDebug,
+		"WARN":  slog.LevelWarn,
+		"error": slog.LevelError,
+	}
+	for name, want := range tests {
+		if got, err := ParseLevel(name); err != nil || got != want {
+			t.Errorf("ParseLevel(%q) = %v, %v, want %v", name, got, err, want)
+		}
+	}
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Errorf("ParseLevel(verbose) returned no error")
+	}
+}
+
+func TestNew(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "gke-mcp.log")
+	l, closer, err := New(Options{Level: "warn", Format: "json", File: name})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	l.Info("dropped")
+	l.Warn("kept", "tool", "list_clusters")
+	if err := closer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var record map[string]any
+	if err := json.Unmarshal(data, &record); err != nil {
+		t.Fatalf("log file = %q, want a single JSON record: %v", data, err)
+	}
+	if record["msg"] != "kept" || record["tool"] != "list_clusters" {
+		t.Errorf("log record = %v, want the warning with its attributes", record)
+	}
+
+	if _, _, err := New(Options{Format: "xml"}); err == nil {
+		t.Errorf("New() with an unknown format returned no error")
+	}
+}
+
+func TestOptionsMerge(t *testing.T) {
+	got := Options{Level: "debug", Format: "json"}.Merge(Options{Level: "error", File: "gke-mcp.log"})
+	if want := (Options{Level: "error", Format: "json", File: "gke-mcp.log"}); got != want {
+		t.Errorf("Merge() = %+v, want %+v", got, want)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	l := slog.New(slog.NewTextHandler(&buf, nil))
+	req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "list_clusters"}}
+
+	tests := []struct {
+		name   string
+		result mcp.Result
+		err    error
+		want   string
+	}{
+		{"success", &mcp.CallToolResult{}, nil, `level=INFO msg="Tool call succeeded" tool=list_clusters duration=`},
+		{"tool error", &mcp.CallToolResult{IsError: true}, nil, `level=WARN msg="Tool call returned an error" tool=list_clusters`},
+		{"error", nil, errors.New("boom"), `level=WARN msg="Tool call failed" tool=list_clusters duration=`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			next := func(context.Context, string, mcp.Request) (mcp.Result, error) {
+				return tt.result, tt.err
+			}
+			if _, err := Middleware(l)(next)(context.Background(), "tools/call", req); !errors.Is(err, tt.err) {
+				t.Errorf("Middleware() error = %v, want %v", err, tt.err)
+			}
+			if got := buf.String(); !strings.Contains(got, tt.want) {
+				t.Errorf("Middleware() logged %q, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+
+	buf.Reset()
+	next := func(context.Context, string, mcp.Request) (mcp.Result, error) { return nil, nil }
+	if _, err := Middleware(l)(next)(context.Background(), "tools/list", &mcp.ListToolsRequest{}); err != nil || buf.Len() != 0 {
+		t.Errorf("Middleware() logged %q, %v for tools/list, want nothing", buf.String(), err)
+	}
+}
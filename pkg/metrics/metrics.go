@@ -0,0 +1,119 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics records the Prometheus metrics of the server: tool calls,
+// cache hits and the latency of external requests and commands.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Outcomes of tool calls and external requests.
+const (
+	outcomeSuccess   = "success"
+	outcomeError     = "error"
+	outcomeToolError = "tool_error"
+)
+
+var (
+	registry = prometheus.NewRegistry()
+
+	toolCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gke_mcp_tool_calls_total",
+		Help: "Number of tool calls, by tool and outcome: success, tool_error or error.",
+	}, []string{"tool", "outcome"})
+
+	toolCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "gke_mcp_tool_call_duration_seconds",
+		Help:    "Duration of tool calls, by tool.",
+		Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120},
+	}, []string{"tool"})
+
+	cacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gke_mcp_cache_requests_total",
+		Help: "Number of cache lookups, by cache and result: hit or miss.",
+	}, []string{"cache", "result"})
+
+	externalDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "gke_mcp_external_request_duration_seconds",
+		Help:    "Duration of external requests and commands, by target, e.g. kubectl or release_notes, and outcome: success or error.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"target", "outcome"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		toolCalls,
+		toolCallDuration,
+		cacheRequests,
+		externalDuration,
+	)
+}
+
+// Handler returns the handler serving the metrics in the Prometheus format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// CacheLookup records a lookup of the cache, which hit if found.
+func CacheLookup(cache string, found bool) {
+	result := "miss"
+	if found {
+		result = "hit"
+	}
+	cacheRequests.WithLabelValues(cache, result).Inc()
+}
+
+// ObserveExternal records the duration of an external request or command
+// to the target which started at start and failed if err is not nil.
+func ObserveExternal(target string, start time.Time, err error) {
+	outcome := outcomeSuccess
+	if err != nil {
+		outcome = outcomeError
+	}
+	externalDuration.WithLabelValues(target, outcome).Observe(time.Since(start).Seconds())
+}
+
+// Middleware records the count, outcome and duration of the tool calls of the server.
+func Middleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+		if !ok || params == nil {
+			return next(ctx, method, req)
+		}
+
+		start := time.Now()
+		result, err := next(ctx, method, req)
+		toolCallDuration.WithLabelValues(params.Name).Observe(time.Since(start).Seconds())
+
+		outcome := outcomeSuccess
+		if err != nil {
+			outcome = outcomeError
+		} else if r, ok := result.(*mcp.CallToolResult); ok && r != nil && r.IsError {
+			outcome = outcomeToolError
+		}
+		toolCalls.WithLabelValues(params.Name, outcome).Inc()
+		return result, err
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMiddleware(t *testing.T) {
+	req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "test_tool"}}
+	results := []struct {
+		result mcp.Result
+		err    error
+	}{
+		{&mcp.CallToolResult{}, nil},
+		{&mcp.CallToolResult{}, nil},
+		{&mcp.CallToolResult{IsError: true}, nil},
+		{nil, errors.New("boom")},
+	}
+	for _, r := range results {
+		next := func(context.Context, string, mcp.Request) (mcp.Result, error) { return r.result, r.err }
+		_, _ = Middleware(next)(context.Background(), "tools/call", req)
+	}
+
+	for outcome, want := range map[string]float64{outcomeSuccess: 2, outcomeToolError: 1, outcomeError: 1} {
+		if got := testutil.ToFloat64(toolCalls.WithLabelValues("test_tool", outcome)); got != want {
+			t.Errorf("tool calls with outcome %s = %v, want %v", outcome, got, want)
+		}
+	}
+	if got := testutil.CollectAndCount(toolCallDuration, "gke_mcp_tool_call_duration_seconds"); got != 1 {
+		t.Errorf("tool call duration series = %d, want 1", got)
+	}
+}
+
+func TestCacheLookup(t *testing.T) {
+	CacheLookup("test_cache", true)
+	CacheLookup("test_cache", false)
+	CacheLookup("test_cache", false)
+	if got := testutil.ToFloat64(cacheRequests.WithLabelValues("test_cache", "hit")); got != 1 {
+		t.Errorf("cache hits = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(cacheRequests.WithLabelValues("test_cache", "miss")); got != 2 {
+		t.Errorf("cache misses = %v, want 2", got)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	ObserveExternal("test_target", time.Now(), nil)
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Handler() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if body := rec.Body.String(); !strings.Contains(body, `gke_mcp_external_request_duration_seconds_count{outcome="success",target="test_target"} 1`) {
+		t.Errorf("Handler() body = %q, want the external request duration", body)
+	}
+}
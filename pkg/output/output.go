@@ -0,0 +1,64 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package output bounds the size of tool results, so that huge changelogs
+// and kubectl dumps don't overflow the context window of clients.
+package output
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// headRatio is the share of the size limit kept from the head of truncated
+// text, the rest being kept from its tail.
+const headRatio = 0.75
+
+// Truncate returns s cut to about maxSize bytes, keeping its head and tail on
+// line boundaries when possible, with an explicit marker telling how many
+// bytes were left out. s is returned unchanged when it fits or maxSize isn't
+// positive. The returned bool reports whether s was truncated.
+func Truncate(s string, maxSize int) (string, bool) {
+	if maxSize <= 0 || len(s) <= maxSize {
+		return s, false
+	}
+	headSize := int(float64(maxSize) * headRatio)
+	tailSize := maxSize - headSize
+
+	head := s[:runeStart(s, headSize)]
+	if i := strings.LastIndexByte(head, '\n'); i > 0 {
+		head = head[:i+1]
+	}
+	tail := s[runeStart(s, len(s)-tailSize):]
+	if i := strings.IndexByte(tail, '\n'); i >= 0 && i < len(tail)-1 {
+		tail = tail[i+1:]
+	}
+
+	omitted := len(s) - len(head) - len(tail)
+	marker := fmt.Sprintf("[... truncated %d of %d bytes ...]\n", omitted, len(s))
+	if !strings.HasSuffix(head, "\n") {
+		marker = "\n" + marker
+	}
+	return head + marker + tail, true
+}
+
+// runeStart returns the start of the rune of s at byte offset i, so that
+// cutting s there doesn't split a UTF-8 sequence.
+func runeStart(s string, i int) int {
+	for i > 0 && i < len(s) && !utf8.RuneStart(s[i]) {
+		i--
+	}
+	return i
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncate(t *testing.T) {
+	if got, truncated := Truncate("short", 10); got != "short" || truncated {
+		t.Errorf("Truncate(short) = %q, %t, want it unchanged", got, truncated)
+	}
+	if got, truncated := Truncate("no limit", 0); got != "no limit" || truncated {
+		t.Errorf("Truncate() without limit = %q, %t, want it unchanged", got, truncated)
+	}
+
+	var lines []string
+	for i := range 100 {
+		lines = append(lines, strings.Repeat(string(rune('a'+i%26)), 9))
+	}
+	s := strings.Join(lines, "\n") + "\n"
+	got, truncated := Truncate(s, 200)
+	if !truncated {
+		t.Fatalf("Truncate() didn't truncate %d bytes to 200", len(s))
+	}
+	if !strings.HasPrefix(got, "aaaaaaaaa\nbbbbbbbbb\n") || !strings.HasSuffix(got, "vvvvvvvvv\n") {
+		t.Errorf("Truncate() = %q, want the head and tail of the text", got)
+	}
+	if !strings.Contains(got, "\n[... truncated ") || !strings.Contains(got, " of 1000 bytes ...]\n") {
+		t.Errorf("Truncate() = %q, want a truncation marker", got)
+	}
+	if len(got) > 250 {
+		t.Errorf("Truncate() returned %d bytes, want about 200", len(got))
+	}
+
+	got, _ = Truncate(strings.Repeat("é", 100), 51)
+	if !utf8.ValidString(got) {
+		t.Errorf("Truncate() = %q, want valid UTF-8", got)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pagination splits long tool results into pages, which clients
+// iterate through with opaque page tokens.
+package pagination
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+)
+
+// tokenPrefix versions the page tokens.
+const tokenPrefix = "offset:"
+
+// Page returns the page of pageSize items starting at the page token, and
+// the token of the next page, empty on the last page. An empty page token
+// starts at the first item, and a pageSize which isn't positive returns all
+// the remaining items.
+func Page[T any](items []T, pageToken string, pageSize int) ([]T, string, error) {
+	offset, err := decodeToken(pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	if offset > len(items) {
+		return nil, "", fmt.Errorf("page_token %q is past the end of the results", pageToken)
+	}
+	end := len(items)
+	if pageSize > 0 {
+		end = min(offset+pageSize, len(items))
+	}
+	next := ""
+	if end < len(items) {
+		next = encodeToken(end)
+	}
+	return items[offset:end], next, nil
+}
+
+// Footer describes the position of a page in the results, with the token of
+// the next page, to append to the page.
+func Footer(pageSize, total int, next string) string {
+	if next == "" {
+		return ""
+	}
+	return fmt.Sprintf("\n\n(Showing %d of %d results. Call the tool again with page_token %q for the next page.)", pageSize, total, next)
+}
+
+func encodeToken(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(tokenPrefix + strconv.Itoa(offset)))
+}
+
+func decodeToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, fmt.Errorf("invalid page_token %q", token)
+	}
+	s := string(data)
+	if len(s) <= len(tokenPrefix) || s[:len(tokenPrefix)] != tokenPrefix {
+		return 0, fmt.Errorf("invalid page_token %q", token)
+	}
+	offset, err := strconv.Atoi(s[len(tokenPrefix):])
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid page_token %q", token)
+	}
+	return offset, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pagination
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPage(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e"}
+
+	var got []string
+	token := ""
+	pages := 0
+	for {
+		page, next, err := Page(items, token, 2)
+		if err != nil {
+			t.Fatalf("Page(%q) error = %v", token, err)
+		}
+		got = append(got, page...)
+		pages++
+		if next == "" {
+			break
+		}
+		token = next
+	}
+	if !slices.Equal(got, items) || pages != 3 {
+		t.Errorf("iterated %v in %d pages, want %v in 3 pages", got, pages, items)
+	}
+
+	if page, next, err := Page(items, "", 0); err != nil || len(page) != 5 || next != "" {
+		t.Errorf("Page() without page size = %v, %q, %v, want all items", page, next, err)
+	}
+}
+
+func TestPageInvalidToken(t *testing.T) {
+	for _, token := range []string{"not base64!", encodeToken(6), "b2Zmc2V0Oi0x"} {
+		if _, _, err := Page([]int{1, 2, 3}, token, 1); err == nil {
+			t.Errorf("Page(%q) returned no error", token)
+		}
+	}
+}
+
+func TestFooter(t *testing.T) {
+	if got := Footer(2, 5, ""); got != "" {
+		t.Errorf("Footer() on the last page = %q, want none", got)
+	}
+	if got := Footer(2, 5, "abc"); !strings.Contains(got, `page_token "abc"`) || !strings.Contains(got, "2 of 5") {
+		t.Errorf("Footer() = %q, want the position and the next page token", got)
+	}
+}
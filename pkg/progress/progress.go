@@ -0,0 +1,68 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package progress reports the progress of long-running tool calls with MCP
+// progress notifications, so that clients don't think they are hung.
+package progress
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type contextKey struct{}
+
+// notifier sends the progress notifications of a tool call.
+type notifier struct {
+	session *mcp.ServerSession
+	token   any
+}
+
+// Middleware makes the progress token of tool calls, sent by clients asking
+// for progress notifications, available to Report.
+func Middleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+		if !ok || params == nil {
+			return next(ctx, method, req)
+		}
+		ss, ok := req.GetSession().(*mcp.ServerSession)
+		if token := params.GetProgressToken(); ok && ss != nil && token != nil {
+			ctx = context.WithValue(ctx, contextKey{}, &notifier{session: ss, token: token})
+		}
+		return next(ctx, method, req)
+	}
+}
+
+// Report notifies the client of the tool call of ctx that done of total steps
+// are complete, with a message describing the current step. A zero total
+// means that it is unknown. Nothing is sent when the client didn't ask for
+// progress notifications.
+func Report(ctx context.Context, done, total int, message string) {
+	n, ok := ctx.Value(contextKey{}).(*notifier)
+	if !ok {
+		return
+	}
+	err := n.session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+		ProgressToken: n.token,
+		Progress:      float64(done),
+		Total:         float64(total),
+		Message:       message,
+	})
+	if err != nil {
+		slog.Debug("Failed to send progress notification", "error", err)
+	}
+}
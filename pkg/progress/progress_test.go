@@ -0,0 +1,85 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package progress
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type stepArgs struct{}
+
+func TestReport(t *testing.T) {
+	ctx := context.Background()
+	s := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	s.AddReceivingMiddleware(Middleware)
+	mcp.AddTool(s, &mcp.Tool{Name: "steps"}, func(ctx context.Context, _ *mcp.CallToolRequest, _ *stepArgs) (*mcp.CallToolResult, any, error) {
+		Report(ctx, 0, 2, "first")
+		Report(ctx, 1, 2, "second")
+		return &mcp.CallToolResult{}, nil, nil
+	})
+
+	var mu sync.Mutex
+	var got []*mcp.ProgressNotificationParams
+	client := mcp.NewClient(&mcp.Implementation{Name: "client"}, &mcp.ClientOptions{
+		ProgressNotificationHandler: func(_ context.Context, req *mcp.ProgressNotificationClientRequest) {
+			mu.Lock()
+			defer mu.Unlock()
+			got = append(got, req.Params)
+		},
+	})
+	ct, st := mcp.NewInMemoryTransports()
+	ss, err := s.Connect(ctx, st, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = ss.Close() }()
+	cs, err := client.Connect(ctx, ct, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = cs.Close() }()
+
+	// Without a progress token, no notification is sent.
+	if _, err := cs.CallTool(ctx, &mcp.CallToolParams{Name: "steps"}); err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	params := &mcp.CallToolParams{Name: "steps", Meta: mcp.Meta{"progressToken": "token"}}
+	if _, err := cs.CallTool(ctx, params); err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	// Close the session to wait for the notifications to be handled.
+	_ = cs.Close()
+	_ = ss.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(got) != 2 {
+		t.Fatalf("got %d progress notifications, want 2", len(got))
+	}
+	for i, want := range []string{"first", "second"} {
+		if got[i].ProgressToken != "token" || got[i].Progress != float64(i) || got[i].Total != 2 || got[i].Message != want {
+			t.Errorf("notification %d = %+v, want progress %d of 2 with message %q", i, got[i], i, want)
+		}
+	}
+}
+
+func TestReportWithoutToken(t *testing.T) {
+	// Report does nothing outside of tool calls asking for progress notifications.
+	Report(context.Background(), 1, 2, "ignored")
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package autopilotassessment provides a prompt template for assessing the
+// migration of a GKE Standard cluster to Autopilot.
+package autopilotassessment
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"text/template"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/promptargs"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/templates"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const gkeAutopilotAssessmentPromptTemplate = `
+# GKE Autopilot Migration Assessment
+
+**1. Input Parameters:**
+  - Cluster Name: {{.clusterName}}
+  - Cluster Location: {{.clusterLocation}}
+
+**2. Your Role:**
+You are a GKE expert. Your task is to assess whether the workloads of the specified GKE Standard cluster can run on GKE Autopilot, and to generate a migration plan. A Standard cluster cannot be converted in place, the workloads have to be moved to a new Autopilot cluster.
+
+**3. Information Gathering & Tools:**
+  - **Cluster Details:** Use the ` + "`gcloud_readonly`" + ` tool to get the cluster mode, its node pools with their machine types, node images, accelerators, taints and labels, and the cluster features in use, e.g. Windows node pools, TPUs, sandboxes or custom kubelet and sysctl settings.
+  - **Credentials:** Use the ` + "`get_kubeconfig`" + ` tool so that ` + "`kubectl`" + ` targets the cluster.
+  - **Workload Compatibility:** Use the ` + "`check_autopilot_compatibility`" + ` tool to list the workloads Autopilot rejects, e.g. privileged containers, host namespaces, host ports, writable hostPath volumes or capabilities beyond the allowed set, and the workloads needing changes, e.g. DaemonSets, GPU requests and node selectors on node pool labels.
+
+**4. Migration Plan:**
+  - **Verdict:** Whether the cluster can move to Autopilot as is, after workload changes, or not at all.
+  - **Blockers:** The cluster features and workloads Autopilot doesn't support, with the workload, the reason and the alternative if any, e.g. moving a node agent to a managed GKE feature or keeping the workload on a Standard cluster.
+  - **Workload Changes:** For every workload needing a change, the change to its manifest, e.g. replacing node pool selectors with compute classes or ` + "`cloud.google.com/gke-accelerator`" + ` selectors, setting resource requests, or dropping privileges.
+  - **Migration Steps:**
+    1. Create an Autopilot cluster with the same network, release channel and version.
+    2. Recreate the cluster-scoped configuration, e.g. namespaces, RBAC, CRDs and Workload Identity bindings.
+    3. Deploy the changed workloads, move the traffic and the persistent data, then decommission the Standard cluster.
+
+**5. Principles:**
+  - Base the assessment SOLELY on the data gathered from the cluster.
+  - Do not change the cluster, only output the commands and manifest changes for the user to review.
+  - List the blockers first, a migration with unresolved blockers must not be attempted.
+  - Do not read or write any local files generating the plan.
+`
+
+var gkeAutopilotAssessmentTmpl = template.Must(template.New("gke-autopilot-assessment").Parse(gkeAutopilotAssessmentPromptTemplate))
+
+const (
+	clusterNameArgName     = "cluster_name"
+	clusterLocationArgName = "cluster_location"
+)
+
+type handlers struct {
+	c *config.Config
+	// listClusters lists the clusters the user can choose from when the
+	// cluster arguments are missing.
+	listClusters promptargs.ClusterLister
+}
+
+// Install registers the Autopilot assessment prompt with the MCP server.
+func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
+	tmpl, err := templates.Load(c.PromptsDir(), "gke-autopilot-assessment", gkeAutopilotAssessmentPromptTemplate, []string{"clusterName", "clusterLocation"})
+	if err != nil {
+		return err
+	}
+	gkeAutopilotAssessmentTmpl = tmpl
+
+	h := &handlers{c: c, listClusters: promptargs.NewClusterLister(c)}
+
+	s.AddPrompt(&mcp.Prompt{
+		Name:        "gke:autopilot-assessment",
+		Description: "Assess whether the workloads of a GKE Standard cluster are compatible with Autopilot and generate a migration plan.",
+		Arguments: []*mcp.PromptArgument{
+			{
+				Name:        clusterNameArgName,
+				Description: "A name of a GKE cluster user want to migrate. Defaults to the configured default cluster.",
+				Required:    false,
+			},
+			{
+				Name:        clusterLocationArgName,
+				Description: "A location of a GKE cluster user want to migrate. Defaults to the configured default location.",
+				Required:    false,
+			},
+		},
+	}, h.gkeAutopilotAssessmentHandler)
+
+	return nil
+}
+
+// gkeAutopilotAssessmentHandler is the handler function for the /gke:autopilot-assessment prompt
+func (h *handlers) gkeAutopilotAssessmentHandler(ctx context.Context, request *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	// Missing cluster arguments are asked to the user rather than failing the prompt.
+	clusterName, clusterLocation, note := promptargs.Cluster(ctx, request, h.c, h.listClusters, clusterNameArgName, clusterLocationArgName)
+
+	var buf bytes.Buffer
+	buf.WriteString(note)
+	if err := gkeAutopilotAssessmentTmpl.Execute(&buf, map[string]string{
+		"clusterName":     clusterName,
+		"clusterLocation": clusterLocation,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to execute prompt template: %w", err)
+	}
+
+	return &mcp.GetPromptResult{
+		Description: "GKE Autopilot Migration Assessment Prompt",
+		Messages: []*mcp.PromptMessage{
+			{
+				Content: &mcp.TextContent{
+					Text: buf.String(),
+				},
+				Role: "user",
+			},
+		},
+	}, nil
+}
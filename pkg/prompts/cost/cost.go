@@ -23,6 +23,7 @@ import (
 	"text/template"
 
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/templates"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -37,6 +38,11 @@ Based on the GKE cost context available, provide a detailed and helpful response
 5. **Resource References**: Point to relevant GCP documentation or console links
 Key points to remember:
 - GKE costs come from GCP Billing Detailed BigQuery Export
+- Use the ` + "`get_cluster_costs`" + ` tool to get the actual spend of a cluster by node pool and namespace
+- Use the ` + "`estimate_node_pool_costs`" + ` tool to quantify the savings of recommendations at list, sustained use and committed use prices
+- Before recommending Spot VMs, use the ` + "`audit_spot_placement`" + ` tool to check which workloads can safely move to or from Spot nodes
+- Before recommending autoscaler changes, use the ` + "`get_autoscaler_config`" + ` tool for the autoscaling profile, node auto-provisioning limits, node pool bounds and recent scale-up failures
+- Use the ` + "`find_orphaned_resources`" + ` tool for the load balancers, firewall rules and disks of the project left behind by deleted clusters and Services
 - BigQuery CLI (bq) is preferred over BigQuery Studio when available
 - GKE Cost Allocation must be enabled for namespace and workload-level cost data
 - Required parameters include BigQuery table path, time frame, project ID, cluster details
@@ -47,7 +53,13 @@ Always be helpful, specific, and actionable in your response.
 var gkeCostTmpl = template.Must(template.New("gke-cost").Parse(gkeCostPromptTemplate))
 
 // Install registers the GKE cost prompt with the MCP server.
-func Install(_ context.Context, s *mcp.Server, _ *config.Config) error {
+func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
+	tmpl, err := templates.Load(c.PromptsDir(), "gke-cost", gkeCostPromptTemplate, []string{"user_question"})
+	if err != nil {
+		return err
+	}
+	gkeCostTmpl = tmpl
+
 	s.AddPrompt(&mcp.Prompt{
 		Name:        "gke:cost",
 		Description: "Answer natural language questions about GKE-related costs by leveraging the bundled cost context instructions within the gke-mcp server.",
@@ -23,6 +23,7 @@ import (
 	"text/template"
 
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/templates"
 	tooldeploy "github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/deploy"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -30,7 +31,13 @@ import (
 var gkeDeployTmpl = template.Must(template.New("gke-deploy").Parse(tooldeploy.PromptTemplate))
 
 // Install registers the GKE deploy prompt with the MCP server.
-func Install(_ context.Context, s *mcp.Server, _ *config.Config) error {
+func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
+	tmpl, err := templates.Load(c.PromptsDir(), "gke-deploy", tooldeploy.PromptTemplate, []string{"user_request"})
+	if err != nil {
+		return err
+	}
+	gkeDeployTmpl = tmpl
+
 	s.AddPrompt(&mcp.Prompt{
 		Name:        "gke:deploy",
 		Description: "Deploys a workload to a GKE cluster using a configuration file.",
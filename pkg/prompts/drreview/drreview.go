@@ -0,0 +1,132 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package drreview provides a prompt template for reviewing the disaster
+// recovery readiness of a GKE cluster.
+package drreview
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"text/template"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/promptargs"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/templates"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const gkeDRReviewPromptTemplate = `
+# GKE Disaster Recovery Readiness Review
+
+**1. Input Parameters:**
+  - Cluster Name: {{.clusterName}}
+  - Cluster Location: {{.clusterLocation}}
+
+**2. Your Role:**
+You are a GKE site reliability engineer. Your task is to evaluate whether the workloads and data of the specified GKE cluster can be recovered from the loss of a zone, of the region or of the cluster itself, and to produce a gap analysis with concrete remediation steps.
+
+**3. Information Gathering & Tools:**
+Use the ` + "`get_kubeconfig`" + ` tool first so that ` + "`kubectl`" + ` targets the cluster, then review every area below:
+  - **Topology:** Use the ` + "`check_zonal_topology`" + ` tool to find a zonal control plane, node pools in a single zone and StatefulSets whose volumes pin them to a zone, and the share of nodes, pods and workloads lost with each zone. None of them survive the loss of their zone.
+  - **Workload Resilience:** Use the ` + "`check_critical_workloads`" + ` tool for production-critical workloads running a single replica or without a PodDisruptionBudget, and the ` + "`check_topology_constraints`" + ` tool for workloads whose replicas are not spread across zones.
+  - **Backup Coverage:** Use the ` + "`gather_cluster_context`" + ` tool to check whether the Backup for GKE agent is enabled, and the ` + "`kubectl_get`" + ` tool with ` + "`customresourcedefinitions`" + ` to find the backup tools installed in the cluster, e.g. the ProtectedApplications of Backup for GKE or Velero. Backup plans are not readable with the tools, output the ` + "`gcloud container backup-restore backup-plans list`" + ` command for the user to run, with the location of the cluster, and ask for its output: the namespaces covered, the schedule, the retention and whether volume data is included.
+  - **Persistent Volumes:** Use the ` + "`audit_storage`" + ` tool with the current version of the cluster as target version to list the StatefulSets, StorageClasses and PersistentVolumes. Report zonal persistent disks holding data of critical workloads, which are lost with their zone, and StorageClasses which could provision regional persistent disks instead. Use the ` + "`kubectl_get`" + ` tool with ` + "`customresourcedefinitions`" + ` to check whether the VolumeSnapshot API is installed, and output the ` + "`gcloud compute resource-policies list`" + ` command for the user to check the snapshot schedules attached to the disks.
+  - **Encryption Keys:** Use the ` + "`get_encryption_status`" + ` tool to list the Cloud KMS keys of the secrets and disks. Backups and snapshots encrypted with these keys cannot be restored without access to them, e.g. in another region.
+  - **Restore Runbooks:** Ask the user whether a restore runbook exists for the cluster, when a restore was last tested, and the recovery point objective (RPO) and recovery time objective (RTO) of its workloads. Do not assume a runbook exists when the user does not answer.
+
+**4. Report Format:**
+  - **Summary:** The disaster recovery readiness of the cluster, ready, partially ready or not ready, in one sentence, for the loss of a zone, of the region and of the cluster.
+  - **Gap Analysis:** A table of the gaps found, the most critical first, with the failure scenario it affects (zone, region or cluster loss), the affected namespaces, workloads or volumes, the evidence gathered and its severity (critical, high, medium or low).
+  - **Remediation Steps:** For every gap, the concrete steps to close it, with the gcloud or kubectl commands to run, e.g. creating a Backup for GKE backup plan covering the namespaces of critical workloads, moving a node pool to several zones, or using a StorageClass provisioning regional persistent disks.
+  - **Checked Areas:** The areas found ready, and the information the user did not provide, so that the reader knows what has been reviewed.
+
+**5. Principles:**
+  - Base the report SOLELY on the data gathered from the cluster and the answers of the user, and tell apart facts from assumptions.
+  - Rank gaps risking the loss of data above those only extending the recovery time.
+  - Do not change the cluster, only output the commands for the user to review and run.
+  - Do not read or write any local files generating the report.
+`
+
+var gkeDRReviewTmpl = template.Must(template.New("gke-drreview").Parse(gkeDRReviewPromptTemplate))
+
+const (
+	clusterNameArgName     = "cluster_name"
+	clusterLocationArgName = "cluster_location"
+)
+
+type handlers struct {
+	c *config.Config
+	// listClusters lists the clusters the user can choose from when the
+	// cluster arguments are missing.
+	listClusters promptargs.ClusterLister
+}
+
+// Install registers the disaster recovery review prompt with the MCP server.
+func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
+	tmpl, err := templates.Load(c.PromptsDir(), "gke-drreview", gkeDRReviewPromptTemplate, []string{"clusterName", "clusterLocation"})
+	if err != nil {
+		return err
+	}
+	gkeDRReviewTmpl = tmpl
+
+	h := &handlers{c: c, listClusters: promptargs.NewClusterLister(c)}
+
+	s.AddPrompt(&mcp.Prompt{
+		Name:        "gke:drreview",
+		Description: "Evaluate the disaster recovery readiness of a GKE cluster and output a gap analysis with remediation steps.",
+		Arguments: []*mcp.PromptArgument{
+			{
+				Name:        clusterNameArgName,
+				Description: "A name of a GKE cluster user want to review. Defaults to the configured default cluster.",
+				Required:    false,
+			},
+			{
+				Name:        clusterLocationArgName,
+				Description: "A location of a GKE cluster user want to review. Defaults to the configured default location.",
+				Required:    false,
+			},
+		},
+	}, h.gkeDRReviewHandler)
+
+	return nil
+}
+
+// gkeDRReviewHandler is the handler function for the /gke:drreview prompt
+func (h *handlers) gkeDRReviewHandler(ctx context.Context, request *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	// Missing cluster arguments are asked to the user rather than failing the prompt.
+	clusterName, clusterLocation, note := promptargs.Cluster(ctx, request, h.c, h.listClusters, clusterNameArgName, clusterLocationArgName)
+
+	var buf bytes.Buffer
+	buf.WriteString(note)
+	if err := gkeDRReviewTmpl.Execute(&buf, map[string]string{
+		"clusterName":     clusterName,
+		"clusterLocation": clusterLocation,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to execute prompt template: %w", err)
+	}
+
+	return &mcp.GetPromptResult{
+		Description: "GKE Disaster Recovery Readiness Review Prompt",
+		Messages: []*mcp.PromptMessage{
+			{
+				Content: &mcp.TextContent{
+					Text: buf.String(),
+				},
+				Role: "user",
+			},
+		},
+	}, nil
+}
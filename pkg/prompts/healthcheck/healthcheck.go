@@ -0,0 +1,132 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package healthcheck provides a prompt template for reviewing the health of
+// a GKE cluster.
+package healthcheck
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"text/template"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/promptargs"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/templates"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const gkeHealthcheckPromptTemplate = `
+# GKE Cluster Health Check
+
+**1. Input Parameters:**
+  - Cluster Name: {{.clusterName}}
+  - Cluster Location: {{.clusterLocation}}
+
+**2. Your Role:**
+You are a GKE site reliability engineer. Your task is to review the current health of the specified GKE cluster and report the issues found, ranked by their impact on the workloads.
+
+**3. Information Gathering & Tools:**
+Use the ` + "`get_kubeconfig`" + ` tool first so that ` + "`kubectl`" + ` targets the cluster, then review every area below:
+  - **Control Plane:** Use the ` + "`gcloud_readonly`" + ` tool to get the cluster status and conditions, the running and recently failed operations, and whether control plane metrics are exported to Cloud Monitoring. Use the ` + "`get_control_plane_logs`" + ` tool to find errors of the control plane components over the past hour, e.g. API server request failures or latency warnings, etcd or webhook timeouts.
+  - **Nodes:** Use the ` + "`kubectl_get`" + ` tool to list the nodes and their conditions. Report nodes which are NotReady or unschedulable, or have the MemoryPressure, DiskPressure or PIDPressure condition, and node pools with fewer nodes than expected.
+  - **System Components:** Use the ` + "`check_system_components`" + ` tool for the GKE-managed workloads of kube-system, e.g. konnectivity-agent, kube-dns and metrics-server. A degraded component affects the whole cluster, report it first. When konnectivity-agent is degraded, or webhook calls time out, use the ` + "`check_control_plane_connectivity`" + ` tool.
+  - **Pods:** Use the ` + "`kubectl_get`" + ` tool to list the pods of all namespaces. Use the ` + "`analyze_pending_pods`" + ` tool for the pods Pending for more than a few minutes and the reasons they are not scheduled. Report pods in CrashLoopBackOff, ImagePullBackOff or Error, and containers with a high restart count or OOMKilled as last termination reason.
+  - **Events:** Use the ` + "`get_events`" + ` tool to list the Warning events of all namespaces, and look for the repeated ones, e.g. FailedScheduling, FailedMount, BackOff, Unhealthy or FailedCreatePodSandBox.
+  - **API Resource Pressure:** Use the ` + "`get_api_object_counts`" + ` tool for the number of objects per resource type and the etcd database size, and report the resource types approaching a scalability limit.
+  - **Quota Pressure:** Use the ` + "`check_quota_headroom`" + ` tool for the Compute Engine quotas and the ` + "`get_ip_utilization`" + ` tool for the node, pod and service IP ranges, and report those close to their limit.
+
+**4. Report Format:**
+  - **Summary:** The overall health of the cluster, healthy, degraded or unhealthy, in one sentence.
+  - **Ranked Issues:** A numbered list of the issues, the most impactful first. For every issue give its severity (critical, high, medium or low), the affected objects, the evidence gathered and the recommended next step.
+  - **Checked Areas:** The areas found healthy, so that the reader knows what has been reviewed.
+
+**5. Principles:**
+  - Base the report SOLELY on the data gathered from the cluster.
+  - Rank issues affecting running workloads or the control plane above those which only risk future capacity.
+  - Do not change the cluster, only output the commands for the user to review and run.
+  - Do not read or write any local files generating the report.
+`
+
+var gkeHealthcheckTmpl = template.Must(template.New("gke-healthcheck").Parse(gkeHealthcheckPromptTemplate))
+
+const (
+	clusterNameArgName     = "cluster_name"
+	clusterLocationArgName = "cluster_location"
+)
+
+type handlers struct {
+	c *config.Config
+	// listClusters lists the clusters the user can choose from when the
+	// cluster arguments are missing.
+	listClusters promptargs.ClusterLister
+}
+
+// Install registers the health check prompt with the MCP server.
+func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
+	tmpl, err := templates.Load(c.PromptsDir(), "gke-healthcheck", gkeHealthcheckPromptTemplate, []string{"clusterName", "clusterLocation"})
+	if err != nil {
+		return err
+	}
+	gkeHealthcheckTmpl = tmpl
+
+	h := &handlers{c: c, listClusters: promptargs.NewClusterLister(c)}
+
+	s.AddPrompt(&mcp.Prompt{
+		Name:        "gke:healthcheck",
+		Description: "Review the health of a GKE cluster and rank the issues found.",
+		Arguments: []*mcp.PromptArgument{
+			{
+				Name:        clusterNameArgName,
+				Description: "A name of a GKE cluster user want to check. Defaults to the configured default cluster.",
+				Required:    false,
+			},
+			{
+				Name:        clusterLocationArgName,
+				Description: "A location of a GKE cluster user want to check. Defaults to the configured default location.",
+				Required:    false,
+			},
+		},
+	}, h.gkeHealthcheckHandler)
+
+	return nil
+}
+
+// gkeHealthcheckHandler is the handler function for the /gke:healthcheck prompt
+func (h *handlers) gkeHealthcheckHandler(ctx context.Context, request *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	// Missing cluster arguments are asked to the user rather than failing the prompt.
+	clusterName, clusterLocation, note := promptargs.Cluster(ctx, request, h.c, h.listClusters, clusterNameArgName, clusterLocationArgName)
+
+	var buf bytes.Buffer
+	buf.WriteString(note)
+	if err := gkeHealthcheckTmpl.Execute(&buf, map[string]string{
+		"clusterName":     clusterName,
+		"clusterLocation": clusterLocation,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to execute prompt template: %w", err)
+	}
+
+	return &mcp.GetPromptResult{
+		Description: "GKE Cluster Health Check Prompt",
+		Messages: []*mcp.PromptMessage{
+			{
+				Content: &mcp.TextContent{
+					Text: buf.String(),
+				},
+				Role: "user",
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package podsecuritymigration provides a prompt template for planning the
+// migration from PodSecurityPolicy to Pod Security Admission.
+package podsecuritymigration
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/templates"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const gkePodSecurityMigrationPromptTemplate = `
+# GKE PodSecurityPolicy to Pod Security Admission Migration Plan
+
+**1. Input Parameters:**
+  - Cluster Name: {{.clusterName}}
+  - Cluster Location: {{.clusterLocation}}
+
+**2. Your Role:**
+You are a GKE security expert. Your task is to generate a migration plan from PodSecurityPolicy (PSP) to Pod Security Admission (PSA) for the specified GKE cluster. PodSecurityPolicy was removed in Kubernetes 1.25, the cluster cannot be upgraded to 1.25 or later while PodSecurityPolicies are in use.
+
+**3. Information Gathering & Tools:**
+  - **Cluster Details:** Use the ` + "`gcloud_readonly`" + ` tool to get the control plane version and check whether the PodSecurityPolicy controller is enabled.
+  - **Credentials:** Use the ` + "`get_kubeconfig`" + ` tool so that ` + "`kubectl`" + ` targets the cluster.
+  - **PSP Usage:** Use the ` + "`check_pod_security_migration`" + ` tool to list PodSecurityPolicies with the equivalent Pod Security Standards level, the namespaces of the pods they admit, and namespaces lacking Pod Security Admission labels.
+
+**4. Migration Plan:**
+For every namespace which is not managed by GKE, produce:
+  - The Pod Security Standards level to enforce, based on the least restrictive policy admitting its pods. Prefer a more restrictive level if the workloads allow it.
+  - The ` + "`kubectl label`" + ` commands setting the ` + "`pod-security.kubernetes.io/warn`" + ` and ` + "`pod-security.kubernetes.io/audit`" + ` labels first, then the ` + "`pod-security.kubernetes.io/enforce`" + ` label.
+  - A ` + "`kubectl label --dry-run=server`" + ` command showing which existing pods would violate the level.
+  - The workload changes needed when pods require more than the chosen level, e.g. dropping capabilities or setting ` + "`runAsNonRoot`" + `, or a dedicated namespace for privileged workloads.
+
+**5. Rollout Order:**
+  1. Label all namespaces in warn and audit mode and fix the reported violations.
+  2. Label all namespaces in enforce mode.
+  3. Disable the PodSecurityPolicy controller with ` + "`gcloud container clusters update --no-enable-pod-security-policy`" + ` and delete the PodSecurityPolicies, their ClusterRoles and RoleBindings.
+  4. Upgrade the cluster to 1.25 or later.
+
+**6. Principles:**
+  - Base the plan SOLELY on the data gathered from the cluster.
+  - Do not change the cluster, only output the commands for the user to review and run.
+  - Do not read or write any local files generating the plan.
+`
+
+var gkePodSecurityMigrationTmpl = template.Must(template.New("gke-pod-security-migration").Parse(gkePodSecurityMigrationPromptTemplate))
+
+const (
+	clusterNameArgName     = "cluster_name"
+	clusterLocationArgName = "cluster_location"
+)
+
+type handlers struct {
+	c *config.Config
+}
+
+// Install registers the pod security migration prompt with the MCP server.
+func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
+	tmpl, err := templates.Load(c.PromptsDir(), "gke-pod-security-migration", gkePodSecurityMigrationPromptTemplate, []string{"clusterName", "clusterLocation"})
+	if err != nil {
+		return err
+	}
+	gkePodSecurityMigrationTmpl = tmpl
+
+	h := &handlers{c: c}
+
+	s.AddPrompt(&mcp.Prompt{
+		Name:        "gke:pod-security-migration",
+		Description: "Generate a plan to migrate a GKE cluster from PodSecurityPolicy to Pod Security Admission.",
+		Arguments: []*mcp.PromptArgument{
+			{
+				Name:        clusterNameArgName,
+				Description: "A name of a GKE cluster user want to migrate. Defaults to the configured default cluster.",
+				Required:    false,
+			},
+			{
+				Name:        clusterLocationArgName,
+				Description: "A location of a GKE cluster user want to migrate. Defaults to the configured default location.",
+				Required:    false,
+			},
+		},
+	}, h.gkePodSecurityMigrationHandler)
+
+	return nil
+}
+
+// gkePodSecurityMigrationHandler is the handler function for the /gke:pod-security-migration prompt
+func (h *handlers) gkePodSecurityMigrationHandler(ctx context.Context, request *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	clusterName := strings.TrimSpace(request.Params.Arguments[clusterNameArgName])
+	if clusterName == "" {
+		clusterName = h.c.DefaultCluster(ctx)
+	}
+	if clusterName == "" {
+		return nil, fmt.Errorf("argument '%s' cannot be empty", clusterNameArgName)
+	}
+	clusterLocation := strings.TrimSpace(request.Params.Arguments[clusterLocationArgName])
+	if clusterLocation == "" {
+		clusterLocation = h.c.DefaultLocation(ctx)
+	}
+	if clusterLocation == "" {
+		return nil, fmt.Errorf("argument '%s' cannot be empty", clusterLocationArgName)
+	}
+
+	var buf bytes.Buffer
+	if err := gkePodSecurityMigrationTmpl.Execute(&buf, map[string]string{
+		"clusterName":     clusterName,
+		"clusterLocation": clusterLocation,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to execute prompt template: %w", err)
+	}
+
+	return &mcp.GetPromptResult{
+		Description: "GKE Pod Security Migration Plan Prompt",
+		Messages: []*mcp.PromptMessage{
+			{
+				Content: &mcp.TextContent{
+					Text: buf.String(),
+				},
+				Role: "user",
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podsecuritymigration
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestGkePodSecurityMigrationHandler_Success(t *testing.T) {
+	req := &mcp.GetPromptRequest{
+		Params: &mcp.GetPromptParams{
+			Arguments: map[string]string{
+				"cluster_name":     "my-cluster",
+				"cluster_location": "us-central1",
+			},
+		},
+	}
+
+	result, err := (&handlers{c: &config.Config{}}).gkePodSecurityMigrationHandler(context.Background(), req)
+	if err != nil {
+		t.Fatalf("gkePodSecurityMigrationHandler() error = %v", err)
+	}
+
+	if len(result.Messages) == 0 {
+		t.Fatal("Expected at least one message in result")
+	}
+
+	content := result.Messages[0].Content.(*mcp.TextContent)
+	for _, want := range []string{"my-cluster", "us-central1", "check_pod_security_migration"} {
+		if !strings.Contains(content.Text, want) {
+			t.Errorf("Expected prompt to contain %q", want)
+		}
+	}
+}
+
+func TestGkePodSecurityMigrationHandler_EmptyClusterName(t *testing.T) {
+	req := &mcp.GetPromptRequest{
+		Params: &mcp.GetPromptParams{
+			Arguments: map[string]string{
+				"cluster_name":     " ",
+				"cluster_location": "us-central1",
+			},
+		},
+	}
+
+	_, err := (&handlers{c: &config.Config{}}).gkePodSecurityMigrationHandler(context.Background(), req)
+	if err == nil {
+		t.Error("Expected error for empty cluster_name, got nil")
+	}
+}
+
+func TestGkePodSecurityMigrationHandler_EmptyClusterLocation(t *testing.T) {
+	req := &mcp.GetPromptRequest{
+		Params: &mcp.GetPromptParams{
+			Arguments: map[string]string{
+				"cluster_name": "my-cluster",
+			},
+		},
+	}
+
+	_, err := (&handlers{c: &config.Config{}}).gkePodSecurityMigrationHandler(context.Background(), req)
+	if err == nil {
+		t.Error("Expected error for empty cluster_location, got nil")
+	}
+}
+
+func TestGkePodSecurityMigrationHandler_Defaults(t *testing.T) {
+	t.Setenv("CLOUDSDK_CONTAINER_CLUSTER", "default-cluster")
+	t.Setenv("CLOUDSDK_COMPUTE_REGION", "europe-west1")
+	h := &handlers{c: config.New("test")}
+	req := &mcp.GetPromptRequest{
+		Params: &mcp.GetPromptParams{
+			Arguments: map[string]string{},
+		},
+	}
+
+	result, err := h.gkePodSecurityMigrationHandler(context.Background(), req)
+	if err != nil {
+		t.Fatalf("gkePodSecurityMigrationHandler() error = %v", err)
+	}
+
+	content := result.Messages[0].Content.(*mcp.TextContent)
+	for _, want := range []string{"default-cluster", "europe-west1"} {
+		if !strings.Contains(content.Text, want) {
+			t.Errorf("Expected prompt to contain %q", want)
+		}
+	}
+}
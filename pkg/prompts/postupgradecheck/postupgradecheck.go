@@ -0,0 +1,149 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package postupgradecheck provides a prompt template for validating a GKE
+// cluster after an upgrade against its pre-upgrade baseline.
+package postupgradecheck
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/promptargs"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/templates"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const gkePostUpgradeCheckPromptTemplate = `
+# GKE Post-Upgrade Check
+
+**1. Input Parameters:**
+  - Cluster Name: {{.clusterName}}
+  - Cluster Location: {{.clusterLocation}}
+{{- if .baselineSnapshot}}
+  - Baseline Snapshot: {{.baselineSnapshot}}
+{{- end}}
+
+**2. Your Role:**
+You are a GKE site reliability engineer. Your task is to validate that the specified GKE cluster is healthy after an upgrade, and that nothing was lost or changed unexpectedly compared to its state before the upgrade.
+
+**3. Baseline Comparison:**
+{{- if .baselineSnapshot}}
+  - Use the ` + "`get_credentials`" + ` tool for the cluster, then the ` + "`snapshot_cluster`" + ` tool with the ` + "`post-upgrade`" + ` label to capture the current state of the cluster.
+  - Use the ` + "`diff_cluster_snapshots`" + ` tool with the 'Baseline Snapshot' as ` + "`before`" + ` and the new snapshot as ` + "`after`" + `.
+  - Expect the control plane and node pool versions to have changed. Report every other difference, e.g. removed workloads, CRDs or webhooks, node pools not RUNNING or fewer workloads, and check whether the user expected it.
+{{- else}}
+  - No baseline snapshot was given. Ask the user for the pre-upgrade snapshot saved by the ` + "`snapshot_cluster`" + ` tool, as recommended by the upgrade risk report. Without one, skip the comparison and only check the health of the cluster.
+{{- end}}
+
+**4. Health Checks:**
+  - **Versions:** Use the ` + "`gather_cluster_context`" + ` tool to confirm the control plane and node pools run the target version, and that no node pool upgrade is still in progress.
+  - **Nodes:** Use the ` + "`kubectl_get`" + ` tool to list the nodes and their conditions. Report nodes which are NotReady or unschedulable, or still run the previous version.
+  - **Pods:** Use the ` + "`kubectl_get`" + ` tool to list the pods of all namespaces. Report pods in CrashLoopBackOff or ImagePullBackOff, and containers restarted since the upgrade. Use the ` + "`analyze_pending_pods`" + ` tool for the Pending pods, aggregated by the reason they are not scheduled.
+  - **System Components:** Use the ` + "`check_system_components`" + ` tool to confirm the GKE-managed workloads of kube-system are ready after the upgrade, and the ` + "`check_control_plane_connectivity`" + ` tool to confirm the control plane still reaches the nodes, which admission webhooks, logs and exec need.
+  - **Load Balancing:** Use the ` + "`check_neg_health`" + ` tool to confirm the load balancers of the Services and Ingresses have healthy endpoints again after the nodes were recreated.
+  - **Events:** Use the ` + "`get_events`" + ` tool to list the Warning events since the upgrade, e.g. FailedScheduling, FailedMount, FailedCreatePodSandBox or webhook call failures.
+
+**5. Report Format:**
+  - **Summary:** Whether the upgrade succeeded, succeeded with issues, or failed, in one sentence.
+  - **Differences from the Baseline:** The differences found by the comparison, the unexpected ones first.
+  - **Issues:** A numbered list of the issues found, the most impactful first, with the affected objects, the evidence gathered and the recommended next step.
+
+**6. Principles:**
+  - Base the report SOLELY on the data gathered from the cluster and the snapshots.
+  - Do not change the cluster, only output the commands for the user to review and run.
+  - Apart from the snapshot saved by the ` + "`snapshot_cluster`" + ` tool, do not read or write any local files generating the report.
+`
+
+var gkePostUpgradeCheckTmpl = template.Must(template.New("gke-post-upgrade-check").Parse(gkePostUpgradeCheckPromptTemplate))
+
+const (
+	clusterNameArgName      = "cluster_name"
+	clusterLocationArgName  = "cluster_location"
+	baselineSnapshotArgName = "baseline_snapshot"
+)
+
+type handlers struct {
+	c *config.Config
+	// listClusters lists the clusters the user can choose from when the
+	// cluster arguments are missing.
+	listClusters promptargs.ClusterLister
+}
+
+// Install registers the post-upgrade check prompt with the MCP server.
+func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
+	tmpl, err := templates.Load(c.PromptsDir(), "gke-post-upgrade-check", gkePostUpgradeCheckPromptTemplate, []string{"clusterName", "clusterLocation", "baselineSnapshot"})
+	if err != nil {
+		return err
+	}
+	gkePostUpgradeCheckTmpl = tmpl
+
+	h := &handlers{c: c, listClusters: promptargs.NewClusterLister(c)}
+
+	s.AddPrompt(&mcp.Prompt{
+		Name:        "gke:post-upgrade-check",
+		Description: "Validate a GKE cluster after an upgrade against its pre-upgrade baseline snapshot.",
+		Arguments: []*mcp.PromptArgument{
+			{
+				Name:        clusterNameArgName,
+				Description: "A name of a GKE cluster user upgraded. Defaults to the configured default cluster.",
+				Required:    false,
+			},
+			{
+				Name:        clusterLocationArgName,
+				Description: "A location of a GKE cluster user upgraded. Defaults to the configured default location.",
+				Required:    false,
+			},
+			{
+				Name:        baselineSnapshotArgName,
+				Description: "The snapshot of the cluster saved by the snapshot_cluster tool before the upgrade.",
+				Required:    false,
+			},
+		},
+	}, h.gkePostUpgradeCheckHandler)
+
+	return nil
+}
+
+// gkePostUpgradeCheckHandler is the handler function for the /gke:post-upgrade-check prompt
+func (h *handlers) gkePostUpgradeCheckHandler(ctx context.Context, request *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	// Missing cluster arguments are asked to the user rather than failing the prompt.
+	clusterName, clusterLocation, note := promptargs.Cluster(ctx, request, h.c, h.listClusters, clusterNameArgName, clusterLocationArgName)
+
+	var buf bytes.Buffer
+	buf.WriteString(note)
+	if err := gkePostUpgradeCheckTmpl.Execute(&buf, map[string]string{
+		"clusterName":      clusterName,
+		"clusterLocation":  clusterLocation,
+		"baselineSnapshot": strings.TrimSpace(request.Params.Arguments[baselineSnapshotArgName]),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to execute prompt template: %w", err)
+	}
+
+	return &mcp.GetPromptResult{
+		Description: "GKE Post-Upgrade Check Prompt",
+		Messages: []*mcp.PromptMessage{
+			{
+				Content: &mcp.TextContent{
+					Text: buf.String(),
+				},
+				Role: "user",
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postupgradecheck
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestGkePostUpgradeCheckHandler_Success(t *testing.T) {
+	req := &mcp.GetPromptRequest{
+		Params: &mcp.GetPromptParams{
+			Arguments: map[string]string{
+				"cluster_name":      "my-cluster",
+				"cluster_location":  "us-central1",
+				"baseline_snapshot": "my-cluster-pre-upgrade-20250601T120000Z.json",
+			},
+		},
+	}
+
+	result, err := (&handlers{c: &config.Config{}}).gkePostUpgradeCheckHandler(context.Background(), req)
+	if err != nil {
+		t.Fatalf("gkePostUpgradeCheckHandler() error = %v", err)
+	}
+
+	if len(result.Messages) == 0 {
+		t.Fatal("Expected at least one message in result")
+	}
+
+	content := result.Messages[0].Content.(*mcp.TextContent)
+	for _, want := range []string{"my-cluster", "us-central1", "Baseline Snapshot: my-cluster-pre-upgrade-20250601T120000Z.json", "diff_cluster_snapshots", "check_neg_health", "Differences from the Baseline"} {
+		if !strings.Contains(content.Text, want) {
+			t.Errorf("Expected prompt to contain %q", want)
+		}
+	}
+}
+
+func TestGkePostUpgradeCheckHandler_NoBaseline(t *testing.T) {
+	req := &mcp.GetPromptRequest{
+		Params: &mcp.GetPromptParams{
+			Arguments: map[string]string{
+				"cluster_name":     "my-cluster",
+				"cluster_location": "us-central1",
+			},
+		},
+	}
+
+	result, err := (&handlers{c: &config.Config{}}).gkePostUpgradeCheckHandler(context.Background(), req)
+	if err != nil {
+		t.Fatalf("gkePostUpgradeCheckHandler() error = %v", err)
+	}
+
+	content := result.Messages[0].Content.(*mcp.TextContent)
+	if !strings.Contains(content.Text, "No baseline snapshot was given") {
+		t.Errorf("Expected prompt to ask for the baseline snapshot, got:\n%s", content.Text)
+	}
+	if strings.Contains(content.Text, "Baseline Snapshot:") {
+		t.Errorf("Expected prompt not to list an empty baseline snapshot")
+	}
+}
+
+func TestGkePostUpgradeCheckHandler_EmptyClusterName(t *testing.T) {
+	req := &mcp.GetPromptRequest{
+		Params: &mcp.GetPromptParams{
+			Arguments: map[string]string{
+				"cluster_name":     " ",
+				"cluster_location": "us-central1",
+			},
+		},
+	}
+
+	result, err := (&handlers{c: &config.Config{}}).gkePostUpgradeCheckHandler(context.Background(), req)
+	if err != nil {
+		t.Fatalf("gkePostUpgradeCheckHandler() error = %v", err)
+	}
+	if text := result.Messages[0].Content.(*mcp.TextContent).Text; !strings.Contains(text, "ask the user which GKE cluster to use") {
+		t.Errorf("Expected prompt to ask the user for the cluster for empty cluster_name")
+	}
+}
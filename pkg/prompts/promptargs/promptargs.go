@@ -0,0 +1,191 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package promptargs asks users for the cluster arguments missing from
+// prompts, instead of failing the prompts.
+package promptargs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+
+	container "cloud.google.com/go/container/apiv1"
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// UnknownCluster and UnknownLocation stand for the cluster the user still
+	// has to choose in the text of prompts.
+	UnknownCluster  = "(the cluster chosen by the user)"
+	UnknownLocation = "(the location of the cluster chosen by the user)"
+)
+
+// ClusterLister returns the clusters of a project in all locations.
+type ClusterLister func(ctx context.Context, projectID string) ([]*containerpb.Cluster, error)
+
+// NewClusterLister returns a lister calling the GKE API, with a client
+// created on first use.
+func NewClusterLister(c *config.Config) ClusterLister {
+	var once sync.Once
+	var cmClient *container.ClusterManagerClient
+	var clientErr error
+	return func(ctx context.Context, projectID string) ([]*containerpb.Cluster, error) {
+		once.Do(func() {
+			cmClient, clientErr = container.NewClusterManagerClient(context.WithoutCancel(ctx), c.ClientOptions()...)
+		})
+		if clientErr != nil {
+			return nil, fmt.Errorf("failed to create cluster manager client: %w", clientErr)
+		}
+		resp, err := cmClient.ListClusters(ctx, &containerpb.ListClustersRequest{
+			Parent: fmt.Sprintf("projects/%s/locations/-", projectID),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list clusters: %w", err)
+		}
+		return resp.Clusters, nil
+	}
+}
+
+// Cluster returns the name and location of the cluster of a prompt, from the
+// arguments, the defaults, the clusters of the default project, or else by
+// asking the user with an elicitation if the client supports it. When the
+// cluster remains unknown, it returns UnknownCluster and UnknownLocation, and
+// a note asking the model to let the user choose among their clusters, to put
+// before the text of the prompt. list may be nil.
+func Cluster(ctx context.Context, req *mcp.GetPromptRequest, c *config.Config, list ClusterLister, nameArg, locationArg string) (name, location, note string) {
+	name = strings.TrimSpace(req.Params.Arguments[nameArg])
+	if name == "" {
+		name = c.DefaultCluster(ctx)
+	}
+	location = strings.TrimSpace(req.Params.Arguments[locationArg])
+	if location == "" {
+		location = c.DefaultLocation(ctx)
+	}
+	if name != "" && location != "" {
+		return name, location, ""
+	}
+
+	projectID := c.DefaultProjectID(ctx)
+	var clusters []*containerpb.Cluster
+	if list != nil && projectID != "" {
+		var err error
+		if clusters, err = list(ctx, projectID); err != nil {
+			slog.Debug("Failed to list the clusters of a prompt", "error", err)
+		}
+	}
+	if name != "" {
+		if location = uniqueLocation(clusters, name); location != "" {
+			return name, location, ""
+		}
+	}
+
+	if name, location = elicit(ctx, req, clusters, nameArg, locationArg, name); name != "" {
+		if location == "" {
+			location = uniqueLocation(clusters, name)
+		}
+		if location != "" {
+			return name, location, ""
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("The cluster is not specified. Before anything else, ask the user which GKE cluster to use")
+	if len(clusters) > 0 {
+		fmt.Fprintf(&b, ", among the clusters of project %s:\n", projectID)
+		for _, cluster := range clusters {
+			fmt.Fprintf(&b, "- %s (%s)\n", cluster.Name, cluster.Location)
+		}
+		b.WriteString("\nThen")
+	} else {
+		b.WriteString(", with the `list_clusters` tool to list their clusters. Then")
+	}
+	b.WriteString(" use its name and location as the cluster name and location below.\n")
+	return UnknownCluster, UnknownLocation, b.String()
+}
+
+// uniqueLocation returns the location of the only cluster with the name, if
+// any.
+func uniqueLocation(clusters []*containerpb.Cluster, name string) string {
+	location := ""
+	for _, cluster := range clusters {
+		if cluster.Name != name {
+			continue
+		}
+		if location != "" {
+			return ""
+		}
+		location = cluster.Location
+	}
+	return location
+}
+
+// elicit asks the user for the name and location of the cluster, if the
+// client supports elicitation, and returns the empty strings otherwise.
+func elicit(ctx context.Context, req *mcp.GetPromptRequest, clusters []*containerpb.Cluster, nameArg, locationArg, name string) (string, string) {
+	if req.Session == nil {
+		return "", ""
+	}
+	if params := req.Session.InitializeParams(); params == nil || params.Capabilities == nil || params.Capabilities.Elicitation == nil {
+		return "", ""
+	}
+
+	nameProperty := map[string]any{
+		"type":        "string",
+		"title":       "Cluster name",
+		"description": "Name of the GKE cluster.",
+	}
+	var names []string
+	for _, cluster := range clusters {
+		if !slices.Contains(names, cluster.Name) {
+			names = append(names, cluster.Name)
+		}
+	}
+	if len(names) > 0 {
+		nameProperty["enum"] = names
+	}
+	if name != "" {
+		nameProperty["default"] = name
+	}
+	res, err := req.Session.Elicit(ctx, &mcp.ElicitParams{
+		Message: "Which GKE cluster should be used?",
+		RequestedSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				nameArg: nameProperty,
+				locationArg: map[string]any{
+					"type":        "string",
+					"title":       "Cluster location",
+					"description": "Region or zone of the GKE cluster, e.g. us-central1. Can be left empty if no other cluster has the same name.",
+				},
+			},
+			"required": []string{nameArg},
+		},
+	})
+	if err != nil {
+		slog.Debug("Failed to elicit the cluster of a prompt", "error", err)
+		return "", ""
+	}
+	if res.Action != "accept" {
+		return "", ""
+	}
+	name, _ = res.Content[nameArg].(string)
+	location, _ := res.Content[locationArg].(string)
+	return strings.TrimSpace(name), strings.TrimSpace(location)
+}
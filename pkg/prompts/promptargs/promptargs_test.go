@@ -0,0 +1,105 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promptargs
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func fakeLister(context.Context, string) ([]*containerpb.Cluster, error) {
+	return []*containerpb.Cluster{
+		{Name: "prod", Location: "us-central1"},
+		{Name: "staging", Location: "us-central1"},
+		{Name: "staging", Location: "europe-west1"},
+	}, nil
+}
+
+func TestCluster(t *testing.T) {
+	t.Setenv("GOOGLE_CLOUD_PROJECT", "my-project")
+	c := config.New("test")
+
+	tests := []struct {
+		name                   string
+		args                   map[string]string
+		wantName, wantLocation string
+		wantNote               string
+	}{
+		{"arguments", map[string]string{"cluster_name": "prod", "cluster_location": "us-east1"}, "prod", "us-east1", ""},
+		{"unique name", map[string]string{"cluster_name": "prod"}, "prod", "us-central1", ""},
+		{"ambiguous name", map[string]string{"cluster_name": "staging"}, UnknownCluster, UnknownLocation, "- staging (europe-west1)"},
+		{"missing arguments", nil, UnknownCluster, UnknownLocation, "among the clusters of project my-project:\n- prod (us-central1)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &mcp.GetPromptRequest{Params: &mcp.GetPromptParams{Arguments: tt.args}}
+			name, location, note := Cluster(context.Background(), req, c, fakeLister, "cluster_name", "cluster_location")
+			if name != tt.wantName || location != tt.wantLocation {
+				t.Errorf("Cluster() = %q, %q, want %q, %q", name, location, tt.wantName, tt.wantLocation)
+			}
+			if !strings.Contains(note, tt.wantNote) || (tt.wantNote == "") != (note == "") {
+				t.Errorf("Cluster() note = %q, want it to contain %q", note, tt.wantNote)
+			}
+		})
+	}
+}
+
+func TestClusterElicitation(t *testing.T) {
+	t.Setenv("GOOGLE_CLOUD_PROJECT", "my-project")
+	ctx := context.Background()
+	c := config.New("test")
+
+	var got, gotNote string
+	s := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	s.AddPrompt(&mcp.Prompt{Name: "report"}, func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		name, location, note := Cluster(ctx, req, c, fakeLister, "cluster_name", "cluster_location")
+		got, gotNote = name+"/"+location, note
+		return &mcp.GetPromptResult{}, nil
+	})
+
+	var message string
+	client := mcp.NewClient(&mcp.Implementation{Name: "client"}, &mcp.ClientOptions{
+		ElicitationHandler: func(_ context.Context, req *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+			message = req.Params.Message
+			return &mcp.ElicitResult{Action: "accept", Content: map[string]any{"cluster_name": "prod"}}, nil
+		},
+	})
+	ct, st := mcp.NewInMemoryTransports()
+	ss, err := s.Connect(ctx, st, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = ss.Close() }()
+	cs, err := client.Connect(ctx, ct, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = cs.Close() }()
+
+	if _, err := cs.GetPrompt(ctx, &mcp.GetPromptParams{Name: "report"}); err != nil {
+		t.Fatalf("GetPrompt() error = %v", err)
+	}
+	if message == "" {
+		t.Error("Cluster() didn't elicit the cluster")
+	}
+	if got != "prod/us-central1" || gotNote != "" {
+		t.Errorf("Cluster() = %q with note %q, want prod/us-central1 without note", got, gotNote)
+	}
+}
@@ -19,8 +19,15 @@ import (
 	"context"
 
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/autopilotassessment"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/cost"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/deploy"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/drreview"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/healthcheck"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/podsecuritymigration"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/postupgradecheck"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/releasechannel"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/triage"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/upgraderiskreport"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/upgradesbestpracticesriskreport"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -31,8 +38,15 @@ type installer func(ctx context.Context, s *mcp.Server, c *config.Config) error
 // Install registers all prompt handlers with the MCP server.
 func Install(ctx context.Context, s *mcp.Server, c *config.Config) error {
 	installers := []installer{
+		autopilotassessment.Install,
 		cost.Install,
 		deploy.Install,
+		drreview.Install,
+		healthcheck.Install,
+		podsecuritymigration.Install,
+		postupgradecheck.Install,
+		releasechannel.Install,
+		triage.Install,
 		upgraderiskreport.Install,
 		upgradesbestpracticesriskreport.Install,
 	}
@@ -0,0 +1,132 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package releasechannel provides a prompt template for recommending the
+// release channel and auto-upgrade configuration of a GKE cluster.
+package releasechannel
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"text/template"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/promptargs"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/templates"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const gkeReleaseChannelPromptTemplate = `
+# GKE Release Channel Recommendation
+
+**1. Input Parameters:**
+  - Cluster Name: {{.clusterName}}
+  - Cluster Location: {{.clusterLocation}}
+
+**2. Your Role:**
+You are a GKE expert. Your task is to assess how much change the specified GKE cluster tolerates, and to recommend a release channel and an auto-upgrade configuration matching it. The Rapid channel gets new features and versions first, Regular balances availability and stability, Stable favors stability, and Extended keeps a minor version for up to 24 months at an additional cost.
+
+**3. Information Gathering & Tools:**
+  - **Cluster Configuration:** Use the ` + "`gather_cluster_context`" + ` tool for the versions, node pools, add-ons, CRDs and webhooks, and the ` + "`gcloud_readonly`" + ` tool for the current release channel, maintenance window and exclusions, and node pool auto-upgrade and upgrade strategy settings.
+  - **Channel Versions:** Use the ` + "`gcloud_readonly`" + ` tool with the ` + "`get-server-config`" + ` command for the default and available versions of every channel in the cluster location.
+  - **Upgrade History:** Use the ` + "`gcloud_readonly`" + ` tool to list the upgrade operations of the cluster, and the ` + "`estimate_upgrade_duration`" + ` tool for their durations. Note the failed, rolled back or manually triggered upgrades.
+  - **Workload Criticality:** Use the ` + "`check_critical_workloads`" + ` tool for the production-critical workloads, and the ` + "`find_deprecated_apis`" + ` tool for the workloads which would break on the next minor versions.
+  - **Add-on Dependencies:** Note the third-party operators, service meshes, CNI plugins, admission webhooks and CRDs pinning supported Kubernetes versions, as they lag behind new minor versions.
+  - **Notifications:** Use the ` + "`get_upgrade_notifications`" + ` tool to check whether the team is notified of upcoming upgrades.
+
+**4. Recommendation Format:**
+  - **Change Tolerance:** Low, medium or high, with the evidence from the cluster supporting it.
+  - **Recommended Release Channel:** The channel, and whether it differs from the current one. When switching, tell whether the cluster version is available in the new channel or the switch waits for the next upgrade.
+  - **Auto-upgrade Configuration:** The maintenance window, the maintenance exclusions for the freeze periods of the team, e.g. ` + "`no_minor_upgrades`" + ` or ` + "`no_minor_or_node_upgrades`" + `, the node pool upgrade strategy, and the notification setup, with the ` + "`gcloud container clusters update`" + ` commands applying them.
+  - **Tradeoffs:** A table comparing the current and recommended channels on time to new features and security fixes, upgrade frequency, version support period, and cost.
+  - **Prerequisites:** The changes needed before switching, e.g. upgrading add-ons or migrating deprecated APIs.
+
+**5. Principles:**
+  - Base the recommendation SOLELY on the data gathered from the cluster.
+  - Never recommend leaving a cluster without a release channel, GKE still upgrades such clusters at the end of support.
+  - Do not change the cluster, only output the commands for the user to review and run.
+  - Do not read or write any local files generating the recommendation.
+`
+
+var gkeReleaseChannelTmpl = template.Must(template.New("gke-release-channel").Parse(gkeReleaseChannelPromptTemplate))
+
+const (
+	clusterNameArgName     = "cluster_name"
+	clusterLocationArgName = "cluster_location"
+)
+
+type handlers struct {
+	c *config.Config
+	// listClusters lists the clusters the user can choose from when the
+	// cluster arguments are missing.
+	listClusters promptargs.ClusterLister
+}
+
+// Install registers the release channel recommendation prompt with the MCP server.
+func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
+	tmpl, err := templates.Load(c.PromptsDir(), "gke-release-channel", gkeReleaseChannelPromptTemplate, []string{"clusterName", "clusterLocation"})
+	if err != nil {
+		return err
+	}
+	gkeReleaseChannelTmpl = tmpl
+
+	h := &handlers{c: c, listClusters: promptargs.NewClusterLister(c)}
+
+	s.AddPrompt(&mcp.Prompt{
+		Name:        "gke:release-channel",
+		Description: "Recommend a release channel and auto-upgrade configuration for a GKE cluster based on its tolerance for change.",
+		Arguments: []*mcp.PromptArgument{
+			{
+				Name:        clusterNameArgName,
+				Description: "A name of a GKE cluster user want a recommendation for. Defaults to the configured default cluster.",
+				Required:    false,
+			},
+			{
+				Name:        clusterLocationArgName,
+				Description: "A location of a GKE cluster user want a recommendation for. Defaults to the configured default location.",
+				Required:    false,
+			},
+		},
+	}, h.gkeReleaseChannelHandler)
+
+	return nil
+}
+
+// gkeReleaseChannelHandler is the handler function for the /gke:release-channel prompt
+func (h *handlers) gkeReleaseChannelHandler(ctx context.Context, request *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	// Missing cluster arguments are asked to the user rather than failing the prompt.
+	clusterName, clusterLocation, note := promptargs.Cluster(ctx, request, h.c, h.listClusters, clusterNameArgName, clusterLocationArgName)
+
+	var buf bytes.Buffer
+	buf.WriteString(note)
+	if err := gkeReleaseChannelTmpl.Execute(&buf, map[string]string{
+		"clusterName":     clusterName,
+		"clusterLocation": clusterLocation,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to execute prompt template: %w", err)
+	}
+
+	return &mcp.GetPromptResult{
+		Description: "GKE Release Channel Recommendation Prompt",
+		Messages: []*mcp.PromptMessage{
+			{
+				Content: &mcp.TextContent{
+					Text: buf.String(),
+				},
+				Role: "user",
+			},
+		},
+	}, nil
+}
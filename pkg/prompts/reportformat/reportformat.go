@@ -0,0 +1,72 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package reportformat provides the output format argument of the report
+// prompts, so that reports can be pasted into the tools teams use for change
+// management.
+package reportformat
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ArgName is the name of the output format argument.
+const ArgName = "output_format"
+
+// Formats are the output formats of the reports, the first one being the
+// default.
+var Formats = []string{"markdown", "json", "slack", "html"}
+
+// instructions tell the model how to write the report in each format.
+var instructions = map[string]string{
+	"markdown": "Write the report in markdown, following the structure above.",
+	"json": "Write the report as a single JSON object with a `risks` array holding one object per risk, whose keys are the snake_case titles of the " +
+		"structure above (e.g. `title`, `description`) and whose values are markdown strings, except `affected_resources`, an array of objects with `namespace`, " +
+		"`kind`, `name` and `owner` keys. Output only the JSON object, without a code fence or any text around it.",
+	"slack": "Write the report in Slack mrkdwn: `*bold*` lines instead of headings, `•` bullets, `<url|text>` links and triple backticks for commands, " +
+		"without tables. Keep each risk under 3000 characters so that it fits into a single Slack message block.",
+	"html": "Write the report as a self-contained HTML fragment: an `<h2>` per risk title, an `<h3>` per section, `<pre><code>` for commands and " +
+		"`<a>` for links, without `<html>`, `<head>` or `<body>` tags, scripts or external stylesheets.",
+}
+
+// Argument returns the output format argument of a report prompt.
+func Argument() *mcp.PromptArgument {
+	return &mcp.PromptArgument{
+		Name:        ArgName,
+		Description: "The format of the report: " + strings.Join(Formats, ", ") + ". Defaults to " + Formats[0] + ".",
+		Required:    false,
+	}
+}
+
+// Parse returns the output format requested in the arguments of a prompt, or
+// the default one.
+func Parse(args map[string]string) (string, error) {
+	format := strings.ToLower(strings.TrimSpace(args[ArgName]))
+	if format == "" {
+		return Formats[0], nil
+	}
+	if !slices.Contains(Formats, format) {
+		return "", fmt.Errorf("argument '%s' must be one of %s", ArgName, strings.Join(Formats, ", "))
+	}
+	return format, nil
+}
+
+// Instructions returns how to write a report in the output format.
+func Instructions(format string) string {
+	return instructions[format]
+}
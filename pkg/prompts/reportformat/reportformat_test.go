@@ -0,0 +1,58 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reportformat
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		arg     string
+		want    string
+		wantErr bool
+	}{
+		{arg: "", want: "markdown"},
+		{arg: "json", want: "json"},
+		{arg: " Slack ", want: "slack"},
+		{arg: "html", want: "html"},
+		{arg: "pdf", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := Parse(map[string]string{ArgName: tt.arg})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Parse(%q) error = %v, wantErr %v", tt.arg, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %q, want %q", tt.arg, got, tt.want)
+		}
+	}
+}
+
+func TestInstructions(t *testing.T) {
+	for _, format := range Formats {
+		if Instructions(format) == "" {
+			t.Errorf("Instructions(%q) is empty", format)
+		}
+	}
+}
+
+func TestInstructions_JSONAffectedResources(t *testing.T) {
+	if got := Instructions("json"); !strings.Contains(got, "`affected_resources`, an array of objects") {
+		t.Errorf("Instructions(\"json\") = %q, want the affected resources as an array", got)
+	}
+}
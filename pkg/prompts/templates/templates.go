@@ -0,0 +1,76 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package templates loads the templates of the prompts, which organizations
+// can override or extend with their own policies and runbook conventions.
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"text/template"
+)
+
+// Load returns the template of a prompt. The file <name>.md of the directory,
+// if any, replaces the built-in text, and the file <name>.append.md is
+// appended to it. The template is validated by executing it with the keys of
+// the data of the prompt, so that it can only use these keys.
+func Load(dir, name, builtin string, keys []string) (*template.Template, error) {
+	text := builtin
+	if dir != "" {
+		override, err := readFile(filepath.Join(dir, name+".md"))
+		if err != nil {
+			return nil, err
+		}
+		if override != "" {
+			text = override
+		}
+		appended, err := readFile(filepath.Join(dir, name+".append.md"))
+		if err != nil {
+			return nil, err
+		}
+		text += appended
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template of prompt %s: %w", name, err)
+	}
+	data := make(map[string]string, len(keys))
+	for _, key := range keys {
+		data[key] = key
+	}
+	if err := tmpl.Execute(io.Discard, data); err != nil {
+		return nil, fmt.Errorf("invalid template of prompt %s: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// readFile returns the content of the file, or the empty string if it doesn't
+// exist.
+func readFile(name string) (string, error) {
+	// #nosec G304
+	data, err := os.ReadFile(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read prompt template: %w", err)
+	}
+	return string(data), nil
+}
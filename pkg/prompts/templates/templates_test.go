@@ -0,0 +1,101 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	const builtin = "Review {{.clusterName}}."
+	tests := []struct {
+		name    string
+		files   map[string]string
+		dir     string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "no override",
+			want: "Review my-cluster.",
+		},
+		{
+			name:  "override file",
+			files: map[string]string{"gke-test.md": "Check {{.clusterName}} against our runbook."},
+			want:  "Check my-cluster against our runbook.",
+		},
+		{
+			name:  "append file",
+			files: map[string]string{"gke-test.append.md": "\nFollow the change policy of {{.clusterName}}."},
+			want:  "Review my-cluster.\nFollow the change policy of my-cluster.",
+		},
+		{
+			name:  "override and append files",
+			files: map[string]string{"gke-test.md": "Check {{.clusterName}}.", "gke-test.append.md": " Then report."},
+			want:  "Check my-cluster. Then report.",
+		},
+		{
+			name:    "unknown key",
+			files:   map[string]string{"gke-test.md": "Review {{.clusterName}} in {{.region}}."},
+			wantErr: "invalid template of prompt gke-test",
+		},
+		{
+			name:    "syntax error",
+			files:   map[string]string{"gke-test.append.md": "{{if .clusterName}}"},
+			wantErr: "failed to parse template of prompt gke-test",
+		},
+		{
+			name: "missing directory",
+			dir:  filepath.Join(t.TempDir(), "missing"),
+			want: "Review my-cluster.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := tt.dir
+			if tt.files != nil {
+				dir = t.TempDir()
+				for name, content := range tt.files {
+					if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			tmpl, err := Load(dir, "gke-test", builtin, []string{"clusterName"})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+
+			var b strings.Builder
+			if err := tmpl.Execute(&b, map[string]string{"clusterName": "my-cluster"}); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("Load() template renders %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
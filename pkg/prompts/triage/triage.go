@@ -0,0 +1,142 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package triage provides a prompt template for triaging GKE incidents.
+package triage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/promptargs"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/templates"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const gkeTriagePromptTemplate = `
+# GKE Incident Triage
+
+**1. Input Parameters:**
+  - Cluster Name: {{.clusterName}}
+  - Cluster Location: {{.clusterLocation}}
+  - Symptom: {{.symptom}}
+
+**2. Your Role:**
+You are a GKE on-call engineer triaging an ongoing incident. Your task is to narrow down the causes of the reported symptom systematically, from the broadest to the most specific, and to tell the user what to check next. Speed matters more than completeness, stop gathering data once the evidence points to a cause.
+
+**3. Triage Steps:**
+  1. **Scope:** Restate the symptom, when it started and which workloads, namespaces or users it affects. Ask the user only if the symptom doesn't tell it and the data can't.
+  2. **Recent Changes:** Use the ` + "`gcloud_readonly`" + ` tool to list the cluster operations of the past day, e.g. upgrades, node pool resizes or configuration updates, and the cluster and node pool configuration. Use the ` + "`query_logs`" + ` tool to find the admin activity audit logs of the cluster and of its workloads around the start of the symptom, e.g. Deployment rollouts or changed ConfigMaps.
+  3. **Cluster State:** Use the ` + "`get_kubeconfig`" + ` tool so that ` + "`kubectl`" + ` targets the cluster, then the ` + "`get_events`" + ` tool for the Warning events of the affected namespaces, and the ` + "`kubectl_get`" + ` tool to check the affected workloads, their pods and the nodes they run on. Use the ` + "`analyze_pending_pods`" + ` tool when pods are Pending.
+  4. **Logs:** Use the ` + "`get_log_schema`" + ` tool to learn the fields of the log types, then the ` + "`query_logs`" + ` tool to read the container logs of the affected workloads and the node and autoscaler logs over the incident window. Use the ` + "`get_control_plane_logs`" + ` tool for the API server, scheduler and controller manager logs.
+  5. **Metrics:** Use the ` + "`list_monitored_resource_descriptors`" + ` tool to find the monitored resources of the affected objects and tell the user which metrics would confirm the hypotheses, e.g. CPU throttling, memory usage near limits or load balancer error rates.
+  6. **Known Issues:** Use the ` + "`get_gke_release_notes`" + ` tool to check whether the versions of the cluster have known issues matching the symptom.
+
+**4. Report Format:**
+  - **Timeline:** The events gathered in chronological order, with their timestamp and source, marking the first occurrence of the symptom.
+  - **Hypotheses:** The likely causes, the most likely first, each with the supporting and contradicting evidence and a confidence level (high, medium or low).
+  - **Next Diagnostic Steps:** The commands or checks to confirm or rule out every hypothesis, the fastest first.
+  - **Mitigation:** The immediate actions limiting the impact while the cause is confirmed, e.g. rolling back a rollout or scaling a node pool, if the evidence supports them.
+
+**5. Principles:**
+  - Base the timeline and the hypotheses SOLELY on the data gathered, and tell apart facts from assumptions.
+  - Do not change the cluster, only output the commands for the user to review and run.
+  - Do not read or write any local files during the triage.
+`
+
+var gkeTriageTmpl = template.Must(template.New("gke-triage").Parse(gkeTriagePromptTemplate))
+
+const (
+	clusterNameArgName     = "cluster_name"
+	clusterLocationArgName = "cluster_location"
+	symptomArgName         = "symptom"
+)
+
+type handlers struct {
+	c *config.Config
+	// listClusters lists the clusters the user can choose from when the
+	// cluster arguments are missing.
+	listClusters promptargs.ClusterLister
+}
+
+// Install registers the incident triage prompt with the MCP server.
+func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
+	tmpl, err := templates.Load(c.PromptsDir(), "gke-triage", gkeTriagePromptTemplate, []string{"clusterName", "clusterLocation", "symptom"})
+	if err != nil {
+		return err
+	}
+	gkeTriageTmpl = tmpl
+
+	h := &handlers{c: c, listClusters: promptargs.NewClusterLister(c)}
+
+	s.AddPrompt(&mcp.Prompt{
+		Name:        "gke:triage",
+		Description: "Triage a GKE incident from a symptom, and output a timeline, the likely causes and the next diagnostic steps.",
+		Arguments: []*mcp.PromptArgument{
+			{
+				Name:        symptomArgName,
+				Description: "A description of the symptom, e.g. '502 errors on the checkout service since 10:00 UTC'.",
+				Required:    true,
+			},
+			{
+				Name:        clusterNameArgName,
+				Description: "A name of the affected GKE cluster. Defaults to the configured default cluster.",
+				Required:    false,
+			},
+			{
+				Name:        clusterLocationArgName,
+				Description: "A location of the affected GKE cluster. Defaults to the configured default location.",
+				Required:    false,
+			},
+		},
+	}, h.gkeTriageHandler)
+
+	return nil
+}
+
+// gkeTriageHandler is the handler function for the /gke:triage prompt
+func (h *handlers) gkeTriageHandler(ctx context.Context, request *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	symptom := strings.TrimSpace(request.Params.Arguments[symptomArgName])
+	if symptom == "" {
+		return nil, fmt.Errorf("argument '%s' cannot be empty", symptomArgName)
+	}
+	// Missing cluster arguments are asked to the user rather than failing the prompt.
+	clusterName, clusterLocation, note := promptargs.Cluster(ctx, request, h.c, h.listClusters, clusterNameArgName, clusterLocationArgName)
+
+	var buf bytes.Buffer
+	buf.WriteString(note)
+	if err := gkeTriageTmpl.Execute(&buf, map[string]string{
+		"clusterName":     clusterName,
+		"clusterLocation": clusterLocation,
+		"symptom":         symptom,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to execute prompt template: %w", err)
+	}
+
+	return &mcp.GetPromptResult{
+		Description: "GKE Incident Triage Prompt",
+		Messages: []*mcp.PromptMessage{
+			{
+				Content: &mcp.TextContent{
+					Text: buf.String(),
+				},
+				Role: "user",
+			},
+		},
+	}, nil
+}
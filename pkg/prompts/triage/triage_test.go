@@ -0,0 +1,75 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triage
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/promptargs"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestGkeTriageHandler(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "symptom",
+			args: map[string]string{"symptom": "502 errors on checkout", "cluster_name": "my-cluster", "cluster_location": "us-central1"},
+			want: []string{
+				"Cluster Name: my-cluster",
+				"Cluster Location: us-central1",
+				"Symptom: 502 errors on checkout",
+				"query_logs",
+				"Timeline",
+				"Next Diagnostic Steps",
+			},
+		},
+		{
+			name: "missing cluster",
+			args: map[string]string{"symptom": "pods pending"},
+			want: []string{"ask the user which GKE cluster to use", "Cluster Name: " + promptargs.UnknownCluster, "Symptom: pods pending"},
+		},
+		{
+			name:    "empty symptom",
+			args:    map[string]string{"symptom": " ", "cluster_name": "my-cluster", "cluster_location": "us-central1"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &mcp.GetPromptRequest{Params: &mcp.GetPromptParams{Arguments: tt.args}}
+			result, err := (&handlers{c: &config.Config{}}).gkeTriageHandler(context.Background(), req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("gkeTriageHandler() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			text := result.Messages[0].Content.(*mcp.TextContent).Text
+			for _, want := range tt.want {
+				if !strings.Contains(text, want) {
+					t.Errorf("gkeTriageHandler() = %q, want to contain %q", text, want)
+				}
+			}
+		})
+	}
+}
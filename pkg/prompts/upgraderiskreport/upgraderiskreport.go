@@ -19,10 +19,14 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"text/template"
 
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/promptargs"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/reportformat"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/templates"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -33,6 +37,12 @@ const gkeUpgradeRiskReportPromptTemplate = `
   - Cluster Name: {{.clusterName}}
   - Cluster Location: {{.clusterLocation}}
   - Target Version: {{.targetVersion}}
+{{- if .minSeverity}}
+  - Minimum Severity: {{.minSeverity}}
+{{- end}}
+{{- if .focusAreas}}
+  - Focus Areas: {{.focusAreas}}
+{{- end}}
 
 **2. Your Role:**
 You are a GKE expert. Your task is to generate a comprehensive upgrade risk report for the specified GKE cluster, analyzing the potential risks of upgrading from its current version to the 'Target Version'.
@@ -43,16 +53,36 @@ Produce a report outlining potential risks, and actionable recommendations to en
 **4. Handling Missing Target Version:**
 If 'Target Version' is not provided:
   a. State that the target version is required.
-  b. Use ` + "`gcloud container get-server-config`" + ` to fetch available GKE versions.
+  b. Use the ` + "`gcloud_readonly`" + ` tool with the ` + "`get-server-config`" + ` command to fetch available GKE versions.
   c. Filter this list to show only versions NEWER than the cluster's current control plane version and compatible with the cluster's release channel.
   d. Present these versions to the user to help them choose a 'Target Version'.
 
 **5. Information Gathering & Tools:**
 Assume you have the ability to run the following commands to gather necessary information:
-  - **Cluster Details:** Use ` + "`gcloud`" + ` to get cluster details like control plane version, release channel, node pool versions, etc.
-  - **In-Cluster Resources:** Use ` + "`kubectl`" + ` (after ` + "`gcloud container clusters get-credentials`" + `) for inspecting workloads, APIs in use, etc.
-  - **Kubernetes Changelogs:** Use the ` + "`get_k8s_changelog`" + ` tool to fetch kubernetes changelogs.
-  - **GKE Release Notes:** Use the ` + "`get_gke_release_notes`" + ` tool to fetch GKE release notes.
+  - **Cluster Details:** Use the ` + "`gcloud_readonly`" + ` tool to get cluster details like control plane version, release channel, node pool versions, etc.
+  - **In-Cluster Resources:** Use the ` + "`kubectl_get`" + ` tool (after the ` + "`get_kubeconfig`" + ` tool) for inspecting workloads, APIs in use, etc.
+  - **Kubernetes Changelogs:** Use the ` + "`get_k8s_changelog`" + ` tool to fetch kubernetes changelogs, with the ` + "`TargetKubernetesMinorVersion`" + ` argument to fetch the changelogs of all the minor versions of the upgrade at once.
+  - **Urgent Upgrade Notes:** Use the ` + "`get_k8s_urgent_upgrade_notes`" + ` tool with the same arguments first, to find the changes requiring action before the upgrade at a fraction of the size of the full changelogs.
+  - **Removed APIs:** Use the ` + "`get_k8s_deprecation_guide`" + ` tool with the current and target versions to list the Kubernetes APIs the upgrade removes and their replacements.
+  - **Keyword Search:** Use the ` + "`search_k8s_changes`" + ` tool with the current and target versions to find the changes of the features and components the cluster relies on, e.g. Ingress or a CSI driver.
+  - **GKE Release Notes:** Use the ` + "`get_gke_release_notes`" + ` tool to fetch GKE release notes, typed as features, changes, fixes and deprecations with the channels and versions they apply to.
+  - **GKE Known Issues:** Use the ` + "`get_gke_known_issues`" + ` tool with the current and target versions to include the issues Google acknowledges in the versions of the upgrade.
+  - **Node Pool Upgrade Settings:** Use the ` + "`get_node_pool_upgrade_settings`" + ` tool to get node pool upgrade strategies, estimated upgrade durations and temporary capacity needs.
+  - **Autoscaling Capacity:** Use the ` + "`get_autoscaler_config`" + ` tool to check that the autoscaler can add the capacity the pods evicted by node upgrades need: node pools at their maximum, node auto-provisioning limits and recent scale-up failures.
+  - **Upgrade Duration:** Use the ` + "`estimate_upgrade_duration`" + ` tool to estimate how long the upgrade takes and to propose a maintenance window.
+  - **Deprecated API Callers:** Use the ` + "`find_deprecated_api_calls`" + ` tool with the target version to name the user agents and service accounts still calling APIs the upgrade removes.
+  - **Critical Workloads:** Use the ` + "`check_critical_workloads`" + ` tool to find production-critical workloads which will incur downtime during node upgrades.
+  - **Priority and Preemption:** Use the ` + "`audit_priority_classes`" + ` tool to find production-critical workloads which can be preempted, or stay Pending, while node upgrades recreate nodes.
+  - **Topology Constraints:** Use the ` + "`check_topology_constraints`" + ` tool to find strict topology spread constraints and required pod anti-affinity which leave pods Pending mid-upgrade.
+  - **Zonal Topology:** Use the ` + "`check_zonal_topology`" + ` tool to find a zonal control plane, whose API is unavailable during the control plane upgrade, single-zone node pools and zone-pinned StatefulSets, and the workloads running in a single zone, which have no replica left if the zone is disrupted during the upgrade.
+  - **Control Plane Connectivity:** Use the ` + "`check_control_plane_connectivity`" + ` tool to check the konnectivity tunnel before the upgrade, and list the admission webhooks served in the cluster which fail if it breaks after the upgrade.
+  - **API Object Counts:** Use the ` + "`get_api_object_counts`" + ` tool to find resource types with huge object counts, or an etcd database close to its limit, which slow down the control plane upgrade and can make it time out.
+  - **Private Networking:** Use the ` + "`review_private_networking`" + ` tool to find private networking settings, e.g. Private Google Access, Cloud NAT or authorized networks, which prevent the nodes created by the upgrade from registering.
+  - **Firewall Rules:** Use the ` + "`validate_firewall_rules`" + ` tool to find blocked control plane, webhook, health check or node egress traffic, which shows up as admission failures or nodes not registering after the upgrade.
+  - **Service Exposure:** Use the ` + "`audit_service_exposure`" + ` tool with the target version to find LoadBalancer Services, Ingresses and Gateway API resources using deprecated annotations or APIs removed in the target version range.
+  - **Legacy Features:** Use the ` + "`detect_legacy_features`" + ` tool to find legacy ABAC, basic authentication, client certificate issuance or legacy metadata endpoints which block the upgrade or are security issues to fix alongside it.
+  - **Namespace Owners:** Use the ` + "`get_namespace_inventory`" + ` tool to find the teams owning the namespaces affected by each risk, and their criticality.
+  - **Pre-upgrade Baseline:** Use the ` + "`get_credentials`" + ` tool for the cluster, then the ` + "`snapshot_cluster`" + ` tool with the ` + "`pre-upgrade`" + ` label to capture a baseline of the versions, node pools, workloads, CRDs and webhooks before the upgrade. Note the snapshot file it returns.
 
 **6. Changelog Analysis:**
   - **Minor Versions:** Include changelogs for ALL minor versions from the current control plane minor version up to AND INCLUDING the target minor version. (e.g., 1.29.x to 1.31.y requires looking at changes in 1.29, 1.30, 1.31).
@@ -65,9 +95,14 @@ Assume you have the ability to run the following commands to gather necessary in
   - **Default Configuration Changes:** Modifications to defaults that could alter workload behavior.
   - **New Feature Interactions:** Potentially disruptive interactions between new features and existing setups.
   - Changes REQUIRING manual action before upgrade to prevent outages.
+  - **Workload Downtime:** Production-critical workloads with a single replica or without a PodDisruptionBudget.
+{{- if .focusAreas}}
+  - **Focus Areas:** Only report the risks related to {{.focusAreas}}, and skip the analysis of the unrelated changes and resources.
+{{- end}}
 
 **8. Report Format:**
-Present the risks as a single list, ordered by severity. Each risk item MUST follow this markdown structure:
+Present the risks as a single list, ordered by severity.
+{{- if .minSeverity}} Rate the severity of each risk as low, medium, high or critical, and leave out the risks below {{.minSeverity}}.{{end}} Each risk item MUST follow this markdown structure:
 
 ` + "```markdown" + `
 # Short Risk Title
@@ -76,20 +111,29 @@ Present the risks as a single list, ordered by severity. Each risk item MUST fol
 
 (Detailed description of the change and the potential risk it introduces for THIS specific upgrade)
 
+## Affected Resources
+
+(The namespaces and workloads affected by this risk found by the audit tools, one per line as ` + "`namespace/Kind/name`" + `, grouped by namespace with the owning team and criticality from the ` + "`get_namespace_inventory`" + ` tool. Write "Not determined" when the risk can't be attributed to specific resources.)
+
 ## Verification Recommendations
 
-(Clear, actionable steps or commands to check if the cluster is affected by this risk. Include example ` + "`kubectl`" + ` or ` + "`gcloud`" + ` commands where appropriate. Reference specific documentation links if possible.)
+(Clear, actionable steps or commands to check if the cluster is affected by this risk. Include example ` + "`kubectl`" + ` or ` + "`gcloud`" + ` commands where appropriate. Reference specific documentation links if possible. When the risk can show after the upgrade, e.g. as missing workloads, CRDs or webhooks, name what to look for in the comparison with the pre-upgrade baseline.)
 
 ## Mitigation Recommendations
 
 (Clear, actionable steps, configuration changes, or code adjustments to mitigate the risk BEFORE the upgrade. Provide examples and link to docs.)
 ` + "```" + `
 
+End the report with a **Post-upgrade Validation** section giving the pre-upgrade baseline snapshot file, and recommending to run the ` + "`gke:post-upgrade-check`" + ` command with it after the upgrade.
+
+**Output Format ({{.outputFormat}}):** {{.outputFormatInstructions}}
+
 **9. Principles:**
   - Be specific for each risk; avoid grouping unrelated issues.
+  - List every affected resource found by the tools under its risk, rather than a sample, so that each owning team can follow up on its own resources.
   - Ensure Verification and Mitigation steps are practical and provide sufficient detail for a GKE administrator to act upon.
   - Base the analysis SOLELY on the changes between the cluster's current version and the target version.
-  - Do not read or write any local files generating the report.
+  - Apart from the baseline snapshot saved by the ` + "`snapshot_cluster`" + ` tool, do not read or write any local files generating the report.
   - In the final report, keep only risks which have mitigation actions, ignore those which have no mitigation actions.
 
 `
@@ -100,52 +144,106 @@ const (
 	clusterNameArgName     = "cluster_name"
 	clusterLocationArgName = "cluster_location"
 	targetVersionArgName   = "target_version"
+	minSeverityArgName     = "min_severity"
+	focusAreasArgName      = "focus_areas"
+)
+
+var (
+	// severities are the severities of the risks, from the lowest.
+	severities = []string{"low", "medium", "high", "critical"}
+	// focusAreas are the areas the report can be restricted to.
+	focusAreas = []string{"apis", "networking", "storage", "security", "nodes", "workloads", "autoscaling", "observability"}
 )
 
+type handlers struct {
+	c *config.Config
+	// listClusters lists the clusters the user can choose from when the
+	// cluster arguments are missing.
+	listClusters promptargs.ClusterLister
+}
+
 // Install registers the upgrade risk report prompt with the MCP server.
-func Install(_ context.Context, s *mcp.Server, _ *config.Config) error {
+func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
+	tmpl, err := templates.Load(c.PromptsDir(), "gke-upgrade-risk-report", gkeUpgradeRiskReportPromptTemplate, []string{"clusterName", "clusterLocation", "targetVersion", "minSeverity", "focusAreas", "outputFormat", "outputFormatInstructions"})
+	if err != nil {
+		return err
+	}
+	gkeUpgradeRiskReportTmpl = tmpl
+
+	h := &handlers{c: c, listClusters: promptargs.NewClusterLister(c)}
+
 	s.AddPrompt(&mcp.Prompt{
 		Name:        "gke:upgrade-risk-report",
 		Description: "Generate GKE cluster upgrade risk report.",
 		Arguments: []*mcp.PromptArgument{
 			{
 				Name:        clusterNameArgName,
-				Description: "A name of a GKE cluster user want to upgrade.",
-				Required:    true,
+				Description: "A name of a GKE cluster user want to upgrade. Defaults to the configured default cluster.",
+				Required:    false,
 			},
 			{
 				Name:        clusterLocationArgName,
-				Description: "A location of a GKE cluster user want to upgrade.",
-				Required:    true,
+				Description: "A location of a GKE cluster user want to upgrade. Defaults to the configured default location.",
+				Required:    false,
 			},
 			{
 				Name:        targetVersionArgName,
 				Description: "A version user want to upgrade their cluster to.",
 				Required:    false,
 			},
+			{
+				Name:        minSeverityArgName,
+				Description: "The minimum severity of the reported risks: " + strings.Join(severities, ", ") + ". Defaults to all risks.",
+				Required:    false,
+			},
+			{
+				Name:        focusAreasArgName,
+				Description: "A comma-separated list of the areas the report focuses on: " + strings.Join(focusAreas, ", ") + ". Defaults to all areas.",
+				Required:    false,
+			},
+			reportformat.Argument(),
 		},
-	}, gkeUpgradeRiskReportHandler)
+	}, h.gkeUpgradeRiskReportHandler)
 
 	return nil
 }
 
 // gkeUpgradeRiskReportHandler is the handler function for the /gke:upgrade-risk-report prompt
-func gkeUpgradeRiskReportHandler(_ context.Context, request *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
-	clusterName := strings.TrimSpace(request.Params.Arguments[clusterNameArgName])
-	if clusterName == "" {
-		return nil, fmt.Errorf("argument '%s' cannot be empty", clusterNameArgName)
+func (h *handlers) gkeUpgradeRiskReportHandler(ctx context.Context, request *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	// Missing cluster arguments are asked to the user rather than failing the prompt.
+	clusterName, clusterLocation, note := promptargs.Cluster(ctx, request, h.c, h.listClusters, clusterNameArgName, clusterLocationArgName)
+	targetVersion := strings.TrimSpace(request.Params.Arguments[targetVersionArgName])
+	minSeverity := strings.ToLower(strings.TrimSpace(request.Params.Arguments[minSeverityArgName]))
+	if minSeverity != "" && !slices.Contains(severities, minSeverity) {
+		return nil, fmt.Errorf("argument '%s' must be one of %s", minSeverityArgName, strings.Join(severities, ", "))
 	}
-	clusterLocation := strings.TrimSpace(request.Params.Arguments[clusterLocationArgName])
-	if clusterLocation == "" {
-		return nil, fmt.Errorf("argument '%s' cannot be empty", clusterLocationArgName)
+	var areas []string
+	for _, area := range strings.Split(request.Params.Arguments[focusAreasArgName], ",") {
+		area = strings.ToLower(strings.TrimSpace(area))
+		if area == "" {
+			continue
+		}
+		if !slices.Contains(focusAreas, area) {
+			return nil, fmt.Errorf("unknown focus area %q in argument '%s', the areas are %s", area, focusAreasArgName, strings.Join(focusAreas, ", "))
+		}
+		areas = append(areas, area)
+	}
+
+	outputFormat, err := reportformat.Parse(request.Params.Arguments)
+	if err != nil {
+		return nil, err
 	}
-	targetVersion := strings.TrimSpace(request.Params.Arguments[targetVersionArgName])
 
 	var buf bytes.Buffer
+	buf.WriteString(note)
 	if err := gkeUpgradeRiskReportTmpl.Execute(&buf, map[string]string{
-		"clusterName":     clusterName,
-		"clusterLocation": clusterLocation,
-		"targetVersion":   targetVersion,
+		"clusterName":              clusterName,
+		"clusterLocation":          clusterLocation,
+		"targetVersion":            targetVersion,
+		"minSeverity":              minSeverity,
+		"focusAreas":               strings.Join(areas, ", "),
+		"outputFormat":             outputFormat,
+		"outputFormatInstructions": reportformat.Instructions(outputFormat),
 	}); err != nil {
 		return nil, fmt.Errorf("failed to execute prompt template: %w", err)
 	}
@@ -19,6 +19,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -33,7 +34,7 @@ func TestGkeUpgradeRiskReportHandler_Success(t *testing.T) {
 		},
 	}
 
-	result, err := gkeUpgradeRiskReportHandler(context.Background(), req)
+	result, err := (&handlers{c: &config.Config{}}).gkeUpgradeRiskReportHandler(context.Background(), req)
 	if err != nil {
 		t.Fatalf("gkeUpgradeRiskReportHandler() error = %v", err)
 	}
@@ -63,9 +64,12 @@ func TestGkeUpgradeRiskReportHandler_EmptyClusterName(t *testing.T) {
 		},
 	}
 
-	_, err := gkeUpgradeRiskReportHandler(context.Background(), req)
-	if err == nil {
-		t.Error("Expected error for empty cluster_name, got nil")
+	result, err := (&handlers{c: &config.Config{}}).gkeUpgradeRiskReportHandler(context.Background(), req)
+	if err != nil {
+		t.Fatalf("gkeUpgradeRiskReportHandler() error = %v", err)
+	}
+	if text := result.Messages[0].Content.(*mcp.TextContent).Text; !strings.Contains(text, "ask the user which GKE cluster to use") {
+		t.Errorf("Expected prompt to ask the user for the cluster for empty cluster_name")
 	}
 }
 
@@ -80,9 +84,12 @@ func TestGkeUpgradeRiskReportHandler_EmptyClusterLocation(t *testing.T) {
 		},
 	}
 
-	_, err := gkeUpgradeRiskReportHandler(context.Background(), req)
-	if err == nil {
-		t.Error("Expected error for empty cluster_location, got nil")
+	result, err := (&handlers{c: &config.Config{}}).gkeUpgradeRiskReportHandler(context.Background(), req)
+	if err != nil {
+		t.Fatalf("gkeUpgradeRiskReportHandler() error = %v", err)
+	}
+	if text := result.Messages[0].Content.(*mcp.TextContent).Text; !strings.Contains(text, "ask the user which GKE cluster to use") {
+		t.Errorf("Expected prompt to ask the user for the cluster for empty cluster_location")
 	}
 }
 
@@ -97,9 +104,12 @@ func TestGkeUpgradeRiskReportHandler_WhitespaceOnlyClusterName(t *testing.T) {
 		},
 	}
 
-	_, err := gkeUpgradeRiskReportHandler(context.Background(), req)
-	if err == nil {
-		t.Error("Expected error for whitespace-only cluster_name, got nil")
+	result, err := (&handlers{c: &config.Config{}}).gkeUpgradeRiskReportHandler(context.Background(), req)
+	if err != nil {
+		t.Fatalf("gkeUpgradeRiskReportHandler() error = %v", err)
+	}
+	if text := result.Messages[0].Content.(*mcp.TextContent).Text; !strings.Contains(text, "ask the user which GKE cluster to use") {
+		t.Errorf("Expected prompt to ask the user for the cluster for whitespace-only cluster_name")
 	}
 }
 
@@ -110,9 +120,12 @@ func TestGkeUpgradeRiskReportHandler_MissingArguments(t *testing.T) {
 		},
 	}
 
-	_, err := gkeUpgradeRiskReportHandler(context.Background(), req)
-	if err == nil {
-		t.Error("Expected error for missing arguments, got nil")
+	result, err := (&handlers{c: &config.Config{}}).gkeUpgradeRiskReportHandler(context.Background(), req)
+	if err != nil {
+		t.Fatalf("gkeUpgradeRiskReportHandler() error = %v", err)
+	}
+	if text := result.Messages[0].Content.(*mcp.TextContent).Text; !strings.Contains(text, "ask the user which GKE cluster to use") {
+		t.Errorf("Expected prompt to ask the user for the cluster for missing arguments")
 	}
 }
 
@@ -127,7 +140,7 @@ func TestGkeUpgradeRiskReportHandler_PromptDescription(t *testing.T) {
 		},
 	}
 
-	result, err := gkeUpgradeRiskReportHandler(context.Background(), req)
+	result, err := (&handlers{c: &config.Config{}}).gkeUpgradeRiskReportHandler(context.Background(), req)
 	if err != nil {
 		t.Fatalf("gkeUpgradeRiskReportHandler() error = %v", err)
 	}
@@ -148,7 +161,7 @@ func TestGkeUpgradeRiskReportHandler_MessageRole(t *testing.T) {
 		},
 	}
 
-	result, err := gkeUpgradeRiskReportHandler(context.Background(), req)
+	result, err := (&handlers{c: &config.Config{}}).gkeUpgradeRiskReportHandler(context.Background(), req)
 	if err != nil {
 		t.Fatalf("gkeUpgradeRiskReportHandler() error = %v", err)
 	}
@@ -169,7 +182,7 @@ func TestGkeUpgradeRiskReportHandler_TemplateSections(t *testing.T) {
 		},
 	}
 
-	result, err := gkeUpgradeRiskReportHandler(context.Background(), req)
+	result, err := (&handlers{c: &config.Config{}}).gkeUpgradeRiskReportHandler(context.Background(), req)
 	if err != nil {
 		t.Fatalf("gkeUpgradeRiskReportHandler() error = %v", err)
 	}
@@ -181,6 +194,9 @@ func TestGkeUpgradeRiskReportHandler_TemplateSections(t *testing.T) {
 		"Input Parameters",
 		"Risk Identification",
 		"Report Format",
+		"Pre-upgrade Baseline",
+		"Post-upgrade Validation",
+		"Affected Resources",
 	}
 
 	for _, section := range expectedSections {
@@ -200,7 +216,7 @@ func TestGkeUpgradeRiskReportHandler_WithoutTargetVersion(t *testing.T) {
 		},
 	}
 
-	result, err := gkeUpgradeRiskReportHandler(context.Background(), req)
+	result, err := (&handlers{c: &config.Config{}}).gkeUpgradeRiskReportHandler(context.Background(), req)
 	if err != nil {
 		t.Fatalf("gkeUpgradeRiskReportHandler() error = %v", err)
 	}
@@ -222,7 +238,7 @@ func TestGkeUpgradeRiskReportHandler_NonEmptyResult(t *testing.T) {
 		},
 	}
 
-	result, err := gkeUpgradeRiskReportHandler(context.Background(), req)
+	result, err := (&handlers{c: &config.Config{}}).gkeUpgradeRiskReportHandler(context.Background(), req)
 	if err != nil {
 		t.Fatalf("gkeUpgradeRiskReportHandler() error = %v", err)
 	}
@@ -232,3 +248,88 @@ func TestGkeUpgradeRiskReportHandler_NonEmptyResult(t *testing.T) {
 		t.Error("Expected non-empty prompt text")
 	}
 }
+
+func TestGkeUpgradeRiskReportHandler_SeverityAndFocusAreas(t *testing.T) {
+	req := &mcp.GetPromptRequest{
+		Params: &mcp.GetPromptParams{
+			Arguments: map[string]string{
+				"cluster_name":     "my-cluster",
+				"cluster_location": "us-central1",
+				"target_version":   "1.28.0",
+				"min_severity":     "High",
+				"focus_areas":      "networking, Storage",
+			},
+		},
+	}
+
+	result, err := (&handlers{c: &config.Config{}}).gkeUpgradeRiskReportHandler(context.Background(), req)
+	if err != nil {
+		t.Fatalf("gkeUpgradeRiskReportHandler() error = %v", err)
+	}
+	text := result.Messages[0].Content.(*mcp.TextContent).Text
+	for _, want := range []string{"Minimum Severity: high", "leave out the risks below high", "Focus Areas: networking, storage", "related to networking, storage"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected prompt to contain %q", want)
+		}
+	}
+}
+
+func TestGkeUpgradeRiskReportHandler_NoSeverityAndFocusAreas(t *testing.T) {
+	req := &mcp.GetPromptRequest{
+		Params: &mcp.GetPromptParams{
+			Arguments: map[string]string{
+				"cluster_name":     "my-cluster",
+				"cluster_location": "us-central1",
+				"target_version":   "1.28.0",
+			},
+		},
+	}
+
+	result, err := (&handlers{c: &config.Config{}}).gkeUpgradeRiskReportHandler(context.Background(), req)
+	if err != nil {
+		t.Fatalf("gkeUpgradeRiskReportHandler() error = %v", err)
+	}
+	text := result.Messages[0].Content.(*mcp.TextContent).Text
+	for _, unwanted := range []string{"Minimum Severity", "Focus Areas"} {
+		if strings.Contains(text, unwanted) {
+			t.Errorf("Expected prompt not to contain %q", unwanted)
+		}
+	}
+}
+
+func TestGkeUpgradeRiskReportHandler_InvalidSeverityAndFocusAreas(t *testing.T) {
+	for name, args := range map[string]map[string]string{
+		"severity":   {"min_severity": "urgent"},
+		"focus area": {"focus_areas": "networking,billing"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			args["cluster_name"] = "my-cluster"
+			args["cluster_location"] = "us-central1"
+			req := &mcp.GetPromptRequest{Params: &mcp.GetPromptParams{Arguments: args}}
+			if _, err := (&handlers{c: &config.Config{}}).gkeUpgradeRiskReportHandler(context.Background(), req); err == nil {
+				t.Error("gkeUpgradeRiskReportHandler() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestGkeUpgradeRiskReportHandler_OutputFormat(t *testing.T) {
+	req := &mcp.GetPromptRequest{
+		Params: &mcp.GetPromptParams{
+			Arguments: map[string]string{
+				"cluster_name":     "my-cluster",
+				"cluster_location": "us-central1",
+				"target_version":   "1.28.0",
+				"output_format":    "JSON",
+			},
+		},
+	}
+
+	result, err := (&handlers{c: &config.Config{}}).gkeUpgradeRiskReportHandler(context.Background(), req)
+	if err != nil {
+		t.Fatalf("gkeUpgradeRiskReportHandler() error = %v", err)
+	}
+	if text := result.Messages[0].Content.(*mcp.TextContent).Text; !strings.Contains(text, "Output Format (json)") {
+		t.Errorf("Expected prompt to ask for a JSON report")
+	}
+}
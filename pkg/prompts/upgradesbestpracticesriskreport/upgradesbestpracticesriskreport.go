@@ -23,6 +23,8 @@ import (
 	"text/template"
 
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/reportformat"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts/templates"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -41,8 +43,10 @@ Produce a report outlining actual risks, and actionable recommendations on how t
 
 **4. Information Gathering & Tools:**
 Assume you have the ability to run the following commands to gather necessary information:
-  - **Cluster Details:** Use ` + "`gcloud`" + ` to get cluster details.
-  - **In-Cluster Resources:** Use ` + "`kubectl`" + ` (after ` + "`gcloud container clusters get-credentials`" + `) for inspecting workloads.
+  - **Cluster Details:** Use the ` + "`gcloud_readonly`" + ` tool to get cluster details.
+  - **In-Cluster Resources:** Use the ` + "`kubectl_get`" + ` tool (after the ` + "`get_kubeconfig`" + ` tool) for inspecting workloads.
+  - **Node Pool Upgrade Settings:** Use the ` + "`get_node_pool_upgrade_settings`" + ` tool to get node pool upgrade strategies, estimated upgrade durations and temporary capacity needs.
+  - **Namespace Owners:** Use the ` + "`get_namespace_inventory`" + ` tool to find the teams owning the namespaces of the affected workloads.
 
 **5. GKE Upgrades Best Practices:**
 
@@ -77,11 +81,17 @@ Present the risks as a single list. Each risk item MUST follow this markdown str
 
 (Detailed description of the risk)
 
+## Affected Resources
+
+(The node pools, namespaces and workloads not following the best practice, one per line as ` + "`namespace/Kind/name`" + ` or ` + "`NodePool/name`" + `, grouped by namespace with the owning team from the ` + "`get_namespace_inventory`" + ` tool.)
+
 ## Mitigation Recommendations
 
 (Clear, actionable steps, commands to to mitigate the risk. Provide examples and link to docs.)
 ` + "```" + `
 
+**Output Format ({{.outputFormat}}):** {{.outputFormatInstructions}}
+
 **9. Principles:**
   - Be specific for each risk; avoid grouping unrelated issues.
   - Ensure Mitigation steps are practical and provide sufficient detail for a GKE administrator to act upon.
@@ -96,43 +106,69 @@ const (
 	clusterLocationArgName = "cluster_location"
 )
 
+type handlers struct {
+	c *config.Config
+}
+
 // Install registers the upgrade best-practices prompt with the MCP server.
-func Install(_ context.Context, s *mcp.Server, _ *config.Config) error {
+func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
+	tmpl, err := templates.Load(c.PromptsDir(), "gke-upgrades-best-practices-risk-report", promptTemplate, []string{"clusterName", "clusterLocation", "outputFormat", "outputFormatInstructions"})
+	if err != nil {
+		return err
+	}
+	promptTmpl = tmpl
+
+	h := &handlers{c: c}
+
 	s.AddPrompt(&mcp.Prompt{
 		Name:        "gke:upgrades-best-practices-risk-report",
 		Description: "Generate GKE cluster upgrades best practices risk report.",
 		Arguments: []*mcp.PromptArgument{
 			{
 				Name:        clusterNameArgName,
-				Description: "A name of a GKE cluster user want to upgrade.",
-				Required:    true,
+				Description: "A name of a GKE cluster user want to upgrade. Defaults to the configured default cluster.",
+				Required:    false,
 			},
 			{
 				Name:        clusterLocationArgName,
-				Description: "A location of a GKE cluster user want to upgrade.",
-				Required:    true,
+				Description: "A location of a GKE cluster user want to upgrade. Defaults to the configured default location.",
+				Required:    false,
 			},
+			reportformat.Argument(),
 		},
-	}, gkeUpgradesBestPracticesRiskReportHandler)
+	}, h.gkeUpgradesBestPracticesRiskReportHandler)
 
 	return nil
 }
 
 // gkeUpgradesBestPracticesRiskReportHandler is the handler function for the /gke:upgrades-best-practices-risk-report prompt
-func gkeUpgradesBestPracticesRiskReportHandler(_ context.Context, request *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+func (h *handlers) gkeUpgradesBestPracticesRiskReportHandler(ctx context.Context, request *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
 	clusterName := strings.TrimSpace(request.Params.Arguments[clusterNameArgName])
+	if clusterName == "" {
+		clusterName = h.c.DefaultCluster(ctx)
+	}
 	if clusterName == "" {
 		return nil, fmt.Errorf("argument '%s' cannot be empty", clusterNameArgName)
 	}
 	clusterLocation := strings.TrimSpace(request.Params.Arguments[clusterLocationArgName])
+	if clusterLocation == "" {
+		clusterLocation = h.c.DefaultLocation(ctx)
+	}
 	if clusterLocation == "" {
 		return nil, fmt.Errorf("argument '%s' cannot be empty", clusterLocationArgName)
 	}
 
+	outputFormat, err := reportformat.Parse(request.Params.Arguments)
+	if err != nil {
+		return nil, err
+	}
+
 	var buf bytes.Buffer
 	if err := promptTmpl.Execute(&buf, map[string]string{
-		"clusterName":     clusterName,
-		"clusterLocation": clusterLocation,
+		"clusterName":              clusterName,
+		"clusterLocation":          clusterLocation,
+		"outputFormat":             outputFormat,
+		"outputFormatInstructions": reportformat.Instructions(outputFormat),
 	}); err != nil {
 		return nil, fmt.Errorf("failed to execute prompt template: %w", err)
 	}
@@ -19,6 +19,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -32,7 +33,7 @@ func TestGkeUpgradesBestPracticesRiskReportHandler_Success(t *testing.T) {
 		},
 	}
 
-	result, err := gkeUpgradesBestPracticesRiskReportHandler(context.Background(), req)
+	result, err := (&handlers{c: &config.Config{}}).gkeUpgradesBestPracticesRiskReportHandler(context.Background(), req)
 	if err != nil {
 		t.Fatalf("gkeUpgradesBestPracticesRiskReportHandler() error = %v", err)
 	}
@@ -61,7 +62,7 @@ func TestGkeUpgradesBestPracticesRiskReportHandler_EmptyClusterName(t *testing.T
 		},
 	}
 
-	_, err := gkeUpgradesBestPracticesRiskReportHandler(context.Background(), req)
+	_, err := (&handlers{c: &config.Config{}}).gkeUpgradesBestPracticesRiskReportHandler(context.Background(), req)
 	if err == nil {
 		t.Error("Expected error for empty cluster_name, got nil")
 	}
@@ -77,7 +78,7 @@ func TestGkeUpgradesBestPracticesRiskReportHandler_EmptyClusterLocation(t *testi
 		},
 	}
 
-	_, err := gkeUpgradesBestPracticesRiskReportHandler(context.Background(), req)
+	_, err := (&handlers{c: &config.Config{}}).gkeUpgradesBestPracticesRiskReportHandler(context.Background(), req)
 	if err == nil {
 		t.Error("Expected error for empty cluster_location, got nil")
 	}
@@ -93,7 +94,7 @@ func TestGkeUpgradesBestPracticesRiskReportHandler_WhitespaceOnlyClusterName(t *
 		},
 	}
 
-	_, err := gkeUpgradesBestPracticesRiskReportHandler(context.Background(), req)
+	_, err := (&handlers{c: &config.Config{}}).gkeUpgradesBestPracticesRiskReportHandler(context.Background(), req)
 	if err == nil {
 		t.Error("Expected error for whitespace-only cluster_name, got nil")
 	}
@@ -106,7 +107,7 @@ func TestGkeUpgradesBestPracticesRiskReportHandler_MissingArguments(t *testing.T
 		},
 	}
 
-	_, err := gkeUpgradesBestPracticesRiskReportHandler(context.Background(), req)
+	_, err := (&handlers{c: &config.Config{}}).gkeUpgradesBestPracticesRiskReportHandler(context.Background(), req)
 	if err == nil {
 		t.Error("Expected error for missing arguments, got nil")
 	}
@@ -122,7 +123,7 @@ func TestGkeUpgradesBestPracticesRiskReportHandler_PromptDescription(t *testing.
 		},
 	}
 
-	result, err := gkeUpgradesBestPracticesRiskReportHandler(context.Background(), req)
+	result, err := (&handlers{c: &config.Config{}}).gkeUpgradesBestPracticesRiskReportHandler(context.Background(), req)
 	if err != nil {
 		t.Fatalf("gkeUpgradesBestPracticesRiskReportHandler() error = %v", err)
 	}
@@ -142,7 +143,7 @@ func TestGkeUpgradesBestPracticesRiskReportHandler_MessageRole(t *testing.T) {
 		},
 	}
 
-	result, err := gkeUpgradesBestPracticesRiskReportHandler(context.Background(), req)
+	result, err := (&handlers{c: &config.Config{}}).gkeUpgradesBestPracticesRiskReportHandler(context.Background(), req)
 	if err != nil {
 		t.Fatalf("gkeUpgradesBestPracticesRiskReportHandler() error = %v", err)
 	}
@@ -162,7 +163,7 @@ func TestGkeUpgradesBestPracticesRiskReportHandler_TemplateSections(t *testing.T
 		},
 	}
 
-	result, err := gkeUpgradesBestPracticesRiskReportHandler(context.Background(), req)
+	result, err := (&handlers{c: &config.Config{}}).gkeUpgradesBestPracticesRiskReportHandler(context.Background(), req)
 	if err != nil {
 		t.Fatalf("gkeUpgradesBestPracticesRiskReportHandler() error = %v", err)
 	}
@@ -174,6 +175,7 @@ func TestGkeUpgradesBestPracticesRiskReportHandler_TemplateSections(t *testing.T
 		"Maintenance Windows",
 		"Pod Disruption Budgets",
 		"Node Pool Upgrades",
+		"Affected Resources",
 	}
 
 	for _, section := range expectedSections {
@@ -193,7 +195,7 @@ func TestGkeUpgradesBestPracticesRiskReportHandler_NonEmptyResult(t *testing.T)
 		},
 	}
 
-	result, err := gkeUpgradesBestPracticesRiskReportHandler(context.Background(), req)
+	result, err := (&handlers{c: &config.Config{}}).gkeUpgradesBestPracticesRiskReportHandler(context.Background(), req)
 	if err != nil {
 		t.Fatalf("gkeUpgradesBestPracticesRiskReportHandler() error = %v", err)
 	}
@@ -214,7 +216,7 @@ func TestGkeUpgradesBestPracticesRiskReportHandler_MitigationRecommendations(t *
 		},
 	}
 
-	result, err := gkeUpgradesBestPracticesRiskReportHandler(context.Background(), req)
+	result, err := (&handlers{c: &config.Config{}}).gkeUpgradesBestPracticesRiskReportHandler(context.Background(), req)
 	if err != nil {
 		t.Fatalf("gkeUpgradesBestPracticesRiskReportHandler() error = %v", err)
 	}
@@ -226,3 +228,34 @@ func TestGkeUpgradesBestPracticesRiskReportHandler_MitigationRecommendations(t *
 		t.Error("Expected prompt to contain Mitigation Recommendations section")
 	}
 }
+
+func TestGkeUpgradesBestPracticesRiskReportHandler_OutputFormat(t *testing.T) {
+	tests := []struct {
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{format: "", want: "Output Format (markdown)"},
+		{format: "slack", want: "Output Format (slack)"},
+		{format: "pdf", wantErr: true},
+	}
+	for _, tt := range tests {
+		req := &mcp.GetPromptRequest{
+			Params: &mcp.GetPromptParams{
+				Arguments: map[string]string{
+					"cluster_name":     "my-cluster",
+					"cluster_location": "us-central1",
+					"output_format":    tt.format,
+				},
+			},
+		}
+		result, err := (&handlers{c: &config.Config{}}).gkeUpgradesBestPracticesRiskReportHandler(context.Background(), req)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("gkeUpgradesBestPracticesRiskReportHandler() with format %q error = %v, wantErr %v", tt.format, err, tt.wantErr)
+			continue
+		}
+		if err == nil && !strings.Contains(result.Messages[0].Content.(*mcp.TextContent).Text, tt.want) {
+			t.Errorf("Expected prompt with format %q to contain %q", tt.format, tt.want)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package registry reads image manifests from container registries to find
+// the platforms an image is built for.
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+const dockerHubRegistry = "registry-1.docker.io"
+
+var (
+	manifestMediaTypes = []string{
+		"application/vnd.oci.image.index.v1+json",
+		"application/vnd.docker.distribution.manifest.list.v2+json",
+		"application/vnd.oci.image.manifest.v1+json",
+		"application/vnd.docker.distribution.manifest.v2+json",
+	}
+	challengeParamRegexp = regexp.MustCompile(`(\w+)="([^"]*)"`)
+)
+
+// Reference is a parsed container image reference.
+type Reference struct {
+	Registry   string
+	Repository string
+	// Reference is a tag or a digest.
+	Reference string
+}
+
+// ParseReference parses an image name such as nginx, gcr.io/project/image:tag
+// or us-docker.pkg.dev/project/repo/image@sha256:... into its parts.
+func ParseReference(image string) Reference {
+	ref := Reference{Registry: dockerHubRegistry, Reference: "latest"}
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		ref.Reference = name[i+1:]
+		name = name[:i]
+	} else if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		ref.Reference = name[i+1:]
+		name = name[:i]
+	}
+
+	if first, rest, found := strings.Cut(name, "/"); found && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		ref.Registry = first
+		name = rest
+	}
+	if ref.Registry == dockerHubRegistry && !strings.Contains(name, "/") {
+		name = "library/" + name
+	}
+	ref.Repository = name
+	return ref
+}
+
+// isGoogleRegistry reports whether the registry is Container Registry or Artifact Registry.
+func isGoogleRegistry(registry string) bool {
+	return registry == "gcr.io" || strings.HasSuffix(registry, ".gcr.io") || strings.HasSuffix(registry, "-docker.pkg.dev")
+}
+
+// Client reads image manifests from container registries.
+type Client struct {
+	httpClient *http.Client
+	scheme     string
+	// tokenSource provides credentials for Google registries.
+	tokenSource oauth2.TokenSource
+}
+
+// NewClient creates a registry client. Google registries are accessed with
+// the token source if not nil, other registries anonymously.
+func NewClient(ts oauth2.TokenSource) *Client {
+	return &Client{httpClient: http.DefaultClient, scheme: "https", tokenSource: ts}
+}
+
+type manifest struct {
+	MediaType string `json:"mediaType"`
+	Manifests []struct {
+		Platform struct {
+			Architecture string `json:"architecture"`
+			OS           string `json:"os"`
+			Variant      string `json:"variant"`
+		} `json:"platform"`
+	} `json:"manifests"`
+	Config struct {
+		Digest string `json:"digest"`
+	} `json:"config"`
+}
+
+type imageConfig struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+}
+
+// Platforms returns the sorted os/architecture platforms the image is built for.
+func (c *Client) Platforms(ctx context.Context, image string) ([]string, error) {
+	ref := ParseReference(image)
+	body, err := c.get(ctx, ref, "manifests/"+ref.Reference, strings.Join(manifestMediaTypes, ", "))
+	if err != nil {
+		return nil, err
+	}
+	var m manifest
+	if err := json.Unmarshal(body, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest of %s: %w", image, err)
+	}
+
+	var platforms []string
+	if len(m.Manifests) > 0 {
+		for _, p := range m.Manifests {
+			// Attestation manifests use the unknown platform.
+			if p.Platform.OS == "" || p.Platform.OS == "unknown" {
+				continue
+			}
+			platform := p.Platform.OS + "/" + p.Platform.Architecture
+			if p.Platform.Variant != "" {
+				platform += "/" + p.Platform.Variant
+			}
+			platforms = append(platforms, platform)
+		}
+		sort.Strings(platforms)
+		return platforms, nil
+	}
+
+	body, err = c.get(ctx, ref, "blobs/"+m.Config.Digest, "")
+	if err != nil {
+		return nil, err
+	}
+	var config imageConfig
+	if err := json.Unmarshal(body, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse image config of %s: %w", image, err)
+	}
+	return []string{config.OS + "/" + config.Architecture}, nil
+}
+
+// get fetches a registry API path of the repository, authenticating with a
+// bearer token if the registry requires it.
+func (c *Client) get(ctx context.Context, ref Reference, path, accept string) ([]byte, error) {
+	u := fmt.Sprintf("%s://%s/v2/%s/%s", c.scheme, ref.Registry, ref.Repository, path)
+	resp, err := c.do(ctx, u, accept, "")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		_ = resp.Body.Close()
+		token, err := c.token(ctx, ref, challenge)
+		if err != nil {
+			return nil, err
+		}
+		resp, err = c.do(ctx, u, accept, "Bearer "+token)
+		if err != nil {
+			return nil, err
+		}
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get %s: status code %d", u, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (c *Client) do(ctx context.Context, u, accept, authorization string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", u, err)
+	}
+	return resp, nil
+}
+
+// token requests a bearer token for pulling the repository from the realm of
+// a WWW-Authenticate challenge.
+func (c *Client) token(ctx context.Context, ref Reference, challenge string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("unsupported registry authentication challenge: %q", challenge)
+	}
+	values := map[string]string{}
+	for _, m := range challengeParamRegexp.FindAllStringSubmatch(params, -1) {
+		values[m[1]] = m[2]
+	}
+	if values["realm"] == "" {
+		return "", fmt.Errorf("registry authentication challenge has no realm: %q", challenge)
+	}
+
+	query := url.Values{}
+	if values["service"] != "" {
+		query.Set("service", values["service"])
+	}
+	query.Set("scope", fmt.Sprintf("repository:%s:pull", ref.Repository))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, values["realm"]+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	if isGoogleRegistry(ref.Registry) && c.tokenSource != nil {
+		t, err := c.tokenSource.Token()
+		if err != nil {
+			return "", fmt.Errorf("failed to get Google credentials: %w", err)
+		}
+		req.SetBasicAuth("oauth2accesstoken", t.AccessToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get registry token: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get registry token: status code %d", resp.StatusCode)
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to parse registry token: %w", err)
+	}
+	if token.Token != "" {
+		return token.Token, nil
+	}
+	return token.AccessToken, nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		image string
+		want  Reference
+	}{
+		{image: "nginx", want: Reference{Registry: "registry-1.docker.io", Repository: "library/nginx", Reference: "latest"}},
+		{image: "bitnami/redis:7.2", want: Reference{Registry: "registry-1.docker.io", Repository: "bitnami/redis", Reference: "7.2"}},
+		{image: "gcr.io/my-project/app:v1", want: Reference{Registry: "gcr.io", Repository: "my-project/app", Reference: "v1"}},
+		{image: "localhost:5000/app", want: Reference{Registry: "localhost:5000", Repository: "app", Reference: "latest"}},
+		{
+			image: "us-docker.pkg.dev/my-project/repo/app@sha256:abc",
+			want:  Reference{Registry: "us-docker.pkg.dev", Repository: "my-project/repo/app", Reference: "sha256:abc"},
+		},
+	}
+	for _, tt := range tests {
+		if diff := cmp.Diff(tt.want, ParseReference(tt.image)); diff != "" {
+			t.Errorf("ParseReference(%q) mismatch (-want +got):\n%s", tt.image, diff)
+		}
+	}
+}
+
+func TestPlatforms(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			if r.URL.Query().Get("scope") != "repository:multi:pull" && r.URL.Query().Get("scope") != "repository:single:pull" {
+				http.Error(w, "bad scope", http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `{"token": "secret"}`)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v2/multi/manifests/latest":
+			fmt.Fprint(w, `{"mediaType": "application/vnd.oci.image.index.v1+json", "manifests": [
+				{"platform": {"architecture": "arm64", "os": "linux", "variant": "v8"}},
+				{"platform": {"architecture": "amd64", "os": "linux"}},
+				{"platform": {"architecture": "unknown", "os": "unknown"}}
+			]}`)
+		case "/v2/single/manifests/v1":
+			fmt.Fprint(w, `{"mediaType": "application/vnd.oci.image.manifest.v1+json", "config": {"digest": "sha256:config"}}`)
+		case "/v2/single/blobs/sha256:config":
+			fmt.Fprint(w, `{"architecture": "amd64", "os": "linux"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	c := &Client{httpClient: server.Client(), scheme: "http"}
+
+	tests := []struct {
+		image   string
+		want    []string
+		wantErr bool
+	}{
+		{image: host + "/multi", want: []string{"linux/amd64", "linux/arm64/v8"}},
+		{image: host + "/single:v1", want: []string{"linux/amd64"}},
+		{image: host + "/missing:v1", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := c.Platforms(context.Background(), tt.image)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Platforms(%q) error = %v, wantErr %v", tt.image, err, tt.wantErr)
+			continue
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("Platforms(%q) mismatch (-want +got):\n%s", tt.image, diff)
+		}
+	}
+}
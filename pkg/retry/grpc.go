@@ -0,0 +1,52 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryClientInterceptor returns a gRPC interceptor retrying the Get and List
+// calls of Google APIs which fail with the Unavailable or ResourceExhausted
+// codes. Other calls, which may not be idempotent, aren't retried.
+func UnaryClientInterceptor(p Policy) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !idempotentMethod(method) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		return Do(ctx, p, func(ctx context.Context) error {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			switch status.Code(err) {
+			case codes.Unavailable, codes.ResourceExhausted:
+				return Retryable(err, 0)
+			default:
+				return err
+			}
+		})
+	}
+}
+
+// idempotentMethod reports whether the full gRPC method name, e.g.
+// /google.container.v1.ClusterManager/GetCluster, only reads resources.
+func idempotentMethod(method string) bool {
+	name := path.Base(method)
+	return strings.HasPrefix(name, "Get") || strings.HasPrefix(name, "List")
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Transport is an HTTP transport retrying the GET and HEAD requests which
+// fail or get a 429 or 5xx response.
+type Transport struct {
+	// Base is the transport making the requests, http.DefaultTransport if nil.
+	Base http.RoundTripper
+	// Policy configures the retries.
+	Policy Policy
+}
+
+// NewClient returns an HTTP client retrying requests with the default policy.
+func NewClient() *http.Client {
+	return &http.Client{Transport: &Transport{Policy: DefaultPolicy}}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	// Requests are only retried when they're idempotent and can be resent.
+	if (req.Method != http.MethodGet && req.Method != http.MethodHead) || (req.Body != nil && req.Body != http.NoBody) {
+		return base.RoundTrip(req)
+	}
+
+	// retried is the response with a retryable status of the last attempt,
+	// returned as is once the attempts are exhausted.
+	var retried *http.Response
+	var resp *http.Response
+	err := Do(req.Context(), t.Policy, func(context.Context) error {
+		if retried != nil {
+			discard(retried)
+			retried = nil
+		}
+		var err error
+		resp, err = base.RoundTrip(req)
+		if err != nil {
+			if req.Context().Err() != nil {
+				return err
+			}
+			return Retryable(err, 0)
+		}
+		if !retryableStatus(resp.StatusCode) {
+			return nil
+		}
+		retried = resp
+		return Retryable(fmt.Errorf("request failed with status %s", resp.Status), retryAfter(resp.Header.Get("Retry-After")))
+	})
+	if retried != nil {
+		if req.Context().Err() != nil {
+			discard(retried)
+			return nil, req.Context().Err()
+		}
+		return retried, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryAfter parses the delay of a Retry-After header, in seconds or as a date.
+func retryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
+
+// discard drains and closes the body of a response which is retried, so that
+// its connection can be reused.
+func discard(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	_ = resp.Body.Close()
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package retry retries the remote requests failing with transient errors,
+// such as 429 and 5xx responses, with exponential backoff and jitter.
+// Retries draw from a budget shared by all requests, so that an outage of a
+// remote service isn't made worse by a storm of retries.
+package retry
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// Policy configures the retries of a request.
+type Policy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	MaxAttempts int
+	// InitialBackoff is the maximum delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff bounds the delay before a retry.
+	MaxBackoff time.Duration
+	// Budget bounds the retries of all the requests of the policy.
+	Budget *Budget
+}
+
+// DefaultPolicy is the policy of the remote requests of the server.
+var DefaultPolicy = Policy{
+	MaxAttempts:    4,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+	Budget:         NewBudget(0.2, 10),
+}
+
+// backoff returns the delay before the retry following the given number of
+// attempts: a random duration up to an exponentially growing bound.
+func (p Policy) backoff(attempts int) time.Duration {
+	bound := p.InitialBackoff << (attempts - 1)
+	if bound <= 0 || bound > p.MaxBackoff {
+		bound = p.MaxBackoff
+	}
+	if bound <= 0 {
+		return 0
+	}
+	// #nosec G404 -- jitter doesn't need a cryptographic random source.
+	return rand.N(bound)
+}
+
+// Budget is a token bucket bounding the ratio of retries to requests. Every
+// request adds ratio tokens, up to max tokens, and every retry takes one.
+type Budget struct {
+	mu     sync.Mutex
+	ratio  float64
+	max    float64
+	tokens float64
+}
+
+// NewBudget returns a full budget allowing ratio retries per request, and
+// bursts of max retries.
+func NewBudget(ratio, max float64) *Budget {
+	return &Budget{ratio: ratio, max: max, tokens: max}
+}
+
+func (b *Budget) deposit() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = min(b.tokens+b.ratio, b.max)
+}
+
+func (b *Budget) withdraw() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// retryableError marks an error as transient.
+type retryableError struct {
+	err   error
+	after time.Duration
+}
+
+func (e *retryableError) Error() string { return e.err.Error() }
+func (e *retryableError) Unwrap() error { return e.err }
+
+// Retryable marks err as transient, so that Do retries the request, after
+// at least the given delay if it's positive, e.g. from a Retry-After header.
+func Retryable(err error, after time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	return &retryableError{err: err, after: after}
+}
+
+// Do calls fn until it succeeds, returns an error not marked with Retryable,
+// the attempts or the retry budget of the policy are exhausted, or ctx is
+// done. It returns the error of the last attempt.
+func Do(ctx context.Context, p Policy, fn func(ctx context.Context) error) error {
+	p.Budget.deposit()
+	for attempts := 1; ; attempts++ {
+		err := fn(ctx)
+		var retryable *retryableError
+		if !errors.As(err, &retryable) {
+			return err
+		}
+		if attempts >= p.MaxAttempts || !p.Budget.withdraw() {
+			return retryable.err
+		}
+		delay := max(p.backoff(attempts), min(retryable.after, p.MaxBackoff))
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(retryable.err, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
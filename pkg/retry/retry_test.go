@@ -0,0 +1,162 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var testPolicy = Policy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+func TestDo(t *testing.T) {
+	errTransient := errors.New("transient")
+	errPermanent := errors.New("permanent")
+
+	tests := []struct {
+		name         string
+		errs         []error
+		policy       Policy
+		wantErr      error
+		wantAttempts int
+	}{
+		{"success", []error{nil}, testPolicy, nil, 1},
+		{"transient then success", []error{Retryable(errTransient, 0), nil}, testPolicy, nil, 2},
+		{"permanent", []error{errPermanent}, testPolicy, errPermanent, 1},
+		{"attempts exhausted", []error{Retryable(errTransient, 0), Retryable(errTransient, 0), Retryable(errTransient, 0), nil}, testPolicy, errTransient, 3},
+		{"budget exhausted", []error{Retryable(errTransient, 0), nil}, Policy{MaxAttempts: 3, Budget: NewBudget(0.1, 0)}, errTransient, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			err := Do(context.Background(), tt.policy, func(context.Context) error {
+				err := tt.errs[attempts]
+				attempts++
+				return err
+			})
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("Do() error = %v, want %v", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("Do() made %d attempts, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestDoCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := Do(ctx, Policy{MaxAttempts: 3, InitialBackoff: time.Hour, MaxBackoff: time.Hour}, func(context.Context) error {
+		return Retryable(errors.New("transient"), 0)
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Do() error = %v, want the cancellation", err)
+	}
+}
+
+func TestBudget(t *testing.T) {
+	b := NewBudget(0.5, 1)
+	if !b.withdraw() || b.withdraw() {
+		t.Fatalf("a budget of 1 retry allowed %v", b.tokens)
+	}
+	b.deposit()
+	b.deposit()
+	if !b.withdraw() {
+		t.Errorf("two requests at a ratio of 0.5 didn't allow a retry")
+	}
+}
+
+func TestTransport(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: &Transport{Policy: testPolicy}}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || requests.Load() != 3 {
+		t.Errorf("Get() = %d after %d requests, want 200 after 3", resp.StatusCode, requests.Load())
+	}
+
+	requests.Store(-10)
+	resp, err = client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable || requests.Load() != -7 {
+		t.Errorf("Get() = %d after %d requests, want the last 503 after 3 attempts", resp.StatusCode, requests.Load()+10)
+	}
+
+	requests.Store(0)
+	resp, err = client.Post(srv.URL, "text/plain", nil)
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if requests.Load() != 1 {
+		t.Errorf("Post() made %d requests, want no retries", requests.Load())
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	if got := retryAfter("3"); got != 3*time.Second {
+		t.Errorf("retryAfter(3) = %v, want 3s", got)
+	}
+	if got := retryAfter("soon"); got != 0 {
+		t.Errorf("retryAfter(soon) = %v, want 0", got)
+	}
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	interceptor := UnaryClientInterceptor(testPolicy)
+	for _, tt := range []struct {
+		method       string
+		wantAttempts int
+	}{
+		{"/google.container.v1.ClusterManager/GetCluster", 3},
+		{"/google.container.v1.ClusterManager/CreateCluster", 1},
+	} {
+		attempts := 0
+		invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+			attempts++
+			return status.Error(codes.Unavailable, "unavailable")
+		}
+		err := interceptor(context.Background(), tt.method, nil, nil, nil, invoker)
+		if status.Code(err) != codes.Unavailable || attempts != tt.wantAttempts {
+			t.Errorf("%s: error = %v after %d attempts, want Unavailable after %d", tt.method, err, attempts, tt.wantAttempts)
+		}
+	}
+}
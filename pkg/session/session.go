@@ -0,0 +1,121 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package session keeps the state of each MCP session, such as the selected
+// project and kubeconfig context, so that multiple clients sharing one
+// long-running server don't interfere with each other.
+package session
+
+import (
+	"context"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// State is the state of an MCP session.
+type State struct {
+	// ProjectID, Location and Cluster override the configured defaults.
+	ProjectID string
+	Location  string
+	Cluster   string
+	// Kubeconfig is the kubeconfig file of the kubectl commands, the one set
+	// with kube.SetKubeconfig when empty.
+	Kubeconfig string
+	// KubeContext is the kubeconfig context of the kubectl commands, the
+	// current context of the kubeconfig when empty.
+	KubeContext string
+}
+
+var (
+	mu sync.RWMutex
+	// states is keyed by session rather than by session ID, which is empty
+	// for the stdio and SSE transports.
+	states = map[*mcp.ServerSession]State{}
+)
+
+// serverSession returns the session of the request, nil if it has none.
+func serverSession(req mcp.Request) *mcp.ServerSession {
+	if req == nil {
+		return nil
+	}
+	ss, _ := req.GetSession().(*mcp.ServerSession)
+	return ss
+}
+
+// Get returns the state of the session of the request.
+func Get(req mcp.Request) State {
+	mu.RLock()
+	defer mu.RUnlock()
+	return states[serverSession(req)]
+}
+
+// Update applies f to the state of the session of the request.
+func Update(req mcp.Request, f func(*State)) {
+	mu.Lock()
+	defer mu.Unlock()
+	ss := serverSession(req)
+	st := states[ss]
+	f(&st)
+	if st == (State{}) {
+		delete(states, ss)
+		return
+	}
+	states[ss] = st
+}
+
+func remove(ss *mcp.ServerSession) {
+	mu.Lock()
+	defer mu.Unlock()
+	delete(states, ss)
+}
+
+// Middleware applies the state of the session to its tool calls, prompts,
+// argument completions and resource reads, and drops the state once the
+// session is closed.
+func Middleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		switch method {
+		case "initialize":
+			if ss := serverSession(req); ss != nil {
+				go func() {
+					_ = ss.Wait()
+					remove(ss)
+				}()
+			}
+		case "tools/call", "prompts/get", "completion/complete", "resources/read":
+			ctx = WithState(ctx, Get(req))
+		}
+		return next(ctx, method, req)
+	}
+}
+
+// WithState returns a context making the tools and kubectl commands run with
+// it use the state of a session.
+func WithState(ctx context.Context, st State) context.Context {
+	ctx = config.WithDefaults(ctx, config.Defaults{
+		ProjectID: st.ProjectID,
+		Location:  st.Location,
+		Cluster:   st.Cluster,
+	})
+	if st.Kubeconfig != "" {
+		ctx = kube.WithKubeconfig(ctx, st.Kubeconfig)
+	}
+	if st.KubeContext != "" {
+		ctx = kube.WithKubeContext(ctx, st.KubeContext)
+	}
+	return ctx
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestUpdate(t *testing.T) {
+	req := &mcp.CallToolRequest{}
+	t.Cleanup(func() { remove(nil) })
+
+	Update(req, func(st *State) { st.ProjectID = "my-project" })
+	Update(req, func(st *State) { st.KubeContext = "staging" })
+	if got, want := Get(req), (State{ProjectID: "my-project", KubeContext: "staging"}); got != want {
+		t.Errorf("Get() = %+v, want %+v", got, want)
+	}
+
+	Update(req, func(st *State) { *st = State{} })
+	if _, ok := states[nil]; ok {
+		t.Errorf("Update() kept an empty state")
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	req := &mcp.CallToolRequest{}
+	t.Cleanup(func() { remove(nil) })
+	c := &config.Config{}
+
+	var gotContext, gotProject string
+	next := func(ctx context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+		gotContext = kube.KubeContext(ctx)
+		gotProject = c.DefaultProjectID(ctx)
+		return nil, nil
+	}
+
+	if _, err := Middleware(next)(context.Background(), "tools/call", req); err != nil || gotContext != "" || gotProject != "" {
+		t.Errorf("Middleware() set context %q and project %q, %v, want none", gotContext, gotProject, err)
+	}
+
+	Update(req, func(st *State) {
+		st.ProjectID = "my-project"
+		st.KubeContext = "staging"
+	})
+	for _, method := range []string{"tools/call", "prompts/get", "completion/complete", "resources/read"} {
+		if _, err := Middleware(next)(context.Background(), method, req); err != nil || gotContext != "staging" || gotProject != "my-project" {
+			t.Errorf("Middleware() set context %q and project %q, %v for %s, want staging and my-project", gotContext, gotProject, err, method)
+		}
+	}
+	if _, err := Middleware(next)(context.Background(), "tools/list", req); err != nil || gotContext != "" {
+		t.Errorf("Middleware() set context %q, %v for tools/list, want none", gotContext, err)
+	}
+
+	Update(req, func(st *State) { st.KubeContext = "" })
+	if _, err := Middleware(next)(context.Background(), "tools/call", req); err != nil || gotContext != "" {
+		t.Errorf("Middleware() set context %q, %v after clearing, want none", gotContext, err)
+	}
+}
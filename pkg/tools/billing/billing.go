@@ -0,0 +1,357 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package billing provides MCP tools reporting the actual spend of GKE
+// clusters from the detailed Cloud Billing export to BigQuery, and estimating
+// their cost from the prices of the Cloud Billing catalog.
+package billing
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	container "cloud.google.com/go/container/apiv1"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	bigquery "google.golang.org/api/bigquery/v2"
+	cloudbilling "google.golang.org/api/cloudbilling/v1"
+)
+
+const (
+	defaultDays = 30
+	maxDays     = 365
+	// queryTimeout is how long BigQuery waits for the query before returning.
+	queryTimeout = 30 * time.Second
+	// unallocated stands for the costs without a namespace, e.g. disks and
+	// the unallocated capacity of the nodes.
+	unallocated = "(unallocated)"
+)
+
+// costQuery sums the net cost of a cluster, after credits, by node pool and
+// namespace, from the GKE cost allocation labels of the billing export.
+const costQuery = `SELECT
+  IFNULL((SELECT value FROM UNNEST(labels) WHERE key = 'goog-k8s-node-pool-name'), '') AS node_pool,
+  IFNULL((SELECT value FROM UNNEST(labels) WHERE key = 'k8s-namespace'), '') AS namespace,
+  currency,
+  SUM(cost) + SUM(IFNULL((SELECT SUM(c.amount) FROM UNNEST(credits) c), 0)) AS net_cost
+FROM ` + "`%s`" + `
+WHERE usage_start_time >= TIMESTAMP_SUB(CURRENT_TIMESTAMP(), INTERVAL @days DAY)
+  AND project.id = @project
+  AND EXISTS (SELECT 1 FROM UNNEST(labels) WHERE key = 'goog-k8s-cluster-name' AND value = @cluster)
+  AND (@location = '' OR EXISTS (SELECT 1 FROM UNNEST(labels) WHERE key = 'goog-k8s-cluster-location' AND value = @location))
+GROUP BY node_pool, namespace, currency
+ORDER BY net_cost DESC`
+
+type handlers struct {
+	c        *config.Config
+	cmClient *container.ClusterManagerClient
+	// query runs a query in a project and returns its rows.
+	query func(ctx context.Context, projectID string, req *bigquery.QueryRequest) ([]*bigquery.TableRow, error)
+	// skus returns the Compute Engine SKUs of the catalog with prices in a
+	// currency.
+	skus     func(ctx context.Context, currency string) ([]*cloudbilling.Sku, error)
+	skuCache skuCache
+}
+
+type getClusterCostsArgs struct {
+	ProjectID          string `json:"project_id,omitempty" jsonschema:"GCP project ID of the cluster. Use the default if the user doesn't provide it."`
+	Location           string `json:"location,omitempty" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name               string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+	Days               int    `json:"days,omitempty" jsonschema:"The number of past days to sum the costs of. Defaults to 30."`
+	BillingExportTable string `json:"billing_export_table,omitempty" jsonschema:"The BigQuery table of the detailed Cloud Billing export, as project.dataset.table. Defaults to the table of the server configuration. Ask the user for it if there is no default."`
+}
+
+// costRow is the net cost of a node pool in a namespace.
+type costRow struct {
+	nodePool  string
+	namespace string
+	currency  string
+	cost      float64
+}
+
+// Install registers the cost tools with the MCP server.
+func Install(ctx context.Context, s *mcp.Server, c *config.Config) error {
+	cmClient, err := container.NewClusterManagerClient(ctx, c.ClientOptions()...)
+	if err != nil {
+		return fmt.Errorf("failed to create cluster manager client: %w", err)
+	}
+
+	h := &handlers{
+		c:        c,
+		cmClient: cmClient,
+		query: func(ctx context.Context, projectID string, req *bigquery.QueryRequest) ([]*bigquery.TableRow, error) {
+			svc, err := bigquery.NewService(ctx, c.RESTClientOptions(ctx)...)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create bigquery client: %w", err)
+			}
+			return runQuery(ctx, svc, projectID, req)
+		},
+	}
+	h.skus = h.listSKUs
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name: "get_cluster_costs",
+		Description: "Get the actual net cost of a GKE cluster over the past days, by node pool and namespace, from the detailed Cloud Billing export to BigQuery. " +
+			"Namespace costs require GKE cost allocation to be enabled on the cluster. The query is billed to the project of the cluster.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.getClusterCosts)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name: "estimate_node_pool_costs",
+		Description: "Estimate the monthly cost of the vCPUs and memory of each node pool of a GKE cluster at list price, with sustained use discounts and with 1-year and 3-year committed use discounts, " +
+			"from the prices of the Cloud Billing catalog in the region of the cluster. Use it to quantify the savings of cost recommendations.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.estimateNodePoolCosts)
+
+	return nil
+}
+
+func (h *handlers) getClusterCosts(ctx context.Context, _ *mcp.CallToolRequest, args *getClusterCostsArgs) (*mcp.CallToolResult, any, error) {
+	if args.ProjectID == "" {
+		args.ProjectID = h.c.DefaultProjectID(ctx)
+	}
+	if args.Location == "" {
+		args.Location = h.c.DefaultLocation(ctx)
+	}
+	if args.Name == "" {
+		args.Name = h.c.DefaultCluster(ctx)
+	}
+	if args.ProjectID == "" {
+		return nil, nil, fmt.Errorf("project_id argument cannot be empty")
+	}
+	if args.Name == "" {
+		return nil, nil, fmt.Errorf("name argument cannot be empty")
+	}
+	if args.Days == 0 {
+		args.Days = defaultDays
+	}
+	if args.Days < 1 || args.Days > maxDays {
+		return nil, nil, fmt.Errorf("days argument must be between 1 and %d", maxDays)
+	}
+	table := strings.TrimSpace(args.BillingExportTable)
+	if table == "" {
+		table = h.c.Billing().ExportTable
+	}
+	if table == "" {
+		return nil, nil, fmt.Errorf("billing_export_table argument cannot be empty when no billing export table is configured")
+	}
+	// The table can't be a query parameter, so it is validated instead.
+	if err := config.ValidateBillingTable(table); err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := h.query(ctx, args.ProjectID, costQueryRequest(table, args))
+	if err != nil {
+		return nil, nil, err
+	}
+	costs, err := parseRows(rows)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: costReport(args.Name, args.Days, table, costs)},
+		},
+	}, nil, nil
+}
+
+// costQueryRequest returns the request of the costs of a cluster.
+func costQueryRequest(table string, args *getClusterCostsArgs) *bigquery.QueryRequest {
+	useLegacySQL := false
+	return &bigquery.QueryRequest{
+		Query:         fmt.Sprintf(costQuery, table),
+		UseLegacySql:  &useLegacySQL,
+		ParameterMode: "NAMED",
+		QueryParameters: []*bigquery.QueryParameter{
+			stringParameter("project", args.ProjectID),
+			stringParameter("cluster", args.Name),
+			stringParameter("location", args.Location),
+			{
+				Name:           "days",
+				ParameterType:  &bigquery.QueryParameterType{Type: "INT64"},
+				ParameterValue: &bigquery.QueryParameterValue{Value: strconv.Itoa(args.Days)},
+			},
+		},
+		TimeoutMs: queryTimeout.Milliseconds(),
+	}
+}
+
+func stringParameter(name, value string) *bigquery.QueryParameter {
+	return &bigquery.QueryParameter{
+		Name:          name,
+		ParameterType: &bigquery.QueryParameterType{Type: "STRING"},
+		// An empty value must be sent, it would be NULL otherwise.
+		ParameterValue: &bigquery.QueryParameterValue{Value: value, ForceSendFields: []string{"Value"}},
+	}
+}
+
+// runQuery runs a query and waits for all its rows.
+func runQuery(ctx context.Context, svc *bigquery.Service, projectID string, req *bigquery.QueryRequest) ([]*bigquery.TableRow, error) {
+	resp, err := svc.Jobs.Query(projectID, req).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the billing export: %w", err)
+	}
+	rows, complete, pageToken := resp.Rows, resp.JobComplete, resp.PageToken
+	for !complete || pageToken != "" {
+		if resp.JobReference == nil {
+			return nil, fmt.Errorf("failed to query the billing export: no job reference")
+		}
+		results, err := svc.Jobs.GetQueryResults(projectID, resp.JobReference.JobId).
+			Location(resp.JobReference.Location).
+			PageToken(pageToken).
+			TimeoutMs(queryTimeout.Milliseconds()).
+			Context(ctx).Do()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the results of the billing export query: %w", err)
+		}
+		if !results.JobComplete {
+			continue
+		}
+		// Incomplete jobs return no rows, so the rows are only appended once complete.
+		rows = append(rows, results.Rows...)
+		complete, pageToken = true, results.PageToken
+	}
+	return rows, nil
+}
+
+// parseRows returns the costs of the rows of the cost query.
+func parseRows(rows []*bigquery.TableRow) ([]costRow, error) {
+	costs := make([]costRow, 0, len(rows))
+	for _, row := range rows {
+		if len(row.F) != 4 {
+			return nil, fmt.Errorf("unexpected billing export row with %d columns", len(row.F))
+		}
+		r := costRow{
+			nodePool:  cellString(row.F[0]),
+			namespace: cellString(row.F[1]),
+			currency:  cellString(row.F[2]),
+		}
+		if v := cellString(row.F[3]); v != "" {
+			cost, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse cost %q: %w", v, err)
+			}
+			r.cost = cost
+		}
+		costs = append(costs, r)
+	}
+	return costs, nil
+}
+
+func cellString(cell *bigquery.TableCell) string {
+	if cell == nil || cell.V == nil {
+		return ""
+	}
+	return fmt.Sprint(cell.V)
+}
+
+// costReport summarizes the costs of a cluster by node pool, by namespace,
+// and by node pool and namespace.
+func costReport(cluster string, days int, table string, costs []costRow) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cluster: %s\n", cluster)
+	fmt.Fprintf(&b, "Period: last %d days\n", days)
+	fmt.Fprintf(&b, "Billing export: %s\n", table)
+	if len(costs) == 0 {
+		b.WriteString("\nNo costs found for the cluster. Check that the table is the detailed (resource-level) billing export, and that the cluster had usage in the period.\n")
+		return b.String()
+	}
+
+	totals := map[string]float64{}
+	byPool := map[string]map[string]float64{}
+	byNamespace := map[string]map[string]float64{}
+	allocated := false
+	for _, r := range costs {
+		totals[r.currency] += r.cost
+		add(byPool, label(r.nodePool, "(no node pool)"), r.currency, r.cost)
+		add(byNamespace, label(r.namespace, unallocated), r.currency, r.cost)
+		allocated = allocated || r.namespace != ""
+	}
+
+	b.WriteString("\nTotal net cost:\n")
+	for _, currency := range sortedKeys(totals) {
+		fmt.Fprintf(&b, "  %.2f %s\n", totals[currency], currency)
+	}
+	writeBreakdown(&b, "By node pool", byPool)
+	if !allocated {
+		b.WriteString("\nNo namespace costs: enable GKE cost allocation on the cluster to break down the costs by namespace.\n")
+		return b.String()
+	}
+	writeBreakdown(&b, "By namespace", byNamespace)
+
+	b.WriteString("\nBy node pool and namespace:\n")
+	for _, r := range costs {
+		fmt.Fprintf(&b, "  %s / %s: %.2f %s\n", label(r.nodePool, "(no node pool)"), label(r.namespace, unallocated), r.cost, r.currency)
+	}
+	return b.String()
+}
+
+func add(m map[string]map[string]float64, key, currency string, cost float64) {
+	if m[key] == nil {
+		m[key] = map[string]float64{}
+	}
+	m[key][currency] += cost
+}
+
+// writeBreakdown writes the costs of each key, from the most expensive.
+func writeBreakdown(b *strings.Builder, title string, m map[string]map[string]float64) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sum := func(k string) float64 {
+		var total float64
+		for _, cost := range m[k] {
+			total += cost
+		}
+		return total
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if si, sj := sum(keys[i]), sum(keys[j]); si != sj {
+			return si > sj
+		}
+		return keys[i] < keys[j]
+	})
+	fmt.Fprintf(b, "\n%s:\n", title)
+	for _, k := range keys {
+		for _, currency := range sortedKeys(m[k]) {
+			fmt.Fprintf(b, "  %s: %.2f %s\n", k, m[k][currency], currency)
+		}
+	}
+}
+
+func sortedKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func label(value, empty string) string {
+	if value == "" {
+		return empty
+	}
+	return value
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package billing
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	bigquery "google.golang.org/api/bigquery/v2"
+)
+
+func row(values ...any) *bigquery.TableRow {
+	r := &bigquery.TableRow{}
+	for _, v := range values {
+		r.F = append(r.F, &bigquery.TableCell{V: v})
+	}
+	return r
+}
+
+func TestGetClusterCosts(t *testing.T) {
+	var gotProject string
+	var gotReq *bigquery.QueryRequest
+	h := &handlers{
+		c: config.New("test", config.WithBilling(config.Billing{ExportTable: "billing-project.billing.gcp_billing_export_resource_v1_X"})),
+		query: func(_ context.Context, projectID string, req *bigquery.QueryRequest) ([]*bigquery.TableRow, error) {
+			gotProject, gotReq = projectID, req
+			return []*bigquery.TableRow{
+				row("default-pool", "shop", "USD", "120.5"),
+				row("gpu-pool", "training", "USD", "300"),
+				row("default-pool", "", "USD", "20.25"),
+				row("default-pool", "kube:unallocated", "USD", "10"),
+			}, nil
+		},
+	}
+
+	result, _, err := h.getClusterCosts(context.Background(), &mcp.CallToolRequest{}, &getClusterCostsArgs{
+		ProjectID: "my-project",
+		Location:  "us-central1",
+		Name:      "prod",
+		Days:      7,
+	})
+	if err != nil {
+		t.Fatalf("getClusterCosts() error = %v", err)
+	}
+	if gotProject != "my-project" {
+		t.Errorf("query project = %q, want %q", gotProject, "my-project")
+	}
+	if !strings.Contains(gotReq.Query, "`billing-project.billing.gcp_billing_export_resource_v1_X`") {
+		t.Errorf("query = %q, want it to read the configured table", gotReq.Query)
+	}
+	params := map[string]string{}
+	for _, p := range gotReq.QueryParameters {
+		params[p.Name] = p.ParameterValue.Value
+	}
+	for name, want := range map[string]string{"project": "my-project", "cluster": "prod", "location": "us-central1", "days": "7"} {
+		if params[name] != want {
+			t.Errorf("query parameter %s = %q, want %q", name, params[name], want)
+		}
+	}
+
+	text := result.Content[0].(*mcp.TextContent).Text
+	for _, want := range []string{
+		"Period: last 7 days",
+		"450.75 USD",
+		"gpu-pool: 300.00 USD",
+		"default-pool: 150.75 USD",
+		"training: 300.00 USD",
+		"(unallocated): 20.25 USD",
+		"default-pool / shop: 120.50 USD",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("getClusterCosts() = %q, want it to contain %q", text, want)
+		}
+	}
+}
+
+func TestGetClusterCosts_NoCostAllocation(t *testing.T) {
+	h := &handlers{
+		c: &config.Config{},
+		query: func(context.Context, string, *bigquery.QueryRequest) ([]*bigquery.TableRow, error) {
+			return []*bigquery.TableRow{row("default-pool", "", "EUR", "42")}, nil
+		},
+	}
+
+	result, _, err := h.getClusterCosts(context.Background(), &mcp.CallToolRequest{}, &getClusterCostsArgs{
+		ProjectID:          "my-project",
+		Name:               "prod",
+		BillingExportTable: "my-project.billing.export",
+	})
+	if err != nil {
+		t.Fatalf("getClusterCosts() error = %v", err)
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	if !strings.Contains(text, "Period: last 30 days") {
+		t.Errorf("getClusterCosts() = %q, want the default period", text)
+	}
+	if !strings.Contains(text, "enable GKE cost allocation") {
+		t.Errorf("getClusterCosts() = %q, want it to suggest enabling cost allocation", text)
+	}
+}
+
+func TestGetClusterCosts_InvalidArgs(t *testing.T) {
+	h := &handlers{
+		c: &config.Config{},
+		query: func(context.Context, string, *bigquery.QueryRequest) ([]*bigquery.TableRow, error) {
+			t.Fatal("query called with invalid arguments")
+			return nil, nil
+		},
+	}
+	tests := map[string]*getClusterCostsArgs{
+		"no table":      {ProjectID: "my-project", Name: "prod"},
+		"invalid table": {ProjectID: "my-project", Name: "prod", BillingExportTable: "billing.export` --"},
+		"too many days": {ProjectID: "my-project", Name: "prod", BillingExportTable: "my-project.billing.export", Days: 1000},
+	}
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := h.getClusterCosts(context.Background(), &mcp.CallToolRequest{}, args); err == nil {
+				t.Error("getClusterCosts() error = nil, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,370 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package billing
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	cloudbilling "google.golang.org/api/cloudbilling/v1"
+	compute "google.golang.org/api/compute/v1"
+)
+
+const (
+	// computeEngineService is the Cloud Billing catalog service of Compute
+	// Engine.
+	computeEngineService = "services/6F81-5844-456A"
+	// hoursPerMonth is the average number of hours in a month.
+	hoursPerMonth = 730
+	// skuCacheTTL is how long the prices of the catalog are reused.
+	skuCacheTTL = 24 * time.Hour
+)
+
+// Usage types of the Compute Engine SKUs.
+const (
+	onDemand    = "OnDemand"
+	preemptible = "Preemptible"
+	commit1Yr   = "Commit1Yr"
+	commit3Yr   = "Commit3Yr"
+)
+
+// sustainedUseDiscounts are the discounts of the machine families for
+// running a full month.
+var sustainedUseDiscounts = map[string]float64{
+	"n1":  0.3,
+	"m1":  0.3,
+	"n2":  0.2,
+	"n2d": 0.2,
+	"c2":  0.2,
+}
+
+// skuPrefixes are stripped from the descriptions of the SKUs before reading
+// their machine family.
+var skuPrefixes = []string{"commitment v1: ", "spot preemptible ", "preemptible "}
+
+// excludedSKUs are the words of the descriptions of the SKUs not priced for
+// predefined machine types.
+var excludedSKUs = []string{"custom", "sole tenancy", "extended", "premium", "reserved"}
+
+type estimateNodePoolCostsArgs struct {
+	ProjectID string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location  string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name      string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+	Currency  string `json:"currency,omitempty" jsonschema:"The ISO 4217 currency code of the prices. Defaults to USD."`
+}
+
+// priceKey identifies the hourly price of a vCPU or a GiB of memory of a
+// machine family.
+type priceKey struct {
+	family    string
+	resource  string
+	usageType string
+}
+
+// nodePoolShape is the size of a node pool.
+type nodePoolShape struct {
+	name        string
+	machineType string
+	nodes       int64
+	vCPUs       float64
+	memoryGiB   float64
+	spot        bool
+}
+
+// poolEstimate is the monthly cost of a node pool at each price.
+type poolEstimate struct {
+	shape nodePoolShape
+	// prices are the monthly costs by usage type, missing when the catalog
+	// has no price.
+	prices map[string]float64
+	sud    float64
+}
+
+// skuCache keeps the SKUs of the catalog by currency.
+type skuCache struct {
+	mu      sync.Mutex
+	fetched map[string]time.Time
+	skus    map[string][]*cloudbilling.Sku
+}
+
+func (h *handlers) estimateNodePoolCosts(ctx context.Context, _ *mcp.CallToolRequest, args *estimateNodePoolCostsArgs) (*mcp.CallToolResult, any, error) {
+	if args.ProjectID == "" {
+		args.ProjectID = h.c.DefaultProjectID(ctx)
+	}
+	currency := strings.ToUpper(strings.TrimSpace(args.Currency))
+	if currency == "" {
+		currency = "USD"
+	}
+	cluster, err := gke.GetCluster(ctx, h.cmClient, h.c, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	svc, err := compute.NewService(ctx, h.c.RESTClientOptions(ctx)...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create compute client: %w", err)
+	}
+	var shapes []nodePoolShape
+	for _, pool := range cluster.GetNodePools() {
+		shape, err := poolShape(ctx, svc, args.ProjectID, cluster, pool)
+		if err != nil {
+			return nil, nil, err
+		}
+		shapes = append(shapes, shape)
+	}
+
+	skus, err := h.skus(ctx, currency)
+	if err != nil {
+		return nil, nil, err
+	}
+	region := gke.Region(cluster.GetLocation())
+	prices := priceTable(skus, region)
+	var estimates []poolEstimate
+	for _, shape := range shapes {
+		estimates = append(estimates, estimate(shape, prices))
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: estimateReport(cluster.GetName(), region, currency, estimates)},
+		},
+	}, nil, nil
+}
+
+// poolShape returns the number of nodes and the machine resources of a node
+// pool.
+func poolShape(ctx context.Context, svc *compute.Service, projectID string, cluster *containerpb.Cluster, pool *containerpb.NodePool) (nodePoolShape, error) {
+	config := pool.GetConfig()
+	shape := nodePoolShape{
+		name:        pool.GetName(),
+		machineType: config.GetMachineType(),
+		spot:        config.GetSpot() || config.GetPreemptible(),
+	}
+	size, err := gke.NodePoolSize(ctx, svc, pool)
+	if err != nil {
+		return shape, err
+	}
+	shape.nodes = size
+	zones := pool.GetLocations()
+	if len(zones) == 0 {
+		zones = cluster.GetLocations()
+	}
+	if len(zones) == 0 {
+		return shape, nil
+	}
+	machineType, err := svc.MachineTypes.Get(projectID, zones[0], shape.machineType).Context(ctx).Do()
+	if err != nil {
+		return shape, fmt.Errorf("failed to get machine type %s: %w", shape.machineType, err)
+	}
+	shape.vCPUs = float64(machineType.GuestCpus)
+	shape.memoryGiB = float64(machineType.MemoryMb) / 1024
+	return shape, nil
+}
+
+// listSKUs returns the Compute Engine SKUs of the catalog with prices in the
+// currency, reusing them for a day.
+func (h *handlers) listSKUs(ctx context.Context, currency string) ([]*cloudbilling.Sku, error) {
+	h.skuCache.mu.Lock()
+	defer h.skuCache.mu.Unlock()
+	if skus, ok := h.skuCache.skus[currency]; ok && time.Since(h.skuCache.fetched[currency]) < skuCacheTTL {
+		return skus, nil
+	}
+
+	svc, err := cloudbilling.NewService(ctx, h.c.RESTClientOptions(ctx)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cloud billing client: %w", err)
+	}
+	var skus []*cloudbilling.Sku
+	if err := svc.Services.Skus.List(computeEngineService).CurrencyCode(currency).Pages(ctx, func(resp *cloudbilling.ListSkusResponse) error {
+		skus = append(skus, resp.Skus...)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list Compute Engine SKUs: %w", err)
+	}
+	if h.skuCache.skus == nil {
+		h.skuCache.skus, h.skuCache.fetched = map[string][]*cloudbilling.Sku{}, map[string]time.Time{}
+	}
+	h.skuCache.skus[currency], h.skuCache.fetched[currency] = skus, time.Now()
+	return skus, nil
+}
+
+// skuPriceKey returns what a SKU prices, if it is the vCPU or memory of a
+// predefined machine family in the region.
+func skuPriceKey(sku *cloudbilling.Sku, region string) (priceKey, bool) {
+	category := sku.Category
+	if category == nil || category.ResourceFamily != "Compute" || !slices.Contains(sku.ServiceRegions, region) {
+		return priceKey{}, false
+	}
+	if !slices.Contains([]string{onDemand, preemptible, commit1Yr, commit3Yr}, category.UsageType) {
+		return priceKey{}, false
+	}
+	desc := strings.ToLower(sku.Description)
+	for _, word := range excludedSKUs {
+		if strings.Contains(desc, word) {
+			return priceKey{}, false
+		}
+	}
+	for _, prefix := range skuPrefixes {
+		desc = strings.TrimPrefix(desc, prefix)
+	}
+	words := strings.Fields(desc)
+	if len(words) < 2 {
+		return priceKey{}, false
+	}
+	var resource string
+	switch {
+	case category.ResourceGroup == "CPU" || slices.Contains(words, "core") || slices.Contains(words, "cpu"):
+		resource = "cpu"
+	case category.ResourceGroup == "RAM" || slices.Contains(words, "ram"):
+		resource = "ram"
+	default:
+		return priceKey{}, false
+	}
+	family := words[0]
+	if family == "cpu" || family == "ram" {
+		// The commitments of N1 machines are not named after the family.
+		family = "n1"
+	}
+	return priceKey{family: family, resource: resource, usageType: category.UsageType}, true
+}
+
+// skuPrice returns the hourly unit price of a SKU, from the rate of its last
+// tier.
+func skuPrice(sku *cloudbilling.Sku) (float64, bool) {
+	if len(sku.PricingInfo) == 0 || sku.PricingInfo[0].PricingExpression == nil {
+		return 0, false
+	}
+	rates := sku.PricingInfo[0].PricingExpression.TieredRates
+	if len(rates) == 0 || rates[len(rates)-1].UnitPrice == nil {
+		return 0, false
+	}
+	price := rates[len(rates)-1].UnitPrice
+	return float64(price.Units) + float64(price.Nanos)/1e9, true
+}
+
+// priceTable returns the hourly prices of the vCPUs and memory of the machine
+// families in the region.
+func priceTable(skus []*cloudbilling.Sku, region string) map[priceKey]float64 {
+	prices := map[priceKey]float64{}
+	for _, sku := range skus {
+		key, ok := skuPriceKey(sku, region)
+		if !ok {
+			continue
+		}
+		if _, seen := prices[key]; seen {
+			continue
+		}
+		if price, ok := skuPrice(sku); ok {
+			prices[key] = price
+		}
+	}
+	return prices
+}
+
+// estimate returns the monthly cost of a node pool at each price of the
+// catalog, and with sustained use discounts.
+func estimate(shape nodePoolShape, prices map[priceKey]float64) poolEstimate {
+	family, _, _ := strings.Cut(shape.machineType, "-")
+	e := poolEstimate{shape: shape, prices: map[string]float64{}}
+	usageTypes := []string{onDemand, commit1Yr, commit3Yr}
+	if shape.spot {
+		// Spot VMs get neither sustained use nor committed use discounts.
+		usageTypes = []string{preemptible}
+	}
+	for _, usageType := range usageTypes {
+		cpuPrice, cpuOK := prices[priceKey{family: family, resource: "cpu", usageType: usageType}]
+		ramPrice, ramOK := prices[priceKey{family: family, resource: "ram", usageType: usageType}]
+		if !cpuOK || !ramOK {
+			continue
+		}
+		hourly := float64(shape.nodes) * (shape.vCPUs*cpuPrice + shape.memoryGiB*ramPrice)
+		e.prices[usageType] = hourly * hoursPerMonth
+	}
+	if list, ok := e.prices[onDemand]; ok && !shape.spot {
+		e.sud = list * (1 - sustainedUseDiscounts[family])
+	}
+	return e
+}
+
+func estimateReport(cluster, region, currency string, estimates []poolEstimate) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cluster: %s\n", cluster)
+	fmt.Fprintf(&b, "Region: %s\n", region)
+	fmt.Fprintf(&b, "Monthly estimates (%d hours) of the vCPUs and memory of the nodes at their current size, in %s, excluding disks, GPUs, network, licenses and the cluster management fee.\n", hoursPerMonth, currency)
+
+	totals := map[string]float64{}
+	complete := true
+	for _, e := range estimates {
+		fmt.Fprintf(&b, "\nNode pool: %s (%d x %s", e.shape.name, e.shape.nodes, e.shape.machineType)
+		if e.shape.spot {
+			b.WriteString(", Spot")
+		}
+		b.WriteString(")\n")
+		if e.shape.nodes == 0 {
+			b.WriteString("  No nodes.\n")
+			continue
+		}
+		if len(e.prices) == 0 {
+			complete = false
+			b.WriteString("  No price found in the catalog for the machine type in the region.\n")
+			continue
+		}
+		if e.shape.spot {
+			fmt.Fprintf(&b, "  Spot: %s\n", money(e.prices[preemptible]))
+			for _, key := range []string{onDemand, "sud", commit1Yr, commit3Yr} {
+				totals[key] += e.prices[preemptible]
+			}
+			continue
+		}
+		fmt.Fprintf(&b, "  List price: %s\n", money(e.prices[onDemand]))
+		if e.sud < e.prices[onDemand] {
+			fmt.Fprintf(&b, "  With sustained use discounts: %s\n", money(e.sud))
+		}
+		totals[onDemand] += e.prices[onDemand]
+		totals["sud"] += e.sud
+		for _, c := range []struct{ usageType, label string }{{commit1Yr, "1-year"}, {commit3Yr, "3-year"}} {
+			price, ok := e.prices[c.usageType]
+			if !ok {
+				// Machine families without commitments keep their on-demand price.
+				price = e.sud
+			} else {
+				fmt.Fprintf(&b, "  With a %s committed use discount: %s (%.0f%% off)\n", c.label, money(price), 100*(1-price/e.prices[onDemand]))
+			}
+			totals[c.usageType] += price
+		}
+	}
+
+	b.WriteString("\nTotal:\n")
+	fmt.Fprintf(&b, "  List price: %s\n", money(totals[onDemand]))
+	fmt.Fprintf(&b, "  With sustained use discounts: %s\n", money(totals["sud"]))
+	fmt.Fprintf(&b, "  With 1-year committed use discounts: %s\n", money(totals[commit1Yr]))
+	fmt.Fprintf(&b, "  With 3-year committed use discounts: %s\n", money(totals[commit3Yr]))
+	if !complete {
+		b.WriteString("The totals exclude the node pools without prices.\n")
+	}
+	b.WriteString("Committed use discounts apply to the resources committed to, in the region, whatever the node pool, and sustained use discounts assume the nodes run the whole month.\n")
+	return b.String()
+}
+
+func money(amount float64) string {
+	return fmt.Sprintf("%.2f", math.Round(amount*100)/100)
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package billing
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	cloudbilling "google.golang.org/api/cloudbilling/v1"
+)
+
+func sku(description, resourceGroup, usageType string, price float64, regions ...string) *cloudbilling.Sku {
+	units := int64(price)
+	return &cloudbilling.Sku{
+		Description:    description,
+		Category:       &cloudbilling.Category{ResourceFamily: "Compute", ResourceGroup: resourceGroup, UsageType: usageType},
+		ServiceRegions: regions,
+		PricingInfo: []*cloudbilling.PricingInfo{{
+			PricingExpression: &cloudbilling.PricingExpression{
+				TieredRates: []*cloudbilling.TierRate{{UnitPrice: &cloudbilling.Money{Units: units, Nanos: int64(math.Round((price - float64(units)) * 1e9))}}},
+			},
+		}},
+	}
+}
+
+var testSKUs = []*cloudbilling.Sku{
+	sku("N2 Instance Core running in Americas", "CPU", onDemand, 0.03, "us-central1"),
+	sku("N2 Instance Ram running in Americas", "RAM", onDemand, 0.004, "us-central1"),
+	sku("Commitment v1: N2 Cpu in Americas for 1 Year", "CPU", commit1Yr, 0.02, "us-central1"),
+	sku("Commitment v1: N2 Ram in Americas for 1 Year", "RAM", commit1Yr, 0.003, "us-central1"),
+	sku("Commitment v1: N2 Cpu in Americas for 3 Year", "CPU", commit3Yr, 0.015, "us-central1"),
+	sku("Commitment v1: N2 Ram in Americas for 3 Year", "RAM", commit3Yr, 0.002, "us-central1"),
+	sku("Spot Preemptible N2 Instance Core running in Americas", "CPU", preemptible, 0.008, "us-central1"),
+	sku("Spot Preemptible N2 Instance Ram running in Americas", "RAM", preemptible, 0.001, "us-central1"),
+	sku("N2 Custom Instance Core running in Americas", "CPU", onDemand, 0.05, "us-central1"),
+	sku("N2 Instance Core running in Frankfurt", "CPU", onDemand, 0.04, "europe-west3"),
+	sku("N2D AMD Instance Core running in Americas", "CPU", onDemand, 0.025, "us-central1"),
+	sku("Commitment v1: Cpu in Americas for 1 Year", "CPU", commit1Yr, 0.02, "us-central1"),
+}
+
+func TestPriceTable(t *testing.T) {
+	got := priceTable(testSKUs, "us-central1")
+	want := map[priceKey]float64{
+		{family: "n2", resource: "cpu", usageType: onDemand}:    0.03,
+		{family: "n2", resource: "ram", usageType: onDemand}:    0.004,
+		{family: "n2", resource: "cpu", usageType: commit1Yr}:   0.02,
+		{family: "n2", resource: "ram", usageType: commit1Yr}:   0.003,
+		{family: "n2", resource: "cpu", usageType: commit3Yr}:   0.015,
+		{family: "n2", resource: "ram", usageType: commit3Yr}:   0.002,
+		{family: "n2", resource: "cpu", usageType: preemptible}: 0.008,
+		{family: "n2", resource: "ram", usageType: preemptible}: 0.001,
+		{family: "n2d", resource: "cpu", usageType: onDemand}:   0.025,
+		{family: "n1", resource: "cpu", usageType: commit1Yr}:   0.02,
+	}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(priceKey{}), cmp.Comparer(func(a, b float64) bool { return a-b < 1e-9 && b-a < 1e-9 })); diff != "" {
+		t.Errorf("priceTable() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestEstimate(t *testing.T) {
+	prices := priceTable(testSKUs, "us-central1")
+	// 2 nodes of 4 vCPUs and 16 GiB cost 2 * (4 * 0.03 + 16 * 0.004) = 0.368 an hour.
+	e := estimate(nodePoolShape{name: "default-pool", machineType: "n2-standard-4", nodes: 2, vCPUs: 4, memoryGiB: 16}, prices)
+	for usageType, want := range map[string]float64{onDemand: 0.368 * hoursPerMonth, commit1Yr: 0.256 * hoursPerMonth, commit3Yr: 0.184 * hoursPerMonth} {
+		if got := e.prices[usageType]; money(got) != money(want) {
+			t.Errorf("estimate() %s = %s, want %s", usageType, money(got), money(want))
+		}
+	}
+	if want := 0.368 * hoursPerMonth * 0.8; money(e.sud) != money(want) {
+		t.Errorf("estimate() sustained use = %s, want %s", money(e.sud), money(want))
+	}
+
+	spot := estimate(nodePoolShape{name: "spot-pool", machineType: "n2-standard-4", nodes: 1, vCPUs: 4, memoryGiB: 16, spot: true}, prices)
+	if _, ok := spot.prices[onDemand]; ok {
+		t.Error("estimate() of a Spot node pool has an on-demand price")
+	}
+	if want := 0.048 * hoursPerMonth; money(spot.prices[preemptible]) != money(want) {
+		t.Errorf("estimate() Spot = %s, want %s", money(spot.prices[preemptible]), money(want))
+	}
+
+	unknown := estimate(nodePoolShape{name: "c4-pool", machineType: "c4-standard-4", nodes: 1, vCPUs: 4, memoryGiB: 15}, prices)
+	if len(unknown.prices) != 0 {
+		t.Errorf("estimate() of an unpriced machine type = %v, want no prices", unknown.prices)
+	}
+}
+
+func TestEstimateReport(t *testing.T) {
+	prices := priceTable(testSKUs, "us-central1")
+	estimates := []poolEstimate{
+		estimate(nodePoolShape{name: "default-pool", machineType: "n2-standard-4", nodes: 2, vCPUs: 4, memoryGiB: 16}, prices),
+		estimate(nodePoolShape{name: "c4-pool", machineType: "c4-standard-4", nodes: 1, vCPUs: 4, memoryGiB: 15}, prices),
+	}
+
+	got := estimateReport("prod", "us-central1", "USD", estimates)
+	for _, want := range []string{
+		"Node pool: default-pool (2 x n2-standard-4)",
+		"List price: 268.64",
+		"With sustained use discounts: 214.91",
+		"With a 1-year committed use discount: 186.88 (30% off)",
+		"With a 3-year committed use discount: 134.32 (50% off)",
+		"No price found in the catalog",
+		"The totals exclude the node pools without prices.",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("estimateReport() = %q, want it to contain %q", got, want)
+		}
+	}
+}
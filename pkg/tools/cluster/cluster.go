@@ -31,13 +31,19 @@ import (
 	container "cloud.google.com/go/container/apiv1"
 	containerpb "cloud.google.com/go/container/apiv1/containerpb"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/dryrun"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/progress"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/session"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
-	"google.golang.org/api/option"
 	"google.golang.org/protobuf/encoding/protojson"
 	"k8s.io/client-go/tools/clientcmd"
 	k8sClientApi "k8s.io/client-go/tools/clientcmd/api"
 )
 
+// podCleanupTimeout bounds the deletion of the SOS report debug pod.
+const podCleanupTimeout = 30 * time.Second
+
 type handlers struct {
 	c        *config.Config
 	cmClient *container.ClusterManagerClient
@@ -77,7 +83,7 @@ type getNodeSosReportArgs struct {
 // Install registers cluster-related tools with the MCP server.
 func Install(ctx context.Context, s *mcp.Server, c *config.Config) error {
 
-	cmClient, err := container.NewClusterManagerClient(ctx, option.WithUserAgent(c.UserAgent()))
+	cmClient, err := container.NewClusterManagerClient(ctx, c.ClientOptions()...)
 	if err != nil {
 		return fmt.Errorf("failed to create cluster manager client: %w", err)
 	}
@@ -113,23 +119,66 @@ func Install(ctx context.Context, s *mcp.Server, c *config.Config) error {
 
 	mcp.AddTool(s, &mcp.Tool{
 		Name:        "get_kubeconfig",
-		Description: "Get the kubeconfig for a GKE cluster by calling the GKE API and extracting necessary details (clusterCaCertificate and endpoint). This tool appends/updates the kubeconfig in ~/.kube/config.",
+		Description: "Get the kubeconfig for a GKE cluster by calling the GKE API and extracting necessary details (clusterCaCertificate and endpoint). This tool appends/updates the kubeconfig in ~/.kube/config, or in a kubeconfig owned by the server when it is shared over HTTP or SSE, and makes the cluster the context of the kubectl-backed tools of the session.",
 		Annotations: &mcp.ToolAnnotations{
 			// ReadOnlyHint is removed because this tool now performs a write operation.
 		},
 	}, h.getKubeconfig)
 
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "get_credentials",
+		Description: "Fetch credentials for a GKE cluster into a kubeconfig owned by this server and make it the context of all kubectl-backed tools, without modifying the default kubeconfig of the user (~/.kube/config). Prefer this tool over get_kubeconfig before running kubectl-backed tools.",
+		Annotations: &mcp.ToolAnnotations{
+			// Not read-only, the tool writes the kubeconfig owned by the server.
+		},
+	}, h.getCredentials)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "gather_cluster_context",
+		Description: "Gather in one call the facts about a GKE cluster most tasks need: versions, release channel, networking, enabled add-ons, node pools and, when the current kubectl context points to the cluster, namespaces, workload counts, CRDs and admission webhooks. The output is size-bounded. Prefer this tool over running many gcloud and kubectl commands.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.gatherClusterContext)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "snapshot_cluster",
+		Description: "Save a snapshot of the inventory of a GKE cluster to a JSON file in the snapshot directory of the server: versions, release channel, add-ons, node pools, namespaces, workloads, CRDs and admission webhooks. The current kubectl context must point to the cluster. Take a snapshot before and after an upgrade and compare them with diff_cluster_snapshots to validate the upgrade.",
+		Annotations: &mcp.ToolAnnotations{
+			// Not destructive, the snapshots are saved to new files only.
+			DestructiveHint: new(bool),
+		},
+	}, h.snapshotCluster)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "diff_cluster_snapshots",
+		Description: "Compare two snapshots of a GKE cluster saved by snapshot_cluster, given by file name, listing the changed versions and node pools and the added or removed add-ons, namespaces, workloads, CRDs and webhooks. Use it to validate a cluster after an upgrade.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.diffClusterSnapshots)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "get_encryption_status",
+		Description: "Report the encryption of the data of a GKE cluster for compliance reviews: application-layer secrets encryption with a Cloud KMS key, customer-managed encryption keys (CMEK) of the control plane disks, node boot disks and persistent disk StorageClasses, and the automatic rotation of every Cloud KMS key used. Uses the current kubectl context for the StorageClasses.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.getEncryptionStatus)
+
 	mcp.AddTool(s, &mcp.Tool{
 		Name:        "get_node_sos_report",
 		Description: "Generate and download an SOS report from a GKE node. Can use 'pod', 'ssh' or 'any' methods. Defaults to 'any' (pod with fallback to ssh). Use 'ssh' if node is API-unhealthy.",
 	}, h.getNodeSosReport)
 
+	h.installResources(s)
+
 	return nil
 }
 
 func (h *handlers) listClusters(ctx context.Context, _ *mcp.CallToolRequest, args *listClustersArgs) (*mcp.CallToolResult, any, error) {
 	if args.ProjectID == "" {
-		args.ProjectID = h.c.DefaultProjectID()
+		args.ProjectID = h.c.DefaultProjectID(ctx)
 	}
 	if args.Location == "" {
 		args.Location = "-"
@@ -155,10 +204,13 @@ func (h *handlers) listClusters(ctx context.Context, _ *mcp.CallToolRequest, arg
 
 func (h *handlers) getCluster(ctx context.Context, _ *mcp.CallToolRequest, args *getClustersArgs) (*mcp.CallToolResult, any, error) {
 	if args.ProjectID == "" {
-		args.ProjectID = h.c.DefaultProjectID()
+		args.ProjectID = h.c.DefaultProjectID(ctx)
 	}
 	if args.Location == "" {
-		args.Location = h.c.DefaultLocation()
+		args.Location = h.c.DefaultLocation(ctx)
+	}
+	if args.Name == "" {
+		args.Name = h.c.DefaultCluster(ctx)
 	}
 	if args.Name == "" {
 		return nil, nil, fmt.Errorf("name argument cannot be empty")
@@ -181,16 +233,24 @@ func (h *handlers) getCluster(ctx context.Context, _ *mcp.CallToolRequest, args
 
 func (h *handlers) createCluster(ctx context.Context, _ *mcp.CallToolRequest, args *createClustersArgs) (*mcp.CallToolResult, any, error) {
 	if args.ProjectID == "" {
-		args.ProjectID = h.c.DefaultProjectID()
+		args.ProjectID = h.c.DefaultProjectID(ctx)
 	}
 	if args.Location == "" {
-		args.Location = h.c.DefaultLocation()
+		args.Location = h.c.DefaultLocation(ctx)
 	}
 
 	req := &containerpb.CreateClusterRequest{
 		Parent:  fmt.Sprintf("projects/%s/locations/%s", args.ProjectID, args.Location),
 		Cluster: &args.Cluster,
 	}
+	if h.c.DryRun() {
+		return (&dryrun.Plan{
+			Method:  "ClusterManager.CreateCluster",
+			Request: req,
+			Gcloud:  fmt.Sprintf("gcloud container clusters create %s --location=%s --project=%s", args.Cluster.GetName(), args.Location, args.ProjectID),
+			Effect:  fmt.Sprintf("Cluster %s is created in %s with the configuration of the request, which the gcloud command only partially reflects.", args.Cluster.GetName(), args.Location),
+		}).Result(), nil, nil
+	}
 	resp, err := h.cmClient.CreateCluster(ctx, req)
 	if err != nil {
 		return nil, nil, err
@@ -205,12 +265,15 @@ func (h *handlers) createCluster(ctx context.Context, _ *mcp.CallToolRequest, ar
 
 // getKubeconfig retrieves GKE cluster details and constructs a kubeconfig file.
 // It appends/updates the configuration in the user's ~/.kube/config file.
-func (h *handlers) getKubeconfig(ctx context.Context, _ *mcp.CallToolRequest, args *getKubeconfigArgs) (*mcp.CallToolResult, any, error) {
+func (h *handlers) getKubeconfig(ctx context.Context, toolReq *mcp.CallToolRequest, args *getKubeconfigArgs) (*mcp.CallToolResult, any, error) {
 	if args.ProjectID == "" {
-		args.ProjectID = h.c.DefaultProjectID()
+		args.ProjectID = h.c.DefaultProjectID(ctx)
 	}
 	if args.Location == "" {
-		args.Location = h.c.DefaultLocation()
+		args.Location = h.c.DefaultLocation(ctx)
+	}
+	if args.Name == "" {
+		args.Name = h.c.DefaultCluster(ctx)
 	}
 	if args.Name == "" {
 		return nil, nil, fmt.Errorf("name argument cannot be empty")
@@ -224,14 +287,68 @@ func (h *handlers) getKubeconfig(ctx context.Context, _ *mcp.CallToolRequest, ar
 		return nil, nil, fmt.Errorf("failed to get cluster %s: %w", args.Name, err)
 	}
 
-	clusterCaCertificate := resp.GetMasterAuth().GetClusterCaCertificate()
-	endpoint := resp.GetEndpoint()
+	// The sessions of a shared server may be other users, so leave the
+	// default kubeconfig and its current context alone, as get_credentials does.
+	if h.c.Shared() {
+		path := managedKubeconfigPath()
+		contextName, err := writeManagedKubeconfig(path, resp, args.ProjectID, args.Location)
+		if err != nil {
+			return nil, nil, err
+		}
+		session.Update(toolReq, func(st *session.State) {
+			st.Kubeconfig = path
+			st.KubeContext = contextName
+		})
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("Kubeconfig for cluster %s (Project: %s, Location: %s) written to %s, since the server is shared. The kubectl-backed tools of this session now use context %s.", args.Name, args.ProjectID, args.Location, path, contextName)},
+			},
+		}, nil, nil
+	}
+
+	// Initialize a Kubeconfig object
+	pathOptions := clientcmd.NewDefaultPathOptions()
+	oldKubeconfig, err := pathOptions.GetStartingConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get starting config: %w", err)
+	}
+	newKubeconfig := oldKubeconfig.DeepCopy()
+
+	newClusterName, err := addClusterToKubeconfig(newKubeconfig, resp, args.ProjectID, args.Location)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = clientcmd.ModifyConfig(pathOptions, *newKubeconfig, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to modify kubeconfig: %w", err)
+	}
+	// Pin the context, so that the kubectl-backed tools of the session keep
+	// targeting the cluster if the current context is changed afterwards.
+	session.Update(toolReq, func(st *session.State) {
+		st.Kubeconfig = ""
+		st.KubeContext = newClusterName
+	})
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: fmt.Sprintf("Kubeconfig for cluster %s (Project: %s, Location: %s) successfully appended/updated in %s. Current context set to %s.", args.Name, args.ProjectID, args.Location, pathOptions.GlobalFile, newClusterName)},
+		},
+	}, nil, nil
+}
+
+// addClusterToKubeconfig appends or updates the cluster, context and user
+// entries of a GKE cluster in a kubeconfig, sets the context as the current
+// one and returns its name.
+func addClusterToKubeconfig(kubeconfig *k8sClientApi.Config, cluster *containerpb.Cluster, projectID, location string) (string, error) {
+	clusterCaCertificate := cluster.GetMasterAuth().GetClusterCaCertificate()
+	endpoint := cluster.GetEndpoint()
 
 	if clusterCaCertificate == "" {
-		return nil, nil, fmt.Errorf("clusterCaCertificate not found for cluster %s", args.Name)
+		return "", fmt.Errorf("clusterCaCertificate not found for cluster %s", cluster.GetName())
 	}
 	if endpoint == "" {
-		return nil, nil, fmt.Errorf("endpoint not found for cluster %s", args.Name)
+		return "", fmt.Errorf("endpoint not found for cluster %s", cluster.GetName())
 	}
 
 	// Ensure the endpoint starts with "https://"
@@ -239,21 +356,12 @@ func (h *handlers) getKubeconfig(ctx context.Context, _ *mcp.CallToolRequest, ar
 		endpoint = "https://" + endpoint
 	}
 
-	// Standard naming convention for gcloud-generated kubeconfigs
-	newClusterName := fmt.Sprintf("gke_%s_%s_%s", args.ProjectID, args.Location, args.Name)
-
-	// Initialize a Kubeconfig object
-	pathOptions := clientcmd.NewDefaultPathOptions()
-	oldKubeconfig, err := pathOptions.GetStartingConfig()
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get starting config: %w", err)
-	}
-	newKubeconfig := oldKubeconfig.DeepCopy()
+	newClusterName := gke.KubeContextName(projectID, location, cluster.GetName())
 
 	// Create new cluster, context, and user entries
 	clusterCaCertificateByte, err := base64.RawStdEncoding.DecodeString(clusterCaCertificate)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to decode clusterCaCertificate: %w", err)
+		return "", fmt.Errorf("failed to decode clusterCaCertificate: %w", err)
 	}
 
 	newCluster := &k8sClientApi.Cluster{
@@ -274,23 +382,13 @@ func (h *handlers) getKubeconfig(ctx context.Context, _ *mcp.CallToolRequest, ar
 	}
 
 	// Append or update cluster, context, and user using map assignments
-	newKubeconfig.Clusters[newClusterName] = newCluster
-	newKubeconfig.Contexts[newClusterName] = newContext
-	newKubeconfig.AuthInfos[newClusterName] = newUser
+	kubeconfig.Clusters[newClusterName] = newCluster
+	kubeconfig.Contexts[newClusterName] = newContext
+	kubeconfig.AuthInfos[newClusterName] = newUser
 
 	// Set current context
-	newKubeconfig.CurrentContext = newClusterName
-
-	err = clientcmd.ModifyConfig(pathOptions, *newKubeconfig, false)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to modify kubeconfig: %w", err)
-	}
-
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{Text: fmt.Sprintf("Kubeconfig for cluster %s (Project: %s, Location: %s) successfully appended/updated in %s. Current context set to %s.", args.Name, args.ProjectID, args.Location, pathOptions.GlobalFile, newClusterName)},
-		},
-	}, nil, nil
+	kubeconfig.CurrentContext = newClusterName
+	return newClusterName, nil
 }
 
 func (h *handlers) getNodeSosReport(ctx context.Context, _ *mcp.CallToolRequest, args *getNodeSosReportArgs) (*mcp.CallToolResult, any, error) {
@@ -394,6 +492,7 @@ func (h *handlers) getNodeSosReportWithPod(ctx context.Context, args *getNodeSos
 		return nil, nil, fmt.Errorf("failed to marshal overrides: %w", err)
 	}
 
+	progress.Report(ctx, 0, 4, "Creating a debug pod on node "+args.Node)
 	// #nosec G204
 	runCmd := exec.CommandContext(ctx, "kubectl", "run", podName, "--image=gke.gcr.io/debian-base", "--restart=Never", "--overrides="+string(overridesBytes))
 	if out, err := runCmd.CombinedOutput(); err != nil {
@@ -401,13 +500,16 @@ func (h *handlers) getNodeSosReportWithPod(ctx context.Context, args *getNodeSos
 	}
 
 	defer func() {
-		// Cleanup pod
+		// Cleanup pod, even when the request was cancelled
+		delCtx, delCancel := context.WithTimeout(context.WithoutCancel(ctx), podCleanupTimeout)
+		defer delCancel()
 		// #nosec G204
-		delCmd := exec.Command("kubectl", "delete", "pod", podName, "--wait=false", "--grace-period=0", "--force")
+		delCmd := exec.CommandContext(delCtx, "kubectl", "delete", "pod", podName, "--wait=false", "--grace-period=0", "--force")
 		_ = delCmd.Run() // Best-effort cleanup
 	}()
 
 	// 2. Wait for pod to be ready
+	progress.Report(ctx, 1, 4, "Waiting for the debug pod "+podName)
 	// #nosec G204
 	waitCmd := exec.CommandContext(ctx, "kubectl", "wait", "--for=condition=Ready", "pod/"+podName, "--timeout=60s")
 	if out, err := waitCmd.CombinedOutput(); err != nil {
@@ -421,6 +523,7 @@ func (h *handlers) getNodeSosReportWithPod(ctx context.Context, args *getNodeSos
 	// Note: chroot /host allows us to use the host's sosreport command and filesystem
 	execScript := fmt.Sprintf("apt update && apt install -y sosreport && mkdir -p /host%s && sos report --sysroot=/host --all-logs --batch --tmp-dir=/host%s", remoteTmpDir, remoteTmpDir)
 
+	progress.Report(ctx, 2, 4, "Generating the SOS report, which can take several minutes")
 	// #nosec G204
 	execCmd := exec.CommandContext(ctx, "kubectl", "exec", podName, "--", "sh", "-c", execScript)
 	outBytes, err := execCmd.CombinedOutput()
@@ -454,6 +557,7 @@ func (h *handlers) getNodeSosReportWithPod(ctx context.Context, args *getNodeSos
 		return nil, nil, fmt.Errorf("failed to create local file %s: %w", localPath, err)
 	}
 
+	progress.Report(ctx, 3, 4, "Downloading the SOS report")
 	// #nosec G204
 	catCmd := exec.CommandContext(ctx, "kubectl", "exec", podName, "--", "cat", remotePath)
 	catCmd.Stdout = f
@@ -483,6 +587,7 @@ func (h *handlers) getNodeSosReportWithPod(ctx context.Context, args *getNodeSos
 func (h *handlers) getNodeSosReportWithSSH(ctx context.Context, args *getNodeSosReportArgs) (*mcp.CallToolResult, any, error) {
 	// 1. Find the zone of the VM
 	// gcloud compute instances list --filter="name=NODE_NAME" --format="value(zone)"
+	progress.Report(ctx, 0, 4, "Finding the zone of node "+args.Node)
 	// #nosec G204
 	findZoneCmd := exec.CommandContext(ctx, "gcloud", "compute", "instances", "list", fmt.Sprintf("--filter=name=%s", args.Node), "--format=value(zone)")
 	zoneOut, err := findZoneCmd.Output()
@@ -496,6 +601,7 @@ func (h *handlers) getNodeSosReportWithSSH(ctx context.Context, args *getNodeSos
 
 	// 2. Generate SOS report via SSH
 	// gcloud compute ssh --zone "ZONE" "NODE_NAME" --command "sudo sos report --all-logs --batch --tmp-dir=/var"
+	progress.Report(ctx, 1, 4, "Generating the SOS report over SSH, which can take several minutes")
 	// #nosec G204
 	sshCmd := exec.CommandContext(ctx, "gcloud", "compute", "ssh", "--zone", zone, args.Node, "--command", "sudo sos report --all-logs --batch --tmp-dir=/var")
 	outBytes, err := sshCmd.CombinedOutput()
@@ -515,6 +621,7 @@ func (h *handlers) getNodeSosReportWithSSH(ctx context.Context, args *getNodeSos
 
 	// 4. Change ownership of the file
 	// gcloud compute ssh ... --command "sudo chown $USER REMOTE_PATH"
+	progress.Report(ctx, 2, 4, "Preparing the SOS report for download")
 	// #nosec G204
 	chownCmd := exec.CommandContext(ctx, "gcloud", "compute", "ssh", "--zone", zone, args.Node, "--command", fmt.Sprintf("sudo chown $USER %s", remotePath))
 	if out, err := chownCmd.CombinedOutput(); err != nil {
@@ -525,6 +632,7 @@ func (h *handlers) getNodeSosReportWithSSH(ctx context.Context, args *getNodeSos
 	// gcloud compute scp --zone "ZONE" "NODE_NAME:REMOTE_PATH" LOCAL_DESTINATION
 	localFilename := fmt.Sprintf("sosreport-%s-%s.tar.xz", args.Node, time.Now().Format("2006-01-02-15-04-05"))
 	localPath := filepath.Join(args.Destination, localFilename)
+	progress.Report(ctx, 3, 4, "Downloading the SOS report")
 	// #nosec G204
 	scpCmd := exec.CommandContext(ctx, "gcloud", "compute", "scp", "--zone", zone, fmt.Sprintf("%s:%s", args.Node, remotePath), localPath)
 	if out, err := scpCmd.CombinedOutput(); err != nil {
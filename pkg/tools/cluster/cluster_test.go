@@ -15,9 +15,13 @@
 package cluster
 
 import (
+	"context"
+	"strings"
 	"testing"
 
 	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 func TestListClustersArgs_Fields(t *testing.T) {
@@ -72,6 +76,32 @@ func TestCreateClustersArgs_Fields(t *testing.T) {
 	}
 }
 
+func TestCreateCluster_DryRun(t *testing.T) {
+	h := &handlers{c: config.New("test", config.WithDryRun(true))}
+	args := &createClustersArgs{
+		ProjectID: "test-project",
+		Location:  "us-central1",
+		Cluster: containerpb.Cluster{
+			Name: "my-cluster",
+		},
+	}
+
+	res, _, err := h.createCluster(context.Background(), nil, args)
+	if err != nil {
+		t.Fatalf("createCluster() error = %v", err)
+	}
+	got := res.Content[0].(*mcp.TextContent).Text
+	for _, want := range []string{
+		"API call: ClusterManager.CreateCluster",
+		"projects/test-project/locations/us-central1",
+		"gcloud container clusters create my-cluster --location=us-central1 --project=test-project",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("createCluster() = %q, want it to contain %q", got, want)
+		}
+	}
+}
+
 func TestGetKubeconfigArgs_Fields(t *testing.T) {
 	args := getKubeconfigArgs{
 		ProjectID: "test-project",
@@ -206,3 +236,40 @@ func TestGetNodeSosReportArgs_JSONTags(t *testing.T) {
 		t.Error("TimeoutSeconds field not working correctly")
 	}
 }
+
+func TestParseResourceURI(t *testing.T) {
+	tests := []struct {
+		uri                     string
+		project, location, name string
+		wantErr                 bool
+	}{
+		{"gke://my-project/us-central1/my-cluster", "my-project", "us-central1", "my-cluster", false},
+		{"gke://my-project/-", "my-project", "-", "", false},
+		{"gke://my-project", "", "", "", true},
+		{"gke://my-project//my-cluster", "", "", "", true},
+		{"gke://my-project/us-central1/my-cluster/nodes", "", "", "", true},
+		{"k8s-changelog://1.31", "", "", "", true},
+	}
+	for _, tt := range tests {
+		project, location, name, err := ParseResourceURI(tt.uri)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseResourceURI(%q) error = %v, wantErr %v", tt.uri, err, tt.wantErr)
+			continue
+		}
+		if project != tt.project || location != tt.location || name != tt.name {
+			t.Errorf("ParseResourceURI(%q) = %q, %q, %q, want %q, %q, %q", tt.uri, project, location, name, tt.project, tt.location, tt.name)
+		}
+	}
+}
+
+func TestRedactMasterAuth(t *testing.T) {
+	cluster := &containerpb.Cluster{MasterAuth: &containerpb.MasterAuth{
+		Password:             "secret",
+		ClientKey:            "key",
+		ClusterCaCertificate: "ca",
+	}}
+	redactMasterAuth(cluster)
+	if auth := cluster.MasterAuth; auth.Password != "" || auth.ClientKey != "" || auth.ClusterCaCertificate != "ca" {
+		t.Errorf("redactMasterAuth() = %+v, want the password and client key cleared", auth)
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// maxContextListItems bounds the number of items listed per category.
+	maxContextListItems = 50
+	// maxContextSize bounds the size of the gathered context, in bytes.
+	maxContextSize = 64 * 1024
+)
+
+// workloadResources are the resources counted in the cluster context.
+var workloadResources = []string{"deployments", "statefulsets", "daemonsets", "jobs", "cronjobs", "pods", "services"}
+
+type gatherClusterContextArgs struct {
+	ProjectID string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location  string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name      string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+}
+
+// inClusterContext holds the objects read from the cluster with kubectl.
+type inClusterContext struct {
+	namespaces []kube.Namespace
+	// counts are the numbers of objects of each workload resource.
+	counts map[string]int
+	// workloads are the objects of each workload resource.
+	workloads          map[string][]kube.Object
+	crds               []kube.Object
+	mutatingWebhooks   []kube.WebhookConfiguration
+	validatingWebhooks []kube.WebhookConfiguration
+	// errs are the errors reading each kind of object.
+	errs map[string]error
+}
+
+func (h *handlers) gatherClusterContext(ctx context.Context, _ *mcp.CallToolRequest, args *gatherClusterContextArgs) (*mcp.CallToolResult, any, error) {
+	if args.ProjectID == "" {
+		args.ProjectID = h.c.DefaultProjectID(ctx)
+	}
+	cluster, err := gke.GetCluster(ctx, h.cmClient, h.c, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var b strings.Builder
+	b.WriteString(clusterContextReport(cluster))
+
+	if err := gke.RequireKubeContext(ctx, args.ProjectID, cluster); err != nil {
+		fmt.Fprintf(&b, "\nIn-cluster resources were not gathered: %v\n", err)
+	} else {
+		b.WriteString(inClusterContextReport(readInClusterContext(ctx)))
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: truncateContext(b.String(), maxContextSize)},
+		},
+	}, nil, nil
+}
+
+func readInClusterContext(ctx context.Context) inClusterContext {
+	ic := inClusterContext{counts: map[string]int{}, workloads: map[string][]kube.Object{}, errs: map[string]error{}}
+
+	var namespaces kube.List[kube.Namespace]
+	if err := kube.Get(ctx, &namespaces, "namespaces"); err != nil {
+		ic.errs["namespaces"] = err
+	}
+	ic.namespaces = namespaces.Items
+	for _, resource := range workloadResources {
+		var list kube.List[kube.Object]
+		if err := kube.Get(ctx, &list, resource, "--all-namespaces"); err != nil {
+			ic.errs[resource] = err
+			continue
+		}
+		ic.counts[resource] = len(list.Items)
+		ic.workloads[resource] = list.Items
+	}
+	var crds kube.List[kube.Object]
+	if err := kube.Get(ctx, &crds, "customresourcedefinitions"); err != nil {
+		ic.errs["customresourcedefinitions"] = err
+	}
+	ic.crds = crds.Items
+	var mutating kube.List[kube.WebhookConfiguration]
+	if err := kube.Get(ctx, &mutating, "mutatingwebhookconfigurations"); err != nil {
+		ic.errs["mutatingwebhookconfigurations"] = err
+	}
+	ic.mutatingWebhooks = mutating.Items
+	var validating kube.List[kube.WebhookConfiguration]
+	if err := kube.Get(ctx, &validating, "validatingwebhookconfigurations"); err != nil {
+		ic.errs["validatingwebhookconfigurations"] = err
+	}
+	ic.validatingWebhooks = validating.Items
+	return ic
+}
+
+func clusterContextReport(cluster *containerpb.Cluster) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cluster: %s (location %s, status %s)\n", cluster.GetName(), cluster.GetLocation(), cluster.GetStatus())
+	mode := "Standard"
+	if cluster.GetAutopilot().GetEnabled() {
+		mode = "Autopilot"
+	}
+	fmt.Fprintf(&b, "Mode: %s\n", mode)
+	fmt.Fprintf(&b, "Control plane version: %s\n", cluster.GetCurrentMasterVersion())
+	fmt.Fprintf(&b, "Release channel: %s\n", cluster.GetReleaseChannel().GetChannel())
+	fmt.Fprintf(&b, "Network: %s, subnetwork: %s, datapath provider: %s\n", cluster.GetNetwork(), cluster.GetSubnetwork(), cluster.GetNetworkConfig().GetDatapathProvider())
+	fmt.Fprintf(&b, "Private nodes: %t\n", cluster.GetPrivateClusterConfig().GetEnablePrivateNodes())
+	if pool := cluster.GetWorkloadIdentityConfig().GetWorkloadPool(); pool != "" {
+		fmt.Fprintf(&b, "Workload Identity pool: %s\n", pool)
+	}
+	fmt.Fprintf(&b, "Enabled add-ons: %s\n", strings.Join(gke.EnabledAddons(cluster), ", "))
+
+	if len(cluster.GetNodePools()) > 0 {
+		b.WriteString("\nNode pools:\n")
+	}
+	for _, pool := range cluster.GetNodePools() {
+		fmt.Fprintf(&b, "- %s: version %s, machine type %s, image type %s, %d initial nodes",
+			pool.GetName(), pool.GetVersion(), pool.GetConfig().GetMachineType(), pool.GetConfig().GetImageType(), pool.GetInitialNodeCount())
+		if a := pool.GetAutoscaling(); a.GetEnabled() {
+			fmt.Fprintf(&b, ", autoscaling %d-%d", a.GetMinNodeCount(), a.GetMaxNodeCount())
+		}
+		if pool.GetConfig().GetSpot() {
+			b.WriteString(", Spot VMs")
+		}
+		fmt.Fprintf(&b, ", upgrade strategy %s, status %s\n", pool.GetUpgradeSettings().GetStrategy(), pool.GetStatus())
+	}
+	return b.String()
+}
+
+func inClusterContextReport(ic inClusterContext) string {
+	var b strings.Builder
+
+	var namespaces []string
+	for _, ns := range ic.namespaces {
+		namespaces = append(namespaces, ns.Name)
+	}
+	fmt.Fprintf(&b, "\nNamespaces (%d): %s\n", len(namespaces), limitedList(namespaces, maxContextListItems))
+
+	var counts []string
+	for _, resource := range workloadResources {
+		if count, ok := ic.counts[resource]; ok {
+			counts = append(counts, fmt.Sprintf("%s: %d", resource, count))
+		}
+	}
+	if len(counts) > 0 {
+		fmt.Fprintf(&b, "Workloads: %s\n", strings.Join(counts, ", "))
+	}
+
+	var crds []string
+	for _, crd := range ic.crds {
+		crds = append(crds, crd.Name)
+	}
+	fmt.Fprintf(&b, "CustomResourceDefinitions (%d): %s\n", len(crds), limitedList(crds, maxContextListItems))
+
+	for _, webhooks := range []struct {
+		kind    string
+		configs []kube.WebhookConfiguration
+	}{
+		{"MutatingWebhookConfigurations", ic.mutatingWebhooks},
+		{"ValidatingWebhookConfigurations", ic.validatingWebhooks},
+	} {
+		var names []string
+		for _, config := range webhooks.configs {
+			for _, w := range config.Webhooks {
+				names = append(names, fmt.Sprintf("%s/%s (failurePolicy %s)", config.Name, w.Name, w.FailurePolicy))
+			}
+		}
+		fmt.Fprintf(&b, "%s (%d webhooks): %s\n", webhooks.kind, len(names), limitedList(names, maxContextListItems))
+	}
+
+	for _, resource := range append([]string{"namespaces", "customresourcedefinitions", "mutatingwebhookconfigurations", "validatingwebhookconfigurations"}, workloadResources...) {
+		if err, ok := ic.errs[resource]; ok {
+			fmt.Fprintf(&b, "%s were not gathered: %v\n", resource, err)
+		}
+	}
+	return b.String()
+}
+
+// limitedList joins at most maxItems items, noting how many were left out.
+func limitedList(items []string, maxItems int) string {
+	if len(items) == 0 {
+		return "none"
+	}
+	if len(items) <= maxItems {
+		return strings.Join(items, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(items[:maxItems], ", "), len(items)-maxItems)
+}
+
+// truncateContext cuts the context to at most maxSize bytes, on a line boundary.
+func truncateContext(s string, maxSize int) string {
+	if len(s) <= maxSize {
+		return s
+	}
+	s = s[:maxSize]
+	if i := strings.LastIndex(s, "\n"); i > 0 {
+		s = s[:i+1]
+	}
+	return s + "... (truncated)\n"
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClusterContextReport(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		Name:                 "my-cluster",
+		Location:             "us-central1",
+		Status:               containerpb.Cluster_RUNNING,
+		CurrentMasterVersion: "1.31.5-gke.1000",
+		ReleaseChannel:       &containerpb.ReleaseChannel{Channel: containerpb.ReleaseChannel_REGULAR},
+		AddonsConfig:         &containerpb.AddonsConfig{DnsCacheConfig: &containerpb.DnsCacheConfig{Enabled: true}},
+		NodePools: []*containerpb.NodePool{
+			{
+				Name:             "default-pool",
+				Version:          "1.30.9-gke.1000",
+				InitialNodeCount: 3,
+				Config:           &containerpb.NodeConfig{MachineType: "e2-standard-4", ImageType: "COS_CONTAINERD", Spot: true},
+				Autoscaling:      &containerpb.NodePoolAutoscaling{Enabled: true, MinNodeCount: 1, MaxNodeCount: 5},
+				UpgradeSettings:  &containerpb.NodePool_UpgradeSettings{Strategy: containerpb.NodePoolUpdateStrategy_SURGE.Enum()},
+				Status:           containerpb.NodePool_RUNNING,
+			},
+		},
+	}
+
+	got := clusterContextReport(cluster)
+	for _, want := range []string{
+		"Cluster: my-cluster (location us-central1, status RUNNING)",
+		"Mode: Standard",
+		"Control plane version: 1.31.5-gke.1000",
+		"Release channel: REGULAR",
+		"Enabled add-ons: HttpLoadBalancing, HorizontalPodAutoscaling, NodeLocalDNSCache",
+		"- default-pool: version 1.30.9-gke.1000, machine type e2-standard-4, image type COS_CONTAINERD, 3 initial nodes, autoscaling 1-5, Spot VMs, upgrade strategy SURGE, status RUNNING",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("clusterContextReport() missing %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestInClusterContextReport(t *testing.T) {
+	webhooks := kube.WebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "gatekeeper"},
+		Webhooks:   []kube.Webhook{{Name: "validation.gatekeeper.sh", FailurePolicy: "Ignore"}},
+	}
+	ic := inClusterContext{
+		namespaces:         []kube.Namespace{{ObjectMeta: metav1.ObjectMeta{Name: "default"}}, {ObjectMeta: metav1.ObjectMeta{Name: "app"}}},
+		counts:             map[string]int{"deployments": 4, "pods": 12},
+		validatingWebhooks: []kube.WebhookConfiguration{webhooks},
+		errs:               map[string]error{"customresourcedefinitions": errors.New("forbidden")},
+	}
+
+	got := inClusterContextReport(ic)
+	for _, want := range []string{
+		"Namespaces (2): default, app",
+		"Workloads: deployments: 4, pods: 12",
+		"CustomResourceDefinitions (0): none",
+		"MutatingWebhookConfigurations (0 webhooks): none",
+		"ValidatingWebhookConfigurations (1 webhooks): gatekeeper/validation.gatekeeper.sh (failurePolicy Ignore)",
+		"customresourcedefinitions were not gathered: forbidden",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("inClusterContextReport() missing %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestLimitedList(t *testing.T) {
+	if got, want := limitedList([]string{"a", "b", "c"}, 2), "a, b and 1 more"; got != want {
+		t.Errorf("limitedList() = %q, want %q", got, want)
+	}
+	if got, want := limitedList(nil, 2), "none"; got != want {
+		t.Errorf("limitedList() = %q, want %q", got, want)
+	}
+}
+
+func TestTruncateContext(t *testing.T) {
+	s := "line 1\nline 2\nline 3\n"
+	if got := truncateContext(s, 100); got != s {
+		t.Errorf("truncateContext() = %q, want it unchanged", got)
+	}
+	if got, want := truncateContext(s, 10), "line 1\n... (truncated)\n"; got != want {
+		t.Errorf("truncateContext() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/session"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"k8s.io/client-go/tools/clientcmd"
+	k8sClientApi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+type getCredentialsArgs struct {
+	ProjectID string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location  string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name      string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+}
+
+// managedKubeconfigPath returns the kubeconfig file owned by the server,
+// which keeps the credentials fetched by get_credentials out of the default
+// kubeconfig of the user.
+func managedKubeconfigPath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "gke-mcp", "kubeconfig")
+}
+
+func (h *handlers) getCredentials(ctx context.Context, req *mcp.CallToolRequest, args *getCredentialsArgs) (*mcp.CallToolResult, any, error) {
+	if args.ProjectID == "" {
+		args.ProjectID = h.c.DefaultProjectID(ctx)
+	}
+	if args.Location == "" {
+		args.Location = h.c.DefaultLocation(ctx)
+	}
+	if args.Name == "" {
+		args.Name = h.c.DefaultCluster(ctx)
+	}
+	cluster, err := gke.GetCluster(ctx, h.cmClient, h.c, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := managedKubeconfigPath()
+	contextName, err := writeManagedKubeconfig(path, cluster, args.ProjectID, args.Location)
+	if err != nil {
+		return nil, nil, err
+	}
+	session.Update(req, func(st *session.State) {
+		st.Kubeconfig = path
+		st.KubeContext = contextName
+	})
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: fmt.Sprintf("Credentials for cluster %s (Project: %s, Location: %s) written to %s. The kubectl-backed tools of this session now use context %s. The default kubeconfig was not modified.", args.Name, args.ProjectID, args.Location, path, contextName)},
+		},
+	}, nil, nil
+}
+
+// managedKubeconfigMu serializes the updates of the managed kubeconfig, which
+// is shared by the sessions of the server.
+var managedKubeconfigMu sync.Mutex
+
+// writeManagedKubeconfig adds the cluster to the kubeconfig file at path,
+// creating it if needed, and returns the name of the context of the cluster.
+func writeManagedKubeconfig(path string, cluster *containerpb.Cluster, projectID, location string) (string, error) {
+	managedKubeconfigMu.Lock()
+	defer managedKubeconfigMu.Unlock()
+
+	kubeconfig, err := clientcmd.LoadFromFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		kubeconfig = k8sClientApi.NewConfig()
+	} else if err != nil {
+		return "", fmt.Errorf("failed to load kubeconfig %s: %w", path, err)
+	}
+
+	contextName, err := addClusterToKubeconfig(kubeconfig, cluster, projectID, location)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", fmt.Errorf("failed to create kubeconfig directory: %w", err)
+	}
+	if err := clientcmd.WriteToFile(*kubeconfig, path); err != nil {
+		return "", fmt.Errorf("failed to write kubeconfig %s: %w", path, err)
+	}
+	return contextName, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"encoding/base64"
+	"path/filepath"
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func TestWriteManagedKubeconfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gke-mcp", "kubeconfig")
+	cluster := func(name string) *containerpb.Cluster {
+		return &containerpb.Cluster{
+			Name:       name,
+			Endpoint:   "10.0.0.1",
+			MasterAuth: &containerpb.MasterAuth{ClusterCaCertificate: base64.RawStdEncoding.EncodeToString([]byte("ca"))},
+		}
+	}
+
+	if _, err := writeManagedKubeconfig(path, cluster("first"), "my-project", "us-central1"); err != nil {
+		t.Fatalf("writeManagedKubeconfig() error = %v", err)
+	}
+	contextName, err := writeManagedKubeconfig(path, cluster("second"), "my-project", "us-central1")
+	if err != nil {
+		t.Fatalf("writeManagedKubeconfig() error = %v", err)
+	}
+	if contextName != "gke_my-project_us-central1_second" {
+		t.Errorf("writeManagedKubeconfig() context = %s, want gke_my-project_us-central1_second", contextName)
+	}
+
+	kubeconfig, err := clientcmd.LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("clientcmd.LoadFromFile() error = %v", err)
+	}
+	if kubeconfig.CurrentContext != contextName {
+		t.Errorf("current context = %s, want %s", kubeconfig.CurrentContext, contextName)
+	}
+	if len(kubeconfig.Contexts) != 2 {
+		t.Errorf("got %d contexts, want 2", len(kubeconfig.Contexts))
+	}
+	if server := kubeconfig.Clusters[contextName].Server; server != "https://10.0.0.1" {
+		t.Errorf("server = %s, want https://10.0.0.1", server)
+	}
+	if string(kubeconfig.Clusters[contextName].CertificateAuthorityData) != "ca" {
+		t.Errorf("certificate authority data = %q, want ca", kubeconfig.Clusters[contextName].CertificateAuthorityData)
+	}
+}
+
+func TestWriteManagedKubeconfig_MissingEndpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	cluster := &containerpb.Cluster{
+		Name:       "my-cluster",
+		MasterAuth: &containerpb.MasterAuth{ClusterCaCertificate: base64.RawStdEncoding.EncodeToString([]byte("ca"))},
+	}
+	if _, err := writeManagedKubeconfig(path, cluster, "my-project", "us-central1"); err == nil {
+		t.Error("writeManagedKubeconfig() expected an error for a cluster without endpoint")
+	}
+}
@@ -0,0 +1,260 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	cloudkms "google.golang.org/api/cloudkms/v1"
+)
+
+// diskEncryptionKeyParameter is the StorageClass parameter of the Compute
+// Engine persistent disk CSI driver naming the Cloud KMS key of new disks.
+const diskEncryptionKeyParameter = "disk-encryption-kms-key"
+
+// maxRotationPeriod is the longest automatic rotation period of a key not
+// reported as a gap, as compliance frameworks commonly require a yearly
+// rotation.
+const maxRotationPeriod = 365 * 24 * time.Hour
+
+// diskProvisioners are the StorageClass provisioners of Compute Engine
+// persistent disks.
+var diskProvisioners = map[string]bool{"pd.csi.storage.gke.io": true, "kubernetes.io/gce-pd": true}
+
+type getEncryptionStatusArgs struct {
+	ProjectID string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location  string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name      string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+}
+
+// encryptedResource is a resource of the cluster and the Cloud KMS key
+// encrypting it, empty when it is encrypted by a Google-managed key.
+type encryptedResource struct {
+	name string
+	key  string
+}
+
+func (h *handlers) getEncryptionStatus(ctx context.Context, _ *mcp.CallToolRequest, args *getEncryptionStatusArgs) (*mcp.CallToolResult, any, error) {
+	if args.ProjectID == "" {
+		args.ProjectID = h.c.DefaultProjectID(ctx)
+	}
+	cluster, err := gke.GetCluster(ctx, h.cmClient, h.c, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var notes strings.Builder
+	var storageClasses kube.List[kube.StorageClass]
+	if err := gke.RequireKubeContext(ctx, args.ProjectID, cluster); err != nil {
+		fmt.Fprintf(&notes, "\nStorageClasses were not read: %v\n", err)
+	} else if err := kube.Get(ctx, &storageClasses, "storageclasses"); err != nil {
+		fmt.Fprintf(&notes, "\nStorageClasses were not read: %v\n", err)
+	}
+
+	svc, err := cloudkms.NewService(ctx, h.c.RESTClientOptions(ctx)...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create Cloud KMS client: %w", err)
+	}
+	keys := map[string]*cloudkms.CryptoKey{}
+	keyErrs := map[string]error{}
+	for _, name := range encryptionKeys(cluster, storageClasses.Items) {
+		key, err := svc.Projects.Locations.KeyRings.CryptoKeys.Get(name).Context(ctx).Do()
+		if err != nil {
+			keyErrs[name] = err
+			continue
+		}
+		keys[name] = key
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: encryptionReport(cluster, storageClasses.Items, keys, keyErrs) + notes.String()},
+		},
+	}, nil, nil
+}
+
+// cryptoKeyName returns the name of the Cloud KMS key of a key or key version
+// name.
+func cryptoKeyName(name string) string {
+	key, _, _ := strings.Cut(name, "/cryptoKeyVersions/")
+	return key
+}
+
+// bootDisks returns the boot disks of the node pools and of the node
+// auto-provisioning defaults, with their Cloud KMS key.
+func bootDisks(cluster *containerpb.Cluster) []encryptedResource {
+	var disks []encryptedResource
+	for _, pool := range cluster.GetNodePools() {
+		disks = append(disks, encryptedResource{name: "Node pool " + pool.GetName(), key: cryptoKeyName(pool.GetConfig().GetBootDiskKmsKey())})
+	}
+	if cluster.GetAutoscaling().GetEnableNodeAutoprovisioning() || cluster.GetAutopilot().GetEnabled() {
+		defaults := cluster.GetAutoscaling().GetAutoprovisioningNodePoolDefaults()
+		disks = append(disks, encryptedResource{name: "Node auto-provisioning defaults", key: cryptoKeyName(defaults.GetBootDiskKmsKey())})
+	}
+	return disks
+}
+
+// diskStorageClasses returns the persistent disk StorageClasses, with the
+// Cloud KMS key of the disks they provision.
+func diskStorageClasses(storageClasses []kube.StorageClass) []encryptedResource {
+	var classes []encryptedResource
+	for _, sc := range storageClasses {
+		if diskProvisioners[sc.Provisioner] {
+			classes = append(classes, encryptedResource{name: sc.Name, key: cryptoKeyName(sc.Parameters[diskEncryptionKeyParameter])})
+		}
+	}
+	sort.Slice(classes, func(i, j int) bool { return classes[i].name < classes[j].name })
+	return classes
+}
+
+// encryptionKeys returns the distinct Cloud KMS keys encrypting the secrets
+// and disks of the cluster.
+func encryptionKeys(cluster *containerpb.Cluster, storageClasses []kube.StorageClass) []string {
+	resources := []encryptedResource{
+		{key: cryptoKeyName(cluster.GetDatabaseEncryption().GetKeyName())},
+		{key: cryptoKeyName(cluster.GetUserManagedKeysConfig().GetControlPlaneDiskEncryptionKey())},
+	}
+	resources = append(resources, bootDisks(cluster)...)
+	resources = append(resources, diskStorageClasses(storageClasses)...)
+
+	seen := map[string]bool{}
+	var keys []string
+	for _, r := range resources {
+		if r.key != "" && !seen[r.key] {
+			seen[r.key] = true
+			keys = append(keys, r.key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func keyDescription(key string) string {
+	if key == "" {
+		return "Google-managed key"
+	}
+	return "customer-managed key " + key
+}
+
+// rotationStatus describes the automatic rotation and the primary version of
+// the key, and returns the gaps found.
+func rotationStatus(key *cloudkms.CryptoKey) (string, []string) {
+	var gaps []string
+	var b strings.Builder
+	if key.RotationPeriod == "" {
+		b.WriteString("no automatic rotation")
+		gaps = append(gaps, fmt.Sprintf("Key %s is not rotated automatically.", key.Name))
+	} else {
+		period, err := time.ParseDuration(key.RotationPeriod)
+		if err != nil {
+			fmt.Fprintf(&b, "rotation period %s", key.RotationPeriod)
+		} else {
+			fmt.Fprintf(&b, "rotation every %.0f days", period.Hours()/24)
+			if period > maxRotationPeriod {
+				gaps = append(gaps, fmt.Sprintf("Key %s is rotated less often than yearly.", key.Name))
+			}
+		}
+		if key.NextRotationTime != "" {
+			fmt.Fprintf(&b, ", next rotation %s", key.NextRotationTime)
+		}
+	}
+
+	if primary := key.Primary; primary != nil {
+		fmt.Fprintf(&b, ", primary version %s created %s (%s)", path.Base(primary.Name), primary.CreateTime, primary.State)
+		if primary.State != "ENABLED" {
+			gaps = append(gaps, fmt.Sprintf("The primary version of key %s is %s, data cannot be encrypted or decrypted with it.", key.Name, primary.State))
+		}
+	}
+	return b.String(), gaps
+}
+
+func encryptionReport(cluster *containerpb.Cluster, storageClasses []kube.StorageClass, keys map[string]*cloudkms.CryptoKey, keyErrs map[string]error) string {
+	var b strings.Builder
+	var gaps []string
+	fmt.Fprintf(&b, "Cluster: %s\n", cluster.GetName())
+
+	db := cluster.GetDatabaseEncryption()
+	if db.GetState() == containerpb.DatabaseEncryption_ENCRYPTED {
+		fmt.Fprintf(&b, "\nApplication-layer secrets encryption: enabled, %s\n", keyDescription(cryptoKeyName(db.GetKeyName())))
+	} else {
+		b.WriteString("\nApplication-layer secrets encryption: disabled, Secrets are only encrypted at rest by Google-managed keys\n")
+		gaps = append(gaps, "Secrets are not encrypted with a customer-managed key at the application layer.")
+	}
+	if state := db.GetCurrentState(); state != containerpb.DatabaseEncryption_CURRENT_STATE_UNSPECIFIED {
+		fmt.Fprintf(&b, "  Current state: %s\n", strings.TrimPrefix(state.String(), "CURRENT_STATE_"))
+		if state == containerpb.DatabaseEncryption_CURRENT_STATE_ENCRYPTION_ERROR || state == containerpb.DatabaseEncryption_CURRENT_STATE_DECRYPTION_ERROR {
+			gaps = append(gaps, "The last change of the application-layer secrets encryption failed.")
+		}
+	}
+	for _, e := range db.GetLastOperationErrors() {
+		fmt.Fprintf(&b, "  Last operation error: %s (key %s)\n", e.GetErrorMessage(), e.GetKeyName())
+	}
+
+	fmt.Fprintf(&b, "Control plane disks: %s\n", keyDescription(cryptoKeyName(cluster.GetUserManagedKeysConfig().GetControlPlaneDiskEncryptionKey())))
+
+	b.WriteString("\nNode boot disks:\n")
+	for _, disk := range bootDisks(cluster) {
+		fmt.Fprintf(&b, "- %s: %s\n", disk.name, keyDescription(disk.key))
+		if disk.key == "" {
+			gaps = append(gaps, disk.name+" boot disks are not encrypted with a customer-managed key.")
+		}
+	}
+
+	if classes := diskStorageClasses(storageClasses); len(classes) > 0 {
+		b.WriteString("\nPersistent disk StorageClasses:\n")
+		for _, sc := range classes {
+			fmt.Fprintf(&b, "- %s: %s\n", sc.name, keyDescription(sc.key))
+			if sc.key == "" {
+				gaps = append(gaps, fmt.Sprintf("StorageClass %s provisions disks without a customer-managed key.", sc.name))
+			}
+		}
+	}
+
+	if names := encryptionKeys(cluster, storageClasses); len(names) > 0 {
+		b.WriteString("\nCloud KMS keys:\n")
+		for _, name := range names {
+			if err, ok := keyErrs[name]; ok {
+				fmt.Fprintf(&b, "- %s: not read: %v\n", name, err)
+				continue
+			}
+			key, ok := keys[name]
+			if !ok {
+				continue
+			}
+			status, keyGaps := rotationStatus(key)
+			fmt.Fprintf(&b, "- %s: %s\n", name, status)
+			gaps = append(gaps, keyGaps...)
+		}
+	}
+
+	if len(gaps) == 0 {
+		b.WriteString("\nThe secrets and disks of the cluster are encrypted with customer-managed keys rotated automatically.\n")
+		return b.String()
+	}
+	b.WriteString("\nGaps:\n")
+	for _, gap := range gaps {
+		fmt.Fprintf(&b, "- %s\n", gap)
+	}
+	return b.String()
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	cloudkms "google.golang.org/api/cloudkms/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	secretsKey = "projects/p/locations/us-central1/keyRings/gke/cryptoKeys/secrets"
+	disksKey   = "projects/p/locations/us-central1/keyRings/gke/cryptoKeys/disks"
+)
+
+func TestEncryptionKeys(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		DatabaseEncryption: &containerpb.DatabaseEncryption{State: containerpb.DatabaseEncryption_ENCRYPTED, KeyName: secretsKey},
+		NodePools: []*containerpb.NodePool{
+			{Name: "a", Config: &containerpb.NodeConfig{BootDiskKmsKey: disksKey}},
+			{Name: "b", Config: &containerpb.NodeConfig{}},
+		},
+	}
+	storageClasses := []kube.StorageClass{
+		{ObjectMeta: metav1.ObjectMeta{Name: "cmek"}, Provisioner: "pd.csi.storage.gke.io", Parameters: map[string]string{diskEncryptionKeyParameter: disksKey + "/cryptoKeyVersions/1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "files"}, Provisioner: "filestore.csi.storage.gke.io", Parameters: map[string]string{diskEncryptionKeyParameter: "ignored"}},
+	}
+
+	got := encryptionKeys(cluster, storageClasses)
+	if want := []string{disksKey, secretsKey}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("encryptionKeys() = %v, want %v", got, want)
+	}
+}
+
+func TestEncryptionReport(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		Name:               "prod",
+		DatabaseEncryption: &containerpb.DatabaseEncryption{State: containerpb.DatabaseEncryption_ENCRYPTED, KeyName: secretsKey},
+		NodePools: []*containerpb.NodePool{
+			{Name: "a", Config: &containerpb.NodeConfig{BootDiskKmsKey: disksKey}},
+			{Name: "b", Config: &containerpb.NodeConfig{}},
+		},
+		Autoscaling: &containerpb.ClusterAutoscaling{
+			EnableNodeAutoprovisioning:       true,
+			AutoprovisioningNodePoolDefaults: &containerpb.AutoprovisioningNodePoolDefaults{BootDiskKmsKey: disksKey},
+		},
+	}
+	storageClasses := []kube.StorageClass{
+		{ObjectMeta: metav1.ObjectMeta{Name: "standard-rwo"}, Provisioner: "pd.csi.storage.gke.io"},
+		{ObjectMeta: metav1.ObjectMeta{Name: "cmek"}, Provisioner: "pd.csi.storage.gke.io", Parameters: map[string]string{diskEncryptionKeyParameter: disksKey}},
+	}
+	keys := map[string]*cloudkms.CryptoKey{
+		secretsKey: {
+			Name:             secretsKey,
+			RotationPeriod:   "7776000s",
+			NextRotationTime: "2026-12-01T00:00:00Z",
+			Primary:          &cloudkms.CryptoKeyVersion{Name: secretsKey + "/cryptoKeyVersions/3", CreateTime: "2026-09-01T00:00:00Z", State: "ENABLED"},
+		},
+	}
+	keyErrs := map[string]error{disksKey: errors.New("permission denied")}
+
+	got := encryptionReport(cluster, storageClasses, keys, keyErrs)
+	for _, want := range []string{
+		"Application-layer secrets encryption: enabled, customer-managed key " + secretsKey + "\n",
+		"Control plane disks: Google-managed key\n",
+		"- Node pool a: customer-managed key " + disksKey + "\n",
+		"- Node pool b: Google-managed key\n",
+		"- Node auto-provisioning defaults: customer-managed key " + disksKey + "\n",
+		"\nPersistent disk StorageClasses:\n- cmek: customer-managed key " + disksKey + "\n- standard-rwo: Google-managed key\n",
+		"- " + disksKey + ": not read: permission denied\n",
+		"- " + secretsKey + ": rotation every 90 days, next rotation 2026-12-01T00:00:00Z, primary version 3 created 2026-09-01T00:00:00Z (ENABLED)\n",
+		"\nGaps:\n- Node pool b boot disks are not encrypted with a customer-managed key.\n- StorageClass standard-rwo provisions disks without a customer-managed key.\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("encryptionReport() missing %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestEncryptionReport_Gaps(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		Name: "prod",
+		DatabaseEncryption: &containerpb.DatabaseEncryption{
+			State:        containerpb.DatabaseEncryption_DECRYPTED,
+			CurrentState: containerpb.DatabaseEncryption_CURRENT_STATE_ENCRYPTION_ERROR.Enum(),
+		},
+		UserManagedKeysConfig: &containerpb.UserManagedKeysConfig{ControlPlaneDiskEncryptionKey: disksKey},
+	}
+	keys := map[string]*cloudkms.CryptoKey{
+		disksKey: {Name: disksKey, Primary: &cloudkms.CryptoKeyVersion{Name: disksKey + "/cryptoKeyVersions/1", State: "DISABLED"}},
+	}
+
+	got := encryptionReport(cluster, nil, keys, nil)
+	for _, want := range []string{
+		"Application-layer secrets encryption: disabled",
+		"  Current state: ENCRYPTION_ERROR\n",
+		"Control plane disks: customer-managed key " + disksKey + "\n",
+		"- Secrets are not encrypted with a customer-managed key at the application layer.\n",
+		"- The last change of the application-layer secrets encryption failed.\n",
+		"- Key " + disksKey + " is not rotated automatically.\n",
+		"- The primary version of key " + disksKey + " is DISABLED",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("encryptionReport() missing %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Persistent disk StorageClasses") {
+		t.Errorf("encryptionReport() lists StorageClasses, got:\n%s", got)
+	}
+}
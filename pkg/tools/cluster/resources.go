@@ -0,0 +1,112 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// resourceScheme is the scheme of the URIs of the cluster resources, e.g.
+// gke://my-project/us-central1/my-cluster.
+const resourceScheme = "gke://"
+
+// installResources registers the resource templates of the GKE clusters with
+// the MCP server.
+func (h *handlers) installResources(s *mcp.Server) {
+	s.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: resourceScheme + "{project}/{location}",
+		Name:        "gke-clusters",
+		Title:       "GKE clusters",
+		Description: "The GKE clusters of a project in a location, or in all locations with -, e.g. gke://my-project/-.",
+		MIMEType:    "application/json",
+	}, h.readClusterResource)
+	s.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: resourceScheme + "{project}/{location}/{cluster}",
+		Name:        "gke-cluster",
+		Title:       "GKE cluster",
+		Description: "The configuration of a GKE cluster, e.g. gke://my-project/us-central1/my-cluster.",
+		MIMEType:    "application/json",
+	}, h.readClusterResource)
+}
+
+// ParseResourceURI returns the project, location and cluster of the URI of a
+// cluster resource, the cluster being empty for the URIs of cluster lists.
+func ParseResourceURI(uri string) (projectID, location, name string, err error) {
+	path, ok := strings.CutPrefix(uri, resourceScheme)
+	if !ok {
+		return "", "", "", fmt.Errorf("invalid GKE resource URI %s: no %s scheme", uri, resourceScheme)
+	}
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 || len(parts) > 3 || slices.Contains(parts, "") {
+		return "", "", "", fmt.Errorf("invalid GKE resource URI %s: want %s{project}/{location}[/{cluster}]", uri, resourceScheme)
+	}
+	if len(parts) == 3 {
+		name = parts[2]
+	}
+	return parts[0], parts[1], name, nil
+}
+
+func (h *handlers) readClusterResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	uri := req.Params.URI
+	projectID, location, name, err := ParseResourceURI(uri)
+	if err != nil {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+
+	var text string
+	if name == "" {
+		resp, err := h.cmClient.ListClusters(ctx, &containerpb.ListClustersRequest{
+			Parent: fmt.Sprintf("projects/%s/locations/%s", projectID, location),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, cluster := range resp.Clusters {
+			redactMasterAuth(cluster)
+		}
+		text = protojson.Format(resp)
+	} else {
+		resp, err := h.cmClient.GetCluster(ctx, &containerpb.GetClusterRequest{
+			Name: fmt.Sprintf("projects/%s/locations/%s/clusters/%s", projectID, location, name),
+		})
+		if err != nil {
+			return nil, err
+		}
+		redactMasterAuth(resp)
+		text = protojson.Format(resp)
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{URI: uri, MIMEType: "application/json", Text: text},
+		},
+	}, nil
+}
+
+// redactMasterAuth clears the legacy basic authentication password and client
+// key of the cluster, which resources pinned as context must not leak.
+func redactMasterAuth(cluster *containerpb.Cluster) {
+	if auth := cluster.GetMasterAuth(); auth != nil {
+		auth.Password = ""
+		auth.ClientKey = ""
+	}
+}
@@ -0,0 +1,403 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// inventoryResources are the workload resources whose objects are listed by
+// name in the snapshots. The other workload resources, such as pods, churn
+// too much and are only counted.
+var inventoryResources = []string{"deployments", "statefulsets", "daemonsets", "cronjobs", "services"}
+
+var unsafeSnapshotNameChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+type snapshotClusterArgs struct {
+	ProjectID string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location  string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name      string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+	Label     string `json:"label,omitempty" jsonschema:"A short label of the snapshot used in the file name, e.g. pre-upgrade or post-upgrade."`
+}
+
+type diffClusterSnapshotsArgs struct {
+	Before string `json:"before" jsonschema:"The snapshot taken first, as named by snapshot_cluster: a file name in the snapshot directory of the server."`
+	After  string `json:"after" jsonschema:"The snapshot taken last, as named by snapshot_cluster: a file name in the snapshot directory of the server."`
+}
+
+// clusterSnapshot is the normalized inventory of a cluster at a point in
+// time. The lists are sorted so that snapshots can be compared.
+type clusterSnapshot struct {
+	Label               string             `json:"label,omitempty"`
+	CreatedAt           string             `json:"createdAt"`
+	ProjectID           string             `json:"projectId"`
+	Location            string             `json:"location"`
+	Cluster             string             `json:"cluster"`
+	ControlPlaneVersion string             `json:"controlPlaneVersion"`
+	ReleaseChannel      string             `json:"releaseChannel"`
+	Addons              []string           `json:"addons,omitempty"`
+	NodePools           []nodePoolSnapshot `json:"nodePools,omitempty"`
+	Namespaces          []string           `json:"namespaces,omitempty"`
+	// WorkloadCounts are the numbers of objects of each workload resource.
+	WorkloadCounts map[string]int `json:"workloadCounts,omitempty"`
+	// Workloads are the namespace/name of the objects of each of the
+	// inventoryResources.
+	Workloads map[string][]string `json:"workloads,omitempty"`
+	CRDs      []string            `json:"crds,omitempty"`
+	// Webhooks are the admission webhooks, as kind/configuration/webhook.
+	Webhooks []string `json:"webhooks,omitempty"`
+	// Errors are the errors reading each kind of object.
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// nodePoolSnapshot is a node pool in a clusterSnapshot.
+type nodePoolSnapshot struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	MachineType string `json:"machineType"`
+	ImageType   string `json:"imageType"`
+	Status      string `json:"status"`
+}
+
+// snapshotDir returns the directory of the snapshots.
+func (h *handlers) snapshotDir() string {
+	if dir := h.c.Files().SnapshotsDir; dir != "" {
+		return dir
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "gke-mcp", "snapshots")
+}
+
+func (h *handlers) snapshotCluster(ctx context.Context, _ *mcp.CallToolRequest, args *snapshotClusterArgs) (*mcp.CallToolResult, any, error) {
+	if args.ProjectID == "" {
+		args.ProjectID = h.c.DefaultProjectID(ctx)
+	}
+	cluster, err := gke.GetCluster(ctx, h.cmClient, h.c, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// A snapshot without the in-cluster resources would make every workload
+	// look removed when diffed, so require the right kubectl context.
+	if err := gke.RequireKubeContext(ctx, args.ProjectID, cluster); err != nil {
+		return nil, nil, err
+	}
+
+	snapshot := newClusterSnapshot(cluster, args.ProjectID, readInClusterContext(ctx), time.Now())
+	snapshot.Label = strings.TrimSpace(args.Label)
+	file, err := saveSnapshot(h.snapshotDir(), snapshot)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: fmt.Sprintf("Saved the snapshot %s of cluster %s to %s: control plane version %s, %d node pools, %d namespaces, %d CRDs and %d webhooks. Use diff_cluster_snapshots with the snapshot name to compare it with another snapshot.",
+				filepath.Base(file), cluster.GetName(), file, snapshot.ControlPlaneVersion, len(snapshot.NodePools), len(snapshot.Namespaces), len(snapshot.CRDs), len(snapshot.Webhooks))},
+		},
+	}, nil, nil
+}
+
+func (h *handlers) diffClusterSnapshots(_ context.Context, _ *mcp.CallToolRequest, args *diffClusterSnapshotsArgs) (*mcp.CallToolResult, any, error) {
+	if args.Before == "" {
+		return nil, nil, fmt.Errorf("before argument cannot be empty")
+	}
+	if args.After == "" {
+		return nil, nil, fmt.Errorf("after argument cannot be empty")
+	}
+	var snapshots []*clusterSnapshot
+	for _, name := range []string{args.Before, args.After} {
+		file, err := snapshotPath(h.snapshotDir(), name)
+		if err != nil {
+			return nil, nil, err
+		}
+		s, err := loadSnapshot(file)
+		if err != nil {
+			return nil, nil, err
+		}
+		snapshots = append(snapshots, s)
+	}
+	before, after := snapshots[0], snapshots[1]
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: diffSnapshots(before, after)},
+		},
+	}, nil, nil
+}
+
+// newClusterSnapshot returns the normalized inventory of a cluster.
+func newClusterSnapshot(cluster *containerpb.Cluster, projectID string, ic inClusterContext, now time.Time) *clusterSnapshot {
+	s := &clusterSnapshot{
+		CreatedAt:           now.UTC().Format(time.RFC3339),
+		ProjectID:           projectID,
+		Location:            cluster.GetLocation(),
+		Cluster:             cluster.GetName(),
+		ControlPlaneVersion: cluster.GetCurrentMasterVersion(),
+		ReleaseChannel:      cluster.GetReleaseChannel().GetChannel().String(),
+		Addons:              sorted(gke.EnabledAddons(cluster)),
+		WorkloadCounts:      ic.counts,
+		Workloads:           map[string][]string{},
+	}
+	for _, pool := range cluster.GetNodePools() {
+		s.NodePools = append(s.NodePools, nodePoolSnapshot{
+			Name:        pool.GetName(),
+			Version:     pool.GetVersion(),
+			MachineType: pool.GetConfig().GetMachineType(),
+			ImageType:   pool.GetConfig().GetImageType(),
+			Status:      pool.GetStatus().String(),
+		})
+	}
+	sort.Slice(s.NodePools, func(i, j int) bool { return s.NodePools[i].Name < s.NodePools[j].Name })
+
+	for _, ns := range ic.namespaces {
+		s.Namespaces = append(s.Namespaces, ns.Name)
+	}
+	s.Namespaces = sorted(s.Namespaces)
+	for _, resource := range inventoryResources {
+		if items, ok := ic.workloads[resource]; ok {
+			names := []string{}
+			for _, item := range items {
+				names = append(names, item.Namespace+"/"+item.Name)
+			}
+			s.Workloads[resource] = sorted(names)
+		}
+	}
+	for _, crd := range ic.crds {
+		s.CRDs = append(s.CRDs, crd.Name)
+	}
+	s.CRDs = sorted(s.CRDs)
+	for kind, configs := range map[string][]kube.WebhookConfiguration{
+		"mutating":   ic.mutatingWebhooks,
+		"validating": ic.validatingWebhooks,
+	} {
+		for _, config := range configs {
+			for _, w := range config.Webhooks {
+				s.Webhooks = append(s.Webhooks, fmt.Sprintf("%s/%s/%s", kind, config.Name, w.Name))
+			}
+		}
+	}
+	s.Webhooks = sorted(s.Webhooks)
+	if len(ic.errs) > 0 {
+		s.Errors = map[string]string{}
+		for resource, err := range ic.errs {
+			s.Errors[resource] = err.Error()
+		}
+	}
+	return s
+}
+
+// sorted sorts a list in place and returns it.
+func sorted(items []string) []string {
+	slices.Sort(items)
+	return items
+}
+
+// saveSnapshot writes a snapshot to a new file in dir, named after its
+// cluster, its label and its time, and returns the path of the file.
+func saveSnapshot(dir string, s *clusterSnapshot) (string, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return "", fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	parts := []string{}
+	for _, part := range []string{s.Cluster, s.Label} {
+		if part = strings.Trim(unsafeSnapshotNameChars.ReplaceAllString(strings.ToLower(part), "-"), "-."); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	createdAt, err := time.Parse(time.RFC3339, s.CreatedAt)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse snapshot time: %w", err)
+	}
+	parts = append(parts, createdAt.Format("20060102T150405Z"))
+	file := filepath.Join(dir, strings.Join(parts, "-")+".json")
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+	// #nosec G304
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", file, err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return "", fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	return file, nil
+}
+
+// snapshotPath returns the path of a snapshot given by file name in dir.
+// Paths are refused, so that callers can't read files outside of dir.
+func snapshotPath(dir, snapshot string) (string, error) {
+	if strings.ContainsAny(snapshot, `/\`) || snapshot == "." || snapshot == ".." {
+		return "", fmt.Errorf("invalid snapshot %q, expected a file name in the snapshot directory %s", snapshot, dir)
+	}
+	if !strings.HasSuffix(snapshot, ".json") {
+		snapshot += ".json"
+	}
+	return filepath.Join(dir, snapshot), nil
+}
+
+// loadSnapshot reads a snapshot saved by saveSnapshot.
+func loadSnapshot(file string) (*clusterSnapshot, error) {
+	// #nosec G304
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot %s: %w", file, err)
+	}
+	var s clusterSnapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %s: %w", file, err)
+	}
+	return &s, nil
+}
+
+// diffSnapshots describes the differences between two snapshots.
+func diffSnapshots(before, after *clusterSnapshot) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Differences of cluster %s between %s and %s:\n", after.Cluster, snapshotName(before), snapshotName(after))
+	if before.ProjectID != after.ProjectID || before.Location != after.Location || before.Cluster != after.Cluster {
+		fmt.Fprintf(&b, "Warning: the snapshots are of different clusters, %s/%s/%s and %s/%s/%s.\n",
+			before.ProjectID, before.Location, before.Cluster, after.ProjectID, after.Location, after.Cluster)
+	}
+
+	var changes []string
+	changed := func(what, from, to string) {
+		if from != to {
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", what, from, to))
+		}
+	}
+	changed("Control plane version", before.ControlPlaneVersion, after.ControlPlaneVersion)
+	changed("Release channel", before.ReleaseChannel, after.ReleaseChannel)
+
+	pools := map[string]nodePoolSnapshot{}
+	for _, pool := range before.NodePools {
+		pools[pool.Name] = pool
+	}
+	for _, pool := range after.NodePools {
+		old, ok := pools[pool.Name]
+		if !ok {
+			changes = append(changes, fmt.Sprintf("Node pool %s added: version %s, machine type %s, image type %s", pool.Name, pool.Version, pool.MachineType, pool.ImageType))
+			continue
+		}
+		delete(pools, pool.Name)
+		changed("Node pool "+pool.Name+" version", old.Version, pool.Version)
+		changed("Node pool "+pool.Name+" machine type", old.MachineType, pool.MachineType)
+		changed("Node pool "+pool.Name+" image type", old.ImageType, pool.ImageType)
+		changed("Node pool "+pool.Name+" status", old.Status, pool.Status)
+	}
+	for _, pool := range before.NodePools {
+		if _, ok := pools[pool.Name]; ok {
+			changes = append(changes, fmt.Sprintf("Node pool %s removed", pool.Name))
+		}
+	}
+
+	listChanges := func(what string, from, to []string) {
+		if added := missing(to, from); len(added) > 0 {
+			changes = append(changes, fmt.Sprintf("%s added (%d): %s", what, len(added), limitedList(added, maxContextListItems)))
+		}
+		if removed := missing(from, to); len(removed) > 0 {
+			changes = append(changes, fmt.Sprintf("%s removed (%d): %s", what, len(removed), limitedList(removed, maxContextListItems)))
+		}
+	}
+	listChanges("Add-ons", before.Addons, after.Addons)
+	listChanges("Namespaces", before.Namespaces, after.Namespaces)
+	for _, resource := range workloadResources {
+		from, okBefore := before.WorkloadCounts[resource]
+		to, okAfter := after.WorkloadCounts[resource]
+		if okBefore && okAfter && from != to {
+			changes = append(changes, fmt.Sprintf("Number of %s: %d -> %d", resource, from, to))
+		}
+	}
+	for _, resource := range inventoryResources {
+		from, okBefore := before.Workloads[resource]
+		to, okAfter := after.Workloads[resource]
+		if okBefore && okAfter {
+			listChanges(capitalize(resource), from, to)
+		}
+	}
+	listChanges("CRDs", before.CRDs, after.CRDs)
+	listChanges("Webhooks", before.Webhooks, after.Webhooks)
+
+	if len(changes) == 0 {
+		b.WriteString("No differences.\n")
+	}
+	for _, change := range changes {
+		fmt.Fprintf(&b, "- %s\n", change)
+	}
+
+	// Resources not read in either snapshot cannot be compared.
+	var errs []string
+	for _, s := range []*clusterSnapshot{before, after} {
+		for resource := range s.Errors {
+			if !slices.Contains(errs, resource) {
+				errs = append(errs, resource)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		fmt.Fprintf(&b, "Not compared, as they were not gathered in both snapshots: %s\n", strings.Join(sorted(errs), ", "))
+	}
+	return b.String()
+}
+
+// snapshotName returns the label and the time of a snapshot.
+func snapshotName(s *clusterSnapshot) string {
+	if s.Label == "" {
+		return s.CreatedAt
+	}
+	return fmt.Sprintf("%s (%s)", s.Label, s.CreatedAt)
+}
+
+// missing returns the items of a sorted list that are not in another.
+func missing(items, other []string) []string {
+	var out []string
+	for _, item := range items {
+		if _, found := slices.BinarySearch(other, item); !found {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// capitalize upper-cases the first letter of a resource name.
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewClusterSnapshot(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		Name:                 "my-cluster",
+		Location:             "us-central1",
+		CurrentMasterVersion: "1.31.5-gke.1000",
+		ReleaseChannel:       &containerpb.ReleaseChannel{Channel: containerpb.ReleaseChannel_REGULAR},
+		NodePools: []*containerpb.NodePool{
+			{Name: "pool-b", Version: "1.31.5-gke.1000", Status: containerpb.NodePool_RUNNING},
+			{Name: "pool-a", Version: "1.30.9-gke.1000", Config: &containerpb.NodeConfig{MachineType: "e2-standard-4", ImageType: "COS_CONTAINERD"}},
+		},
+	}
+	object := func(namespace, name string) kube.Object {
+		return kube.Object{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+	ic := inClusterContext{
+		namespaces: []kube.Namespace{{ObjectMeta: metav1.ObjectMeta{Name: "default"}}, {ObjectMeta: metav1.ObjectMeta{Name: "app"}}},
+		counts:     map[string]int{"deployments": 2, "pods": 7},
+		workloads:  map[string][]kube.Object{"deployments": {object("default", "web"), object("app", "api")}, "pods": {object("app", "api-1")}},
+		crds:       []kube.Object{object("", "widgets.example.com")},
+		validatingWebhooks: []kube.WebhookConfiguration{{
+			ObjectMeta: metav1.ObjectMeta{Name: "gatekeeper"},
+			Webhooks:   []kube.Webhook{{Name: "validation.gatekeeper.sh"}},
+		}},
+		errs: map[string]error{"services": errors.New("forbidden")},
+	}
+
+	got := newClusterSnapshot(cluster, "my-project", ic, time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+	want := &clusterSnapshot{
+		CreatedAt:           "2025-06-01T12:00:00Z",
+		ProjectID:           "my-project",
+		Location:            "us-central1",
+		Cluster:             "my-cluster",
+		ControlPlaneVersion: "1.31.5-gke.1000",
+		ReleaseChannel:      "REGULAR",
+		Addons:              []string{"HorizontalPodAutoscaling", "HttpLoadBalancing"},
+		NodePools: []nodePoolSnapshot{
+			{Name: "pool-a", Version: "1.30.9-gke.1000", MachineType: "e2-standard-4", ImageType: "COS_CONTAINERD", Status: "STATUS_UNSPECIFIED"},
+			{Name: "pool-b", Version: "1.31.5-gke.1000", Status: "RUNNING"},
+		},
+		Namespaces:     []string{"app", "default"},
+		WorkloadCounts: map[string]int{"deployments": 2, "pods": 7},
+		Workloads:      map[string][]string{"deployments": {"app/api", "default/web"}},
+		CRDs:           []string{"widgets.example.com"},
+		Webhooks:       []string{"validating/gatekeeper/validation.gatekeeper.sh"},
+		Errors:         map[string]string{"services": "forbidden"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("newClusterSnapshot() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSaveAndLoadSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	s := &clusterSnapshot{Label: "Pre Upgrade", CreatedAt: "2025-06-01T12:00:00Z", Cluster: "my-cluster", ControlPlaneVersion: "1.31.5-gke.1000"}
+
+	file, err := saveSnapshot(dir, s)
+	if err != nil {
+		t.Fatalf("saveSnapshot() error = %v", err)
+	}
+	if want := filepath.Join(dir, "my-cluster-pre-upgrade-20250601T120000Z.json"); file != want {
+		t.Errorf("saveSnapshot() = %q, want %q", file, want)
+	}
+	if _, err := saveSnapshot(dir, s); err == nil {
+		t.Error("saveSnapshot() overwrote an existing snapshot")
+	}
+
+	path, err := snapshotPath(dir, "my-cluster-pre-upgrade-20250601T120000Z")
+	if err != nil {
+		t.Fatalf("snapshotPath() error = %v", err)
+	}
+	got, err := loadSnapshot(path)
+	if err != nil {
+		t.Fatalf("loadSnapshot() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, s) {
+		t.Errorf("loadSnapshot() = %+v, want %+v", got, s)
+	}
+}
+
+func TestSnapshotPath(t *testing.T) {
+	for _, tc := range []struct {
+		snapshot string
+		want     string
+		wantErr  bool
+	}{
+		{snapshot: "before.json", want: filepath.Join("dir", "before.json")},
+		{snapshot: "before", want: filepath.Join("dir", "before.json")},
+		{snapshot: "other/before.json", wantErr: true},
+		{snapshot: `other\before.json`, wantErr: true},
+		{snapshot: "../before", wantErr: true},
+		{snapshot: "/etc/passwd", wantErr: true},
+		{snapshot: "..", wantErr: true},
+	} {
+		got, err := snapshotPath("dir", tc.snapshot)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("snapshotPath(%q) error = %v, wantErr %v", tc.snapshot, err, tc.wantErr)
+		}
+		if got != tc.want {
+			t.Errorf("snapshotPath(%q) = %q, want %q", tc.snapshot, got, tc.want)
+		}
+	}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	before := &clusterSnapshot{
+		Label:               "pre-upgrade",
+		CreatedAt:           "2025-06-01T12:00:00Z",
+		Cluster:             "my-cluster",
+		ControlPlaneVersion: "1.30.9-gke.1000",
+		ReleaseChannel:      "REGULAR",
+		NodePools: []nodePoolSnapshot{
+			{Name: "default-pool", Version: "1.30.9-gke.1000", Status: "RUNNING"},
+			{Name: "old-pool", Version: "1.30.9-gke.1000", Status: "RUNNING"},
+		},
+		Namespaces:     []string{"app", "default"},
+		WorkloadCounts: map[string]int{"deployments": 2, "pods": 7},
+		Workloads:      map[string][]string{"deployments": {"app/api", "default/web"}},
+		CRDs:           []string{"widgets.example.com"},
+	}
+	after := &clusterSnapshot{
+		CreatedAt:           "2025-06-02T12:00:00Z",
+		Cluster:             "my-cluster",
+		ControlPlaneVersion: "1.31.5-gke.1000",
+		ReleaseChannel:      "REGULAR",
+		NodePools: []nodePoolSnapshot{
+			{Name: "default-pool", Version: "1.31.5-gke.1000", Status: "RUNNING"},
+			{Name: "new-pool", Version: "1.31.5-gke.1000", MachineType: "e2-standard-4", ImageType: "COS_CONTAINERD", Status: "RUNNING"},
+		},
+		Namespaces:     []string{"app", "default"},
+		WorkloadCounts: map[string]int{"deployments": 1, "pods": 5},
+		Workloads:      map[string][]string{"deployments": {"default/web"}},
+		CRDs:           []string{"gadgets.example.com", "widgets.example.com"},
+		Errors:         map[string]string{"services": "forbidden"},
+	}
+
+	got := diffSnapshots(before, after)
+	for _, want := range []string{
+		"Differences of cluster my-cluster between pre-upgrade (2025-06-01T12:00:00Z) and 2025-06-02T12:00:00Z:",
+		"- Control plane version: 1.30.9-gke.1000 -> 1.31.5-gke.1000",
+		"- Node pool default-pool version: 1.30.9-gke.1000 -> 1.31.5-gke.1000",
+		"- Node pool new-pool added: version 1.31.5-gke.1000, machine type e2-standard-4, image type COS_CONTAINERD",
+		"- Node pool old-pool removed",
+		"- Number of deployments: 2 -> 1",
+		"- Number of pods: 7 -> 5",
+		"- Deployments removed (1): app/api",
+		"- CRDs added (1): gadgets.example.com",
+		"Not compared, as they were not gathered in both snapshots: services",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("diffSnapshots() missing %q, got:\n%s", want, got)
+		}
+	}
+	for _, unwanted := range []string{"Release channel", "Namespaces", "Warning"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("diffSnapshots() contains %q, got:\n%s", unwanted, got)
+		}
+	}
+
+	if got := diffSnapshots(before, before); !strings.Contains(got, "No differences.") {
+		t.Errorf("diffSnapshots() of the same snapshot = %q, want no differences", got)
+	}
+}
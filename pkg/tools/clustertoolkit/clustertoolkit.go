@@ -18,7 +18,7 @@ package clustertoolkit
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"os/exec"
 	"path/filepath"
 	"strings"
@@ -41,7 +41,7 @@ func Install(_ context.Context, s *mcp.Server, _ *config.Config) error {
 	return nil
 }
 
-func clusterToolkitDownload(_ context.Context, _ *mcp.CallToolRequest, args *clusterToolkitDownloadArgs) (*mcp.CallToolResult, any, error) {
+func clusterToolkitDownload(ctx context.Context, _ *mcp.CallToolRequest, args *clusterToolkitDownloadArgs) (*mcp.CallToolResult, any, error) {
 	if args.DownloadDirectory == "" {
 		return nil, nil, fmt.Errorf("download_directory argument cannot be empty")
 	}
@@ -51,9 +51,9 @@ func clusterToolkitDownload(_ context.Context, _ *mcp.CallToolRequest, args *clu
 		downloadDir = filepath.Join(downloadDir, "cluster-toolkit")
 	}
 	// #nosec G204
-	out, err := exec.Command("git", "clone", "https://github.com/GoogleCloudPlatform/cluster-toolkit.git", downloadDir).Output()
+	out, err := exec.CommandContext(ctx, "git", "clone", "https://github.com/GoogleCloudPlatform/cluster-toolkit.git", downloadDir).Output()
 	if err != nil {
-		log.Printf("Failed to download Cluster Toolkit: %v %s", err, out)
+		slog.Error("Failed to download Cluster Toolkit", "error", err, "output", string(out))
 		return nil, nil, err
 	}
 
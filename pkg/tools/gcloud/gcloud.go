@@ -0,0 +1,216 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gcloud provides an MCP tool running read-only gcloud container commands.
+package gcloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/metrics"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/output"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tracing"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// maxOutputSize bounds the size of the returned gcloud output, in bytes.
+	maxOutputSize = 64 * 1024
+	// commandTimeout bounds the runtime of a gcloud command.
+	commandTimeout = 60 * time.Second
+	redacted       = "REDACTED"
+)
+
+// command is an allowlisted gcloud container command.
+type command struct {
+	// args are the gcloud arguments following "container".
+	args []string
+	// needsResource reports whether the command takes the name of a resource.
+	needsResource bool
+	// needsCluster reports whether the command takes the --cluster flag.
+	needsCluster bool
+}
+
+var commands = map[string]command{
+	"clusters list":       {args: []string{"clusters", "list"}},
+	"clusters describe":   {args: []string{"clusters", "describe"}, needsResource: true},
+	"node-pools list":     {args: []string{"node-pools", "list"}, needsCluster: true},
+	"node-pools describe": {args: []string{"node-pools", "describe"}, needsResource: true, needsCluster: true},
+	"operations list":     {args: []string{"operations", "list"}},
+	"operations describe": {args: []string{"operations", "describe"}, needsResource: true},
+	"get-server-config":   {args: []string{"get-server-config"}},
+}
+
+// sensitiveFields are the fields of gcloud JSON output holding credentials.
+var sensitiveFields = []string{"password", "clientKey", "clientCertificate", "clusterCaCertificate", "accessToken", "token"}
+
+var (
+	nameRegexp     = regexp.MustCompile(`^[a-z0-9]([-a-z0-9_]*[a-z0-9])?$`)
+	locationRegexp = regexp.MustCompile(`^[a-z]+(-[a-z0-9]+)+$`)
+	projectRegexp  = regexp.MustCompile(`^[a-z][-a-z0-9.:]*[a-z0-9]$`)
+)
+
+type handlers struct {
+	c *config.Config
+}
+
+type gcloudReadonlyArgs struct {
+	Command   string `json:"command" jsonschema:"The gcloud container command to run: clusters list, clusters describe, node-pools list, node-pools describe, operations list, operations describe or get-server-config."`
+	Resource  string `json:"resource,omitempty" jsonschema:"Name of the cluster, node pool or operation for describe commands."`
+	Cluster   string `json:"cluster,omitempty" jsonschema:"GKE cluster name for node-pools commands."`
+	ProjectID string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location  string `json:"location,omitempty" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Filter    string `json:"filter,omitempty" jsonschema:"gcloud filter expression for list commands, e.g. status=RUNNING."`
+}
+
+// Install registers the read-only gcloud tool with the MCP server.
+func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
+	h := &handlers{c: c}
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "gcloud_readonly",
+		Description: "Run an allowlisted read-only gcloud container command (clusters, node-pools and operations list and describe, get-server-config) with JSON output. Credentials in the output are redacted. Prefer the dedicated tools, and this tool over running gcloud in a shell.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.gcloudReadonly)
+
+	return nil
+}
+
+func (h *handlers) gcloudReadonly(ctx context.Context, _ *mcp.CallToolRequest, args *gcloudReadonlyArgs) (*mcp.CallToolResult, any, error) {
+	if args.ProjectID == "" {
+		args.ProjectID = h.c.DefaultProjectID(ctx)
+	}
+	if args.Location == "" && strings.HasSuffix(args.Command, "describe") {
+		args.Location = h.c.DefaultLocation(ctx)
+	}
+	gcloudArgs, err := buildArgs(args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, "gcloud", attribute.String("gcloud.command", args.Command))
+	start := time.Now()
+	// #nosec G204
+	out, err := exec.CommandContext(ctx, "gcloud", gcloudArgs...).Output()
+	metrics.ObserveExternal("gcloud", start, err)
+	tracing.End(span, err)
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, nil, fmt.Errorf("failed to run gcloud %s: %w: %s", strings.Join(gcloudArgs, " "), err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, nil, fmt.Errorf("failed to run gcloud %s: %w", strings.Join(gcloudArgs, " "), err)
+	}
+
+	text, err := redact(out)
+	if err != nil {
+		return nil, nil, err
+	}
+	if truncated, ok := output.Truncate(text, maxOutputSize); ok {
+		text = truncated + "\n(output truncated, narrow the query with a filter)"
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: text},
+		},
+	}, nil, nil
+}
+
+// buildArgs validates the arguments against the allowlist and returns the gcloud arguments.
+func buildArgs(args *gcloudReadonlyArgs) ([]string, error) {
+	cmd, ok := commands[strings.Join(strings.Fields(args.Command), " ")]
+	if !ok {
+		return nil, fmt.Errorf("command %q is not allowed, use one of: %s", args.Command, strings.Join(slices.Sorted(maps.Keys(commands)), ", "))
+	}
+
+	gcloudArgs := slices.Concat([]string{"container"}, cmd.args)
+	if cmd.needsResource {
+		if !nameRegexp.MatchString(args.Resource) {
+			return nil, fmt.Errorf("invalid resource name: %q", args.Resource)
+		}
+		gcloudArgs = append(gcloudArgs, args.Resource)
+	}
+	if cmd.needsCluster {
+		if !nameRegexp.MatchString(args.Cluster) {
+			return nil, fmt.Errorf("invalid cluster name: %q", args.Cluster)
+		}
+		gcloudArgs = append(gcloudArgs, "--cluster="+args.Cluster)
+	}
+	if !projectRegexp.MatchString(args.ProjectID) {
+		return nil, fmt.Errorf("invalid project ID: %q", args.ProjectID)
+	}
+	gcloudArgs = append(gcloudArgs, "--project="+args.ProjectID)
+	if args.Location != "" {
+		if !locationRegexp.MatchString(args.Location) {
+			return nil, fmt.Errorf("invalid location: %q", args.Location)
+		}
+		gcloudArgs = append(gcloudArgs, "--location="+args.Location)
+	}
+	if args.Filter != "" {
+		if cmd.needsResource {
+			return nil, fmt.Errorf("filter can only be used with list commands")
+		}
+		gcloudArgs = append(gcloudArgs, "--filter="+args.Filter)
+	}
+	return append(gcloudArgs, "--format=json", "--quiet"), nil
+}
+
+// redact replaces the credentials in gcloud JSON output and re-indents it.
+func redact(out []byte) (string, error) {
+	var v any
+	if err := json.Unmarshal(out, &v); err != nil {
+		return "", fmt.Errorf("failed to parse gcloud output: %w", err)
+	}
+	redactValue(v)
+	redactedOut, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to format gcloud output: %w", err)
+	}
+	return string(redactedOut), nil
+}
+
+func redactValue(v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if slices.Contains(sensitiveFields, key) {
+				if s, ok := value.(string); ok && s != "" {
+					v[key] = redacted
+				}
+				continue
+			}
+			redactValue(value)
+		}
+	case []any:
+		for _, value := range v {
+			redactValue(value)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloud
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBuildArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    gcloudReadonlyArgs
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "clusters list",
+			args: gcloudReadonlyArgs{Command: "clusters list", ProjectID: "my-project", Filter: "status=RUNNING"},
+			want: []string{"container", "clusters", "list", "--project=my-project", "--filter=status=RUNNING", "--format=json", "--quiet"},
+		},
+		{
+			name: "node pool describe",
+			args: gcloudReadonlyArgs{Command: " node-pools  describe ", Resource: "default-pool", Cluster: "my-cluster", ProjectID: "my-project", Location: "us-central1-a"},
+			want: []string{"container", "node-pools", "describe", "default-pool", "--cluster=my-cluster", "--project=my-project", "--location=us-central1-a", "--format=json", "--quiet"},
+		},
+		{
+			name: "get-server-config",
+			args: gcloudReadonlyArgs{Command: "get-server-config", ProjectID: "my-project", Location: "us-central1"},
+			want: []string{"container", "get-server-config", "--project=my-project", "--location=us-central1", "--format=json", "--quiet"},
+		},
+		{name: "mutating command", args: gcloudReadonlyArgs{Command: "clusters delete", Resource: "my-cluster", ProjectID: "my-project"}, wantErr: true},
+		{name: "get-credentials", args: gcloudReadonlyArgs{Command: "clusters get-credentials", Resource: "my-cluster", ProjectID: "my-project"}, wantErr: true},
+		{name: "missing resource", args: gcloudReadonlyArgs{Command: "clusters describe", ProjectID: "my-project"}, wantErr: true},
+		{name: "flag as resource", args: gcloudReadonlyArgs{Command: "clusters describe", Resource: "--impersonate-service-account=x", ProjectID: "my-project"}, wantErr: true},
+		{name: "missing cluster", args: gcloudReadonlyArgs{Command: "node-pools list", ProjectID: "my-project"}, wantErr: true},
+		{name: "invalid location", args: gcloudReadonlyArgs{Command: "clusters list", ProjectID: "my-project", Location: "--log-http"}, wantErr: true},
+		{name: "filter on describe", args: gcloudReadonlyArgs{Command: "clusters describe", Resource: "c", ProjectID: "my-project", Filter: "x"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildArgs(&tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("buildArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("buildArgs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedact(t *testing.T) {
+	out := `{"name": "my-cluster", "masterAuth": {"clusterCaCertificate": "LS0tLS1CRUdJTi...", "clientKey": "secret", "password": ""}, "nodePools": [{"name": "default-pool", "config": {"token": "abc"}}]}`
+
+	got, err := redact([]byte(out))
+	if err != nil {
+		t.Fatalf("redact() error = %v", err)
+	}
+	for _, secret := range []string{"LS0tLS1CRUdJTi", `"secret"`, `"abc"`} {
+		if strings.Contains(got, secret) {
+			t.Errorf("redact() kept %s, got:\n%s", secret, got)
+		}
+	}
+	for _, want := range []string{`"name": "my-cluster"`, `"clientKey": "REDACTED"`, `"password": ""`, `"token": "REDACTED"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("redact() missing %s, got:\n%s", want, got)
+		}
+	}
+
+	if _, err := redact([]byte("not json")); err == nil {
+		t.Error("redact() expected an error for invalid JSON")
+	}
+}
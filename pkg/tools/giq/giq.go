@@ -18,7 +18,7 @@ package giq
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"os/exec"
 
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
@@ -46,7 +46,7 @@ func Install(_ context.Context, s *mcp.Server, _ *config.Config) error {
 	return nil
 }
 
-func giqGenerateManifest(_ context.Context, _ *mcp.CallToolRequest, args *giqGenerateManifestArgs) (*mcp.CallToolResult, any, error) {
+func giqGenerateManifest(ctx context.Context, _ *mcp.CallToolRequest, args *giqGenerateManifestArgs) (*mcp.CallToolResult, any, error) {
 	if args.Model == "" {
 		return nil, nil, fmt.Errorf("model argument cannot be empty")
 	}
@@ -71,9 +71,9 @@ func giqGenerateManifest(_ context.Context, _ *mcp.CallToolRequest, args *giqGen
 		gcloudArgs = append(gcloudArgs, "--target-ntpot-milliseconds", args.TargetNTPOTMilliseconds)
 	}
 	// #nosec G204
-	out, err := exec.Command("gcloud", gcloudArgs...).Output()
+	out, err := exec.CommandContext(ctx, "gcloud", gcloudArgs...).Output()
 	if err != nil {
-		log.Printf("Failed to generate manifest: %v", err)
+		slog.Error("Failed to generate manifest", "error", err)
 
 		return nil, nil, err
 	}
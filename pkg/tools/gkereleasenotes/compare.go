@@ -0,0 +1,176 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gkereleasenotes
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/output"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// maxReleaseNotesSize bounds the size of the release notes included in a
+// versions comparison, in bytes.
+const maxReleaseNotesSize = 48 * 1024
+
+var (
+	// nodeImageKeywords pick the node image entries from the release notes.
+	nodeImageKeywords = []string{"node image", "container-optimized os", "cos-", "ubuntu", "containerd", "kernel", "runc", "cgroup"}
+	// nodeImageRegexp matches COS and Ubuntu node image versions, e.g.
+	// cos-117-18613-164-13 or ubuntu-gke-2204-1-30-v20250101.
+	nodeImageRegexp = regexp.MustCompile(`\b(cos-[0-9]+-[0-9]+-[0-9]+-[0-9]+|ubuntu-gke-[0-9]+-[0-9a-z-]+)\b`)
+)
+
+type compareGkeVersionsArgs struct {
+	SourceVersion string `json:"source_version" jsonschema:"The older GKE version, e.g. 1.31.5-gke.1169000."`
+	TargetVersion string `json:"target_version" jsonschema:"The newer GKE version, e.g. 1.32.2-gke.1297002."`
+}
+
+func compareGkeVersions(ctx context.Context, _ *mcp.CallToolRequest, args *compareGkeVersionsArgs) (*mcp.CallToolResult, any, error) {
+	if args.SourceVersion == "" {
+		return nil, nil, fmt.Errorf("source_version argument cannot be empty")
+	}
+	if args.TargetVersion == "" {
+		return nil, nil, fmt.Errorf("target_version argument cannot be empty")
+	}
+	cmp, err := compareVersions(args.SourceVersion, args.TargetVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cmp <= 0 {
+		return nil, nil, fmt.Errorf("target_version %s must be newer than source_version %s", args.TargetVersion, args.SourceVersion)
+	}
+	releaseNotes, err := ReleaseNotesForUpgrade(ctx, args.SourceVersion, args.TargetVersion)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get GKE release notes: %w", err)
+	}
+	diff, err := versionsDiff(args.SourceVersion, args.TargetVersion, releaseNotes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: diff},
+		},
+	}, nil, nil
+}
+
+// versionsDiff describes the Kubernetes, GKE and node image changes between
+// two GKE versions, followed by the release notes between them.
+func versionsDiff(sourceVersion, targetVersion, releaseNotes string) (string, error) {
+	sourceMajor, sourceMinor, sourcePatch, sourceGKE, err := parseGkeVersion(sourceVersion)
+	if err != nil {
+		return "", err
+	}
+	targetMajor, targetMinor, targetPatch, targetGKE, err := parseGkeVersion(targetVersion)
+	if err != nil {
+		return "", err
+	}
+	if sourceMajor != targetMajor {
+		return "", fmt.Errorf("cannot compare versions of different major versions: %s and %s", sourceVersion, targetVersion)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "GKE versions: %s -> %s\n", sourceVersion, targetVersion)
+
+	b.WriteString("\nKubernetes:\n")
+	fmt.Fprintf(&b, "- Version: %d.%d.%d -> %d.%d.%d\n", sourceMajor, sourceMinor, sourcePatch, targetMajor, targetMinor, targetPatch)
+	if sourceMinor == targetMinor {
+		if targetPatch > sourcePatch {
+			fmt.Fprintf(&b, "- Patch releases: v%d.%d.%d to v%d.%d.%d\n", targetMajor, targetMinor, sourcePatch+1, targetMajor, targetMinor, targetPatch)
+		} else {
+			b.WriteString("- Patch releases: none, same Kubernetes version\n")
+		}
+	} else {
+		minors := make([]string, 0, targetMinor-sourceMinor)
+		for m := sourceMinor + 1; m <= targetMinor; m++ {
+			minors = append(minors, fmt.Sprintf("%d.%d", targetMajor, m))
+		}
+		fmt.Fprintf(&b, "- Minor versions crossed: %s\n", strings.Join(minors, ", "))
+		patches := []string{fmt.Sprintf("v%d.%d.%d and later", sourceMajor, sourceMinor, sourcePatch+1)}
+		for m := sourceMinor + 1; m < targetMinor; m++ {
+			patches = append(patches, fmt.Sprintf("all of v%d.%d", targetMajor, m))
+		}
+		patches = append(patches, fmt.Sprintf("v%d.%d.0 to v%d.%d.%d", targetMajor, targetMinor, targetMajor, targetMinor, targetPatch))
+		fmt.Fprintf(&b, "- Patch releases: %s\n", strings.Join(patches, ", "))
+	}
+	b.WriteString("- Use the get_k8s_changelog tool for the Kubernetes changes of these versions.\n")
+
+	b.WriteString("\nGKE:\n")
+	if sourceMinor == targetMinor && sourcePatch == targetPatch {
+		fmt.Fprintf(&b, "- GKE patch: gke.%d -> gke.%d, same Kubernetes version\n", sourceGKE, targetGKE)
+	} else {
+		fmt.Fprintf(&b, "- GKE patch: gke.%d -> gke.%d\n", sourceGKE, targetGKE)
+	}
+	var between []string
+	for _, version := range gkeVersionRegexp.FindAllString(releaseNotes, -1) {
+		if slices.Contains(between, version) {
+			continue
+		}
+		afterSource, err := compareVersions(sourceVersion, version)
+		if err != nil {
+			continue
+		}
+		beforeTarget, err := compareVersions(version, targetVersion)
+		if err != nil {
+			continue
+		}
+		if afterSource > 0 && beforeTarget > 0 {
+			between = append(between, version)
+		}
+	}
+	slices.SortFunc(between, func(a, b string) int {
+		cmp, _ := compareVersions(b, a)
+		return cmp
+	})
+	if len(between) > 0 {
+		fmt.Fprintf(&b, "- Versions in between mentioned in the release notes: %s\n", strings.Join(between, ", "))
+	}
+
+	b.WriteString("\nNode images:\n")
+	nodeImageEntries := FilterEntries(releaseNotes, nodeImageKeywords)
+	var images []string
+	for _, entry := range nodeImageEntries {
+		for _, image := range nodeImageRegexp.FindAllString(entry, -1) {
+			if !slices.Contains(images, image) {
+				images = append(images, image)
+			}
+		}
+	}
+	if len(images) > 0 {
+		fmt.Fprintf(&b, "- Node images mentioned: %s\n", strings.Join(images, ", "))
+	}
+	if len(nodeImageEntries) == 0 {
+		b.WriteString("- No node image or container runtime release notes found.\n")
+	}
+	for _, entry := range nodeImageEntries {
+		fmt.Fprintf(&b, "\n%s\n", entry)
+	}
+
+	releases := splitReleases(releaseNotes)
+	fmt.Fprintf(&b, "\nRelease notes (%d releases):\n", len(releases))
+	notes := strings.Join(releases, "\n\n")
+	if truncated, ok := output.Truncate(notes, maxReleaseNotesSize); ok {
+		notes = truncated + "\n(release notes truncated, use the get_gke_release_notes tool to page through them)"
+	}
+	b.WriteString(notes)
+	b.WriteString("\n")
+	return b.String(), nil
+}
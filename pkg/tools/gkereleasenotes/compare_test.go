@@ -0,0 +1,73 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gkereleasenotes
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVersionsDiff(t *testing.T) {
+	releaseNotes := `March 10, 2025
+
+Version 1.32.2-gke.1297002 is now the default version in the Regular channel.
+
+Nodes running 1.32.2-gke.1297002 use the node image cos-117-18613-164-13 with containerd 1.7.24.
+
+February 20, 2025
+
+Version 1.31.6-gke.1020000 fixes an issue with Workload Identity.
+
+Version 1.30.1-gke.1000 is no longer available.`
+
+	got, err := versionsDiff("1.30.5-gke.1169000", "1.32.2-gke.1297002", releaseNotes)
+	if err != nil {
+		t.Fatalf("versionsDiff() error = %v", err)
+	}
+	for _, want := range []string{
+		"GKE versions: 1.30.5-gke.1169000 -> 1.32.2-gke.1297002",
+		"- Version: 1.30.5 -> 1.32.2",
+		"- Minor versions crossed: 1.31, 1.32",
+		"- Patch releases: v1.30.6 and later, all of v1.31, v1.32.0 to v1.32.2",
+		"- GKE patch: gke.1169000 -> gke.1297002",
+		"- Versions in between mentioned in the release notes: 1.31.6-gke.1020000\n",
+		"- Node images mentioned: cos-117-18613-164-13",
+		"March 10, 2025\nNodes running 1.32.2-gke.1297002",
+		"Release notes (2 releases):",
+		"fixes an issue with Workload Identity",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("versionsDiff() missing %q, got:\n%s", want, got)
+		}
+	}
+
+	got, err = versionsDiff("1.32.1-gke.1000", "1.32.1-gke.2000", "")
+	if err != nil {
+		t.Fatalf("versionsDiff() error = %v", err)
+	}
+	for _, want := range []string{
+		"- Patch releases: none, same Kubernetes version",
+		"- GKE patch: gke.1000 -> gke.2000, same Kubernetes version",
+		"- No node image or container runtime release notes found.",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("versionsDiff() missing %q, got:\n%s", want, got)
+		}
+	}
+
+	if _, err := versionsDiff("1.32", "1.33.1-gke.1000", ""); err == nil {
+		t.Error("versionsDiff() with a Kubernetes version error = nil, want an error")
+	}
+}
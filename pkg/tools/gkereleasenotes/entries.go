@@ -0,0 +1,221 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gkereleasenotes
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// channelRegexp matches the release channels mentioned in a release note.
+var channelRegexp = regexp.MustCompile(`(?i)\b(rapid|regular|stable|extended)\s+(?:release\s+)?channel|\bno\s+channel\b`)
+
+// releaseNoteTypes maps the labels of the release notes, from the classes
+// of their elements or the first line of their text, to their types.
+var releaseNoteTypes = map[string]string{
+	"feature":      "feature",
+	"changed":      "change",
+	"change":       "change",
+	"fixed":        "fix",
+	"fix":          "fix",
+	"deprecated":   "deprecation",
+	"deprecation":  "deprecation",
+	"breaking":     "breaking",
+	"issue":        "issue",
+	"security":     "security",
+	"announcement": "announcement",
+}
+
+// releaseNote is an entry of the GKE release notes.
+type releaseNote struct {
+	Date     string   `json:"date" jsonschema:"The date of the release, e.g. October 17, 2025."`
+	Type     string   `json:"type,omitempty" jsonschema:"The type of the entry: feature, change, fix, deprecation, breaking, issue, security, announcement, version-update or security-update."`
+	Channels []string `json:"channels,omitempty" jsonschema:"The release channels the entry mentions, e.g. Rapid or No channel."`
+	Versions []string `json:"versions,omitempty" jsonschema:"The GKE versions the entry mentions."`
+	Body     string   `json:"body" jsonschema:"The text of the entry."`
+}
+
+// updateTypeAttr is the attribute marking the included sections of the
+// version and security updates of the HTML page with the type of their entries.
+const updateTypeAttr = "data-update-type"
+
+// includedUpdates selects the sections of the release notes listing the
+// version and security updates of each release, left out by default.
+type includedUpdates struct {
+	version  bool
+	security bool
+}
+
+// updateType returns the type of the entries of the section with the heading,
+// and whether the section is included, for the version and security updates.
+func (i includedUpdates) updateType(heading string) (typ string, included, ok bool) {
+	switch {
+	case strings.HasSuffix(heading, "Version updates"):
+		return "version-update", i.version, true
+	case strings.HasSuffix(heading, "Security updates"):
+		return "security-update", i.security, true
+	}
+	return "", false, false
+}
+
+// parseReleaseNotes returns the entries of the HTML page or the Atom feed of the
+// release notes, from newest to oldest, with the version and security updates
+// if included. Entries of the HTML page are read from the elements with a
+// release-<type> class under the date headings, or else from the paragraphs
+// of the text of the releases.
+func parseReleaseNotes(page []byte, include includedUpdates) ([]releaseNote, error) {
+	if isFeed(page) {
+		return parseReleaseNotesFeed(page, include)
+	}
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(page))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse release notes: %w", err)
+	}
+	doc.Find("[data-text$=\"Version updates\"], [data-text$=\"Security updates\"]").Each(func(_ int, s *goquery.Selection) {
+		typ, included, _ := include.updateType(s.AttrOr("data-text", ""))
+		section := s.Parent().Parent()
+		if !included {
+			section.Remove()
+			return
+		}
+		section.SetAttr(updateTypeAttr, typ)
+	})
+
+	// Separate the text of the blocks of each entry.
+	doc.Find(".releases").Find("div, p, li, h3, h4, br").AfterHtml(" ")
+
+	var notes []releaseNote
+	date := ""
+	doc.Find(".releases h2, .releases [class*=\"release-\"]").Each(func(_ int, s *goquery.Selection) {
+		if goquery.NodeName(s) == "h2" {
+			date = collapseSpaces(s.Text())
+			return
+		}
+		typ := ""
+		for _, class := range strings.Fields(s.AttrOr("class", "")) {
+			if t, ok := releaseNoteTypes[strings.TrimPrefix(class, "release-")]; ok && strings.HasPrefix(class, "release-") {
+				typ = t
+			}
+		}
+		if t, ok := s.Attr(updateTypeAttr); ok {
+			typ = t
+		}
+		if typ == "" {
+			return
+		}
+		label := collapseSpaces(s.Find("strong").First().Text())
+		body := strings.TrimSpace(strings.TrimPrefix(collapseSpaces(s.Text()), label))
+		notes = append(notes, newReleaseNote(date, typ, body))
+	})
+	if len(notes) > 0 {
+		return notes, nil
+	}
+
+	var text strings.Builder
+	doc.Find(".releases").Each(func(_ int, s *goquery.Selection) {
+		text.WriteString(s.Text())
+	})
+	return releaseNotesOfText(text.String()), nil
+}
+
+// releaseNotesOfText returns the entries of the text of the releases, whose
+// paragraphs follow their date headings and may start with their type.
+func releaseNotesOfText(text string) []releaseNote {
+	var notes []releaseNote
+	for _, release := range splitReleases(text) {
+		paragraphs := releaseNotesEntrySeparatorRegexp.Split(release, -1)
+		date := ""
+		if releaseDateHeadingRegexp.MatchString(paragraphs[0]) && !strings.Contains(paragraphs[0], "\n") {
+			date, paragraphs = strings.TrimSpace(paragraphs[0]), paragraphs[1:]
+		}
+		typ := ""
+		for _, p := range paragraphs {
+			p = strings.TrimSpace(p)
+			if t, ok := releaseNoteTypes[strings.ToLower(p)]; ok {
+				typ = t
+				continue
+			}
+			if first, rest, ok := strings.Cut(p, "\n"); ok {
+				if t, ok := releaseNoteTypes[strings.ToLower(strings.TrimSpace(first))]; ok {
+					typ, p = t, strings.TrimSpace(rest)
+				}
+			}
+			notes = append(notes, newReleaseNote(date, typ, collapseSpaces(p)))
+		}
+	}
+	return notes
+}
+
+// newReleaseNote returns the entry with the channels and versions its body
+// mentions.
+func newReleaseNote(date, typ, body string) releaseNote {
+	note := releaseNote{Date: date, Type: typ, Body: body}
+	for _, m := range channelRegexp.FindAllStringSubmatch(body, -1) {
+		channel := "No channel"
+		if m[1] != "" {
+			channel = strings.ToUpper(m[1][:1]) + strings.ToLower(m[1][1:])
+		}
+		if !slices.Contains(note.Channels, channel) {
+			note.Channels = append(note.Channels, channel)
+		}
+	}
+	for _, version := range gkeVersionRegexp.FindAllString(body, -1) {
+		if !slices.Contains(note.Versions, version) {
+			note.Versions = append(note.Versions, version)
+		}
+	}
+	return note
+}
+
+// releaseNotesText returns the text of the entries, with the date heading of
+// each release, as read by extractReleaseNotesRelevantForUpgrade.
+func releaseNotesText(notes []releaseNote) string {
+	var b strings.Builder
+	date := ""
+	for i, note := range notes {
+		if i == 0 || note.Date != date {
+			date = note.Date
+			fmt.Fprintf(&b, "%s\n\n", date)
+		}
+		fmt.Fprintf(&b, "%s\n\n", note.Body)
+	}
+	return b.String()
+}
+
+// upgradeReleaseNotes returns the entries of the releases relevant for an
+// upgrade from sourceVersion to targetVersion.
+func upgradeReleaseNotes(notes []releaseNote, sourceVersion, targetVersion string) ([]releaseNote, error) {
+	reduced, err := extractReleaseNotesRelevantForUpgrade(releaseNotesText(notes), sourceVersion, targetVersion)
+	if err != nil {
+		return nil, err
+	}
+	dates := make(map[string]bool)
+	for _, release := range splitReleases(reduced) {
+		first, _, _ := strings.Cut(release, "\n")
+		dates[strings.TrimSpace(first)] = true
+	}
+	var relevant []releaseNote
+	for _, note := range notes {
+		if dates[note.Date] {
+			relevant = append(relevant, note)
+		}
+	}
+	return relevant, nil
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gkereleasenotes
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const releaseNotesHTML = `<html><body><section class="releases">
+<h2 id="March_10_2025" data-text="March 10, 2025">March 10, 2025</h2>
+<div class="release-feature"><strong>Feature</strong>
+  <p>Version 1.32.2-gke.1297002 is now available in the Rapid channel and the Regular channel.</p>
+</div>
+<div class="release-changed"><strong>Changed</strong>
+  <div><h3 data-text="(2025-R10) Version updates">(2025-R10) Version updates</h3><p>1.31.6-gke.1020000 is the default.</p></div>
+</div>
+<div class="release-fixed"><strong>Fixed</strong><p>Fixed an issue with Workload Identity in 1.31.6-gke.1020000.</p></div>
+<h2 id="February_20_2025" data-text="February 20, 2025">February 20, 2025</h2>
+<div class="release-deprecated"><strong>Deprecated</strong><p>The   legacy logging agent is deprecated for clusters on no channel.</p></div>
+<div class="release-fixed"><strong>Fixed</strong><p>Version 1.30.5-gke.1000 fixes a kubelet crash.</p></div>
+<h2 id="January_15_2025" data-text="January 15, 2025">January 15, 2025</h2>
+<div class="release-feature"><strong>Feature</strong><p>Version 1.29.1-gke.100 adds a feature.</p></div>
+</section></body></html>`
+
+func TestParseReleaseNotes(t *testing.T) {
+	notes, err := parseReleaseNotes([]byte(releaseNotesHTML), includedUpdates{})
+	if err != nil {
+		t.Fatalf("parseReleaseNotes() error = %v", err)
+	}
+	want := []releaseNote{
+		{Date: "March 10, 2025", Type: "feature", Channels: []string{"Rapid", "Regular"}, Versions: []string{"1.32.2-gke.1297002"}, Body: "Version 1.32.2-gke.1297002 is now available in the Rapid channel and the Regular channel."},
+		{Date: "March 10, 2025", Type: "fix", Versions: []string{"1.31.6-gke.1020000"}, Body: "Fixed an issue with Workload Identity in 1.31.6-gke.1020000."},
+		{Date: "February 20, 2025", Type: "deprecation", Channels: []string{"No channel"}, Body: "The legacy logging agent is deprecated for clusters on no channel."},
+		{Date: "February 20, 2025", Type: "fix", Versions: []string{"1.30.5-gke.1000"}, Body: "Version 1.30.5-gke.1000 fixes a kubelet crash."},
+		{Date: "January 15, 2025", Type: "feature", Versions: []string{"1.29.1-gke.100"}, Body: "Version 1.29.1-gke.100 adds a feature."},
+	}
+	if !reflect.DeepEqual(notes, want) {
+		t.Errorf("parseReleaseNotes() = %+v\nwant %+v", notes, want)
+	}
+}
+
+func TestParseReleaseNotesText(t *testing.T) {
+	page := `<div class="releases">March 10, 2025
+
+Feature
+Version 1.32.2-gke.1297002 is now available.
+
+Changed
+
+Nodes use   containerd 2.0.
+
+February 20, 2025
+
+A note without a type.</div>`
+	notes, err := parseReleaseNotes([]byte(page), includedUpdates{})
+	if err != nil {
+		t.Fatalf("parseReleaseNotes() error = %v", err)
+	}
+	want := []releaseNote{
+		{Date: "March 10, 2025", Type: "feature", Versions: []string{"1.32.2-gke.1297002"}, Body: "Version 1.32.2-gke.1297002 is now available."},
+		{Date: "March 10, 2025", Type: "change", Body: "Nodes use containerd 2.0."},
+		{Date: "February 20, 2025", Body: "A note without a type."},
+	}
+	if !reflect.DeepEqual(notes, want) {
+		t.Errorf("parseReleaseNotes() = %+v\nwant %+v", notes, want)
+	}
+}
+
+func TestGetGkeReleaseNotes(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, OfflineFileName), []byte(releaseNotesHTML), 0600); err != nil {
+		t.Fatal(err)
+	}
+	offlineDir = dir
+	t.Cleanup(func() { offlineDir = "" })
+
+	s := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	mcp.AddTool(s, &mcp.Tool{Name: "get_gke_release_notes"}, getGkeReleaseNotes)
+	client := mcp.NewClient(&mcp.Implementation{Name: "client"}, nil)
+	ct, st := mcp.NewInMemoryTransports()
+	ss, err := s.Connect(ctx, st, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = ss.Close() }()
+	cs, err := client.Connect(ctx, ct, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = cs.Close() }()
+
+	result, err := cs.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "get_gke_release_notes",
+		Arguments: map[string]any{"SourceVersion": "1.30.5-gke.1000", "TargetVersion": "1.31.6-gke.1020000", "page_size": 2},
+	})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("CallTool() returned an error: %+v", result.Content)
+	}
+	var got getGkeReleaseNotesOutput
+	b, err := json.Marshal(result.StructuredContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Total != 4 || len(got.ReleaseNotes) != 2 || got.NextPageToken == "" {
+		t.Errorf("CallTool() = %+v, want the first 2 of the 4 release notes of March 10 and February 20", got)
+	}
+	if got.ReleaseNotes[0].Date != "March 10, 2025" || got.ReleaseNotes[1].Type != "fix" {
+		t.Errorf("CallTool() release notes = %+v", got.ReleaseNotes)
+	}
+	if text := result.Content[0].(*mcp.TextContent).Text; text != string(b) {
+		t.Errorf("CallTool() text content = %q, want the structured content %q", text, b)
+	}
+}
+
+func TestParseReleaseNotesIncludedUpdates(t *testing.T) {
+	page := `<section class="releases">
+<h2 data-text="March 10, 2025">March 10, 2025</h2>
+<div class="release-changed"><strong>Changed</strong>
+  <div><h3 data-text="(2025-R10) Version updates">(2025-R10) Version updates</h3><p>1.31.6-gke.1020000 is the default in the Stable channel.</p></div>
+</div>
+<div class="release-security"><strong>Security</strong>
+  <div><h3 data-text="(2025-R10) Security updates">(2025-R10) Security updates</h3><p>1.31.6-gke.1020000 patches CVE-2025-0001.</p></div>
+</div>
+<div class="release-fixed"><strong>Fixed</strong><p>Fixed a kubelet crash.</p></div>
+</section>`
+	tests := []struct {
+		include includedUpdates
+		want    []string
+	}{
+		{includedUpdates{}, []string{"fix"}},
+		{includedUpdates{version: true}, []string{"version-update", "fix"}},
+		{includedUpdates{security: true}, []string{"security-update", "fix"}},
+		{includedUpdates{version: true, security: true}, []string{"version-update", "security-update", "fix"}},
+	}
+	for _, tt := range tests {
+		notes, err := parseReleaseNotes([]byte(page), tt.include)
+		if err != nil {
+			t.Fatalf("parseReleaseNotes() error = %v", err)
+		}
+		var got []string
+		for _, note := range notes {
+			got = append(got, note.Type)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseReleaseNotes(%+v) types = %q, want %q", tt.include, got, tt.want)
+		}
+	}
+
+	notes, err := parseReleaseNotes([]byte(page), includedUpdates{security: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := releaseNote{Date: "March 10, 2025", Type: "security-update", Versions: []string{"1.31.6-gke.1020000"}, Body: "(2025-R10) Security updates 1.31.6-gke.1020000 patches CVE-2025-0001."}
+	if !reflect.DeepEqual(notes[0], want) {
+		t.Errorf("parseReleaseNotes() security update = %+v, want %+v", notes[0], want)
+	}
+}
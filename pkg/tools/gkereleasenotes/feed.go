@@ -0,0 +1,90 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gkereleasenotes
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// atomFeed is an Atom feed of release notes, with an entry per release.
+type atomFeed struct {
+	Entries []atomEntry `xml:"entry"`
+}
+
+// atomEntry is a release of an Atom feed of release notes, whose title is
+// its date and whose content is the HTML of its notes.
+type atomEntry struct {
+	Title   string `xml:"title"`
+	Content string `xml:"content"`
+}
+
+// isFeed reports whether the release notes are an Atom feed rather than an
+// HTML page.
+func isFeed(page []byte) bool {
+	return bytes.Contains(page[:min(len(page), 1024)], []byte("<feed"))
+}
+
+// parseReleaseNotesFeed returns the entries of the Atom feed of the release
+// notes, from newest to oldest, with the version and security updates if
+// included. The content of each release lists its notes under headings naming
+// their type, e.g. <h3>Feature</h3>.
+func parseReleaseNotesFeed(feed []byte, include includedUpdates) ([]releaseNote, error) {
+	var f atomFeed
+	if err := xml.Unmarshal(feed, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse release notes feed: %w", err)
+	}
+
+	var notes []releaseNote
+	for _, entry := range f.Entries {
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(entry.Content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse release notes of %s: %w", entry.Title, err)
+		}
+		date := collapseSpaces(entry.Title)
+		typ, skipping := "", false
+		var body []string
+		flush := func() {
+			if len(body) > 0 && !skipping {
+				notes = append(notes, newReleaseNote(date, typ, strings.Join(body, " ")))
+			}
+			body = nil
+		}
+		doc.Find("body").Children().Each(func(_ int, s *goquery.Selection) {
+			text := collapseSpaces(s.Text())
+			if goquery.NodeName(s) == "h3" {
+				if t, ok := releaseNoteTypes[strings.ToLower(text)]; ok {
+					flush()
+					typ, skipping = t, false
+					return
+				}
+				if t, included, ok := include.updateType(text); ok {
+					flush()
+					typ, skipping = t, !included
+					return
+				}
+			}
+			if text != "" {
+				body = append(body, text)
+			}
+		})
+		flush()
+	}
+	return notes, nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gkereleasenotes
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+)
+
+const releaseNotesFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Google Kubernetes Engine - Release notes</title>
+  <entry>
+    <title>March 10, 2025</title>
+    <updated>2025-03-10T00:00:00-07:00</updated>
+    <content type="html"><![CDATA[<h3>Feature</h3>
+<p>Version 1.32.2-gke.1297002 is now available in the Regular channel.</p>
+<h3>(2025-R10) Version updates</h3>
+<p>1.31.6-gke.1020000 is the default.</p>
+<h3>Fixed</h3>
+<p>Fixed an issue with Workload Identity in 1.31.6-gke.1020000.</p>
+<ul><li>Affects   Autopilot clusters.</li></ul>]]></content>
+  </entry>
+  <entry>
+    <title>February 20, 2025</title>
+    <updated>2025-02-20T00:00:00-07:00</updated>
+    <content type="html"><![CDATA[<h3>Deprecated</h3>
+<p>Version 1.30.5-gke.1000 deprecates the legacy logging agent.</p>]]></content>
+  </entry>
+</feed>`
+
+func TestParseReleaseNotesFeed(t *testing.T) {
+	if !isFeed([]byte(releaseNotesFeed)) || isFeed([]byte(releaseNotesHTML)) {
+		t.Fatal("isFeed() doesn't tell the feed from the HTML page")
+	}
+	notes, err := parseReleaseNotes([]byte(releaseNotesFeed), includedUpdates{})
+	if err != nil {
+		t.Fatalf("parseReleaseNotes() error = %v", err)
+	}
+	want := []releaseNote{
+		{Date: "March 10, 2025", Type: "feature", Channels: []string{"Regular"}, Versions: []string{"1.32.2-gke.1297002"}, Body: "Version 1.32.2-gke.1297002 is now available in the Regular channel."},
+		{Date: "March 10, 2025", Type: "fix", Versions: []string{"1.31.6-gke.1020000"}, Body: "Fixed an issue with Workload Identity in 1.31.6-gke.1020000. Affects Autopilot clusters."},
+		{Date: "February 20, 2025", Type: "deprecation", Versions: []string{"1.30.5-gke.1000"}, Body: "Version 1.30.5-gke.1000 deprecates the legacy logging agent."},
+	}
+	if !reflect.DeepEqual(notes, want) {
+		t.Errorf("parseReleaseNotes() = %+v\nwant %+v", notes, want)
+	}
+
+	if _, err := parseReleaseNotesFeed([]byte("<feed><entry>"), includedUpdates{}); err == nil {
+		t.Error("parseReleaseNotesFeed() of a truncated feed error = nil, want an error")
+	}
+}
+
+func TestReleaseNotesForUpgradeFeed(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, OfflineFileName), []byte(releaseNotesFeed), 0600); err != nil {
+		t.Fatal(err)
+	}
+	offlineDir = dir
+	t.Cleanup(func() { offlineDir = "" })
+
+	got, err := ReleaseNotesForUpgrade(context.Background(), "1.30.5-gke.1000", "1.31.6-gke.1020000")
+	if err != nil {
+		t.Fatalf("ReleaseNotesForUpgrade() error = %v", err)
+	}
+	for _, want := range []string{"March 10, 2025\n\n", "Workload Identity", "February 20, 2025\n\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("ReleaseNotesForUpgrade() missing %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "is the default") {
+		t.Errorf("ReleaseNotesForUpgrade() contains the version updates, got:\n%s", got)
+	}
+}
+
+func TestSourceReleaseNotesURL(t *testing.T) {
+	tests := []struct {
+		sources config.Sources
+		want    string
+	}{
+		{config.Sources{}, releaseNotesURL},
+		{config.Sources{ReleaseNotesFeed: true}, releaseNotesFeedURL},
+		{config.Sources{ReleaseNotesFeed: true, ReleaseNotesURL: "https://mirror.example.com/gke.xml"}, "https://mirror.example.com/gke.xml"},
+	}
+	for _, tt := range tests {
+		c := config.New("test", config.WithSources(tt.sources))
+		if got := sourceReleaseNotesURL(c); got != tt.want {
+			t.Errorf("sourceReleaseNotesURL(%+v) = %q, want %q", tt.sources, got, tt.want)
+		}
+	}
+}
+
+func TestParseReleaseNotesFeedVersionUpdates(t *testing.T) {
+	notes, err := parseReleaseNotesFeed([]byte(releaseNotesFeed), includedUpdates{version: true})
+	if err != nil {
+		t.Fatalf("parseReleaseNotesFeed() error = %v", err)
+	}
+	if len(notes) != 4 || notes[1].Type != "version-update" || notes[1].Body != "1.31.6-gke.1020000 is the default." {
+		t.Errorf("parseReleaseNotesFeed() = %+v, want the version updates as the second entry", notes)
+	}
+}
@@ -18,18 +18,25 @@ package gkereleasenotes
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
-	"log"
+	"io/fs"
+	"log/slog"
 	"net/http"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/metrics"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/pagination"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/retry"
 	"github.com/PuerkitoBio/goquery"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -37,65 +44,263 @@ import (
 var (
 	gkeVersionRegexp         = regexp.MustCompile(`\d+\.\d+\.\d+-gke\.\d+`)
 	releaseDateHeadingRegexp = regexp.MustCompile(`(^|\n)\s*[A-Za-z]+\s+\d+,\s+\d+\s*(\n|$)`)
+	// httpClient retries the release notes requests failing with transient errors.
+	httpClient = retry.NewClient()
+	// releaseNotesURL is the URL of the HTML page of the GKE release notes.
+	releaseNotesURL = "https://cloud.google.com/kubernetes-engine/docs/release-notes"
+	// releaseNotesFeedURL is the URL of the Atom feed of the GKE release notes.
+	releaseNotesFeedURL = "https://cloud.google.com/feeds/kubernetes-engine-release-notes.xml"
+	// useFeed reads the views of the release notes from their feeds instead
+	// of their pages.
+	useFeed bool
+	// offlineDir is the directory the release notes are read from instead of
+	// the network, if set.
+	offlineDir string
 )
 
+// OfflineFileName is the name of the release notes page in an offline directory.
+const OfflineFileName = "release-notes.html"
+
 type getGkeReleaseNotesArgs struct {
-	SourceVersion string `json:"SourceVersion" jsonschema:"A source GKE version an upgrade happens from. For example, '1.33.5-gke.120000'."`
-	TargetVersion string `json:"TargetVersion" jsonschema:"A target GKE version an upgrade happens from. For example, '1.34.3-gke.240500'."`
+	SourceVersion          string `json:"SourceVersion" jsonschema:"A source GKE version an upgrade happens from. For example, '1.33.5-gke.120000'."`
+	TargetVersion          string `json:"TargetVersion" jsonschema:"A target GKE version an upgrade happens from. For example, '1.34.3-gke.240500'."`
+	View                   string `json:"view,omitempty" jsonschema:"The release notes to read: 'all' for the release notes of all channels (the default), 'rapid', 'regular', 'stable', 'extended' or 'no-channel' for those of a release channel, 'new-features' for the new features only, or 'security-bulletins' for the GKE security bulletins."`
+	IncludeVersionUpdates  bool   `json:"include_version_updates,omitempty" jsonschema:"Include the version updates of each release, i.e. the versions made available, default or auto-upgrade targets in each release channel. Defaults to false."`
+	IncludeSecurityUpdates bool   `json:"include_security_updates,omitempty" jsonschema:"Include the security updates of each release, i.e. the vulnerabilities patched by the new versions. Defaults to false."`
+	PageToken              string `json:"page_token,omitempty" jsonschema:"Token of the page of release notes to return, from the next_page_token of the previous call. Leave this empty for the first page."`
+	PageSize               int    `json:"page_size,omitempty" jsonschema:"Maximum number of release notes to return. Defaults to 50."`
+}
+
+type getGkeReleaseNotesOutput struct {
+	ReleaseNotes  []releaseNote `json:"release_notes" jsonschema:"The release notes of the upgrade, from newest to oldest."`
+	Total         int           `json:"total" jsonschema:"The number of release notes of the upgrade, across all pages."`
+	NextPageToken string        `json:"next_page_token,omitempty" jsonschema:"The token to pass as page_token for the next page, if any."`
 }
 
-// Install registers the GKE release notes tool with the MCP server.
-func Install(_ context.Context, s *mcp.Server, _ *config.Config) error {
+// defaultPageSize is the number of entries of a page of release notes.
+const defaultPageSize = 50
+
+// Install registers the GKE release notes tools with the MCP server. The
+// release notes and known issues of all tools are read from the URLs of the
+// config, if set.
+func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
+	releaseNotesURL = sourceReleaseNotesURL(c)
+	useFeed = c.Sources().ReleaseNotesFeed
+	if u := c.Sources().KnownIssuesURL; u != "" {
+		knownIssuesURL = u
+	}
+	offlineDir = c.Sources().OfflineDir
 	mcp.AddTool(s, &mcp.Tool{
 		Name:        "get_gke_release_notes",
-		Description: "Get GKE release notes. Prefer to use this tool if GKE release notes are needed.",
+		Description: "Get the GKE release notes of an upgrade, of all channels or of a view: a release channel, the new features or the security bulletins, as structured entries, each with its date, type (feature, change, fix, deprecation, breaking, issue, security or announcement), release channels, GKE versions and text. Prefer to use this tool if GKE release notes are needed.",
 		Annotations: &mcp.ToolAnnotations{
 			ReadOnlyHint:   true,
 			IdempotentHint: true,
 		},
 	}, getGkeReleaseNotes)
 
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "compare_gke_versions",
+		Description: "Compare two full GKE versions, e.g. 1.31.5-gke.1169000 and 1.32.2-gke.1297002: the Kubernetes minor and patch versions crossed, the GKE patch delta, the node image and container runtime changes, and the GKE release notes entries between them, in a single diff.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+	}, compareGkeVersions)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "get_gke_known_issues",
+		Description: "Get the issues acknowledged by Google on the GKE known issues page, with the versions they were identified and fixed in, optionally only those affecting the Kubernetes minor versions between a source and a target version, or mentioning a keyword. Use it to include known issues of the target version in upgrade risk reports.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+	}, getGkeKnownIssues)
+
 	return nil
 }
 
-func getGkeReleaseNotes(_ context.Context, _ *mcp.CallToolRequest, args *getGkeReleaseNotesArgs) (*mcp.CallToolResult, any, error) {
-	releaseNotesFilePath := fmt.Sprintf("release-notes-%s.html", time.Now().Format("2006-01-02"))
-	releaseNotesFilePath = filepath.Clean(releaseNotesFilePath)
+func getGkeReleaseNotes(ctx context.Context, _ *mcp.CallToolRequest, args *getGkeReleaseNotesArgs) (*mcp.CallToolResult, *getGkeReleaseNotesOutput, error) {
+	page, err := releaseNotesPage(ctx, args.View)
+	if err != nil {
+		return nil, nil, err
+	}
+	notes, err := parseReleaseNotes(page, includedUpdates{version: args.IncludeVersionUpdates, security: args.IncludeSecurityUpdates})
+	if err != nil {
+		return nil, nil, err
+	}
+	notes, err = upgradeReleaseNotes(notes, args.SourceVersion, args.TargetVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+	if args.PageSize <= 0 {
+		args.PageSize = defaultPageSize
+	}
+	paged, next, err := pagination.Page(notes, args.PageToken, args.PageSize)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	var out []byte
-	var err error
+	return nil, &getGkeReleaseNotesOutput{
+		ReleaseNotes:  paged,
+		Total:         len(notes),
+		NextPageToken: next,
+	}, nil
+}
 
-	if _, err = os.Stat(releaseNotesFilePath); err == nil {
-		log.Printf("Reading release notes from cached file: %s", releaseNotesFilePath)
-		out, err = os.ReadFile(releaseNotesFilePath)
-		if err != nil {
-			log.Printf("Failed to read cached release notes file: %v", err)
-			return nil, nil, err
+// Download returns the HTML page of the GKE release notes from the release
+// notes URL of the config, to store it in an offline directory.
+func Download(ctx context.Context, c *config.Config) ([]byte, error) {
+	return fetchReleaseNotes(ctx, sourceReleaseNotesURL(c))
+}
+
+// sourceReleaseNotesURL returns the URL the release notes are read from with
+// the sources of the config: the mirror if set, or else the Atom feed or the
+// HTML page.
+func sourceReleaseNotesURL(c *config.Config) string {
+	if u := c.Sources().ReleaseNotesURL; u != "" {
+		return u
+	}
+	if c.Sources().ReleaseNotesFeed {
+		return releaseNotesFeedURL
+	}
+	return releaseNotesURL
+}
+
+// MinorVersions returns the Kubernetes minor versions of the GKE versions
+// mentioned in the HTML page of the release notes, from newest to oldest.
+func MinorVersions(page []byte) []string {
+	seen := make(map[[2]int]bool)
+	var minors [][2]int
+	for _, version := range gkeVersionRegexp.FindAll(page, -1) {
+		parts := strings.SplitN(string(version), ".", 3)
+		major, err1 := strconv.Atoi(parts[0])
+		minor, err2 := strconv.Atoi(parts[1])
+		if err1 != nil || err2 != nil || seen[[2]int{major, minor}] {
+			continue
 		}
-	} else {
-		log.Printf("Fetching release notes from web")
-		const releaseNotesPageURL = "https://cloud.google.com/kubernetes-engine/docs/release-notes"
-		resp, err := http.Get(releaseNotesPageURL)
-		if err != nil {
-			log.Printf("Failed to get release notes: %v", err)
-			return nil, nil, err
+		seen[[2]int{major, minor}] = true
+		minors = append(minors, [2]int{major, minor})
+	}
+	sort.Slice(minors, func(i, j int) bool {
+		if minors[i][0] != minors[j][0] {
+			return minors[i][0] > minors[j][0]
+		}
+		return minors[i][1] > minors[j][1]
+	})
+	versions := make([]string, len(minors))
+	for i, m := range minors {
+		versions[i] = fmt.Sprintf("%d.%d", m[0], m[1])
+	}
+	return versions
+}
+
+// fetchReleaseNotes returns the HTML page or the Atom feed of the GKE release
+// notes at url.
+func fetchReleaseNotes(ctx context.Context, url string) ([]byte, error) {
+	return fetchPage(ctx, url, "release notes")
+}
+
+// fetchPage returns the HTML page at url, naming it with what in errors.
+func fetchPage(ctx context.Context, url, what string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s request: %w", what, err)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		slog.Error("Failed to get "+what, "error", err)
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		err := fmt.Errorf("failed to get %s with status code: %d", what, resp.StatusCode)
+		slog.Error("Failed to get "+what, "error", err)
+		return nil, err
+	}
+	out, err := io.ReadAll(resp.Body)
+	if err != nil {
+		slog.Error("Failed to read "+what+" response body", "error", err)
+		return nil, err
+	}
+	return out, nil
+}
+
+// releaseNotesPage returns the HTML page of the release notes of the view, or
+// their feed, from the offline directory if set, or else from the file cached
+// for the day in the working directory or the web. Only the aggregated release
+// notes of all channels, the empty view, are available offline.
+func releaseNotesPage(ctx context.Context, view string) ([]byte, error) {
+	u, err := viewURL(view)
+	if err != nil {
+		return nil, err
+	}
+	if offlineDir != "" {
+		if view != "" && view != allView {
+			return nil, fmt.Errorf("the GKE %s release notes are not available in the offline directory %s, only the release notes of all channels are", view, offlineDir)
+		}
+		out, err := os.ReadFile(filepath.Join(offlineDir, OfflineFileName))
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("the GKE release notes are not in the offline directory %s, download them with gke-mcp download-offline-sources", offlineDir)
 		}
-		defer func() { _ = resp.Body.Close() }()
-		out, err = io.ReadAll(resp.Body)
 		if err != nil {
-			log.Printf("Failed to read release notes response body: %v", err)
-			return nil, nil, err
+			return nil, fmt.Errorf("failed to read offline release notes: %w", err)
 		}
-		if err = os.WriteFile(releaseNotesFilePath, out, 0600); err != nil {
-			log.Printf("Failed to write release notes to file: %v", err)
+		return out, nil
+	}
+
+	releaseNotesFilePath := fmt.Sprintf("release-notes-%s.html", time.Now().Format("2006-01-02"))
+	if view != "" && view != allView {
+		releaseNotesFilePath = fmt.Sprintf("release-notes-%s-%s.html", view, time.Now().Format("2006-01-02"))
+	}
+	releaseNotesFilePath = filepath.Clean(releaseNotesFilePath)
+
+	var out []byte
+	_, err = os.Stat(releaseNotesFilePath)
+	metrics.CacheLookup("release_notes", err == nil)
+	if err == nil {
+		slog.Debug("Reading release notes from cached file", "path", releaseNotesFilePath)
+		out, err = os.ReadFile(releaseNotesFilePath)
+		if err != nil {
+			slog.Error("Failed to read cached release notes file", "error", err)
+			return nil, err
 		}
+		return out, nil
+	}
+	slog.Debug("Fetching release notes from web")
+	start := time.Now()
+	out, err = fetchReleaseNotes(ctx, u)
+	metrics.ObserveExternal("release_notes", start, err)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.WriteFile(releaseNotesFilePath, out, 0600); err != nil {
+		slog.Warn("Failed to write release notes to file", "error", err)
+	}
+	return out, nil
+}
+
+// ReleaseNotesForUpgrade returns the text of the GKE release notes relevant for
+// an upgrade from sourceVersion to targetVersion.
+func ReleaseNotesForUpgrade(ctx context.Context, sourceVersion, targetVersion string) (string, error) {
+	out, err := releaseNotesPage(ctx, "")
+	if err != nil {
+		return "", err
 	}
 
 	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(out))
 	if err != nil {
-		log.Printf("Failed to parse release notes html content: %v", err)
+		slog.Error("Failed to parse release notes html content", "error", err)
 
-		return nil, nil, err
+		return "", err
+	}
+
+	if isFeed(out) {
+		notes, err := parseReleaseNotesFeed(out, includedUpdates{})
+		if err != nil {
+			return "", err
+		}
+		return extractReleaseNotesRelevantForUpgrade(releaseNotesText(notes), sourceVersion, targetVersion)
 	}
 
 	var fullReleaseNotesContent strings.Builder
@@ -106,16 +311,92 @@ func getGkeReleaseNotes(_ context.Context, _ *mcp.CallToolRequest, args *getGkeR
 	})
 	fullReleaseNotesContentText := fullReleaseNotesContent.String()
 
-	reducedReleaseNotes, err := extractReleaseNotesRelevantForUpgrade(fullReleaseNotesContentText, args.SourceVersion, args.TargetVersion)
+	return extractReleaseNotesRelevantForUpgrade(fullReleaseNotesContentText, sourceVersion, targetVersion)
+}
+
+var releaseNotesEntrySeparatorRegexp = regexp.MustCompile(`\n\s*\n`)
+
+// FilterEntries splits release notes into entries separated by blank lines and
+// returns the entries mentioning at least one of the keywords (case-insensitive).
+// Each returned entry is prefixed with the date heading of its release.
+func FilterEntries(releaseNotes string, keywords []string) []string {
+	var entries []string
+	releaseDate := ""
+	for _, entry := range releaseNotesEntrySeparatorRegexp.Split(releaseNotes, -1) {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if releaseDateHeadingRegexp.MatchString(entry) && !strings.Contains(entry, "\n") {
+			releaseDate = entry
+			continue
+		}
+		lowerEntry := strings.ToLower(entry)
+		for _, keyword := range keywords {
+			if strings.Contains(lowerEntry, strings.ToLower(keyword)) {
+				if releaseDate != "" {
+					entry = releaseDate + "\n" + entry
+				}
+				entries = append(entries, entry)
+				break
+			}
+		}
+	}
+	return entries
+}
+
+// splitReleases splits release notes into releases, each starting with its
+// date heading and holding the entries of the release.
+func splitReleases(releaseNotes string) []string {
+	var releases []string
+	var release []string
+	for _, entry := range releaseNotesEntrySeparatorRegexp.Split(releaseNotes, -1) {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if releaseDateHeadingRegexp.MatchString(entry) && !strings.Contains(entry, "\n") && len(release) > 0 {
+			releases = append(releases, strings.Join(release, "\n\n"))
+			release = nil
+		}
+		release = append(release, entry)
+	}
+	if len(release) > 0 {
+		releases = append(releases, strings.Join(release, "\n\n"))
+	}
+	return releases
+}
+
+// UpgradeEntries returns the GKE release notes entries matching the keywords
+// for an upgrade from currentVersion to targetVersion. It returns nothing if
+// targetVersion is empty.
+func UpgradeEntries(ctx context.Context, currentVersion, targetVersion string, keywords []string) ([]string, error) {
+	if targetVersion == "" {
+		return nil, nil
+	}
+	releaseNotes, err := ReleaseNotesForUpgrade(ctx, currentVersion, targetVersion)
 	if err != nil {
-		return nil, nil, err
+		return nil, fmt.Errorf("failed to get GKE release notes: %w", err)
 	}
+	return FilterEntries(releaseNotes, keywords), nil
+}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{Text: reducedReleaseNotes},
-		},
-	}, nil, nil
+// WriteEntries writes the release notes entries about topic found for an
+// upgrade from currentVersion to targetVersion to a report.
+func WriteEntries(b *strings.Builder, topic, currentVersion, targetVersion string, entries []string) {
+	if targetVersion == "" {
+		fmt.Fprintf(b, "\nNo target version provided, %s release notes were not analyzed.\n", topic)
+		return
+	}
+
+	fmt.Fprintf(b, "\n%s GKE release notes between %s and %s:\n", topic, currentVersion, targetVersion)
+	if len(entries) == 0 {
+		fmt.Fprintf(b, "No %s release notes found.\n", topic)
+		return
+	}
+	for _, entry := range entries {
+		fmt.Fprintf(b, "\n%s\n", entry)
+	}
 }
 
 func extractReleaseNotesRelevantForUpgrade(fullReleaseNotes string, sourceVersion string, targetVersion string) (string, error) {
@@ -221,12 +502,12 @@ func extractReleaseNotesRelevantForUpgrade(fullReleaseNotes string, sourceVersio
 func compareVersions(a, b string) (int, error) {
 	aMajor, aMinor, aPatch, aGKE, err := parseGkeVersion(a)
 	if err != nil {
-		log.Printf("Failed to parse version A '%s': %v", a, err)
+		slog.Error("Failed to parse version A", "version", a, "error", err)
 		return 0, err
 	}
 	bMajor, bMinor, bPatch, bGKE, err := parseGkeVersion(b)
 	if err != nil {
-		log.Printf("Failed to parse version B '%s': %v", b, err)
+		slog.Error("Failed to parse version B", "version", b, "error", err)
 		return 0, err
 	}
 
@@ -259,34 +540,17 @@ func compareVersions(a, b string) (int, error) {
 
 // parseGkeVersion returns 4 ints: major, minor, patch and GKE patch versions
 func parseGkeVersion(version string) (int, int, int, int, error) {
-	parts := strings.Split(version, "-gke.")
-	if len(parts) != 2 {
+	k8sVersionPart, gkeVersionPart, ok := strings.Cut(version, "-gke.")
+	if !ok {
 		return 0, 0, 0, 0, fmt.Errorf("invalid GKE version format: %s", version)
 	}
-
-	k8sVersionPart := parts[0]
-	gkeVersionPart := parts[1]
-
-	k8sParts := strings.Split(k8sVersionPart, ".")
-	if len(k8sParts) != 3 {
-		return 0, 0, 0, 0, fmt.Errorf("invalid Kubernetes version part in GKE version: %s", k8sVersionPart)
-	}
-
-	major, err := strconv.Atoi(k8sParts[0])
-	if err != nil {
-		return 0, 0, 0, 0, fmt.Errorf("cannot parse major version: %w", err)
-	}
-	minor, err := strconv.Atoi(k8sParts[1])
-	if err != nil {
-		return 0, 0, 0, 0, fmt.Errorf("cannot parse minor version: %w", err)
-	}
-	patch, err := strconv.Atoi(k8sParts[2])
+	k8sVersion, err := gke.ParsePatchVersion(k8sVersionPart)
 	if err != nil {
-		return 0, 0, 0, 0, fmt.Errorf("cannot parse patch version: %w", err)
+		return 0, 0, 0, 0, fmt.Errorf("invalid Kubernetes version part in GKE version: %w", err)
 	}
 	gkePatch, err := strconv.Atoi(gkeVersionPart)
 	if err != nil {
 		return 0, 0, 0, 0, fmt.Errorf("cannot parse GKE patch version: %w", err)
 	}
-	return major, minor, patch, gkePatch, nil
+	return k8sVersion.Major, k8sVersion.Minor, k8sVersion.Patch, gkePatch, nil
 }
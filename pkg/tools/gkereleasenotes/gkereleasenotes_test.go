@@ -15,6 +15,10 @@
 package gkereleasenotes
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -205,3 +209,127 @@ August 28, 2025
 		})
 	}
 }
+
+func TestFilterEntries(t *testing.T) {
+	notes := `
+October 17, 2025
+
+      Issue
+      Don't use GKE version 1.34.1-gke.1431000 or later when creating
+or upgrading node pools with the a3-highgpu-8g machine type.
+
+October 14, 2025
+
+      Issue
+      In GKE versions 1.32.4-gke.1029000 and later, MountVolume calls
+for network file system (NFS) volumes might fail.
+
+October 09, 2025
+
+      Feature
+      In GKE version 1.30.3-gke.1211000 and later, you can assign
+additional subnets to a VPC-native cluster.
+`
+	tests := []struct {
+		name     string
+		keywords []string
+		want     []string
+	}{
+		{
+			name:     "single match keeps release date",
+			keywords: []string{"nfs"},
+			want: []string{"October 14, 2025\nIssue\n      In GKE versions 1.32.4-gke.1029000 and later, MountVolume calls\n" +
+				"for network file system (NFS) volumes might fail."},
+		},
+		{
+			name:     "multiple keywords",
+			keywords: []string{"a3-highgpu", "subnets"},
+			want: []string{
+				"October 17, 2025\nIssue\n      Don't use GKE version 1.34.1-gke.1431000 or later when creating\n" +
+					"or upgrading node pools with the a3-highgpu-8g machine type.",
+				"October 09, 2025\nFeature\n      In GKE version 1.30.3-gke.1211000 and later, you can assign\n" +
+					"additional subnets to a VPC-native cluster.",
+			},
+		},
+		{
+			name:     "no match",
+			keywords: []string{"dns"},
+			want:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FilterEntries(notes, tt.keywords)
+			if len(got) != len(tt.want) {
+				t.Fatalf("FilterEntries() returned %d entries, want %d: %q", len(got), len(tt.want), got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("FilterEntries()[%d] = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSplitReleases(t *testing.T) {
+	notes := `
+October 17, 2025
+
+      Issue
+      First issue.
+
+      Feature
+      First feature.
+
+October 14, 2025
+
+      Issue
+      Second issue.
+`
+	got := splitReleases(notes)
+	want := []string{
+		"October 17, 2025\n\nIssue\n      First issue.\n\nFeature\n      First feature.",
+		"October 14, 2025\n\nIssue\n      Second issue.",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("splitReleases() = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("splitReleases()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestMinorVersions(t *testing.T) {
+	page := []byte(`<p>1.33.5-gke.1200 and 1.9.2-gke.1</p><p>1.34.1-gke.100, 1.33.4-gke.900 and 1.10.1-gke.2</p>`)
+	got := MinorVersions(page)
+	want := []string{"1.34", "1.33", "1.10", "1.9"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MinorVersions() = %q, want %q", got, want)
+	}
+}
+
+func TestReleaseNotesPageOffline(t *testing.T) {
+	dir := t.TempDir()
+	offlineDir = dir
+	t.Cleanup(func() { offlineDir = "" })
+
+	if _, err := releaseNotesPage(context.Background(), ""); err == nil || !strings.Contains(err.Error(), "download-offline-sources") {
+		t.Errorf("releaseNotesPage() error = %v, want an error about download-offline-sources", err)
+	}
+
+	want := "<div class=\"releases\">October 17, 2025</div>"
+	if err := os.WriteFile(filepath.Join(dir, OfflineFileName), []byte(want), 0600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := releaseNotesPage(context.Background(), "")
+	if err != nil {
+		t.Fatalf("releaseNotesPage() error = %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("releaseNotesPage() = %q, want %q", got, want)
+	}
+}
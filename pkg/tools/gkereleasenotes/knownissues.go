@@ -0,0 +1,276 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gkereleasenotes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/metrics"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/pagination"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// KnownIssuesOfflineFileName is the name of the known issues page in an
+// offline directory.
+const KnownIssuesOfflineFileName = "known-issues.html"
+
+// defaultKnownIssuesPageSize is the number of known issues of a page.
+const defaultKnownIssuesPageSize = 10
+
+var (
+	// knownIssuesURL is the URL of the HTML page of the GKE known issues.
+	knownIssuesURL = "https://cloud.google.com/kubernetes-engine/docs/troubleshooting/known-issues"
+	// minorVersionRegexp matches the Kubernetes minor versions mentioned in
+	// the versions of a known issue, e.g. 1.30 in 1.30.5-gke.1014001.
+	minorVersionRegexp = regexp.MustCompile(`\b1\.([0-9]+)\b`)
+	// openRangeRegexp matches versions of a known issue including all later
+	// versions, e.g. 1.29 and later.
+	openRangeRegexp = regexp.MustCompile(`(?i)\b(later|newer|above)\b|\+`)
+)
+
+type getGkeKnownIssuesArgs struct {
+	SourceVersion string `json:"source_version,omitempty" jsonschema:"The current GKE or Kubernetes version of the cluster, e.g. 1.31.5-gke.1169000. Leave this empty to only check the target version."`
+	TargetVersion string `json:"target_version,omitempty" jsonschema:"The GKE or Kubernetes version to check the known issues of, e.g. 1.32.2-gke.1297002. Leave this empty for the known issues of all versions."`
+	Keyword       string `json:"keyword,omitempty" jsonschema:"Only return the known issues mentioning this keyword, e.g. containerd or Workload Identity."`
+	PageToken     string `json:"page_token,omitempty" jsonschema:"Token of the page of known issues to return, from the previous call with the same arguments. Leave this empty for the first page."`
+	PageSize      int    `json:"page_size,omitempty" jsonschema:"Maximum number of known issues to return. Defaults to 10."`
+}
+
+// knownIssue is an issue of the GKE known issues page.
+type knownIssue struct {
+	category   string
+	identified string
+	fixed      string
+	text       string
+}
+
+func getGkeKnownIssues(ctx context.Context, _ *mcp.CallToolRequest, args *getGkeKnownIssuesArgs) (*mcp.CallToolResult, any, error) {
+	fromMinor, toMinor := 0, 0
+	if args.TargetVersion != "" {
+		var err error
+		if _, toMinor, err = gke.MinorVersion(args.TargetVersion); err != nil {
+			return nil, nil, err
+		}
+		fromMinor = toMinor
+	}
+	if args.SourceVersion != "" {
+		if args.TargetVersion == "" {
+			return nil, nil, fmt.Errorf("target_version argument cannot be empty when source_version is set")
+		}
+		var err error
+		if _, fromMinor, err = gke.MinorVersion(args.SourceVersion); err != nil {
+			return nil, nil, err
+		}
+		if fromMinor > toMinor {
+			return nil, nil, fmt.Errorf("target_version %s must not be older than source_version %s", args.TargetVersion, args.SourceVersion)
+		}
+	}
+
+	page, err := knownIssuesPage(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	issues, err := parseKnownIssues(page)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var matching []string
+	for _, issue := range issues {
+		if toMinor > 0 && !issue.affects(fromMinor, toMinor) {
+			continue
+		}
+		if args.Keyword != "" && !strings.Contains(strings.ToLower(issue.text), strings.ToLower(args.Keyword)) {
+			continue
+		}
+		matching = append(matching, issue.String())
+	}
+	if len(matching) == 0 {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: "No GKE known issues found."},
+			},
+		}, nil, nil
+	}
+	if args.PageSize <= 0 {
+		args.PageSize = defaultKnownIssuesPageSize
+	}
+	paged, next, err := pagination.Page(matching, args.PageToken, args.PageSize)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: strings.Join(paged, "\n\n") + pagination.Footer(len(paged), len(matching), next)},
+		},
+	}, nil, nil
+}
+
+// DownloadKnownIssues returns the HTML page of the GKE known issues from the
+// known issues URL of the config, to store it in an offline directory.
+func DownloadKnownIssues(ctx context.Context, c *config.Config) ([]byte, error) {
+	u := knownIssuesURL
+	if c.Sources().KnownIssuesURL != "" {
+		u = c.Sources().KnownIssuesURL
+	}
+	return fetchPage(ctx, u, "known issues")
+}
+
+// knownIssuesPage returns the HTML page of the known issues, from the
+// offline directory if set, or else from the web.
+func knownIssuesPage(ctx context.Context) ([]byte, error) {
+	if offlineDir != "" {
+		out, err := os.ReadFile(filepath.Join(offlineDir, KnownIssuesOfflineFileName))
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("the GKE known issues are not in the offline directory %s, download them with gke-mcp download-offline-sources", offlineDir)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read offline known issues: %w", err)
+		}
+		return out, nil
+	}
+
+	start := time.Now()
+	out, err := fetchPage(ctx, knownIssuesURL, "known issues")
+	metrics.ObserveExternal("known_issues", start, err)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// parseKnownIssues returns the known issues of the rows of the tables of the
+// page, whose header names the category, identified versions, fixed versions
+// and issue columns.
+func parseKnownIssues(page []byte) ([]knownIssue, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(page))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse known issues: %w", err)
+	}
+
+	var issues []knownIssue
+	doc.Find("table").Each(func(_ int, table *goquery.Selection) {
+		columns := map[string]int{}
+		table.Find("tr").First().Find("th, td").Each(func(i int, cell *goquery.Selection) {
+			header := strings.ToLower(cell.Text())
+			switch {
+			case strings.Contains(header, "categor"):
+				columns["category"] = i
+			case strings.Contains(header, "identified") || strings.Contains(header, "affected"):
+				columns["identified"] = i
+			case strings.Contains(header, "fixed"):
+				columns["fixed"] = i
+			case strings.Contains(header, "issue"):
+				columns["issue"] = i
+			}
+		})
+		issueColumn, ok := columns["issue"]
+		if !ok {
+			return
+		}
+		category := strings.TrimSpace(table.PrevAllFiltered("h2").First().Text())
+		table.Find("tr").Each(func(row int, tr *goquery.Selection) {
+			if row == 0 {
+				return
+			}
+			cells := tr.Find("td")
+			cell := func(column string) string {
+				i, ok := columns[column]
+				if !ok || i >= cells.Length() {
+					return ""
+				}
+				return collapseSpaces(cells.Eq(i).Text())
+			}
+			if issueColumn >= cells.Length() {
+				return
+			}
+			issue := knownIssue{
+				category:   category,
+				identified: cell("identified"),
+				fixed:      cell("fixed"),
+				text:       strings.TrimSpace(cells.Eq(issueColumn).Text()),
+			}
+			if c := cell("category"); c != "" {
+				issue.category = c
+			}
+			issues = append(issues, issue)
+		})
+	})
+	if len(issues) == 0 {
+		return nil, fmt.Errorf("no known issues found in the GKE known issues page, its format may have changed")
+	}
+	return issues, nil
+}
+
+// collapseSpaces replaces the runs of whitespace of s with single spaces.
+func collapseSpaces(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// affects reports whether the issue was identified in a Kubernetes minor
+// version between fromMinor and toMinor, both included. Issues whose
+// identified versions are unknown are reported as affecting all versions.
+func (i knownIssue) affects(fromMinor, toMinor int) bool {
+	var minors []int
+	for _, m := range minorVersionRegexp.FindAllStringSubmatch(i.identified, -1) {
+		minor, err := strconv.Atoi(m[1])
+		if err == nil {
+			minors = append(minors, minor)
+		}
+	}
+	if len(minors) == 0 {
+		return true
+	}
+	openRange := openRangeRegexp.MatchString(i.identified)
+	for _, minor := range minors {
+		if minor >= fromMinor && minor <= toMinor {
+			return true
+		}
+		if openRange && minor <= toMinor {
+			return true
+		}
+	}
+	return false
+}
+
+// String formats the issue for the tool result.
+func (i knownIssue) String() string {
+	var b strings.Builder
+	if i.category != "" {
+		fmt.Fprintf(&b, "Category: %s\n", i.category)
+	}
+	if i.identified != "" {
+		fmt.Fprintf(&b, "Identified versions: %s\n", i.identified)
+	}
+	if i.fixed != "" {
+		fmt.Fprintf(&b, "Fixed versions: %s\n", i.fixed)
+	}
+	b.WriteString(i.text)
+	return b.String()
+}
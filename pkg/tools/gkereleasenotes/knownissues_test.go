@@ -0,0 +1,94 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gkereleasenotes
+
+import (
+	"strings"
+	"testing"
+)
+
+const knownIssuesHTML = `<html><body>
+<h2>Networking</h2>
+<table>
+  <tr><th>Identified versions</th><th>Fixed versions</th><th>Issue and workaround</th></tr>
+  <tr><td>1.30, 1.31</td><td>1.31.6-gke.1020000</td><td>Pods lose   connectivity
+    after a containerd restart.</td></tr>
+  <tr><td>1.33 and later</td><td></td><td>Workload Identity tokens expire early.</td></tr>
+</table>
+<h2>Storage</h2>
+<table>
+  <tr><th>Category</th><th>Affected versions</th><th>Issue</th></tr>
+  <tr><td>Persistent Disk</td><td>1.28</td><td>Volumes fail to attach.</td></tr>
+  <tr><td></td><td>All</td><td>Snapshots are slow.</td></tr>
+</table>
+<table><tr><th>Flag</th><th>Description</th></tr><tr><td>a</td><td>b</td></tr></table>
+</body></html>`
+
+func TestParseKnownIssues(t *testing.T) {
+	issues, err := parseKnownIssues([]byte(knownIssuesHTML))
+	if err != nil {
+		t.Fatalf("parseKnownIssues() error = %v", err)
+	}
+	want := []knownIssue{
+		{category: "Networking", identified: "1.30, 1.31", fixed: "1.31.6-gke.1020000", text: "Pods lose   connectivity\n    after a containerd restart."},
+		{category: "Networking", identified: "1.33 and later", text: "Workload Identity tokens expire early."},
+		{category: "Persistent Disk", identified: "1.28", text: "Volumes fail to attach."},
+		{category: "Storage", identified: "All", text: "Snapshots are slow."},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("parseKnownIssues() returned %d issues, want %d: %+v", len(issues), len(want), issues)
+	}
+	for i := range want {
+		if issues[i] != want[i] {
+			t.Errorf("parseKnownIssues()[%d] = %+v, want %+v", i, issues[i], want[i])
+		}
+	}
+
+	if _, err := parseKnownIssues([]byte("<html><body><p>Moved</p></body></html>")); err == nil {
+		t.Error("parseKnownIssues() of a page without issues error = nil, want an error")
+	}
+}
+
+func TestKnownIssueAffects(t *testing.T) {
+	tests := []struct {
+		identified string
+		from, to   int
+		want       bool
+	}{
+		{"1.30, 1.31", 31, 32, true},
+		{"1.30, 1.31", 32, 33, false},
+		{"1.33 and later", 31, 34, true},
+		{"1.29+", 32, 32, true},
+		{"1.33 and later", 31, 32, false},
+		{"All", 30, 30, true},
+		{"", 30, 30, true},
+	}
+	for _, tt := range tests {
+		if got := (knownIssue{identified: tt.identified}).affects(tt.from, tt.to); got != tt.want {
+			t.Errorf("knownIssue{identified: %q}.affects(%d, %d) = %v, want %v", tt.identified, tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestKnownIssueString(t *testing.T) {
+	got := knownIssue{category: "Networking", identified: "1.30", fixed: "1.30.9-gke.100", text: "Pods lose connectivity."}.String()
+	want := "Category: Networking\nIdentified versions: 1.30\nFixed versions: 1.30.9-gke.100\nPods lose connectivity."
+	if got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got := (knownIssue{text: "Snapshots are slow."}).String(); !strings.HasPrefix(got, "Snapshots") {
+		t.Errorf("String() = %q, want only the text", got)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gkereleasenotes
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// releaseNotesView is a view of the GKE release notes published on its own
+// page and feed, e.g. the release notes of a release channel.
+type releaseNotesView struct {
+	page string
+	feed string
+}
+
+// allView is the view of the aggregated release notes of all channels.
+const allView = "all"
+
+// releaseNotesViews are the views of the GKE release notes besides the
+// aggregated release notes, by name.
+var releaseNotesViews = map[string]releaseNotesView{
+	"rapid": {
+		page: "https://cloud.google.com/kubernetes-engine/docs/release-notes-rapid",
+		feed: "https://cloud.google.com/feeds/kubernetes-engine-rapid-channel-release-notes.xml",
+	},
+	"regular": {
+		page: "https://cloud.google.com/kubernetes-engine/docs/release-notes-regular",
+		feed: "https://cloud.google.com/feeds/kubernetes-engine-regular-channel-release-notes.xml",
+	},
+	"stable": {
+		page: "https://cloud.google.com/kubernetes-engine/docs/release-notes-stable",
+		feed: "https://cloud.google.com/feeds/kubernetes-engine-stable-channel-release-notes.xml",
+	},
+	"extended": {
+		page: "https://cloud.google.com/kubernetes-engine/docs/release-notes-extended",
+		feed: "https://cloud.google.com/feeds/kubernetes-engine-extended-channel-release-notes.xml",
+	},
+	"no-channel": {
+		page: "https://cloud.google.com/kubernetes-engine/docs/release-notes-nochannel",
+		feed: "https://cloud.google.com/feeds/kubernetes-engine-no-channel-release-notes.xml",
+	},
+	"new-features": {
+		page: "https://cloud.google.com/kubernetes-engine/docs/release-notes-new-features",
+		feed: "https://cloud.google.com/feeds/kubernetes-engine-new-features-release-notes.xml",
+	},
+	"security-bulletins": {
+		page: "https://cloud.google.com/kubernetes-engine/security-bulletins",
+		feed: "https://cloud.google.com/feeds/kubernetes-engine-security-bulletins.xml",
+	},
+}
+
+// viewURL returns the URL of the release notes of the view, the feed if
+// useFeed is set. The aggregated release notes are read from releaseNotesURL.
+func viewURL(view string) (string, error) {
+	if view == "" || view == allView {
+		return releaseNotesURL, nil
+	}
+	v, ok := releaseNotesViews[view]
+	if !ok {
+		return "", fmt.Errorf("unknown release notes view %q, the views are: %s", view, strings.Join(viewNames(), ", "))
+	}
+	if useFeed {
+		return v.feed, nil
+	}
+	return v.page, nil
+}
+
+// viewNames returns the names of the views, starting with the aggregated
+// release notes.
+func viewNames() []string {
+	return append([]string{allView}, slices.Sorted(maps.Keys(releaseNotesViews))...)
+}
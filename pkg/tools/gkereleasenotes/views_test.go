@@ -0,0 +1,68 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gkereleasenotes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestViewURL(t *testing.T) {
+	for _, view := range []string{"", "all"} {
+		if got, err := viewURL(view); err != nil || got != releaseNotesURL {
+			t.Errorf("viewURL(%q) = %q, %v, want %q", view, got, err, releaseNotesURL)
+		}
+	}
+	if got, err := viewURL("rapid"); err != nil || got != "https://cloud.google.com/kubernetes-engine/docs/release-notes-rapid" {
+		t.Errorf("viewURL(rapid) = %q, %v", got, err)
+	}
+	useFeed = true
+	t.Cleanup(func() { useFeed = false })
+	if got, err := viewURL("security-bulletins"); err != nil || got != "https://cloud.google.com/feeds/kubernetes-engine-security-bulletins.xml" {
+		t.Errorf("viewURL(security-bulletins) = %q, %v", got, err)
+	}
+	if _, err := viewURL("beta"); err == nil || !strings.Contains(err.Error(), "all, extended, new-features, no-channel, rapid, regular, security-bulletins, stable") {
+		t.Errorf("viewURL(beta) error = %v, want an error listing the views", err)
+	}
+}
+
+func TestReleaseNotesPageView(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, "<section class=\"releases\">%s</section>", r.URL.Path)
+	}))
+	defer server.Close()
+	original := releaseNotesViews["stable"]
+	releaseNotesViews["stable"] = releaseNotesView{page: server.URL + "/stable"}
+	t.Cleanup(func() { releaseNotesViews["stable"] = original })
+	t.Chdir(t.TempDir())
+
+	got, err := releaseNotesPage(context.Background(), "stable")
+	if err != nil {
+		t.Fatalf("releaseNotesPage() error = %v", err)
+	}
+	if want := "<section class=\"releases\">/stable</section>"; string(got) != want {
+		t.Errorf("releaseNotesPage() = %q, want %q", got, want)
+	}
+
+	offlineDir = t.TempDir()
+	t.Cleanup(func() { offlineDir = "" })
+	if _, err := releaseNotesPage(context.Background(), "stable"); err == nil || !strings.Contains(err.Error(), "only the release notes of all channels") {
+		t.Errorf("releaseNotesPage() offline error = %v, want an error about the views", err)
+	}
+}
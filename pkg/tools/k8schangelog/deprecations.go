@@ -0,0 +1,94 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8schangelog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type getK8sDeprecationGuideArgs struct {
+	SourceVersion string `json:"source_version,omitempty" jsonschema:"The current Kubernetes or GKE version of the cluster, e.g. 1.29 or 1.29.5-gke.1091002. Only the APIs removed after this version are returned. Leave this empty for all the removed APIs up to the target version."`
+	TargetVersion string `json:"target_version,omitempty" jsonschema:"The Kubernetes or GKE version to upgrade to, e.g. 1.32. Only the APIs removed up to and including this version are returned. Leave this empty for all the removed APIs."`
+	Kind          string `json:"kind,omitempty" jsonschema:"Only return the APIs of this kind, e.g. Ingress or CronJob."`
+}
+
+func getK8sDeprecationGuide(_ context.Context, _ *mcp.CallToolRequest, args *getK8sDeprecationGuideArgs) (*mcp.CallToolResult, any, error) {
+	fromMinor, toMinor := 0, 0
+	if args.SourceVersion != "" {
+		var err error
+		if _, fromMinor, err = gke.MinorVersion(args.SourceVersion); err != nil {
+			return nil, nil, err
+		}
+	}
+	if args.TargetVersion != "" {
+		var err error
+		if _, toMinor, err = gke.MinorVersion(args.TargetVersion); err != nil {
+			return nil, nil, err
+		}
+		if toMinor < fromMinor {
+			return nil, nil, fmt.Errorf("target_version %s must not be older than source_version %s", args.TargetVersion, args.SourceVersion)
+		}
+	}
+
+	var apis []kube.DeprecatedAPI
+	for _, api := range kube.DeprecatedAPIs {
+		if api.RemovedIn <= fromMinor || (toMinor > 0 && api.RemovedIn > toMinor) {
+			continue
+		}
+		if args.Kind != "" && !strings.EqualFold(api.Kind, args.Kind) {
+			continue
+		}
+		apis = append(apis, api)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: deprecationGuide(apis)},
+		},
+	}, nil, nil
+}
+
+// deprecationGuide formats the removed APIs grouped by the Kubernetes minor
+// version removing them, in the order of the deprecated API migration guide.
+func deprecationGuide(apis []kube.DeprecatedAPI) string {
+	if len(apis) == 0 {
+		return "No Kubernetes APIs are removed in these versions."
+	}
+	var b strings.Builder
+	b.WriteString("Removed Kubernetes APIs, from the Kubernetes deprecated API migration guide (https://kubernetes.io/docs/reference/using-api/deprecation-guide/):\n")
+	removedIn := 0
+	for _, api := range apis {
+		if api.RemovedIn != removedIn {
+			removedIn = api.RemovedIn
+			fmt.Fprintf(&b, "\nRemoved in 1.%d:\n", removedIn)
+		}
+		replacement := api.Replacement
+		if replacement == "" {
+			replacement = "none"
+		}
+		fmt.Fprintf(&b, "- %s %s -> %s", api.APIVersion, api.Kind, replacement)
+		if api.Notes != "" {
+			fmt.Fprintf(&b, "\n  Notable changes: %s", api.Notes)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8schangelog
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestGetK8sDeprecationGuide(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    getK8sDeprecationGuideArgs
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "upgrade range",
+			args: getK8sDeprecationGuideArgs{SourceVersion: "1.24.17-gke.2000", TargetVersion: "1.26"},
+			want: []string{
+				"Removed in 1.25:\n- batch/v1beta1 CronJob -> batch/v1\n",
+				"- policy/v1beta1 PodSecurityPolicy -> none\n  Notable changes: Removed without a replacement API",
+				"Removed in 1.26:",
+				"- autoscaling/v2beta2 HorizontalPodAutoscaler -> autoscaling/v2",
+			},
+			notWant: []string{"Removed in 1.22", "Removed in 1.27"},
+		},
+		{
+			name:    "kind",
+			args:    getK8sDeprecationGuideArgs{TargetVersion: "1.30", Kind: "ingress"},
+			want:    []string{"- networking.k8s.io/v1beta1 Ingress -> networking.k8s.io/v1\n  Notable changes: spec.backend is renamed to spec.defaultBackend"},
+			notWant: []string{"IngressClass", "CronJob"},
+		},
+		{
+			name: "nothing removed",
+			args: getK8sDeprecationGuideArgs{SourceVersion: "1.32", TargetVersion: "1.33"},
+			want: []string{"No Kubernetes APIs are removed in these versions."},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _, err := getK8sDeprecationGuide(context.Background(), nil, &tt.args)
+			if err != nil {
+				t.Fatalf("getK8sDeprecationGuide() error = %v", err)
+			}
+			got := result.Content[0].(*mcp.TextContent).Text
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("getK8sDeprecationGuide() missing %q, got:\n%s", want, got)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(got, notWant) {
+					t.Errorf("getK8sDeprecationGuide() contains %q, got:\n%s", notWant, got)
+				}
+			}
+		})
+	}
+
+	if _, _, err := getK8sDeprecationGuide(context.Background(), nil, &getK8sDeprecationGuideArgs{SourceVersion: "1.30", TargetVersion: "1.29"}); err == nil {
+		t.Error("getK8sDeprecationGuide() with a target older than the source error = nil, want an error")
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8schangelog
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	// sigLabelRegexp matches the label naming the SIGs of a changelog entry,
+	// e.g. [SIG Network and Windows].
+	sigLabelRegexp = regexp.MustCompile(`\[SIG ([^\]]+)\]\s*$`)
+	// sigSeparatorRegexp matches the separators of the SIGs of a label.
+	sigSeparatorRegexp = regexp.MustCompile(`,\s*|\s+and\s+`)
+	// pullRequestRegexp matches the links to the Kubernetes pull requests of
+	// a changelog entry, e.g. [#134613](https://github.com/kubernetes/kubernetes/pull/134613).
+	pullRequestRegexp = regexp.MustCompile(`\[#(\d+)\]\(https://github\.com/kubernetes/kubernetes/pull/`)
+)
+
+// changelogEntry is a change of a changelog, under the headings of its version
+// and section.
+type changelogEntry struct {
+	// version is the heading of the version, e.g. # v1.33.6.
+	version string
+	// section is the heading of the section, e.g. ### Bug or Regression.
+	section string
+	// text is the markdown list item of the change, with its continuation lines.
+	text string
+	// start and end are the indexes of the first line of the entry in the
+	// changes and of the line following it.
+	start, end int
+}
+
+// parseEntries returns the entries of the changes, in the order of the
+// changelog.
+func parseEntries(changes string) []changelogEntry {
+	var entries []changelogEntry
+	version, section := "", ""
+	var current *changelogEntry
+	flush := func() {
+		if current != nil {
+			current.text = strings.TrimRight(current.text, "\n")
+			current.end = current.start + strings.Count(current.text, "\n") + 1
+			entries = append(entries, *current)
+			current = nil
+		}
+	}
+	for i, line := range strings.Split(changes, "\n") {
+		switch {
+		case current != nil && (line == "" || strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")):
+			current.text += "\n" + line
+		case strings.HasPrefix(line, "- "):
+			flush()
+			current = &changelogEntry{version: version, section: section, text: line, start: i}
+		default:
+			flush()
+			if m := headingRegexp.FindStringSubmatch(line); m != nil {
+				if len(m[1]) == 1 {
+					version, section = line, ""
+				} else {
+					section = line
+				}
+			}
+		}
+	}
+	flush()
+	return entries
+}
+
+// renderEntries formats the entries as a changelog, under the headings of
+// their versions and sections.
+func renderEntries(entries []changelogEntry) string {
+	var b strings.Builder
+	version, section := "", ""
+	for i, e := range entries {
+		if i == 0 || e.version != version {
+			version, section = e.version, ""
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			fmt.Fprintf(&b, "%s\n\n", e.version)
+		}
+		if e.section != section {
+			section = e.section
+			fmt.Fprintf(&b, "%s\n\n", e.section)
+		}
+		b.WriteString(e.text)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// sigs returns the lowercase names of the SIGs of the entry's label, e.g.
+// network and windows for [SIG Network and Windows].
+func (e changelogEntry) sigs() []string {
+	m := sigLabelRegexp.FindStringSubmatch(e.text)
+	if m == nil {
+		return nil
+	}
+	var sigs []string
+	for _, sig := range sigSeparatorRegexp.Split(m[1], -1) {
+		if sig = strings.ToLower(strings.TrimSpace(sig)); sig != "" {
+			sigs = append(sigs, sig)
+		}
+	}
+	return sigs
+}
+
+// normalizeSIG returns the name of a SIG or area as used by sigs, e.g.
+// api machinery for sig-api-machinery.
+func normalizeSIG(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.TrimPrefix(strings.TrimPrefix(name, "sig-"), "sig ")
+	return strings.ReplaceAll(name, "-", " ")
+}
+
+// filterBySIG returns the entries of the SIGs or areas. An entry matches a
+// name if its label has the SIG, or its text mentions the area, e.g. kubelet.
+func filterBySIG(entries []changelogEntry, names []string) []changelogEntry {
+	var areas []*regexp.Regexp
+	var sigs []string
+	for _, name := range names {
+		if name = normalizeSIG(name); name != "" {
+			sigs = append(sigs, name)
+			areas = append(areas, regexp.MustCompile(`(?i)\b`+regexp.QuoteMeta(name)+`\b`))
+		}
+	}
+	var filtered []changelogEntry
+	for _, e := range entries {
+		entrySIGs := e.sigs()
+		matches := false
+		for i, sig := range sigs {
+			if slices.Contains(entrySIGs, sig) || areas[i].MatchString(sigLabelRegexp.ReplaceAllString(e.text, "")) {
+				matches = true
+				break
+			}
+		}
+		if matches {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+// pullRequests returns the numbers of the pull requests of the entry, joined
+// with commas, or an empty string if it links none.
+func (e changelogEntry) pullRequests() string {
+	var numbers []string
+	for _, m := range pullRequestRegexp.FindAllStringSubmatch(e.text, -1) {
+		numbers = append(numbers, m[1])
+	}
+	return strings.Join(numbers, ",")
+}
+
+// dedupeChanges returns the changes of each version without the entries of the
+// pull requests already listed by a previous entry, e.g. backported to several
+// patch versions or repeated by the release candidates and the .0 release.
+// The first entry of a pull request lists the other versions it appears in.
+func dedupeChanges(changes []string) []string {
+	type position struct{ changes, entry int }
+	parsed := make([][]changelogEntry, len(changes))
+	first := make(map[string]position)
+	also := make(map[position][]string)
+	duplicate := make(map[position]bool)
+	for i, c := range changes {
+		parsed[i] = parseEntries(c)
+		for j, e := range parsed[i] {
+			key := e.pullRequests()
+			if key == "" {
+				continue
+			}
+			p, ok := first[key]
+			if !ok {
+				first[key] = position{i, j}
+				continue
+			}
+			duplicate[position{i, j}] = true
+			version := strings.TrimPrefix(e.version, "# ")
+			if parsed[p.changes][p.entry].version != e.version && !slices.Contains(also[p], version) {
+				also[p] = append(also[p], version)
+			}
+		}
+	}
+
+	deduped := make([]string, len(changes))
+	for i, c := range changes {
+		lines := strings.Split(c, "\n")
+		drop := make([]bool, len(lines))
+		entryAt := make(map[int]int)
+		for j, e := range parsed[i] {
+			entryAt[e.start] = j
+			if duplicate[position{i, j}] {
+				for k := e.start; k < e.end; k++ {
+					drop[k] = true
+				}
+			} else if versions := also[position{i, j}]; len(versions) > 0 {
+				lines[e.end-1] += fmt.Sprintf(" (also in %s)", strings.Join(versions, ", "))
+			}
+		}
+		dropEmptied(lines, drop, entryAt)
+		var kept []string
+		for k, line := range lines {
+			if !drop[k] {
+				kept = append(kept, line)
+			}
+		}
+		deduped[i] = strings.Join(kept, "\n")
+	}
+	return deduped
+}
+
+// dropEmptied marks as dropped the headings of the sections, and the whole
+// blocks of the versions, whose entries are all dropped. entryAt maps the
+// first lines of the entries to their indexes.
+func dropEmptied(lines []string, drop []bool, entryAt map[int]int) {
+	type block struct{ start, entries, kept int }
+	version, section := block{start: -1}, block{start: -1}
+	end := func(b block, until int) {
+		if b.start >= 0 && b.entries > 0 && b.kept == 0 {
+			for k := b.start; k < until; k++ {
+				drop[k] = true
+			}
+		}
+	}
+	for k, line := range lines {
+		if m := headingRegexp.FindStringSubmatch(line); m != nil {
+			end(section, k)
+			section = block{start: k}
+			if len(m[1]) == 1 {
+				end(version, k)
+				version = block{start: k}
+			}
+			continue
+		}
+		if _, ok := entryAt[k]; ok {
+			section.entries++
+			version.entries++
+			if !drop[k] {
+				section.kept++
+				version.kept++
+			}
+		}
+	}
+	end(section, len(lines))
+	end(version, len(lines))
+}
@@ -0,0 +1,207 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8schangelog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const entriesChanges = `# v1.33.6
+
+## Changelog since v1.33.5
+
+## Changes by Kind
+
+### Feature
+
+- Kubernetes is now built using Go 1.24.9
+  - update setcap and debian-base to bookworm-v1.0.6 ([#134613](https://github.com/kubernetes/kubernetes/pull/134613), [@cpanato](https://github.com/cpanato)) [SIG Architecture, Cloud Provider, Etcd, Release, Storage and Testing]
+
+### Bug or Regression
+
+- Fix Windows kube-proxy (winkernel) issue where stale RemoteEndpoints remained
+  when a Deployment was referenced by multiple Services. ([#135171](https://github.com/kubernetes/kubernetes/pull/135171), [@princepereira](https://github.com/princepereira)) [SIG Network and Windows]
+- Fixed a kubelet crash on startup. ([#135105](https://github.com/kubernetes/kubernetes/pull/135105), [@LionelJouin](https://github.com/LionelJouin)) [SIG Node]
+
+# v1.33.5
+
+## Changes by Kind
+
+### Bug or Regression
+
+- Kube-controller-manager: Fixes a regression in daemonset handling of orphaned pods ([#134652](https://github.com/kubernetes/kubernetes/pull/134652), [@liggitt](https://github.com/liggitt)) [SIG Apps]
+`
+
+func TestParseEntries(t *testing.T) {
+	entries := parseEntries(entriesChanges)
+	if len(entries) != 4 {
+		t.Fatalf("parseEntries() returned %d entries, want 4: %+v", len(entries), entries)
+	}
+	first := entries[0]
+	if first.version != "# v1.33.6" || first.section != "### Feature" || !strings.HasSuffix(first.text, "Storage and Testing]") {
+		t.Errorf("parseEntries()[0] = %+v", first)
+	}
+	if got, want := strings.Join(first.sigs(), ","), "architecture,cloud provider,etcd,release,storage,testing"; got != want {
+		t.Errorf("sigs() = %q, want %q", got, want)
+	}
+	if last := entries[3]; last.version != "# v1.33.5" || last.section != "### Bug or Regression" {
+		t.Errorf("parseEntries()[3] = %+v", last)
+	}
+}
+
+func TestFilterBySIG(t *testing.T) {
+	entries := parseEntries(entriesChanges)
+	tests := []struct {
+		names []string
+		want  []string
+	}{
+		{[]string{"sig-network"}, []string{"#135171"}},
+		{[]string{"Cloud-Provider"}, []string{"#134613"}},
+		{[]string{"kubelet", "apps"}, []string{"#135105", "#134652"}},
+		{[]string{"scheduling"}, nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, e := range filterBySIG(entries, tt.names) {
+			got = append(got, e.text[strings.Index(e.text, "[#")+1:strings.Index(e.text, "](")])
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("filterBySIG(%q) = %q, want %q", tt.names, got, tt.want)
+		}
+	}
+}
+
+func TestRenderEntries(t *testing.T) {
+	got := renderEntries(filterBySIG(parseEntries(entriesChanges), []string{"node", "apps"}))
+	want := `# v1.33.6
+
+### Bug or Regression
+
+- Fixed a kubelet crash on startup. ([#135105](https://github.com/kubernetes/kubernetes/pull/135105), [@LionelJouin](https://github.com/LionelJouin)) [SIG Node]
+
+# v1.33.5
+
+### Bug or Regression
+
+- Kube-controller-manager: Fixes a regression in daemonset handling of orphaned pods ([#134652](https://github.com/kubernetes/kubernetes/pull/134652), [@liggitt](https://github.com/liggitt)) [SIG Apps]
+`
+	if got != want {
+		t.Errorf("renderEntries() = %q, want %q", got, want)
+	}
+}
+
+func TestGetK8sChangelogSIGs(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, OfflineFileName("1.33")), []byte(fakeChangelogContent), 0600); err != nil {
+		t.Fatal(err)
+	}
+	h := &handlers{offlineDir: dir}
+
+	result, _, err := h.getK8sChangelog(context.Background(), nil, &getK8sChangelogArgs{KubernetesMinorVersion: "1.33", SIGs: []string{"sig-storage"}})
+	if err != nil {
+		t.Fatalf("getK8sChangelog() error = %v", err)
+	}
+	got := result.Content[0].(*mcp.TextContent).Text
+	if !strings.Contains(got, "Portworx in-tree driver") || strings.Contains(got, "kube-proxy") || strings.Contains(got, "## Changes by Kind") {
+		t.Errorf("getK8sChangelog() = %q, want only the changes of SIG Storage", got)
+	}
+
+	result, _, err = h.getK8sChangelog(context.Background(), nil, &getK8sChangelogArgs{KubernetesMinorVersion: "1.33", SIGs: []string{"multicluster"}})
+	if err != nil {
+		t.Fatalf("getK8sChangelog() error = %v", err)
+	}
+	if got := result.Content[0].(*mcp.TextContent).Text; got != "The changelog of Kubernetes 1.33 has no changes of multicluster." {
+		t.Errorf("getK8sChangelog() = %q", got)
+	}
+}
+
+func TestDedupeChanges(t *testing.T) {
+	changes := []string{`# v1.32.1
+
+## Changes by Kind
+
+### Bug or Regression
+
+- Fixed a kubelet crash on startup. ([#135105](https://github.com/kubernetes/kubernetes/pull/135105), [@LionelJouin](https://github.com/LionelJouin)) [SIG Node]
+- Fixed a scheduler panic. ([#135200](https://github.com/kubernetes/kubernetes/pull/135200), [@dev](https://github.com/dev)) [SIG Scheduling]
+
+# v1.32.0
+
+## Changes by Kind
+
+### API Change
+
+- Added a field. ([#135300](https://github.com/kubernetes/kubernetes/pull/135300), [@dev](https://github.com/dev)) [SIG Apps]
+
+### Bug or Regression
+
+- Fixed a kubelet crash on startup. ([#135105](https://github.com/kubernetes/kubernetes/pull/135105), [@LionelJouin](https://github.com/LionelJouin)) [SIG Node]
+
+# v1.32.0-rc.0
+
+## Changes by Kind
+
+### API Change
+
+- Added a field. ([#135300](https://github.com/kubernetes/kubernetes/pull/135300), [@dev](https://github.com/dev)) [SIG Apps]
+`, `# v1.33.0
+
+## Changes by Kind
+
+### Bug or Regression
+
+- Fixed a scheduler panic. ([#135200](https://github.com/kubernetes/kubernetes/pull/135200), [@dev](https://github.com/dev)) [SIG Scheduling]
+- A change without a pull request.
+- A change without a pull request.
+`}
+
+	got := dedupeChanges(changes)
+	want := []string{`# v1.32.1
+
+## Changes by Kind
+
+### Bug or Regression
+
+- Fixed a kubelet crash on startup. ([#135105](https://github.com/kubernetes/kubernetes/pull/135105), [@LionelJouin](https://github.com/LionelJouin)) [SIG Node] (also in v1.32.0)
+- Fixed a scheduler panic. ([#135200](https://github.com/kubernetes/kubernetes/pull/135200), [@dev](https://github.com/dev)) [SIG Scheduling] (also in v1.33.0)
+
+# v1.32.0
+
+## Changes by Kind
+
+### API Change
+
+- Added a field. ([#135300](https://github.com/kubernetes/kubernetes/pull/135300), [@dev](https://github.com/dev)) [SIG Apps] (also in v1.32.0-rc.0)
+`, `# v1.33.0
+
+## Changes by Kind
+
+### Bug or Regression
+
+- A change without a pull request.
+- A change without a pull request.
+`}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("dedupeChanges()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
@@ -17,73 +17,325 @@ package k8schangelog
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
-	"log"
+	"io/fs"
+	"log/slog"
 	"net/http"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/metrics"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/progress"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/retry"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/sync/errgroup"
 )
 
 var (
 	kubernetesMinorVersionRegexp = regexp.MustCompile(`^\d+\.\d+$`)
-	changelogHostURL             = "https://raw.githubusercontent.com"
+	// githubAPIURL is the URL of the GitHub API the changelogs are read from.
+	githubAPIURL = "https://api.github.com"
+	// httpClient retries the changelog requests failing with transient errors.
+	httpClient = retry.NewClient()
 )
 
 type getK8sChangelogArgs struct {
-	KubernetesMinorVersion string `json:"KubernetesMinorVersion" jsonschema:"The kubernetes minor version to get changelog for. For example, '1.33'."`
+	KubernetesMinorVersion       string   `json:"KubernetesMinorVersion" jsonschema:"The kubernetes minor version to get changelog for. For example, '1.33'."`
+	TargetKubernetesMinorVersion string   `json:"TargetKubernetesMinorVersion,omitempty" jsonschema:"The last kubernetes minor version of a range starting at KubernetesMinorVersion, to get the changelogs of all the versions of an upgrade at once. For example, '1.33' with KubernetesMinorVersion '1.31' gets the changelogs of 1.31, 1.32 and 1.33."`
+	SIGs                         []string `json:"SIGs,omitempty" jsonschema:"Only return the changes of these SIGs or areas, e.g. ['network', 'sig-storage', 'kubelet']. A change matches a SIG of its [SIG ...] label, or an area its text mentions. Leave this empty for all the changes."`
+}
+
+const (
+	// maxConcurrentFetches bounds the number of changelogs fetched at the same
+	// time for a range of versions.
+	maxConcurrentFetches = 4
+	// maxVersionRange is the maximum number of minor versions of a range.
+	maxVersionRange = 10
+)
+
+type handlers struct {
+	githubToken string
+	// mirrorURL is the URL of the changelogs, with a version placeholder,
+	// replacing the GitHub API if set.
+	mirrorURL string
+	// offlineDir is the directory the changelogs are read from instead of
+	// the network, if set.
+	offlineDir string
+}
+
+func newHandlers(c *config.Config) *handlers {
+	return &handlers{
+		githubToken: c.GitHubToken(),
+		mirrorURL:   c.Sources().ChangelogURL,
+		offlineDir:  c.Sources().OfflineDir,
+	}
+}
+
+// OfflineFileName returns the name of the changelog of the Kubernetes minor
+// version in an offline directory.
+func OfflineFileName(version string) string {
+	return fmt.Sprintf("CHANGELOG-%s.md", version)
+}
+
+// Download returns the changelog of the Kubernetes minor version from the
+// GitHub API or the mirror of the config, to store it in an offline directory.
+func Download(ctx context.Context, c *config.Config, version string) ([]byte, error) {
+	if !kubernetesMinorVersionRegexp.MatchString(version) {
+		return nil, fmt.Errorf("invalid kubernetes minor version: %s", version)
+	}
+	h := newHandlers(c)
+	h.offlineDir = ""
+	return h.changelog(ctx, version)
 }
 
 // Install registers Kubernetes changelog tools with the MCP server.
-func Install(_ context.Context, s *mcp.Server, _ *config.Config) error {
+func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
+	h := newHandlers(c)
 	mcp.AddTool(s, &mcp.Tool{
 		Name:        "get_k8s_changelog",
-		Description: "Get changelog file for a specific kubernetes minor version and keep only changes content. Prefer to use this tool if kubernetes minor version changelog is needed.",
+		Description: "Get changelog file for a specific kubernetes minor version, or for each minor version of a range, and keep only changes content, optionally only the changes of some SIGs or areas, e.g. network or kubelet. The changes of a pull request listed by several versions are listed once. Prefer to use this tool if kubernetes minor version changelog is needed.",
 		Annotations: &mcp.ToolAnnotations{
 			ReadOnlyHint:   true,
 			IdempotentHint: true,
 		},
-	}, getK8sChangelog)
+	}, h.getK8sChangelog)
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "get_k8s_deprecation_guide",
+		Description: "Get the Kubernetes APIs removed between two Kubernetes or GKE versions, with the Kubernetes version removing them, the API version to migrate to and the notable changes of the migration, from the Kubernetes deprecated API migration guide built into the server. Prefer this tool over the changelogs to check API removals.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+	}, getK8sDeprecationGuide)
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "get_k8s_urgent_upgrade_notes",
+		Description: "Get only the \"Urgent Upgrade Notes\" sections of the changelog of a kubernetes minor version, or of each minor version of a range, i.e. the changes requiring action before upgrading, at a fraction of the size of the full changelogs. Prefer this tool over get_k8s_changelog for a first pass of an upgrade risk review.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+	}, h.getK8sUrgentUpgradeNotes)
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "search_k8s_changes",
+		Description: "Search the Kubernetes changelogs and GKE release notes of the versions of an upgrade for keywords, e.g. Ingress, cgroup or topology, and return the matching changes with their versions. Prefer this tool over reading the full changelogs to check the changes of a feature or component.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+	}, h.searchK8sChanges)
+	h.installResources(s)
 
 	return nil
 }
 
-func getK8sChangelog(_ context.Context, _ *mcp.CallToolRequest, args *getK8sChangelogArgs) (*mcp.CallToolResult, any, error) {
-	version := strings.TrimSpace(args.KubernetesMinorVersion)
-	if !kubernetesMinorVersionRegexp.MatchString(version) {
-		return nil, nil, fmt.Errorf("invalid kubernetes minor version: %s", version)
+// githubHeader returns the header of the requests to the GitHub contents API,
+// returning the raw content of files, authenticated with the token if it's set.
+func githubHeader(token string) http.Header {
+	header := http.Header{}
+	header.Set("Accept", "application/vnd.github.raw+json")
+	header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if token != "" {
+		header.Set("Authorization", "Bearer "+token)
 	}
+	return header
+}
 
-	changelogURL := fmt.Sprintf("%s/kubernetes/kubernetes/refs/heads/master/CHANGELOG/CHANGELOG-%s.md", changelogHostURL, version)
-	// #nosec G107
-	resp, err := http.Get(changelogURL)
+// fetchChangelog returns the content of the changelog at the URL, requested
+// with the header.
+func fetchChangelog(ctx context.Context, changelogURL string, header http.Header) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, changelogURL, nil)
 	if err != nil {
-		log.Printf("Failed to get changelog: %v", err)
-		return nil, nil, err
+		return nil, fmt.Errorf("failed to create changelog request: %w", err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		slog.Error("Failed to get changelog", "error", err)
+		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
+	if rateLimited(resp) {
+		err := fmt.Errorf("failed to get changelog: the GitHub API rate limit is exceeded, configure a GitHub token to raise it")
+		slog.Error("Failed to get changelog", "error", err)
+		return nil, err
+	}
 	if resp.StatusCode != http.StatusOK {
 		err := fmt.Errorf("failed to get changelog with status code: %d", resp.StatusCode)
-		log.Printf("Failed to get changelog: %v", err)
-		return nil, nil, err
+		slog.Error("Failed to get changelog", "error", err)
+		return nil, err
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		log.Printf("Failed to read changelog response body: %v", err)
+		slog.Error("Failed to read changelog response body", "error", err)
+		return nil, err
+	}
+	return body, nil
+}
+
+// rateLimited reports whether the GitHub API refused a request because of its
+// rate limit.
+func rateLimited(resp *http.Response) bool {
+	return (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests) &&
+		resp.Header.Get("X-RateLimit-Remaining") == "0"
+}
+
+// changelog returns the changelog of the Kubernetes minor version, from the
+// offline directory if set.
+func (h *handlers) changelog(ctx context.Context, version string) ([]byte, error) {
+	if h.offlineDir != "" {
+		name := filepath.Join(h.offlineDir, OfflineFileName(version))
+		body, err := os.ReadFile(name)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("the changelog of Kubernetes %s is not in the offline directory %s, download it with gke-mcp download-offline-sources", version, h.offlineDir)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read offline changelog: %w", err)
+		}
+		return body, nil
+	}
+
+	changelogURL := fmt.Sprintf("%s/repos/kubernetes/kubernetes/contents/CHANGELOG/CHANGELOG-%s.md?ref=master", githubAPIURL, version)
+	header := githubHeader(h.githubToken)
+	if h.mirrorURL != "" {
+		// The GitHub token isn't sent to mirrors.
+		changelogURL = strings.ReplaceAll(h.mirrorURL, config.VersionPlaceholder, version)
+		header = nil
+	}
+	start := time.Now()
+	body, err := fetchChangelog(ctx, changelogURL, header)
+	metrics.ObserveExternal("changelog", start, err)
+	return body, err
+}
+
+// changelogs returns the changelogs of the Kubernetes minor versions, fetched
+// concurrently with at most maxConcurrentFetches requests at the same time.
+func (h *handlers) changelogs(ctx context.Context, versions []string) ([][]byte, error) {
+	bodies := make([][]byte, len(versions))
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxConcurrentFetches)
+	var done atomic.Int32
+	for i, version := range versions {
+		g.Go(func() error {
+			body, err := h.changelog(ctx, version)
+			if err != nil {
+				return fmt.Errorf("failed to get changelog of Kubernetes %s: %w", version, err)
+			}
+			bodies[i] = body
+			progress.Report(ctx, int(done.Add(1)), len(versions), fmt.Sprintf("Fetched the changelog of Kubernetes %s", version))
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return bodies, nil
+}
+
+// minorVersionRange returns the minor versions from one to another, both
+// included.
+func minorVersionRange(from, to string) ([]string, error) {
+	fromMajor, fromMinor, err := gke.MinorVersion(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kubernetes minor version: %s", from)
+	}
+	toMajor, toMinor, err := gke.MinorVersion(to)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kubernetes minor version: %s", to)
+	}
+	if fromMajor != toMajor || fromMinor > toMinor {
+		return nil, fmt.Errorf("target kubernetes minor version %s is not a later minor version of %s", to, from)
+	}
+	if toMinor-fromMinor >= maxVersionRange {
+		return nil, fmt.Errorf("the range from %s to %s has more than %d minor versions", from, to, maxVersionRange)
+	}
+	var versions []string
+	for minor := fromMinor; minor <= toMinor; minor++ {
+		versions = append(versions, fmt.Sprintf("%d.%d", fromMajor, minor))
+	}
+	return versions, nil
+}
+
+// changelogVersions returns the Kubernetes minor versions of the changelogs
+// requested by the arguments.
+func changelogVersions(args *getK8sChangelogArgs) ([]string, error) {
+	version := strings.TrimSpace(args.KubernetesMinorVersion)
+	if !kubernetesMinorVersionRegexp.MatchString(version) {
+		return nil, fmt.Errorf("invalid kubernetes minor version: %s", version)
+	}
+	target := strings.TrimSpace(args.TargetKubernetesMinorVersion)
+	if target == "" || target == version {
+		return []string{version}, nil
+	}
+	if !kubernetesMinorVersionRegexp.MatchString(target) {
+		return nil, fmt.Errorf("invalid kubernetes minor version: %s", target)
+	}
+	return minorVersionRange(version, target)
+}
+
+func (h *handlers) getK8sChangelog(ctx context.Context, _ *mcp.CallToolRequest, args *getK8sChangelogArgs) (*mcp.CallToolResult, any, error) {
+	versions, err := changelogVersions(args)
+	if err != nil {
 		return nil, nil, err
 	}
-	changelogFileContent := string(body)
+	if len(versions) == 1 {
+		body, err := h.changelog(ctx, versions[0])
+		if err != nil {
+			return nil, nil, err
+		}
+		changelogFileContent := string(body)
+		changes := dedupeChanges([]string{filterChanges(keepOnlyChanges(changelogFileContent), args.SIGs)})
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{Text: keepOnlyChanges(changelogFileContent)},
-		},
-	}, nil, nil
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: changesText(versions[0], changes[0], args.SIGs)},
+			},
+		}, nil, nil
+	}
+
+	bodies, err := h.changelogs(ctx, versions)
+	if err != nil {
+		return nil, nil, err
+	}
+	changes := make([]string, len(bodies))
+	for i, body := range bodies {
+		changes[i] = filterChanges(keepOnlyChanges(string(body)), args.SIGs)
+	}
+	changes = dedupeChanges(changes)
+	content := make([]mcp.Content, len(versions))
+	for i := range changes {
+		content[i] = &mcp.TextContent{Text: fmt.Sprintf("Changelog of Kubernetes %s:\n\n%s", versions[i], changesText(versions[i], changes[i], args.SIGs))}
+	}
+	return &mcp.CallToolResult{Content: content}, nil, nil
+}
+
+// filterChanges returns the changes of the SIGs or areas, or all the changes
+// if sigs is empty.
+func filterChanges(changes string, sigs []string) string {
+	if len(sigs) == 0 {
+		return changes
+	}
+	return renderEntries(filterBySIG(parseEntries(changes), sigs))
+}
+
+// changesText returns the changes of the Kubernetes minor version, or a note
+// that the SIGs or areas have no changes.
+func changesText(version, changes string, sigs []string) string {
+	if changes == "" && len(sigs) > 0 {
+		return fmt.Sprintf("The changelog of Kubernetes %s has no changes of %s.", version, strings.Join(sigs, ", "))
+	}
+	return changes
 }
 
 var (
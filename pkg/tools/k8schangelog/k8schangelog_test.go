@@ -19,8 +19,12 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -34,7 +38,16 @@ func TestGetK8sChangelog(t *testing.T) {
 	expectedProcessedContentObjectJSONString := string(expectedProcessedContentObjectJSON)
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if strings.HasSuffix(r.URL.Path, "CHANGELOG-1.31.md") {
+		if r.Header.Get("Accept") != "application/vnd.github.raw+json" {
+			w.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "CHANGELOG-1.30.md") {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "CHANGELOG-1.31.md") && r.Header.Get("Authorization") == "Bearer test-token" {
 			w.WriteHeader(http.StatusOK)
 			_, _ = fmt.Fprint(w, fakeChangelogContent) // Test response
 		} else if strings.HasSuffix(r.URL.Path, "CHANGELOG-1.32.md") {
@@ -46,10 +59,11 @@ func TestGetK8sChangelog(t *testing.T) {
 	}))
 	defer server.Close()
 
-	// Temporarily replace the changelog host URL to point to the test server.
-	originalChangelogHostURL := changelogHostURL
-	changelogHostURL = server.URL
-	defer func() { changelogHostURL = originalChangelogHostURL }()
+	// Temporarily replace the GitHub API URL to point to the test server.
+	originalGitHubAPIURL := githubAPIURL
+	githubAPIURL = server.URL
+	defer func() { githubAPIURL = originalGitHubAPIURL }()
+	h := &handlers{githubToken: "test-token"}
 
 	testCases := []struct {
 		name          string
@@ -73,6 +87,11 @@ func TestGetK8sChangelog(t *testing.T) {
 			args:    &getK8sChangelogArgs{KubernetesMinorVersion: "1.32"},
 			wantErr: "failed to get changelog with status code: 404",
 		},
+		{
+			name:    "rate limited",
+			args:    &getK8sChangelogArgs{KubernetesMinorVersion: "1.30"},
+			wantErr: "the GitHub API rate limit is exceeded",
+		},
 		{
 			name:    "http server error",
 			args:    &getK8sChangelogArgs{KubernetesMinorVersion: "1.35"},
@@ -82,7 +101,7 @@ func TestGetK8sChangelog(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result, _, err := getK8sChangelog(context.Background(), nil, tc.args)
+			result, _, err := h.getK8sChangelog(context.Background(), nil, tc.args)
 
 			if tc.wantErr != "" {
 				if err == nil {
@@ -499,3 +518,105 @@ const expectedProcessedContent = `# v1.33.6
 - Masked off access to Linux thermal interrupt info in ` + "`" + `/proc` + "`" + ` and ` + "`" + `/sys` + "`" + `. ([#132985](https://github.com/kubernetes/kubernetes/pull/132985), [@saschagrunert](https://github.com/saschagrunert)) [SIG Node]
 
 `
+
+func TestGetK8sChangelogFromMirror(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mirror/CHANGELOG-1.31.md" || r.Header.Get("Authorization") != "" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = fmt.Fprint(w, fakeChangelogContent)
+	}))
+	defer server.Close()
+
+	h := &handlers{githubToken: "test-token", mirrorURL: server.URL + "/mirror/CHANGELOG-{version}.md"}
+	result, _, err := h.getK8sChangelog(context.Background(), nil, &getK8sChangelogArgs{KubernetesMinorVersion: "1.31"})
+	if err != nil {
+		t.Fatalf("getK8sChangelog() error = %v", err)
+	}
+	if got := result.Content[0].(*mcp.TextContent).Text; got != expectedProcessedContent {
+		t.Errorf("getK8sChangelog() = %q, want %q", got, expectedProcessedContent)
+	}
+}
+
+func TestGetK8sChangelogOffline(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, OfflineFileName("1.31")), []byte(fakeChangelogContent), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	h := &handlers{offlineDir: dir}
+	result, _, err := h.getK8sChangelog(context.Background(), nil, &getK8sChangelogArgs{KubernetesMinorVersion: "1.31"})
+	if err != nil {
+		t.Fatalf("getK8sChangelog() error = %v", err)
+	}
+	if got := result.Content[0].(*mcp.TextContent).Text; got != expectedProcessedContent {
+		t.Errorf("getK8sChangelog() = %q, want %q", got, expectedProcessedContent)
+	}
+
+	_, _, err = h.getK8sChangelog(context.Background(), nil, &getK8sChangelogArgs{KubernetesMinorVersion: "1.30"})
+	if err == nil || !strings.Contains(err.Error(), "download-offline-sources") {
+		t.Errorf("getK8sChangelog() error = %v, want an error about download-offline-sources", err)
+	}
+}
+
+func TestGetK8sChangelogRange(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		version := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/CHANGELOG-"), ".md")
+		_, _ = fmt.Fprintf(w, "# v%s.0\n\nchanges of %s\n", version, version)
+	}))
+	defer server.Close()
+
+	h := &handlers{mirrorURL: server.URL + "/CHANGELOG-{version}.md"}
+	result, _, err := h.getK8sChangelog(context.Background(), nil, &getK8sChangelogArgs{KubernetesMinorVersion: "1.25", TargetKubernetesMinorVersion: "1.31"})
+	if err != nil {
+		t.Fatalf("getK8sChangelog() error = %v", err)
+	}
+	if len(result.Content) != 7 {
+		t.Fatalf("getK8sChangelog() returned %d contents, want 7", len(result.Content))
+	}
+	for i, c := range result.Content {
+		version := fmt.Sprintf("1.%d", 25+i)
+		want := fmt.Sprintf("Changelog of Kubernetes %s:\n\n# v%s.0\n\nchanges of %s\n\n", version, version, version)
+		if got := c.(*mcp.TextContent).Text; got != want {
+			t.Errorf("getK8sChangelog() content %d = %q, want %q", i, got, want)
+		}
+	}
+	if got := maxInFlight.Load(); got > maxConcurrentFetches {
+		t.Errorf("getK8sChangelog() fetched %d changelogs at the same time, want at most %d", got, maxConcurrentFetches)
+	}
+}
+
+func TestMinorVersionRange(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     []string
+		wantErr  bool
+	}{
+		{"1.31", "1.33", []string{"1.31", "1.32", "1.33"}, false},
+		{"1.31", "1.31", []string{"1.31"}, false},
+		{"1.33", "1.31", nil, true},
+		{"1.31", "2.1", nil, true},
+		{"1.10", "1.30", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := minorVersionRange(tt.from, tt.to)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("minorVersionRange(%q, %q) error = %v, wantErr %v", tt.from, tt.to, err, tt.wantErr)
+			continue
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("minorVersionRange(%q, %q) = %q, want %q", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8schangelog
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// resourceScheme is the scheme of the URIs of the changelog resources, e.g.
+// k8s-changelog://1.31 or k8s-changelog://1.31/deprecation.
+const resourceScheme = "k8s-changelog://"
+
+var (
+	headingRegexp      = regexp.MustCompile(`^(#{1,3}) (.*)$`)
+	nonSlugCharsRegexp = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// installResources registers the resource templates of the changelogs and
+// their sections with the MCP server.
+func (h *handlers) installResources(s *mcp.Server) {
+	s.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: resourceScheme + "{version}",
+		Name:        "k8s-changelog",
+		Title:       "Kubernetes changelog",
+		Description: "Changes of the patch versions of a Kubernetes minor version, e.g. k8s-changelog://1.31.",
+		MIMEType:    "text/markdown",
+	}, h.readChangelogResource)
+	s.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: resourceScheme + "{version}/{section}",
+		Name:        "k8s-changelog-section",
+		Title:       "Kubernetes changelog section",
+		Description: "A section of the changes of the patch versions of a Kubernetes minor version, e.g. k8s-changelog://1.31/deprecation, k8s-changelog://1.31/api-change or k8s-changelog://1.31/urgent-upgrade-notes.",
+		MIMEType:    "text/markdown",
+	}, h.readChangelogResource)
+}
+
+func (h *handlers) readChangelogResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	uri := req.Params.URI
+	version, section, _ := strings.Cut(strings.TrimPrefix(uri, resourceScheme), "/")
+	if !kubernetesMinorVersionRegexp.MatchString(version) {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+
+	body, err := h.changelog(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	text := keepOnlyChanges(string(body))
+	if section != "" {
+		text = changelogSection(text, section)
+		if text == "" {
+			return nil, fmt.Errorf("the changelog of Kubernetes %s has no %s section, the sections are: %s", version, section, strings.Join(sectionSlugs(keepOnlyChanges(string(body))), ", "))
+		}
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{URI: uri, MIMEType: "text/markdown", Text: text},
+		},
+	}, nil
+}
+
+// slug returns the name of a changelog section in resource URIs, e.g.
+// bug-or-regression for "Bug or Regression".
+func slug(heading string) string {
+	return strings.Trim(nonSlugCharsRegexp.ReplaceAllString(strings.ToLower(heading), "-"), "-")
+}
+
+// changelogSection returns the sections with the slug of each patch version of
+// the changes, under the heading of their version.
+func changelogSection(changes, sectionSlug string) string {
+	var b strings.Builder
+	versionHeading, written := "", false
+	level := 0 // the level of the heading of the section being copied, or 0
+	for _, line := range strings.Split(changes, "\n") {
+		if m := headingRegexp.FindStringSubmatch(line); m != nil {
+			if len(m[1]) == 1 {
+				versionHeading, written, level = line, false, 0
+				continue
+			}
+			if level != 0 && len(m[1]) <= level {
+				level = 0
+			}
+			if level == 0 && slug(m[2]) == sectionSlug {
+				level = len(m[1])
+				if !written {
+					fmt.Fprintf(&b, "%s\n\n", versionHeading)
+					written = true
+				}
+			}
+		}
+		if level != 0 {
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// sectionSlugs returns the slugs of the sections of the changes, in the order
+// they first appear.
+func sectionSlugs(changes string) []string {
+	var slugs []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(changes, "\n") {
+		m := headingRegexp.FindStringSubmatch(line)
+		if m == nil || len(m[1]) == 1 {
+			continue
+		}
+		s := slug(m[2])
+		if s != "" && !seen[s] && !strings.HasPrefix(s, "changelog-since-") {
+			seen[s] = true
+			slugs = append(slugs, s)
+		}
+	}
+	return slugs
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8schangelog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestChangelogResources(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, OfflineFileName("1.33")), []byte(fakeChangelogContent), 0600); err != nil {
+		t.Fatal(err)
+	}
+	s := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	(&handlers{offlineDir: dir}).installResources(s)
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "client"}, nil)
+	ct, st := mcp.NewInMemoryTransports()
+	ss, err := s.Connect(ctx, st, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = ss.Close() }()
+	cs, err := client.Connect(ctx, ct, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = cs.Close() }()
+
+	templates, err := cs.ListResourceTemplates(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListResourceTemplates() error = %v", err)
+	}
+	if len(templates.ResourceTemplates) != 2 {
+		t.Errorf("ListResourceTemplates() returned %d templates, want 2", len(templates.ResourceTemplates))
+	}
+
+	result, err := cs.ReadResource(ctx, &mcp.ReadResourceParams{URI: "k8s-changelog://1.33"})
+	if err != nil {
+		t.Fatalf("ReadResource() error = %v", err)
+	}
+	if got := result.Contents[0].Text; got != expectedProcessedContent {
+		t.Errorf("ReadResource() = %q, want %q", got, expectedProcessedContent)
+	}
+
+	result, err = cs.ReadResource(ctx, &mcp.ReadResourceParams{URI: "k8s-changelog://1.33/feature"})
+	if err != nil {
+		t.Fatalf("ReadResource() error = %v", err)
+	}
+	want := "# v1.33.6\n\n### Feature\n\n- Kubernetes is now built using Go 1.24.9\n"
+	if got := result.Contents[0].Text; !strings.HasPrefix(got, want) || !strings.Contains(got, "# v1.33.5\n\n### Feature\n") || strings.Contains(got, "### Bug or Regression") {
+		t.Errorf("ReadResource() = %q, want the Feature sections of each patch version", got)
+	}
+
+	if _, err := cs.ReadResource(ctx, &mcp.ReadResourceParams{URI: "k8s-changelog://1.33/unknown"}); err == nil || !strings.Contains(err.Error(), "bug-or-regression") {
+		t.Errorf("ReadResource() error = %v, want an error listing the sections", err)
+	}
+}
+
+func TestSlug(t *testing.T) {
+	tests := map[string]string{
+		"Bug or Regression":        "bug-or-regression",
+		"API Change":               "api-change",
+		"Other (Cleanup or Flake)": "other-cleanup-or-flake",
+		"Urgent Upgrade Notes":     "urgent-upgrade-notes",
+	}
+	for heading, want := range tests {
+		if got := slug(heading); got != want {
+			t.Errorf("slug(%q) = %q, want %q", heading, got, want)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8schangelog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/pagination"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/gkereleasenotes"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// defaultSearchPageSize is the number of matching changes of a page.
+const defaultSearchPageSize = 20
+
+type searchK8sChangesArgs struct {
+	SourceVersion string   `json:"source_version" jsonschema:"The current GKE or Kubernetes version of the cluster, e.g. 1.31.5-gke.1169000. Only the changes of the later versions are searched."`
+	TargetVersion string   `json:"target_version" jsonschema:"The GKE or Kubernetes version to upgrade to, e.g. 1.32.2-gke.1297002. The changes up to and including this version are searched."`
+	Keywords      []string `json:"keywords" jsonschema:"The keywords to search for, case-insensitive, e.g. ['Ingress', 'cgroup', 'topology']. A change matches if it mentions any of them."`
+	PageToken     string   `json:"page_token,omitempty" jsonschema:"Token of the page of matching changes to return, from the previous call with the same arguments. Leave this empty for the first page."`
+	PageSize      int      `json:"page_size,omitempty" jsonschema:"Maximum number of matching changes to return. Defaults to 20."`
+}
+
+func (h *handlers) searchK8sChanges(ctx context.Context, _ *mcp.CallToolRequest, args *searchK8sChangesArgs) (*mcp.CallToolResult, any, error) {
+	var keywords []string
+	for _, keyword := range args.Keywords {
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			keywords = append(keywords, keyword)
+		}
+	}
+	if len(keywords) == 0 {
+		return nil, nil, fmt.Errorf("keywords argument cannot be empty")
+	}
+	source, err := gke.ParsePatchVersion(args.SourceVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+	target, err := gke.ParsePatchVersion(args.TargetVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+	if target.Less(source) {
+		return nil, nil, fmt.Errorf("target_version %s must not be older than source_version %s", args.TargetVersion, args.SourceVersion)
+	}
+
+	versions, err := minorVersionRange(fmt.Sprintf("%d.%d", source.Major, source.Minor), fmt.Sprintf("%d.%d", target.Major, target.Minor))
+	if err != nil {
+		return nil, nil, err
+	}
+	bodies, err := h.changelogs(ctx, versions)
+	if err != nil {
+		return nil, nil, err
+	}
+	changes := make([]string, len(bodies))
+	for i, body := range bodies {
+		changes[i] = withoutPreReleases(keepOnlyChanges(string(body)))
+	}
+	matches := searchChangelogs(dedupeChanges(changes), source, target, keywords)
+
+	notes := "GKE release notes were not searched, pass GKE versions, e.g. 1.31.5-gke.1169000, to search them."
+	if strings.Contains(args.SourceVersion, "-gke.") && strings.Contains(args.TargetVersion, "-gke.") {
+		entries, err := gkereleasenotes.UpgradeEntries(ctx, strings.TrimSpace(args.SourceVersion), strings.TrimSpace(args.TargetVersion), keywords)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, entry := range entries {
+			matches = append(matches, "[GKE release notes] "+entry)
+		}
+		notes = ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Changes mentioning %s from %s to %s:\n", strings.Join(keywords, ", "), args.SourceVersion, args.TargetVersion)
+	if notes != "" {
+		fmt.Fprintf(&b, "%s\n", notes)
+	}
+	if len(matches) == 0 {
+		b.WriteString("\nNo matching changes found.")
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: b.String()},
+			},
+		}, nil, nil
+	}
+	if args.PageSize <= 0 {
+		args.PageSize = defaultSearchPageSize
+	}
+	paged, next, err := pagination.Page(matches, args.PageToken, args.PageSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, match := range paged {
+		fmt.Fprintf(&b, "\n%s\n", match)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: b.String() + pagination.Footer(len(paged), len(matches), next)},
+		},
+	}, nil, nil
+}
+
+// searchChangelogs returns the entries of the changes mentioning any of the
+// keywords, of the patch versions after source up to and including target,
+// prefixed with their version and section.
+func searchChangelogs(changes []string, source, target gke.PatchVersion, keywords []string) []string {
+	var matches []string
+	for _, c := range changes {
+		for _, e := range parseEntries(c) {
+			version, err := gke.ParsePatchVersion(strings.TrimPrefix(e.version, "# "))
+			if err != nil || !source.Less(version) || target.Less(version) {
+				continue
+			}
+			text := strings.ToLower(e.text)
+			for _, keyword := range keywords {
+				if strings.Contains(text, strings.ToLower(keyword)) {
+					where := strings.TrimPrefix(e.version, "# ")
+					if e.section != "" {
+						where += ", " + strings.TrimLeft(e.section, "# ")
+					}
+					matches = append(matches, fmt.Sprintf("[Kubernetes %s] %s", where, e.text))
+					break
+				}
+			}
+		}
+	}
+	return matches
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8schangelog
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestSearchK8sChanges(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/CHANGELOG-1.31.md":
+			_, _ = fmt.Fprint(w, `# v1.31.6
+
+## Changes by Kind
+
+### Bug or Regression
+
+- Fixed Ingress status updates. ([#130001](https://github.com/kubernetes/kubernetes/pull/130001), [@dev](https://github.com/dev)) [SIG Network]
+
+# v1.31.5
+
+## Changes by Kind
+
+### Bug or Regression
+
+- Fixed an Ingress panic. ([#130000](https://github.com/kubernetes/kubernetes/pull/130000), [@dev](https://github.com/dev)) [SIG Network]
+`)
+		case "/CHANGELOG-1.32.md":
+			_, _ = fmt.Fprint(w, `# v1.32.3
+
+## Changes by Kind
+
+### Feature
+
+- Kubelet supports cgroup v2 memory QoS. ([#131000](https://github.com/kubernetes/kubernetes/pull/131000), [@dev](https://github.com/dev)) [SIG Node]
+
+# v1.32.2
+
+## Changes by Kind
+
+### Feature
+
+- Topology aware routing is GA. ([#130500](https://github.com/kubernetes/kubernetes/pull/130500), [@dev](https://github.com/dev)) [SIG Network]
+- Kubectl prints ingress classes. ([#130600](https://github.com/kubernetes/kubernetes/pull/130600), [@dev](https://github.com/dev)) [SIG CLI]
+
+# v1.32.0-rc.0
+
+## Changes by Kind
+
+### Feature
+
+- Added INGRESS metrics. ([#129000](https://github.com/kubernetes/kubernetes/pull/129000), [@dev](https://github.com/dev)) [SIG Network]
+`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	h := &handlers{mirrorURL: server.URL + "/CHANGELOG-{version}.md"}
+
+	result, _, err := h.searchK8sChanges(context.Background(), nil, &searchK8sChangesArgs{
+		SourceVersion: "1.31.5",
+		TargetVersion: "1.32.2",
+		Keywords:      []string{"ingress", " topology "},
+	})
+	if err != nil {
+		t.Fatalf("searchK8sChanges() error = %v", err)
+	}
+	got := result.Content[0].(*mcp.TextContent).Text
+	for _, want := range []string{
+		"Changes mentioning ingress, topology from 1.31.5 to 1.32.2:\nGKE release notes were not searched",
+		"[Kubernetes v1.31.6, Bug or Regression] - Fixed Ingress status updates.",
+		"[Kubernetes v1.32.2, Feature] - Topology aware routing is GA.",
+		"[Kubernetes v1.32.2, Feature] - Kubectl prints ingress classes.",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("searchK8sChanges() missing %q, got:\n%s", want, got)
+		}
+	}
+	for _, notWant := range []string{"Ingress panic", "cgroup", "INGRESS metrics"} {
+		if strings.Contains(got, notWant) {
+			t.Errorf("searchK8sChanges() contains %q, got:\n%s", notWant, got)
+		}
+	}
+
+	result, _, err = h.searchK8sChanges(context.Background(), nil, &searchK8sChangesArgs{SourceVersion: "1.31.5", TargetVersion: "1.32.2", Keywords: []string{"ingress"}, PageSize: 1})
+	if err != nil {
+		t.Fatalf("searchK8sChanges() error = %v", err)
+	}
+	if got := result.Content[0].(*mcp.TextContent).Text; !strings.Contains(got, "Showing 1 of 2 results") {
+		t.Errorf("searchK8sChanges() = %q, want a page of 1 of 2 results", got)
+	}
+
+	for _, args := range []*searchK8sChangesArgs{
+		{SourceVersion: "1.31.5", TargetVersion: "1.32.2"},
+		{SourceVersion: "1.31", TargetVersion: "1.32.2", Keywords: []string{"ingress"}},
+		{SourceVersion: "1.32.2", TargetVersion: "1.31.5", Keywords: []string{"ingress"}},
+	} {
+		if _, _, err := h.searchK8sChanges(context.Background(), nil, args); err == nil {
+			t.Errorf("searchK8sChanges(%+v) error = nil, want an error", args)
+		}
+	}
+}
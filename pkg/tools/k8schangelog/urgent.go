@@ -0,0 +1,86 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8schangelog
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// urgentUpgradeNotesSlug is the slug of the sections of the changes requiring
+// action before upgrading, "Urgent Upgrade Notes".
+const urgentUpgradeNotesSlug = "urgent-upgrade-notes"
+
+// preReleaseHeadingRegexp matches the headings of the alpha, beta and release
+// candidate versions of a changelog, e.g. # v1.31.0-rc.1.
+var preReleaseHeadingRegexp = regexp.MustCompile(`^# v\d+\.\d+\.\d+-`)
+
+func (h *handlers) getK8sUrgentUpgradeNotes(ctx context.Context, _ *mcp.CallToolRequest, args *getK8sChangelogArgs) (*mcp.CallToolResult, any, error) {
+	versions, err := changelogVersions(args)
+	if err != nil {
+		return nil, nil, err
+	}
+	bodies, err := h.changelogs(ctx, versions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	notes := make([]string, len(bodies))
+	for i, body := range bodies {
+		notes[i] = filterChanges(urgentUpgradeNotes(keepOnlyChanges(string(body))), args.SIGs)
+	}
+	notes = dedupeChanges(notes)
+
+	content := make([]mcp.Content, len(versions))
+	for i, notes := range notes {
+		text := fmt.Sprintf("Urgent upgrade notes of Kubernetes %s:\n\n%s", versions[i], notes)
+		if notes == "" {
+			text = fmt.Sprintf("Kubernetes %s has no urgent upgrade notes.", versions[i])
+			if len(args.SIGs) > 0 {
+				text = fmt.Sprintf("Kubernetes %s has no urgent upgrade notes of %s.", versions[i], strings.Join(args.SIGs, ", "))
+			}
+		}
+		content[i] = &mcp.TextContent{Text: text}
+	}
+	return &mcp.CallToolResult{Content: content}, nil, nil
+}
+
+// urgentUpgradeNotes returns the urgent upgrade notes of the released versions
+// of the changes. The notes of the alpha, beta and release candidate versions
+// are left out, since the notes of the .0 version repeat them.
+func urgentUpgradeNotes(changes string) string {
+	return changelogSection(withoutPreReleases(changes), urgentUpgradeNotesSlug)
+}
+
+// withoutPreReleases returns the changes without the sections of the alpha,
+// beta and release candidate versions.
+func withoutPreReleases(changes string) string {
+	var b strings.Builder
+	skipping := false
+	for _, line := range strings.Split(changes, "\n") {
+		if strings.HasPrefix(line, "# ") {
+			skipping = preReleaseHeadingRegexp.MatchString(line)
+		}
+		if !skipping {
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
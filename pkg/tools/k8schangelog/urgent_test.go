@@ -0,0 +1,115 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8schangelog
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const urgentChangelogContent = `<!-- BEGIN MUNGE: GENERATED_TOC -->
+- [v1.31.1](#v1311)
+<!-- END MUNGE: GENERATED_TOC -->
+
+# v1.31.1
+
+## Changelog since v1.31.0
+
+## Changes by Kind
+
+### Bug or Regression
+
+- Fixed a kubelet crash. ([#126001](https://github.com/kubernetes/kubernetes/pull/126001), [@dev](https://github.com/dev)) [SIG Node]
+
+# v1.31.0
+
+## Changelog since v1.30.0
+
+## Urgent Upgrade Notes
+
+### (No, really, you MUST read this before you upgrade)
+
+- The deprecated kubelet flag --keep-terminated-pod-volumes is removed. ([#122082](https://github.com/kubernetes/kubernetes/pull/122082), [@carlory](https://github.com/carlory)) [SIG Node]
+
+## Changes by Kind
+
+### Deprecation
+
+- kube-proxy --oom-score-adj is deprecated. ([#125001](https://github.com/kubernetes/kubernetes/pull/125001), [@dev](https://github.com/dev)) [SIG Network]
+
+# v1.31.0-rc.0
+
+## Changelog since v1.31.0-beta.0
+
+## Urgent Upgrade Notes
+
+### (No, really, you MUST read this before you upgrade)
+
+- The deprecated kubelet flag --keep-terminated-pod-volumes is removed. ([#122082](https://github.com/kubernetes/kubernetes/pull/122082), [@carlory](https://github.com/carlory)) [SIG Node]
+
+## Changes by Kind
+`
+
+func TestGetK8sUrgentUpgradeNotes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/CHANGELOG-1.31.md":
+			_, _ = fmt.Fprint(w, urgentChangelogContent)
+		case "/CHANGELOG-1.32.md":
+			_, _ = fmt.Fprint(w, "# v1.32.0\n\n## Changes by Kind\n\n### Feature\n\n- A feature.\n")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	h := &handlers{mirrorURL: server.URL + "/CHANGELOG-{version}.md"}
+	result, _, err := h.getK8sUrgentUpgradeNotes(context.Background(), nil, &getK8sChangelogArgs{KubernetesMinorVersion: "1.31", TargetKubernetesMinorVersion: "1.32"})
+	if err != nil {
+		t.Fatalf("getK8sUrgentUpgradeNotes() error = %v", err)
+	}
+	if len(result.Content) != 2 {
+		t.Fatalf("getK8sUrgentUpgradeNotes() returned %d contents, want 2", len(result.Content))
+	}
+
+	got := result.Content[0].(*mcp.TextContent).Text
+	want := `Urgent upgrade notes of Kubernetes 1.31:
+
+# v1.31.0
+
+## Urgent Upgrade Notes
+
+### (No, really, you MUST read this before you upgrade)
+
+- The deprecated kubelet flag --keep-terminated-pod-volumes is removed. ([#122082](https://github.com/kubernetes/kubernetes/pull/122082), [@carlory](https://github.com/carlory)) [SIG Node]
+
+`
+	if got != want {
+		t.Errorf("getK8sUrgentUpgradeNotes() content 0 = %q, want %q", got, want)
+	}
+	if got := result.Content[1].(*mcp.TextContent).Text; got != "Kubernetes 1.32 has no urgent upgrade notes." {
+		t.Errorf("getK8sUrgentUpgradeNotes() content 1 = %q", got)
+	}
+
+	if _, _, err := h.getK8sUrgentUpgradeNotes(context.Background(), nil, &getK8sChangelogArgs{KubernetesMinorVersion: "1.31.2"}); err == nil || !strings.Contains(err.Error(), "invalid kubernetes minor version") {
+		t.Errorf("getK8sUrgentUpgradeNotes() error = %v, want an invalid version error", err)
+	}
+}
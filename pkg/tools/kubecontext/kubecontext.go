@@ -0,0 +1,166 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kubecontext provides MCP tools listing the kubeconfig contexts and
+// selecting the one kubectl commands of an MCP session run against.
+package kubecontext
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/session"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// kubeconfig is the subset of the kubectl config view output listing the contexts.
+type kubeconfig struct {
+	CurrentContext string         `json:"current-context"`
+	Contexts       []namedContext `json:"contexts"`
+}
+
+type namedContext struct {
+	Name    string `json:"name"`
+	Context struct {
+		Cluster   string `json:"cluster"`
+		Namespace string `json:"namespace,omitempty"`
+	} `json:"context"`
+}
+
+type handlers struct{}
+
+type listKubeContextsArgs struct{}
+
+type useKubeContextArgs struct {
+	Context string `json:"context" jsonschema:"Name of the kubeconfig context to use, as returned by list_kube_contexts. Leave this empty to go back to the current kubectl context."`
+}
+
+// Install registers the kubeconfig context tools with the MCP server. The
+// session middleware applies the context selected by each session to its tool calls.
+func Install(_ context.Context, s *mcp.Server, _ *config.Config) error {
+	h := &handlers{}
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "list_kube_contexts",
+		Description: "List the available kubeconfig contexts with their cluster and namespace, marking the current kubectl context and the context selected for this session with use_kube_context.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.listKubeContexts)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "use_kube_context",
+		Description: "Select the kubeconfig context the kubectl-backed tools use for the rest of this session, without changing the current kubectl context of the kubeconfig file. Select a context before running checks in multi-cluster workflows, so they don't run against the wrong cluster.",
+		Annotations: &mcp.ToolAnnotations{
+			// Read-only, the selection only lives in the server for the session.
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+	}, h.useKubeContext)
+
+	return nil
+}
+
+func (h *handlers) listKubeContexts(ctx context.Context, req *mcp.CallToolRequest, _ *listKubeContextsArgs) (*mcp.CallToolResult, any, error) {
+	kc, err := readKubeconfig(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: contextsReport(kc, session.Get(req).KubeContext)},
+		},
+	}, nil, nil
+}
+
+func (h *handlers) useKubeContext(ctx context.Context, req *mcp.CallToolRequest, args *useKubeContextArgs) (*mcp.CallToolResult, any, error) {
+	name := strings.TrimSpace(args.Context)
+	if name == "" {
+		session.Update(req, func(st *session.State) { st.KubeContext = "" })
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: "Cleared the selected kubeconfig context, kubectl commands use the current kubectl context."},
+			},
+		}, nil, nil
+	}
+
+	kc, err := readKubeconfig(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !hasContext(kc, name) {
+		return nil, nil, fmt.Errorf("kubeconfig context %q not found, run list_kube_contexts to see the available contexts", name)
+	}
+	session.Update(req, func(st *session.State) { st.KubeContext = name })
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: fmt.Sprintf("kubectl commands of this session now use the kubeconfig context %s.", name)},
+		},
+	}, nil, nil
+}
+
+// readKubeconfig returns the contexts of the kubeconfig, without its credentials.
+func readKubeconfig(ctx context.Context) (*kubeconfig, error) {
+	out, err := kube.Run(ctx, "config", "view", "--output=json")
+	if err != nil {
+		return nil, err
+	}
+	kc := &kubeconfig{}
+	if err := json.Unmarshal(out, kc); err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+	return kc, nil
+}
+
+func hasContext(kc *kubeconfig, name string) bool {
+	for _, c := range kc.Contexts {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func contextsReport(kc *kubeconfig, selected string) string {
+	if len(kc.Contexts) == 0 {
+		return "No kubeconfig contexts found. Run get_kubeconfig or get_credentials for a cluster first."
+	}
+
+	var b strings.Builder
+	b.WriteString("Kubeconfig contexts:\n")
+	for _, c := range kc.Contexts {
+		fmt.Fprintf(&b, "- %s (cluster: %s", c.Name, c.Context.Cluster)
+		if c.Context.Namespace != "" {
+			fmt.Fprintf(&b, ", namespace: %s", c.Context.Namespace)
+		}
+		b.WriteString(")")
+		if c.Name == kc.CurrentContext {
+			b.WriteString(" [current]")
+		}
+		if c.Name == selected {
+			b.WriteString(" [selected for this session]")
+		}
+		b.WriteString("\n")
+	}
+	if selected == "" {
+		b.WriteString("\nNo context is selected for this session, kubectl commands use the current context. Run use_kube_context to select one.\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubecontext
+
+import (
+	"strings"
+	"testing"
+)
+
+func testKubeconfig() *kubeconfig {
+	kc := &kubeconfig{
+		CurrentContext: "gke_my-project_us-central1_prod",
+		Contexts:       make([]namedContext, 2),
+	}
+	kc.Contexts[0].Name = "gke_my-project_us-central1_prod"
+	kc.Contexts[0].Context.Cluster = "gke_my-project_us-central1_prod"
+	kc.Contexts[1].Name = "staging"
+	kc.Contexts[1].Context.Cluster = "gke_my-project_us-east1_staging"
+	kc.Contexts[1].Context.Namespace = "web"
+	return kc
+}
+
+func TestContextsReport(t *testing.T) {
+	kc := testKubeconfig()
+
+	got := contextsReport(kc, "staging")
+	for _, want := range []string{
+		"- gke_my-project_us-central1_prod (cluster: gke_my-project_us-central1_prod) [current]\n",
+		"- staging (cluster: gke_my-project_us-east1_staging, namespace: web) [selected for this session]\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("contextsReport() = %q, want it to contain %q", got, want)
+		}
+	}
+	if strings.Contains(got, "No context is selected") {
+		t.Errorf("contextsReport() = %q, want no hint to select a context", got)
+	}
+
+	if got := contextsReport(kc, ""); !strings.Contains(got, "Run use_kube_context") {
+		t.Errorf("contextsReport() = %q, want a hint to select a context", got)
+	}
+	if got := contextsReport(&kubeconfig{}, ""); !strings.Contains(got, "No kubeconfig contexts found") {
+		t.Errorf("contextsReport() = %q, want no contexts found", got)
+	}
+	if !hasContext(kc, "staging") || hasContext(kc, "dev") {
+		t.Errorf("hasContext() did not match the kubeconfig contexts")
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubectl
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/output"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// defaultEventsSince is the default age of the oldest events returned.
+	defaultEventsSince = time.Hour
+	// defaultEventsLimit is the default number of event groups returned.
+	defaultEventsLimit = 50
+)
+
+var (
+	eventTypes = []string{"Normal", "Warning"}
+	// kindRegexp matches Kubernetes kinds, e.g. Pod or HorizontalPodAutoscaler.
+	kindRegexp = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+)
+
+type getEventsArgs struct {
+	Namespace          string `json:"namespace,omitempty" jsonschema:"Namespace of the events. Leave this empty for the events of all namespaces."`
+	Type               string `json:"type,omitempty" jsonschema:"Type of the events: Normal or Warning. Leave this empty for both."`
+	InvolvedObjectKind string `json:"involved_object_kind,omitempty" jsonschema:"Kind of the object the events are about, e.g. Pod, Node or Deployment."`
+	InvolvedObjectName string `json:"involved_object_name,omitempty" jsonschema:"Name of the object the events are about."`
+	SinceMinutes       int    `json:"since_minutes,omitempty" jsonschema:"Only return the events last seen in this many past minutes. Defaults to 60."`
+	Limit              int    `json:"limit,omitempty" jsonschema:"Maximum number of deduplicated events to return, the most recent first. Defaults to 50."`
+}
+
+// eventGroup is a set of events with the same object, type, reason and
+// message, e.g. the repeated BackOff events of a pod.
+type eventGroup struct {
+	eventType string
+	object    kube.ObjectReference
+	reason    string
+	message   string
+	source    string
+	count     int
+	firstSeen time.Time
+	lastSeen  time.Time
+}
+
+func getEvents(ctx context.Context, _ *mcp.CallToolRequest, args *getEventsArgs) (*mcp.CallToolResult, any, error) {
+	getArgs, err := eventsArgs(args)
+	if err != nil {
+		return nil, nil, err
+	}
+	since := defaultEventsSince
+	if args.SinceMinutes > 0 {
+		since = time.Duration(args.SinceMinutes) * time.Minute
+	}
+	limit := defaultEventsLimit
+	if args.Limit > 0 {
+		limit = args.Limit
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+	defer cancel()
+	var events kube.List[kube.Event]
+	if err := kube.Get(ctx, &events, getArgs...); err != nil {
+		return nil, nil, err
+	}
+
+	text := eventsReport(events.Items, time.Now().Add(-since), limit)
+	if truncated, ok := output.Truncate(text, maxOutputSize); ok {
+		text = truncated + "\n(output truncated, narrow the query with a namespace, type or involved object, or return fewer events with limit)"
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: text},
+		},
+	}, nil, nil
+}
+
+// eventsArgs validates the arguments and returns the kubectl get arguments
+// listing the events, filtered on the server with a field selector.
+func eventsArgs(args *getEventsArgs) ([]string, error) {
+	if args.SinceMinutes < 0 {
+		return nil, fmt.Errorf("since_minutes must not be negative")
+	}
+	if args.Limit < 0 {
+		return nil, fmt.Errorf("limit must not be negative")
+	}
+
+	getArgs := []string{"events"}
+	if args.Namespace == "" {
+		getArgs = append(getArgs, "--all-namespaces")
+	} else {
+		if !nameRegexp.MatchString(args.Namespace) {
+			return nil, fmt.Errorf("invalid namespace %q", args.Namespace)
+		}
+		getArgs = append(getArgs, "--namespace="+args.Namespace)
+	}
+
+	var fields []string
+	if args.Type != "" {
+		if !slices.Contains(eventTypes, args.Type) {
+			return nil, fmt.Errorf("type %q is not allowed, use one of: %s", args.Type, strings.Join(eventTypes, ", "))
+		}
+		fields = append(fields, "type="+args.Type)
+	}
+	if args.InvolvedObjectKind != "" {
+		if !kindRegexp.MatchString(args.InvolvedObjectKind) {
+			return nil, fmt.Errorf("invalid involved object kind %q", args.InvolvedObjectKind)
+		}
+		fields = append(fields, "involvedObject.kind="+args.InvolvedObjectKind)
+	}
+	if args.InvolvedObjectName != "" {
+		if !nameRegexp.MatchString(args.InvolvedObjectName) {
+			return nil, fmt.Errorf("invalid involved object name %q", args.InvolvedObjectName)
+		}
+		fields = append(fields, "involvedObject.name="+args.InvolvedObjectName)
+	}
+	if len(fields) > 0 {
+		getArgs = append(getArgs, "--field-selector="+strings.Join(fields, ","))
+	}
+	return append(getArgs, fmt.Sprintf("--request-timeout=%s", commandTimeout)), nil
+}
+
+// eventCount returns the number of occurrences of the event.
+func eventCount(event kube.Event) int {
+	switch {
+	case event.Series != nil && event.Series.Count > 0:
+		return int(event.Series.Count)
+	case event.Count > 0:
+		return int(event.Count)
+	default:
+		return 1
+	}
+}
+
+// eventTimes returns when the event was first and last seen, from the
+// timestamps set by the reporting component.
+func eventTimes(event kube.Event) (first, last time.Time) {
+	first = event.FirstTimestamp.Time
+	if first.IsZero() {
+		first = event.EventTime.Time
+	}
+	if first.IsZero() {
+		first = event.CreationTimestamp.Time
+	}
+	last = event.LastTimestamp.Time
+	if event.Series != nil && event.Series.LastObservedTime.After(last) {
+		last = event.Series.LastObservedTime.Time
+	}
+	if last.IsZero() {
+		last = first
+	}
+	return first, last
+}
+
+// groupEvents deduplicates the events last seen after since, the most
+// recently seen first.
+func groupEvents(events []kube.Event, since time.Time) []*eventGroup {
+	type key struct {
+		eventType string
+		object    kube.ObjectReference
+		reason    string
+		message   string
+	}
+	groups := map[key]*eventGroup{}
+	for _, event := range events {
+		first, last := eventTimes(event)
+		if last.Before(since) {
+			continue
+		}
+		object := event.InvolvedObject
+		object.FieldPath = ""
+		if object.Namespace == "" {
+			object.Namespace = event.Namespace
+		}
+		k := key{eventType: event.Type, object: object, reason: event.Reason, message: event.Message}
+		g := groups[k]
+		if g == nil {
+			source := event.Source.Component
+			if source == "" {
+				source = event.ReportingComponent
+			}
+			g = &eventGroup{eventType: event.Type, object: object, reason: event.Reason, message: event.Message, source: source, firstSeen: first, lastSeen: last}
+			groups[k] = g
+		}
+		g.count += eventCount(event)
+		if first.Before(g.firstSeen) {
+			g.firstSeen = first
+		}
+		if last.After(g.lastSeen) {
+			g.lastSeen = last
+		}
+	}
+
+	sorted := make([]*eventGroup, 0, len(groups))
+	for _, g := range groups {
+		sorted = append(sorted, g)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].lastSeen.Equal(sorted[j].lastSeen) {
+			return sorted[i].lastSeen.After(sorted[j].lastSeen)
+		}
+		return sorted[i].count > sorted[j].count
+	})
+	return sorted
+}
+
+// eventsReport lists the deduplicated events last seen after since, with
+// their counts, and a summary of the Warning reasons.
+func eventsReport(events []kube.Event, since time.Time, limit int) string {
+	groups := groupEvents(events, since)
+	if len(groups) == 0 {
+		return fmt.Sprintf("No events since %s.", since.UTC().Format(time.RFC3339))
+	}
+
+	total, warnings := 0, map[string]int{}
+	for _, g := range groups {
+		total += g.count
+		if g.eventType == "Warning" {
+			warnings[g.reason] += g.count
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Events since %s: %d occurrences of %d distinct events\n", since.UTC().Format(time.RFC3339), total, len(groups))
+	if len(warnings) > 0 {
+		reasons := make([]string, 0, len(warnings))
+		for reason := range warnings {
+			reasons = append(reasons, reason)
+		}
+		sort.Slice(reasons, func(i, j int) bool {
+			if warnings[reasons[i]] != warnings[reasons[j]] {
+				return warnings[reasons[i]] > warnings[reasons[j]]
+			}
+			return reasons[i] < reasons[j]
+		})
+		parts := make([]string, len(reasons))
+		for i, reason := range reasons {
+			parts[i] = fmt.Sprintf("%s (%d)", reason, warnings[reason])
+		}
+		fmt.Fprintf(&b, "Warning reasons: %s\n", strings.Join(parts, ", "))
+	}
+
+	b.WriteString("\n")
+	for _, g := range groups[:min(limit, len(groups))] {
+		object := g.object.Kind + " " + g.object.Name
+		if g.object.Namespace != "" {
+			object = g.object.Kind + " " + g.object.Namespace + "/" + g.object.Name
+		}
+		fmt.Fprintf(&b, "- %s %s %s: %s (x%d, first seen %s, last seen %s", g.eventType, g.reason, object, g.message, g.count,
+			g.firstSeen.UTC().Format(time.RFC3339), g.lastSeen.UTC().Format(time.RFC3339))
+		if g.source != "" {
+			fmt.Fprintf(&b, ", from %s", g.source)
+		}
+		b.WriteString(")\n")
+	}
+	if len(groups) > limit {
+		fmt.Fprintf(&b, "\n(%d more distinct events, narrow the query with a namespace, type or involved object, or raise limit)\n", len(groups)-limit)
+	}
+	return b.String()
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubectl
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEventsArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    getEventsArgs
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "all namespaces",
+			args: getEventsArgs{},
+			want: []string{"events", "--all-namespaces", "--request-timeout=30s"},
+		},
+		{
+			name: "warnings of a pod",
+			args: getEventsArgs{Namespace: "default", Type: "Warning", InvolvedObjectKind: "Pod", InvolvedObjectName: "web-1"},
+			want: []string{"events", "--namespace=default", "--field-selector=type=Warning,involvedObject.kind=Pod,involvedObject.name=web-1", "--request-timeout=30s"},
+		},
+		{name: "invalid type", args: getEventsArgs{Type: "Error"}, wantErr: true},
+		{name: "invalid namespace", args: getEventsArgs{Namespace: "a b"}, wantErr: true},
+		{name: "selector injection", args: getEventsArgs{InvolvedObjectName: "web,type=Normal"}, wantErr: true},
+		{name: "flag as kind", args: getEventsArgs{InvolvedObjectKind: "--raw"}, wantErr: true},
+		{name: "negative since", args: getEventsArgs{SinceMinutes: -1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := eventsArgs(&tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("eventsArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("eventsArgs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventsReport(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	at := func(ago time.Duration) metav1.Time { return metav1.NewTime(now.Add(-ago)) }
+	pod := kube.ObjectReference{Kind: "Pod", Namespace: "default", Name: "web-1"}
+	events := []kube.Event{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-1.a"}, InvolvedObject: pod,
+			Type: "Warning", Reason: "BackOff", Message: "Back-off restarting failed container", Count: 5,
+			FirstTimestamp: at(30 * time.Minute), LastTimestamp: at(10 * time.Minute), Source: kube.EventSource{Component: "kubelet"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-1.b"}, InvolvedObject: pod,
+			Type: "Warning", Reason: "BackOff", Message: "Back-off restarting failed container",
+			EventTime: metav1.NewMicroTime(now.Add(-5 * time.Minute)),
+			Series:    &kube.EventSeries{Count: 3, LastObservedTime: metav1.NewMicroTime(now.Add(-time.Minute))},
+		},
+		{
+			ObjectMeta:     metav1.ObjectMeta{Namespace: "default", Name: "web-1.c"},
+			InvolvedObject: kube.ObjectReference{Kind: "Pod", Name: "web-2"},
+			Type:           "Normal", Reason: "Pulled", Message: "Container image pulled", Count: 1,
+			FirstTimestamp: at(20 * time.Minute), LastTimestamp: at(20 * time.Minute),
+		},
+		{
+			ObjectMeta:     metav1.ObjectMeta{Namespace: "default", Name: "old"},
+			InvolvedObject: pod, Type: "Warning", Reason: "Unhealthy", Message: "Readiness probe failed",
+			FirstTimestamp: at(3 * time.Hour), LastTimestamp: at(2 * time.Hour),
+		},
+	}
+
+	report := eventsReport(events, now.Add(-time.Hour), 50)
+	for _, want := range []string{
+		"9 occurrences of 2 distinct events",
+		"Warning reasons: BackOff (8)",
+		"- Warning BackOff Pod default/web-1: Back-off restarting failed container (x8, first seen 2025-06-01T11:30:00Z, last seen 2025-06-01T11:59:00Z, from kubelet)",
+		"- Normal Pulled Pod default/web-2",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("eventsReport() missing %q, got:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "Unhealthy") {
+		t.Errorf("eventsReport() lists an event older than since, got:\n%s", report)
+	}
+	if strings.Index(report, "BackOff Pod") > strings.Index(report, "Pulled Pod") {
+		t.Errorf("eventsReport() doesn't list the most recent event first, got:\n%s", report)
+	}
+
+	if got := eventsReport(events, now.Add(-time.Hour), 1); !strings.Contains(got, "(1 more distinct events") {
+		t.Errorf("eventsReport() with limit 1 doesn't report the omitted events, got:\n%s", got)
+	}
+	if got := eventsReport(nil, now, 50); !strings.HasPrefix(got, "No events since") {
+		t.Errorf("eventsReport() without events = %q", got)
+	}
+}
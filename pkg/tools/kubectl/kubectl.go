@@ -0,0 +1,216 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kubectl provides MCP tools running read-only kubectl commands
+// against the cluster of the current kubectl context.
+package kubectl
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/output"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/pagination"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// maxOutputSize bounds the size of the returned kubectl output, in bytes.
+	maxOutputSize = 64 * 1024
+	// commandTimeout bounds the runtime of a kubectl command.
+	commandTimeout = 30 * time.Second
+)
+
+var (
+	allowedVerbs   = []string{"get", "describe", "api-resources", "version"}
+	allowedOutputs = []string{"wide", "yaml", "json", "name"}
+
+	// allowedResources are the resources which can be read. Secrets and
+	// ConfigMaps are left out as they commonly hold credentials.
+	allowedResources = []string{
+		"apiservices", "certificatesigningrequests", "clusterrolebindings", "clusterroles", "cronjobs", "csidrivers",
+		"csinodes", "customresourcedefinitions", "daemonsets", "deployments", "endpoints", "endpointslices", "events",
+		"gateways", "horizontalpodautoscalers", "httproutes", "ingressclasses", "ingresses", "jobs", "leases",
+		"limitranges", "mutatingwebhookconfigurations", "namespaces", "networkpolicies", "nodes", "persistentvolumeclaims",
+		"persistentvolumes", "poddisruptionbudgets", "pods", "priorityclasses", "replicasets", "resourcequotas",
+		"rolebindings", "roles", "runtimeclasses", "serviceaccounts", "services", "statefulsets", "storageclasses",
+		"validatingwebhookconfigurations", "verticalpodautoscalers", "volumeattachments",
+	}
+
+	// shortNames maps the kubectl short names of the allowed resources to their plural names.
+	shortNames = map[string]string{
+		"cj": "cronjobs", "crd": "customresourcedefinitions", "crds": "customresourcedefinitions", "csr": "certificatesigningrequests",
+		"deploy": "deployments", "ds": "daemonsets", "ep": "endpoints", "ev": "events", "hpa": "horizontalpodautoscalers",
+		"ing": "ingresses", "limits": "limitranges", "netpol": "networkpolicies", "no": "nodes", "ns": "namespaces",
+		"pc": "priorityclasses", "pdb": "poddisruptionbudgets", "po": "pods", "pv": "persistentvolumes",
+		"pvc": "persistentvolumeclaims", "quota": "resourcequotas", "rs": "replicasets", "sa": "serviceaccounts",
+		"sc": "storageclasses", "sts": "statefulsets", "svc": "services", "vpa": "verticalpodautoscalers",
+	}
+
+	// resourceRegexp matches a resource type, optionally qualified with its API group, e.g. deployments.apps.
+	resourceRegexp = regexp.MustCompile(`^[a-z0-9]+(\.[a-z0-9-]+)*$`)
+	// nameRegexp matches Kubernetes object and namespace names.
+	nameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9.:]*[a-z0-9])?$`)
+	// selectorRegexp matches label selectors, e.g. app=web,tier!=db or env in (prod,staging).
+	selectorRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/=!,() -]*$`)
+)
+
+type kubectlGetArgs struct {
+	Verb          string `json:"verb" jsonschema:"The kubectl command to run: get, describe, api-resources or version."`
+	Resource      string `json:"resource,omitempty" jsonschema:"Resource type for get and describe, e.g. pods, deployments or nodes. Secrets and ConfigMaps cannot be read."`
+	Name          string `json:"name,omitempty" jsonschema:"Name of the object. Leave this empty to list all objects of the resource type."`
+	Namespace     string `json:"namespace,omitempty" jsonschema:"Namespace of the objects. Leave this empty for the namespace of the current kubectl context."`
+	AllNamespaces bool   `json:"all_namespaces,omitempty" jsonschema:"List the objects of all namespaces."`
+	LabelSelector string `json:"label_selector,omitempty" jsonschema:"Label selector filtering the objects, e.g. app=web."`
+	Output        string `json:"output,omitempty" jsonschema:"Output format of get: wide, yaml, json or name. Leave this empty for the default table."`
+	PageSize      int    `json:"page_size,omitempty" jsonschema:"Maximum number of objects to list with get in the default table, wide or name output. Leave this empty to list all objects."`
+	PageToken     string `json:"page_token,omitempty" jsonschema:"Token of the page of objects to list, from the previous call with the same arguments. Leave this empty for the first page."`
+}
+
+// Install registers the read-only kubectl tools with the MCP server.
+func Install(_ context.Context, s *mcp.Server, _ *config.Config) error {
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "kubectl_get",
+		Description: "Run a read-only kubectl command (get, describe, api-resources or version) against the cluster of the current kubectl context, or of the context selected with use_kube_context. Only an allowlist of resource types can be read, Secrets and ConfigMaps are excluded, and the output is size-bounded. Prefer this tool over running kubectl in a shell, and run get_kubeconfig for the cluster first.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, kubectlGet)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "get_events",
+		Description: "Get the recent events of the cluster of the current kubectl context, optionally filtered by namespace, type (Normal or Warning) and involved object. Repeated events are deduplicated with their total count and first and last seen times, the most recent first, with a summary of the Warning reasons. Start troubleshooting with the Warning events, and run get_kubeconfig for the cluster first.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, getEvents)
+
+	return nil
+}
+
+func kubectlGet(ctx context.Context, _ *mcp.CallToolRequest, args *kubectlGetArgs) (*mcp.CallToolResult, any, error) {
+	kubectlArgs, err := buildArgs(args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+	defer cancel()
+	out, err := kube.Run(ctx, kubectlArgs...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	text := string(out)
+	if strings.TrimSpace(text) == "" {
+		text = "No resources found."
+	} else if args.PageSize > 0 || args.PageToken != "" {
+		if text, err = pageLines(text, args.Output != "name", args.PageToken, args.PageSize); err != nil {
+			return nil, nil, err
+		}
+	}
+	if truncated, ok := output.Truncate(text, maxOutputSize); ok {
+		text = truncated + "\n(output truncated, narrow the query with a name, namespace or label selector, or list fewer objects with page_size)"
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: text},
+		},
+	}, nil, nil
+}
+
+// pageLines returns the page of the lines of kubectl list output, one per
+// object, repeating the header line of tables on every page.
+func pageLines(text string, hasHeader bool, pageToken string, pageSize int) (string, error) {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	header := ""
+	if hasHeader {
+		header, lines = lines[0]+"\n", lines[1:]
+	}
+	page, next, err := pagination.Page(lines, pageToken, pageSize)
+	if err != nil {
+		return "", err
+	}
+	return header + strings.Join(page, "\n") + "\n" + pagination.Footer(len(page), len(lines), next), nil
+}
+
+// resourceName returns the plural name of the resource type, without its API group.
+func resourceName(resource string) string {
+	name, _, _ := strings.Cut(resource, ".")
+	if plural, ok := shortNames[name]; ok {
+		return plural
+	}
+	return name
+}
+
+// buildArgs validates the arguments against the allowlists and returns the kubectl arguments.
+func buildArgs(args *kubectlGetArgs) ([]string, error) {
+	verb := strings.TrimSpace(args.Verb)
+	if !slices.Contains(allowedVerbs, verb) {
+		return nil, fmt.Errorf("verb %q is not allowed, use one of: %s", verb, strings.Join(allowedVerbs, ", "))
+	}
+	switch verb {
+	case "version":
+		return []string{"version", "--output=yaml"}, nil
+	case "api-resources":
+		return []string{"api-resources"}, nil
+	}
+
+	resource := strings.ToLower(strings.TrimSpace(args.Resource))
+	if resource == "" {
+		return nil, fmt.Errorf("resource argument cannot be empty")
+	}
+	if !resourceRegexp.MatchString(resource) || !slices.Contains(allowedResources, resourceName(resource)) {
+		return nil, fmt.Errorf("resource %q is not allowed", args.Resource)
+	}
+	kubectlArgs := []string{verb, resource}
+
+	if args.Name != "" {
+		if !nameRegexp.MatchString(args.Name) {
+			return nil, fmt.Errorf("invalid name: %s", args.Name)
+		}
+		kubectlArgs = append(kubectlArgs, args.Name)
+	}
+	if args.AllNamespaces {
+		kubectlArgs = append(kubectlArgs, "--all-namespaces")
+	} else if args.Namespace != "" {
+		if !nameRegexp.MatchString(args.Namespace) {
+			return nil, fmt.Errorf("invalid namespace: %s", args.Namespace)
+		}
+		kubectlArgs = append(kubectlArgs, "--namespace="+args.Namespace)
+	}
+	if args.LabelSelector != "" {
+		if !selectorRegexp.MatchString(args.LabelSelector) {
+			return nil, fmt.Errorf("invalid label selector: %s", args.LabelSelector)
+		}
+		kubectlArgs = append(kubectlArgs, "--selector="+args.LabelSelector)
+	}
+	if (args.PageSize > 0 || args.PageToken != "") && (verb != "get" || args.Name != "" || args.Output == "yaml" || args.Output == "json") {
+		return nil, fmt.Errorf("page_size and page_token are only supported to list objects with get in the table, wide or name output")
+	}
+	if args.Output != "" {
+		if verb != "get" || !slices.Contains(allowedOutputs, args.Output) {
+			return nil, fmt.Errorf("output %q is not allowed, use one of: %s with get", args.Output, strings.Join(allowedOutputs, ", "))
+		}
+		kubectlArgs = append(kubectlArgs, "--output="+args.Output)
+	}
+	return append(kubectlArgs, fmt.Sprintf("--request-timeout=%s", commandTimeout)), nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubectl
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBuildArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    kubectlGetArgs
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "get pods in namespace",
+			args: kubectlGetArgs{Verb: "get", Resource: "pods", Namespace: "kube-system", LabelSelector: "k8s-app=kube-dns", Output: "wide"},
+			want: []string{"get", "pods", "--namespace=kube-system", "--selector=k8s-app=kube-dns", "--output=wide", "--request-timeout=30s"},
+		},
+		{
+			name: "describe node",
+			args: kubectlGetArgs{Verb: "describe", Resource: "no", Name: "gke-pool-1-abcd"},
+			want: []string{"describe", "no", "gke-pool-1-abcd", "--request-timeout=30s"},
+		},
+		{
+			name: "all namespaces with API group",
+			args: kubectlGetArgs{Verb: "get", Resource: "Deployments.apps", AllNamespaces: true, Namespace: "ignored"},
+			want: []string{"get", "deployments.apps", "--all-namespaces", "--request-timeout=30s"},
+		},
+		{
+			name: "version",
+			args: kubectlGetArgs{Verb: "version", Resource: "pods"},
+			want: []string{"version", "--output=yaml"},
+		},
+		{
+			name: "api-resources",
+			args: kubectlGetArgs{Verb: "api-resources"},
+			want: []string{"api-resources"},
+		},
+		{name: "mutating verb", args: kubectlGetArgs{Verb: "delete", Resource: "pods"}, wantErr: true},
+		{name: "exec", args: kubectlGetArgs{Verb: "exec", Resource: "pods"}, wantErr: true},
+		{name: "secrets", args: kubectlGetArgs{Verb: "get", Resource: "secrets"}, wantErr: true},
+		{name: "secrets with group", args: kubectlGetArgs{Verb: "get", Resource: "secrets.v1"}, wantErr: true},
+		{name: "configmaps", args: kubectlGetArgs{Verb: "describe", Resource: "cm"}, wantErr: true},
+		{name: "missing resource", args: kubectlGetArgs{Verb: "get"}, wantErr: true},
+		{name: "flag as name", args: kubectlGetArgs{Verb: "get", Resource: "pods", Name: "--kubeconfig=/tmp/x"}, wantErr: true},
+		{name: "flag as resource", args: kubectlGetArgs{Verb: "get", Resource: "--raw=/api"}, wantErr: true},
+		{name: "invalid namespace", args: kubectlGetArgs{Verb: "get", Resource: "pods", Namespace: "a b"}, wantErr: true},
+		{name: "invalid selector", args: kubectlGetArgs{Verb: "get", Resource: "pods", LabelSelector: "-o=json"}, wantErr: true},
+		{name: "output with describe", args: kubectlGetArgs{Verb: "describe", Resource: "pods", Output: "yaml"}, wantErr: true},
+		{name: "jsonpath output", args: kubectlGetArgs{Verb: "get", Resource: "pods", Output: "jsonpath={.items}"}, wantErr: true},
+		{name: "page of json output", args: kubectlGetArgs{Verb: "get", Resource: "pods", Output: "json", PageSize: 10}, wantErr: true},
+		{name: "page of describe", args: kubectlGetArgs{Verb: "describe", Resource: "pods", PageSize: 10}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildArgs(&tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("buildArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("buildArgs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPageLines(t *testing.T) {
+	text := "NAME   READY\nweb-1  1/1\nweb-2  1/1\nweb-3  0/1\n"
+	first, err := pageLines(text, true, "", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(first, "NAME   READY\nweb-1  1/1\nweb-2  1/1\n") || !strings.Contains(first, "Showing 2 of 3 results") {
+		t.Fatalf("pageLines() = %q, want the header and the first two objects", first)
+	}
+	token := first[strings.Index(first, `page_token "`)+len(`page_token "`):]
+	token = token[:strings.Index(token, `"`)]
+	second, err := pageLines(text, true, token, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second != "NAME   READY\nweb-3  0/1\n" {
+		t.Errorf("pageLines() = %q, want the header and the last object", second)
+	}
+
+	if got, err := pageLines("pod/web-1\npod/web-2\n", false, "", 1); err != nil || !strings.HasPrefix(got, "pod/web-1\n\n") {
+		t.Errorf("pageLines() without header = %q, %v, want the first object", got, err)
+	}
+}
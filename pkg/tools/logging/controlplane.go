@@ -0,0 +1,184 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// defaultControlPlaneSince is the default time window of the control
+	// plane logs.
+	defaultControlPlaneSince = "1h"
+	// defaultControlPlaneSeverity is the default minimum severity of the
+	// control plane logs.
+	defaultControlPlaneSeverity = "WARNING"
+	defaultControlPlaneLimit    = 50
+)
+
+var (
+	// controlPlaneComponents are the control plane components whose logs GKE
+	// exports, named as in their log names.
+	controlPlaneComponents = []string{"apiserver", "scheduler", "controller-manager"}
+	severities             = []string{"DEFAULT", "DEBUG", "INFO", "NOTICE", "WARNING", "ERROR", "CRITICAL", "ALERT", "EMERGENCY"}
+	// resourceLabelRegexp matches the project, location and cluster names
+	// inserted in the log filter.
+	resourceLabelRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9-:.]*$`)
+)
+
+type getControlPlaneLogsArgs struct {
+	ProjectID   string    `json:"project_id,omitempty" jsonschema:"GCP project ID of the cluster. Use the default if the user doesn't provide it."`
+	Location    string    `json:"location,omitempty" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name        string    `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+	Components  []string  `json:"components,omitempty" jsonschema:"Control plane components to return the logs of: apiserver, scheduler or controller-manager. Defaults to all of them."`
+	MinSeverity string    `json:"min_severity,omitempty" jsonschema:"Minimum severity of the log entries, e.g. INFO, WARNING or ERROR. Defaults to WARNING."`
+	Since       string    `json:"since,omitempty" jsonschema:"Only return logs newer than a relative duration like 30m or 3h. Defaults to 1h."`
+	TimeRange   TimeRange `json:"time_range,omitempty" jsonschema:"Time range of the logs, e.g. the window of an upgrade or an incident. Cannot be used with since."`
+	Limit       int       `json:"limit,omitempty" jsonschema:"Maximum number of log entries to return, i.e. the page size. Cannot be greater than 100. Defaults to 50."`
+	PageToken   string    `json:"page_token,omitempty" jsonschema:"Token of the page of log entries to return, from the previous call with the same arguments. Use time_range rather than since to iterate through pages. Leave this empty for the first page."`
+}
+
+func installGetControlPlaneLogsTool(s *mcp.Server, conf *config.Config) {
+	t := newQueryLogsTool(conf)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "get_control_plane_logs",
+		Description: "Get the recent logs of the control plane components of a GKE cluster (kube-apiserver, kube-scheduler and kube-controller-manager) from Cloud Logging, filtered by component, minimum severity and time window, oldest first. Use it to verify a control plane upgrade or to triage API errors, scheduling failures and reconciliation issues. Requires control plane logs to be enabled in the logging configuration of the cluster.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, t.getControlPlaneLogs)
+}
+
+func (t *queryLogsTool) getControlPlaneLogs(ctx context.Context, _ *mcp.CallToolRequest, args *getControlPlaneLogsArgs) (*mcp.CallToolResult, any, error) {
+	if args.ProjectID == "" {
+		args.ProjectID = t.conf.DefaultProjectID(ctx)
+	}
+	if args.Location == "" {
+		args.Location = t.conf.DefaultLocation(ctx)
+	}
+	if args.Name == "" {
+		args.Name = t.conf.DefaultCluster(ctx)
+	}
+	req, err := controlPlaneLogsRequest(args)
+	if err != nil {
+		return nil, nil, err
+	}
+	result, err := t.listLogEntries(ctx, req, &controlPlaneFormatter{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: result},
+		},
+	}, nil, nil
+}
+
+// controlPlaneLogsRequest validates the arguments and returns the log query
+// of the control plane logs of the cluster.
+func controlPlaneLogsRequest(args *getControlPlaneLogsArgs) (*LogQueryRequest, error) {
+	if args.ProjectID == "" {
+		return nil, fmt.Errorf("project_id argument cannot be empty")
+	}
+	if args.Name == "" {
+		return nil, fmt.Errorf("name argument cannot be empty")
+	}
+	for _, label := range []string{args.ProjectID, args.Location, args.Name} {
+		if label != "" && !resourceLabelRegexp.MatchString(label) {
+			return nil, fmt.Errorf("invalid project, location or cluster name %q", label)
+		}
+	}
+	components := args.Components
+	if len(components) == 0 {
+		components = controlPlaneComponents
+	}
+	logNames := make([]string, 0, len(components))
+	for _, component := range components {
+		component = strings.TrimPrefix(component, "kube-")
+		if !slices.Contains(controlPlaneComponents, component) {
+			return nil, fmt.Errorf("component %q is not supported, use one of: %s", component, strings.Join(controlPlaneComponents, ", "))
+		}
+		logNames = append(logNames, fmt.Sprintf("%q", fmt.Sprintf("projects/%s/logs/container.googleapis.com%%2F%s", args.ProjectID, component)))
+	}
+	severity := strings.ToUpper(args.MinSeverity)
+	if severity == "" {
+		severity = defaultControlPlaneSeverity
+	}
+	if !slices.Contains(severities, severity) {
+		return nil, fmt.Errorf("min_severity %q is not supported, use one of: %s", args.MinSeverity, strings.Join(severities, ", "))
+	}
+
+	filter := []string{
+		`resource.type="k8s_control_plane_component"`,
+		fmt.Sprintf("logName=(%s)", strings.Join(logNames, " OR ")),
+		fmt.Sprintf("resource.labels.cluster_name=%q", args.Name),
+	}
+	if args.Location != "" {
+		filter = append(filter, fmt.Sprintf("resource.labels.location=%q", args.Location))
+	}
+	filter = append(filter, "severity>="+severity)
+
+	req := &LogQueryRequest{
+		Query:     strings.Join(filter, "\n"),
+		ProjectID: args.ProjectID,
+		TimeRange: args.TimeRange,
+		Since:     args.Since,
+		Limit:     args.Limit,
+		PageToken: args.PageToken,
+	}
+	if req.Since == "" && req.TimeRange == (TimeRange{}) {
+		req.Since = defaultControlPlaneSince
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultControlPlaneLimit
+	}
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// controlPlaneFormatter formats control plane log entries on a single line
+// with their timestamp, severity and component.
+type controlPlaneFormatter struct{}
+
+func (f *controlPlaneFormatter) format(entry *loggingpb.LogEntry) (string, error) {
+	message := entry.GetTextPayload()
+	if payload := entry.GetJsonPayload(); payload != nil {
+		if m, ok := payload.GetFields()["message"]; ok {
+			message = m.GetStringValue()
+		} else {
+			b, err := json.Marshal(payload.AsMap())
+			if err != nil {
+				return "", fmt.Errorf("could not marshal log entry payload to JSON: %w", err)
+			}
+			message = string(b)
+		}
+	}
+	return fmt.Sprintf("%s [%s] %s: %s", entry.GetTimestamp().AsTime().UTC().Format(time.RFC3339Nano), entry.GetSeverity(),
+		entry.GetResource().GetLabels()["component_name"], strings.TrimSpace(message)), nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"google.golang.org/genproto/googleapis/api/monitoredres"
+	ltype "google.golang.org/genproto/googleapis/logging/type"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestControlPlaneLogsRequest(t *testing.T) {
+	req, err := controlPlaneLogsRequest(&getControlPlaneLogsArgs{
+		ProjectID:   "my-project",
+		Location:    "us-central1",
+		Name:        "my-cluster",
+		Components:  []string{"kube-apiserver", "scheduler"},
+		MinSeverity: "error",
+	})
+	if err != nil {
+		t.Fatalf("controlPlaneLogsRequest() error = %v", err)
+	}
+	want := `resource.type="k8s_control_plane_component"
+logName=("projects/my-project/logs/container.googleapis.com%2Fapiserver" OR "projects/my-project/logs/container.googleapis.com%2Fscheduler")
+resource.labels.cluster_name="my-cluster"
+resource.labels.location="us-central1"
+severity>=ERROR`
+	if req.Query != want {
+		t.Errorf("controlPlaneLogsRequest() query = %s, want %s", req.Query, want)
+	}
+	if req.Since != "1h" || req.Limit != 50 {
+		t.Errorf("controlPlaneLogsRequest() since = %q, limit = %d, want 1h and 50", req.Since, req.Limit)
+	}
+
+	req, err = controlPlaneLogsRequest(&getControlPlaneLogsArgs{
+		ProjectID: "my-project",
+		Name:      "my-cluster",
+		TimeRange: TimeRange{StartTime: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+	})
+	if err != nil {
+		t.Fatalf("controlPlaneLogsRequest() error = %v", err)
+	}
+	if !strings.Contains(req.Query, "container.googleapis.com%2Fcontroller-manager") || !strings.Contains(req.Query, "severity>=WARNING") {
+		t.Errorf("controlPlaneLogsRequest() query = %s, want all components and WARNING severity", req.Query)
+	}
+	if strings.Contains(req.Query, "resource.labels.location") {
+		t.Errorf("controlPlaneLogsRequest() query = %s, want no location filter", req.Query)
+	}
+	if req.Since != "" {
+		t.Errorf("controlPlaneLogsRequest() since = %q with a time range, want none", req.Since)
+	}
+}
+
+func TestControlPlaneLogsRequest_Invalid(t *testing.T) {
+	tests := map[string]getControlPlaneLogsArgs{
+		"no project":         {Name: "my-cluster"},
+		"no cluster":         {ProjectID: "my-project"},
+		"filter injection":   {ProjectID: "my-project", Name: `my-cluster" OR severity>=DEFAULT OR "`},
+		"unknown component":  {ProjectID: "my-project", Name: "my-cluster", Components: []string{"etcd"}},
+		"unknown severity":   {ProjectID: "my-project", Name: "my-cluster", MinSeverity: "FATAL"},
+		"limit too high":     {ProjectID: "my-project", Name: "my-cluster", Limit: 101},
+		"since and a range":  {ProjectID: "my-project", Name: "my-cluster", Since: "1h", TimeRange: TimeRange{StartTime: time.Now()}},
+		"invalid since unit": {ProjectID: "my-project", Name: "my-cluster", Since: "1d"},
+	}
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := controlPlaneLogsRequest(&args); err == nil {
+				t.Error("controlPlaneLogsRequest() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestControlPlaneFormatter(t *testing.T) {
+	ts := timestamppb.New(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+	resource := &monitoredres.MonitoredResource{Labels: map[string]string{"component_name": "apiserver"}}
+	tests := []struct {
+		name  string
+		entry *loggingpb.LogEntry
+		want  string
+	}{
+		{
+			name:  "text payload",
+			entry: &loggingpb.LogEntry{Timestamp: ts, Severity: ltype.LogSeverity_ERROR, Resource: resource, Payload: &loggingpb.LogEntry_TextPayload{TextPayload: "etcd timeout\n"}},
+			want:  "2025-06-01T12:00:00Z [ERROR] apiserver: etcd timeout",
+		},
+		{
+			name: "json payload with message",
+			entry: &loggingpb.LogEntry{Timestamp: ts, Severity: ltype.LogSeverity_WARNING, Resource: resource, Payload: &loggingpb.LogEntry_JsonPayload{JsonPayload: &structpb.Struct{
+				Fields: map[string]*structpb.Value{"message": structpb.NewStringValue("slow request")},
+			}}},
+			want: "2025-06-01T12:00:00Z [WARNING] apiserver: slow request",
+		},
+		{
+			name: "json payload without message",
+			entry: &loggingpb.LogEntry{Timestamp: ts, Severity: ltype.LogSeverity_WARNING, Resource: resource, Payload: &loggingpb.LogEntry_JsonPayload{JsonPayload: &structpb.Struct{
+				Fields: map[string]*structpb.Value{"latency": structpb.NewStringValue("5s")},
+			}}},
+			want: `2025-06-01T12:00:00Z [WARNING] apiserver: {"latency":"5s"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (&controlPlaneFormatter{}).format(tt.entry)
+			if err != nil {
+				t.Fatalf("format() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,300 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	logging "cloud.google.com/go/logging/apiv2"
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/iterator"
+	auditpb "google.golang.org/genproto/googleapis/cloud/audit"
+)
+
+const (
+	defaultDeprecatedCallsDays = 30
+	// maxDeprecatedCallsDays is the retention of the _Default log bucket,
+	// older audit logs are only kept in custom buckets.
+	maxDeprecatedCallsDays = 30
+	// maxDeprecatedCallEntries bounds the number of audit log entries read,
+	// as the calls are counted by the server rather than by Cloud Logging.
+	maxDeprecatedCallEntries = 10000
+	// maxCallers is the number of callers listed per API, the most frequent first.
+	maxCallers = 10
+
+	deprecatedLabel     = "k8s.io/deprecated"
+	removedReleaseLabel = "k8s.io/removed-release"
+)
+
+// apiVersionRegexp matches the version of a Kubernetes API, e.g. v1 or v1beta1.
+var apiVersionRegexp = regexp.MustCompile(`^v[0-9]+((alpha|beta)[0-9]+)?$`)
+
+type findDeprecatedAPICallsArgs struct {
+	ProjectID     string `json:"project_id,omitempty" jsonschema:"GCP project ID of the cluster. Use the default if the user doesn't provide it."`
+	Location      string `json:"location,omitempty" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name          string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+	Days          int    `json:"days,omitempty" jsonschema:"The number of past days to count the calls of. Cannot be greater than 30. Defaults to 30."`
+	TargetVersion string `json:"target_version,omitempty" jsonschema:"Only count the calls to APIs removed in this Kubernetes or GKE version or earlier, e.g. 1.32. Leave this empty for all deprecated APIs."`
+}
+
+// deprecatedAPI counts the calls to a deprecated API resource, per caller.
+type deprecatedAPI struct {
+	apiVersion     string
+	resource       string
+	removedRelease string
+	replacement    string
+	count          int
+	callers        map[string]int
+	lastSeen       time.Time
+}
+
+func installFindDeprecatedAPICallsTool(s *mcp.Server, conf *config.Config) {
+	t := newQueryLogsTool(conf)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "find_deprecated_api_calls",
+		Description: "Count the calls to deprecated Kubernetes APIs of a GKE cluster over the past days from its Kubernetes API server audit logs in Cloud Logging, per API group, version and resource, with the Kubernetes release removing them and the user agents and service accounts making the calls. Complements find_deprecated_apis, which only finds applied objects, by naming the clients which would break after an upgrade. Read calls are only logged when Data Access audit logs are enabled.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, t.findDeprecatedAPICalls)
+}
+
+func (t *queryLogsTool) findDeprecatedAPICalls(ctx context.Context, _ *mcp.CallToolRequest, args *findDeprecatedAPICallsArgs) (*mcp.CallToolResult, any, error) {
+	if args.ProjectID == "" {
+		args.ProjectID = t.conf.DefaultProjectID(ctx)
+	}
+	if args.Location == "" {
+		args.Location = t.conf.DefaultLocation(ctx)
+	}
+	if args.Name == "" {
+		args.Name = t.conf.DefaultCluster(ctx)
+	}
+	filter, err := deprecatedCallsFilter(args, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+	targetMinor := 0
+	if args.TargetVersion != "" {
+		if _, targetMinor, err = gke.MinorVersion(args.TargetVersion); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	client, err := logging.NewClient(ctx, t.conf.ClientOptions()...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create logging client: %w", err)
+	}
+	defer func() {
+		if err := client.Close(); err != nil {
+			slog.Warn("Failed to close logging client", "error", err)
+		}
+	}()
+
+	it := client.ListLogEntries(ctx, &loggingpb.ListLogEntriesRequest{
+		ResourceNames: []string{fmt.Sprintf("projects/%s", args.ProjectID)},
+		Filter:        filter,
+		PageSize:      1000,
+		OrderBy:       "timestamp desc",
+	})
+	var entries []*loggingpb.LogEntry
+	truncated := false
+	for {
+		entry, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to iterate log entries: %w", err)
+		}
+		if len(entries) == maxDeprecatedCallEntries {
+			truncated = true
+			break
+		}
+		entries = append(entries, entry)
+	}
+
+	text := fmt.Sprintf("Project ID: %s\nLQL Query:\n```\n%s\n```\n\n%s", args.ProjectID, filter, deprecatedCallsReport(entries, targetMinor, truncated))
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: text},
+		},
+	}, nil, nil
+}
+
+// deprecatedCallsFilter validates the arguments and returns the filter of the
+// audit log entries of the calls to deprecated APIs of the cluster in the
+// past days before now.
+func deprecatedCallsFilter(args *findDeprecatedAPICallsArgs, now time.Time) (string, error) {
+	if args.ProjectID == "" {
+		return "", fmt.Errorf("project_id argument cannot be empty")
+	}
+	if args.Name == "" {
+		return "", fmt.Errorf("name argument cannot be empty")
+	}
+	for _, label := range []string{args.ProjectID, args.Location, args.Name} {
+		if label != "" && !resourceLabelRegexp.MatchString(label) {
+			return "", fmt.Errorf("invalid project, location or cluster name %q", label)
+		}
+	}
+	if args.Days == 0 {
+		args.Days = defaultDeprecatedCallsDays
+	}
+	if args.Days < 1 || args.Days > maxDeprecatedCallsDays {
+		return "", fmt.Errorf("days argument must be between 1 and %d", maxDeprecatedCallsDays)
+	}
+
+	filter := []string{
+		`resource.type="k8s_cluster"`,
+		fmt.Sprintf("resource.labels.cluster_name=%q", args.Name),
+	}
+	if args.Location != "" {
+		filter = append(filter, fmt.Sprintf("resource.labels.location=%q", args.Location))
+	}
+	filter = append(filter,
+		fmt.Sprintf("labels.%q=\"true\"", deprecatedLabel),
+		fmt.Sprintf("timestamp>=%q", now.Add(-time.Duration(args.Days)*24*time.Hour).UTC().Format(time.RFC3339)),
+	)
+	return strings.Join(filter, "\n"), nil
+}
+
+// parseMethodName returns the API version and resource of the method name of
+// a Kubernetes audit log entry, e.g. batch/v1beta1 and cronjobs for
+// io.k8s.batch.v1beta1.cronjobs.list. The core API version is v1.
+func parseMethodName(method string) (apiVersion, resource string, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(method, "io.k8s."), ".")
+	for i, part := range parts {
+		if !apiVersionRegexp.MatchString(part) || i+1 >= len(parts) {
+			continue
+		}
+		group := strings.Join(parts[:i], ".")
+		if group == "" || group == "core" {
+			return part, parts[i+1], true
+		}
+		return group + "/" + part, parts[i+1], true
+	}
+	return "", "", false
+}
+
+// deprecatedCallsReport counts the calls of the audit log entries per API
+// and caller. With a target minor version, the APIs removed after it are left
+// out.
+func deprecatedCallsReport(entries []*loggingpb.LogEntry, targetMinor int, truncated bool) string {
+	apis := map[string]*deprecatedAPI{}
+	for _, entry := range entries {
+		payload := &auditpb.AuditLog{}
+		if err := entry.GetProtoPayload().UnmarshalTo(payload); err != nil {
+			continue
+		}
+		apiVersion, resource, ok := parseMethodName(payload.GetMethodName())
+		if !ok {
+			continue
+		}
+		removedRelease, replacement := entry.GetLabels()[removedReleaseLabel], ""
+		for _, api := range kube.DeprecatedAPIs {
+			if api.APIVersion == apiVersion && api.Resource == resource {
+				replacement = api.Replacement
+				if removedRelease == "" {
+					removedRelease = "1." + strconv.Itoa(api.RemovedIn)
+				}
+			}
+		}
+		if targetMinor > 0 && removedRelease != "" {
+			if _, minor, err := gke.MinorVersion(removedRelease); err == nil && minor > targetMinor {
+				continue
+			}
+		}
+
+		key := apiVersion + " " + resource
+		api := apis[key]
+		if api == nil {
+			api = &deprecatedAPI{apiVersion: apiVersion, resource: resource, removedRelease: removedRelease, replacement: replacement, callers: map[string]int{}}
+			apis[key] = api
+		}
+		caller := payload.GetAuthenticationInfo().GetPrincipalEmail()
+		if caller == "" {
+			caller = "unknown principal"
+		}
+		if userAgent := payload.GetRequestMetadata().GetCallerSuppliedUserAgent(); userAgent != "" {
+			caller += " (" + userAgent + ")"
+		}
+		api.count++
+		api.callers[caller]++
+		if ts := entry.GetTimestamp().AsTime(); ts.After(api.lastSeen) {
+			api.lastSeen = ts
+		}
+	}
+
+	var b strings.Builder
+	if truncated {
+		fmt.Fprintf(&b, "Only the %d most recent calls were counted, shorten the time window with days for exact counts.\n\n", maxDeprecatedCallEntries)
+	}
+	if len(apis) == 0 {
+		b.WriteString("No calls to deprecated APIs found.\n")
+		return b.String()
+	}
+
+	sorted := make([]*deprecatedAPI, 0, len(apis))
+	total := 0
+	for _, api := range apis {
+		sorted = append(sorted, api)
+		total += api.count
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].count != sorted[j].count {
+			return sorted[i].count > sorted[j].count
+		}
+		return sorted[i].apiVersion+sorted[i].resource < sorted[j].apiVersion+sorted[j].resource
+	})
+	fmt.Fprintf(&b, "%d calls to %d deprecated APIs:\n", total, len(sorted))
+	for _, api := range sorted {
+		fmt.Fprintf(&b, "\n- %s %s: %d calls, last seen %s", api.apiVersion, api.resource, api.count, api.lastSeen.UTC().Format(time.RFC3339))
+		if api.removedRelease != "" {
+			fmt.Fprintf(&b, ", removed in %s", api.removedRelease)
+		}
+		if api.replacement != "" {
+			fmt.Fprintf(&b, ", replaced by %s", api.replacement)
+		}
+		b.WriteString("\n")
+		callers := make([]string, 0, len(api.callers))
+		for caller := range api.callers {
+			callers = append(callers, caller)
+		}
+		sort.Slice(callers, func(i, j int) bool {
+			if api.callers[callers[i]] != api.callers[callers[j]] {
+				return api.callers[callers[i]] > api.callers[callers[j]]
+			}
+			return callers[i] < callers[j]
+		})
+		for _, caller := range callers[:min(maxCallers, len(callers))] {
+			fmt.Fprintf(&b, "  - %d calls by %s\n", api.callers[caller], caller)
+		}
+		if len(callers) > maxCallers {
+			fmt.Fprintf(&b, "  - %d more callers\n", len(callers)-maxCallers)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	auditpb "google.golang.org/genproto/googleapis/cloud/audit"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestDeprecatedCallsFilter(t *testing.T) {
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	got, err := deprecatedCallsFilter(&findDeprecatedAPICallsArgs{ProjectID: "my-project", Location: "us-central1", Name: "my-cluster"}, now)
+	if err != nil {
+		t.Fatalf("deprecatedCallsFilter() error = %v", err)
+	}
+	want := `resource.type="k8s_cluster"
+resource.labels.cluster_name="my-cluster"
+resource.labels.location="us-central1"
+labels."k8s.io/deprecated"="true"
+timestamp>="2025-05-31T12:00:00Z"`
+	if got != want {
+		t.Errorf("deprecatedCallsFilter() = %s, want %s", got, want)
+	}
+
+	for name, args := range map[string]findDeprecatedAPICallsArgs{
+		"no project":       {Name: "my-cluster"},
+		"no cluster":       {ProjectID: "my-project"},
+		"filter injection": {ProjectID: "my-project", Name: `x" OR "`},
+		"too many days":    {ProjectID: "my-project", Name: "my-cluster", Days: 31},
+		"negative days":    {ProjectID: "my-project", Name: "my-cluster", Days: -1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := deprecatedCallsFilter(&args, now); err == nil {
+				t.Error("deprecatedCallsFilter() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestParseMethodName(t *testing.T) {
+	tests := []struct {
+		method, apiVersion, resource string
+		ok                           bool
+	}{
+		{"io.k8s.batch.v1beta1.cronjobs.list", "batch/v1beta1", "cronjobs", true},
+		{"io.k8s.networking.k8s.io.v1beta1.ingresses.create", "networking.k8s.io/v1beta1", "ingresses", true},
+		{"io.k8s.core.v1.pods.get", "v1", "pods", true},
+		{"io.k8s.get", "", "", false},
+	}
+	for _, tt := range tests {
+		apiVersion, resource, ok := parseMethodName(tt.method)
+		if apiVersion != tt.apiVersion || resource != tt.resource || ok != tt.ok {
+			t.Errorf("parseMethodName(%q) = %q, %q, %v, want %q, %q, %v", tt.method, apiVersion, resource, ok, tt.apiVersion, tt.resource, tt.ok)
+		}
+	}
+}
+
+func auditEntry(t *testing.T, method, principal, userAgent, removedRelease string, ts time.Time) *loggingpb.LogEntry {
+	t.Helper()
+	payload, err := anypb.New(&auditpb.AuditLog{
+		MethodName:         method,
+		AuthenticationInfo: &auditpb.AuthenticationInfo{PrincipalEmail: principal},
+		RequestMetadata:    &auditpb.RequestMetadata{CallerSuppliedUserAgent: userAgent},
+	})
+	if err != nil {
+		t.Fatalf("anypb.New() error = %v", err)
+	}
+	labels := map[string]string{deprecatedLabel: "true"}
+	if removedRelease != "" {
+		labels[removedReleaseLabel] = removedRelease
+	}
+	return &loggingpb.LogEntry{
+		Timestamp: timestamppb.New(ts),
+		Labels:    labels,
+		Payload:   &loggingpb.LogEntry_ProtoPayload{ProtoPayload: payload},
+	}
+}
+
+func TestDeprecatedCallsReport(t *testing.T) {
+	ts := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	entries := []*loggingpb.LogEntry{
+		auditEntry(t, "io.k8s.batch.v1beta1.cronjobs.list", "system:serviceaccount:ops:cron-sync", "cron-sync/1.0", "1.25", ts),
+		auditEntry(t, "io.k8s.batch.v1beta1.cronjobs.list", "system:serviceaccount:ops:cron-sync", "cron-sync/1.0", "1.25", ts.Add(time.Hour)),
+		auditEntry(t, "io.k8s.batch.v1beta1.cronjobs.get", "dev@example.com", "kubectl/v1.24.0", "1.25", ts),
+		auditEntry(t, "io.k8s.flowcontrol.apiserver.k8s.io.v1beta3.flowschemas.list", "system:kube-controller-manager", "", "", ts),
+	}
+
+	report := deprecatedCallsReport(entries, 0, false)
+	for _, want := range []string{
+		"4 calls to 2 deprecated APIs",
+		"- batch/v1beta1 cronjobs: 3 calls, last seen 2025-06-01T13:00:00Z, removed in 1.25, replaced by batch/v1",
+		"  - 2 calls by system:serviceaccount:ops:cron-sync (cron-sync/1.0)",
+		"  - 1 calls by dev@example.com (kubectl/v1.24.0)",
+		"- flowcontrol.apiserver.k8s.io/v1beta3 flowschemas: 1 calls",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("deprecatedCallsReport() missing %q, got:\n%s", want, report)
+		}
+	}
+
+	if report := deprecatedCallsReport(entries, 24, false); strings.Contains(report, "cronjobs") {
+		t.Errorf("deprecatedCallsReport() with target 1.24 lists an API removed in 1.25, got:\n%s", report)
+	}
+	if report := deprecatedCallsReport(nil, 0, true); !strings.Contains(report, "Only the 10000 most recent calls") || !strings.Contains(report, "No calls to deprecated APIs found.") {
+		t.Errorf("deprecatedCallsReport() without entries = %q", report)
+	}
+}
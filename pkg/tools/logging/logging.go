@@ -26,6 +26,8 @@ import (
 func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
 	installQueryLogsTool(s, c)
 	installGetLogSchemas(s)
+	installGetControlPlaneLogsTool(s, c)
+	installFindDeprecatedAPICallsTool(s, c)
 
 	return nil
 }
@@ -18,7 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"strings"
 	"text/template"
 	"time"
@@ -28,7 +28,6 @@ import (
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"google.golang.org/api/iterator"
-	"google.golang.org/api/option"
 	_ "google.golang.org/genproto/googleapis/cloud/audit" // Import for AuditLog proto so we can convert to JSON.
 	"google.golang.org/protobuf/encoding/protojson"
 )
@@ -39,7 +38,8 @@ type LogQueryRequest struct {
 	ProjectID string    `json:"project_id" jsonschema:"GCP project ID to query logs from. Required."`
 	TimeRange TimeRange `json:"time_range,omitempty" jsonschema:"Time range for log query. If empty, no restrictions are applied."`
 	Since     string    `json:"since,omitempty" jsonschema:"Only return logs newer than a relative duration like 5s, 2m, or 3h. The only supported units are seconds ('s'), minutes ('m'), and hours ('h')."`
-	Limit     int       `json:"limit,omitempty" jsonschema:"Maximum number of log entries to return. Cannot be greater than 100. Consider multiple calls if needed. Defaults to 10."`
+	Limit     int       `json:"limit,omitempty" jsonschema:"Maximum number of log entries to return, i.e. the page size. Cannot be greater than 100. Use page_token to get the next entries. Defaults to 10."`
+	PageToken string    `json:"page_token,omitempty" jsonschema:"Token of the page of log entries to return, from the previous call with the same arguments. Use time_range rather than since to iterate through pages. Leave this empty for the first page."`
 	Format    string    `json:"format,omitempty" jsonschema:"Go template string to format each log entry. If empty, the full JSON representation is returned. Note that empty fields are not included in the response. Example: '{{.timestamp}} [{{.severity}}] {{.textPayload}}'. It's strongly recommended to use a template to minimize the size of the response and only include the fields you need. Use the get_schema tool before this tool to get information about supported log types and their schemas."`
 }
 
@@ -125,57 +125,44 @@ func (r *LogQueryRequest) validate() error {
 }
 
 func (t *queryLogsTool) queryGCPLogs(ctx context.Context, req *LogQueryRequest) (string, error) {
-	client, err := logging.NewClient(ctx, option.WithUserAgent(t.conf.UserAgent()))
+	formatter, err := formatterForRequest(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to create formatter: %w", err)
+	}
+	return t.listLogEntries(ctx, req, formatter)
+}
+
+// listLogEntries returns a page of the log entries matching the request,
+// formatted with f.
+func (t *queryLogsTool) listLogEntries(ctx context.Context, req *LogQueryRequest, f formatter) (string, error) {
+	client, err := logging.NewClient(ctx, t.conf.ClientOptions()...)
 	if err != nil {
 		return "", fmt.Errorf("failed to create logging client: %v", err)
 	}
 	defer func() {
 		if err := client.Close(); err != nil {
-			log.Printf("Failed to close logging client: %v\n", err)
+			slog.Warn("Failed to close logging client", "error", err)
 		}
 	}()
 
 	listLogsReq := buildListLogEntriesRequest(req)
-	// Request one more than the limit to check for truncation.
-	// #nosec G115
-	listLogsReq.PageSize = int32(req.Limit + 1)
-
 	resp := client.ListLogEntries(ctx, listLogsReq)
 
 	var entries []*loggingpb.LogEntry
-	for {
-		entry, err := resp.Next()
-		if err == iterator.Done {
-			break
-		}
-		if err != nil {
-			return "", fmt.Errorf("failed to iterate log entries: %v", err)
-		}
-		entries = append(entries, entry)
-		if len(entries) > req.Limit {
-			break
-		}
-	}
-
-	truncated := len(entries) > req.Limit
-	if truncated {
-		entries = entries[:req.Limit]
+	nextPageToken, err := iterator.NewPager(resp, req.Limit, req.PageToken).NextPage(&entries)
+	if err != nil {
+		return "", fmt.Errorf("failed to iterate log entries: %v", err)
 	}
 
 	allLogLines := strings.Builder{}
 	if len(entries) == 0 {
 		allLogLines.WriteString("No log entries found.")
 	} else {
-		formatter, err := formatterForRequest(req)
-		if err != nil {
-			return "", fmt.Errorf("failed to create formatter: %w", err)
-		}
-
 		for i, entry := range entries {
 			if i > 0 {
 				allLogLines.WriteString("\n")
 			}
-			logLine, err := formatter.format(entry)
+			logLine, err := f.format(entry)
 			if err != nil {
 				return "", fmt.Errorf("failed to format log entry: %w", err)
 			}
@@ -184,8 +171,8 @@ func (t *queryLogsTool) queryGCPLogs(ctx context.Context, req *LogQueryRequest)
 	}
 
 	result := fmt.Sprintf("Project ID: %s\nLQL Query:\n```\n%s\n```\nResult:\n\n%s", req.ProjectID, listLogsReq.Filter, allLogLines.String())
-	if truncated {
-		result += fmt.Sprintf("\n\nWarning: Results truncated. The query returned more than the limit of %d log entries. You can use the `limit` parameter to request more entries (up to %d).", req.Limit, maxLimit)
+	if nextPageToken != "" {
+		result += fmt.Sprintf("\n\nMore log entries match the query. Call the tool again with the same arguments and page_token %q for the next page, or use the `limit` parameter to request more entries per page (up to %d).", nextPageToken, maxLimit)
 	}
 
 	return result, nil
@@ -18,7 +18,7 @@ package monitoring
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"strings"
 
 	monitoring "cloud.google.com/go/monitoring/apiv3/v2"
@@ -26,7 +26,6 @@ import (
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"google.golang.org/api/iterator"
-	"google.golang.org/api/option"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
@@ -57,18 +56,18 @@ func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
 
 func (h *handlers) listMRDescriptor(ctx context.Context, _ *mcp.CallToolRequest, args *listMonitoredResourceDescriptorsArgs) (*mcp.CallToolResult, any, error) {
 	if args.ProjectID == "" {
-		args.ProjectID = h.c.DefaultProjectID()
+		args.ProjectID = h.c.DefaultProjectID(ctx)
 	}
 	if args.ProjectID == "" {
 		return nil, nil, fmt.Errorf("project_id argument cannot be empty")
 	}
-	c, err := monitoring.NewMetricClient(ctx, option.WithUserAgent(h.c.UserAgent()))
+	c, err := monitoring.NewMetricClient(ctx, h.c.ClientOptions()...)
 	if err != nil {
 		return nil, nil, err
 	}
 	defer func() {
 		if err := c.Close(); err != nil {
-			log.Printf("Failed to close monitoring client: %v\n", err)
+			slog.Warn("Failed to close monitoring client", "error", err)
 		}
 	}()
 	req := &monitoringpb.ListMonitoredResourceDescriptorsRequest{
@@ -0,0 +1,104 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networking
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/gkereleasenotes"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// dataplaneReleaseNoteKeywords are used to pick network datapath entries from the GKE release notes.
+var dataplaneReleaseNoteKeywords = []string{
+	"dataplane v2",
+	"cilium",
+	"calico",
+	"network polic",
+	"networkpolic",
+	"kube-proxy",
+	"iptables",
+	"firewall",
+}
+
+type checkDataplaneReadinessArgs struct {
+	ProjectID     string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location      string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name          string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+	TargetVersion string `json:"target_version,omitempty" jsonschema:"GKE version the cluster is going to be upgraded to. For example, '1.34.3-gke.240500'. If empty, release notes are not analyzed."`
+}
+
+func (h *handlers) checkDataplaneReadiness(ctx context.Context, _ *mcp.CallToolRequest, args *checkDataplaneReadinessArgs) (*mcp.CallToolResult, any, error) {
+	cluster, err := h.getCluster(ctx, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	releaseNoteEntries, err := gkereleasenotes.UpgradeEntries(ctx, cluster.GetCurrentMasterVersion(), args.TargetVersion, dataplaneReleaseNoteKeywords)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: dataplaneReport(cluster, args.TargetVersion, releaseNoteEntries)},
+		},
+	}, nil, nil
+}
+
+func isDataplaneV2(cluster *containerpb.Cluster) bool {
+	return cluster.GetNetworkConfig().GetDatapathProvider() == containerpb.DatapathProvider_ADVANCED_DATAPATH
+}
+
+// networkPolicyEnforcement describes which component, if any, enforces NetworkPolicy objects in the cluster.
+func networkPolicyEnforcement(cluster *containerpb.Cluster) string {
+	if isDataplaneV2(cluster) {
+		return "enforced by Dataplane V2"
+	}
+	if cluster.GetNetworkPolicy().GetEnabled() {
+		if cluster.GetNetworkPolicy().GetProvider() == containerpb.NetworkPolicy_CALICO {
+			return "enforced by Calico"
+		}
+		return fmt.Sprintf("enforced by %s", cluster.GetNetworkPolicy().GetProvider())
+	}
+	if cluster.GetAddonsConfig().GetNetworkPolicyConfig() != nil && !cluster.GetAddonsConfig().GetNetworkPolicyConfig().GetDisabled() {
+		return "not enforced (the NetworkPolicy add-on is enabled on the control plane but enforcement is disabled on nodes)"
+	}
+	return "not enforced"
+}
+
+func dataplaneReport(cluster *containerpb.Cluster, targetVersion string, releaseNoteEntries []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cluster: %s\n", cluster.GetName())
+	fmt.Fprintf(&b, "Current control plane version: %s\n", cluster.GetCurrentMasterVersion())
+	if isDataplaneV2(cluster) {
+		b.WriteString("Datapath: Dataplane V2 (eBPF, Cilium based)\n")
+	} else {
+		b.WriteString("Datapath: legacy (kube-proxy and iptables)\n")
+	}
+	fmt.Fprintf(&b, "NetworkPolicy: %s\n", networkPolicyEnforcement(cluster))
+	fmt.Fprintf(&b, "FQDN network policy enabled: %t\n", cluster.GetNetworkConfig().GetEnableFqdnNetworkPolicy())
+	fmt.Fprintf(&b, "Cilium clusterwide network policy enabled: %t\n", cluster.GetNetworkConfig().GetEnableCiliumClusterwideNetworkPolicy())
+
+	if !isDataplaneV2(cluster) && cluster.GetNetworkPolicy().GetProvider() == containerpb.NetworkPolicy_CALICO {
+		b.WriteString("\nNote: Dataplane V2 can only be enabled when a cluster is created. Moving from Calico to Dataplane V2 requires migrating workloads to a new cluster, and Calico-specific policy CRDs are not supported by Dataplane V2.\n")
+	}
+
+	gkereleasenotes.WriteEntries(&b, "Network datapath related", cluster.GetCurrentMasterVersion(), targetVersion, releaseNoteEntries)
+	return b.String()
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networking
+
+import (
+	"strings"
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNetworkPolicyEnforcement(t *testing.T) {
+	tests := []struct {
+		name    string
+		cluster *containerpb.Cluster
+		want    string
+	}{
+		{
+			name: "dataplane v2",
+			cluster: &containerpb.Cluster{
+				NetworkConfig: &containerpb.NetworkConfig{DatapathProvider: containerpb.DatapathProvider_ADVANCED_DATAPATH},
+			},
+			want: "enforced by Dataplane V2",
+		},
+		{
+			name: "calico",
+			cluster: &containerpb.Cluster{
+				NetworkPolicy: &containerpb.NetworkPolicy{Enabled: true, Provider: containerpb.NetworkPolicy_CALICO},
+			},
+			want: "enforced by Calico",
+		},
+		{
+			name: "add-on only",
+			cluster: &containerpb.Cluster{
+				AddonsConfig: &containerpb.AddonsConfig{NetworkPolicyConfig: &containerpb.NetworkPolicyConfig{}},
+			},
+			want: "not enforced (the NetworkPolicy add-on is enabled on the control plane but enforcement is disabled on nodes)",
+		},
+		{
+			name:    "disabled",
+			cluster: &containerpb.Cluster{},
+			want:    "not enforced",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := networkPolicyEnforcement(tt.cluster); got != tt.want {
+				t.Errorf("networkPolicyEnforcement() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDataplaneReport(t *testing.T) {
+	t.Run("calico cluster", func(t *testing.T) {
+		cluster := &containerpb.Cluster{
+			Name:                 "my-cluster",
+			CurrentMasterVersion: "1.32.4-gke.1029000",
+			NetworkPolicy:        &containerpb.NetworkPolicy{Enabled: true, Provider: containerpb.NetworkPolicy_CALICO},
+		}
+		got := dataplaneReport(cluster, "1.33.1-gke.100", []string{"October 14, 2025\nIssue\nCalico change."})
+		for _, want := range []string{
+			"Datapath: legacy",
+			"NetworkPolicy: enforced by Calico",
+			"Moving from Calico to Dataplane V2",
+			"Calico change.",
+		} {
+			if !strings.Contains(got, want) {
+				t.Errorf("dataplaneReport() = %q, want to contain %q", got, want)
+			}
+		}
+	})
+
+	t.Run("dataplane v2 cluster", func(t *testing.T) {
+		cluster := &containerpb.Cluster{
+			Name: "my-cluster",
+			NetworkConfig: &containerpb.NetworkConfig{
+				DatapathProvider:        containerpb.DatapathProvider_ADVANCED_DATAPATH,
+				EnableFqdnNetworkPolicy: proto.Bool(true),
+			},
+		}
+		got := dataplaneReport(cluster, "", nil)
+		for _, want := range []string{
+			"Datapath: Dataplane V2",
+			"FQDN network policy enabled: true",
+			"release notes were not analyzed",
+		} {
+			if !strings.Contains(got, want) {
+				t.Errorf("dataplaneReport() = %q, want to contain %q", got, want)
+			}
+		}
+		if strings.Contains(got, "Moving from Calico") {
+			t.Errorf("dataplaneReport() = %q, want no Calico migration note", got)
+		}
+	})
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networking
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/gkereleasenotes"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// dnsReleaseNoteKeywords are used to pick DNS-related entries from the GKE release notes.
+var dnsReleaseNoteKeywords = []string{"dns"}
+
+type checkDNSProviderArgs struct {
+	ProjectID     string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location      string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name          string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+	TargetVersion string `json:"target_version,omitempty" jsonschema:"GKE version the cluster is going to be upgraded to. For example, '1.34.3-gke.240500'. If empty, release notes are not analyzed."`
+}
+
+func (h *handlers) checkDNSProvider(ctx context.Context, _ *mcp.CallToolRequest, args *checkDNSProviderArgs) (*mcp.CallToolResult, any, error) {
+	cluster, err := h.getCluster(ctx, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	releaseNoteEntries, err := gkereleasenotes.UpgradeEntries(ctx, cluster.GetCurrentMasterVersion(), args.TargetVersion, dnsReleaseNoteKeywords)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: dnsReport(cluster, args.TargetVersion, releaseNoteEntries)},
+		},
+	}, nil, nil
+}
+
+// dnsProvider returns a human readable name of the DNS provider used by the cluster.
+func dnsProvider(cluster *containerpb.Cluster) string {
+	switch cluster.GetNetworkConfig().GetDnsConfig().GetClusterDns() {
+	case containerpb.DNSConfig_CLOUD_DNS:
+		return "Cloud DNS"
+	case containerpb.DNSConfig_KUBE_DNS:
+		return "kube-dns"
+	default:
+		if cluster.GetAutopilot().GetEnabled() {
+			return "platform default (Cloud DNS for Autopilot clusters created on recent versions, kube-dns otherwise)"
+		}
+		return "kube-dns (platform default)"
+	}
+}
+
+func dnsReport(cluster *containerpb.Cluster, targetVersion string, releaseNoteEntries []string) string {
+	dnsConfig := cluster.GetNetworkConfig().GetDnsConfig()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cluster: %s\n", cluster.GetName())
+	fmt.Fprintf(&b, "Current control plane version: %s\n", cluster.GetCurrentMasterVersion())
+	fmt.Fprintf(&b, "DNS provider: %s\n", dnsProvider(cluster))
+	if dnsConfig.GetClusterDns() == containerpb.DNSConfig_CLOUD_DNS {
+		fmt.Fprintf(&b, "Cloud DNS scope: %s\n", dnsConfig.GetClusterDnsScope())
+		if domain := dnsConfig.GetClusterDnsDomain(); domain != "" {
+			fmt.Fprintf(&b, "Cloud DNS domain: %s\n", domain)
+		}
+		if domain := dnsConfig.GetAdditiveVpcScopeDnsDomain(); domain != "" {
+			fmt.Fprintf(&b, "Additive VPC scope DNS domain: %s\n", domain)
+		}
+	}
+	fmt.Fprintf(&b, "NodeLocal DNSCache enabled: %t\n", cluster.GetAddonsConfig().GetDnsCacheConfig().GetEnabled())
+
+	gkereleasenotes.WriteEntries(&b, "DNS-related", cluster.GetCurrentMasterVersion(), targetVersion, releaseNoteEntries)
+	return b.String()
+}
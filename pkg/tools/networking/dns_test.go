@@ -0,0 +1,111 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networking
+
+import (
+	"strings"
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+)
+
+func TestDNSProvider(t *testing.T) {
+	tests := []struct {
+		name    string
+		cluster *containerpb.Cluster
+		want    string
+	}{
+		{
+			name: "cloud dns",
+			cluster: &containerpb.Cluster{
+				NetworkConfig: &containerpb.NetworkConfig{
+					DnsConfig: &containerpb.DNSConfig{ClusterDns: containerpb.DNSConfig_CLOUD_DNS},
+				},
+			},
+			want: "Cloud DNS",
+		},
+		{
+			name: "kube-dns",
+			cluster: &containerpb.Cluster{
+				NetworkConfig: &containerpb.NetworkConfig{
+					DnsConfig: &containerpb.DNSConfig{ClusterDns: containerpb.DNSConfig_KUBE_DNS},
+				},
+			},
+			want: "kube-dns",
+		},
+		{
+			name:    "standard platform default",
+			cluster: &containerpb.Cluster{},
+			want:    "kube-dns (platform default)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dnsProvider(tt.cluster); got != tt.want {
+				t.Errorf("dnsProvider() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDNSReport(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		Name:                 "my-cluster",
+		CurrentMasterVersion: "1.32.4-gke.1029000",
+		NetworkConfig: &containerpb.NetworkConfig{
+			DnsConfig: &containerpb.DNSConfig{
+				ClusterDns:       containerpb.DNSConfig_CLOUD_DNS,
+				ClusterDnsScope:  containerpb.DNSConfig_VPC_SCOPE,
+				ClusterDnsDomain: "my-cluster.example",
+			},
+		},
+		AddonsConfig: &containerpb.AddonsConfig{
+			DnsCacheConfig: &containerpb.DnsCacheConfig{Enabled: true},
+		},
+	}
+
+	t.Run("without target version", func(t *testing.T) {
+		got := dnsReport(cluster, "", nil)
+		for _, want := range []string{
+			"DNS provider: Cloud DNS",
+			"Cloud DNS scope: VPC_SCOPE",
+			"Cloud DNS domain: my-cluster.example",
+			"NodeLocal DNSCache enabled: true",
+			"release notes were not analyzed",
+		} {
+			if !strings.Contains(got, want) {
+				t.Errorf("dnsReport() = %q, want to contain %q", got, want)
+			}
+		}
+	})
+
+	t.Run("with release notes", func(t *testing.T) {
+		got := dnsReport(cluster, "1.34.1-gke.1431000", []string{"October 14, 2025\nFeature\nCloud DNS change."})
+		if !strings.Contains(got, "between 1.32.4-gke.1029000 and 1.34.1-gke.1431000") {
+			t.Errorf("dnsReport() = %q, want to contain version range", got)
+		}
+		if !strings.Contains(got, "Cloud DNS change.") {
+			t.Errorf("dnsReport() = %q, want to contain release note entry", got)
+		}
+	})
+
+	t.Run("no matching release notes", func(t *testing.T) {
+		got := dnsReport(cluster, "1.34.1-gke.1431000", nil)
+		if !strings.Contains(got, "No DNS-related release notes found.") {
+			t.Errorf("dnsReport() = %q, want to contain no release notes message", got)
+		}
+	})
+}
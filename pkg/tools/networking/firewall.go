@@ -0,0 +1,408 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networking
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	compute "google.golang.org/api/compute/v1"
+)
+
+const (
+	ingress = "INGRESS"
+	egress  = "EGRESS"
+	// defaultWebhookPort is the port of the webhook Services which don't set one.
+	defaultWebhookPort = 443
+)
+
+// healthCheckRanges are the source ranges of the Google Cloud load balancer health checks.
+var healthCheckRanges = []string{"35.191.0.0/16", "130.211.0.0/22"}
+
+var networkRegexp = regexp.MustCompile(`projects/([^/]+)/global/networks/([^/]+)$`)
+
+type validateFirewallRulesArgs struct {
+	ProjectID string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location  string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name      string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+}
+
+// nodeTarget identifies the nodes of a cluster in the targets of the firewall rules.
+type nodeTarget struct {
+	tags map[string]bool
+	// clusterTagSuffix ends the network tag GKE sets on every node of the
+	// cluster, gke-{cluster}-{first 8 characters of the cluster ID}-node.
+	clusterTagSuffix string
+	serviceAccounts  map[string]bool
+}
+
+// requiredFlow is traffic of the nodes the firewall rules must allow.
+type requiredFlow struct {
+	description string
+	direction   string
+	// cidr is the source of the ingress traffic, or the destination of the egress traffic.
+	cidr     string
+	protocol string
+	// port is 0 when the flow needs all the ports of the protocol.
+	port int64
+	// impact is what breaks when the flow is blocked.
+	impact string
+}
+
+// webhookPort is the port of the pods serving an admission webhook.
+type webhookPort struct {
+	webhook string
+	port    int64
+}
+
+func (h *handlers) validateFirewallRules(ctx context.Context, _ *mcp.CallToolRequest, args *validateFirewallRulesArgs) (*mcp.CallToolResult, any, error) {
+	if args.ProjectID == "" {
+		args.ProjectID = h.c.DefaultProjectID(ctx)
+	}
+	cluster, err := h.getCluster(ctx, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	svc, err := compute.NewService(ctx, h.c.RESTClientOptions(ctx)...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create compute client: %w", err)
+	}
+
+	// The firewall rules of a Shared VPC belong to the host project.
+	networkProject, network := args.ProjectID, cluster.GetNetwork()
+	if m := networkRegexp.FindStringSubmatch(cluster.GetNetworkConfig().GetNetwork()); m != nil {
+		networkProject, network = m[1], m[2]
+	}
+	var rules []*compute.Firewall
+	if err := svc.Firewalls.List(networkProject).Pages(ctx, func(l *compute.FirewallList) error {
+		for _, fw := range l.Items {
+			if strings.HasSuffix(fw.Network, "/networks/"+network) {
+				rules = append(rules, fw)
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, nil, fmt.Errorf("failed to list firewall rules: %w", err)
+	}
+
+	var webhooks []webhookPort
+	var webhookNotes []string
+	if cluster.GetPrivateClusterConfig().GetMasterIpv4CidrBlock() != "" {
+		webhooks, webhookNotes = readWebhookPorts(ctx, args.ProjectID, cluster)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: firewallReport(cluster, rules, webhooks, webhookNotes)},
+		},
+	}, nil, nil
+}
+
+// readWebhookPorts returns the ports of the pods serving the admission
+// webhooks, when the current kubectl context is the cluster's, and notes on
+// the webhooks not checked.
+func readWebhookPorts(ctx context.Context, projectID string, cluster *containerpb.Cluster) ([]webhookPort, []string) {
+	if err := gke.RequireKubeContext(ctx, projectID, cluster); err != nil {
+		return nil, []string{fmt.Sprintf("The webhooks were not checked: %v", err)}
+	}
+	var mutating, validating kube.List[kube.WebhookConfiguration]
+	if err := kube.Get(ctx, &mutating, "mutatingwebhookconfigurations"); err != nil {
+		return nil, []string{fmt.Sprintf("The webhooks were not checked: %v", err)}
+	}
+	if err := kube.Get(ctx, &validating, "validatingwebhookconfigurations"); err != nil {
+		return nil, []string{fmt.Sprintf("The webhooks were not checked: %v", err)}
+	}
+	var services kube.List[kube.Service]
+	if err := kube.Get(ctx, &services, "services", "--all-namespaces"); err != nil {
+		return nil, []string{fmt.Sprintf("The webhooks were not checked: %v", err)}
+	}
+	return webhookPorts(mutating.Items, validating.Items, services.Items)
+}
+
+// webhookPorts resolves the ports of the pods serving the admission webhooks
+// from their Services. Webhooks whose port can't be resolved, e.g. named
+// target ports, are returned as notes.
+func webhookPorts(mutating, validating []kube.WebhookConfiguration, services []kube.Service) ([]webhookPort, []string) {
+	byName := map[string]kube.Service{}
+	for _, s := range services {
+		byName[s.Namespace+"/"+s.Name] = s
+	}
+
+	var ports []webhookPort
+	var notes []string
+	for _, kind := range []struct {
+		name    string
+		configs []kube.WebhookConfiguration
+	}{{"mutating", mutating}, {"validating", validating}} {
+		for _, config := range kind.configs {
+			for _, w := range config.Webhooks {
+				ref := w.ClientConfig.Service
+				if ref == nil {
+					continue
+				}
+				name := fmt.Sprintf("%s/%s/%s", kind.name, config.Name, w.Name)
+				port := int32(defaultWebhookPort)
+				if ref.Port != nil {
+					port = *ref.Port
+				}
+				service, ok := byName[ref.Namespace+"/"+ref.Name]
+				if !ok {
+					notes = append(notes, fmt.Sprintf("%s: its Service %s/%s doesn't exist.", name, ref.Namespace, ref.Name))
+					continue
+				}
+				for _, sp := range service.Spec.Ports {
+					if sp.Port != port {
+						continue
+					}
+					switch {
+					case sp.TargetPort.StrVal != "":
+						notes = append(notes, fmt.Sprintf("%s: the target port %q of its Service %s/%s is named, check the firewall rules for its number.", name, sp.TargetPort.StrVal, ref.Namespace, ref.Name))
+					case sp.TargetPort.IntVal != 0:
+						ports = append(ports, webhookPort{webhook: name, port: int64(sp.TargetPort.IntVal)})
+					default:
+						ports = append(ports, webhookPort{webhook: name, port: int64(sp.Port)})
+					}
+				}
+			}
+		}
+	}
+	return ports, notes
+}
+
+// clusterNodeTarget returns the network tags and service accounts of the
+// nodes of the cluster.
+func clusterNodeTarget(cluster *containerpb.Cluster) nodeTarget {
+	t := nodeTarget{tags: map[string]bool{}, serviceAccounts: map[string]bool{}}
+	if id := cluster.GetId(); len(id) >= 8 {
+		t.clusterTagSuffix = "-" + id[:8] + "-node"
+	}
+	for _, pool := range cluster.GetNodePools() {
+		for _, tag := range pool.GetConfig().GetTags() {
+			t.tags[tag] = true
+		}
+		if sa := pool.GetConfig().GetServiceAccount(); sa != "" {
+			t.serviceAccounts[sa] = true
+		}
+	}
+	return t
+}
+
+// appliesTo reports whether a firewall rule applies to the nodes.
+func appliesTo(fw *compute.Firewall, t nodeTarget) bool {
+	if len(fw.TargetTags) == 0 && len(fw.TargetServiceAccounts) == 0 {
+		return true
+	}
+	for _, tag := range fw.TargetTags {
+		if t.tags[tag] || (t.clusterTagSuffix != "" && strings.HasPrefix(tag, "gke-") && strings.HasSuffix(tag, t.clusterTagSuffix)) {
+			return true
+		}
+	}
+	for _, sa := range fw.TargetServiceAccounts {
+		if t.serviceAccounts[sa] {
+			return true
+		}
+	}
+	return false
+}
+
+// rangeCovers reports whether one of the ranges contains the CIDR block.
+func rangeCovers(ranges []string, cidr string) bool {
+	_, want, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return false
+	}
+	wantOnes, _ := want.Mask.Size()
+	for _, r := range ranges {
+		if !strings.Contains(r, "/") {
+			r += "/32"
+		}
+		_, ipNet, err := net.ParseCIDR(r)
+		if err != nil {
+			continue
+		}
+		if ones, _ := ipNet.Mask.Size(); ones <= wantOnes && ipNet.Contains(want.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+// portMatches reports whether a protocol and ports of a firewall rule match
+// the flow.
+func portMatches(protocol string, ports []string, flow requiredFlow) bool {
+	if protocol != "all" && protocol != flow.protocol {
+		return false
+	}
+	if len(ports) == 0 {
+		return true
+	}
+	if flow.port == 0 {
+		return false
+	}
+	for _, p := range ports {
+		low, high, found := strings.Cut(p, "-")
+		if !found {
+			high = low
+		}
+		lo, errLow := strconv.ParseInt(low, 10, 64)
+		hi, errHigh := strconv.ParseInt(high, 10, 64)
+		if errLow == nil && errHigh == nil && lo <= flow.port && flow.port <= hi {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateFlow returns whether the firewall rules allow the flow, and the rule
+// deciding it: the matching rule of the highest priority, deny rules first
+// on ties, or the implied rules of the VPC.
+func evaluateFlow(rules []*compute.Firewall, t nodeTarget, flow requiredFlow) (bool, string) {
+	var decision *compute.Firewall
+	decisionAllows := false
+	for _, fw := range rules {
+		direction := fw.Direction
+		if direction == "" {
+			direction = ingress
+		}
+		if fw.Disabled || direction != flow.direction || !appliesTo(fw, t) {
+			continue
+		}
+		ranges := fw.SourceRanges
+		if direction == egress {
+			ranges = fw.DestinationRanges
+		}
+		if !rangeCovers(ranges, flow.cidr) {
+			continue
+		}
+		allows, denies := false, false
+		for _, a := range fw.Allowed {
+			allows = allows || portMatches(a.IPProtocol, a.Ports, flow)
+		}
+		for _, d := range fw.Denied {
+			denies = denies || portMatches(d.IPProtocol, d.Ports, flow)
+		}
+		if !allows && !denies {
+			continue
+		}
+		if decision == nil || fw.Priority < decision.Priority || (fw.Priority == decision.Priority && denies && decisionAllows) {
+			decision, decisionAllows = fw, allows && !denies
+		}
+	}
+	if decision == nil {
+		if flow.direction == egress {
+			return true, "the implied allow egress rule"
+		}
+		return false, "the implied deny ingress rule, no rule allows it"
+	}
+	return decisionAllows, fmt.Sprintf("%s (priority %d)", decision.Name, decision.Priority)
+}
+
+// requiredFlows returns the traffic of the nodes GKE features need.
+func requiredFlows(cluster *containerpb.Cluster, webhooks []webhookPort) []requiredFlow {
+	var flows []requiredFlow
+	if cidr := cluster.GetPrivateClusterConfig().GetMasterIpv4CidrBlock(); cidr != "" {
+		flows = append(flows,
+			requiredFlow{"Control plane to kubelet", ingress, cidr, "tcp", 10250, "kubectl logs, exec and port-forward, and the metrics of the nodes"},
+			requiredFlow{"Control plane to webhooks and aggregated APIs", ingress, cidr, "tcp", 443, "admission webhooks and aggregated APIs, e.g. metrics-server"},
+		)
+		seen := map[int64]bool{443: true, 10250: true}
+		for _, w := range webhooks {
+			if seen[w.port] {
+				continue
+			}
+			seen[w.port] = true
+			flows = append(flows, requiredFlow{fmt.Sprintf("Control plane to the webhook %s", w.webhook), ingress, cidr, "tcp", w.port, "the admission requests the webhook intercepts, which fail or time out"})
+		}
+	}
+
+	podCIDR := cluster.GetIpAllocationPolicy().GetClusterIpv4CidrBlock()
+	if podCIDR == "" {
+		podCIDR = cluster.GetClusterIpv4Cidr()
+	}
+	if podCIDR != "" {
+		flows = append(flows, requiredFlow{"Pods to nodes and pods", ingress, podCIDR, "tcp", 0, "traffic between the pods of different nodes, e.g. DNS and Services"})
+	}
+	for _, r := range healthCheckRanges {
+		flows = append(flows, requiredFlow{"Load balancer health checks", ingress, r, "tcp", 10256, "the load balancers of LoadBalancer Services and Ingresses, which mark all the nodes unhealthy"})
+	}
+
+	endpoint := cluster.GetPrivateClusterConfig().GetPrivateEndpoint()
+	if endpoint == "" {
+		endpoint = cluster.GetEndpoint()
+	}
+	if endpoint != "" {
+		flows = append(flows,
+			requiredFlow{"Nodes to the API server", egress, endpoint + "/32", "tcp", 443, "the registration of new nodes and the kubelet, which turn NotReady"},
+			requiredFlow{"Nodes to the konnectivity server", egress, endpoint + "/32", "tcp", 8132, "the konnectivity tunnel of webhooks, logs and exec"},
+		)
+	}
+	return flows
+}
+
+func firewallReport(cluster *containerpb.Cluster, rules []*compute.Firewall, webhooks []webhookPort, webhookNotes []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cluster: %s\n", cluster.GetName())
+	fmt.Fprintf(&b, "Network: %s\n", cluster.GetNetwork())
+	t := clusterNodeTarget(cluster)
+	applying := 0
+	for _, fw := range rules {
+		if appliesTo(fw, t) {
+			applying++
+		}
+	}
+	fmt.Fprintf(&b, "Firewall rules applying to the nodes: %d of %d\n", applying, len(rules))
+	if cluster.GetPrivateClusterConfig().GetMasterIpv4CidrBlock() == "" {
+		b.WriteString("The cluster has no control plane range, the control plane to node rules are not checked.\n")
+	}
+
+	var blocked []requiredFlow
+	b.WriteString("\nRequired traffic:\n")
+	for _, flow := range requiredFlows(cluster, webhooks) {
+		port := "all ports"
+		if flow.port != 0 {
+			port = fmt.Sprintf("port %d", flow.port)
+		}
+		allowed, rule := evaluateFlow(rules, t, flow)
+		status := "allowed"
+		if !allowed {
+			status = "BLOCKED"
+			blocked = append(blocked, flow)
+		}
+		fmt.Fprintf(&b, "- %s (%s %s, %s %s): %s by %s\n", flow.description, strings.ToLower(flow.direction), flow.cidr, flow.protocol, port, status, rule)
+	}
+	for _, note := range webhookNotes {
+		fmt.Fprintf(&b, "- %s\n", note)
+	}
+
+	if len(blocked) == 0 {
+		b.WriteString("\nThe firewall rules allow the traffic GKE features need.\n")
+		return b.String()
+	}
+	b.WriteString("\nBlocked traffic breaks:\n")
+	for _, flow := range blocked {
+		fmt.Fprintf(&b, "- %s: %s\n", flow.description, flow.impact)
+	}
+	b.WriteString("Add allow rules of a higher priority than the deny rules for these flows, targeting the node network tags or service accounts.\n")
+	return b.String()
+}
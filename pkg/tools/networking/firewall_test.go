@@ -0,0 +1,161 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networking
+
+import (
+	"strings"
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	compute "google.golang.org/api/compute/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestWebhookPorts(t *testing.T) {
+	port := int32(8443)
+	webhook := func(name string, ref *kube.ServiceReference) kube.WebhookConfiguration {
+		config := kube.WebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: name}, Webhooks: []kube.Webhook{{Name: name + ".example.com"}}}
+		config.Webhooks[0].ClientConfig.Service = ref
+		return config
+	}
+	mutating := []kube.WebhookConfiguration{webhook("injector", &kube.ServiceReference{Namespace: "mesh", Name: "istiod"})}
+	validating := []kube.WebhookConfiguration{
+		webhook("policy", &kube.ServiceReference{Namespace: "gatekeeper", Name: "webhook", Port: &port}),
+		webhook("named", &kube.ServiceReference{Namespace: "certs", Name: "cert-manager"}),
+		webhook("gone", &kube.ServiceReference{Namespace: "old", Name: "webhook"}),
+		webhook("external", nil),
+	}
+	services := []kube.Service{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "mesh", Name: "istiod"}, Spec: kube.ServiceSpec{Ports: []kube.ServicePort{{Port: 15010}, {Port: 443, TargetPort: intstr.FromInt32(15017)}}}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "gatekeeper", Name: "webhook"}, Spec: kube.ServiceSpec{Ports: []kube.ServicePort{{Port: 8443}}}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "certs", Name: "cert-manager"}, Spec: kube.ServiceSpec{Ports: []kube.ServicePort{{Port: 443, TargetPort: intstr.FromString("https")}}}},
+	}
+
+	ports, notes := webhookPorts(mutating, validating, services)
+	want := []webhookPort{
+		{webhook: "mutating/injector/injector.example.com", port: 15017},
+		{webhook: "validating/policy/policy.example.com", port: 8443},
+	}
+	if len(ports) != len(want) || ports[0] != want[0] || ports[1] != want[1] {
+		t.Errorf("webhookPorts() = %v, want %v", ports, want)
+	}
+	if len(notes) != 2 || !strings.Contains(notes[0], `target port "https"`) || !strings.Contains(notes[1], "Service old/webhook doesn't exist") {
+		t.Errorf("webhookPorts() notes = %v", notes)
+	}
+}
+
+func TestEvaluateFlow(t *testing.T) {
+	target := nodeTarget{tags: map[string]bool{"web": true}, clusterTagSuffix: "-0123abcd-node"}
+	flow := requiredFlow{direction: ingress, cidr: "172.16.0.0/28", protocol: "tcp", port: 8443}
+	allow := &compute.Firewall{Name: "allow-master", Priority: 1000, SourceRanges: []string{"172.16.0.0/16"}, TargetTags: []string{"gke-prod-0123abcd-node"}, Allowed: []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"443", "8000-9000"}}}}
+	tests := []struct {
+		name        string
+		rules       []*compute.Firewall
+		flow        requiredFlow
+		wantAllowed bool
+		wantRule    string
+	}{
+		{name: "implied deny", flow: flow, wantRule: "the implied deny ingress rule, no rule allows it"},
+		{name: "allowed by port range", rules: []*compute.Firewall{allow}, flow: flow, wantAllowed: true, wantRule: "allow-master (priority 1000)"},
+		{
+			name:     "denied by a higher priority rule",
+			rules:    []*compute.Firewall{allow, {Name: "deny-all", Priority: 100, SourceRanges: []string{"0.0.0.0/0"}, Denied: []*compute.FirewallDenied{{IPProtocol: "all"}}}},
+			flow:     flow,
+			wantRule: "deny-all (priority 100)",
+		},
+		{
+			name:     "deny wins ties",
+			rules:    []*compute.Firewall{allow, {Name: "deny-tcp", Priority: 1000, SourceRanges: []string{"172.16.0.0/12"}, Denied: []*compute.FirewallDenied{{IPProtocol: "tcp"}}}},
+			flow:     flow,
+			wantRule: "deny-tcp (priority 1000)",
+		},
+		{
+			name: "other targets, disabled rules and narrower ranges are ignored",
+			rules: []*compute.Firewall{
+				{Name: "db", Priority: 1, SourceRanges: []string{"172.16.0.0/16"}, TargetTags: []string{"db"}, Allowed: []*compute.FirewallAllowed{{IPProtocol: "all"}}},
+				{Name: "disabled", Disabled: true, SourceRanges: []string{"172.16.0.0/16"}, Allowed: []*compute.FirewallAllowed{{IPProtocol: "all"}}},
+				{Name: "narrow", SourceRanges: []string{"172.16.0.2"}, Allowed: []*compute.FirewallAllowed{{IPProtocol: "all"}}},
+			},
+			flow:     flow,
+			wantRule: "the implied deny ingress rule, no rule allows it",
+		},
+		{name: "implied egress allow", flow: requiredFlow{direction: egress, cidr: "172.16.0.2/32", protocol: "tcp", port: 443}, wantAllowed: true, wantRule: "the implied allow egress rule"},
+		{
+			name:     "all ports need a rule without ports",
+			rules:    []*compute.Firewall{{Name: "web", Priority: 1000, SourceRanges: []string{"10.4.0.0/14"}, TargetTags: []string{"web"}, Allowed: []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"80"}}}}},
+			flow:     requiredFlow{direction: ingress, cidr: "10.4.0.0/14", protocol: "tcp"},
+			wantRule: "the implied deny ingress rule, no rule allows it",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, rule := evaluateFlow(tt.rules, target, tt.flow)
+			if allowed != tt.wantAllowed || rule != tt.wantRule {
+				t.Errorf("evaluateFlow() = %t, %q, want %t, %q", allowed, rule, tt.wantAllowed, tt.wantRule)
+			}
+		})
+	}
+}
+
+func TestFirewallReport(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		Name:    "prod",
+		Id:      "0123abcd4567ef89",
+		Network: "vpc",
+		PrivateClusterConfig: &containerpb.PrivateClusterConfig{
+			MasterIpv4CidrBlock: "172.16.0.0/28",
+			PrivateEndpoint:     "172.16.0.2",
+		},
+		IpAllocationPolicy: &containerpb.IPAllocationPolicy{ClusterIpv4CidrBlock: "10.4.0.0/14"},
+	}
+	rules := []*compute.Firewall{
+		{Name: "gke-prod-0123abcd-master", Priority: 1000, SourceRanges: []string{"172.16.0.0/28"}, TargetTags: []string{"gke-prod-0123abcd-node"}, Allowed: []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"443", "10250"}}}},
+		{Name: "gke-prod-0123abcd-all", Priority: 1000, SourceRanges: []string{"10.4.0.0/14"}, TargetTags: []string{"gke-prod-0123abcd-node"}, Allowed: []*compute.FirewallAllowed{{IPProtocol: "tcp"}, {IPProtocol: "udp"}}},
+		{Name: "k8s-hc", Priority: 1000, SourceRanges: healthCheckRanges, TargetTags: []string{"gke-prod-0123abcd-node"}, Allowed: []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"10256"}}}},
+		{Name: "deny-egress", Direction: egress, Priority: 65000, DestinationRanges: []string{"0.0.0.0/0"}, Denied: []*compute.FirewallDenied{{IPProtocol: "all"}}},
+		{Name: "allow-api", Direction: egress, Priority: 1000, DestinationRanges: []string{"172.16.0.0/28"}, Allowed: []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"443"}}}},
+	}
+	webhooks := []webhookPort{{webhook: "validating/policy/policy.example.com", port: 8443}}
+
+	got := firewallReport(cluster, rules, webhooks, []string{"validating/named/named.example.com: the target port \"https\" is named."})
+	for _, want := range []string{
+		"Firewall rules applying to the nodes: 5 of 5\n",
+		"- Control plane to kubelet (ingress 172.16.0.0/28, tcp port 10250): allowed by gke-prod-0123abcd-master (priority 1000)\n",
+		"- Control plane to the webhook validating/policy/policy.example.com (ingress 172.16.0.0/28, tcp port 8443): BLOCKED by the implied deny ingress rule, no rule allows it\n",
+		"- Pods to nodes and pods (ingress 10.4.0.0/14, tcp all ports): allowed by gke-prod-0123abcd-all (priority 1000)\n",
+		"- Load balancer health checks (ingress 130.211.0.0/22, tcp port 10256): allowed by k8s-hc (priority 1000)\n",
+		"- Nodes to the API server (egress 172.16.0.2/32, tcp port 443): allowed by allow-api (priority 1000)\n",
+		"- Nodes to the konnectivity server (egress 172.16.0.2/32, tcp port 8132): BLOCKED by deny-egress (priority 65000)\n",
+		"- validating/named/named.example.com: the target port",
+		"- Control plane to the webhook validating/policy/policy.example.com: the admission requests",
+		"- Nodes to the konnectivity server: the konnectivity tunnel",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("firewallReport() missing %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestFirewallReport_PublicCluster(t *testing.T) {
+	got := firewallReport(&containerpb.Cluster{Name: "dev"}, nil, nil, nil)
+	for _, want := range []string{"the control plane to node rules are not checked", "Load balancer health checks (ingress 35.191.0.0/16, tcp port 10256): BLOCKED"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("firewallReport() missing %q, got:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networking
+
+import (
+	"context"
+	"fmt"
+	"math/bits"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	compute "google.golang.org/api/compute/v1"
+)
+
+const (
+	// defaultMaxPodsPerNode is used when neither the node pool nor the cluster set a max pods constraint.
+	defaultMaxPodsPerNode = 110
+	// subnetReservedAddresses is the number of addresses Google Cloud reserves in a subnet primary range.
+	subnetReservedAddresses = 4
+	// highUtilizationThreshold is the utilization ratio above which a range is reported as nearly exhausted.
+	highUtilizationThreshold = 0.8
+)
+
+var subnetworkRegexp = regexp.MustCompile(`projects/([^/]+)/regions/([^/]+)/subnetworks/([^/]+)$`)
+
+type getIPUtilizationArgs struct {
+	ProjectID string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location  string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name      string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+}
+
+// ipRangeUsage captures address usage of a single IP range.
+type ipRangeUsage struct {
+	description string
+	cidr        string
+	capacity    int64
+	used        int64
+	// surgeNeed is the number of additional addresses the most demanding node pool upgrade needs.
+	surgeNeed int64
+}
+
+func (h *handlers) getIPUtilization(ctx context.Context, _ *mcp.CallToolRequest, args *getIPUtilizationArgs) (*mcp.CallToolResult, any, error) {
+	if args.ProjectID == "" {
+		args.ProjectID = h.c.DefaultProjectID(ctx)
+	}
+	cluster, err := h.getCluster(ctx, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	svc, err := compute.NewService(ctx, h.c.RESTClientOptions(ctx)...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create compute client: %w", err)
+	}
+
+	poolSizes := map[string]int64{}
+	for _, pool := range cluster.GetNodePools() {
+		size, err := gke.NodePoolSize(ctx, svc, pool)
+		if err != nil {
+			return nil, nil, err
+		}
+		poolSizes[pool.GetName()] = size
+	}
+
+	subnetCIDR := ""
+	if m := subnetworkRegexp.FindStringSubmatch(cluster.GetNetworkConfig().GetSubnetwork()); m != nil {
+		subnet, err := svc.Subnetworks.Get(m[1], m[2], m[3]).Context(ctx).Do()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get subnetwork %s: %w", m[3], err)
+		}
+		subnetCIDR = subnet.IpCidrRange
+	}
+
+	// The Services are only known from the cluster, so require the right kubectl context.
+	var notes strings.Builder
+	var services kube.List[kube.Service]
+	if err := gke.RequireKubeContext(ctx, args.ProjectID, cluster); err != nil {
+		fmt.Fprintf(&notes, "\nServices were not read: %v\n", err)
+	} else if err := kube.Get(ctx, &services, "services", "--all-namespaces"); err != nil {
+		fmt.Fprintf(&notes, "\nServices were not read: %v\n", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: ipUtilizationReport(cluster, poolSizes, subnetCIDR, services.Items) + notes.String()},
+		},
+	}, nil, nil
+}
+
+// clusterIPServices returns the number of Services with an address allocated
+// from the Service range. Headless and ExternalName Services have none.
+func clusterIPServices(services []kube.Service) int64 {
+	var n int64
+	for _, s := range services {
+		if s.Spec.Type == "ExternalName" || s.Spec.ClusterIP == "" || s.Spec.ClusterIP == "None" {
+			continue
+		}
+		n++
+	}
+	return n
+}
+
+// cidrAddresses returns the number of addresses in an IPv4 CIDR block.
+func cidrAddresses(cidr string) (int64, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+	}
+	ones, size := ipNet.Mask.Size()
+	return int64(1) << (size - ones), nil
+}
+
+// podBlockAddresses returns the number of pod addresses GKE reserves for every node of the pool.
+func podBlockAddresses(cluster *containerpb.Cluster, pool *containerpb.NodePool) int64 {
+	if size := pool.GetPodIpv4CidrSize(); size > 0 {
+		return int64(1) << (32 - size)
+	}
+	maxPods := pool.GetMaxPodsConstraint().GetMaxPodsPerNode()
+	if maxPods == 0 {
+		maxPods = cluster.GetDefaultMaxPodsConstraint().GetMaxPodsPerNode()
+	}
+	if maxPods == 0 {
+		maxPods = defaultMaxPodsPerNode
+	}
+	// GKE allocates the smallest block holding at least twice the max pods per node.
+	// #nosec G115
+	return int64(1) << bits.Len64(uint64(2*maxPods-1))
+}
+
+// ipUtilizationReport reports the usage of the IP ranges of the cluster. The
+// Service range usage is only reported when services is not nil.
+func ipUtilizationReport(cluster *containerpb.Cluster, poolSizes map[string]int64, subnetCIDR string, services []kube.Service) string {
+	var ranges []*ipRangeUsage
+
+	var totalNodes, nodeSurgeNeed int64
+	podRanges := map[string]*ipRangeUsage{}
+	for _, pool := range cluster.GetNodePools() {
+		size := poolSizes[pool.GetName()]
+		surge := gke.SurgeNodes(pool, size)
+		totalNodes += size
+		nodeSurgeNeed = max(nodeSurgeNeed, surge)
+
+		name := cluster.GetIpAllocationPolicy().GetClusterSecondaryRangeName()
+		cidr := cluster.GetIpAllocationPolicy().GetClusterIpv4CidrBlock()
+		if cidr == "" {
+			cidr = cluster.GetClusterIpv4Cidr()
+		}
+		if poolCIDR := pool.GetNetworkConfig().GetPodIpv4CidrBlock(); poolCIDR != "" {
+			name = pool.GetNetworkConfig().GetPodRange()
+			cidr = poolCIDR
+		}
+		if cidr == "" {
+			continue
+		}
+		r, ok := podRanges[cidr]
+		if !ok {
+			capacity, err := cidrAddresses(cidr)
+			if err != nil {
+				continue
+			}
+			description := "Pod range"
+			if name != "" {
+				description = fmt.Sprintf("Pod range %q", name)
+			}
+			r = &ipRangeUsage{description: description, cidr: cidr, capacity: capacity}
+			podRanges[cidr] = r
+		}
+		block := podBlockAddresses(cluster, pool)
+		r.used += size * block
+		r.surgeNeed = max(r.surgeNeed, surge*block)
+	}
+
+	if subnetCIDR != "" {
+		if capacity, err := cidrAddresses(subnetCIDR); err == nil {
+			ranges = append(ranges, &ipRangeUsage{
+				description: "Node subnet",
+				cidr:        subnetCIDR,
+				capacity:    capacity - subnetReservedAddresses,
+				used:        totalNodes,
+				surgeNeed:   nodeSurgeNeed,
+			})
+		}
+	}
+
+	cidrs := make([]string, 0, len(podRanges))
+	for cidr := range podRanges {
+		cidrs = append(cidrs, cidr)
+	}
+	sort.Strings(cidrs)
+	for _, cidr := range cidrs {
+		ranges = append(ranges, podRanges[cidr])
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cluster: %s\n", cluster.GetName())
+	fmt.Fprintf(&b, "Nodes: %d\n", totalNodes)
+	if !cluster.GetIpAllocationPolicy().GetUseIpAliases() {
+		b.WriteString("The cluster is routes-based (not VPC-native).\n")
+	}
+
+	var warnings []string
+	for _, r := range ranges {
+		free := r.capacity - r.used
+		fmt.Fprintf(&b, "\n%s (%s): %d of %d addresses used (%.1f%%), %d free. The most demanding node pool upgrade needs %d more.\n",
+			r.description, r.cidr, r.used, r.capacity, 100*float64(r.used)/float64(r.capacity), free, r.surgeNeed)
+		if r.surgeNeed > free {
+			warnings = append(warnings, fmt.Sprintf("%s (%s) does not have enough free addresses for a surge or blue-green upgrade: %d needed, %d free.", r.description, r.cidr, r.surgeNeed, free))
+		} else if float64(r.used) > highUtilizationThreshold*float64(r.capacity) {
+			warnings = append(warnings, fmt.Sprintf("%s (%s) is more than %.0f%% utilized; node pool recreation or scale up could exhaust it.", r.description, r.cidr, 100*highUtilizationThreshold))
+		}
+	}
+
+	if utilization := cluster.GetIpAllocationPolicy().GetDefaultPodIpv4RangeUtilization(); utilization > 0 {
+		fmt.Fprintf(&b, "\nDefault pod range utilization reported by GKE: %.1f%%\n", 100*utilization)
+	}
+	for _, info := range cluster.GetIpAllocationPolicy().GetAdditionalPodRangesConfig().GetPodRangeInfo() {
+		fmt.Fprintf(&b, "Additional pod range %q utilization reported by GKE: %.1f%%\n", info.GetRangeName(), 100*info.GetUtilization())
+	}
+
+	if cidr := cluster.GetIpAllocationPolicy().GetServicesIpv4CidrBlock(); cidr != "" {
+		if capacity, err := cidrAddresses(cidr); err == nil {
+			if services == nil {
+				fmt.Fprintf(&b, "\nService range (%s): %d addresses.\n", cidr, capacity)
+			} else {
+				used := clusterIPServices(services)
+				fmt.Fprintf(&b, "\nService range (%s): %d of %d addresses used (%.1f%%), %d free.\n",
+					cidr, used, capacity, 100*float64(used)/float64(capacity), capacity-used)
+				if float64(used) > highUtilizationThreshold*float64(capacity) {
+					warnings = append(warnings, fmt.Sprintf("Service range (%s) is more than %.0f%% utilized; new Services could fail to get a ClusterIP.", cidr, 100*highUtilizationThreshold))
+				}
+			}
+		}
+	}
+
+	if len(warnings) == 0 {
+		b.WriteString("\nNo IP exhaustion risks found.\n")
+		return b.String()
+	}
+	b.WriteString("\nWarnings:\n")
+	for _, w := range warnings {
+		fmt.Fprintf(&b, "- %s\n", w)
+	}
+	return b.String()
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networking
+
+import (
+	"strings"
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+)
+
+func TestCIDRAddresses(t *testing.T) {
+	tests := []struct {
+		cidr    string
+		want    int64
+		wantErr bool
+	}{
+		{cidr: "10.0.0.0/24", want: 256},
+		{cidr: "10.4.0.0/14", want: 262144},
+		{cidr: "invalid", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			got, err := cidrAddresses(tt.cidr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("cidrAddresses() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("cidrAddresses() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPodBlockAddresses(t *testing.T) {
+	tests := []struct {
+		name    string
+		cluster *containerpb.Cluster
+		pool    *containerpb.NodePool
+		want    int64
+	}{
+		{
+			name:    "defaults",
+			cluster: &containerpb.Cluster{},
+			pool:    &containerpb.NodePool{},
+			want:    256,
+		},
+		{
+			name:    "explicit pod cidr size",
+			cluster: &containerpb.Cluster{},
+			pool:    &containerpb.NodePool{PodIpv4CidrSize: 26},
+			want:    64,
+		},
+		{
+			name:    "node pool max pods",
+			cluster: &containerpb.Cluster{},
+			pool:    &containerpb.NodePool{MaxPodsConstraint: &containerpb.MaxPodsConstraint{MaxPodsPerNode: 32}},
+			want:    64,
+		},
+		{
+			name:    "cluster default max pods",
+			cluster: &containerpb.Cluster{DefaultMaxPodsConstraint: &containerpb.MaxPodsConstraint{MaxPodsPerNode: 8}},
+			pool:    &containerpb.NodePool{},
+			want:    16,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := podBlockAddresses(tt.cluster, tt.pool); got != tt.want {
+				t.Errorf("podBlockAddresses() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIPUtilizationReport(t *testing.T) {
+	t.Run("healthy cluster", func(t *testing.T) {
+		cluster := &containerpb.Cluster{
+			Name: "my-cluster",
+			IpAllocationPolicy: &containerpb.IPAllocationPolicy{
+				UseIpAliases:          true,
+				ClusterIpv4CidrBlock:  "10.4.0.0/14",
+				ServicesIpv4CidrBlock: "10.8.0.0/20",
+			},
+			NodePools: []*containerpb.NodePool{
+				{Name: "default-pool", Locations: []string{"us-central1-a"}},
+			},
+		}
+		services := []kube.Service{
+			{Spec: kube.ServiceSpec{Type: "ClusterIP", ClusterIP: "10.8.0.1"}},
+			{Spec: kube.ServiceSpec{Type: "LoadBalancer", ClusterIP: "10.8.0.2"}},
+			{Spec: kube.ServiceSpec{Type: "ClusterIP", ClusterIP: "None"}},
+			{Spec: kube.ServiceSpec{Type: "ExternalName"}},
+		}
+		got := ipUtilizationReport(cluster, map[string]int64{"default-pool": 3}, "10.0.0.0/20", services)
+		for _, want := range []string{
+			"Node subnet (10.0.0.0/20): 3 of 4092 addresses used",
+			"Pod range (10.4.0.0/14): 768 of 262144 addresses used",
+			"Service range (10.8.0.0/20): 2 of 4096 addresses used (0.0%), 4094 free.",
+			"No IP exhaustion risks found.",
+		} {
+			if !strings.Contains(got, want) {
+				t.Errorf("ipUtilizationReport() = %q, want to contain %q", got, want)
+			}
+		}
+	})
+
+	t.Run("exhausted pod range", func(t *testing.T) {
+		cluster := &containerpb.Cluster{
+			Name: "my-cluster",
+			IpAllocationPolicy: &containerpb.IPAllocationPolicy{
+				UseIpAliases:              true,
+				ClusterSecondaryRangeName: "pods",
+				ClusterIpv4CidrBlock:      "10.4.0.0/22",
+			},
+			NodePools: []*containerpb.NodePool{
+				{Name: "default-pool", Locations: []string{"us-central1-a"}},
+			},
+		}
+		got := ipUtilizationReport(cluster, map[string]int64{"default-pool": 4}, "", nil)
+		if !strings.Contains(got, `Pod range "pods" (10.4.0.0/22) does not have enough free addresses`) {
+			t.Errorf("ipUtilizationReport() = %q, want pod range exhaustion warning", got)
+		}
+	})
+
+	t.Run("nearly full service range", func(t *testing.T) {
+		cluster := &containerpb.Cluster{
+			Name: "my-cluster",
+			IpAllocationPolicy: &containerpb.IPAllocationPolicy{
+				UseIpAliases:          true,
+				ServicesIpv4CidrBlock: "10.8.0.0/30",
+			},
+		}
+		services := []kube.Service{
+			{Spec: kube.ServiceSpec{Type: "ClusterIP", ClusterIP: "10.8.0.1"}},
+			{Spec: kube.ServiceSpec{Type: "ClusterIP", ClusterIP: "10.8.0.2"}},
+			{Spec: kube.ServiceSpec{Type: "NodePort", ClusterIP: "10.8.0.3"}},
+			{Spec: kube.ServiceSpec{Type: "ClusterIP", ClusterIP: "10.8.0.0"}},
+		}
+		got := ipUtilizationReport(cluster, nil, "", services)
+		for _, want := range []string{
+			"Service range (10.8.0.0/30): 4 of 4 addresses used (100.0%), 0 free.",
+			"Service range (10.8.0.0/30) is more than 80% utilized",
+		} {
+			if !strings.Contains(got, want) {
+				t.Errorf("ipUtilizationReport() = %q, want to contain %q", got, want)
+			}
+		}
+	})
+
+	t.Run("services not read", func(t *testing.T) {
+		cluster := &containerpb.Cluster{
+			Name: "my-cluster",
+			IpAllocationPolicy: &containerpb.IPAllocationPolicy{
+				UseIpAliases:          true,
+				ServicesIpv4CidrBlock: "10.8.0.0/20",
+			},
+		}
+		got := ipUtilizationReport(cluster, nil, "", nil)
+		if !strings.Contains(got, "Service range (10.8.0.0/20): 4096 addresses.") {
+			t.Errorf("ipUtilizationReport() = %q, want service range capacity", got)
+		}
+	})
+
+	t.Run("additional pod range", func(t *testing.T) {
+		cluster := &containerpb.Cluster{
+			Name: "my-cluster",
+			IpAllocationPolicy: &containerpb.IPAllocationPolicy{
+				UseIpAliases:         true,
+				ClusterIpv4CidrBlock: "10.4.0.0/14",
+			},
+			NodePools: []*containerpb.NodePool{
+				{Name: "default-pool"},
+				{
+					Name: "extra-pool",
+					NetworkConfig: &containerpb.NodeNetworkConfig{
+						PodRange:         "extra-pods",
+						PodIpv4CidrBlock: "10.20.0.0/24",
+					},
+				},
+			},
+		}
+		got := ipUtilizationReport(cluster, map[string]int64{"default-pool": 1, "extra-pool": 1}, "", nil)
+		if !strings.Contains(got, `Pod range "extra-pods" (10.20.0.0/24): 256 of 256 addresses used`) {
+			t.Errorf("ipUtilizationReport() = %q, want additional pod range usage", got)
+		}
+	})
+}
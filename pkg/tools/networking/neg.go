@@ -0,0 +1,218 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networking
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	compute "google.golang.org/api/compute/v1"
+)
+
+// negStatusAnnotation is set by the NEG controller on the Services with
+// network endpoint groups, with their names by Service port and their zones.
+const negStatusAnnotation = "cloud.google.com/neg-status"
+
+type checkNEGHealthArgs struct {
+	ProjectID string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location  string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name      string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+}
+
+// serviceNEG is a network endpoint group of a Service port.
+type serviceNEG struct {
+	service string
+	port    string
+	name    string
+	zones   []string
+}
+
+// negBackend is the health of the endpoints of a NEG in a backend service.
+type negBackend struct {
+	backendService string
+	healthy        int
+	// unhealthy are the endpoints not healthy, as ip:port (state).
+	unhealthy []string
+}
+
+func (h *handlers) checkNEGHealth(ctx context.Context, _ *mcp.CallToolRequest, args *checkNEGHealthArgs) (*mcp.CallToolResult, any, error) {
+	if args.ProjectID == "" {
+		args.ProjectID = h.c.DefaultProjectID(ctx)
+	}
+	cluster, err := h.getCluster(ctx, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The NEGs are only known from the Services, so require the right kubectl context.
+	if err := gke.RequireKubeContext(ctx, args.ProjectID, cluster); err != nil {
+		return nil, nil, err
+	}
+	var services kube.List[kube.Service]
+	if err := kube.Get(ctx, &services, "services", "--all-namespaces"); err != nil {
+		return nil, nil, err
+	}
+	negs := serviceNEGs(services.Items)
+	names := map[string]bool{}
+	for _, neg := range negs {
+		names[neg.name] = true
+	}
+
+	svc, err := compute.NewService(ctx, h.c.RESTClientOptions(ctx)...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create compute client: %w", err)
+	}
+	var backendServices []*compute.BackendService
+	if err := svc.BackendServices.AggregatedList(args.ProjectID).Pages(ctx, func(l *compute.BackendServiceAggregatedList) error {
+		for _, scoped := range l.Items {
+			backendServices = append(backendServices, scoped.BackendServices...)
+		}
+		return nil
+	}); err != nil {
+		return nil, nil, fmt.Errorf("failed to list backend services: %w", err)
+	}
+
+	backends := map[string][]negBackend{}
+	for _, bs := range backendServices {
+		// A NEG has one group per zone in the backend service, whose health is summed.
+		byNEG := map[string]*negBackend{}
+		for _, backend := range bs.Backends {
+			name := path.Base(backend.Group)
+			if !strings.Contains(backend.Group, "/networkEndpointGroups/") || !names[name] {
+				continue
+			}
+			ref := &compute.ResourceGroupReference{Group: backend.Group}
+			var health *compute.BackendServiceGroupHealth
+			if bs.Region == "" {
+				health, err = svc.BackendServices.GetHealth(args.ProjectID, bs.Name, ref).Context(ctx).Do()
+			} else {
+				health, err = svc.RegionBackendServices.GetHealth(args.ProjectID, path.Base(bs.Region), bs.Name, ref).Context(ctx).Do()
+			}
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get the health of backend service %s: %w", bs.Name, err)
+			}
+			b, ok := byNEG[name]
+			if !ok {
+				b = &negBackend{backendService: bs.Name}
+				byNEG[name] = b
+			}
+			addHealth(b, health.HealthStatus)
+		}
+		for name, b := range byNEG {
+			backends[name] = append(backends[name], *b)
+		}
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: negHealthReport(cluster, negs, backends)},
+		},
+	}, nil, nil
+}
+
+// serviceNEGs returns the NEGs of the Services from their neg-status annotation.
+func serviceNEGs(services []kube.Service) []serviceNEG {
+	var negs []serviceNEG
+	for _, s := range services {
+		var status struct {
+			NetworkEndpointGroups map[string]string `json:"network_endpoint_groups"`
+			Zones                 []string          `json:"zones"`
+		}
+		if err := json.Unmarshal([]byte(s.Annotations[negStatusAnnotation]), &status); err != nil {
+			continue
+		}
+		for _, port := range slices.Sorted(maps.Keys(status.NetworkEndpointGroups)) {
+			negs = append(negs, serviceNEG{
+				service: s.Namespace + "/" + s.Name,
+				port:    port,
+				name:    status.NetworkEndpointGroups[port],
+				zones:   status.Zones,
+			})
+		}
+	}
+	return negs
+}
+
+// addHealth adds the health of endpoints to the backend.
+func addHealth(b *negBackend, statuses []*compute.HealthStatus) {
+	for _, s := range statuses {
+		if s.HealthState == "HEALTHY" {
+			b.healthy++
+			continue
+		}
+		state := s.HealthState
+		if state == "" {
+			state = "UNKNOWN"
+		}
+		b.unhealthy = append(b.unhealthy, fmt.Sprintf("%s:%d (%s)", s.IpAddress, s.Port, state))
+	}
+}
+
+func negHealthReport(cluster *containerpb.Cluster, negs []serviceNEG, backends map[string][]negBackend) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cluster: %s\n", cluster.GetName())
+	if len(negs) == 0 {
+		b.WriteString("No Service has network endpoint groups: the cluster has no container-native load balancing.\n")
+		return b.String()
+	}
+
+	var failing, degraded []string
+	fmt.Fprintf(&b, "\nNetwork endpoint groups of Services (%d):\n", len(negs))
+	for _, neg := range negs {
+		fmt.Fprintf(&b, "- %s port %s: NEG %s in %s\n", neg.service, neg.port, neg.name, strings.Join(neg.zones, ", "))
+		attached := backends[neg.name]
+		if len(attached) == 0 {
+			b.WriteString("  Not used by a backend service: no load balancer sends traffic to it yet, or its Ingress or Gateway was deleted.\n")
+			continue
+		}
+		for _, backend := range attached {
+			total := backend.healthy + len(backend.unhealthy)
+			fmt.Fprintf(&b, "  Backend service %s: %d/%d endpoints healthy\n", backend.backendService, backend.healthy, total)
+			if len(backend.unhealthy) > 0 {
+				fmt.Fprintf(&b, "  Not healthy: %s\n", strings.Join(backend.unhealthy, ", "))
+			}
+			name := fmt.Sprintf("%s port %s (%s)", neg.service, neg.port, backend.backendService)
+			switch {
+			case backend.healthy == 0:
+				failing = append(failing, name)
+			case len(backend.unhealthy) > 0:
+				degraded = append(degraded, name)
+			}
+		}
+	}
+
+	if len(failing) == 0 && len(degraded) == 0 {
+		b.WriteString("\nAll the endpoints of the NEGs of the cluster are healthy.\n")
+		return b.String()
+	}
+	b.WriteString("\n")
+	if len(failing) > 0 {
+		fmt.Fprintf(&b, "Backends without healthy endpoints, which serve no traffic: %s.\n", strings.Join(failing, ", "))
+	}
+	if len(degraded) > 0 {
+		fmt.Fprintf(&b, "Backends with endpoints not healthy: %s. Right after node upgrades, endpoints of pods being recreated are expected to recover within minutes.\n", strings.Join(degraded, ", "))
+	}
+	b.WriteString("Check the readiness of the pods, the health check of the backend service against the serving port of the pods, and the firewall rules allowing the health check ranges to reach the pods.\n")
+	return b.String()
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networking
+
+import (
+	"strings"
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	compute "google.golang.org/api/compute/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestServiceNEGs(t *testing.T) {
+	services := []kube.Service{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web", Annotations: map[string]string{
+			negStatusAnnotation: `{"network_endpoint_groups":{"8080":"k8s1-web-8080","80":"k8s1-web-80"},"zones":["us-central1-a","us-central1-b"]}`,
+		}}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "db"}},
+	}
+
+	got := serviceNEGs(services)
+	if len(got) != 2 || got[0].port != "80" || got[0].name != "k8s1-web-80" || got[1].name != "k8s1-web-8080" || got[0].service != "shop/web" || len(got[0].zones) != 2 {
+		t.Errorf("serviceNEGs() = %+v", got)
+	}
+}
+
+func TestNEGHealthReport(t *testing.T) {
+	negs := []serviceNEG{
+		{service: "shop/web", port: "80", name: "k8s1-web-80", zones: []string{"us-central1-a", "us-central1-b"}},
+		{service: "shop/api", port: "8080", name: "k8s1-api-8080", zones: []string{"us-central1-a"}},
+		{service: "shop/admin", port: "80", name: "k8s1-admin-80", zones: []string{"us-central1-a"}},
+		{service: "shop/new", port: "80", name: "k8s1-new-80", zones: []string{"us-central1-a"}},
+	}
+	web := negBackend{backendService: "k8s1-web-bs"}
+	addHealth(&web, []*compute.HealthStatus{
+		{IpAddress: "10.4.0.5", Port: 8080, HealthState: "HEALTHY"},
+		{IpAddress: "10.4.1.5", Port: 8080, HealthState: "UNHEALTHY"},
+	})
+	api := negBackend{backendService: "k8s1-api-bs"}
+	addHealth(&api, []*compute.HealthStatus{{IpAddress: "10.4.2.5", Port: 8080}})
+	backends := map[string][]negBackend{
+		"k8s1-web-80":   {web},
+		"k8s1-api-8080": {api},
+		"k8s1-admin-80": {{backendService: "k8s1-admin-bs"}},
+	}
+
+	got := negHealthReport(&containerpb.Cluster{Name: "prod"}, negs, backends)
+	for _, want := range []string{
+		"Network endpoint groups of Services (4):\n",
+		"- shop/web port 80: NEG k8s1-web-80 in us-central1-a, us-central1-b\n  Backend service k8s1-web-bs: 1/2 endpoints healthy\n  Not healthy: 10.4.1.5:8080 (UNHEALTHY)\n",
+		"  Backend service k8s1-api-bs: 0/1 endpoints healthy\n  Not healthy: 10.4.2.5:8080 (UNKNOWN)\n",
+		"  Backend service k8s1-admin-bs: 0/0 endpoints healthy\n",
+		"- shop/new port 80: NEG k8s1-new-80 in us-central1-a\n  Not used by a backend service",
+		"Backends without healthy endpoints, which serve no traffic: shop/api port 8080 (k8s1-api-bs), shop/admin port 80 (k8s1-admin-bs).\n",
+		"Backends with endpoints not healthy: shop/web port 80 (k8s1-web-bs).",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("negHealthReport() missing %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestNEGHealthReport_NoNEGs(t *testing.T) {
+	got := negHealthReport(&containerpb.Cluster{Name: "prod"}, nil, nil)
+	if !strings.Contains(got, "No Service has network endpoint groups") {
+		t.Errorf("negHealthReport() = %q", got)
+	}
+}
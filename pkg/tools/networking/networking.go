@@ -0,0 +1,99 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package networking provides MCP tools for inspecting GKE cluster networking.
+package networking
+
+import (
+	"context"
+	"fmt"
+
+	container "cloud.google.com/go/container/apiv1"
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type handlers struct {
+	c        *config.Config
+	cmClient *container.ClusterManagerClient
+}
+
+// Install registers networking tools with the MCP server.
+func Install(ctx context.Context, s *mcp.Server, c *config.Config) error {
+	cmClient, err := container.NewClusterManagerClient(ctx, c.ClientOptions()...)
+	if err != nil {
+		return fmt.Errorf("failed to create cluster manager client: %w", err)
+	}
+
+	h := &handlers{
+		c:        c,
+		cmClient: cmClient,
+	}
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "check_dns_provider",
+		Description: "Detect whether a GKE cluster uses kube-dns or Cloud DNS and flag DNS-related changes in GKE release notes between the cluster's current version and a target version. Prefer to use this tool instead of gcloud when assessing DNS upgrade impact.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.checkDNSProvider)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "check_dataplane_readiness",
+		Description: "Report whether a GKE cluster uses Dataplane V2 or Calico, whether NetworkPolicy is enforced, and flag network datapath changes in GKE release notes between the cluster's current version and a target version.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.checkDataplaneReadiness)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "get_ip_utilization",
+		Description: "Compute node subnet, pod and service IP range utilization for a GKE cluster, including secondary ranges, with the ClusterIP Services read with the current kubectl context, and warn when surge upgrades or node pool recreation could exhaust IP addresses.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.getIPUtilization)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "review_private_networking",
+		Description: "Review the private networking of a GKE cluster: private nodes, public and private control plane endpoints, master authorized networks, VPC Network Peering or Private Service Connect, Private Google Access and Cloud NAT of the node subnetwork, flagging the settings which break the registration of new nodes, e.g. during upgrades, or expose the control plane.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.reviewPrivateNetworking)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "validate_firewall_rules",
+		Description: "Validate that the VPC firewall rules allow the traffic of the nodes of a GKE cluster which GKE features need: control plane to kubelet and to admission webhooks, including webhooks on ports other than 443 read with the current kubectl context, pod to pod, load balancer health checks, and node egress to the control plane. Reports the rule allowing or blocking each flow, since blocked flows show up as admission failures or NotReady nodes after upgrades.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.validateFirewallRules)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "check_neg_health",
+		Description: "Check the health of the network endpoint groups (NEGs) of the Services of a GKE cluster, read with the current kubectl context, in the backend services of their load balancers: healthy endpoints, endpoints not healthy and NEGs used by no backend service. Use it after upgrades to confirm that the traffic serving paths recovered once the nodes were recreated.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.checkNEGHealth)
+
+	return nil
+}
+
+func (h *handlers) getCluster(ctx context.Context, projectID, location, name string) (*containerpb.Cluster, error) {
+	return gke.GetCluster(ctx, h.cmClient, h.c, projectID, location, name)
+}
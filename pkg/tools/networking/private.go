@@ -0,0 +1,213 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networking
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	compute "google.golang.org/api/compute/v1"
+)
+
+type reviewPrivateNetworkingArgs struct {
+	ProjectID string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location  string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name      string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+}
+
+func (h *handlers) reviewPrivateNetworking(ctx context.Context, _ *mcp.CallToolRequest, args *reviewPrivateNetworkingArgs) (*mcp.CallToolResult, any, error) {
+	cluster, err := h.getCluster(ctx, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	svc, err := compute.NewService(ctx, h.c.RESTClientOptions(ctx)...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create compute client: %w", err)
+	}
+
+	var subnet *compute.Subnetwork
+	var routers []*compute.Router
+	if m := subnetworkRegexp.FindStringSubmatch(cluster.GetNetworkConfig().GetSubnetwork()); m != nil {
+		subnet, err = svc.Subnetworks.Get(m[1], m[2], m[3]).Context(ctx).Do()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get subnetwork %s: %w", m[3], err)
+		}
+		if err := svc.Routers.List(m[1], m[2]).Pages(ctx, func(resp *compute.RouterList) error {
+			routers = append(routers, resp.Items...)
+			return nil
+		}); err != nil {
+			return nil, nil, fmt.Errorf("failed to list Cloud Routers of region %s: %w", m[2], err)
+		}
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: privateNetworkingReport(cluster, subnet, routers)},
+		},
+	}, nil, nil
+}
+
+// privateNodes reports whether the nodes of the cluster, or of some of its
+// node pools, have no external IP address.
+func privateNodes(cluster *containerpb.Cluster) bool {
+	if cluster.GetPrivateClusterConfig().GetEnablePrivateNodes() || cluster.GetNetworkConfig().GetDefaultEnablePrivateNodes() {
+		return true
+	}
+	for _, pool := range cluster.GetNodePools() {
+		if pool.GetNetworkConfig().GetEnablePrivateNodes() {
+			return true
+		}
+	}
+	return false
+}
+
+// authorizedNetworks returns the master authorized networks of the cluster,
+// from the control plane endpoints configuration when it's set.
+func authorizedNetworks(cluster *containerpb.Cluster) *containerpb.MasterAuthorizedNetworksConfig {
+	if config := cluster.GetControlPlaneEndpointsConfig().GetIpEndpointsConfig().GetAuthorizedNetworksConfig(); config != nil {
+		return config
+	}
+	return cluster.GetMasterAuthorizedNetworksConfig()
+}
+
+// publicEndpointEnabled reports whether the control plane has an external IP
+// endpoint.
+func publicEndpointEnabled(cluster *containerpb.Cluster) bool {
+	if ip := cluster.GetControlPlaneEndpointsConfig().GetIpEndpointsConfig(); ip != nil && ip.EnablePublicEndpoint != nil {
+		return ip.GetEnablePublicEndpoint()
+	}
+	return !cluster.GetPrivateClusterConfig().GetEnablePrivateEndpoint()
+}
+
+// natCovers returns the NAT gateways giving internet access to the primary
+// range of the subnetwork, as router/gateway.
+func natCovers(routers []*compute.Router, subnet *compute.Subnetwork) []string {
+	var gateways []string
+	for _, router := range routers {
+		if router.Network != subnet.Network {
+			continue
+		}
+		for _, nat := range router.Nats {
+			if nat.Type == "PRIVATE" {
+				continue
+			}
+			covered := false
+			switch nat.SourceSubnetworkIpRangesToNat {
+			case "ALL_SUBNETWORKS_ALL_IP_RANGES", "ALL_SUBNETWORKS_ALL_PRIMARY_IP_RANGES":
+				covered = true
+			case "LIST_OF_SUBNETWORKS":
+				for _, s := range nat.Subnetworks {
+					covered = covered || s.Name == subnet.SelfLink
+				}
+			}
+			if covered {
+				gateways = append(gateways, fmt.Sprintf("%s/%s (min %d ports per VM, dynamic port allocation %t)", router.Name, nat.Name, nat.MinPortsPerVm, nat.EnableDynamicPortAllocation))
+			}
+		}
+	}
+	return gateways
+}
+
+func privateNetworkingReport(cluster *containerpb.Cluster, subnet *compute.Subnetwork, routers []*compute.Router) string {
+	var b strings.Builder
+	var warnings []string
+	fmt.Fprintf(&b, "Cluster: %s\n", cluster.GetName())
+
+	private := privateNodes(cluster)
+	fmt.Fprintf(&b, "Private nodes: %t\n", private)
+
+	pcc := cluster.GetPrivateClusterConfig()
+	public := publicEndpointEnabled(cluster)
+	fmt.Fprintf(&b, "Public endpoint: %t", public)
+	if endpoint := pcc.GetPublicEndpoint(); public && endpoint != "" {
+		fmt.Fprintf(&b, " (%s)", endpoint)
+	}
+	b.WriteString("\n")
+	if endpoint := pcc.GetPrivateEndpoint(); endpoint != "" {
+		fmt.Fprintf(&b, "Private endpoint: %s, global access %t\n", endpoint, pcc.GetMasterGlobalAccessConfig().GetEnabled())
+	}
+	if dns := cluster.GetControlPlaneEndpointsConfig().GetDnsEndpointConfig(); dns.GetEndpoint() != "" {
+		fmt.Fprintf(&b, "DNS endpoint: %s, external traffic allowed %t\n", dns.GetEndpoint(), dns.GetAllowExternalTraffic())
+	}
+	switch {
+	case pcc.GetPeeringName() != "":
+		fmt.Fprintf(&b, "Control plane connection: VPC Network Peering %s, control plane range %s\n", pcc.GetPeeringName(), pcc.GetMasterIpv4CidrBlock())
+	case pcc.GetPrivateEndpoint() != "":
+		b.WriteString("Control plane connection: Private Service Connect\n")
+		if subnetwork := pcc.GetPrivateEndpointSubnetwork(); subnetwork != "" {
+			fmt.Fprintf(&b, "Private endpoint subnetwork: %s\n", subnetwork)
+		}
+	}
+
+	authorized := authorizedNetworks(cluster)
+	if !authorized.GetEnabled() {
+		b.WriteString("Master authorized networks: disabled\n")
+		if public {
+			warnings = append(warnings, "The public endpoint accepts connections from any IP address; enable master authorized networks to restrict access to the control plane.")
+		}
+	} else {
+		var blocks []string
+		for _, block := range authorized.GetCidrBlocks() {
+			blocks = append(blocks, fmt.Sprintf("%s (%s)", block.GetCidrBlock(), block.GetDisplayName()))
+		}
+		if len(blocks) == 0 {
+			blocks = append(blocks, "none")
+		}
+		fmt.Fprintf(&b, "Master authorized networks: %s\n", strings.Join(blocks, ", "))
+		fmt.Fprintf(&b, "Google Cloud public IP addresses allowed: %t, enforced on the private endpoint: %t\n", authorized.GetGcpPublicCidrsAccessEnabled(), authorized.GetPrivateEndpointEnforcementEnabled())
+		if authorized.GetGcpPublicCidrsAccessEnabled() {
+			warnings = append(warnings, "The public IP addresses of Google Cloud, including the Compute Engine VMs of other customers, can reach the control plane; disable it unless a Google Cloud service outside of the VPC needs access.")
+		}
+		if authorized.GetPrivateEndpointEnforcementEnabled() {
+			for _, pool := range cluster.GetNodePools() {
+				if subnetwork := pool.GetNetworkConfig().GetSubnetwork(); subnetwork != "" && subnetwork != cluster.GetNetworkConfig().GetSubnetwork() {
+					warnings = append(warnings, fmt.Sprintf("Node pool %s uses the subnetwork %s and the authorized networks are enforced on the private endpoint; check that its range is authorized, otherwise its new nodes fail to register.", pool.GetName(), subnetwork))
+				}
+			}
+		}
+	}
+
+	if subnet == nil {
+		b.WriteString("\nThe node subnetwork of the cluster is unknown, Private Google Access and Cloud NAT are not checked.\n")
+	} else {
+		fmt.Fprintf(&b, "\nNode subnetwork: %s (%s), Private Google Access %t\n", subnet.Name, subnet.IpCidrRange, subnet.PrivateIpGoogleAccess)
+		gateways := natCovers(routers, subnet)
+		if len(gateways) == 0 {
+			b.WriteString("Cloud NAT: none covers the node subnetwork\n")
+		} else {
+			fmt.Fprintf(&b, "Cloud NAT: %s\n", strings.Join(gateways, ", "))
+		}
+		if private && !subnet.PrivateIpGoogleAccess {
+			warnings = append(warnings, fmt.Sprintf("Private Google Access is disabled on the subnetwork %s: private nodes can't reach the Google APIs, and new nodes created by upgrades can fail to pull the system images of their version and to register with the cluster.", subnet.Name))
+		}
+		if private && len(gateways) == 0 {
+			warnings = append(warnings, "No Cloud NAT covers the node subnetwork: private nodes can't reach the internet, so images from registries outside of Google Cloud, e.g. Docker Hub, fail to pull on new nodes.")
+		}
+	}
+
+	if len(warnings) == 0 {
+		b.WriteString("\nNo private networking settings breaking the control plane access or the registration of new nodes were found.\n")
+		return b.String()
+	}
+	b.WriteString("\nWarnings:\n")
+	for _, w := range warnings {
+		fmt.Fprintf(&b, "- %s\n", w)
+	}
+	return b.String()
+}
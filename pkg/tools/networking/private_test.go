@@ -0,0 +1,110 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networking
+
+import (
+	"strings"
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	testNetwork    = "https://www.googleapis.com/compute/v1/projects/p/global/networks/vpc"
+	testSubnetwork = "https://www.googleapis.com/compute/v1/projects/p/regions/us-central1/subnetworks/nodes"
+)
+
+func TestPrivateNetworkingReport(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		Name: "my-cluster",
+		PrivateClusterConfig: &containerpb.PrivateClusterConfig{
+			EnablePrivateNodes: true,
+			PrivateEndpoint:    "10.0.0.2",
+			PublicEndpoint:     "34.1.2.3",
+		},
+		MasterAuthorizedNetworksConfig: &containerpb.MasterAuthorizedNetworksConfig{
+			Enabled:                           true,
+			CidrBlocks:                        []*containerpb.MasterAuthorizedNetworksConfig_CidrBlock{{CidrBlock: "203.0.113.0/24", DisplayName: "office"}},
+			GcpPublicCidrsAccessEnabled:       proto.Bool(true),
+			PrivateEndpointEnforcementEnabled: proto.Bool(true),
+		},
+		NetworkConfig: &containerpb.NetworkConfig{Subnetwork: "projects/p/regions/us-central1/subnetworks/nodes"},
+		NodePools: []*containerpb.NodePool{
+			{Name: "default-pool"},
+			{Name: "batch", NetworkConfig: &containerpb.NodeNetworkConfig{Subnetwork: "projects/p/regions/us-central1/subnetworks/batch"}},
+		},
+	}
+	subnet := &compute.Subnetwork{Name: "nodes", IpCidrRange: "10.128.0.0/20", Network: testNetwork, SelfLink: testSubnetwork}
+	routers := []*compute.Router{
+		{Name: "other-vpc", Network: "https://www.googleapis.com/compute/v1/projects/p/global/networks/other", Nats: []*compute.RouterNat{{Name: "nat", SourceSubnetworkIpRangesToNat: "ALL_SUBNETWORKS_ALL_IP_RANGES"}}},
+		{Name: "router", Network: testNetwork, Nats: []*compute.RouterNat{{Name: "private-nat", Type: "PRIVATE", SourceSubnetworkIpRangesToNat: "ALL_SUBNETWORKS_ALL_IP_RANGES"}}},
+	}
+
+	got := privateNetworkingReport(cluster, subnet, routers)
+	for _, want := range []string{
+		"Private nodes: true\n",
+		"Public endpoint: true (34.1.2.3)\n",
+		"Control plane connection: Private Service Connect\n",
+		"Master authorized networks: 203.0.113.0/24 (office)\n",
+		"Node subnetwork: nodes (10.128.0.0/20), Private Google Access false\n",
+		"Cloud NAT: none covers the node subnetwork\n",
+		"The public IP addresses of Google Cloud",
+		"Node pool batch uses the subnetwork projects/p/regions/us-central1/subnetworks/batch",
+		"Private Google Access is disabled on the subnetwork nodes",
+		"No Cloud NAT covers the node subnetwork",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("privateNetworkingReport() missing %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "default-pool") {
+		t.Errorf("privateNetworkingReport() flags a node pool of the cluster subnetwork, got:\n%s", got)
+	}
+}
+
+func TestPrivateNetworkingReport_NoWarnings(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		Name: "my-cluster",
+		PrivateClusterConfig: &containerpb.PrivateClusterConfig{
+			EnablePrivateNodes:    true,
+			EnablePrivateEndpoint: true,
+			PrivateEndpoint:       "172.16.0.2",
+			PeeringName:           "gke-n1234-peer",
+			MasterIpv4CidrBlock:   "172.16.0.0/28",
+		},
+	}
+	subnet := &compute.Subnetwork{Name: "nodes", Network: testNetwork, SelfLink: testSubnetwork, PrivateIpGoogleAccess: true}
+	routers := []*compute.Router{{Name: "router", Network: testNetwork, Nats: []*compute.RouterNat{{
+		Name:                          "nat",
+		SourceSubnetworkIpRangesToNat: "LIST_OF_SUBNETWORKS",
+		Subnetworks:                   []*compute.RouterNatSubnetworkToNat{{Name: testSubnetwork}},
+		MinPortsPerVm:                 64,
+	}}}}
+
+	got := privateNetworkingReport(cluster, subnet, routers)
+	for _, want := range []string{
+		"Public endpoint: false\n",
+		"Control plane connection: VPC Network Peering gke-n1234-peer, control plane range 172.16.0.0/28\n",
+		"Master authorized networks: disabled\n",
+		"Cloud NAT: router/nat (min 64 ports per VM, dynamic port allocation false)\n",
+		"No private networking settings",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("privateNetworkingReport() missing %q, got:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodepools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/registry"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/gkereleasenotes"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// maxInspectedImages limits the number of image manifests fetched by one call.
+const maxInspectedImages = 50
+
+// armReleaseNoteKeywords are used to pick Arm-related entries from the GKE release notes.
+var armReleaseNoteKeywords = []string{"arm64", "arm-based", "arm node", "t2a", "c4a", "n4a", "axion"}
+
+var armTaint = kube.Taint{Key: "kubernetes.io/arch", Value: "arm64", Effect: "NoSchedule"}
+
+type checkArmNodePoolsArgs struct {
+	ProjectID     string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location      string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name          string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+	TargetVersion string `json:"target_version,omitempty" jsonschema:"Target GKE version of the upgrade. Leave this empty if the user doesn't provide it."`
+}
+
+func (h *handlers) checkArmNodePools(ctx context.Context, _ *mcp.CallToolRequest, args *checkArmNodePoolsArgs) (*mcp.CallToolResult, any, error) {
+	cluster, err := gke.GetCluster(ctx, h.cmClient, h.c, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	releaseNoteEntries, err := gkereleasenotes.UpgradeEntries(ctx, cluster.GetCurrentMasterVersion(), args.TargetVersion, armReleaseNoteKeywords)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var b strings.Builder
+	b.WriteString(armNodePoolsReport(cluster))
+	if len(armNodePools(cluster)) > 0 {
+		var nodes kube.List[kube.Node]
+		var pods kube.List[kube.Pod]
+		kubeErr := kube.Get(ctx, &nodes, "nodes", "-l", "kubernetes.io/arch=arm64")
+		if kubeErr == nil {
+			kubeErr = kube.Get(ctx, &pods, "pods", "--all-namespaces")
+		}
+		if kubeErr != nil {
+			fmt.Fprintf(&b, "\nWorkload images were not analyzed: %v\n", kubeErr)
+		} else {
+			images := armWorkloadImages(nodes.Items, pods.Items)
+			// Without credentials, Google registries are accessed anonymously.
+			ts, _ := h.c.TokenSource(ctx)
+			client := registry.NewClient(ts)
+			platforms := map[string][]string{}
+			errs := map[string]error{}
+			for i, image := range images {
+				if i >= maxInspectedImages {
+					break
+				}
+				platforms[image], errs[image] = client.Platforms(ctx, image)
+			}
+			b.WriteString(imagePlatformsReport(images, platforms, errs))
+		}
+	}
+	gkereleasenotes.WriteEntries(&b, "Arm-related", cluster.GetCurrentMasterVersion(), args.TargetVersion, releaseNoteEntries)
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: b.String()},
+		},
+	}, nil, nil
+}
+
+func armNodePools(cluster *containerpb.Cluster) []*containerpb.NodePool {
+	var pools []*containerpb.NodePool
+	for _, pool := range cluster.GetNodePools() {
+		if gke.IsArmMachineType(pool.GetConfig().GetMachineType()) {
+			pools = append(pools, pool)
+		}
+	}
+	return pools
+}
+
+func armNodePoolsReport(cluster *containerpb.Cluster) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cluster: %s\n", cluster.GetName())
+	pools := armNodePools(cluster)
+	if len(pools) == 0 {
+		b.WriteString("\nThe cluster has no Arm node pools.\n")
+		return b.String()
+	}
+	b.WriteString("\nArm node pools:\n")
+	for _, pool := range pools {
+		fmt.Fprintf(&b, "- %s (version %s, machine type %s)\n", pool.GetName(), pool.GetVersion(), pool.GetConfig().GetMachineType())
+	}
+	return b.String()
+}
+
+// schedulableOnArm reports whether the pod can be scheduled on Arm nodes,
+// either because it selects arm64 nodes or tolerates the Arm node taint.
+func schedulableOnArm(spec kube.PodSpec) bool {
+	if spec.NodeSelector["kubernetes.io/arch"] == "arm64" {
+		return true
+	}
+	for _, t := range spec.Tolerations {
+		if t.Tolerates(armTaint) {
+			return true
+		}
+	}
+	return false
+}
+
+// armWorkloadImages returns the sorted images of the pods running on Arm
+// nodes or which can be scheduled on Arm nodes, e.g. when they are evicted
+// during an upgrade.
+func armWorkloadImages(armNodes []kube.Node, pods []kube.Pod) []string {
+	onArm := map[string]bool{}
+	for _, node := range armNodes {
+		onArm[node.Name] = true
+	}
+	var images []string
+	for _, pod := range pods {
+		if !onArm[pod.Spec.NodeName] && !schedulableOnArm(pod.Spec) {
+			continue
+		}
+		for _, c := range slices.Concat(pod.Spec.InitContainers, pod.Spec.Containers) {
+			if !slices.Contains(images, c.Image) {
+				images = append(images, c.Image)
+			}
+		}
+	}
+	sort.Strings(images)
+	return images
+}
+
+func imagePlatformsReport(images []string, platforms map[string][]string, errs map[string]error) string {
+	var b strings.Builder
+	if len(images) == 0 {
+		b.WriteString("\nNo workloads run on or tolerate Arm nodes.\n")
+		return b.String()
+	}
+
+	b.WriteString("\nImages of workloads running on or schedulable on Arm nodes:\n")
+	for _, image := range images {
+		p, inspected := platforms[image]
+		switch {
+		case errs[image] != nil:
+			fmt.Fprintf(&b, "- %s: could not be inspected: %v\n", image, errs[image])
+		case !inspected:
+			fmt.Fprintf(&b, "- %s: not inspected, more than %d images\n", image, maxInspectedImages)
+		case slices.ContainsFunc(p, func(platform string) bool { return strings.HasPrefix(platform, "linux/arm64") }):
+			fmt.Fprintf(&b, "- %s: OK (%s)\n", image, strings.Join(p, ", "))
+		default:
+			fmt.Fprintf(&b, "- %s: MISSING linux/arm64, image is built for %s\n", image, strings.Join(p, ", "))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodepools
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestArmNodePoolsReport(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		Name: "my-cluster",
+		NodePools: []*containerpb.NodePool{
+			{Name: "default-pool", Version: "1.32.4-gke.1029000", Config: &containerpb.NodeConfig{MachineType: "e2-standard-4"}},
+			{Name: "axion-pool", Version: "1.32.4-gke.1029000", Config: &containerpb.NodeConfig{MachineType: "c4a-standard-8"}},
+		},
+	}
+	report := armNodePoolsReport(cluster)
+	if !strings.Contains(report, "- axion-pool (version 1.32.4-gke.1029000, machine type c4a-standard-8)") || strings.Contains(report, "default-pool") {
+		t.Errorf("armNodePoolsReport() = %q", report)
+	}
+}
+
+func TestArmWorkloadImages(t *testing.T) {
+	nodes := []kube.Node{{ObjectMeta: metav1.ObjectMeta{Name: "arm-node"}}}
+	pods := []kube.Pod{
+		{Spec: kube.PodSpec{NodeName: "arm-node", Containers: []kube.Container{{Image: "nginx"}}, InitContainers: []kube.Container{{Image: "busybox"}}}},
+		{Spec: kube.PodSpec{NodeName: "x86-node", Containers: []kube.Container{{Image: "redis"}}, NodeSelector: map[string]string{"kubernetes.io/arch": "arm64"}}},
+		{Spec: kube.PodSpec{NodeName: "x86-node", Containers: []kube.Container{{Image: "nginx"}}, Tolerations: []kube.Toleration{{Key: "kubernetes.io/arch", Operator: "Exists"}}}},
+		{Spec: kube.PodSpec{NodeName: "x86-node", Containers: []kube.Container{{Image: "x86-only"}}}},
+	}
+	want := []string{"busybox", "nginx", "redis"}
+	if diff := cmp.Diff(want, armWorkloadImages(nodes, pods)); diff != "" {
+		t.Errorf("armWorkloadImages() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestImagePlatformsReport(t *testing.T) {
+	images := []string{"multi", "amd64-only", "private", "uninspected"}
+	platforms := map[string][]string{
+		"multi":      {"linux/amd64", "linux/arm64/v8"},
+		"amd64-only": {"linux/amd64"},
+		"private":    nil,
+	}
+	errs := map[string]error{"private": errors.New("status code 403")}
+
+	report := imagePlatformsReport(images, platforms, errs)
+	for _, want := range []string{
+		"- multi: OK (linux/amd64, linux/arm64/v8)",
+		"- amd64-only: MISSING linux/arm64, image is built for linux/amd64",
+		"- private: could not be inspected: status code 403",
+		"- uninspected: not inspected, more than 50 images",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("imagePlatformsReport() missing %q in:\n%s", want, report)
+		}
+	}
+
+	if report := imagePlatformsReport(nil, nil, nil); !strings.Contains(report, "No workloads run on or tolerate Arm nodes.") {
+		t.Errorf("imagePlatformsReport() without images = %q", report)
+	}
+}
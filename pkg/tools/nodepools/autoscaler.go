@@ -0,0 +1,244 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodepools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// maxScaleUpFailures bounds the number of distinct scale-up failures listed.
+const maxScaleUpFailures = 20
+
+// scaleUpFailureReasons are the reasons of the events the cluster autoscaler
+// reports when it can't or doesn't add nodes for pending pods.
+var scaleUpFailureReasons = map[string]bool{
+	"NotTriggerScaleUp":    true,
+	"FailedScaleUp":        true,
+	"FailedToScaleUpGroup": true,
+	"ScaleUpTimedOut":      true,
+}
+
+type getAutoscalerConfigArgs struct {
+	ProjectID string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location  string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name      string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+}
+
+// scaleUpFailure is a distinct scale-up failure reported by the cluster
+// autoscaler, with the number of times it was reported.
+type scaleUpFailure struct {
+	reason  string
+	message string
+	count   int
+	last    time.Time
+}
+
+func (h *handlers) getAutoscalerConfig(ctx context.Context, _ *mcp.CallToolRequest, args *getAutoscalerConfigArgs) (*mcp.CallToolResult, any, error) {
+	cluster, err := gke.GetCluster(ctx, h.cmClient, h.c, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var nodes kube.List[kube.Node]
+	var events kube.List[kube.Event]
+	kubeErr := kube.Get(ctx, &nodes, "nodes")
+	if kubeErr == nil {
+		kubeErr = kube.Get(ctx, &events, "events", "--all-namespaces")
+	}
+	var nodeCounts map[string]int
+	var failures []scaleUpFailure
+	if kubeErr == nil {
+		nodeCounts = map[string]int{}
+		for _, node := range nodes.Items {
+			nodeCounts[node.Labels[gke.NodePoolLabel]]++
+		}
+		failures = scaleUpFailures(events.Items)
+	}
+
+	report := autoscalerReport(cluster, nodeCounts, failures)
+	if kubeErr != nil {
+		report += fmt.Sprintf("\nNode counts and recent scale-up failures were not read from the cluster: %v\n", kubeErr)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: report},
+		},
+	}, nil, nil
+}
+
+// scaleUpFailures groups the scale-up failure events by reason and message,
+// the most recent first.
+func scaleUpFailures(events []kube.Event) []scaleUpFailure {
+	groups := map[string]*scaleUpFailure{}
+	for _, event := range events {
+		if !scaleUpFailureReasons[event.Reason] {
+			continue
+		}
+		count := int(event.Count)
+		if event.Series != nil && event.Series.Count > 0 {
+			count = int(event.Series.Count)
+		}
+		if count == 0 {
+			count = 1
+		}
+		last := event.LastTimestamp.Time
+		if last.IsZero() {
+			last = event.EventTime.Time
+		}
+		key := event.Reason + "\x00" + event.Message
+		g, ok := groups[key]
+		if !ok {
+			g = &scaleUpFailure{reason: event.Reason, message: event.Message}
+			groups[key] = g
+		}
+		g.count += count
+		if last.After(g.last) {
+			g.last = last
+		}
+	}
+	var failures []scaleUpFailure
+	for _, g := range groups {
+		failures = append(failures, *g)
+	}
+	sort.Slice(failures, func(i, j int) bool {
+		if !failures[i].last.Equal(failures[j].last) {
+			return failures[i].last.After(failures[j].last)
+		}
+		return failures[i].message < failures[j].message
+	})
+	return failures
+}
+
+// nodePoolBounds returns the minimum and maximum number of nodes of an
+// autoscaled node pool, across all its zones.
+func nodePoolBounds(pool *containerpb.NodePool) (minNodes, maxNodes int32, scope string) {
+	a := pool.GetAutoscaling()
+	if a.GetTotalMaxNodeCount() > 0 {
+		return a.GetTotalMinNodeCount(), a.GetTotalMaxNodeCount(), "total"
+	}
+	zones := int32(max(len(pool.GetLocations()), 1))
+	if zones == 1 {
+		return a.GetMinNodeCount(), a.GetMaxNodeCount(), "in 1 zone"
+	}
+	return a.GetMinNodeCount() * zones, a.GetMaxNodeCount() * zones, fmt.Sprintf("%d-%d per zone in %d zones", a.GetMinNodeCount(), a.GetMaxNodeCount(), zones)
+}
+
+// autoscalerReport describes the cluster autoscaler and node
+// auto-provisioning configuration, and flags the settings limiting capacity
+// while node upgrades recreate nodes. nodeCounts are the current numbers of
+// nodes of each node pool, nil when unknown.
+func autoscalerReport(cluster *containerpb.Cluster, nodeCounts map[string]int, failures []scaleUpFailure) string {
+	var b strings.Builder
+	var findings []string
+
+	a := cluster.GetAutoscaling()
+	profile := a.GetAutoscalingProfile()
+	if profile == containerpb.ClusterAutoscaling_PROFILE_UNSPECIFIED {
+		profile = containerpb.ClusterAutoscaling_BALANCED
+	}
+	fmt.Fprintf(&b, "Cluster %s autoscaling profile: %s\n", cluster.GetName(), profile)
+	if profile == containerpb.ClusterAutoscaling_OPTIMIZE_UTILIZATION {
+		findings = append(findings, "The optimize-utilization profile removes underutilized nodes aggressively, leaving little spare capacity for the pods evicted by node upgrades. Consider the balanced profile during upgrades.")
+	}
+
+	if a.GetEnableNodeAutoprovisioning() {
+		b.WriteString("Node auto-provisioning: enabled\n")
+		for _, limit := range a.GetResourceLimits() {
+			fmt.Fprintf(&b, "- %s: %d-%d\n", limit.GetResourceType(), limit.GetMinimum(), limit.GetMaximum())
+		}
+		defaults := a.GetAutoprovisioningNodePoolDefaults()
+		if defaults.GetImageType() != "" {
+			fmt.Fprintf(&b, "- default image type: %s\n", defaults.GetImageType())
+		}
+		if sa := defaults.GetServiceAccount(); sa != "" {
+			fmt.Fprintf(&b, "- default service account: %s\n", sa)
+		}
+		if u := defaults.GetUpgradeSettings(); u != nil {
+			fmt.Fprintf(&b, "- default upgrade settings: strategy %s, maxSurge %d, maxUnavailable %d\n", u.GetStrategy(), u.GetMaxSurge(), u.GetMaxUnavailable())
+		}
+		if locations := a.GetAutoprovisioningLocations(); len(locations) > 0 {
+			fmt.Fprintf(&b, "- locations: %s\n", strings.Join(locations, ", "))
+		}
+		if len(a.GetResourceLimits()) == 0 {
+			findings = append(findings, "Node auto-provisioning is enabled without resource limits, new node pools can't be created.")
+		}
+	} else {
+		b.WriteString("Node auto-provisioning: disabled\n")
+	}
+
+	b.WriteString("\nNode pools:\n")
+	for _, pool := range cluster.GetNodePools() {
+		fmt.Fprintf(&b, "- %s:", pool.GetName())
+		current, known := nodeCounts[pool.GetName()]
+		if known {
+			fmt.Fprintf(&b, " %d nodes,", current)
+		}
+		pa := pool.GetAutoscaling()
+		if !pa.GetEnabled() {
+			b.WriteString(" autoscaling disabled\n")
+			findings = append(findings, fmt.Sprintf("Node pool %s is not autoscaled, the pods evicted by node upgrades only fit in its existing spare capacity and surge nodes.", pool.GetName()))
+			continue
+		}
+		minNodes, maxNodes, scope := nodePoolBounds(pool)
+		fmt.Fprintf(&b, " autoscaling %d-%d nodes (%s)", minNodes, maxNodes, scope)
+		if policy := pa.GetLocationPolicy(); policy != containerpb.NodePoolAutoscaling_LOCATION_POLICY_UNSPECIFIED {
+			fmt.Fprintf(&b, ", location policy %s", policy)
+		}
+		if pa.GetAutoprovisioned() {
+			b.WriteString(", auto-provisioned")
+		}
+		b.WriteString("\n")
+		if known && int32(current) >= maxNodes {
+			findings = append(findings, fmt.Sprintf("Node pool %s runs at its maximum of %d nodes, the autoscaler can't add nodes for the pods evicted by node upgrades. Raise the maximum before the upgrade.", pool.GetName(), maxNodes))
+		}
+	}
+
+	if nodeCounts != nil {
+		if len(failures) == 0 {
+			b.WriteString("\nNo recent scale-up failures reported by the cluster autoscaler.\n")
+		} else {
+			fmt.Fprintf(&b, "\nRecent scale-up failures reported by the cluster autoscaler (%d distinct):\n", len(failures))
+			for i, f := range failures {
+				if i == maxScaleUpFailures {
+					fmt.Fprintf(&b, "... and %d more\n", len(failures)-maxScaleUpFailures)
+					break
+				}
+				last := "unknown time"
+				if !f.last.IsZero() {
+					last = f.last.UTC().Format(time.RFC3339)
+				}
+				fmt.Fprintf(&b, "- %s x%d, last at %s: %s\n", f.reason, f.count, last, strings.TrimSpace(f.message))
+			}
+		}
+	}
+
+	if len(findings) > 0 {
+		b.WriteString("\nFindings:\n")
+		for _, f := range findings {
+			fmt.Fprintf(&b, "- %s\n", f)
+		}
+	}
+	return b.String()
+}
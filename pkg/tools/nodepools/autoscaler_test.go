@@ -0,0 +1,114 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodepools
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestScaleUpFailures(t *testing.T) {
+	at := func(hour int) metav1.Time {
+		return metav1.NewTime(time.Date(2025, 6, 1, hour, 0, 0, 0, time.UTC))
+	}
+	events := []kube.Event{
+		{Reason: "NotTriggerScaleUp", Message: "pod didn't trigger scale-up: 1 max node group size reached", Count: 3, LastTimestamp: at(10)},
+		{Reason: "NotTriggerScaleUp", Message: "pod didn't trigger scale-up: 1 max node group size reached", Count: 2, LastTimestamp: at(11)},
+		{Reason: "FailedToScaleUpGroup", Message: "Scale-up failed for group pool-a: out of resources", LastTimestamp: at(12)},
+		{Reason: "TriggeredScaleUp", Message: "pod triggered scale-up", LastTimestamp: at(13)},
+	}
+
+	got := scaleUpFailures(events)
+	if len(got) != 2 {
+		t.Fatalf("scaleUpFailures() = %+v, want 2 failures", got)
+	}
+	if got[0].reason != "FailedToScaleUpGroup" || got[0].count != 1 {
+		t.Errorf("scaleUpFailures()[0] = %+v, want the most recent FailedToScaleUpGroup", got[0])
+	}
+	if got[1].reason != "NotTriggerScaleUp" || got[1].count != 5 || !got[1].last.Equal(at(11).Time) {
+		t.Errorf("scaleUpFailures()[1] = %+v, want NotTriggerScaleUp x5 last at 11:00", got[1])
+	}
+}
+
+func TestAutoscalerReport(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		Name: "my-cluster",
+		Autoscaling: &containerpb.ClusterAutoscaling{
+			AutoscalingProfile:         containerpb.ClusterAutoscaling_OPTIMIZE_UTILIZATION,
+			EnableNodeAutoprovisioning: true,
+			ResourceLimits: []*containerpb.ResourceLimit{
+				{ResourceType: "cpu", Minimum: 1, Maximum: 100},
+				{ResourceType: "memory", Minimum: 1, Maximum: 400},
+			},
+			AutoprovisioningNodePoolDefaults: &containerpb.AutoprovisioningNodePoolDefaults{
+				ImageType:       "COS_CONTAINERD",
+				UpgradeSettings: &containerpb.NodePool_UpgradeSettings{MaxSurge: 1, Strategy: containerpb.NodePoolUpdateStrategy_SURGE.Enum()},
+			},
+		},
+		NodePools: []*containerpb.NodePool{
+			{
+				Name:        "default-pool",
+				Locations:   []string{"us-central1-a", "us-central1-b"},
+				Autoscaling: &containerpb.NodePoolAutoscaling{Enabled: true, MinNodeCount: 1, MaxNodeCount: 3, LocationPolicy: containerpb.NodePoolAutoscaling_BALANCED},
+			},
+			{
+				Name:        "batch",
+				Autoscaling: &containerpb.NodePoolAutoscaling{Enabled: true, TotalMinNodeCount: 0, TotalMaxNodeCount: 10, LocationPolicy: containerpb.NodePoolAutoscaling_ANY},
+			},
+			{Name: "fixed"},
+		},
+	}
+	failures := []scaleUpFailure{{reason: "NotTriggerScaleUp", message: "max node group size reached", count: 5, last: time.Date(2025, 6, 1, 11, 0, 0, 0, time.UTC)}}
+
+	got := autoscalerReport(cluster, map[string]int{"default-pool": 6, "batch": 2, "fixed": 3}, failures)
+	for _, want := range []string{
+		"Cluster my-cluster autoscaling profile: OPTIMIZE_UTILIZATION\n",
+		"Node auto-provisioning: enabled\n- cpu: 1-100\n- memory: 1-400\n- default image type: COS_CONTAINERD\n",
+		"- default upgrade settings: strategy SURGE, maxSurge 1, maxUnavailable 0\n",
+		"- default-pool: 6 nodes, autoscaling 2-6 nodes (1-3 per zone in 2 zones), location policy BALANCED\n",
+		"- batch: 2 nodes, autoscaling 0-10 nodes (total), location policy ANY\n",
+		"- fixed: 3 nodes, autoscaling disabled\n",
+		"- NotTriggerScaleUp x5, last at 2025-06-01T11:00:00Z: max node group size reached\n",
+		"- The optimize-utilization profile removes underutilized nodes aggressively",
+		"- Node pool fixed is not autoscaled",
+		"- Node pool default-pool runs at its maximum of 6 nodes",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("autoscalerReport() missing %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Node pool batch runs at its maximum") {
+		t.Errorf("autoscalerReport() flagged node pool batch below its maximum, got:\n%s", got)
+	}
+}
+
+func TestAutoscalerReport_UnknownNodeCounts(t *testing.T) {
+	cluster := &containerpb.Cluster{Name: "my-cluster", NodePools: []*containerpb.NodePool{{Name: "default-pool", Autoscaling: &containerpb.NodePoolAutoscaling{Enabled: true, MinNodeCount: 1, MaxNodeCount: 3}}}}
+
+	got := autoscalerReport(cluster, nil, nil)
+	for _, want := range []string{"autoscaling profile: BALANCED", "Node auto-provisioning: disabled", "- default-pool: autoscaling 1-3 nodes (in 1 zone)\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("autoscalerReport() missing %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "scale-up failures") {
+		t.Errorf("autoscalerReport() reported scale-up failures without in-cluster data, got:\n%s", got)
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodepools
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/gkereleasenotes"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// gpuReleaseNoteKeywords are used to pick GPU-related entries from the GKE release notes.
+var gpuReleaseNoteKeywords = []string{"gpu", "nvidia", "cuda", "accelerator"}
+
+// cudaImageRegexp matches the CUDA version in image names such as
+// nvidia/cuda:12.4.1-runtime or pytorch/pytorch:2.3.0-cuda12.1-cudnn8.
+var cudaImageRegexp = regexp.MustCompile(`cuda[:\-_]?(\d+)\.(\d+)`)
+
+// minDriverForCUDA is the minimum Linux NVIDIA driver version supporting each
+// CUDA major version with minor version compatibility.
+var minDriverForCUDA = map[int]string{
+	11: "450.80.02",
+	12: "525.60.13",
+	13: "580.65.06",
+}
+
+type checkGPUCompatibilityArgs struct {
+	ProjectID     string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location      string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name          string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+	TargetVersion string `json:"target_version,omitempty" jsonschema:"Target GKE version of the upgrade. Leave this empty if the user doesn't provide it."`
+}
+
+// gpuInventory is the in-cluster state relevant to GPU node pools.
+type gpuInventory struct {
+	nodes      []kube.Node
+	pods       []kube.Pod
+	daemonSets []kube.DaemonSet
+}
+
+func (h *handlers) checkGPUCompatibility(ctx context.Context, _ *mcp.CallToolRequest, args *checkGPUCompatibilityArgs) (*mcp.CallToolResult, any, error) {
+	cluster, err := gke.GetCluster(ctx, h.cmClient, h.c, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	releaseNoteEntries, err := gkereleasenotes.UpgradeEntries(ctx, cluster.GetCurrentMasterVersion(), args.TargetVersion, gpuReleaseNoteKeywords)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var inventory gpuInventory
+	var nodes kube.List[kube.Node]
+	var pods kube.List[kube.Pod]
+	var daemonSets kube.List[kube.DaemonSet]
+	kubeErr := kube.Get(ctx, &nodes, "nodes")
+	if kubeErr == nil {
+		kubeErr = kube.Get(ctx, &pods, "pods", "--all-namespaces")
+	}
+	if kubeErr == nil {
+		kubeErr = kube.Get(ctx, &daemonSets, "daemonsets", "--all-namespaces")
+	}
+	if kubeErr == nil {
+		inventory = gpuInventory{nodes: nodes.Items, pods: pods.Items, daemonSets: daemonSets.Items}
+	}
+
+	report := gpuReport(cluster, inventory, args.TargetVersion, releaseNoteEntries)
+	if kubeErr != nil {
+		report += fmt.Sprintf("\nIn-cluster GPU drivers and workloads were not analyzed: %v\n", kubeErr)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: report},
+		},
+	}, nil, nil
+}
+
+func driverInstallation(accelerator *containerpb.AcceleratorConfig) string {
+	switch accelerator.GetGpuDriverInstallationConfig().GetGpuDriverVersion() {
+	case containerpb.GPUDriverInstallationConfig_DEFAULT:
+		return "installed by GKE (default version)"
+	case containerpb.GPUDriverInstallationConfig_LATEST:
+		return "installed by GKE (latest version)"
+	case containerpb.GPUDriverInstallationConfig_INSTALLATION_DISABLED:
+		return "installed manually (GKE installation disabled)"
+	default:
+		return "not specified (installed by GKE on recent versions, by the nvidia-driver-installer DaemonSet otherwise)"
+	}
+}
+
+// nodeDriverVersion returns the NVIDIA driver version of a node from the
+// labels set by NVIDIA GPU feature discovery, if present.
+func nodeDriverVersion(node kube.Node) string {
+	if v := node.Labels["nvidia.com/cuda.driver-version.full"]; v != "" {
+		return v
+	}
+	major, minor, rev := node.Labels["nvidia.com/cuda.driver.major"], node.Labels["nvidia.com/cuda.driver.minor"], node.Labels["nvidia.com/cuda.driver.rev"]
+	if major == "" {
+		return ""
+	}
+	return strings.Trim(strings.Join([]string{major, minor, rev}, "."), ".")
+}
+
+// compareDriverVersions compares dotted numeric driver versions, returning
+// -1, 0 or 1 if a is older, equal or newer than b.
+func compareDriverVersions(a, b string) int {
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < max(len(aParts), len(bParts)); i++ {
+		var x, y int
+		if i < len(aParts) {
+			x, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			y, _ = strconv.Atoi(bParts[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// cudaVersion returns the CUDA major and minor version in an image name.
+func cudaVersion(image string) (major, minor int, ok bool) {
+	m := cudaImageRegexp.FindStringSubmatch(strings.ToLower(image))
+	if m == nil {
+		return 0, 0, false
+	}
+	major, _ = strconv.Atoi(m[1])
+	minor, _ = strconv.Atoi(m[2])
+	return major, minor, true
+}
+
+func gpuReport(cluster *containerpb.Cluster, inventory gpuInventory, targetVersion string, releaseNoteEntries []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cluster: %s\n", cluster.GetName())
+
+	gpuPools := map[string]bool{}
+	for _, pool := range cluster.GetNodePools() {
+		for _, accelerator := range pool.GetConfig().GetAccelerators() {
+			gpuPools[pool.GetName()] = true
+			fmt.Fprintf(&b, "\nNode pool %s (version %s): %d x %s\n", pool.GetName(), pool.GetVersion(), accelerator.GetAcceleratorCount(), accelerator.GetAcceleratorType())
+			fmt.Fprintf(&b, "- Driver: %s\n", driverInstallation(accelerator))
+			if partition := accelerator.GetGpuPartitionSize(); partition != "" {
+				fmt.Fprintf(&b, "- Multi-instance GPU partition size: %s\n", partition)
+			}
+			if sharing := accelerator.GetGpuSharingConfig(); sharing != nil {
+				fmt.Fprintf(&b, "- GPU sharing: %s, up to %d clients per GPU\n", sharing.GetGpuSharingStrategy(), sharing.GetMaxSharedClientsPerGpu())
+			}
+		}
+	}
+	if len(gpuPools) == 0 {
+		b.WriteString("\nThe cluster has no GPU node pools.\n")
+		return b.String()
+	}
+
+	// Observed driver versions, keyed by node name and by node pool.
+	nodeDrivers := map[string]string{}
+	poolDrivers := map[string][]string{}
+	for _, node := range inventory.nodes {
+		pool := node.Labels[gke.NodePoolLabel]
+		if !gpuPools[pool] {
+			continue
+		}
+		v := nodeDriverVersion(node)
+		nodeDrivers[node.Name] = v
+		if v != "" && !slices.Contains(poolDrivers[pool], v) {
+			poolDrivers[pool] = append(poolDrivers[pool], v)
+		}
+	}
+	if len(inventory.nodes) > 0 {
+		b.WriteString("\nNVIDIA driver versions reported by the nodes:\n")
+		pools := make([]string, 0, len(gpuPools))
+		for pool := range gpuPools {
+			pools = append(pools, pool)
+		}
+		sort.Strings(pools)
+		for _, pool := range pools {
+			if len(poolDrivers[pool]) == 0 {
+				fmt.Fprintf(&b, "- %s: unknown, node labels from NVIDIA GPU feature discovery are missing. Run nvidia-smi in a GPU pod to get it.\n", pool)
+				continue
+			}
+			fmt.Fprintf(&b, "- %s: %s\n", pool, strings.Join(poolDrivers[pool], ", "))
+		}
+	}
+
+	var gpuDaemonSets []string
+	for _, ds := range inventory.daemonSets {
+		name := strings.ToLower(ds.Name)
+		if !strings.Contains(name, "nvidia") && !strings.Contains(name, "gpu") {
+			continue
+		}
+		var images []string
+		for _, c := range slices.Concat(ds.Spec.Template.Spec.InitContainers, ds.Spec.Template.Spec.Containers) {
+			images = append(images, c.Image)
+		}
+		gpuDaemonSets = append(gpuDaemonSets, fmt.Sprintf("- %s/%s: %s", ds.Namespace, ds.Name, strings.Join(images, ", ")))
+	}
+	if len(gpuDaemonSets) > 0 {
+		b.WriteString("\nGPU driver and device plugin DaemonSets. Manually installed drivers must support the node image of the target version:\n")
+		b.WriteString(strings.Join(gpuDaemonSets, "\n"))
+		b.WriteString("\n")
+	}
+
+	var cudaWorkloads []string
+	for _, pod := range inventory.pods {
+		driver, onGPUNode := nodeDrivers[pod.Spec.NodeName]
+		if !onGPUNode {
+			continue
+		}
+		for _, c := range pod.Spec.Containers {
+			major, minor, ok := cudaVersion(c.Image)
+			if !ok {
+				continue
+			}
+			line := fmt.Sprintf("- %s/%s container %s uses CUDA %d.%d", pod.Namespace, pod.Name, c.Name, major, minor)
+			if minDriver, ok := minDriverForCUDA[major]; ok {
+				line += fmt.Sprintf(", requires driver %s or newer", minDriver)
+				if driver != "" && compareDriverVersions(driver, minDriver) < 0 {
+					line += fmt.Sprintf(". INCOMPATIBLE with driver %s", driver)
+				}
+			}
+			cudaWorkloads = append(cudaWorkloads, line)
+		}
+	}
+	if len(cudaWorkloads) > 0 {
+		b.WriteString("\nCUDA workloads on GPU nodes. Check that the driver version of the target version still supports them:\n")
+		b.WriteString(strings.Join(cudaWorkloads, "\n"))
+		b.WriteString("\n")
+	}
+
+	gkereleasenotes.WriteEntries(&b, "GPU-related", cluster.GetCurrentMasterVersion(), targetVersion, releaseNoteEntries)
+	return b.String()
+}
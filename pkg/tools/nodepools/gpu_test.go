@@ -0,0 +1,133 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodepools
+
+import (
+	"strings"
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCUDAVersion(t *testing.T) {
+	tests := []struct {
+		image     string
+		wantMajor int
+		wantMinor int
+		wantOK    bool
+	}{
+		{image: "nvidia/cuda:12.4.1-runtime-ubuntu22.04", wantMajor: 12, wantMinor: 4, wantOK: true},
+		{image: "pytorch/pytorch:2.3.0-cuda12.1-cudnn8-runtime", wantMajor: 12, wantMinor: 1, wantOK: true},
+		{image: "us-docker.pkg.dev/project/repo/trainer:CUDA_11.8", wantMajor: 11, wantMinor: 8, wantOK: true},
+		{image: "nginx:1.27", wantOK: false},
+	}
+	for _, tt := range tests {
+		major, minor, ok := cudaVersion(tt.image)
+		if major != tt.wantMajor || minor != tt.wantMinor || ok != tt.wantOK {
+			t.Errorf("cudaVersion(%q) = (%d, %d, %v), want (%d, %d, %v)", tt.image, major, minor, ok, tt.wantMajor, tt.wantMinor, tt.wantOK)
+		}
+	}
+}
+
+func TestCompareDriverVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "535.183.01", b: "525.60.13", want: 1},
+		{a: "525.60.13", b: "525.60.13", want: 0},
+		{a: "470.256.02", b: "525.60.13", want: -1},
+		{a: "550", b: "550.54.14", want: -1},
+	}
+	for _, tt := range tests {
+		if got := compareDriverVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareDriverVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestGPUReport(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		Name:                 "my-cluster",
+		CurrentMasterVersion: "1.32.4-gke.1029000",
+		NodePools: []*containerpb.NodePool{
+			{Name: "default-pool", Version: "1.32.4-gke.1029000"},
+			{
+				Name:    "gpu-pool",
+				Version: "1.32.4-gke.1029000",
+				Config: &containerpb.NodeConfig{Accelerators: []*containerpb.AcceleratorConfig{{
+					AcceleratorCount:            2,
+					AcceleratorType:             "nvidia-l4",
+					GpuDriverInstallationConfig: &containerpb.GPUDriverInstallationConfig{GpuDriverVersion: containerpb.GPUDriverInstallationConfig_INSTALLATION_DISABLED.Enum()},
+				}}},
+			},
+		},
+	}
+	inventory := gpuInventory{
+		nodes: []kube.Node{
+			{ObjectMeta: metav1.ObjectMeta{Name: "gpu-node", Labels: map[string]string{
+				gke.NodePoolLabel:                     "gpu-pool",
+				"nvidia.com/cuda.driver-version.full": "470.256.02",
+			}}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "cpu-node", Labels: map[string]string{gke.NodePoolLabel: "default-pool"}}},
+		},
+		pods: []kube.Pod{
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ml", Name: "trainer"},
+				Spec: kube.PodSpec{NodeName: "gpu-node", Containers: []kube.Container{
+					{Name: "train", Image: "nvidia/cuda:12.4.1-runtime-ubuntu22.04"},
+				}},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ml", Name: "on-cpu"},
+				Spec: kube.PodSpec{NodeName: "cpu-node", Containers: []kube.Container{
+					{Name: "train", Image: "nvidia/cuda:12.4.1-runtime-ubuntu22.04"},
+				}},
+			},
+		},
+		daemonSets: []kube.DaemonSet{
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "nvidia-driver-installer"},
+				Spec: kube.DaemonSetSpec{Template: kube.PodTemplateSpec{Spec: kube.PodSpec{
+					InitContainers: []kube.Container{{Name: "installer", Image: "cos-nvidia-installer:fixed"}},
+				}}},
+			},
+		},
+	}
+
+	report := gpuReport(cluster, inventory, "", nil)
+	for _, want := range []string{
+		"Node pool gpu-pool (version 1.32.4-gke.1029000): 2 x nvidia-l4\n- Driver: installed manually (GKE installation disabled)",
+		"- gpu-pool: 470.256.02",
+		"- kube-system/nvidia-driver-installer: cos-nvidia-installer:fixed",
+		"- ml/trainer container train uses CUDA 12.4, requires driver 525.60.13 or newer. INCOMPATIBLE with driver 470.256.02",
+		"No target version provided, GPU-related release notes were not analyzed.",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("gpuReport() missing %q in:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "on-cpu") || strings.Contains(report, "Node pool default-pool") {
+		t.Errorf("gpuReport() reports non-GPU node pools or workloads:\n%s", report)
+	}
+
+	noGPU := &containerpb.Cluster{Name: "cpu-cluster", NodePools: []*containerpb.NodePool{{Name: "default-pool"}}}
+	if report := gpuReport(noGPU, gpuInventory{}, "", nil); !strings.Contains(report, "The cluster has no GPU node pools.") {
+		t.Errorf("gpuReport() for a cluster without GPUs = %q", report)
+	}
+}
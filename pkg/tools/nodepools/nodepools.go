@@ -0,0 +1,96 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nodepools provides MCP tools for checking specialized GKE node
+// pools, such as GPU, Windows and Arm node pools, before an upgrade, for
+// rightsizing and autoscaling node pools, and for reviewing the identity the
+// nodes run as.
+package nodepools
+
+import (
+	"context"
+	"fmt"
+
+	container "cloud.google.com/go/container/apiv1"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type handlers struct {
+	c        *config.Config
+	cmClient *container.ClusterManagerClient
+}
+
+// Install registers node pool compatibility tools with the MCP server.
+func Install(ctx context.Context, s *mcp.Server, c *config.Config) error {
+	cmClient, err := container.NewClusterManagerClient(ctx, c.ClientOptions()...)
+	if err != nil {
+		return fmt.Errorf("failed to create cluster manager client: %w", err)
+	}
+
+	h := &handlers{
+		c:        c,
+		cmClient: cmClient,
+	}
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "check_gpu_compatibility",
+		Description: "Inspect the GPU node pools of a GKE cluster: accelerator types, GPU driver installation mode, NVIDIA driver versions of the nodes and GPU device plugin or driver DaemonSets, and check the CUDA versions of GPU workload images against the driver versions. Also lists GPU-related GKE release notes up to a target version. Uses the current kubectl context for in-cluster data.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.checkGPUCompatibility)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "check_windows_node_pools",
+		Description: "Detect Windows Server node pools of a GKE cluster, report their image type, Windows Server version and node builds, list Windows container images, and surface Windows-specific upgrade constraints and GKE release notes up to a target version. Uses the current kubectl context for in-cluster data.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.checkWindowsNodePools)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "check_arm_node_pools",
+		Description: "Detect Arm (T2A, C4A Axion, N4A) node pools of a GKE cluster and verify that the images of workloads running on or schedulable on Arm nodes are built for linux/arm64 by reading their manifests from the registries. Also lists Arm-related GKE release notes up to a target version. Uses the current kubectl context for in-cluster data.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.checkArmNodePools)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "recommend_node_pool_rightsizing",
+		Description: "Compare the requested, used and allocatable CPU and memory of each node pool of a GKE cluster over the past days, from Cloud Monitoring metrics, and recommend machine type and autoscaler bound changes, keeping room for the nodes surge upgrades take down.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.recommendNodePoolRightsizing)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "get_autoscaler_config",
+		Description: "Report the cluster autoscaler configuration of a GKE cluster: autoscaling profile, node auto-provisioning resource limits and defaults, the autoscaling bounds and location policy of each node pool against its current node count, and the recent scale-up failures reported by the cluster autoscaler. Use it to reason about capacity while node upgrades recreate nodes, and about cost. Uses the current kubectl context for in-cluster data.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.getAutoscalerConfig)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "review_node_service_accounts",
+		Description: "Review the identity the nodes of a GKE cluster run as: the service account and OAuth access scopes of each node pool and of the node auto-provisioning defaults, and whether the GKE metadata server and Workload Identity Federation are enabled. Flags nodes running as the Compute Engine default service account, especially with broad scopes, and returns the gcloud commands to move them to a dedicated least-privilege service account.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.reviewNodeServiceAccounts)
+
+	return nil
+}
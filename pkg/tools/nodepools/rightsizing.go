@@ -0,0 +1,423 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodepools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	monitoring "cloud.google.com/go/monitoring/apiv3/v2"
+	monitoringpb "cloud.google.com/go/monitoring/apiv3/v2/monitoringpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/iterator"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultRightsizingDays = 7
+	// maxRightsizingDays is the retention of the GKE system metrics.
+	maxRightsizingDays = 42
+	// targetRequestUtilization is the share of the allocatable resources the
+	// recommended node counts leave for the requests at their peak.
+	targetRequestUtilization = 0.8
+)
+
+type recommendNodePoolRightsizingArgs struct {
+	ProjectID string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location  string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name      string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+	Days      int    `json:"days,omitempty" jsonschema:"The number of past days of metrics to analyze, up to 42. Defaults to 7."`
+}
+
+// resourceUsage is the hourly requested, used and allocatable amount of a
+// resource in a node pool.
+type resourceUsage struct {
+	requested   map[int64]float64
+	used        map[int64]float64
+	allocatable map[int64]float64
+}
+
+func newResourceUsage() *resourceUsage {
+	return &resourceUsage{requested: map[int64]float64{}, used: map[int64]float64{}, allocatable: map[int64]float64{}}
+}
+
+// poolUsage is the hourly usage of a node pool.
+type poolUsage struct {
+	cpu    *resourceUsage
+	memory *resourceUsage
+	// nodes is the number of nodes each hour.
+	nodes map[int64]float64
+}
+
+// usageStats summarizes the hourly values of a resource of a node pool.
+type usageStats struct {
+	requestedAvg, requestedPeak float64
+	usedAvg, usedPeak           float64
+	// allocatablePerNode is the average allocatable amount of a node.
+	allocatablePerNode float64
+	// allocatableAvg is the average allocatable amount of the node pool.
+	allocatableAvg float64
+}
+
+// poolStats summarizes the usage of a node pool.
+type poolStats struct {
+	cpu, memory usageStats
+	peakNodes   float64
+}
+
+// metricQuery is a GKE system metric summed by node.
+type metricQuery struct {
+	metricType   string
+	resourceType string
+	aligner      monitoringpb.Aggregation_Aligner
+	// extraFilter restricts the time series, if not empty.
+	extraFilter string
+	add         func(u *poolUsage, hour int64, value float64)
+}
+
+var rightsizingQueries = []metricQuery{
+	{metricType: "kubernetes.io/container/cpu/request_cores", resourceType: "k8s_container", aligner: monitoringpb.Aggregation_ALIGN_MEAN,
+		add: func(u *poolUsage, hour int64, v float64) { u.cpu.requested[hour] += v }},
+	{metricType: "kubernetes.io/node/cpu/core_usage_time", resourceType: "k8s_node", aligner: monitoringpb.Aggregation_ALIGN_RATE,
+		add: func(u *poolUsage, hour int64, v float64) { u.cpu.used[hour] += v }},
+	{metricType: "kubernetes.io/container/memory/request_bytes", resourceType: "k8s_container", aligner: monitoringpb.Aggregation_ALIGN_MEAN,
+		add: func(u *poolUsage, hour int64, v float64) { u.memory.requested[hour] += v }},
+	{metricType: "kubernetes.io/node/memory/used_bytes", resourceType: "k8s_node", aligner: monitoringpb.Aggregation_ALIGN_MEAN,
+		extraFilter: `metric.labels.memory_type = "non-evictable"`,
+		add:         func(u *poolUsage, hour int64, v float64) { u.memory.used[hour] += v }},
+	{metricType: "kubernetes.io/node/memory/allocatable_bytes", resourceType: "k8s_node", aligner: monitoringpb.Aggregation_ALIGN_MEAN,
+		add: func(u *poolUsage, hour int64, v float64) { u.memory.allocatable[hour] += v }},
+}
+
+func (h *handlers) recommendNodePoolRightsizing(ctx context.Context, _ *mcp.CallToolRequest, args *recommendNodePoolRightsizingArgs) (*mcp.CallToolResult, any, error) {
+	if args.ProjectID == "" {
+		args.ProjectID = h.c.DefaultProjectID(ctx)
+	}
+	if args.Days == 0 {
+		args.Days = defaultRightsizingDays
+	}
+	if args.Days < 1 || args.Days > maxRightsizingDays {
+		return nil, nil, fmt.Errorf("days argument must be between 1 and %d", maxRightsizingDays)
+	}
+	cluster, err := gke.GetCluster(ctx, h.cmClient, h.c, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mc, err := monitoring.NewMetricClient(ctx, h.c.ClientOptions()...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create monitoring client: %w", err)
+	}
+	defer func() {
+		if err := mc.Close(); err != nil {
+			slog.Warn("Failed to close monitoring client", "error", err)
+		}
+	}()
+
+	end := time.Now().Truncate(time.Hour)
+	interval := &monitoringpb.TimeInterval{
+		StartTime: timestamppb.New(end.Add(-time.Duration(args.Days) * 24 * time.Hour)),
+		EndTime:   timestamppb.New(end),
+	}
+	listSeries := func(filter string, aligner monitoringpb.Aggregation_Aligner, groupBy ...string) ([]*monitoringpb.TimeSeries, error) {
+		it := mc.ListTimeSeries(ctx, &monitoringpb.ListTimeSeriesRequest{
+			Name:     "projects/" + args.ProjectID,
+			Filter:   filter,
+			Interval: interval,
+			Aggregation: &monitoringpb.Aggregation{
+				AlignmentPeriod:    durationpb.New(time.Hour),
+				PerSeriesAligner:   aligner,
+				CrossSeriesReducer: monitoringpb.Aggregation_REDUCE_SUM,
+				GroupByFields:      groupBy,
+			},
+			View: monitoringpb.ListTimeSeriesRequest_FULL,
+		})
+		var series []*monitoringpb.TimeSeries
+		for {
+			ts, err := it.Next()
+			if err == iterator.Done {
+				return series, nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to list time series: %w", err)
+			}
+			series = append(series, ts)
+		}
+	}
+	clusterFilter := func(metricType, resourceType string) string {
+		return fmt.Sprintf(`metric.type = %q AND resource.type = %q AND resource.labels.cluster_name = %q AND resource.labels.location = %q`,
+			metricType, resourceType, cluster.GetName(), cluster.GetLocation())
+	}
+
+	// The allocatable CPU of the nodes also maps the nodes to their node pool.
+	allocatable, err := listSeries(clusterFilter("kubernetes.io/node/cpu/allocatable_cores", "k8s_node"), monitoringpb.Aggregation_ALIGN_MEAN,
+		"resource.labels.node_name", fmt.Sprintf("metadata.user_labels.%q", gke.NodePoolLabel))
+	if err != nil {
+		return nil, nil, err
+	}
+	usage := map[string]*poolUsage{}
+	for _, pool := range cluster.GetNodePools() {
+		usage[pool.GetName()] = &poolUsage{cpu: newResourceUsage(), memory: newResourceUsage(), nodes: map[int64]float64{}}
+	}
+	nodePools := map[string]string{}
+	for _, ts := range allocatable {
+		node, pool := seriesNode(ts), ts.GetMetadata().GetUserLabels()[gke.NodePoolLabel]
+		u := usage[pool]
+		if node == "" || u == nil {
+			continue
+		}
+		nodePools[node] = pool
+		for _, p := range ts.GetPoints() {
+			hour := p.GetInterval().GetEndTime().GetSeconds()
+			u.cpu.allocatable[hour] += pointValue(p)
+			u.nodes[hour]++
+		}
+	}
+
+	for _, q := range rightsizingQueries {
+		filter := clusterFilter(q.metricType, q.resourceType)
+		if q.extraFilter != "" {
+			filter += " AND " + q.extraFilter
+		}
+		nodeField := "resource.labels.node_name"
+		if q.resourceType == "k8s_container" {
+			nodeField = "metadata.system_labels.node_name"
+		}
+		series, err := listSeries(filter, q.aligner, nodeField)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, ts := range series {
+			u := usage[nodePools[seriesNode(ts)]]
+			if u == nil {
+				continue
+			}
+			for _, p := range ts.GetPoints() {
+				q.add(u, p.GetInterval().GetEndTime().GetSeconds(), pointValue(p))
+			}
+		}
+	}
+
+	stats := map[string]poolStats{}
+	for name, u := range usage {
+		stats[name] = summarize(u)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: rightsizingReport(cluster, args.Days, stats)},
+		},
+	}, nil, nil
+}
+
+// seriesNode returns the name of the node of a time series grouped by node.
+func seriesNode(ts *monitoringpb.TimeSeries) string {
+	if node := ts.GetResource().GetLabels()["node_name"]; node != "" {
+		return node
+	}
+	return ts.GetMetadata().GetSystemLabels().GetFields()["node_name"].GetStringValue()
+}
+
+func pointValue(p *monitoringpb.Point) float64 {
+	if v, ok := p.GetValue().GetValue().(*monitoringpb.TypedValue_Int64Value); ok {
+		return float64(v.Int64Value)
+	}
+	return p.GetValue().GetDoubleValue()
+}
+
+// summarize returns the averages and peaks of the hourly usage of a node
+// pool.
+func summarize(u *poolUsage) poolStats {
+	var s poolStats
+	s.cpu = summarizeResource(u.cpu, u.nodes)
+	s.memory = summarizeResource(u.memory, u.nodes)
+	_, s.peakNodes = avgPeak(u.nodes)
+	return s
+}
+
+func summarizeResource(r *resourceUsage, nodes map[int64]float64) usageStats {
+	var s usageStats
+	s.requestedAvg, s.requestedPeak = avgPeak(r.requested)
+	s.usedAvg, s.usedPeak = avgPeak(r.used)
+	s.allocatableAvg, _ = avgPeak(r.allocatable)
+	var allocatable, count float64
+	for hour, v := range r.allocatable {
+		allocatable += v
+		count += nodes[hour]
+	}
+	if count > 0 {
+		s.allocatablePerNode = allocatable / count
+	}
+	return s
+}
+
+func avgPeak(values map[int64]float64) (avg, peak float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	for _, v := range values {
+		avg += v
+		peak = max(peak, v)
+	}
+	return avg / float64(len(values)), peak
+}
+
+// neededNodes returns the number of nodes fitting the peak requests at the
+// target utilization, plus the nodes a surge upgrade takes down at once.
+func neededNodes(s poolStats, maxUnavailable int64) int64 {
+	var nodes float64
+	for _, r := range []usageStats{s.cpu, s.memory} {
+		if r.allocatablePerNode > 0 {
+			nodes = max(nodes, r.requestedPeak/(r.allocatablePerNode*targetRequestUtilization))
+		}
+	}
+	return max(int64(math.Ceil(nodes)), 1) + maxUnavailable
+}
+
+// rightsizingRecommendations returns the recommendations for a node pool.
+func rightsizingRecommendations(pool *containerpb.NodePool, s poolStats) []string {
+	var recs []string
+	zones := int64(max(len(pool.GetLocations()), 1))
+	maxUnavailable := int64(pool.GetUpgradeSettings().GetMaxUnavailable()) * zones
+	blueGreen := pool.GetUpgradeSettings().GetStrategy() == containerpb.NodePoolUpdateStrategy_BLUE_GREEN
+	if blueGreen {
+		// Blue-green upgrades keep the blue pool until the green pool is ready.
+		maxUnavailable = 0
+	}
+
+	for _, r := range []struct {
+		name  string
+		stats usageStats
+	}{{"CPU", s.cpu}, {"memory", s.memory}} {
+		if r.stats.requestedAvg > 0 && r.stats.usedPeak < 0.5*r.stats.requestedAvg {
+			recs = append(recs, fmt.Sprintf("Workloads use at most %.0f%% of their %s requests: lower the requests, e.g. with the recommendations of a VerticalPodAutoscaler, before shrinking the node pool, since nodes are sized by requests.",
+				100*r.stats.usedPeak/r.stats.requestedAvg, r.name))
+		}
+	}
+
+	cpuShare, memShare := share(s.cpu.requestedPeak, s.cpu.allocatableAvg), share(s.memory.requestedPeak, s.memory.allocatableAvg)
+	switch {
+	case memShare > 0.5 && memShare > 2*cpuShare:
+		recs = append(recs, fmt.Sprintf("Requests are memory-bound (%.0f%% of the memory vs %.0f%% of the CPU at peak): a machine type with more memory per vCPU than %s, e.g. a highmem one, would fit them on fewer nodes.",
+			100*memShare, 100*cpuShare, pool.GetConfig().GetMachineType()))
+	case cpuShare > 0.5 && cpuShare > 2*memShare:
+		recs = append(recs, fmt.Sprintf("Requests are CPU-bound (%.0f%% of the CPU vs %.0f%% of the memory at peak): a machine type with less memory per vCPU than %s, e.g. a highcpu one, would fit them on fewer nodes.",
+			100*cpuShare, 100*memShare, pool.GetConfig().GetMachineType()))
+	}
+
+	needed := neededNodes(s, maxUnavailable)
+	autoscaling := pool.GetAutoscaling()
+	peakShare := max(cpuShare, memShare)
+	switch {
+	case autoscaling.GetEnabled():
+		minNodes, maxNodes, perZone := int64(autoscaling.GetTotalMinNodeCount()), int64(autoscaling.GetTotalMaxNodeCount()), false
+		if minNodes == 0 && maxNodes == 0 {
+			minNodes, maxNodes, perZone = int64(autoscaling.GetMinNodeCount())*zones, int64(autoscaling.GetMaxNodeCount())*zones, true
+		}
+		bound := func(total int64) string {
+			if perZone {
+				return fmt.Sprintf("%d per zone", ceilDiv(total, zones))
+			}
+			return fmt.Sprintf("%d in total", total)
+		}
+		if minNodes > needed {
+			recs = append(recs, fmt.Sprintf("The autoscaler minimum of %d nodes is above the %d nodes the peak requests need, including %d for surge upgrades: lower the minimum node count to %s.",
+				minNodes, needed, maxUnavailable, bound(needed)))
+		}
+		if maxNodes > 0 && s.peakNodes >= float64(maxNodes) {
+			recs = append(recs, fmt.Sprintf("The node pool reached its autoscaler maximum of %d nodes: raise the maximum node count to at least %s so that pending pods can be scheduled.",
+				maxNodes, bound(max(needed, maxNodes+zones))))
+		}
+	case peakShare > 0 && peakShare < 0.5:
+		if current := int64(math.Round(s.peakNodes)); current > needed {
+			recs = append(recs, fmt.Sprintf("The peak requests use %.0f%% of the node pool: %d nodes instead of %d would fit them, including %d for surge upgrades, or enable the cluster autoscaler with a minimum of %d nodes.",
+				100*peakShare, needed, current, maxUnavailable, needed))
+		}
+	}
+
+	if peakShare > 0.9 {
+		if maxUnavailable > 0 {
+			recs = append(recs, fmt.Sprintf("The peak requests use %.0f%% of the node pool and surge upgrades take %d nodes down at once: set maxUnavailable to 0 and maxSurge to at least 1 so that pods aren't left pending during upgrades.",
+				100*peakShare, maxUnavailable))
+		} else if !autoscaling.GetEnabled() {
+			recs = append(recs, fmt.Sprintf("The peak requests use %.0f%% of the node pool: add nodes or enable the cluster autoscaler to leave room for new pods and node failures.", 100*peakShare))
+		}
+	}
+	return recs
+}
+
+func share(value, total float64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return value / total
+}
+
+func ceilDiv(a, b int64) int64 {
+	return (a + b - 1) / b
+}
+
+// rightsizingReport returns the usage and recommendations of each node pool.
+func rightsizingReport(cluster *containerpb.Cluster, days int, stats map[string]poolStats) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cluster: %s\n", cluster.GetName())
+	fmt.Fprintf(&b, "Period: last %d days, from hourly averages of Cloud Monitoring metrics\n", days)
+	b.WriteString("Nodes are sized by the requests of their pods, and recommended node counts keep the peak requests under 80% of the allocatable resources, plus the nodes surge upgrades take down at once.\n")
+
+	pools := append([]*containerpb.NodePool{}, cluster.GetNodePools()...)
+	sort.Slice(pools, func(i, j int) bool { return pools[i].GetName() < pools[j].GetName() })
+	for _, pool := range pools {
+		s := stats[pool.GetName()]
+		fmt.Fprintf(&b, "\nNode pool: %s (%s)\n", pool.GetName(), pool.GetConfig().GetMachineType())
+		if s.peakNodes == 0 {
+			b.WriteString("  No metrics found for the nodes of the node pool in the period.\n")
+			continue
+		}
+		if a := pool.GetAutoscaling(); a.GetEnabled() {
+			if a.GetTotalMinNodeCount() > 0 || a.GetTotalMaxNodeCount() > 0 {
+				fmt.Fprintf(&b, "  Autoscaling: %d to %d nodes in total\n", a.GetTotalMinNodeCount(), a.GetTotalMaxNodeCount())
+			} else {
+				fmt.Fprintf(&b, "  Autoscaling: %d to %d nodes per zone\n", a.GetMinNodeCount(), a.GetMaxNodeCount())
+			}
+		}
+		fmt.Fprintf(&b, "  Nodes: %.0f at peak\n", s.peakNodes)
+		fmt.Fprintf(&b, "  CPU (cores): allocatable %.1f, requested %.1f avg / %.1f peak, used %.1f avg / %.1f peak\n",
+			s.cpu.allocatableAvg, s.cpu.requestedAvg, s.cpu.requestedPeak, s.cpu.usedAvg, s.cpu.usedPeak)
+		fmt.Fprintf(&b, "  Memory (GiB): allocatable %.1f, requested %.1f avg / %.1f peak, used %.1f avg / %.1f peak\n",
+			gib(s.memory.allocatableAvg), gib(s.memory.requestedAvg), gib(s.memory.requestedPeak), gib(s.memory.usedAvg), gib(s.memory.usedPeak))
+		recs := rightsizingRecommendations(pool, s)
+		if len(recs) == 0 {
+			b.WriteString("  Recommendations: none, the node pool is sized for its requests.\n")
+			continue
+		}
+		b.WriteString("  Recommendations:\n")
+		for _, rec := range recs {
+			fmt.Fprintf(&b, "  - %s\n", rec)
+		}
+	}
+	return b.String()
+}
+
+func gib(bytes float64) float64 {
+	return bytes / (1 << 30)
+}
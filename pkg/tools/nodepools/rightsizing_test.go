@@ -0,0 +1,165 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodepools
+
+import (
+	"strings"
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+)
+
+func TestSummarize(t *testing.T) {
+	u := &poolUsage{cpu: newResourceUsage(), memory: newResourceUsage(), nodes: map[int64]float64{1: 2, 2: 4}}
+	u.cpu.allocatable = map[int64]float64{1: 8, 2: 16}
+	u.cpu.requested = map[int64]float64{1: 4, 2: 12}
+	u.cpu.used = map[int64]float64{1: 1, 2: 3}
+
+	s := summarize(u)
+	if s.peakNodes != 4 {
+		t.Errorf("peakNodes = %v, want 4", s.peakNodes)
+	}
+	if s.cpu.allocatablePerNode != 4 {
+		t.Errorf("cpu.allocatablePerNode = %v, want 4", s.cpu.allocatablePerNode)
+	}
+	if s.cpu.requestedAvg != 8 || s.cpu.requestedPeak != 12 {
+		t.Errorf("cpu requested = %v avg / %v peak, want 8 / 12", s.cpu.requestedAvg, s.cpu.requestedPeak)
+	}
+	if s.cpu.usedAvg != 2 || s.cpu.usedPeak != 3 {
+		t.Errorf("cpu used = %v avg / %v peak, want 2 / 3", s.cpu.usedAvg, s.cpu.usedPeak)
+	}
+}
+
+func TestNeededNodes(t *testing.T) {
+	s := poolStats{
+		cpu:    usageStats{requestedPeak: 10, allocatablePerNode: 4},
+		memory: usageStats{requestedPeak: 20, allocatablePerNode: 16},
+	}
+	// 10 cores at 80% of 4 cores per node need 4 nodes, plus 1 unavailable.
+	if got := neededNodes(s, 1); got != 5 {
+		t.Errorf("neededNodes() = %d, want 5", got)
+	}
+	if got := neededNodes(poolStats{}, 0); got != 1 {
+		t.Errorf("neededNodes() without requests = %d, want 1", got)
+	}
+}
+
+func TestRightsizingRecommendations(t *testing.T) {
+	tests := []struct {
+		name  string
+		pool  *containerpb.NodePool
+		stats poolStats
+		want  []string
+	}{
+		{
+			name: "oversized autoscaler minimum",
+			pool: &containerpb.NodePool{
+				Config:          &containerpb.NodeConfig{MachineType: "e2-standard-4"},
+				Locations:       []string{"us-central1-a"},
+				Autoscaling:     &containerpb.NodePoolAutoscaling{Enabled: true, MinNodeCount: 10, MaxNodeCount: 20},
+				UpgradeSettings: &containerpb.NodePool_UpgradeSettings{MaxSurge: 1, MaxUnavailable: 1},
+			},
+			stats: poolStats{
+				cpu:       usageStats{requestedAvg: 4, requestedPeak: 6, usedPeak: 5, allocatablePerNode: 4, allocatableAvg: 40},
+				memory:    usageStats{requestedAvg: 8, requestedPeak: 12, usedPeak: 10, allocatablePerNode: 16, allocatableAvg: 160},
+				peakNodes: 10,
+			},
+			want: []string{"lower the minimum node count to 3 per zone"},
+		},
+		{
+			name: "autoscaler maximum reached",
+			pool: &containerpb.NodePool{
+				Config:      &containerpb.NodeConfig{MachineType: "e2-standard-4"},
+				Autoscaling: &containerpb.NodePoolAutoscaling{Enabled: true, TotalMinNodeCount: 1, TotalMaxNodeCount: 4},
+			},
+			stats: poolStats{
+				cpu:       usageStats{requestedAvg: 12, requestedPeak: 15, usedPeak: 12, allocatablePerNode: 4, allocatableAvg: 16},
+				memory:    usageStats{requestedAvg: 16, requestedPeak: 20, usedPeak: 16, allocatablePerNode: 16, allocatableAvg: 64},
+				peakNodes: 4,
+			},
+			want: []string{"raise the maximum node count to at least 5 in total", "Requests are CPU-bound"},
+		},
+		{
+			name: "over-requested and memory-bound",
+			pool: &containerpb.NodePool{
+				Config:          &containerpb.NodeConfig{MachineType: "n2-standard-8"},
+				UpgradeSettings: &containerpb.NodePool_UpgradeSettings{MaxSurge: 0, MaxUnavailable: 1},
+			},
+			stats: poolStats{
+				cpu:       usageStats{requestedAvg: 8, requestedPeak: 8, usedPeak: 2, allocatablePerNode: 8, allocatableAvg: 32},
+				memory:    usageStats{requestedAvg: 110, requestedPeak: 120, usedPeak: 100, allocatablePerNode: 32, allocatableAvg: 128},
+				peakNodes: 4,
+			},
+			want: []string{"use at most 25% of their CPU requests", "Requests are memory-bound", "set maxUnavailable to 0"},
+		},
+		{
+			name: "oversized fixed node pool",
+			pool: &containerpb.NodePool{
+				Config: &containerpb.NodeConfig{MachineType: "e2-standard-4"},
+			},
+			stats: poolStats{
+				cpu:       usageStats{requestedAvg: 3, requestedPeak: 4, usedPeak: 3, allocatablePerNode: 4, allocatableAvg: 40},
+				memory:    usageStats{requestedAvg: 12, requestedPeak: 16, usedPeak: 12, allocatablePerNode: 16, allocatableAvg: 160},
+				peakNodes: 10,
+			},
+			want: []string{"2 nodes instead of 10 would fit them"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recs := rightsizingRecommendations(tt.pool, tt.stats)
+			got := strings.Join(recs, "\n")
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("rightsizingRecommendations() = %q, want it to contain %q", got, want)
+				}
+			}
+			if len(recs) != len(tt.want) {
+				t.Errorf("rightsizingRecommendations() returned %d recommendations, want %d: %q", len(recs), len(tt.want), got)
+			}
+		})
+	}
+}
+
+func TestRightsizingReport(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		Name: "prod",
+		NodePools: []*containerpb.NodePool{
+			{Name: "default-pool", Config: &containerpb.NodeConfig{MachineType: "e2-standard-4"}},
+			{Name: "new-pool", Config: &containerpb.NodeConfig{MachineType: "e2-standard-8"}},
+		},
+	}
+	stats := map[string]poolStats{
+		"default-pool": {
+			cpu:       usageStats{requestedAvg: 5, requestedPeak: 6, usedAvg: 4, usedPeak: 5, allocatablePerNode: 4, allocatableAvg: 8},
+			memory:    usageStats{requestedAvg: 8 << 30, requestedPeak: 10 << 30, usedAvg: 7 << 30, usedPeak: 9 << 30, allocatablePerNode: 13 << 30, allocatableAvg: 26 << 30},
+			peakNodes: 2,
+		},
+	}
+
+	got := rightsizingReport(cluster, 7, stats)
+	for _, want := range []string{
+		"Period: last 7 days",
+		"Node pool: default-pool (e2-standard-4)",
+		"CPU (cores): allocatable 8.0, requested 5.0 avg / 6.0 peak, used 4.0 avg / 5.0 peak",
+		"Memory (GiB): allocatable 26.0, requested 8.0 avg / 10.0 peak",
+		"Recommendations: none",
+		"Node pool: new-pool (e2-standard-8)\n  No metrics found",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("rightsizingReport() = %q, want it to contain %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodepools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const scopePrefix = "https://www.googleapis.com/auth/"
+
+// broadScopes are the OAuth scopes giving the nodes write access to most of
+// the Google Cloud APIs, or to all the Cloud Storage buckets or Compute Engine
+// resources, when their service account has the permissions.
+var broadScopes = []string{
+	"cloud-platform",
+	"compute",
+	"devstorage.full_control",
+	"devstorage.read_write",
+}
+
+type reviewNodeServiceAccountsArgs struct {
+	ProjectID string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location  string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name      string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+}
+
+// nodeIdentity is the service account and the scopes of nodes.
+type nodeIdentity struct {
+	// nodes names the node pool, or the node auto-provisioning defaults.
+	nodes          string
+	serviceAccount string
+	scopes         []string
+	// gkeMetadata is set when Workload Identity Federation hides the node
+	// credentials from the pods.
+	gkeMetadata bool
+}
+
+func (h *handlers) reviewNodeServiceAccounts(ctx context.Context, _ *mcp.CallToolRequest, args *reviewNodeServiceAccountsArgs) (*mcp.CallToolResult, any, error) {
+	if args.ProjectID == "" {
+		args.ProjectID = h.c.DefaultProjectID(ctx)
+	}
+	cluster, err := gke.GetCluster(ctx, h.cmClient, h.c, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: serviceAccountsReport(cluster, args.ProjectID)},
+		},
+	}, nil, nil
+}
+
+// nodeIdentities returns the service accounts and scopes of the node pools,
+// and of the node auto-provisioning defaults when it's enabled.
+func nodeIdentities(cluster *containerpb.Cluster) []nodeIdentity {
+	var identities []nodeIdentity
+	for _, pool := range cluster.GetNodePools() {
+		identities = append(identities, nodeIdentity{
+			nodes:          "Node pool " + pool.GetName(),
+			serviceAccount: pool.GetConfig().GetServiceAccount(),
+			scopes:         pool.GetConfig().GetOauthScopes(),
+			gkeMetadata:    pool.GetConfig().GetWorkloadMetadataConfig().GetMode() == containerpb.WorkloadMetadataConfig_GKE_METADATA,
+		})
+	}
+	if cluster.GetAutoscaling().GetEnableNodeAutoprovisioning() || cluster.GetAutopilot().GetEnabled() {
+		defaults := cluster.GetAutoscaling().GetAutoprovisioningNodePoolDefaults()
+		identities = append(identities, nodeIdentity{
+			nodes:          "Node auto-provisioning defaults",
+			serviceAccount: defaults.GetServiceAccount(),
+			scopes:         defaults.GetOauthScopes(),
+			gkeMetadata:    cluster.GetWorkloadIdentityConfig().GetWorkloadPool() != "",
+		})
+	}
+	return identities
+}
+
+// isDefaultServiceAccount reports whether the nodes run as the Compute Engine
+// default service account, which has the Editor role on the project unless
+// it was removed.
+func isDefaultServiceAccount(serviceAccount string) bool {
+	return serviceAccount == "" || serviceAccount == "default" || strings.HasSuffix(serviceAccount, "-compute@developer.gserviceaccount.com")
+}
+
+// identityFinding returns the severity and the finding of the identity of
+// nodes, empty if it follows the recommendations.
+func identityFinding(id nodeIdentity) (string, string) {
+	if !isDefaultServiceAccount(id.serviceAccount) {
+		return "", ""
+	}
+	var broad []string
+	for _, scope := range id.scopes {
+		if s := strings.TrimPrefix(scope, scopePrefix); slices.Contains(broadScopes, s) {
+			broad = append(broad, s)
+		}
+	}
+	switch {
+	case len(broad) > 0 && !id.gkeMetadata:
+		return "HIGH", fmt.Sprintf("runs as the Compute Engine default service account with the %s scopes, and the pods can get its credentials from the metadata server, e.g. to use its Editor role on the project", strings.Join(broad, ", "))
+	case len(broad) > 0:
+		return "MEDIUM", fmt.Sprintf("runs as the Compute Engine default service account with the %s scopes; Workload Identity Federation hides its credentials from the pods, except those using the host network", strings.Join(broad, ", "))
+	default:
+		return "MEDIUM", "runs as the Compute Engine default service account; its scopes limit its Editor role, but scopes are a legacy access control"
+	}
+}
+
+func serviceAccountsReport(cluster *containerpb.Cluster, projectID string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cluster: %s\n", cluster.GetName())
+	workloadPool := cluster.GetWorkloadIdentityConfig().GetWorkloadPool()
+	if workloadPool == "" {
+		workloadPool = "disabled"
+	}
+	fmt.Fprintf(&b, "Workload Identity Federation: %s\n", workloadPool)
+
+	var findings []string
+	for _, id := range nodeIdentities(cluster) {
+		serviceAccount := id.serviceAccount
+		if isDefaultServiceAccount(serviceAccount) {
+			serviceAccount = "Compute Engine default service account"
+		}
+		var scopes []string
+		for _, scope := range id.scopes {
+			scopes = append(scopes, strings.TrimPrefix(scope, scopePrefix))
+		}
+		if len(scopes) == 0 {
+			scopes = append(scopes, "none")
+		}
+		fmt.Fprintf(&b, "\n%s:\n", id.nodes)
+		fmt.Fprintf(&b, "  Service account: %s\n", serviceAccount)
+		fmt.Fprintf(&b, "  Scopes: %s\n", strings.Join(scopes, ", "))
+		fmt.Fprintf(&b, "  GKE metadata server: %t\n", id.gkeMetadata)
+		if severity, finding := identityFinding(id); finding != "" {
+			fmt.Fprintf(&b, "  %s: %s\n", severity, finding)
+			findings = append(findings, id.nodes)
+		}
+	}
+
+	if len(findings) == 0 {
+		b.WriteString("\nAll the nodes run as dedicated service accounts. Check that they only have the roles/container.defaultNodeServiceAccount role, and the roles the nodes need to pull images, e.g. roles/artifactregistry.reader.\n")
+		return b.String()
+	}
+
+	sa := fmt.Sprintf("gke-nodes@%s.iam.gserviceaccount.com", projectID)
+	fmt.Fprintf(&b, "\nRemediation of %s:\n", strings.Join(findings, ", "))
+	b.WriteString("- Create a dedicated service account with the minimal role of the nodes, and grant it roles/artifactregistry.reader on the repositories of private images:\n")
+	fmt.Fprintf(&b, "  gcloud iam service-accounts create gke-nodes --project %s\n", projectID)
+	fmt.Fprintf(&b, "  gcloud projects add-iam-policy-binding %s --member serviceAccount:%s --role roles/container.defaultNodeServiceAccount\n", projectID, sa)
+	if cluster.GetAutopilot().GetEnabled() {
+		b.WriteString("- The service account of the nodes of an Autopilot cluster is set at creation: create a new cluster with the --service-account flag and migrate the workloads.\n")
+	} else {
+		b.WriteString("- The service account of a node pool can't be changed: create a replacement node pool with the same settings and the new service account, cordon and drain the old nodes, then delete the old node pool:\n")
+		fmt.Fprintf(&b, "  gcloud container node-pools create POOL-v2 --cluster %s --location %s --service-account %s --scopes cloud-platform\n", cluster.GetName(), cluster.GetLocation(), sa)
+	}
+	if cluster.GetAutoscaling().GetEnableNodeAutoprovisioning() {
+		b.WriteString("- Set the service account of the auto-provisioned node pools:\n")
+		fmt.Fprintf(&b, "  gcloud container clusters update %s --location %s --enable-autoprovisioning --autoprovisioning-service-account %s\n", cluster.GetName(), cluster.GetLocation(), sa)
+	}
+	if cluster.GetWorkloadIdentityConfig().GetWorkloadPool() == "" {
+		b.WriteString("- Enable Workload Identity Federation for GKE, so that the pods calling Google Cloud APIs use their own identity instead of the credentials of the nodes:\n")
+		fmt.Fprintf(&b, "  gcloud container clusters update %s --location %s --workload-pool %s.svc.id.goog\n", cluster.GetName(), cluster.GetLocation(), projectID)
+	}
+	b.WriteString("- Remove the Editor role from the Compute Engine default service account once nothing else uses it.\n")
+	return b.String()
+}
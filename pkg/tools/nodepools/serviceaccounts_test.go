@@ -0,0 +1,91 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodepools
+
+import (
+	"strings"
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+)
+
+func TestIdentityFinding(t *testing.T) {
+	tests := []struct {
+		name         string
+		id           nodeIdentity
+		wantSeverity string
+	}{
+		{name: "dedicated service account", id: nodeIdentity{serviceAccount: "gke-nodes@p.iam.gserviceaccount.com", scopes: []string{scopePrefix + "cloud-platform"}}},
+		{name: "default with cloud-platform", id: nodeIdentity{serviceAccount: "default", scopes: []string{scopePrefix + "cloud-platform"}}, wantSeverity: "HIGH"},
+		{name: "default email with compute", id: nodeIdentity{serviceAccount: "123-compute@developer.gserviceaccount.com", scopes: []string{scopePrefix + "compute"}}, wantSeverity: "HIGH"},
+		{name: "default with broad scopes and GKE metadata server", id: nodeIdentity{scopes: []string{scopePrefix + "devstorage.read_write"}, gkeMetadata: true}, wantSeverity: "MEDIUM"},
+		{name: "default with gke-default scopes", id: nodeIdentity{serviceAccount: "default", scopes: []string{scopePrefix + "devstorage.read_only", scopePrefix + "logging.write"}}, wantSeverity: "MEDIUM"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			severity, _ := identityFinding(tt.id)
+			if severity != tt.wantSeverity {
+				t.Errorf("identityFinding() severity = %q, want %q", severity, tt.wantSeverity)
+			}
+		})
+	}
+}
+
+func TestServiceAccountsReport(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		Name:     "prod",
+		Location: "us-central1",
+		NodePools: []*containerpb.NodePool{
+			{Name: "default-pool", Config: &containerpb.NodeConfig{ServiceAccount: "default", OauthScopes: []string{scopePrefix + "cloud-platform"}}},
+			{Name: "secure", Config: &containerpb.NodeConfig{
+				ServiceAccount:         "gke-nodes@p.iam.gserviceaccount.com",
+				OauthScopes:            []string{scopePrefix + "cloud-platform"},
+				WorkloadMetadataConfig: &containerpb.WorkloadMetadataConfig{Mode: containerpb.WorkloadMetadataConfig_GKE_METADATA},
+			}},
+		},
+		Autoscaling: &containerpb.ClusterAutoscaling{EnableNodeAutoprovisioning: true},
+	}
+
+	got := serviceAccountsReport(cluster, "p")
+	for _, want := range []string{
+		"Workload Identity Federation: disabled\n",
+		"Node pool default-pool:\n  Service account: Compute Engine default service account\n  Scopes: cloud-platform\n  GKE metadata server: false\n  HIGH: runs as the Compute Engine default service account with the cloud-platform scopes",
+		"Node pool secure:\n  Service account: gke-nodes@p.iam.gserviceaccount.com\n  Scopes: cloud-platform\n  GKE metadata server: true\n\n",
+		"Node auto-provisioning defaults:\n  Service account: Compute Engine default service account\n  Scopes: none\n",
+		"Remediation of Node pool default-pool, Node auto-provisioning defaults:\n",
+		"--role roles/container.defaultNodeServiceAccount\n",
+		"gcloud container node-pools create POOL-v2 --cluster prod --location us-central1 --service-account gke-nodes@p.iam.gserviceaccount.com --scopes cloud-platform\n",
+		"--autoprovisioning-service-account gke-nodes@p.iam.gserviceaccount.com\n",
+		"--workload-pool p.svc.id.goog\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("serviceAccountsReport() missing %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestServiceAccountsReport_Dedicated(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		Name:                   "prod",
+		WorkloadIdentityConfig: &containerpb.WorkloadIdentityConfig{WorkloadPool: "p.svc.id.goog"},
+		NodePools:              []*containerpb.NodePool{{Name: "pool", Config: &containerpb.NodeConfig{ServiceAccount: "gke-nodes@p.iam.gserviceaccount.com"}}},
+	}
+
+	got := serviceAccountsReport(cluster, "p")
+	if !strings.Contains(got, "All the nodes run as dedicated service accounts.") || strings.Contains(got, "Remediation") {
+		t.Errorf("serviceAccountsReport() = %q", got)
+	}
+}
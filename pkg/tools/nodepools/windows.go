@@ -0,0 +1,149 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodepools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/gkereleasenotes"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// windowsReleaseNoteKeywords are used to pick Windows-related entries from the GKE release notes.
+var windowsReleaseNoteKeywords = []string{"windows"}
+
+type checkWindowsNodePoolsArgs struct {
+	ProjectID     string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location      string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name          string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+	TargetVersion string `json:"target_version,omitempty" jsonschema:"Target GKE version of the upgrade. Leave this empty if the user doesn't provide it."`
+}
+
+func (h *handlers) checkWindowsNodePools(ctx context.Context, _ *mcp.CallToolRequest, args *checkWindowsNodePoolsArgs) (*mcp.CallToolResult, any, error) {
+	cluster, err := gke.GetCluster(ctx, h.cmClient, h.c, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	releaseNoteEntries, err := gkereleasenotes.UpgradeEntries(ctx, cluster.GetCurrentMasterVersion(), args.TargetVersion, windowsReleaseNoteKeywords)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var nodes kube.List[kube.Node]
+	var pods kube.List[kube.Pod]
+	kubeErr := kube.Get(ctx, &nodes, "nodes", "-l", "kubernetes.io/os=windows")
+	if kubeErr == nil {
+		kubeErr = kube.Get(ctx, &pods, "pods", "--all-namespaces")
+	}
+
+	report := windowsReport(cluster, nodes.Items, pods.Items, args.TargetVersion, releaseNoteEntries)
+	if kubeErr != nil {
+		report += fmt.Sprintf("\nWindows nodes and workloads were not analyzed: %v\n", kubeErr)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: report},
+		},
+	}, nil, nil
+}
+
+func windowsOSVersion(pool *containerpb.NodePool) string {
+	switch pool.GetConfig().GetWindowsNodeConfig().GetOsVersion() {
+	case containerpb.WindowsNodeConfig_OS_VERSION_LTSC2019:
+		return "Windows Server 2019 (LTSC)"
+	case containerpb.WindowsNodeConfig_OS_VERSION_LTSC2022:
+		return "Windows Server 2022 (LTSC)"
+	default:
+		return "default for the image type"
+	}
+}
+
+func windowsReport(cluster *containerpb.Cluster, nodes []kube.Node, pods []kube.Pod, targetVersion string, releaseNoteEntries []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cluster: %s\n", cluster.GetName())
+
+	var windowsPools []string
+	hasLinuxPool := false
+	for _, pool := range cluster.GetNodePools() {
+		imageType := strings.ToUpper(pool.GetConfig().GetImageType())
+		if !gke.IsWindowsImageType(imageType) {
+			hasLinuxPool = true
+			continue
+		}
+		windowsPools = append(windowsPools, pool.GetName())
+
+		fmt.Fprintf(&b, "\nNode pool %s (version %s, image type %s, OS version %s)\n", pool.GetName(), pool.GetVersion(), imageType, windowsOSVersion(pool))
+		var builds []string
+		for _, node := range nodes {
+			if node.Labels[gke.NodePoolLabel] != pool.GetName() {
+				continue
+			}
+			build := fmt.Sprintf("%s, build %s, %s", node.Status.NodeInfo.OSImage, node.Status.NodeInfo.KernelVersion, node.Status.NodeInfo.ContainerRuntimeVersion)
+			if !slices.Contains(builds, build) {
+				builds = append(builds, build)
+			}
+		}
+		for _, build := range builds {
+			fmt.Fprintf(&b, "- Current nodes: %s\n", build)
+		}
+		if strings.Contains(imageType, "SAC") {
+			b.WriteString("- Warning: Windows Server Semi-Annual Channel images are no longer supported, recreate the node pool with an LTSC image type.\n")
+		}
+		if !strings.Contains(imageType, "CONTAINERD") {
+			b.WriteString("- Warning: the node image type uses Docker, recreate the node pool with a containerd image type.\n")
+		}
+	}
+	if len(windowsPools) == 0 {
+		b.WriteString("\nThe cluster has no Windows Server node pools.\n")
+		return b.String()
+	}
+
+	b.WriteString("\nWindows Server node pool constraints:\n")
+	b.WriteString("- Windows containers use process isolation, so container images must be built for the Windows Server version of the nodes. A change of the OS version requires rebuilding the images.\n")
+	b.WriteString("- Each GKE version ships a new Windows Server node image, and Windows node images are much larger than Linux ones, so Windows node pool upgrades take longer. Consider a higher maxSurge.\n")
+	b.WriteString("- Windows node pools follow the Kubernetes version skew policy like Linux node pools and are never upgraded before the control plane.\n")
+	if !hasLinuxPool {
+		b.WriteString("- Warning: the cluster has no Linux node pool. GKE system workloads require at least one Linux node pool.\n")
+	}
+
+	windowsNodes := map[string]bool{}
+	for _, node := range nodes {
+		windowsNodes[node.Name] = true
+	}
+	var workloads []string
+	for _, pod := range pods {
+		if !windowsNodes[pod.Spec.NodeName] {
+			continue
+		}
+		for _, c := range pod.Spec.Containers {
+			workloads = append(workloads, fmt.Sprintf("- %s/%s: %s", pod.Namespace, pod.Name, c.Image))
+		}
+	}
+	if len(workloads) > 0 {
+		b.WriteString("\nContainer images running on Windows nodes, check that they match the Windows Server version of the upgraded nodes:\n")
+		b.WriteString(strings.Join(workloads, "\n"))
+		b.WriteString("\n")
+	}
+
+	gkereleasenotes.WriteEntries(&b, "Windows-related", cluster.GetCurrentMasterVersion(), targetVersion, releaseNoteEntries)
+	return b.String()
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodepools
+
+import (
+	"strings"
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWindowsReport(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		Name:                 "my-cluster",
+		CurrentMasterVersion: "1.32.4-gke.1029000",
+		NodePools: []*containerpb.NodePool{
+			{
+				Name:    "win-pool",
+				Version: "1.32.4-gke.1029000",
+				Config: &containerpb.NodeConfig{
+					ImageType:         "WINDOWS_LTSC_CONTAINERD",
+					WindowsNodeConfig: &containerpb.WindowsNodeConfig{OsVersion: containerpb.WindowsNodeConfig_OS_VERSION_LTSC2019},
+				},
+			},
+			{
+				Name:    "sac-pool",
+				Version: "1.32.4-gke.1029000",
+				Config:  &containerpb.NodeConfig{ImageType: "WINDOWS_SAC"},
+			},
+		},
+	}
+	nodes := []kube.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "win-node", Labels: map[string]string{gke.NodePoolLabel: "win-pool"}},
+			Status: kube.NodeStatus{NodeInfo: kube.NodeSystemInfo{
+				OSImage:                 "Windows Server 2019 Datacenter",
+				KernelVersion:           "10.0.17763.6414",
+				ContainerRuntimeVersion: "containerd://1.7.20",
+			}},
+		},
+	}
+	pods := []kube.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "iis"},
+			Spec:       kube.PodSpec{NodeName: "win-node", Containers: []kube.Container{{Name: "iis", Image: "mcr.microsoft.com/windows/servercore/iis:windowsservercore-ltsc2019"}}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx"},
+			Spec:       kube.PodSpec{NodeName: "linux-node", Containers: []kube.Container{{Name: "nginx", Image: "nginx"}}},
+		},
+	}
+
+	report := windowsReport(cluster, nodes, pods, "", nil)
+	for _, want := range []string{
+		"Node pool win-pool (version 1.32.4-gke.1029000, image type WINDOWS_LTSC_CONTAINERD, OS version Windows Server 2019 (LTSC))\n- Current nodes: Windows Server 2019 Datacenter, build 10.0.17763.6414, containerd://1.7.20\n",
+		"Node pool sac-pool (version 1.32.4-gke.1029000, image type WINDOWS_SAC, OS version default for the image type)\n- Warning: Windows Server Semi-Annual Channel images are no longer supported",
+		"- Warning: the node image type uses Docker",
+		"- Warning: the cluster has no Linux node pool.",
+		"- default/iis: mcr.microsoft.com/windows/servercore/iis:windowsservercore-ltsc2019",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("windowsReport() missing %q in:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "nginx") {
+		t.Errorf("windowsReport() reports Linux workloads:\n%s", report)
+	}
+
+	linux := &containerpb.Cluster{Name: "linux-cluster", NodePools: []*containerpb.NodePool{{Name: "default-pool"}}}
+	if report := windowsReport(linux, nil, nil, "", nil); !strings.Contains(report, "The cluster has no Windows Server node pools.") {
+		t.Errorf("windowsReport() for a Linux cluster = %q", report)
+	}
+}
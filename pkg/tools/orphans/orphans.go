@@ -0,0 +1,359 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package orphans provides MCP tools finding the Google Cloud resources left
+// behind by deleted GKE clusters and Kubernetes Services.
+package orphans
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	container "cloud.google.com/go/container/apiv1"
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	compute "google.golang.org/api/compute/v1"
+)
+
+const (
+	// serviceNameKey is the key of the descriptions of the load balancer
+	// resources created for a Service.
+	serviceNameKey = "kubernetes.io/service-name"
+	// pvNameKey is the key of the descriptions of the disks provisioned for a
+	// PersistentVolume.
+	pvNameKey = "kubernetes.io/created-for/pv/name"
+	// pvcNameKey and pvcNamespaceKey name the claim a disk was provisioned for.
+	pvcNameKey      = "kubernetes.io/created-for/pvc/name"
+	pvcNamespaceKey = "kubernetes.io/created-for/pvc/namespace"
+	// clusterNameLabel is the label of the disks and VMs created by GKE.
+	clusterNameLabel = "goog-k8s-cluster-name"
+)
+
+var (
+	// clusterFirewallRegexp matches the firewall rules GKE creates for a
+	// cluster, gke-{cluster}-{first 8 characters of the cluster ID}-{purpose}.
+	clusterFirewallRegexp = regexp.MustCompile(`^gke-.+-([0-9a-f]{8})-[a-z]+$`)
+	// serviceTargetPoolRegexp matches the names of the load balancers of
+	// Services, "a" followed by the UID of the Service without dashes.
+	serviceTargetPoolRegexp = regexp.MustCompile(`^a[0-9a-f]{31,32}$`)
+)
+
+type handlers struct {
+	c        *config.Config
+	cmClient *container.ClusterManagerClient
+}
+
+// Install registers the orphaned resource tools with the MCP server.
+func Install(ctx context.Context, s *mcp.Server, c *config.Config) error {
+	cmClient, err := container.NewClusterManagerClient(ctx, c.ClientOptions()...)
+	if err != nil {
+		return fmt.Errorf("failed to create cluster manager client: %w", err)
+	}
+
+	h := &handlers{
+		c:        c,
+		cmClient: cmClient,
+	}
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "find_orphaned_resources",
+		Description: "Find the Google Cloud resources of a project left behind by deleted GKE clusters and LoadBalancer Services: forwarding rules and target pools without live instances or backends, firewall rules of deleted clusters and load balancers, and unattached persistent disks provisioned for PersistentVolumes. They cost money and can confuse networking, e.g. firewall rules allowing traffic to reused IP addresses. Nothing is deleted.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.findOrphanedResources)
+
+	return nil
+}
+
+type findOrphanedResourcesArgs struct {
+	ProjectID string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+}
+
+// projectResources are the resources of a project checked for orphans.
+type projectResources struct {
+	clusters []*containerpb.Cluster
+	// instances are the self links of the VMs of the project.
+	instances       map[string]bool
+	firewalls       []*compute.Firewall
+	forwardingRules []*compute.ForwardingRule
+	targetPools     []*compute.TargetPool
+	backendServices []*compute.BackendService
+	disks           []*compute.Disk
+}
+
+// orphan is a resource which is likely no longer used.
+type orphan struct {
+	kind   string
+	name   string
+	reason string
+}
+
+func (h *handlers) findOrphanedResources(ctx context.Context, _ *mcp.CallToolRequest, args *findOrphanedResourcesArgs) (*mcp.CallToolResult, any, error) {
+	if args.ProjectID == "" {
+		args.ProjectID = h.c.DefaultProjectID(ctx)
+	}
+	if args.ProjectID == "" {
+		return nil, nil, fmt.Errorf("project_id argument cannot be empty")
+	}
+
+	resp, err := h.cmClient.ListClusters(ctx, &containerpb.ListClustersRequest{
+		Parent: fmt.Sprintf("projects/%s/locations/-", args.ProjectID),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list clusters: %w", err)
+	}
+
+	svc, err := compute.NewService(ctx, h.c.RESTClientOptions(ctx)...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create compute client: %w", err)
+	}
+
+	r := projectResources{clusters: resp.Clusters, instances: map[string]bool{}}
+	if err := svc.Instances.AggregatedList(args.ProjectID).Pages(ctx, func(l *compute.InstanceAggregatedList) error {
+		for _, scoped := range l.Items {
+			for _, instance := range scoped.Instances {
+				r.instances[instance.SelfLink] = true
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, nil, fmt.Errorf("failed to list instances: %w", err)
+	}
+	if err := svc.Firewalls.List(args.ProjectID).Pages(ctx, func(l *compute.FirewallList) error {
+		r.firewalls = append(r.firewalls, l.Items...)
+		return nil
+	}); err != nil {
+		return nil, nil, fmt.Errorf("failed to list firewall rules: %w", err)
+	}
+	if err := svc.ForwardingRules.AggregatedList(args.ProjectID).Pages(ctx, func(l *compute.ForwardingRuleAggregatedList) error {
+		for _, scoped := range l.Items {
+			r.forwardingRules = append(r.forwardingRules, scoped.ForwardingRules...)
+		}
+		return nil
+	}); err != nil {
+		return nil, nil, fmt.Errorf("failed to list forwarding rules: %w", err)
+	}
+	if err := svc.TargetPools.AggregatedList(args.ProjectID).Pages(ctx, func(l *compute.TargetPoolAggregatedList) error {
+		for _, scoped := range l.Items {
+			r.targetPools = append(r.targetPools, scoped.TargetPools...)
+		}
+		return nil
+	}); err != nil {
+		return nil, nil, fmt.Errorf("failed to list target pools: %w", err)
+	}
+	if err := svc.BackendServices.AggregatedList(args.ProjectID).Pages(ctx, func(l *compute.BackendServiceAggregatedList) error {
+		for _, scoped := range l.Items {
+			r.backendServices = append(r.backendServices, scoped.BackendServices...)
+		}
+		return nil
+	}); err != nil {
+		return nil, nil, fmt.Errorf("failed to list backend services: %w", err)
+	}
+	if err := svc.Disks.AggregatedList(args.ProjectID).Pages(ctx, func(l *compute.DiskAggregatedList) error {
+		for _, scoped := range l.Items {
+			r.disks = append(r.disks, scoped.Disks...)
+		}
+		return nil
+	}); err != nil {
+		return nil, nil, fmt.Errorf("failed to list disks: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: orphansReport(args.ProjectID, r)},
+		},
+	}, nil, nil
+}
+
+// description decodes the JSON descriptions Kubernetes writes on the Google
+// Cloud resources it creates. Other descriptions decode to nil.
+func description(s string) map[string]string {
+	var d map[string]string
+	if err := json.Unmarshal([]byte(s), &d); err != nil {
+		return nil
+	}
+	return d
+}
+
+// liveInstances returns the number of instances of a target pool which still exist.
+func liveInstances(pool *compute.TargetPool, instances map[string]bool) int {
+	n := 0
+	for _, instance := range pool.Instances {
+		if instances[instance] {
+			n++
+		}
+	}
+	return n
+}
+
+// orphanedLoadBalancers returns the forwarding rules and target pools of
+// Services whose nodes or backends no longer exist.
+func orphanedLoadBalancers(r projectResources) []orphan {
+	pools := map[string]*compute.TargetPool{}
+	for _, pool := range r.targetPools {
+		pools[pool.SelfLink] = pool
+	}
+	backendServices := map[string]*compute.BackendService{}
+	for _, bs := range r.backendServices {
+		backendServices[bs.SelfLink] = bs
+	}
+
+	var orphans []orphan
+	targeted := map[string]bool{}
+	for _, rule := range r.forwardingRules {
+		targeted[rule.Target] = true
+		service := description(rule.Description)[serviceNameKey]
+		if service == "" {
+			continue
+		}
+		name := fmt.Sprintf("%s (%s, Service %s)", rule.Name, rule.IPAddress, service)
+		switch {
+		case rule.Target != "":
+			pool, ok := pools[rule.Target]
+			if !ok {
+				orphans = append(orphans, orphan{"Forwarding rule", name, "its target pool no longer exists"})
+			} else if liveInstances(pool, r.instances) == 0 {
+				orphans = append(orphans, orphan{"Forwarding rule", name, "none of the nodes of its target pool exist"})
+			}
+		case rule.BackendService != "":
+			bs, ok := backendServices[rule.BackendService]
+			if !ok {
+				orphans = append(orphans, orphan{"Forwarding rule", name, "its backend service no longer exists"})
+			} else if len(bs.Backends) == 0 {
+				orphans = append(orphans, orphan{"Forwarding rule", name, "its backend service has no backends"})
+			}
+		}
+	}
+
+	for _, pool := range r.targetPools {
+		service := description(pool.Description)[serviceNameKey]
+		if service == "" && !serviceTargetPoolRegexp.MatchString(pool.Name) {
+			continue
+		}
+		name := pool.Name
+		if service != "" {
+			name = fmt.Sprintf("%s (Service %s)", pool.Name, service)
+		}
+		switch {
+		case !targeted[pool.SelfLink]:
+			orphans = append(orphans, orphan{"Target pool", name, "no forwarding rule uses it"})
+		case liveInstances(pool, r.instances) == 0:
+			orphans = append(orphans, orphan{"Target pool", name, "none of its nodes exist"})
+		}
+	}
+	return orphans
+}
+
+// orphanedFirewalls returns the firewall rules of deleted clusters and of
+// deleted Service load balancers.
+func orphanedFirewalls(r projectResources) []orphan {
+	loadBalancers := map[string]bool{}
+	for _, rule := range r.forwardingRules {
+		loadBalancers[rule.Name] = true
+	}
+
+	var orphans []orphan
+	for _, fw := range r.firewalls {
+		if m := clusterFirewallRegexp.FindStringSubmatch(fw.Name); m != nil {
+			found := false
+			for _, cluster := range r.clusters {
+				found = found || strings.HasPrefix(cluster.GetId(), m[1])
+			}
+			if !found {
+				orphans = append(orphans, orphan{"Firewall rule", fw.Name, "no cluster of the project has the ID it was created for"})
+			}
+			continue
+		}
+		if lb, ok := strings.CutPrefix(fw.Name, "k8s-fw-"); ok && !loadBalancers[lb] {
+			name := fw.Name
+			if service := description(fw.Description)[serviceNameKey]; service != "" {
+				name = fmt.Sprintf("%s (Service %s)", fw.Name, service)
+			}
+			orphans = append(orphans, orphan{"Firewall rule", name, "the load balancer it opens no longer exists"})
+		}
+	}
+	return orphans
+}
+
+// orphanedDisks returns the unattached disks provisioned for PersistentVolumes
+// or created by GKE, and the total size of the disks.
+func orphanedDisks(r projectResources) ([]orphan, int64) {
+	clusters := map[string]bool{}
+	for _, cluster := range r.clusters {
+		clusters[cluster.GetName()] = true
+	}
+
+	var orphans []orphan
+	var sizeGB int64
+	for _, disk := range r.disks {
+		if len(disk.Users) > 0 {
+			continue
+		}
+		d := description(disk.Description)
+		cluster := disk.Labels[clusterNameLabel]
+		name := fmt.Sprintf("%s (%d GB)", disk.Name, disk.SizeGb)
+		switch {
+		case cluster != "" && !clusters[cluster]:
+			orphans = append(orphans, orphan{"Disk", name, fmt.Sprintf("unattached, and its cluster %s no longer exists", cluster)})
+		case d[pvNameKey] != "":
+			claim := d[pvcNameKey]
+			if ns := d[pvcNamespaceKey]; ns != "" {
+				claim = ns + "/" + claim
+			}
+			orphans = append(orphans, orphan{"Disk", name, fmt.Sprintf("unattached disk of PersistentVolume %s for claim %s; check that no PersistentVolume references it, e.g. of a StatefulSet scaled down", d[pvNameKey], claim)})
+		default:
+			continue
+		}
+		sizeGB += disk.SizeGb
+	}
+	return orphans, sizeGB
+}
+
+func orphansReport(projectID string, r projectResources) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Project: %s\n", projectID)
+	var names []string
+	for _, cluster := range r.clusters {
+		names = append(names, cluster.GetName())
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		names = append(names, "none")
+	}
+	fmt.Fprintf(&b, "Existing clusters: %s\n", strings.Join(names, ", "))
+
+	disks, sizeGB := orphanedDisks(r)
+	orphans := append(orphanedLoadBalancers(r), orphanedFirewalls(r)...)
+	orphans = append(orphans, disks...)
+	if len(orphans) == 0 {
+		b.WriteString("\nNo orphaned load balancers, target pools, firewall rules or disks were found.\n")
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "\nLikely orphaned resources (%d):\n", len(orphans))
+	for _, o := range orphans {
+		fmt.Fprintf(&b, "- %s %s: %s\n", o.kind, o.name, o.reason)
+	}
+	if sizeGB > 0 {
+		fmt.Fprintf(&b, "\nThe orphaned disks hold %d GB of persistent disk billed every month.\n", sizeGB)
+	}
+	b.WriteString("\nConfirm that no cluster of another project, or Service being recreated, uses a resource before deleting it. Snapshot the disks holding data first.\n")
+	return b.String()
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orphans
+
+import (
+	"strings"
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	compute "google.golang.org/api/compute/v1"
+)
+
+const (
+	zoneURL   = "https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a"
+	regionURL = "https://www.googleapis.com/compute/v1/projects/p/regions/us-central1"
+)
+
+func TestOrphansReport(t *testing.T) {
+	r := projectResources{
+		clusters:  []*containerpb.Cluster{{Name: "prod", Id: "0123abcd4567ef89"}},
+		instances: map[string]bool{zoneURL + "/instances/gke-prod-node-1": true},
+		forwardingRules: []*compute.ForwardingRule{
+			{Name: "alive", IPAddress: "34.1.1.1", Target: regionURL + "/targetPools/alive", Description: `{"kubernetes.io/service-name":"shop/frontend"}`},
+			{Name: "a5f1e0c2b7d94e3a8c6b1d0e9f2a3b4c", IPAddress: "34.2.2.2", Target: regionURL + "/targetPools/a5f1e0c2b7d94e3a8c6b1d0e9f2a3b4c", Description: `{"kubernetes.io/service-name":"old/api"}`},
+			{Name: "ilb", IPAddress: "10.0.0.5", BackendService: regionURL + "/backendServices/k8s2-ilb", Description: `{"kubernetes.io/service-name":"old/internal"}`},
+			{Name: "manual", IPAddress: "34.3.3.3", Target: regionURL + "/targetPools/missing"},
+		},
+		targetPools: []*compute.TargetPool{
+			{Name: "alive", SelfLink: regionURL + "/targetPools/alive", Instances: []string{zoneURL + "/instances/gke-prod-node-1"}},
+			{Name: "a5f1e0c2b7d94e3a8c6b1d0e9f2a3b4c", SelfLink: regionURL + "/targetPools/a5f1e0c2b7d94e3a8c6b1d0e9f2a3b4c", Instances: []string{zoneURL + "/instances/gke-old-node-1"}},
+			{Name: "a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5", SelfLink: regionURL + "/targetPools/a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5"},
+		},
+		backendServices: []*compute.BackendService{{Name: "k8s2-ilb", SelfLink: regionURL + "/backendServices/k8s2-ilb"}},
+		firewalls: []*compute.Firewall{
+			{Name: "gke-prod-0123abcd-all"},
+			{Name: "gke-old-9876fedc-all"},
+			{Name: "k8s-fw-alive"},
+			{Name: "k8s-fw-a9999999999999999999999999999999", Description: `{"kubernetes.io/service-name":"old/web", "kubernetes.io/service-ip":"34.4.4.4"}`},
+			{Name: "allow-ssh"},
+		},
+		disks: []*compute.Disk{
+			{Name: "pvc-attached", SizeGb: 100, Users: []string{zoneURL + "/instances/gke-prod-node-1"}, Description: `{"kubernetes.io/created-for/pv/name":"pvc-attached"}`},
+			{Name: "pvc-1234", SizeGb: 50, Description: `{"kubernetes.io/created-for/pv/name":"pvc-1234","kubernetes.io/created-for/pvc/name":"data-db-2","kubernetes.io/created-for/pvc/namespace":"db"}`},
+			{Name: "gke-old-disk", SizeGb: 200, Labels: map[string]string{clusterNameLabel: "old"}},
+			{Name: "gke-prod-disk", SizeGb: 30, Labels: map[string]string{clusterNameLabel: "prod"}},
+			{Name: "scratch", SizeGb: 10},
+		},
+	}
+
+	got := orphansReport("p", r)
+	for _, want := range []string{
+		"Existing clusters: prod\n",
+		"Likely orphaned resources (8):\n",
+		"- Forwarding rule a5f1e0c2b7d94e3a8c6b1d0e9f2a3b4c (34.2.2.2, Service old/api): none of the nodes of its target pool exist\n",
+		"- Forwarding rule ilb (10.0.0.5, Service old/internal): its backend service has no backends\n",
+		"- Target pool a5f1e0c2b7d94e3a8c6b1d0e9f2a3b4c: none of its nodes exist\n",
+		"- Target pool a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5: no forwarding rule uses it\n",
+		"- Firewall rule gke-old-9876fedc-all: no cluster of the project has the ID it was created for\n",
+		"- Firewall rule k8s-fw-a9999999999999999999999999999999 (Service old/web): the load balancer it opens no longer exists\n",
+		"- Disk pvc-1234 (50 GB): unattached disk of PersistentVolume pvc-1234 for claim db/data-db-2",
+		"- Disk gke-old-disk (200 GB): unattached, and its cluster old no longer exists\n",
+		"hold 250 GB of persistent disk",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("orphansReport() missing %q, got:\n%s", want, got)
+		}
+	}
+	for _, unwanted := range []string{"alive", "manual", "gke-prod-0123abcd-all", "allow-ssh", "pvc-attached", "gke-prod-disk", "scratch"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("orphansReport() reports %q, got:\n%s", unwanted, got)
+		}
+	}
+}
+
+func TestOrphansReport_None(t *testing.T) {
+	got := orphansReport("p", projectResources{})
+	for _, want := range []string{"Existing clusters: none\n", "No orphaned load balancers"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("orphansReport() missing %q, got:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package plugin registers the tools of an organization, described in the
+// config file and run as subprocesses, alongside the built-in tools.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Install registers the plugins of the config with the MCP server. It fails if
+// a plugin has the name of a built-in tool.
+func Install(ctx context.Context, s *mcp.Server, c *config.Config) error {
+	if len(c.Plugins()) == 0 {
+		return nil
+	}
+	builtins, err := toolNames(ctx, s)
+	if err != nil {
+		return err
+	}
+	for _, p := range c.Plugins() {
+		if builtins[p.Name] {
+			return fmt.Errorf("plugin %s has the name of a built-in tool", p.Name)
+		}
+		tool := &mcp.Tool{
+			Name:        p.Name,
+			Description: p.Description,
+			Annotations: &mcp.ToolAnnotations{ReadOnlyHint: p.ReadOnly},
+		}
+		if p.InputSchema != nil {
+			tool.InputSchema = p.InputSchema
+		}
+		mcp.AddTool(s, tool, handler(c, p))
+	}
+	return nil
+}
+
+// toolNames returns the names of the tools registered with the server.
+func toolNames(ctx context.Context, s *mcp.Server) (map[string]bool, error) {
+	// The server doesn't expose its tools, list them through an in-memory session.
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	ss, err := s.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to server: %w", err)
+	}
+	defer func() { _ = ss.Close() }()
+	cs, err := mcp.NewClient(&mcp.Implementation{Name: "gke-mcp-plugin"}, nil).Connect(ctx, clientTransport, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to server: %w", err)
+	}
+	defer func() { _ = cs.Close() }()
+
+	names := make(map[string]bool)
+	for tool, err := range cs.Tools(ctx, nil) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tools: %w", err)
+		}
+		names[tool.Name] = true
+	}
+	return names, nil
+}
+
+// handler runs the command of the plugin with the arguments of the call on
+// its standard input, and the defaults of the session in the
+// GKE_MCP_DEFAULT_PROJECT, GKE_MCP_DEFAULT_LOCATION and
+// GKE_MCP_DEFAULT_CLUSTER environment variables.
+func handler(c *config.Config, p config.Plugin) mcp.ToolHandlerFor[map[string]any, any] {
+	return func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		if args == nil {
+			args = map[string]any{}
+		}
+		input, err := json.Marshal(args)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to encode plugin arguments: %w", err)
+		}
+
+		// #nosec G204
+		cmd := exec.CommandContext(ctx, p.Command[0], p.Command[1:]...)
+		cmd.Stdin = bytes.NewReader(input)
+		cmd.Env = append(os.Environ(),
+			"GKE_MCP_DEFAULT_PROJECT="+c.DefaultProjectID(ctx),
+			"GKE_MCP_DEFAULT_LOCATION="+c.DefaultLocation(ctx),
+			"GKE_MCP_DEFAULT_CLUSTER="+c.DefaultCluster(ctx),
+		)
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout, cmd.Stderr = &stdout, &stderr
+		if err := cmd.Run(); err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) && stderr.Len() > 0 {
+				return nil, nil, fmt.Errorf("plugin %s failed: %w: %s", p.Name, err, strings.TrimSpace(stderr.String()))
+			}
+			return nil, nil, fmt.Errorf("plugin %s failed: %w", p.Name, err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: stdout.String()},
+			},
+		}, nil, nil
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type builtinArgs struct{}
+
+func connect(t *testing.T, s *mcp.Server) *mcp.ClientSession {
+	t.Helper()
+	ctx := context.Background()
+	ct, st := mcp.NewInMemoryTransports()
+	ss, err := s.Connect(ctx, st, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = ss.Close() })
+	cs, err := mcp.NewClient(&mcp.Implementation{Name: "client"}, nil).Connect(ctx, ct, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = cs.Close() })
+	return cs
+}
+
+func TestInstall(t *testing.T) {
+	t.Setenv("GOOGLE_CLOUD_PROJECT", "my-project")
+	ctx := context.Background()
+	c := config.New("test", config.WithPlugins(config.Plugins{
+		{
+			Name:        "echo_args",
+			Description: "Echo the arguments and the default project.",
+			Command:     []string{"sh", "-c", `cat; echo " $GKE_MCP_DEFAULT_PROJECT"`},
+			InputSchema: map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"team": map[string]any{"type": "string"}},
+				"required":   []any{"team"},
+			},
+			ReadOnly: true,
+		},
+		{
+			Name:        "fail",
+			Description: "Fail.",
+			Command:     []string{"sh", "-c", "echo denied by policy >&2; exit 3"},
+		},
+	}))
+	s := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	if err := Install(ctx, s, c); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	cs := connect(t, s)
+
+	result, err := cs.CallTool(ctx, &mcp.CallToolParams{Name: "echo_args", Arguments: map[string]any{"team": "payments"}})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if got, want := result.Content[0].(*mcp.TextContent).Text, "{\"team\":\"payments\"} my-project\n"; result.IsError || got != want {
+		t.Errorf("CallTool() = %q, want %q", got, want)
+	}
+
+	// The arguments are validated against the input schema.
+	if result, err := cs.CallTool(ctx, &mcp.CallToolParams{Name: "echo_args", Arguments: map[string]any{}}); err == nil && !result.IsError {
+		t.Error("CallTool() without a required argument succeeded")
+	}
+
+	result, err = cs.CallTool(ctx, &mcp.CallToolParams{Name: "fail"})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if got := result.Content[0].(*mcp.TextContent).Text; !result.IsError || !strings.Contains(got, "denied by policy") {
+		t.Errorf("CallTool() = %q, IsError %t, want an error with the standard error of the plugin", got, result.IsError)
+	}
+}
+
+func TestInstallBuiltinName(t *testing.T) {
+	s := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	mcp.AddTool(s, &mcp.Tool{Name: "list_clusters"}, func(context.Context, *mcp.CallToolRequest, *builtinArgs) (*mcp.CallToolResult, any, error) {
+		return &mcp.CallToolResult{}, nil, nil
+	})
+	c := config.New("test", config.WithPlugins(config.Plugins{
+		{Name: "list_clusters", Description: "Shadow a built-in tool.", Command: []string{"true"}},
+	}))
+	if err := Install(context.Background(), s, c); err == nil {
+		t.Error("Install() of a plugin with the name of a built-in tool succeeded")
+	}
+}
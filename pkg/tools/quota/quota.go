@@ -0,0 +1,227 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package quota provides MCP tools for checking Compute Engine quota headroom.
+package quota
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	container "cloud.google.com/go/container/apiv1"
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	compute "google.golang.org/api/compute/v1"
+)
+
+// localSSDSizeGB is the size of a single local SSD disk.
+const localSSDSizeGB = 375
+
+// sharedCPUQuotaFamilies are machine families whose vCPUs count against the generic CPUS quota.
+var sharedCPUQuotaFamilies = map[string]bool{
+	"n1": true,
+	"e2": true,
+	"f1": true,
+	"g1": true,
+}
+
+type handlers struct {
+	c        *config.Config
+	cmClient *container.ClusterManagerClient
+}
+
+type checkQuotaHeadroomArgs struct {
+	ProjectID string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location  string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name      string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+}
+
+// Install registers quota tools with the MCP server.
+func Install(ctx context.Context, s *mcp.Server, c *config.Config) error {
+	cmClient, err := container.NewClusterManagerClient(ctx, c.ClientOptions()...)
+	if err != nil {
+		return fmt.Errorf("failed to create cluster manager client: %w", err)
+	}
+
+	h := &handlers{
+		c:        c,
+		cmClient: cmClient,
+	}
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "check_quota_headroom",
+		Description: "Check Compute Engine regional quotas (CPUs, instances, in-use IP addresses, persistent and local SSD) against what a surge or blue-green upgrade of the GKE cluster's node pools would temporarily require, and report which quotas would be exceeded.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.checkQuotaHeadroom)
+
+	return nil
+}
+
+func (h *handlers) checkQuotaHeadroom(ctx context.Context, _ *mcp.CallToolRequest, args *checkQuotaHeadroomArgs) (*mcp.CallToolResult, any, error) {
+	if args.ProjectID == "" {
+		args.ProjectID = h.c.DefaultProjectID(ctx)
+	}
+	cluster, err := gke.GetCluster(ctx, h.cmClient, h.c, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	svc, err := compute.NewService(ctx, h.c.RESTClientOptions(ctx)...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create compute client: %w", err)
+	}
+
+	poolSizes := map[string]int64{}
+	poolCPUs := map[string]int64{}
+	for _, pool := range cluster.GetNodePools() {
+		size, err := gke.NodePoolSize(ctx, svc, pool)
+		if err != nil {
+			return nil, nil, err
+		}
+		poolSizes[pool.GetName()] = size
+
+		zones := pool.GetLocations()
+		if len(zones) == 0 {
+			zones = cluster.GetLocations()
+		}
+		if len(zones) == 0 {
+			continue
+		}
+		machineType, err := svc.MachineTypes.Get(args.ProjectID, zones[0], pool.GetConfig().GetMachineType()).Context(ctx).Do()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get machine type %s: %w", pool.GetConfig().GetMachineType(), err)
+		}
+		poolCPUs[pool.GetName()] = machineType.GuestCpus
+	}
+
+	region := gke.Region(cluster.GetLocation())
+	r, err := svc.Regions.Get(args.ProjectID, region).Context(ctx).Do()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get region %s: %w", region, err)
+	}
+
+	needs := surgeQuotaNeeds(cluster, poolSizes, poolCPUs)
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: quotaReport(cluster.GetName(), region, needs, r.Quotas)},
+		},
+	}, nil, nil
+}
+
+// cpuQuotaMetric returns the regional quota metric the vCPUs of a machine type count against.
+func cpuQuotaMetric(machineType string) string {
+	family, _, _ := strings.Cut(machineType, "-")
+	if family == "" || family == "custom" || sharedCPUQuotaFamilies[family] {
+		return "CPUS"
+	}
+	return strings.ToUpper(family) + "_CPUS"
+}
+
+// diskQuotaMetric returns the regional quota metric a node boot disk counts against.
+func diskQuotaMetric(diskType string) string {
+	if diskType == "" || diskType == "pd-standard" {
+		return "DISKS_TOTAL_GB"
+	}
+	return "SSD_TOTAL_GB"
+}
+
+func privateNodes(cluster *containerpb.Cluster, pool *containerpb.NodePool) bool {
+	if networkConfig := pool.GetNetworkConfig(); networkConfig != nil && networkConfig.EnablePrivateNodes != nil {
+		return networkConfig.GetEnablePrivateNodes()
+	}
+	return cluster.GetPrivateClusterConfig().GetEnablePrivateNodes() || cluster.GetNetworkConfig().GetDefaultEnablePrivateNodes()
+}
+
+// surgeQuotaNeeds returns, per quota metric, the peak amount of additional
+// quota needed while the node pools are upgraded one at a time.
+func surgeQuotaNeeds(cluster *containerpb.Cluster, poolSizes, poolCPUs map[string]int64) map[string]int64 {
+	needs := map[string]int64{}
+	for _, pool := range cluster.GetNodePools() {
+		extra := gke.SurgeNodes(pool, poolSizes[pool.GetName()])
+		if extra == 0 {
+			continue
+		}
+		nodeConfig := pool.GetConfig()
+		poolNeeds := map[string]int64{
+			"INSTANCES": extra,
+			cpuQuotaMetric(nodeConfig.GetMachineType()): extra * poolCPUs[pool.GetName()],
+			diskQuotaMetric(nodeConfig.GetDiskType()):   extra * int64(nodeConfig.GetDiskSizeGb()),
+		}
+		if localSSDs := nodeConfig.GetLocalSsdCount() + nodeConfig.GetEphemeralStorageLocalSsdConfig().GetLocalSsdCount() + nodeConfig.GetLocalNvmeSsdBlockConfig().GetLocalSsdCount(); localSSDs > 0 {
+			poolNeeds["LOCAL_SSD_TOTAL_GB"] = extra * int64(localSSDs) * localSSDSizeGB
+		}
+		if !privateNodes(cluster, pool) {
+			poolNeeds["IN_USE_ADDRESSES"] = extra
+		}
+		for metric, need := range poolNeeds {
+			needs[metric] = max(needs[metric], need)
+		}
+	}
+	return needs
+}
+
+func quotaReport(clusterName, region string, needs map[string]int64, quotas []*compute.Quota) string {
+	byMetric := map[string]*compute.Quota{}
+	for _, q := range quotas {
+		byMetric[q.Metric] = q
+	}
+
+	metrics := make([]string, 0, len(needs))
+	for metric := range needs {
+		metrics = append(metrics, metric)
+	}
+	sort.Strings(metrics)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cluster: %s\n", clusterName)
+	fmt.Fprintf(&b, "Region: %s\n", region)
+	b.WriteString("Node pools are assumed to be upgraded one at a time; the peak temporary need across node pools is used.\n\n")
+
+	if len(metrics) == 0 {
+		b.WriteString("The upgrade does not need additional quota (no surge nodes).\n")
+		return b.String()
+	}
+
+	var exceeded []string
+	b.WriteString("| Metric | Needed | Usage | Limit | Headroom | Status |\n")
+	b.WriteString("|---|---|---|---|---|---|\n")
+	for _, metric := range metrics {
+		need := needs[metric]
+		q, ok := byMetric[metric]
+		if !ok {
+			fmt.Fprintf(&b, "| %s | %d | - | - | - | quota not found in region |\n", metric, need)
+			continue
+		}
+		headroom := q.Limit - q.Usage
+		status := "OK"
+		if float64(need) > headroom {
+			status = "EXCEEDED"
+			exceeded = append(exceeded, metric)
+		}
+		fmt.Fprintf(&b, "| %s | %d | %.0f | %.0f | %.0f | %s |\n", metric, need, q.Usage, q.Limit, headroom, status)
+	}
+
+	if len(exceeded) == 0 {
+		b.WriteString("\nAll quotas have enough headroom for the upgrade.\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "\nQuotas that would be exceeded: %s. Request a quota increase or lower maxSurge before upgrading.\n", strings.Join(exceeded, ", "))
+	return b.String()
+}
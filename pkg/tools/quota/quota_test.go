@@ -0,0 +1,132 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"strings"
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+)
+
+func TestCPUQuotaMetric(t *testing.T) {
+	tests := []struct {
+		machineType string
+		want        string
+	}{
+		{machineType: "e2-standard-4", want: "CPUS"},
+		{machineType: "n1-standard-8", want: "CPUS"},
+		{machineType: "custom-4-8192", want: "CPUS"},
+		{machineType: "n2-standard-4", want: "N2_CPUS"},
+		{machineType: "n2d-highmem-2", want: "N2D_CPUS"},
+		{machineType: "c3-standard-22", want: "C3_CPUS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.machineType, func(t *testing.T) {
+			if got := cpuQuotaMetric(tt.machineType); got != tt.want {
+				t.Errorf("cpuQuotaMetric(%q) = %q, want %q", tt.machineType, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSurgeQuotaNeeds(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		PrivateClusterConfig: &containerpb.PrivateClusterConfig{EnablePrivateNodes: true},
+		NodePools: []*containerpb.NodePool{
+			{
+				Name:      "default-pool",
+				Locations: []string{"us-central1-a", "us-central1-b"},
+				Config:    &containerpb.NodeConfig{MachineType: "e2-standard-4", DiskType: "pd-balanced", DiskSizeGb: 100},
+			},
+			{
+				Name:      "highmem-pool",
+				Locations: []string{"us-central1-a"},
+				Config:    &containerpb.NodeConfig{MachineType: "n2-highmem-8", DiskType: "pd-standard", DiskSizeGb: 200, LocalSsdCount: 1},
+				UpgradeSettings: &containerpb.NodePool_UpgradeSettings{
+					Strategy: containerpb.NodePoolUpdateStrategy_BLUE_GREEN.Enum(),
+				},
+			},
+		},
+	}
+
+	got := surgeQuotaNeeds(cluster, map[string]int64{"default-pool": 6, "highmem-pool": 3}, map[string]int64{"default-pool": 4, "highmem-pool": 8})
+	want := map[string]int64{
+		"INSTANCES":          3,
+		"CPUS":               8,
+		"SSD_TOTAL_GB":       200,
+		"N2_CPUS":            24,
+		"DISKS_TOTAL_GB":     600,
+		"LOCAL_SSD_TOTAL_GB": 1125,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("surgeQuotaNeeds() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSurgeQuotaNeeds_PublicNodes(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		NodePools: []*containerpb.NodePool{
+			{
+				Name:      "default-pool",
+				Locations: []string{"us-central1-a"},
+				Config:    &containerpb.NodeConfig{MachineType: "e2-standard-4", DiskSizeGb: 100},
+			},
+		},
+	}
+
+	got := surgeQuotaNeeds(cluster, map[string]int64{"default-pool": 3}, map[string]int64{"default-pool": 4})
+	if got["IN_USE_ADDRESSES"] != 1 {
+		t.Errorf("surgeQuotaNeeds()[IN_USE_ADDRESSES] = %d, want 1", got["IN_USE_ADDRESSES"])
+	}
+}
+
+func TestQuotaReport(t *testing.T) {
+	quotas := []*compute.Quota{
+		{Metric: "CPUS", Limit: 24, Usage: 20},
+		{Metric: "INSTANCES", Limit: 100, Usage: 10},
+	}
+
+	t.Run("exceeded", func(t *testing.T) {
+		got := quotaReport("my-cluster", "us-central1", map[string]int64{"CPUS": 8, "INSTANCES": 2, "N2_CPUS": 8}, quotas)
+		for _, want := range []string{
+			"| CPUS | 8 | 20 | 24 | 4 | EXCEEDED |",
+			"| INSTANCES | 2 | 10 | 100 | 90 | OK |",
+			"| N2_CPUS | 8 | - | - | - | quota not found in region |",
+			"Quotas that would be exceeded: CPUS.",
+		} {
+			if !strings.Contains(got, want) {
+				t.Errorf("quotaReport() = %q, want to contain %q", got, want)
+			}
+		}
+	})
+
+	t.Run("enough headroom", func(t *testing.T) {
+		got := quotaReport("my-cluster", "us-central1", map[string]int64{"CPUS": 4}, quotas)
+		if !strings.Contains(got, "All quotas have enough headroom for the upgrade.") {
+			t.Errorf("quotaReport() = %q, want enough headroom message", got)
+		}
+	})
+
+	t.Run("no surge nodes", func(t *testing.T) {
+		got := quotaReport("my-cluster", "us-central1", map[string]int64{}, quotas)
+		if !strings.Contains(got, "does not need additional quota") {
+			t.Errorf("quotaReport() = %q, want no additional quota message", got)
+		}
+	})
+}
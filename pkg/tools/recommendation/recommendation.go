@@ -18,7 +18,7 @@ package recommendation
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"strings"
 
 	recommender "cloud.google.com/go/recommender/apiv1"
@@ -26,7 +26,6 @@ import (
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"google.golang.org/api/iterator"
-	"google.golang.org/api/option"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
@@ -59,7 +58,7 @@ func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
 
 func (h *handlers) listProjectRecommendations(ctx context.Context, _ *mcp.CallToolRequest, args *listRecommendationsArgs) (*mcp.CallToolResult, any, error) {
 	if args.ProjectID == "" {
-		args.ProjectID = h.c.DefaultProjectID()
+		args.ProjectID = h.c.DefaultProjectID(ctx)
 	}
 	if args.ProjectID == "" {
 		return nil, nil, fmt.Errorf("project_id argument cannot be empty")
@@ -67,13 +66,13 @@ func (h *handlers) listProjectRecommendations(ctx context.Context, _ *mcp.CallTo
 	if args.Location == "" {
 		return nil, nil, fmt.Errorf("location argument not set")
 	}
-	c, err := recommender.NewClient(ctx, option.WithUserAgent(h.c.UserAgent()))
+	c, err := recommender.NewClient(ctx, h.c.ClientOptions()...)
 	if err != nil {
 		return nil, nil, err
 	}
 	defer func() {
 		if err := c.Close(); err != nil {
-			log.Printf("Failed to close recommender client: %v\n", err)
+			slog.Warn("Failed to close recommender client", "error", err)
 		}
 	}()
 
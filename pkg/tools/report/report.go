@@ -0,0 +1,274 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package report provides an MCP tool archiving the generated reports, such
+// as upgrade risk assessments, to a local directory or a Cloud Storage bucket
+// for audits. The local reports are confined to the configured reports
+// directory.
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	storage "google.golang.org/api/storage/v1"
+)
+
+// gcsScheme prefixes the destinations in Cloud Storage.
+const gcsScheme = "gs://"
+
+// extensions are the file extensions of the report formats.
+var extensions = map[string]string{
+	"markdown": ".md",
+	"json":     ".json",
+	"slack":    ".txt",
+	"html":     ".html",
+}
+
+// contentTypes are the content types of the report formats.
+var contentTypes = map[string]string{
+	"markdown": "text/markdown; charset=utf-8",
+	"json":     "application/json",
+	"slack":    "text/plain; charset=utf-8",
+	"html":     "text/html; charset=utf-8",
+}
+
+var unsafeNameChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+type handlers struct {
+	c   *config.Config
+	now func() time.Time
+	// upload writes an object to Cloud Storage.
+	upload func(ctx context.Context, bucket string, object *storage.Object, content string) error
+}
+
+type saveReportArgs struct {
+	Content     string `json:"content" jsonschema:"The full text of the generated report."`
+	Destination string `json:"destination,omitempty" jsonschema:"A Cloud Storage location as gs://bucket or gs://bucket/prefix, or a relative subdirectory of the reports directory of the server, to save the report to. Defaults to the reports directory."`
+	Name        string `json:"name" jsonschema:"A short name of the report, e.g. upgrade-risk-report, used in the file name."`
+	Format      string `json:"format,omitempty" jsonschema:"The format of the report: markdown, json, slack or html. Defaults to markdown."`
+	ProjectID   string `json:"project_id,omitempty" jsonschema:"GCP project ID of the cluster the report is about. Use the default if the user doesn't provide it."`
+	Location    string `json:"location,omitempty" jsonschema:"Location of the GKE cluster the report is about."`
+	Cluster     string `json:"cluster,omitempty" jsonschema:"Name of the GKE cluster the report is about."`
+}
+
+// metadata describes a saved report.
+type metadata struct {
+	Name      string `json:"name"`
+	Format    string `json:"format"`
+	CreatedAt string `json:"createdAt"`
+	ProjectID string `json:"projectId,omitempty"`
+	Location  string `json:"location,omitempty"`
+	Cluster   string `json:"cluster,omitempty"`
+}
+
+// Install registers the report tool with the MCP server.
+func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
+	h := &handlers{
+		c:   c,
+		now: time.Now,
+		upload: func(ctx context.Context, bucket string, object *storage.Object, content string) error {
+			svc, err := storage.NewService(ctx, c.RESTClientOptions(ctx)...)
+			if err != nil {
+				return fmt.Errorf("failed to create storage client: %w", err)
+			}
+			// Fail instead of overwriting an existing report.
+			if _, err := svc.Objects.Insert(bucket, object).IfGenerationMatch(0).Media(strings.NewReader(content)).Context(ctx).Do(); err != nil {
+				return fmt.Errorf("failed to upload gs://%s/%s: %w", bucket, object.Name, err)
+			}
+			return nil
+		},
+	}
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "save_report",
+		Description: "Save a generated report, such as an upgrade risk report, to the reports directory of the server or a Cloud Storage bucket, in a new file named after the report, the cluster and the time, along with metadata about the cluster, so that it can be archived for audits. Existing files are never overwritten.",
+		Annotations: &mcp.ToolAnnotations{
+			// Not destructive, the reports are saved to new files only.
+			DestructiveHint: new(bool),
+		},
+	}, h.saveReport)
+
+	return nil
+}
+
+func (h *handlers) saveReport(ctx context.Context, _ *mcp.CallToolRequest, args *saveReportArgs) (*mcp.CallToolResult, any, error) {
+	if strings.TrimSpace(args.Content) == "" {
+		return nil, nil, fmt.Errorf("content argument cannot be empty")
+	}
+	destination := strings.TrimSpace(args.Destination)
+	format := strings.ToLower(strings.TrimSpace(args.Format))
+	if format == "" {
+		format = "markdown"
+	}
+	if _, ok := extensions[format]; !ok {
+		return nil, nil, fmt.Errorf("unsupported report format %q, the formats are markdown, json, slack and html", args.Format)
+	}
+	if args.ProjectID == "" {
+		args.ProjectID = h.c.DefaultProjectID(ctx)
+	}
+
+	now := h.now().UTC()
+	meta := metadata{
+		Name:      strings.TrimSpace(args.Name),
+		Format:    format,
+		CreatedAt: now.Format(time.RFC3339),
+		ProjectID: args.ProjectID,
+		Location:  strings.TrimSpace(args.Location),
+		Cluster:   strings.TrimSpace(args.Cluster),
+	}
+	name := fileName(meta, now)
+
+	var saved string
+	if bucketPath, ok := strings.CutPrefix(destination, gcsScheme); ok {
+		bucket, prefix, _ := strings.Cut(bucketPath, "/")
+		if bucket == "" {
+			return nil, nil, fmt.Errorf("invalid Cloud Storage destination %q, expected gs://bucket or gs://bucket/prefix", destination)
+		}
+		object := &storage.Object{
+			Name:        path.Join(prefix, name),
+			ContentType: contentTypes[format],
+			Metadata:    objectMetadata(meta),
+		}
+		if err := h.upload(ctx, bucket, object, args.Content); err != nil {
+			return nil, nil, err
+		}
+		saved = gcsScheme + bucket + "/" + object.Name
+	} else {
+		dir, err := localDir(h.reportsDir(), destination)
+		if err != nil {
+			return nil, nil, err
+		}
+		file, err := saveFile(dir, name, args.Content, meta)
+		if err != nil {
+			return nil, nil, err
+		}
+		saved = file
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: fmt.Sprintf("Saved the report to %s.", saved)},
+		},
+	}, nil, nil
+}
+
+// reportsDir returns the directory of the local reports.
+func (h *handlers) reportsDir() string {
+	if dir := h.c.Files().ReportsDir; dir != "" {
+		return dir
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "gke-mcp", "reports")
+}
+
+// localDir returns the directory of a local destination, which must be a
+// subdirectory of the reports directory, so that callers can't write files
+// anywhere else on the host.
+func localDir(reportsDir, destination string) (string, error) {
+	if filepath.IsAbs(destination) {
+		return "", fmt.Errorf("invalid destination %q, expected gs://bucket/prefix or a relative subdirectory of the reports directory %s", destination, reportsDir)
+	}
+	dir := filepath.Join(reportsDir, destination)
+	rel, err := filepath.Rel(reportsDir, dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid destination %q, expected gs://bucket/prefix or a relative subdirectory of the reports directory %s", destination, reportsDir)
+	}
+	return dir, nil
+}
+
+// fileName returns the name of the file of a report, made of its name, its
+// cluster and the time.
+func fileName(meta metadata, now time.Time) string {
+	parts := []string{}
+	for _, part := range []string{meta.Name, meta.Cluster} {
+		if part = strings.Trim(unsafeNameChars.ReplaceAllString(strings.ToLower(part), "-"), "-."); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "report")
+	}
+	parts = append(parts, now.Format("20060102T150405Z"))
+	return strings.Join(parts, "-") + extensions[meta.Format]
+}
+
+// objectMetadata returns the metadata of a report as Cloud Storage custom
+// metadata.
+func objectMetadata(meta metadata) map[string]string {
+	m := map[string]string{
+		"report-format":     meta.Format,
+		"report-created-at": meta.CreatedAt,
+	}
+	for key, value := range map[string]string{
+		"report-name":  meta.Name,
+		"gcp-project":  meta.ProjectID,
+		"gke-location": meta.Location,
+		"gke-cluster":  meta.Cluster,
+	} {
+		if value != "" {
+			m[key] = value
+		}
+	}
+	return m
+}
+
+// saveFile writes a report and its metadata, as a JSON file next to it, to
+// a local directory, and returns the path of the report.
+func saveFile(dir, name, content string, meta metadata) (string, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return "", fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	file := filepath.Join(dir, name)
+	if err := writeNewFile(file, []byte(content)); err != nil {
+		return "", err
+	}
+	metaJSON, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal report metadata: %w", err)
+	}
+	if err := writeNewFile(file+".metadata.json", append(metaJSON, '\n')); err != nil {
+		return "", err
+	}
+	return file, nil
+}
+
+// writeNewFile writes a file, failing if it already exists.
+func writeNewFile(file string, data []byte) error {
+	// #nosec G304
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", file, err)
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	return nil
+}
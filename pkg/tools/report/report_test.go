@@ -0,0 +1,207 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/google/go-cmp/cmp"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	storage "google.golang.org/api/storage/v1"
+)
+
+var testTime = time.Date(2025, 10, 1, 12, 30, 0, 0, time.UTC)
+
+func TestFileName(t *testing.T) {
+	tests := []struct {
+		meta metadata
+		want string
+	}{
+		{
+			meta: metadata{Name: "upgrade-risk-report", Format: "markdown", Cluster: "prod-cluster"},
+			want: "upgrade-risk-report-prod-cluster-20251001T123000Z.md",
+		},
+		{
+			meta: metadata{Name: "Upgrade Risk / Report", Format: "json"},
+			want: "upgrade-risk-report-20251001T123000Z.json",
+		},
+		{
+			meta: metadata{Name: "../..", Format: "html"},
+			want: "report-20251001T123000Z.html",
+		},
+	}
+	for _, tt := range tests {
+		if got := fileName(tt.meta, testTime); got != tt.want {
+			t.Errorf("fileName(%+v) = %q, want %q", tt.meta, got, tt.want)
+		}
+	}
+}
+
+func TestSaveReport_Local(t *testing.T) {
+	reportsDir := t.TempDir()
+	dir := filepath.Join(reportsDir, "upgrades")
+	h := &handlers{c: config.New("test", config.WithFiles(config.Files{ReportsDir: reportsDir})), now: func() time.Time { return testTime }}
+
+	args := &saveReportArgs{
+		Content:     "# Risk",
+		Destination: "upgrades",
+		Name:        "upgrade-risk-report",
+		ProjectID:   "my-project",
+		Location:    "us-central1",
+		Cluster:     "prod",
+	}
+	result, _, err := h.saveReport(context.Background(), &mcp.CallToolRequest{}, args)
+	if err != nil {
+		t.Fatalf("saveReport() error = %v", err)
+	}
+	file := filepath.Join(dir, "upgrade-risk-report-prod-20251001T123000Z.md")
+	if text := result.Content[0].(*mcp.TextContent).Text; !strings.Contains(text, file) {
+		t.Errorf("saveReport() = %q, want it to mention %s", text, file)
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("failed to read the report: %v", err)
+	}
+	if string(content) != "# Risk" {
+		t.Errorf("report content = %q, want %q", content, "# Risk")
+	}
+	metaJSON, err := os.ReadFile(file + ".metadata.json")
+	if err != nil {
+		t.Fatalf("failed to read the report metadata: %v", err)
+	}
+	var got metadata
+	if err := json.Unmarshal(metaJSON, &got); err != nil {
+		t.Fatalf("failed to unmarshal the report metadata: %v", err)
+	}
+	want := metadata{
+		Name:      "upgrade-risk-report",
+		Format:    "markdown",
+		CreatedAt: "2025-10-01T12:30:00Z",
+		ProjectID: "my-project",
+		Location:  "us-central1",
+		Cluster:   "prod",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("report metadata mismatch (-want +got):\n%s", diff)
+	}
+
+	// The same report at the same time must not overwrite the first one.
+	if _, _, err := h.saveReport(context.Background(), &mcp.CallToolRequest{}, args); err == nil {
+		t.Error("saveReport() of an existing report error = nil, want an error")
+	}
+}
+
+func TestSaveReport_GCS(t *testing.T) {
+	var gotBucket string
+	var gotObject *storage.Object
+	var gotContent string
+	h := &handlers{
+		c:   &config.Config{},
+		now: func() time.Time { return testTime },
+		upload: func(_ context.Context, bucket string, object *storage.Object, content string) error {
+			gotBucket, gotObject, gotContent = bucket, object, content
+			return nil
+		},
+	}
+
+	result, _, err := h.saveReport(context.Background(), &mcp.CallToolRequest{}, &saveReportArgs{
+		Content:     `{"risks": []}`,
+		Destination: "gs://audits/gke/upgrades",
+		Name:        "upgrade-risk-report",
+		Format:      "json",
+		ProjectID:   "my-project",
+		Cluster:     "prod",
+	})
+	if err != nil {
+		t.Fatalf("saveReport() error = %v", err)
+	}
+	if gotBucket != "audits" {
+		t.Errorf("bucket = %q, want %q", gotBucket, "audits")
+	}
+	if want := "gke/upgrades/upgrade-risk-report-prod-20251001T123000Z.json"; gotObject.Name != want {
+		t.Errorf("object name = %q, want %q", gotObject.Name, want)
+	}
+	if gotObject.ContentType != "application/json" {
+		t.Errorf("content type = %q, want %q", gotObject.ContentType, "application/json")
+	}
+	wantMetadata := map[string]string{
+		"report-name":       "upgrade-risk-report",
+		"report-format":     "json",
+		"report-created-at": "2025-10-01T12:30:00Z",
+		"gcp-project":       "my-project",
+		"gke-cluster":       "prod",
+	}
+	if diff := cmp.Diff(wantMetadata, gotObject.Metadata); diff != "" {
+		t.Errorf("object metadata mismatch (-want +got):\n%s", diff)
+	}
+	if gotContent != `{"risks": []}` {
+		t.Errorf("content = %q, want the report", gotContent)
+	}
+	if text := result.Content[0].(*mcp.TextContent).Text; !strings.Contains(text, "gs://audits/gke/upgrades/upgrade-risk-report-prod-20251001T123000Z.json") {
+		t.Errorf("saveReport() = %q, want it to mention the object", text)
+	}
+}
+
+func TestSaveReport_InvalidArgs(t *testing.T) {
+	h := &handlers{c: config.New("test", config.WithFiles(config.Files{ReportsDir: t.TempDir()})), now: func() time.Time { return testTime }}
+	tests := map[string]*saveReportArgs{
+		"empty content":        {Content: ""},
+		"unknown format":       {Content: "# Risk", Format: "pdf"},
+		"no bucket":            {Content: "# Risk", Destination: "gs:///prefix"},
+		"absolute destination": {Content: "# Risk", Destination: t.TempDir()},
+		"parent destination":   {Content: "# Risk", Destination: "../elsewhere"},
+	}
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := h.saveReport(context.Background(), &mcp.CallToolRequest{}, args); err == nil {
+				t.Error("saveReport() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestLocalDir(t *testing.T) {
+	reportsDir := filepath.Join(string(filepath.Separator), "var", "reports")
+	tests := []struct {
+		destination string
+		want        string
+		wantErr     bool
+	}{
+		{destination: "", want: reportsDir},
+		{destination: "upgrades", want: filepath.Join(reportsDir, "upgrades")},
+		{destination: "upgrades/../prod", want: filepath.Join(reportsDir, "prod")},
+		{destination: "..", wantErr: true},
+		{destination: "upgrades/../../etc", wantErr: true},
+		{destination: filepath.Join(string(filepath.Separator), "etc"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.destination, func(t *testing.T) {
+			got, err := localDir(reportsDir, tt.destination)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("localDir() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("localDir() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sessiondefaults provides an MCP tool selecting the project, location
+// and cluster the tools of an MCP session fall back to.
+package sessiondefaults
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/session"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type handlers struct {
+	c *config.Config
+}
+
+type setSessionDefaultsArgs struct {
+	ProjectID string `json:"project_id,omitempty" jsonschema:"GCP project ID the tools of this session use when the project is not given. Leave this empty to keep the current default."`
+	Location  string `json:"location,omitempty" jsonschema:"GKE cluster location the tools of this session use when the location is not given. Leave this empty to keep the current default."`
+	Cluster   string `json:"cluster,omitempty" jsonschema:"GKE cluster name the tools of this session use when the cluster is not given. Leave this empty to keep the current default."`
+	Reset     bool   `json:"reset,omitempty" jsonschema:"Go back to the defaults of the server before applying the other arguments."`
+}
+
+// Install registers the session defaults tool with the MCP server.
+func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
+	h := &handlers{c: c}
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "set_session_defaults",
+		Description: "Set the GCP project, location and GKE cluster the tools use for the rest of this session when they are not given, without affecting other sessions of the server. Call it without arguments to show the current defaults.",
+		Annotations: &mcp.ToolAnnotations{
+			// Read-only, the defaults only live in the server for the session.
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+	}, h.setSessionDefaults)
+
+	return nil
+}
+
+func (h *handlers) setSessionDefaults(ctx context.Context, req *mcp.CallToolRequest, args *setSessionDefaultsArgs) (*mcp.CallToolResult, any, error) {
+	var st session.State
+	session.Update(req, func(s *session.State) {
+		if args.Reset {
+			s.ProjectID, s.Location, s.Cluster = "", "", ""
+		}
+		if p := strings.TrimSpace(args.ProjectID); p != "" {
+			s.ProjectID = p
+		}
+		if l := strings.TrimSpace(args.Location); l != "" {
+			s.Location = l
+		}
+		if n := strings.TrimSpace(args.Cluster); n != "" {
+			s.Cluster = n
+		}
+		st = *s
+	})
+
+	// The context of the call holds the previous defaults of the session.
+	ctx = session.WithState(ctx, st)
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: defaultsReport(h.c.DefaultProjectID(ctx), h.c.DefaultLocation(ctx), h.c.DefaultCluster(ctx))},
+		},
+	}, nil, nil
+}
+
+func defaultsReport(projectID, location, cluster string) string {
+	var b strings.Builder
+	b.WriteString("Defaults of this session:\n")
+	for _, d := range []struct{ name, value string }{
+		{"Project", projectID},
+		{"Location", location},
+		{"Cluster", cluster},
+	} {
+		if d.value == "" {
+			d.value = "(not set)"
+		}
+		fmt.Fprintf(&b, "- %s: %s\n", d.name, d.value)
+	}
+	return b.String()
+}
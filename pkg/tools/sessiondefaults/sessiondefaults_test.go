@@ -0,0 +1,60 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sessiondefaults
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/session"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestSetSessionDefaults(t *testing.T) {
+	h := &handlers{c: &config.Config{}}
+	req := &mcp.CallToolRequest{}
+	t.Cleanup(func() { session.Update(req, func(st *session.State) { *st = session.State{} }) })
+
+	result, _, err := h.setSessionDefaults(context.Background(), req, &setSessionDefaultsArgs{ProjectID: "my-project", Cluster: "my-cluster"})
+	if err != nil {
+		t.Fatalf("setSessionDefaults() error = %v", err)
+	}
+	got := result.Content[0].(*mcp.TextContent).Text
+	for _, want := range []string{"- Project: my-project\n", "- Location: (not set)\n", "- Cluster: my-cluster\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("setSessionDefaults() = %q, want it to contain %q", got, want)
+		}
+	}
+
+	if _, _, err := h.setSessionDefaults(context.Background(), req, &setSessionDefaultsArgs{Location: "us-central1"}); err != nil {
+		t.Fatalf("setSessionDefaults() error = %v", err)
+	}
+	if got, want := session.Get(req), (session.State{ProjectID: "my-project", Location: "us-central1", Cluster: "my-cluster"}); got != want {
+		t.Errorf("session state = %+v, want %+v", got, want)
+	}
+
+	result, _, err = h.setSessionDefaults(context.Background(), req, &setSessionDefaultsArgs{Reset: true})
+	if err != nil {
+		t.Fatalf("setSessionDefaults() error = %v", err)
+	}
+	if got := result.Content[0].(*mcp.TextContent).Text; strings.Contains(got, "my-project") {
+		t.Errorf("setSessionDefaults() = %q, want the defaults reset", got)
+	}
+	if got := session.Get(req); got != (session.State{}) {
+		t.Errorf("session state = %+v, want it reset", got)
+	}
+}
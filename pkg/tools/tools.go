@@ -19,15 +19,28 @@ import (
 	"context"
 
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/billing"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/cluster"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/clustertoolkit"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/deploy"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/gcloud"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/giq"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/gkereleasenotes"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/k8schangelog"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/kubecontext"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/kubectl"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/logging"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/monitoring"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/networking"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/nodepools"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/orphans"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/plugin"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/quota"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/recommendation"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/report"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/sessiondefaults"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/upgrade"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/workloads"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -36,15 +49,29 @@ type installer func(ctx context.Context, s *mcp.Server, c *config.Config) error
 // Install registers all tools with the MCP server.
 func Install(ctx context.Context, s *mcp.Server, c *config.Config) error {
 	installers := []installer{
+		billing.Install,
 		cluster.Install,
 		clustertoolkit.Install,
 		deploy.Install,
+		gcloud.Install,
 		giq.Install,
+		kubecontext.Install,
+		kubectl.Install,
 		logging.Install,
 		monitoring.Install,
+		networking.Install,
+		nodepools.Install,
+		orphans.Install,
+		quota.Install,
 		recommendation.Install,
+		report.Install,
+		sessiondefaults.Install,
+		upgrade.Install,
+		workloads.Install,
 		k8schangelog.Install,
 		gkereleasenotes.Install,
+		// Plugins are installed last, so that they can't replace built-in tools.
+		plugin.Install,
 	}
 
 	for _, installer := range installers {
@@ -0,0 +1,227 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// defaultControlPlaneUpgradeDuration is the assumed control plane upgrade duration when there is no history.
+	defaultControlPlaneUpgradeDuration = 30 * time.Minute
+	// pdbDrainTimeout is how long GKE respects PodDisruptionBudgets when draining a node during an upgrade.
+	pdbDrainTimeout = time.Hour
+)
+
+type estimateUpgradeDurationArgs struct {
+	ProjectID string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location  string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name      string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+}
+
+// upgradeHistory holds durations derived from previous upgrade operations of a cluster.
+type upgradeHistory struct {
+	controlPlane     time.Duration
+	controlPlaneOps  int
+	nodeBatch        time.Duration
+	nodePoolUpgrades int
+}
+
+func (h *handlers) estimateUpgradeDuration(ctx context.Context, _ *mcp.CallToolRequest, args *estimateUpgradeDurationArgs) (*mcp.CallToolResult, any, error) {
+	if args.ProjectID == "" {
+		args.ProjectID = h.c.DefaultProjectID(ctx)
+	}
+	cluster, err := gke.GetCluster(ctx, h.cmClient, h.c, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	sizes, err := h.nodePoolSizes(ctx, cluster)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := h.cmClient.ListOperations(ctx, &containerpb.ListOperationsRequest{
+		Parent: fmt.Sprintf("projects/%s/locations/%s", args.ProjectID, cluster.GetLocation()),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list operations: %w", err)
+	}
+	history := upgradeHistoryFromOperations(cluster, sizes, resp.GetOperations())
+
+	var pdbs kube.List[kube.PodDisruptionBudget]
+	pdbErr := kube.Get(ctx, &pdbs, "poddisruptionbudgets", "--all-namespaces")
+
+	var b strings.Builder
+	b.WriteString(upgradeDurationReport(cluster, sizes, history, blockingPDBs(pdbs.Items)))
+	if pdbErr != nil {
+		fmt.Fprintf(&b, "\nPodDisruptionBudgets were not analyzed: %v\n", pdbErr)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: b.String()},
+		},
+	}, nil, nil
+}
+
+// operationTarget returns the node pool name an operation targets, or an empty
+// string for the cluster itself. ok is false if the operation targets another cluster.
+func operationTarget(op *containerpb.Operation, clusterName string) (nodePool string, ok bool) {
+	_, target, found := strings.Cut(op.GetTargetLink(), "/clusters/")
+	if !found {
+		return "", false
+	}
+	parts := strings.Split(target, "/")
+	if parts[0] != clusterName {
+		return "", false
+	}
+	if len(parts) >= 3 && parts[1] == "nodePools" {
+		return parts[2], true
+	}
+	return "", true
+}
+
+func operationDuration(op *containerpb.Operation) (time.Duration, bool) {
+	start, err := time.Parse(time.RFC3339Nano, op.GetStartTime())
+	if err != nil {
+		return 0, false
+	}
+	end, err := time.Parse(time.RFC3339Nano, op.GetEndTime())
+	if err != nil || end.Before(start) {
+		return 0, false
+	}
+	return end.Sub(start), true
+}
+
+// upgradeHistoryFromOperations averages the durations of completed upgrade
+// operations of the cluster. Node pool upgrade durations are divided by the
+// number of surge batches the node pool needs with its current size and
+// settings, blue-green upgrades are skipped since their duration is dominated
+// by soak times.
+func upgradeHistoryFromOperations(cluster *containerpb.Cluster, sizes map[string]int64, ops []*containerpb.Operation) upgradeHistory {
+	pools := map[string]*containerpb.NodePool{}
+	for _, pool := range cluster.GetNodePools() {
+		pools[pool.GetName()] = pool
+	}
+
+	var history upgradeHistory
+	var controlPlaneTotal, nodeBatchTotal time.Duration
+	for _, op := range ops {
+		if op.GetStatus() != containerpb.Operation_DONE {
+			continue
+		}
+		target, ok := operationTarget(op, cluster.GetName())
+		if !ok {
+			continue
+		}
+		duration, ok := operationDuration(op)
+		if !ok {
+			continue
+		}
+
+		switch op.GetOperationType() {
+		case containerpb.Operation_UPGRADE_MASTER:
+			controlPlaneTotal += duration
+			history.controlPlaneOps++
+		case containerpb.Operation_UPGRADE_NODES, containerpb.Operation_AUTO_UPGRADE_NODES:
+			pool, ok := pools[target]
+			if !ok || pool.GetUpgradeSettings().GetStrategy() == containerpb.NodePoolUpdateStrategy_BLUE_GREEN {
+				continue
+			}
+			batches := surgeBatches(pool, sizes[target])
+			if batches == 0 {
+				continue
+			}
+			nodeBatchTotal += duration / time.Duration(batches)
+			history.nodePoolUpgrades++
+		}
+	}
+
+	if history.controlPlaneOps > 0 {
+		history.controlPlane = controlPlaneTotal / time.Duration(history.controlPlaneOps)
+	}
+	if history.nodePoolUpgrades > 0 {
+		history.nodeBatch = nodeBatchTotal / time.Duration(history.nodePoolUpgrades)
+	}
+	return history
+}
+
+// blockingPDBs returns the namespaced names of PodDisruptionBudgets which
+// currently allow no disruptions.
+func blockingPDBs(pdbs []kube.PodDisruptionBudget) []string {
+	var blocking []string
+	for _, pdb := range pdbs {
+		if pdb.Status.DisruptionsAllowed == 0 {
+			blocking = append(blocking, pdb.Namespace+"/"+pdb.Name)
+		}
+	}
+	return blocking
+}
+
+func upgradeDurationReport(cluster *containerpb.Cluster, sizes map[string]int64, history upgradeHistory, blocking []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cluster: %s\n\n", cluster.GetName())
+
+	controlPlane := defaultControlPlaneUpgradeDuration
+	if history.controlPlaneOps > 0 {
+		controlPlane = history.controlPlane.Round(time.Minute)
+		fmt.Fprintf(&b, "Control plane upgrade: %s (average of %d previous control plane upgrades)\n", controlPlane, history.controlPlaneOps)
+	} else {
+		fmt.Fprintf(&b, "Control plane upgrade: %s (assumed, no previous control plane upgrades found)\n", controlPlane)
+	}
+
+	batchDuration := defaultNodeUpgradeMinutes * time.Minute
+	if history.nodePoolUpgrades > 0 && history.nodeBatch >= time.Minute {
+		batchDuration = history.nodeBatch.Round(time.Minute)
+		fmt.Fprintf(&b, "Node upgrade batch: %s (derived from %d previous node pool upgrades)\n\n", batchDuration, history.nodePoolUpgrades)
+	} else {
+		fmt.Fprintf(&b, "Node upgrade batch: %s (assumed, no previous surge node pool upgrades found)\n\n", batchDuration)
+	}
+
+	b.WriteString("| Node pool | Nodes | Strategy | Estimated duration |\n")
+	b.WriteString("|---|---|---|---|\n")
+	var nodes time.Duration
+	for _, pool := range cluster.GetNodePools() {
+		e := estimatePoolUpgrade(pool, sizes[pool.GetName()], batchDuration)
+		fmt.Fprintf(&b, "| %s | %d | %s | %s |\n", e.name, e.size, e.strategy, e.duration)
+		nodes += e.duration
+	}
+	fmt.Fprintf(&b, "\nEstimated node upgrade duration (node pools upgraded one at a time): %s\n", nodes)
+
+	total := controlPlane + nodes
+	worstCase := total
+	if len(blocking) > 0 {
+		fmt.Fprintf(&b, "\nPodDisruptionBudgets currently allowing no disruptions: %s\n", strings.Join(blocking, ", "))
+		fmt.Fprintf(&b, "Each of them can delay draining a node by up to %s before GKE evicts the pods anyway.\n", pdbDrainTimeout)
+		worstCase += time.Duration(len(blocking)) * pdbDrainTimeout
+	}
+
+	fmt.Fprintf(&b, "\nEstimated total upgrade duration: %s", total)
+	if worstCase > total {
+		fmt.Fprintf(&b, " (up to %s if PodDisruptionBudgets block node drains)", worstCase)
+	}
+	b.WriteString("\n")
+	window := time.Duration(ceilDiv(int64(worstCase), int64(time.Hour))) * time.Hour
+	fmt.Fprintf(&b, "Suggested maintenance window: at least %s\n", window)
+	return b.String()
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrade
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestOperationTarget(t *testing.T) {
+	tests := []struct {
+		name         string
+		targetLink   string
+		wantNodePool string
+		wantOK       bool
+	}{
+		{
+			name:       "cluster",
+			targetLink: "https://container.googleapis.com/v1/projects/123/zones/us-central1-a/clusters/my-cluster",
+			wantOK:     true,
+		},
+		{
+			name:         "node pool",
+			targetLink:   "https://container.googleapis.com/v1/projects/123/locations/us-central1/clusters/my-cluster/nodePools/default-pool",
+			wantNodePool: "default-pool",
+			wantOK:       true,
+		},
+		{
+			name:       "other cluster",
+			targetLink: "https://container.googleapis.com/v1/projects/123/locations/us-central1/clusters/other/nodePools/default-pool",
+		},
+		{
+			name:       "no target",
+			targetLink: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodePool, ok := operationTarget(&containerpb.Operation{TargetLink: tt.targetLink}, "my-cluster")
+			if nodePool != tt.wantNodePool || ok != tt.wantOK {
+				t.Errorf("operationTarget() = (%q, %v), want (%q, %v)", nodePool, ok, tt.wantNodePool, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestUpgradeHistoryFromOperations(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		Name: "my-cluster",
+		NodePools: []*containerpb.NodePool{
+			{Name: "default-pool"},
+			{
+				Name: "blue-green-pool",
+				UpgradeSettings: &containerpb.NodePool_UpgradeSettings{
+					Strategy: containerpb.NodePoolUpdateStrategy_BLUE_GREEN.Enum(),
+				},
+			},
+		},
+	}
+	sizes := map[string]int64{"default-pool": 4, "blue-green-pool": 3}
+	clusterLink := "https://container.googleapis.com/v1/projects/123/locations/us-central1/clusters/my-cluster"
+	ops := []*containerpb.Operation{
+		{
+			OperationType: containerpb.Operation_UPGRADE_MASTER,
+			Status:        containerpb.Operation_DONE,
+			TargetLink:    clusterLink,
+			StartTime:     "2025-10-01T10:00:00.123456789Z",
+			EndTime:       "2025-10-01T10:20:00.123456789Z",
+		},
+		{
+			OperationType: containerpb.Operation_UPGRADE_MASTER,
+			Status:        containerpb.Operation_DONE,
+			TargetLink:    clusterLink,
+			StartTime:     "2025-11-01T10:00:00Z",
+			EndTime:       "2025-11-01T10:40:00Z",
+		},
+		{
+			// 4 nodes with the default surge settings are upgraded in 4 batches.
+			OperationType: containerpb.Operation_AUTO_UPGRADE_NODES,
+			Status:        containerpb.Operation_DONE,
+			TargetLink:    clusterLink + "/nodePools/default-pool",
+			StartTime:     "2025-10-01T11:00:00Z",
+			EndTime:       "2025-10-01T11:48:00Z",
+		},
+		{
+			OperationType: containerpb.Operation_UPGRADE_NODES,
+			Status:        containerpb.Operation_DONE,
+			TargetLink:    clusterLink + "/nodePools/blue-green-pool",
+			StartTime:     "2025-10-01T12:00:00Z",
+			EndTime:       "2025-10-01T15:00:00Z",
+		},
+		{
+			OperationType: containerpb.Operation_UPGRADE_NODES,
+			Status:        containerpb.Operation_RUNNING,
+			TargetLink:    clusterLink + "/nodePools/default-pool",
+			StartTime:     "2025-11-01T11:00:00Z",
+		},
+		{
+			OperationType: containerpb.Operation_UPGRADE_MASTER,
+			Status:        containerpb.Operation_DONE,
+			TargetLink:    "https://container.googleapis.com/v1/projects/123/locations/us-central1/clusters/other",
+			StartTime:     "2025-10-01T10:00:00Z",
+			EndTime:       "2025-10-01T13:00:00Z",
+		},
+	}
+
+	got := upgradeHistoryFromOperations(cluster, sizes, ops)
+	want := upgradeHistory{
+		controlPlane:     30 * time.Minute,
+		controlPlaneOps:  2,
+		nodeBatch:        12 * time.Minute,
+		nodePoolUpgrades: 1,
+	}
+	if got != want {
+		t.Errorf("upgradeHistoryFromOperations() = %+v, want %+v", got, want)
+	}
+}
+
+func TestBlockingPDBs(t *testing.T) {
+	pdbs := []kube.PodDisruptionBudget{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+			Status:     kube.PodDisruptionBudgetStatus{DisruptionsAllowed: 1},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "db", Name: "postgres"},
+			Status:     kube.PodDisruptionBudgetStatus{DisruptionsAllowed: 0},
+		},
+	}
+	got := blockingPDBs(pdbs)
+	if len(got) != 1 || got[0] != "db/postgres" {
+		t.Errorf("blockingPDBs() = %v, want [db/postgres]", got)
+	}
+}
+
+func TestUpgradeDurationReport(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		Name:      "my-cluster",
+		NodePools: []*containerpb.NodePool{{Name: "default-pool"}},
+	}
+	sizes := map[string]int64{"default-pool": 3}
+
+	t.Run("no history", func(t *testing.T) {
+		report := upgradeDurationReport(cluster, sizes, upgradeHistory{}, nil)
+		for _, want := range []string{
+			"Control plane upgrade: 30m0s (assumed",
+			"Node upgrade batch: 10m0s (assumed",
+			"| default-pool | 3 | surge (maxSurge=1, maxUnavailable=0) | 30m0s |",
+			"Estimated total upgrade duration: 1h0m0s\n",
+			"Suggested maintenance window: at least 1h0m0s",
+		} {
+			if !strings.Contains(report, want) {
+				t.Errorf("upgradeDurationReport() missing %q in:\n%s", want, report)
+			}
+		}
+	})
+
+	t.Run("history and blocking PDBs", func(t *testing.T) {
+		history := upgradeHistory{controlPlane: 20 * time.Minute, controlPlaneOps: 1, nodeBatch: 15 * time.Minute, nodePoolUpgrades: 2}
+		report := upgradeDurationReport(cluster, sizes, history, []string{"db/postgres"})
+		for _, want := range []string{
+			"Control plane upgrade: 20m0s (average of 1 previous control plane upgrades)",
+			"Node upgrade batch: 15m0s (derived from 2 previous node pool upgrades)",
+			"PodDisruptionBudgets currently allowing no disruptions: db/postgres",
+			"Estimated total upgrade duration: 1h5m0s (up to 2h5m0s if PodDisruptionBudgets block node drains)",
+			"Suggested maintenance window: at least 3h0m0s",
+		} {
+			if !strings.Contains(report, want) {
+				t.Errorf("upgradeDurationReport() missing %q in:\n%s", want, report)
+			}
+		}
+	})
+}
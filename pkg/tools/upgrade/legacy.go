@@ -0,0 +1,169 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// disableLegacyEndpointsKey is the node metadata key turning off the v0.1 and
+// v1beta1 endpoints of the Compute Engine metadata server.
+const disableLegacyEndpointsKey = "disable-legacy-endpoints"
+
+type detectLegacyFeaturesArgs struct {
+	ProjectID string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location  string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name      string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+}
+
+// legacyFeature is a legacy GKE feature still enabled on a cluster.
+type legacyFeature struct {
+	name        string
+	scope       string
+	security    string
+	lifecycle   string
+	remediation []string
+}
+
+func (h *handlers) detectLegacyFeatures(ctx context.Context, _ *mcp.CallToolRequest, args *detectLegacyFeaturesArgs) (*mcp.CallToolResult, any, error) {
+	if args.ProjectID == "" {
+		args.ProjectID = h.c.DefaultProjectID(ctx)
+	}
+	cluster, err := gke.GetCluster(ctx, h.cmClient, h.c, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: legacyFeaturesReport(cluster)},
+		},
+	}, nil, nil
+}
+
+// basicAuthEnabled reports whether the cluster still has a basic
+// authentication username or password.
+func basicAuthEnabled(cluster *containerpb.Cluster) bool {
+	auth := cluster.GetMasterAuth()
+	return auth.GetUsername() != "" || auth.GetPassword() != "" //nolint:staticcheck // Detecting the deprecated basic authentication is the purpose.
+}
+
+// clientCertificateIssued reports whether the cluster issues a client
+// certificate, or has issued one in the past.
+func clientCertificateIssued(cluster *containerpb.Cluster) bool {
+	auth := cluster.GetMasterAuth()
+	return auth.GetClientCertificateConfig().GetIssueClientCertificate() || auth.GetClientCertificate() != ""
+}
+
+// legacyEndpointsPools returns the node pools whose nodes do not turn off the
+// legacy metadata server endpoints.
+func legacyEndpointsPools(cluster *containerpb.Cluster) []string {
+	var pools []string
+	for _, pool := range cluster.GetNodePools() {
+		if pool.GetConfig().GetMetadata()[disableLegacyEndpointsKey] != "true" {
+			pools = append(pools, pool.GetName())
+		}
+	}
+	return pools
+}
+
+// legacyFeatures returns the legacy features enabled on the cluster, with
+// their security impact, lifecycle and the steps to turn them off.
+func legacyFeatures(cluster *containerpb.Cluster) []legacyFeature {
+	update := fmt.Sprintf("gcloud container clusters update %s --location %s", cluster.GetName(), cluster.GetLocation())
+
+	var features []legacyFeature
+	if cluster.GetLegacyAbac().GetEnabled() {
+		features = append(features, legacyFeature{
+			name:      "Legacy ABAC authorization",
+			scope:     "cluster",
+			security:  "ABAC grants static, broad permissions which RBAC cannot restrict, e.g. every service account can be granted access to the whole API. Any permission granted by ABAC bypasses the RBAC policies of the cluster.",
+			lifecycle: "Disabled by default since GKE 1.8. It is not supported by new GKE features and GKE recommends RBAC only.",
+			remediation: []string{
+				"Grant the permissions workloads and users rely on through RBAC Roles and RoleBindings first, otherwise their API calls are denied.",
+				update + " --no-enable-legacy-authorization",
+			},
+		})
+	}
+	if basicAuthEnabled(cluster) {
+		features = append(features, legacyFeature{
+			name:      "Basic authentication",
+			scope:     "cluster",
+			security:  "A static username and password authenticate to the control plane, cannot be scoped and are not rotated. Anyone reading them, e.g. from the cluster description, gets the permissions bound to the user.",
+			lifecycle: "Removed from GKE control plane versions 1.19 and later. The cluster cannot be upgraded to 1.19 or later while it is enabled.",
+			remediation: []string{
+				"Move the clients using the username and password to Google Cloud credentials with get-credentials, or to Kubernetes service accounts.",
+				update + " --no-enable-basic-auth",
+			},
+		})
+	}
+	if clientCertificateIssued(cluster) {
+		features = append(features, legacyFeature{
+			name:      "Client certificate issuance",
+			scope:     "cluster",
+			security:  "A long-lived client certificate authenticates to the control plane and cannot be revoked individually. Anyone reading it from the cluster description gets the permissions bound to its user.",
+			lifecycle: "Disabled by default since GKE 1.12, and cannot be turned off on an existing cluster.",
+			remediation: []string{
+				"Remove every RBAC binding granting permissions to the certificate user \"client\".",
+				"Rotate the cluster credentials to replace a certificate which may have leaked, this also rotates the cluster CA and IP address: " + update + " --start-credential-rotation, then " + update + " --complete-credential-rotation once every client uses the new credentials.",
+				"Create replacement clusters without --issue-client-certificate and migrate the workloads.",
+			},
+		})
+	}
+	if pools := legacyEndpointsPools(cluster); len(pools) > 0 {
+		var remediation []string
+		for _, pool := range pools {
+			remediation = append(remediation, fmt.Sprintf("gcloud container node-pools create %s-v2 --cluster %s --location %s --metadata %s=true", pool, cluster.GetName(), cluster.GetLocation(), disableLegacyEndpointsKey))
+		}
+		remediation = append(remediation, "Node metadata cannot be changed on an existing node pool, migrate the workloads to the new node pools and delete the old ones.")
+		features = append(features, legacyFeature{
+			name:        "Legacy metadata server endpoints",
+			scope:       "node pools " + strings.Join(pools, ", "),
+			security:    "The v0.1 and v1beta1 endpoints do not require the Metadata-Flavor header, so a server-side request forgery in a pod can read the node credentials and kube-env.",
+			lifecycle:   "Disabled by default on node pools created with GKE 1.12 and later, and shut down by Compute Engine. Workloads still calling them already fail.",
+			remediation: remediation,
+		})
+	}
+	return features
+}
+
+func legacyFeaturesReport(cluster *containerpb.Cluster) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cluster: %s (version %s)\n", cluster.GetName(), cluster.GetCurrentMasterVersion())
+
+	features := legacyFeatures(cluster)
+	if len(features) == 0 {
+		b.WriteString("\nNo legacy features are enabled: legacy ABAC authorization, basic authentication, client certificate issuance and legacy metadata server endpoints are all turned off.\n")
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "Legacy features enabled: %d\n", len(features))
+	for _, f := range features {
+		fmt.Fprintf(&b, "\n%s (%s):\n", f.name, f.scope)
+		fmt.Fprintf(&b, "  Security: %s\n", f.security)
+		fmt.Fprintf(&b, "  Lifecycle: %s\n", f.lifecycle)
+		b.WriteString("  Remediation:\n")
+		for _, step := range f.remediation {
+			fmt.Fprintf(&b, "  - %s\n", step)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrade
+
+import (
+	"strings"
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+)
+
+func TestLegacyFeatures(t *testing.T) {
+	secureConfig := &containerpb.NodeConfig{Metadata: map[string]string{disableLegacyEndpointsKey: "true"}}
+	tests := []struct {
+		name    string
+		cluster *containerpb.Cluster
+		want    []string
+	}{
+		{
+			name: "modern cluster",
+			cluster: &containerpb.Cluster{
+				MasterAuth: &containerpb.MasterAuth{ClusterCaCertificate: "ca"},
+				NodePools:  []*containerpb.NodePool{{Name: "pool", Config: secureConfig}},
+			},
+		},
+		{
+			name: "legacy ABAC",
+			cluster: &containerpb.Cluster{
+				LegacyAbac: &containerpb.LegacyAbac{Enabled: true},
+			},
+			want: []string{"Legacy ABAC authorization"},
+		},
+		{
+			name: "basic auth and client certificate",
+			cluster: &containerpb.Cluster{
+				MasterAuth: &containerpb.MasterAuth{Username: "admin", Password: "secret", ClientCertificate: "cert"},
+			},
+			want: []string{"Basic authentication", "Client certificate issuance"},
+		},
+		{
+			name: "client certificate issuance",
+			cluster: &containerpb.Cluster{
+				MasterAuth: &containerpb.MasterAuth{ClientCertificateConfig: &containerpb.ClientCertificateConfig{IssueClientCertificate: true}},
+			},
+			want: []string{"Client certificate issuance"},
+		},
+		{
+			name: "legacy metadata endpoints",
+			cluster: &containerpb.Cluster{
+				NodePools: []*containerpb.NodePool{
+					{Name: "old", Config: &containerpb.NodeConfig{}},
+					{Name: "explicit", Config: &containerpb.NodeConfig{Metadata: map[string]string{disableLegacyEndpointsKey: "false"}}},
+					{Name: "new", Config: secureConfig},
+				},
+			},
+			want: []string{"Legacy metadata server endpoints"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, f := range legacyFeatures(tt.cluster) {
+				got = append(got, f.name)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("legacyFeatures() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLegacyFeaturesReport(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		Name:                 "prod",
+		Location:             "us-central1",
+		CurrentMasterVersion: "1.18.20-gke.900",
+		LegacyAbac:           &containerpb.LegacyAbac{Enabled: true},
+		MasterAuth:           &containerpb.MasterAuth{Username: "admin"},
+		NodePools: []*containerpb.NodePool{
+			{Name: "a", Config: &containerpb.NodeConfig{}},
+			{Name: "b", Config: &containerpb.NodeConfig{}},
+		},
+	}
+
+	got := legacyFeaturesReport(cluster)
+	for _, want := range []string{
+		"Cluster: prod (version 1.18.20-gke.900)\n",
+		"Legacy features enabled: 3\n",
+		"\nLegacy ABAC authorization (cluster):\n",
+		"  - gcloud container clusters update prod --location us-central1 --no-enable-legacy-authorization\n",
+		"  Lifecycle: Removed from GKE control plane versions 1.19 and later.",
+		"  - gcloud container clusters update prod --location us-central1 --no-enable-basic-auth\n",
+		"\nLegacy metadata server endpoints (node pools a, b):\n",
+		"  - gcloud container node-pools create b-v2 --cluster prod --location us-central1 --metadata disable-legacy-endpoints=true\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("legacyFeaturesReport() missing %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Client certificate") {
+		t.Errorf("legacyFeaturesReport() reports client certificate issuance, got:\n%s", got)
+	}
+
+	got = legacyFeaturesReport(&containerpb.Cluster{Name: "prod"})
+	if !strings.Contains(got, "No legacy features are enabled") {
+		t.Errorf("legacyFeaturesReport() = %q, want no legacy features", got)
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrade
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	pubsub "google.golang.org/api/pubsub/v1"
+)
+
+const (
+	defaultMaxMessages = 10
+	maxMaxMessages     = 100
+)
+
+// subscriptionRegexp matches Pub/Sub subscription IDs and full names.
+var subscriptionRegexp = regexp.MustCompile(`^(projects/[a-z][-a-z0-9.:]*/subscriptions/)?[A-Za-z][-A-Za-z0-9._~+%]*$`)
+
+type getUpgradeNotificationsArgs struct {
+	ProjectID    string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location     string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name         string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+	Pull         bool   `json:"pull,omitempty" jsonschema:"Pull the recent notification messages of the cluster from a subscription of the notification topic. The messages are not acknowledged and remain available to other subscribers."`
+	Subscription string `json:"subscription,omitempty" jsonschema:"Pub/Sub subscription to pull the messages from, as an ID or projects/PROJECT/subscriptions/ID. Defaults to the only subscription of the notification topic."`
+	MaxMessages  int    `json:"max_messages,omitempty" jsonschema:"Maximum number of messages to pull. Cannot be greater than 100. Defaults to 10."`
+}
+
+func (h *handlers) getUpgradeNotifications(ctx context.Context, _ *mcp.CallToolRequest, args *getUpgradeNotificationsArgs) (*mcp.CallToolResult, any, error) {
+	if args.MaxMessages == 0 {
+		args.MaxMessages = defaultMaxMessages
+	}
+	if args.MaxMessages < 1 || args.MaxMessages > maxMaxMessages {
+		return nil, nil, fmt.Errorf("max_messages argument must be between 1 and %d", maxMaxMessages)
+	}
+	if args.Subscription != "" && !subscriptionRegexp.MatchString(args.Subscription) {
+		return nil, nil, fmt.Errorf("invalid subscription %q", args.Subscription)
+	}
+	cluster, err := gke.GetCluster(ctx, h.cmClient, h.c, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	report := notificationConfigReport(cluster)
+	topic := cluster.GetNotificationConfig().GetPubsub().GetTopic()
+	if args.Pull && cluster.GetNotificationConfig().GetPubsub().GetEnabled() && topic != "" {
+		messages, err := h.pullNotifications(ctx, topic, args.Subscription, args.MaxMessages)
+		if err != nil {
+			return nil, nil, err
+		}
+		report += "\n" + notificationMessagesReport(cluster, messages)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: report},
+		},
+	}, nil, nil
+}
+
+// pullNotifications pulls messages from the subscription of the topic, and
+// immediately makes them available again for redelivery.
+func (h *handlers) pullNotifications(ctx context.Context, topic, subscription string, maxMessages int) ([]*pubsub.ReceivedMessage, error) {
+	svc, err := pubsub.NewService(ctx, h.c.RESTClientOptions(ctx)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pubsub client: %w", err)
+	}
+
+	if subscription == "" {
+		resp, err := svc.Projects.Topics.Subscriptions.List(topic).Context(ctx).Do()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list subscriptions of %s: %w", topic, err)
+		}
+		switch len(resp.Subscriptions) {
+		case 0:
+			return nil, fmt.Errorf("topic %s has no subscription, create one to pull the notifications", topic)
+		case 1:
+			subscription = resp.Subscriptions[0]
+		default:
+			return nil, fmt.Errorf("topic %s has several subscriptions, set the subscription argument to one of: %s", topic, strings.Join(resp.Subscriptions, ", "))
+		}
+	} else if !strings.HasPrefix(subscription, "projects/") {
+		project, _, _ := strings.Cut(strings.TrimPrefix(topic, "projects/"), "/")
+		subscription = "projects/" + project + "/subscriptions/" + subscription
+	}
+
+	resp, err := svc.Projects.Subscriptions.Pull(subscription, &pubsub.PullRequest{MaxMessages: int64(maxMessages)}).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull messages from %s: %w", subscription, err)
+	}
+	if len(resp.ReceivedMessages) == 0 {
+		return nil, nil
+	}
+	ackIDs := make([]string, len(resp.ReceivedMessages))
+	for i, m := range resp.ReceivedMessages {
+		ackIDs[i] = m.AckId
+	}
+	// A zero ack deadline releases the messages, so that the subscribers
+	// processing the notifications still receive them.
+	if _, err := svc.Projects.Subscriptions.ModifyAckDeadline(subscription, &pubsub.ModifyAckDeadlineRequest{
+		AckIds:             ackIDs,
+		AckDeadlineSeconds: 0,
+		ForceSendFields:    []string{"AckDeadlineSeconds"},
+	}).Context(ctx).Do(); err != nil {
+		return nil, fmt.Errorf("failed to release messages of %s: %w", subscription, err)
+	}
+	return resp.ReceivedMessages, nil
+}
+
+// notificationConfigReport reports whether the cluster publishes upgrade
+// notifications to Pub/Sub, and which ones.
+func notificationConfigReport(cluster *containerpb.Cluster) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cluster: %s, version %s, release channel %s\n", cluster.GetName(), cluster.GetCurrentMasterVersion(), cluster.GetReleaseChannel().GetChannel())
+
+	config := cluster.GetNotificationConfig().GetPubsub()
+	if !config.GetEnabled() || config.GetTopic() == "" {
+		b.WriteString("Upgrade notifications: not configured. Pending auto-upgrades can only be found in the GKE release schedule and the maintenance policy.\n")
+		fmt.Fprintf(&b, "Enable them with: gcloud container clusters update %s --location %s --notification-config=pubsub=ENABLED,pubsub-topic=projects/PROJECT_ID/topics/TOPIC\n", cluster.GetName(), cluster.GetLocation())
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "Upgrade notifications: published to %s\n", config.GetTopic())
+	eventTypes := config.GetFilter().GetEventType()
+	if len(eventTypes) == 0 {
+		b.WriteString("Event types: all (UpgradeAvailableEvent, UpgradeEvent, UpgradeInfoEvent, SecurityBulletinEvent)\n")
+		return b.String()
+	}
+	names := make([]string, len(eventTypes))
+	for i, eventType := range eventTypes {
+		names[i] = eventType.String()
+	}
+	fmt.Fprintf(&b, "Event types: %s\n", strings.Join(names, ", "))
+	if !slices.Contains(eventTypes, containerpb.NotificationConfig_UPGRADE_EVENT) {
+		b.WriteString("Warning: UPGRADE_EVENT is filtered out, the start of auto-upgrades is not notified.\n")
+	}
+	return b.String()
+}
+
+// notificationMessagesReport lists the notification messages of the cluster,
+// the most recent first. The topic can be shared by several clusters.
+func notificationMessagesReport(cluster *containerpb.Cluster, messages []*pubsub.ReceivedMessage) string {
+	var ours []*pubsub.PubsubMessage
+	for _, m := range messages {
+		if m.Message == nil || m.Message.Attributes["cluster_name"] != cluster.GetName() {
+			continue
+		}
+		if location := m.Message.Attributes["cluster_location"]; location != "" && location != cluster.GetLocation() {
+			continue
+		}
+		ours = append(ours, m.Message)
+	}
+	if len(ours) == 0 {
+		return fmt.Sprintf("No pending notification messages of the cluster among the %d pulled.\n", len(messages))
+	}
+	sort.Slice(ours, func(i, j int) bool { return ours[i].PublishTime > ours[j].PublishTime })
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Notification messages (%d):\n", len(ours))
+	for _, m := range ours {
+		typeURL := m.Attributes["type_url"]
+		eventType := typeURL[strings.LastIndex(typeURL, ".")+1:]
+		data, err := base64.StdEncoding.DecodeString(m.Data)
+		if err != nil {
+			data = []byte(m.Data)
+		}
+		fmt.Fprintf(&b, "- %s %s: %s\n", m.PublishTime, eventType, strings.TrimSpace(string(data)))
+	}
+	return b.String()
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrade
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	pubsub "google.golang.org/api/pubsub/v1"
+)
+
+func TestNotificationConfigReport(t *testing.T) {
+	cluster := &containerpb.Cluster{Name: "my-cluster", Location: "us-central1", CurrentMasterVersion: "1.31.5-gke.1000"}
+	if got := notificationConfigReport(cluster); !strings.Contains(got, "Upgrade notifications: not configured") || !strings.Contains(got, "--notification-config=pubsub=ENABLED") {
+		t.Errorf("notificationConfigReport() without notifications = %s", got)
+	}
+
+	cluster.NotificationConfig = &containerpb.NotificationConfig{Pubsub: &containerpb.NotificationConfig_PubSub{
+		Enabled: true,
+		Topic:   "projects/my-project/topics/gke-upgrades",
+	}}
+	got := notificationConfigReport(cluster)
+	for _, want := range []string{"published to projects/my-project/topics/gke-upgrades", "Event types: all"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("notificationConfigReport() missing %q, got:\n%s", want, got)
+		}
+	}
+
+	cluster.NotificationConfig.Pubsub.Filter = &containerpb.NotificationConfig_Filter{
+		EventType: []containerpb.NotificationConfig_EventType{containerpb.NotificationConfig_SECURITY_BULLETIN_EVENT},
+	}
+	got = notificationConfigReport(cluster)
+	for _, want := range []string{"Event types: SECURITY_BULLETIN_EVENT", "Warning: UPGRADE_EVENT is filtered out"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("notificationConfigReport() missing %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestNotificationMessagesReport(t *testing.T) {
+	cluster := &containerpb.Cluster{Name: "my-cluster", Location: "us-central1"}
+	message := func(name, typeURL, publishTime, data string) *pubsub.ReceivedMessage {
+		return &pubsub.ReceivedMessage{Message: &pubsub.PubsubMessage{
+			Attributes:  map[string]string{"cluster_name": name, "cluster_location": "us-central1", "type_url": typeURL},
+			Data:        base64.StdEncoding.EncodeToString([]byte(data)),
+			PublishTime: publishTime,
+		}}
+	}
+	messages := []*pubsub.ReceivedMessage{
+		message("my-cluster", "type.googleapis.com/google.container.v1beta1.UpgradeAvailableEvent", "2025-06-01T10:00:00Z", `{"version":"1.32.1-gke.1000"}`),
+		message("my-cluster", "type.googleapis.com/google.container.v1beta1.UpgradeEvent", "2025-06-02T10:00:00Z", `{"targetVersion":"1.32.1-gke.1000"}`),
+		message("other-cluster", "type.googleapis.com/google.container.v1beta1.UpgradeEvent", "2025-06-03T10:00:00Z", `{}`),
+	}
+
+	got := notificationMessagesReport(cluster, messages)
+	for _, want := range []string{
+		"Notification messages (2):",
+		`- 2025-06-02T10:00:00Z UpgradeEvent: {"targetVersion":"1.32.1-gke.1000"}`,
+		`- 2025-06-01T10:00:00Z UpgradeAvailableEvent: {"version":"1.32.1-gke.1000"}`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("notificationMessagesReport() missing %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "2025-06-03") {
+		t.Errorf("notificationMessagesReport() lists a message of another cluster, got:\n%s", got)
+	}
+	if strings.Index(got, "UpgradeEvent:") > strings.Index(got, "UpgradeAvailableEvent:") {
+		t.Errorf("notificationMessagesReport() doesn't list the most recent message first, got:\n%s", got)
+	}
+
+	if got := notificationMessagesReport(cluster, messages[2:]); !strings.Contains(got, "No pending notification messages of the cluster among the 1 pulled") {
+		t.Errorf("notificationMessagesReport() without messages of the cluster = %s", got)
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/progress"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/gkereleasenotes"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// runtimeReleaseNoteKeywords are used to pick node image and container runtime entries from the GKE release notes.
+var runtimeReleaseNoteKeywords = []string{"containerd", "cgroup", "runc", "container-optimized os", "cos-", "ubuntu", "kernel", "node image"}
+
+// runtimeMilestone is a node image or container runtime change introduced in a GKE minor version.
+type runtimeMilestone struct {
+	minor       int
+	description string
+}
+
+// runtimeMilestones are runtime-level changes which are not part of the Kubernetes changelog.
+var runtimeMilestones = []runtimeMilestone{
+	{minor: 24, description: "Docker-based node images are no longer supported, nodes only run containerd. Workloads mounting the Docker socket break."},
+	{minor: 26, description: "New node pools use cgroup v2 by default. Workloads reading cgroup v1 files, old JDKs and old monitoring agents can misbehave."},
+	{minor: 33, description: "Nodes run containerd 2.0. Deprecated containerd configuration, such as registry mirrors in config.toml, and Docker schema 1 images are not supported anymore."},
+	{minor: 35, description: "The kubelet fails to start on nodes using cgroup v1. Node pools configured for cgroup v1 must be migrated to cgroup v2 before the upgrade."},
+}
+
+// dockerImageTypes are the node image types using Docker as the container runtime.
+var dockerImageTypes = map[string]bool{"COS": true, "UBUNTU": true, "WINDOWS_LTSC": true, "WINDOWS_SAC": true}
+
+type getNodeRuntimeChangesArgs struct {
+	ProjectID     string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location      string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name          string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+	TargetVersion string `json:"target_version" jsonschema:"Target GKE version of the upgrade, e.g. 1.33.2-gke.1240000."`
+}
+
+func (h *handlers) getNodeRuntimeChanges(ctx context.Context, _ *mcp.CallToolRequest, args *getNodeRuntimeChangesArgs) (*mcp.CallToolResult, any, error) {
+	if args.TargetVersion == "" {
+		return nil, nil, fmt.Errorf("target_version argument cannot be empty")
+	}
+	cluster, err := gke.GetCluster(ctx, h.cmClient, h.c, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	progress.Report(ctx, 0, 2, "Reading the GKE release notes")
+	releaseNoteEntries, err := gkereleasenotes.UpgradeEntries(ctx, cluster.GetCurrentMasterVersion(), args.TargetVersion, runtimeReleaseNoteKeywords)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	progress.Report(ctx, 1, 2, "Reading the node software versions")
+	var nodes kube.List[kube.Node]
+	nodesErr := kube.Get(ctx, &nodes, "nodes")
+
+	report, err := runtimeChangesReport(cluster, args.TargetVersion, nodes.Items, releaseNoteEntries)
+	if err != nil {
+		return nil, nil, err
+	}
+	if nodesErr != nil {
+		report += fmt.Sprintf("\nCurrent node software versions were not read: %v\n", nodesErr)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: report},
+		},
+	}, nil, nil
+}
+
+// nodeSoftware returns the distinct OS image, kernel and container runtime
+// versions of the nodes of each node pool.
+func nodeSoftware(nodes []kube.Node) map[string][]string {
+	seen := map[string]bool{}
+	software := map[string][]string{}
+	for _, node := range nodes {
+		pool := node.Labels[gke.NodePoolLabel]
+		info := node.Status.NodeInfo
+		s := fmt.Sprintf("%s, kernel %s, %s", info.OSImage, info.KernelVersion, info.ContainerRuntimeVersion)
+		if seen[pool+s] {
+			continue
+		}
+		seen[pool+s] = true
+		software[pool] = append(software[pool], s)
+	}
+	for _, s := range software {
+		sort.Strings(s)
+	}
+	return software
+}
+
+func cgroupMode(pool *containerpb.NodePool) string {
+	switch pool.GetConfig().GetLinuxNodeConfig().GetCgroupMode() {
+	case containerpb.LinuxNodeConfig_CGROUP_MODE_V1:
+		return "v1"
+	case containerpb.LinuxNodeConfig_CGROUP_MODE_V2:
+		return "v2"
+	default:
+		return "default"
+	}
+}
+
+func runtimeChangesReport(cluster *containerpb.Cluster, targetVersion string, nodes []kube.Node, releaseNoteEntries []string) (string, error) {
+	_, targetMinor, err := gke.MinorVersion(targetVersion)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cluster: %s\n", cluster.GetName())
+	fmt.Fprintf(&b, "Target version: %s\n", targetVersion)
+
+	software := nodeSoftware(nodes)
+	oldestMinor := targetMinor
+	for _, pool := range cluster.GetNodePools() {
+		_, minor, err := gke.MinorVersion(pool.GetVersion())
+		if err != nil {
+			return "", err
+		}
+		oldestMinor = min(oldestMinor, minor)
+
+		fmt.Fprintf(&b, "\nNode pool %s (version %s, image type %s, cgroup mode %s)\n", pool.GetName(), pool.GetVersion(), pool.GetConfig().GetImageType(), cgroupMode(pool))
+		for _, s := range software[pool.GetName()] {
+			fmt.Fprintf(&b, "- Current nodes: %s\n", s)
+		}
+		if cgroupMode(pool) == "v1" && targetMinor >= 35 {
+			b.WriteString("- Warning: the node pool uses cgroup v1, which is not supported by the target version.\n")
+		}
+		if dockerImageTypes[strings.ToUpper(pool.GetConfig().GetImageType())] {
+			b.WriteString("- Warning: the node image type uses Docker, switch to a containerd image type.\n")
+		}
+	}
+
+	var milestones []string
+	for _, m := range runtimeMilestones {
+		if m.minor > oldestMinor && m.minor <= targetMinor {
+			milestones = append(milestones, fmt.Sprintf("- 1.%d: %s", m.minor, m.description))
+		}
+	}
+	if len(milestones) > 0 {
+		b.WriteString("\nRuntime changes crossed by this upgrade:\n")
+		b.WriteString(strings.Join(milestones, "\n"))
+		b.WriteString("\n")
+	}
+
+	gkereleasenotes.WriteEntries(&b, "Node image and container runtime", cluster.GetCurrentMasterVersion(), targetVersion, releaseNoteEntries)
+	return b.String(), nil
+}
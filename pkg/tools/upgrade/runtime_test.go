@@ -0,0 +1,86 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrade
+
+import (
+	"strings"
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRuntimeChangesReport(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		Name:                 "my-cluster",
+		CurrentMasterVersion: "1.32.4-gke.1029000",
+		NodePools: []*containerpb.NodePool{
+			{
+				Name:    "default-pool",
+				Version: "1.32.4-gke.1029000",
+				Config:  &containerpb.NodeConfig{ImageType: "COS_CONTAINERD"},
+			},
+			{
+				Name:    "legacy-pool",
+				Version: "1.32.4-gke.1029000",
+				Config: &containerpb.NodeConfig{
+					ImageType:       "UBUNTU_CONTAINERD",
+					LinuxNodeConfig: &containerpb.LinuxNodeConfig{CgroupMode: containerpb.LinuxNodeConfig_CGROUP_MODE_V1},
+				},
+			},
+		},
+	}
+	node := func(name, pool, osImage string) kube.Node {
+		return kube.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{gke.NodePoolLabel: pool}},
+			Status: kube.NodeStatus{NodeInfo: kube.NodeSystemInfo{
+				OSImage:                 osImage,
+				KernelVersion:           "6.6.72+",
+				ContainerRuntimeVersion: "containerd://1.7.24",
+			}},
+		}
+	}
+	nodes := []kube.Node{
+		node("node-1", "default-pool", "Container-Optimized OS from Google"),
+		node("node-2", "default-pool", "Container-Optimized OS from Google"),
+		node("node-3", "legacy-pool", "Ubuntu 22.04.5 LTS"),
+	}
+
+	report, err := runtimeChangesReport(cluster, "1.35.0-gke.1000000", nodes, nil)
+	if err != nil {
+		t.Fatalf("runtimeChangesReport() error = %v", err)
+	}
+	for _, want := range []string{
+		"Node pool default-pool (version 1.32.4-gke.1029000, image type COS_CONTAINERD, cgroup mode default)\n- Current nodes: Container-Optimized OS from Google, kernel 6.6.72+, containerd://1.7.24\n\n",
+		"Node pool legacy-pool (version 1.32.4-gke.1029000, image type UBUNTU_CONTAINERD, cgroup mode v1)",
+		"- Warning: the node pool uses cgroup v1",
+		"- 1.33: Nodes run containerd 2.0.",
+		"- 1.35: The kubelet fails to start on nodes using cgroup v1.",
+		"No Node image and container runtime release notes found.",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("runtimeChangesReport() missing %q in:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "1.26:") {
+		t.Errorf("runtimeChangesReport() reports a runtime change older than the node pools:\n%s", report)
+	}
+
+	if _, err := runtimeChangesReport(cluster, "latest", nodes, nil); err == nil {
+		t.Error("runtimeChangesReport() expected error for an invalid target version, got nil")
+	}
+}
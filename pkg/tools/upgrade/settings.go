@@ -0,0 +1,176 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// defaultNodeUpgradeMinutes is the assumed time to create, drain and replace a batch of nodes.
+	defaultNodeUpgradeMinutes = 10
+	// defaultNodePoolSoakDuration is the node pool soak time GKE uses for blue-green upgrades when it is not set.
+	defaultNodePoolSoakDuration = time.Hour
+)
+
+type getNodePoolUpgradeSettingsArgs struct {
+	ProjectID          string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location           string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name               string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+	NodeUpgradeMinutes int    `json:"node_upgrade_minutes,omitempty" jsonschema:"Assumed number of minutes to upgrade one batch of nodes, including node creation and drain. Defaults to 10."`
+}
+
+// poolUpgradeEstimate describes how a node pool is going to be upgraded.
+type poolUpgradeEstimate struct {
+	name     string
+	size     int64
+	strategy string
+	// extraNodes is the number of nodes temporarily added during the upgrade.
+	extraNodes int64
+	duration   time.Duration
+}
+
+func (h *handlers) getNodePoolUpgradeSettings(ctx context.Context, _ *mcp.CallToolRequest, args *getNodePoolUpgradeSettingsArgs) (*mcp.CallToolResult, any, error) {
+	if args.NodeUpgradeMinutes <= 0 {
+		args.NodeUpgradeMinutes = defaultNodeUpgradeMinutes
+	}
+	cluster, err := gke.GetCluster(ctx, h.cmClient, h.c, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	sizes, err := h.nodePoolSizes(ctx, cluster)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	batchDuration := time.Duration(args.NodeUpgradeMinutes) * time.Minute
+	var estimates []poolUpgradeEstimate
+	for _, pool := range cluster.GetNodePools() {
+		estimates = append(estimates, estimatePoolUpgrade(pool, sizes[pool.GetName()], batchDuration))
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: upgradeSettingsReport(cluster.GetName(), estimates, batchDuration)},
+		},
+	}, nil, nil
+}
+
+func ceilDiv(a, b int64) int64 {
+	if b <= 0 {
+		return 0
+	}
+	return (a + b - 1) / b
+}
+
+// estimatePoolUpgrade estimates the duration and temporary capacity of a node
+// pool upgrade, assuming each batch of nodes takes batchDuration to upgrade.
+func estimatePoolUpgrade(pool *containerpb.NodePool, size int64, batchDuration time.Duration) poolUpgradeEstimate {
+	settings := pool.GetUpgradeSettings()
+	estimate := poolUpgradeEstimate{
+		name:       pool.GetName(),
+		size:       size,
+		extraNodes: gke.SurgeNodes(pool, size),
+	}
+
+	if settings.GetStrategy() == containerpb.NodePoolUpdateStrategy_BLUE_GREEN {
+		blueGreen := settings.GetBlueGreenSettings()
+		poolSoak := defaultNodePoolSoakDuration
+		if blueGreen.GetNodePoolSoakDuration() != nil {
+			poolSoak = blueGreen.GetNodePoolSoakDuration().AsDuration()
+		}
+
+		if autoscaled := blueGreen.GetAutoscaledRolloutPolicy(); autoscaled != nil {
+			estimate.strategy = fmt.Sprintf("blue-green (autoscaled rollout, wait for drain %s, node pool soak %s)", autoscaled.GetWaitForDrainDuration().AsDuration(), poolSoak)
+			estimate.duration = batchDuration + autoscaled.GetWaitForDrainDuration().AsDuration() + poolSoak
+			return estimate
+		}
+
+		rollout := blueGreen.GetStandardRolloutPolicy()
+		batchSize := int64(rollout.GetBatchNodeCount())
+		if rollout.GetBatchPercentage() > 0 {
+			batchSize = int64(math.Ceil(float64(rollout.GetBatchPercentage()) * float64(size)))
+		}
+		if batchSize <= 0 {
+			batchSize = size
+		}
+		batchSoak := rollout.GetBatchSoakDuration().AsDuration()
+		batches := ceilDiv(size, batchSize)
+		estimate.strategy = fmt.Sprintf("blue-green (batches of %d nodes, batch soak %s, node pool soak %s)", batchSize, batchSoak, poolSoak)
+		// The green pool is created first, then blue nodes are drained in batches.
+		estimate.duration = batchDuration + time.Duration(batches)*(batchDuration+batchSoak) + poolSoak
+		return estimate
+	}
+
+	maxSurge := int64(settings.GetMaxSurge())
+	maxUnavailable := int64(settings.GetMaxUnavailable())
+	if settings == nil {
+		maxSurge = 1
+	}
+	estimate.strategy = fmt.Sprintf("surge (maxSurge=%d, maxUnavailable=%d)", maxSurge, maxUnavailable)
+
+	estimate.duration = time.Duration(surgeBatches(pool, size)) * batchDuration
+	return estimate
+}
+
+// surgeBatches returns the number of sequential batches of a surge upgrade of
+// a node pool with size nodes.
+func surgeBatches(pool *containerpb.NodePool, size int64) int64 {
+	settings := pool.GetUpgradeSettings()
+	maxSurge := int64(settings.GetMaxSurge())
+	if settings == nil {
+		maxSurge = 1
+	}
+	zones := int64(len(pool.GetLocations()))
+	if zones == 0 {
+		zones = 1
+	}
+	// Surge settings apply per zone and zones are upgraded in parallel.
+	nodesPerZone := ceilDiv(size, zones)
+	return ceilDiv(nodesPerZone, maxSurge+int64(settings.GetMaxUnavailable()))
+}
+
+func upgradeSettingsReport(clusterName string, estimates []poolUpgradeEstimate, batchDuration time.Duration) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cluster: %s\n", clusterName)
+	fmt.Fprintf(&b, "Assumed time to upgrade one batch of nodes: %s\n\n", batchDuration)
+
+	b.WriteString("| Node pool | Nodes | Strategy | Extra nodes during upgrade | Estimated duration |\n")
+	b.WriteString("|---|---|---|---|---|\n")
+	var total time.Duration
+	var peakExtraNodes int64
+	for _, e := range estimates {
+		fmt.Fprintf(&b, "| %s | %d | %s | %d | %s |\n", e.name, e.size, e.strategy, e.extraNodes, e.duration)
+		total += e.duration
+		peakExtraNodes = max(peakExtraNodes, e.extraNodes)
+	}
+
+	fmt.Fprintf(&b, "\nEstimated total node upgrade duration (node pools upgraded one at a time): %s\n", total)
+	fmt.Fprintf(&b, "Peak temporary extra nodes: %d\n", peakExtraNodes)
+	for _, e := range estimates {
+		if strings.HasPrefix(e.strategy, "surge") && e.extraNodes == 0 && e.size > 0 {
+			fmt.Fprintf(&b, "Warning: node pool %s upgrades without surge nodes, so capacity is reduced during the upgrade.\n", e.name)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrade
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestEstimatePoolUpgrade(t *testing.T) {
+	tests := []struct {
+		name         string
+		pool         *containerpb.NodePool
+		size         int64
+		wantStrategy string
+		wantExtra    int64
+		wantDuration time.Duration
+	}{
+		{
+			name:         "default surge",
+			pool:         &containerpb.NodePool{Name: "default-pool", Locations: []string{"us-central1-a"}},
+			size:         3,
+			wantStrategy: "surge (maxSurge=1, maxUnavailable=0)",
+			wantExtra:    1,
+			wantDuration: 30 * time.Minute,
+		},
+		{
+			name: "surge across zones",
+			pool: &containerpb.NodePool{
+				Name:            "pool",
+				Locations:       []string{"us-central1-a", "us-central1-b"},
+				UpgradeSettings: &containerpb.NodePool_UpgradeSettings{MaxSurge: 2, MaxUnavailable: 1},
+			},
+			size:         12,
+			wantStrategy: "surge (maxSurge=2, maxUnavailable=1)",
+			wantExtra:    4,
+			wantDuration: 20 * time.Minute,
+		},
+		{
+			name: "blue-green standard rollout",
+			pool: &containerpb.NodePool{
+				Name: "pool",
+				UpgradeSettings: &containerpb.NodePool_UpgradeSettings{
+					Strategy: containerpb.NodePoolUpdateStrategy_BLUE_GREEN.Enum(),
+					BlueGreenSettings: &containerpb.BlueGreenSettings{
+						RolloutPolicy: &containerpb.BlueGreenSettings_StandardRolloutPolicy_{
+							StandardRolloutPolicy: &containerpb.BlueGreenSettings_StandardRolloutPolicy{
+								UpdateBatchSize:   &containerpb.BlueGreenSettings_StandardRolloutPolicy_BatchNodeCount{BatchNodeCount: 2},
+								BatchSoakDuration: durationpb.New(5 * time.Minute),
+							},
+						},
+						NodePoolSoakDuration: durationpb.New(30 * time.Minute),
+					},
+				},
+			},
+			size:         4,
+			wantStrategy: "blue-green (batches of 2 nodes, batch soak 5m0s, node pool soak 30m0s)",
+			wantExtra:    4,
+			wantDuration: 10*time.Minute + 2*15*time.Minute + 30*time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := estimatePoolUpgrade(tt.pool, tt.size, 10*time.Minute)
+			if got.strategy != tt.wantStrategy {
+				t.Errorf("estimatePoolUpgrade().strategy = %q, want %q", got.strategy, tt.wantStrategy)
+			}
+			if got.extraNodes != tt.wantExtra {
+				t.Errorf("estimatePoolUpgrade().extraNodes = %d, want %d", got.extraNodes, tt.wantExtra)
+			}
+			if got.duration != tt.wantDuration {
+				t.Errorf("estimatePoolUpgrade().duration = %s, want %s", got.duration, tt.wantDuration)
+			}
+		})
+	}
+}
+
+func TestUpgradeSettingsReport(t *testing.T) {
+	estimates := []poolUpgradeEstimate{
+		{name: "default-pool", size: 3, strategy: "surge (maxSurge=1, maxUnavailable=0)", extraNodes: 1, duration: 30 * time.Minute},
+		{name: "no-surge-pool", size: 2, strategy: "surge (maxSurge=0, maxUnavailable=1)", extraNodes: 0, duration: 20 * time.Minute},
+	}
+	got := upgradeSettingsReport("my-cluster", estimates, 10*time.Minute)
+	for _, want := range []string{
+		"| default-pool | 3 | surge (maxSurge=1, maxUnavailable=0) | 1 | 30m0s |",
+		"Estimated total node upgrade duration (node pools upgraded one at a time): 50m0s",
+		"Peak temporary extra nodes: 1",
+		"Warning: node pool no-surge-pool upgrades without surge nodes",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("upgradeSettingsReport() = %q, want to contain %q", got, want)
+		}
+	}
+}
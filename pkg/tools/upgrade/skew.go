@@ -0,0 +1,121 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type checkVersionSkewArgs struct {
+	ProjectID     string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location      string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name          string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+	TargetVersion string `json:"target_version" jsonschema:"Target control plane version, e.g. 1.31 or 1.31.1-gke.1146000."`
+}
+
+func (h *handlers) checkVersionSkew(ctx context.Context, _ *mcp.CallToolRequest, args *checkVersionSkewArgs) (*mcp.CallToolResult, any, error) {
+	if args.TargetVersion == "" {
+		return nil, nil, fmt.Errorf("target_version argument cannot be empty")
+	}
+	cluster, err := gke.GetCluster(ctx, h.cmClient, h.c, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	report, err := versionSkewReport(cluster, args.TargetVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: report},
+		},
+	}, nil, nil
+}
+
+// maxKubeletSkew returns how many minor versions a kubelet may be older than
+// a kube-apiserver of the given minor version, following the Kubernetes
+// version skew policy.
+func maxKubeletSkew(apiServerMinor int) int {
+	if apiServerMinor >= 28 {
+		return 3
+	}
+	return 2
+}
+
+// firstUnsupportedMinor returns the first control plane minor version after
+// currentMinor which no longer supports nodes of nodeMinor.
+func firstUnsupportedMinor(currentMinor, nodeMinor int) int {
+	m := currentMinor + 1
+	for m-nodeMinor <= maxKubeletSkew(m) {
+		m++
+	}
+	return m
+}
+
+func versionSkewReport(cluster *containerpb.Cluster, targetVersion string) (string, error) {
+	currentMajor, currentMinor, err := gke.MinorVersion(cluster.GetCurrentMasterVersion())
+	if err != nil {
+		return "", err
+	}
+	targetMajor, targetMinor, err := gke.MinorVersion(targetVersion)
+	if err != nil {
+		return "", err
+	}
+	if targetMajor != currentMajor || targetMinor < currentMinor {
+		return "", fmt.Errorf("target version %s is older than the current control plane version %s", targetVersion, cluster.GetCurrentMasterVersion())
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cluster: %s\n", cluster.GetName())
+	fmt.Fprintf(&b, "Control plane version: %s, target: %s\n", cluster.GetCurrentMasterVersion(), targetVersion)
+	fmt.Fprintf(&b, "Nodes may be up to %d minor versions older than a %d.%d control plane and never newer.\n", maxKubeletSkew(targetMinor), targetMajor, targetMinor)
+	if targetMinor-currentMinor > 1 {
+		var steps []string
+		for m := currentMinor; m <= targetMinor; m++ {
+			steps = append(steps, fmt.Sprintf("%d.%d", currentMajor, m))
+		}
+		fmt.Fprintf(&b, "GKE upgrades the control plane one minor version at a time: %s.\n", strings.Join(steps, " -> "))
+	}
+
+	b.WriteString("\n| Node pool | Version | Skew after upgrade | Action |\n")
+	b.WriteString("|---|---|---|---|\n")
+	for _, pool := range cluster.GetNodePools() {
+		major, minor, err := gke.MinorVersion(pool.GetVersion())
+		if err != nil {
+			return "", err
+		}
+		skew := targetMinor - minor
+		var action string
+		switch {
+		case major != targetMajor || skew < 0:
+			action = "Node pool is newer than the target control plane version, which is not supported."
+		case skew > maxKubeletSkew(targetMinor):
+			action = fmt.Sprintf("Must be upgraded to at least %d.%d before the control plane is upgraded to %d.%d.", major, targetMinor-maxKubeletSkew(targetMinor), major, firstUnsupportedMinor(currentMinor, minor))
+		case skew == maxKubeletSkew(targetMinor):
+			action = "At the maximum supported skew after the upgrade. Upgrade it together with the control plane, the next control plane upgrade requires it."
+		default:
+			action = "OK"
+		}
+		fmt.Fprintf(&b, "| %s | %s | %d | %s |\n", pool.GetName(), pool.GetVersion(), skew, action)
+	}
+	return b.String(), nil
+}
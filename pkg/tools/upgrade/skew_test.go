@@ -0,0 +1,68 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrade
+
+import (
+	"strings"
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+)
+
+func TestFirstUnsupportedMinor(t *testing.T) {
+	tests := []struct {
+		currentMinor, nodeMinor, want int
+	}{
+		{currentMinor: 30, nodeMinor: 27, want: 31},
+		{currentMinor: 30, nodeMinor: 30, want: 34},
+		{currentMinor: 25, nodeMinor: 24, want: 27},
+	}
+	for _, tt := range tests {
+		if got := firstUnsupportedMinor(tt.currentMinor, tt.nodeMinor); got != tt.want {
+			t.Errorf("firstUnsupportedMinor(%d, %d) = %d, want %d", tt.currentMinor, tt.nodeMinor, got, tt.want)
+		}
+	}
+}
+
+func TestVersionSkewReport(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		Name:                 "my-cluster",
+		CurrentMasterVersion: "1.30.5-gke.1014001",
+		NodePools: []*containerpb.NodePool{
+			{Name: "current", Version: "1.30.5-gke.1014001"},
+			{Name: "at-limit", Version: "1.29.8-gke.1031000"},
+			{Name: "too-old", Version: "1.28.9-gke.1000000"},
+		},
+	}
+
+	report, err := versionSkewReport(cluster, "1.32.1-gke.1357000")
+	if err != nil {
+		t.Fatalf("versionSkewReport() error = %v", err)
+	}
+	for _, want := range []string{
+		"GKE upgrades the control plane one minor version at a time: 1.30 -> 1.31 -> 1.32.",
+		"| current | 1.30.5-gke.1014001 | 2 | OK |",
+		"| at-limit | 1.29.8-gke.1031000 | 3 | At the maximum supported skew after the upgrade.",
+		"| too-old | 1.28.9-gke.1000000 | 4 | Must be upgraded to at least 1.29 before the control plane is upgraded to 1.32. |",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("versionSkewReport() missing %q in:\n%s", want, report)
+		}
+	}
+
+	if _, err := versionSkewReport(cluster, "1.29"); err == nil {
+		t.Error("versionSkewReport() expected error for a downgrade, got nil")
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package upgrade provides MCP tools for planning GKE cluster upgrades.
+package upgrade
+
+import (
+	"context"
+	"fmt"
+
+	container "cloud.google.com/go/container/apiv1"
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	compute "google.golang.org/api/compute/v1"
+)
+
+type handlers struct {
+	c        *config.Config
+	cmClient *container.ClusterManagerClient
+}
+
+// Install registers upgrade planning tools with the MCP server.
+func Install(ctx context.Context, s *mcp.Server, c *config.Config) error {
+	cmClient, err := container.NewClusterManagerClient(ctx, c.ClientOptions()...)
+	if err != nil {
+		return fmt.Errorf("failed to create cluster manager client: %w", err)
+	}
+
+	h := &handlers{
+		c:        c,
+		cmClient: cmClient,
+	}
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "get_node_pool_upgrade_settings",
+		Description: "Read the upgrade strategy of each node pool of a GKE cluster (surge settings, or blue-green with batch and soak times) and estimate the upgrade duration and temporary extra nodes per node pool.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.getNodePoolUpgradeSettings)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "estimate_upgrade_duration",
+		Description: "Estimate how long an upgrade of a GKE cluster takes, based on node pool sizes, upgrade settings, PodDisruptionBudgets of the current kubectl context and durations of previous upgrade operations. Use it to propose a maintenance window.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.estimateUpgradeDuration)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "check_version_skew",
+		Description: "Validate a target control plane version of a GKE cluster against the version of each node pool using the Kubernetes version skew policy, and report which node pools must be upgraded before or together with the control plane.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.checkVersionSkew)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "get_node_runtime_changes",
+		Description: "Report the node image, kernel and containerd versions of each node pool of a GKE cluster, the cgroup mode, and the node image and container runtime changes (cgroup v2, containerd 2, ...) between the current and a target GKE version. These changes are not part of the Kubernetes changelog.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.getNodeRuntimeChanges)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "get_upgrade_notifications",
+		Description: "Report whether a GKE cluster publishes upgrade notifications to a Pub/Sub topic and for which event types, and optionally pull its recent UpgradeAvailableEvent, UpgradeEvent, UpgradeInfoEvent and SecurityBulletinEvent messages from a subscription of the topic, to learn about the auto-upgrades GKE has scheduled. Pulled messages are not acknowledged.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.getUpgradeNotifications)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "detect_legacy_features",
+		Description: "Detect legacy features still enabled on a GKE cluster: legacy ABAC authorization, basic authentication, client certificate issuance and legacy metadata server endpoints on node pools. Reports each as a security issue and as a feature removed or unsupported in newer GKE versions, with the gcloud commands to turn it off.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.detectLegacyFeatures)
+
+	if c.AllowWrite() || c.DryRun() {
+		h.installWriteTools(s)
+	}
+
+	return nil
+}
+
+// nodePoolSizes returns the current number of nodes of every node pool of the cluster, keyed by node pool name.
+func (h *handlers) nodePoolSizes(ctx context.Context, cluster *containerpb.Cluster) (map[string]int64, error) {
+	svc, err := compute.NewService(ctx, h.c.RESTClientOptions(ctx)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create compute client: %w", err)
+	}
+
+	sizes := map[string]int64{}
+	for _, pool := range cluster.GetNodePools() {
+		size, err := gke.NodePoolSize(ctx, svc, pool)
+		if err != nil {
+			return nil, err
+		}
+		sizes[pool.GetName()] = size
+	}
+	return sizes, nil
+}
@@ -0,0 +1,317 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/dryrun"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// exclusionScopes maps the maintenance exclusion scope arguments to their API values.
+var exclusionScopes = map[string]containerpb.MaintenanceExclusionOptions_Scope{
+	"no_upgrades":               containerpb.MaintenanceExclusionOptions_NO_UPGRADES,
+	"no_minor_upgrades":         containerpb.MaintenanceExclusionOptions_NO_MINOR_UPGRADES,
+	"no_minor_or_node_upgrades": containerpb.MaintenanceExclusionOptions_NO_MINOR_OR_NODE_UPGRADES,
+}
+
+// exclusionScopeEffects describes the upgrades prevented by each maintenance exclusion scope.
+var exclusionScopeEffects = map[string]string{
+	"no_upgrades":               "automatic upgrades",
+	"no_minor_upgrades":         "automatic minor version upgrades",
+	"no_minor_or_node_upgrades": "automatic minor version and node upgrades",
+}
+
+type startControlPlaneUpgradeArgs struct {
+	ProjectID     string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location      string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name          string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+	TargetVersion string `json:"target_version" jsonschema:"Target GKE version of the control plane, e.g. 1.33 or 1.33.2-gke.1240000."`
+	Confirm       bool   `json:"confirm" jsonschema:"Must be true. Set it only after the user explicitly confirmed this exact change."`
+}
+
+type startNodePoolUpgradeArgs struct {
+	ProjectID     string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location      string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name          string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+	NodePool      string `json:"node_pool" jsonschema:"Name of the node pool to upgrade."`
+	TargetVersion string `json:"target_version,omitempty" jsonschema:"Target GKE version of the nodes, e.g. 1.33.2-gke.1240000. Leave this empty to upgrade to the control plane version."`
+	Confirm       bool   `json:"confirm" jsonschema:"Must be true. Set it only after the user explicitly confirmed this exact change."`
+}
+
+type setMaintenanceExclusionArgs struct {
+	ProjectID     string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location      string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name          string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+	ExclusionName string `json:"exclusion_name" jsonschema:"Name of the maintenance exclusion. An existing exclusion with the same name is replaced."`
+	StartTime     string `json:"start_time" jsonschema:"Start time of the exclusion in RFC 3339 format, e.g. 2025-12-01T00:00:00Z."`
+	EndTime       string `json:"end_time" jsonschema:"End time of the exclusion in RFC 3339 format, e.g. 2025-12-31T00:00:00Z."`
+	Scope         string `json:"scope,omitempty" jsonschema:"Scope of the exclusion: no_upgrades, no_minor_upgrades or no_minor_or_node_upgrades. Defaults to no_upgrades."`
+	Confirm       bool   `json:"confirm" jsonschema:"Must be true. Set it only after the user explicitly confirmed this exact change."`
+}
+
+// installWriteTools registers the tools mutating GKE clusters. They are only
+// registered when the server is started with --allow-write or --dry-run.
+func (h *handlers) installWriteTools(s *mcp.Server) {
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "start_control_plane_upgrade",
+		Description: "Start an upgrade of the control plane of a GKE cluster to a target version and return the upgrade operation. Only call this tool after the user reviewed the upgrade risks and explicitly confirmed the upgrade, and set confirm to true.",
+		Annotations: &mcp.ToolAnnotations{},
+	}, h.startControlPlaneUpgrade)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "start_node_pool_upgrade",
+		Description: "Start an upgrade of a node pool of a GKE cluster to a target version, by default the control plane version, and return the upgrade operation. Nodes are drained and recreated using the upgrade strategy of the node pool. Only call this tool after the user explicitly confirmed the upgrade, and set confirm to true.",
+		Annotations: &mcp.ToolAnnotations{},
+	}, h.startNodePoolUpgrade)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "set_maintenance_exclusion",
+		Description: "Add or replace a maintenance exclusion of a GKE cluster, preventing automatic upgrades during a time window. Only call this tool after the user explicitly confirmed the exclusion, and set confirm to true.",
+		Annotations: &mcp.ToolAnnotations{},
+	}, h.setMaintenanceExclusion)
+}
+
+func checkConfirmed(confirm bool) error {
+	if !confirm {
+		return fmt.Errorf("confirm argument must be true, ask the user to explicitly confirm the change first")
+	}
+	return nil
+}
+
+// resolveCluster fills in the default project, location and cluster name and
+// returns the resource name of the cluster.
+func (h *handlers) resolveCluster(ctx context.Context, projectID, location, name *string) (string, error) {
+	if *projectID == "" {
+		*projectID = h.c.DefaultProjectID(ctx)
+	}
+	if *location == "" {
+		*location = h.c.DefaultLocation(ctx)
+	}
+	if *name == "" {
+		*name = h.c.DefaultCluster(ctx)
+	}
+	return gke.ClusterName(ctx, h.c, *projectID, *location, *name)
+}
+
+func (h *handlers) startControlPlaneUpgrade(ctx context.Context, _ *mcp.CallToolRequest, args *startControlPlaneUpgradeArgs) (*mcp.CallToolResult, any, error) {
+	if args.TargetVersion == "" {
+		return nil, nil, fmt.Errorf("target_version argument cannot be empty")
+	}
+	clusterName, err := h.resolveCluster(ctx, &args.ProjectID, &args.Location, &args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	req := &containerpb.UpdateMasterRequest{
+		Name:          clusterName,
+		MasterVersion: args.TargetVersion,
+	}
+
+	if h.c.DryRun() {
+		return (&dryrun.Plan{
+			Method:  "ClusterManager.UpdateMaster",
+			Request: req,
+			Gcloud:  fmt.Sprintf("gcloud container clusters upgrade %s --master --cluster-version=%s --location=%s --project=%s", args.Name, args.TargetVersion, args.Location, args.ProjectID),
+			Effect:  fmt.Sprintf("The control plane of cluster %s is upgraded to %s. The node pools keep their version, and zonal clusters lose API server availability during the upgrade.", args.Name, args.TargetVersion),
+		}).Result(), nil, nil
+	}
+	if err := checkConfirmed(args.Confirm); err != nil {
+		return nil, nil, err
+	}
+
+	op, err := h.cmClient.UpdateMaster(ctx, req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to start control plane upgrade of cluster %s: %w", args.Name, err)
+	}
+
+	return operationResult(fmt.Sprintf("Started the upgrade of the control plane of cluster %s to %s.", args.Name, args.TargetVersion), op), nil, nil
+}
+
+func (h *handlers) startNodePoolUpgrade(ctx context.Context, _ *mcp.CallToolRequest, args *startNodePoolUpgradeArgs) (*mcp.CallToolResult, any, error) {
+	if args.NodePool == "" {
+		return nil, nil, fmt.Errorf("node_pool argument cannot be empty")
+	}
+	clusterName, err := h.resolveCluster(ctx, &args.ProjectID, &args.Location, &args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !h.c.DryRun() {
+		if err := checkConfirmed(args.Confirm); err != nil {
+			return nil, nil, err
+		}
+	}
+	cluster, err := gke.GetCluster(ctx, h.cmClient, h.c, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := nodePoolUpgradeRequest(clusterName, cluster, args.NodePool, args.TargetVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	version := args.TargetVersion
+	if version == "" {
+		version = "the control plane version"
+	}
+	if h.c.DryRun() {
+		gcloud := fmt.Sprintf("gcloud container clusters upgrade %s --node-pool=%s --location=%s --project=%s", args.Name, args.NodePool, args.Location, args.ProjectID)
+		if args.TargetVersion != "" {
+			gcloud += " --cluster-version=" + args.TargetVersion
+		}
+		return (&dryrun.Plan{
+			Method:  "ClusterManager.UpdateNodePool",
+			Request: req,
+			Gcloud:  gcloud,
+			Effect:  fmt.Sprintf("The nodes of node pool %s are drained and recreated with %s, using the upgrade strategy of the node pool.", args.NodePool, version),
+		}).Result(), nil, nil
+	}
+
+	op, err := h.cmClient.UpdateNodePool(ctx, req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to start upgrade of node pool %s: %w", args.NodePool, err)
+	}
+
+	return operationResult(fmt.Sprintf("Started the upgrade of node pool %s of cluster %s to %s.", args.NodePool, args.Name, version), op), nil, nil
+}
+
+func (h *handlers) setMaintenanceExclusion(ctx context.Context, _ *mcp.CallToolRequest, args *setMaintenanceExclusionArgs) (*mcp.CallToolResult, any, error) {
+	clusterName, err := h.resolveCluster(ctx, &args.ProjectID, &args.Location, &args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !h.c.DryRun() {
+		if err := checkConfirmed(args.Confirm); err != nil {
+			return nil, nil, err
+		}
+	}
+	cluster, err := gke.GetCluster(ctx, h.cmClient, h.c, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	policy, err := maintenancePolicyWithExclusion(cluster.GetMaintenancePolicy(), args)
+	if err != nil {
+		return nil, nil, err
+	}
+	req := &containerpb.SetMaintenancePolicyRequest{
+		Name:              clusterName,
+		MaintenancePolicy: policy,
+	}
+
+	if h.c.DryRun() {
+		return (&dryrun.Plan{
+			Method:  "ClusterManager.SetMaintenancePolicy",
+			Request: req,
+			Gcloud: fmt.Sprintf("gcloud container clusters update %s --add-maintenance-exclusion-name=%s --add-maintenance-exclusion-start=%s --add-maintenance-exclusion-end=%s --add-maintenance-exclusion-scope=%s --location=%s --project=%s",
+				args.Name, args.ExclusionName, args.StartTime, args.EndTime, exclusionScopeName(args.Scope), args.Location, args.ProjectID),
+			Effect: fmt.Sprintf("GKE does not start %s of cluster %s from %s to %s. The maintenance window and the other exclusions are kept.", exclusionScopeEffects[exclusionScopeName(args.Scope)], args.Name, args.StartTime, args.EndTime),
+		}).Result(), nil, nil
+	}
+
+	op, err := h.cmClient.SetMaintenancePolicy(ctx, req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to set maintenance exclusion of cluster %s: %w", args.Name, err)
+	}
+
+	return operationResult(fmt.Sprintf("Set maintenance exclusion %s of cluster %s from %s to %s.", args.ExclusionName, args.Name, args.StartTime, args.EndTime), op), nil, nil
+}
+
+// nodePoolUpgradeRequest returns the request upgrading a node pool of the
+// cluster, keeping its image type. An empty version selects the control plane version.
+func nodePoolUpgradeRequest(clusterName string, cluster *containerpb.Cluster, nodePool, version string) (*containerpb.UpdateNodePoolRequest, error) {
+	i := slices.IndexFunc(cluster.GetNodePools(), func(pool *containerpb.NodePool) bool {
+		return pool.GetName() == nodePool
+	})
+	if i < 0 {
+		return nil, fmt.Errorf("node pool %s not found in cluster %s", nodePool, cluster.GetName())
+	}
+	if version == "" {
+		version = "-"
+	}
+	return &containerpb.UpdateNodePoolRequest{
+		Name:        fmt.Sprintf("%s/nodePools/%s", clusterName, nodePool),
+		NodeVersion: version,
+		ImageType:   cluster.GetNodePools()[i].GetConfig().GetImageType(),
+	}, nil
+}
+
+// exclusionScopeName returns the name of a maintenance exclusion scope, defaulting to no_upgrades.
+func exclusionScopeName(scope string) string {
+	if scope == "" {
+		return "no_upgrades"
+	}
+	return strings.ToLower(scope)
+}
+
+// maintenancePolicyWithExclusion returns a copy of the maintenance policy of
+// the cluster with the exclusion added, keeping its resource version so that
+// concurrent policy changes are not overwritten.
+func maintenancePolicyWithExclusion(current *containerpb.MaintenancePolicy, args *setMaintenanceExclusionArgs) (*containerpb.MaintenancePolicy, error) {
+	if args.ExclusionName == "" {
+		return nil, fmt.Errorf("exclusion_name argument cannot be empty")
+	}
+	start, err := time.Parse(time.RFC3339, args.StartTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start_time: %w", err)
+	}
+	end, err := time.Parse(time.RFC3339, args.EndTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end_time: %w", err)
+	}
+	if !end.After(start) {
+		return nil, fmt.Errorf("end_time must be after start_time")
+	}
+	scope, ok := exclusionScopes[exclusionScopeName(args.Scope)]
+	if !ok {
+		return nil, fmt.Errorf("invalid scope %q, use no_upgrades, no_minor_upgrades or no_minor_or_node_upgrades", args.Scope)
+	}
+
+	window := &containerpb.MaintenanceWindow{
+		Policy:                current.GetWindow().GetPolicy(),
+		MaintenanceExclusions: map[string]*containerpb.TimeWindow{},
+	}
+	maps.Copy(window.MaintenanceExclusions, current.GetWindow().GetMaintenanceExclusions())
+	window.MaintenanceExclusions[args.ExclusionName] = &containerpb.TimeWindow{
+		StartTime: timestamppb.New(start),
+		EndTime:   timestamppb.New(end),
+		Options: &containerpb.TimeWindow_MaintenanceExclusionOptions{
+			MaintenanceExclusionOptions: &containerpb.MaintenanceExclusionOptions{Scope: scope},
+		},
+	}
+	return &containerpb.MaintenancePolicy{
+		Window:          window,
+		ResourceVersion: current.GetResourceVersion(),
+	}, nil
+}
+
+func operationResult(summary string, op *containerpb.Operation) *mcp.CallToolResult {
+	var b strings.Builder
+	b.WriteString(summary + "\n")
+	fmt.Fprintf(&b, "Operation: %s (%s), status %s\n", op.GetName(), op.GetOperationType(), op.GetStatus())
+	b.WriteString("Track its progress with gcloud_readonly (operations describe).\n")
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: b.String()},
+		},
+	}
+}
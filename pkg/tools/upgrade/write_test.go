@@ -0,0 +1,143 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrade
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestCheckConfirmed(t *testing.T) {
+	h := &handlers{c: &config.Config{}}
+	if _, _, err := h.startControlPlaneUpgrade(context.Background(), nil, &startControlPlaneUpgradeArgs{ProjectID: "my-project", Location: "us-central1", Name: "my-cluster", TargetVersion: "1.33"}); err == nil {
+		t.Errorf("startControlPlaneUpgrade() without confirm returned no error")
+	}
+	if _, _, err := h.startNodePoolUpgrade(context.Background(), nil, &startNodePoolUpgradeArgs{ProjectID: "my-project", Location: "us-central1", Name: "my-cluster", NodePool: "pool-1"}); err == nil {
+		t.Errorf("startNodePoolUpgrade() without confirm returned no error")
+	}
+	if _, _, err := h.setMaintenanceExclusion(context.Background(), nil, &setMaintenanceExclusionArgs{ProjectID: "my-project", Location: "us-central1", Name: "my-cluster", ExclusionName: "freeze"}); err == nil {
+		t.Errorf("setMaintenanceExclusion() without confirm returned no error")
+	}
+}
+
+func TestStartControlPlaneUpgrade_DryRun(t *testing.T) {
+	h := &handlers{c: config.New("test", config.WithDryRun(true))}
+	res, _, err := h.startControlPlaneUpgrade(context.Background(), nil, &startControlPlaneUpgradeArgs{ProjectID: "my-project", Location: "us-central1", Name: "my-cluster", TargetVersion: "1.33"})
+	if err != nil {
+		t.Fatalf("startControlPlaneUpgrade() error = %v", err)
+	}
+	got := res.Content[0].(*mcp.TextContent).Text
+	for _, want := range []string{
+		"Dry run: no change was made.",
+		"API call: ClusterManager.UpdateMaster",
+		"projects/my-project/locations/us-central1/clusters/my-cluster",
+		"gcloud container clusters upgrade my-cluster --master --cluster-version=1.33 --location=us-central1 --project=my-project",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("startControlPlaneUpgrade() = %q, want it to contain %q", got, want)
+		}
+	}
+}
+
+func TestNodePoolUpgradeRequest(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		Name: "my-cluster",
+		NodePools: []*containerpb.NodePool{
+			{Name: "pool-1", Config: &containerpb.NodeConfig{ImageType: "COS_CONTAINERD"}},
+		},
+	}
+	clusterName := "projects/my-project/locations/us-central1/clusters/my-cluster"
+
+	req, err := nodePoolUpgradeRequest(clusterName, cluster, "pool-1", "")
+	if err != nil {
+		t.Fatalf("nodePoolUpgradeRequest() error = %v", err)
+	}
+	if want := clusterName + "/nodePools/pool-1"; req.GetName() != want {
+		t.Errorf("Name = %q, want %q", req.GetName(), want)
+	}
+	if req.GetNodeVersion() != "-" {
+		t.Errorf("NodeVersion = %q, want - for the control plane version", req.GetNodeVersion())
+	}
+	if req.GetImageType() != "COS_CONTAINERD" {
+		t.Errorf("ImageType = %q, want COS_CONTAINERD", req.GetImageType())
+	}
+
+	if req, _ := nodePoolUpgradeRequest(clusterName, cluster, "pool-1", "1.33.2-gke.1240000"); req.GetNodeVersion() != "1.33.2-gke.1240000" {
+		t.Errorf("NodeVersion = %q, want 1.33.2-gke.1240000", req.GetNodeVersion())
+	}
+	if _, err := nodePoolUpgradeRequest(clusterName, cluster, "pool-2", ""); err == nil {
+		t.Errorf("nodePoolUpgradeRequest() for a missing node pool returned no error")
+	}
+}
+
+func TestMaintenancePolicyWithExclusion(t *testing.T) {
+	current := &containerpb.MaintenancePolicy{
+		Window: &containerpb.MaintenanceWindow{
+			Policy: &containerpb.MaintenanceWindow_DailyMaintenanceWindow{
+				DailyMaintenanceWindow: &containerpb.DailyMaintenanceWindow{StartTime: "03:00"},
+			},
+			MaintenanceExclusions: map[string]*containerpb.TimeWindow{
+				"holidays": {},
+			},
+		},
+		ResourceVersion: "abc123",
+	}
+
+	policy, err := maintenancePolicyWithExclusion(current, &setMaintenanceExclusionArgs{
+		ExclusionName: "freeze",
+		StartTime:     "2025-12-01T00:00:00Z",
+		EndTime:       "2025-12-31T00:00:00Z",
+		Scope:         "no_minor_upgrades",
+	})
+	if err != nil {
+		t.Fatalf("maintenancePolicyWithExclusion() error = %v", err)
+	}
+	if policy.GetResourceVersion() != "abc123" {
+		t.Errorf("ResourceVersion = %q, want abc123", policy.GetResourceVersion())
+	}
+	if policy.GetWindow().GetDailyMaintenanceWindow().GetStartTime() != "03:00" {
+		t.Errorf("daily maintenance window was not kept")
+	}
+	exclusions := policy.GetWindow().GetMaintenanceExclusions()
+	if _, ok := exclusions["holidays"]; !ok {
+		t.Errorf("existing exclusion holidays was not kept")
+	}
+	freeze := exclusions["freeze"]
+	if freeze.GetMaintenanceExclusionOptions().GetScope() != containerpb.MaintenanceExclusionOptions_NO_MINOR_UPGRADES {
+		t.Errorf("Scope = %v, want NO_MINOR_UPGRADES", freeze.GetMaintenanceExclusionOptions().GetScope())
+	}
+	if got := freeze.GetEndTime().AsTime().Format("2006-01-02"); got != "2025-12-31" {
+		t.Errorf("EndTime = %s, want 2025-12-31", got)
+	}
+	if len(current.GetWindow().GetMaintenanceExclusions()) != 1 {
+		t.Errorf("current maintenance policy was modified")
+	}
+
+	for _, args := range []*setMaintenanceExclusionArgs{
+		{StartTime: "2025-12-01T00:00:00Z", EndTime: "2025-12-31T00:00:00Z"},
+		{ExclusionName: "freeze", StartTime: "December 1", EndTime: "2025-12-31T00:00:00Z"},
+		{ExclusionName: "freeze", StartTime: "2025-12-31T00:00:00Z", EndTime: "2025-12-01T00:00:00Z"},
+		{ExclusionName: "freeze", StartTime: "2025-12-01T00:00:00Z", EndTime: "2025-12-31T00:00:00Z", Scope: "no_patches"},
+	} {
+		if _, err := maintenancePolicyWithExclusion(current, args); err == nil {
+			t.Errorf("maintenancePolicyWithExclusion(%+v) returned no error", args)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/progress"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"sigs.k8s.io/yaml"
+)
+
+// lastAppliedAnnotation holds the manifest last applied with kubectl apply, including its apiVersion.
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+var manifestSeparatorRegexp = regexp.MustCompile(`(?m)^---`)
+
+type findDeprecatedAPIsArgs struct {
+	ProjectID           string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location            string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name                string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+	TargetVersion       string `json:"target_version" jsonschema:"Target GKE or Kubernetes version of the upgrade, e.g. 1.33 or 1.33.2-gke.1240000."`
+	IncludeHelmReleases bool   `json:"include_helm_releases,omitempty" jsonschema:"Also read the deployed Helm releases, stored in Secrets which include their chart values. Only set it if the user explicitly allows reading the Helm release Secrets."`
+}
+
+// manifestObject is an object of a manifest applied to the cluster.
+type manifestObject struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"metadata"`
+	// source describes where the manifest comes from.
+	source string
+}
+
+// apiFinding is an object using an API removed in the target version range.
+type apiFinding struct {
+	object manifestObject
+	api    kube.DeprecatedAPI
+}
+
+func (h *handlers) findDeprecatedAPIs(ctx context.Context, _ *mcp.CallToolRequest, args *findDeprecatedAPIsArgs) (*mcp.CallToolResult, any, error) {
+	if args.TargetVersion == "" {
+		return nil, nil, fmt.Errorf("target_version argument cannot be empty")
+	}
+	cluster, err := gke.GetCluster(ctx, h.cmClient, h.c, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, currentMinor, err := gke.MinorVersion(cluster.GetCurrentMasterVersion())
+	if err != nil {
+		return nil, nil, err
+	}
+	_, targetMinor, err := gke.MinorVersion(args.TargetVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+	removed := kube.RemovedAPIs(currentMinor, targetMinor)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cluster: %s, upgrade from %s to %s\n", cluster.GetName(), cluster.GetCurrentMasterVersion(), args.TargetVersion)
+	if len(removed) == 0 {
+		b.WriteString("No Kubernetes APIs are removed in this version range.\n")
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: b.String()},
+			},
+		}, nil, nil
+	}
+
+	var resources []string
+	for _, api := range removed {
+		if !slices.Contains(resources, api.Resource) {
+			resources = append(resources, api.Resource)
+		}
+	}
+
+	// Listing every resource of a large cluster can take a while.
+	var objects []manifestObject
+	steps := len(resources)
+	if args.IncludeHelmReleases {
+		steps++
+	}
+	for i, resource := range resources {
+		progress.Report(ctx, i, steps, fmt.Sprintf("Listing %s", resource))
+		var list kube.List[kube.Object]
+		if err := kube.Get(ctx, &list, resource, "--all-namespaces"); err != nil {
+			fmt.Fprintf(&b, "Skipped %s: %v\n", resource, err)
+			continue
+		}
+		objects = append(objects, lastAppliedObjects(list.Items)...)
+	}
+
+	if args.IncludeHelmReleases {
+		progress.Report(ctx, len(resources), steps, "Reading Helm releases")
+		var releases kube.List[kube.Secret]
+		if err := kube.Get(ctx, &releases, "secrets", "--all-namespaces", "-l", "owner=helm,status=deployed"); err != nil {
+			fmt.Fprintf(&b, "Skipped Helm releases: %v\n", err)
+		}
+		for _, secret := range releases.Items {
+			releaseObjects, err := helmReleaseObjects(secret)
+			if err != nil {
+				fmt.Fprintf(&b, "Skipped Helm release %s/%s: %v\n", secret.Namespace, secret.Name, err)
+				continue
+			}
+			objects = append(objects, releaseObjects...)
+		}
+	} else {
+		b.WriteString("Helm releases were not read, set include_helm_releases to read them from their Secrets, or use find_deprecated_api_calls for the calls to deprecated APIs in the audit logs.\n")
+	}
+
+	b.WriteString(deprecatedAPIsReport(removed, findDeprecated(removed, objects)))
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: b.String()},
+		},
+	}, nil, nil
+}
+
+// lastAppliedObjects returns the manifests last applied with kubectl apply to
+// the objects. Live objects are always returned in the preferred API version,
+// so the applied manifest is the only place where the used apiVersion is kept.
+func lastAppliedObjects(objects []kube.Object) []manifestObject {
+	var manifests []manifestObject
+	for _, o := range objects {
+		lastApplied := o.Annotations[lastAppliedAnnotation]
+		if lastApplied == "" {
+			continue
+		}
+		var m manifestObject
+		if err := json.Unmarshal([]byte(lastApplied), &m); err != nil {
+			continue
+		}
+		m.Metadata.Name = o.Name
+		m.Metadata.Namespace = o.Namespace
+		m.source = "kubectl apply"
+		manifests = append(manifests, m)
+	}
+	return manifests
+}
+
+// helmReleaseObjects decodes the manifest of a Helm 3 release secret.
+func helmReleaseObjects(secret kube.Secret) ([]manifestObject, error) {
+	data, err := base64.StdEncoding.DecodeString(string(secret.Data["release"]))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode release: %w", err)
+	}
+	if bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress release: %w", err)
+		}
+		if data, err = io.ReadAll(r); err != nil {
+			return nil, fmt.Errorf("failed to decompress release: %w", err)
+		}
+	}
+	var release struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+		Manifest  string `json:"manifest"`
+	}
+	if err := json.Unmarshal(data, &release); err != nil {
+		return nil, fmt.Errorf("failed to parse release: %w", err)
+	}
+
+	var objects []manifestObject
+	for _, doc := range manifestSeparatorRegexp.Split(release.Manifest, -1) {
+		var m manifestObject
+		if err := yaml.Unmarshal([]byte(doc), &m); err != nil || m.Kind == "" {
+			continue
+		}
+		if m.Metadata.Namespace == "" {
+			m.Metadata.Namespace = release.Namespace
+		}
+		m.source = "Helm release " + release.Name
+		objects = append(objects, m)
+	}
+	return objects, nil
+}
+
+func findDeprecated(removed []kube.DeprecatedAPI, objects []manifestObject) []apiFinding {
+	var findings []apiFinding
+	for _, o := range objects {
+		for _, api := range removed {
+			if o.APIVersion == api.APIVersion && o.Kind == api.Kind {
+				findings = append(findings, apiFinding{object: o, api: api})
+			}
+		}
+	}
+	return findings
+}
+
+func deprecatedAPIsReport(removed []kube.DeprecatedAPI, findings []apiFinding) string {
+	var b strings.Builder
+	b.WriteString("\nAPIs removed in this version range:\n")
+	for _, api := range removed {
+		replacement := "use " + api.Replacement
+		if api.Replacement == "" {
+			replacement = "no replacement"
+		}
+		fmt.Fprintf(&b, "- %s %s (removed in 1.%d, %s)\n", api.APIVersion, api.Kind, api.RemovedIn, replacement)
+	}
+
+	if len(findings) == 0 {
+		b.WriteString("\nNo applied manifests or Helm releases use the removed APIs. Check the CI/CD pipelines and the deprecated API usage insights of the cluster for clients calling them.\n")
+		return b.String()
+	}
+	b.WriteString("\nObjects applied with removed APIs:\n\n")
+	b.WriteString("| Namespace | Name | Kind | API version | Source | Removed in | Replacement |\n")
+	b.WriteString("|---|---|---|---|---|---|---|\n")
+	for _, f := range findings {
+		replacement := f.api.Replacement
+		if replacement == "" {
+			replacement = "none"
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | 1.%d | %s |\n", f.object.Metadata.Namespace, f.object.Metadata.Name, f.object.Kind, f.object.APIVersion, f.object.source, f.api.RemovedIn, replacement)
+	}
+	return b.String()
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func helmSecret(t *testing.T, release string) kube.Secret {
+	t.Helper()
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write([]byte(release)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return kube.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "web", Name: "sh.helm.release.v1.frontend.v3"},
+		Data:       map[string][]byte{"release": []byte(base64.StdEncoding.EncodeToString(buf.Bytes()))},
+	}
+}
+
+func TestHelmReleaseObjects(t *testing.T) {
+	release := `{"name": "frontend", "namespace": "web", "manifest": "---\n# Source: frontend/templates/ingress.yaml\napiVersion: networking.k8s.io/v1beta1\nkind: Ingress\nmetadata:\n  name: frontend\n---\napiVersion: v1\nkind: Service\nmetadata:\n  name: frontend\n  namespace: other\n"}`
+
+	objects, err := helmReleaseObjects(helmSecret(t, release))
+	if err != nil {
+		t.Fatalf("helmReleaseObjects() error = %v", err)
+	}
+	if len(objects) != 2 {
+		t.Fatalf("helmReleaseObjects() returned %d objects, want 2: %+v", len(objects), objects)
+	}
+	ingress := objects[0]
+	if ingress.APIVersion != "networking.k8s.io/v1beta1" || ingress.Kind != "Ingress" || ingress.Metadata.Name != "frontend" || ingress.Metadata.Namespace != "web" || ingress.source != "Helm release frontend" {
+		t.Errorf("helmReleaseObjects()[0] = %+v", ingress)
+	}
+	if objects[1].Metadata.Namespace != "other" {
+		t.Errorf("helmReleaseObjects()[1] namespace = %q, want other", objects[1].Metadata.Namespace)
+	}
+
+	if _, err := helmReleaseObjects(kube.Secret{Data: map[string][]byte{"release": []byte("not base64!")}}); err == nil {
+		t.Error("helmReleaseObjects() expected error for an invalid release, got nil")
+	}
+}
+
+func TestFindDeprecated(t *testing.T) {
+	objects := lastAppliedObjects([]kube.Object{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "jobs", Name: "nightly", Annotations: map[string]string{
+				lastAppliedAnnotation: `{"apiVersion":"batch/v1beta1","kind":"CronJob","metadata":{"name":"nightly"}}`,
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "jobs", Name: "hourly", Annotations: map[string]string{
+				lastAppliedAnnotation: `{"apiVersion":"batch/v1","kind":"CronJob","metadata":{"name":"hourly"}}`,
+			}},
+		},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "jobs", Name: "created"}},
+	})
+	removed := kube.RemovedAPIs(24, 26)
+
+	findings := findDeprecated(removed, objects)
+	if len(findings) != 1 {
+		t.Fatalf("findDeprecated() returned %d findings, want 1: %+v", len(findings), findings)
+	}
+
+	report := deprecatedAPIsReport(removed, findings)
+	for _, want := range []string{
+		"- policy/v1beta1 PodSecurityPolicy (removed in 1.25, no replacement)",
+		"- autoscaling/v2beta2 HorizontalPodAutoscaler (removed in 1.26, use autoscaling/v2)",
+		"| jobs | nightly | CronJob | batch/v1beta1 | kubectl apply | 1.25 | batch/v1 |",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("deprecatedAPIsReport() missing %q in:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "extensions/v1beta1") {
+		t.Errorf("deprecatedAPIsReport() reports APIs removed before the current version:\n%s", report)
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// autopilotCapabilities are the capabilities Autopilot allows to add, besides
+// the capabilities of the baseline Pod Security Standards level.
+var autopilotCapabilities = []string{"NET_RAW", "SYS_PTRACE"}
+
+// autopilotNodeSelectors are the node labels Autopilot provisions nodes for.
+var autopilotNodeSelectors = []string{
+	"cloud.google.com/compute-class",
+	"cloud.google.com/gke-spot",
+	"cloud.google.com/gke-accelerator",
+	"cloud.google.com/gke-accelerator-count",
+	"cloud.google.com/gke-ephemeral-storage-local-ssd",
+	"cloud.google.com/machine-family",
+	"cloud.google.com/gke-placement-group",
+	"cloud.google.com/gke-tpu-accelerator",
+	"cloud.google.com/gke-tpu-topology",
+	"kubernetes.io/arch",
+	"kubernetes.io/os",
+	"topology.kubernetes.io/region",
+	"topology.kubernetes.io/zone",
+}
+
+// autopilotHostPathPrefix is the only host path Autopilot allows to mount,
+// read-only.
+const autopilotHostPathPrefix = "/var/log/"
+
+type checkAutopilotCompatibilityArgs struct{}
+
+// autopilotFindings are the blockers and warnings of migrating a workload to
+// Autopilot.
+type autopilotFindings struct {
+	blockers []string
+	warnings []string
+}
+
+func (h *handlers) checkAutopilotCompatibility(ctx context.Context, _ *mcp.CallToolRequest, _ *checkAutopilotCompatibilityArgs) (*mcp.CallToolResult, any, error) {
+	var pods kube.List[kube.Pod]
+	if err := kube.Get(ctx, &pods, "pods", "--all-namespaces"); err != nil {
+		return nil, nil, err
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: autopilotReport(pods.Items)},
+		},
+	}, nil, nil
+}
+
+// podAutopilotFindings returns what prevents running a pod on Autopilot, and
+// what changes when it runs there.
+func podAutopilotFindings(pod kube.Pod) autopilotFindings {
+	var f autopilotFindings
+	spec := pod.Spec
+	if spec.HostNetwork || spec.HostPID || spec.HostIPC {
+		f.blockers = append(f.blockers, "uses the host network, PID or IPC namespace")
+	}
+
+	hostPaths := map[string]string{}
+	for _, v := range spec.Volumes {
+		if v.HostPath != nil {
+			hostPaths[v.Name] = v.HostPath.Path
+		}
+	}
+	gpus, missingRequests := false, false
+	for _, c := range append(append([]kube.Container{}, spec.InitContainers...), spec.Containers...) {
+		if sc := c.SecurityContext; sc != nil {
+			if sc.Privileged != nil && *sc.Privileged {
+				f.blockers = append(f.blockers, fmt.Sprintf("container %s is privileged, only allowlisted partner workloads can be on Autopilot", c.Name))
+			}
+			if sc.Capabilities != nil {
+				for _, capability := range sc.Capabilities.Add {
+					name := strings.TrimPrefix(capability, "CAP_")
+					if !slices.Contains(baselineCapabilities, name) && !slices.Contains(autopilotCapabilities, name) {
+						f.blockers = append(f.blockers, fmt.Sprintf("container %s adds capability %s", c.Name, capability))
+					}
+				}
+			}
+		}
+		for _, m := range c.VolumeMounts {
+			path, ok := hostPaths[m.Name]
+			if !ok {
+				continue
+			}
+			if !m.ReadOnly || !strings.HasPrefix(strings.TrimSuffix(path, "/")+"/", autopilotHostPathPrefix) {
+				f.blockers = append(f.blockers, fmt.Sprintf("container %s mounts hostPath %s, Autopilot only allows reading %s", c.Name, path, autopilotHostPathPrefix))
+			}
+		}
+		for _, p := range c.Ports {
+			if p.HostPort != 0 {
+				f.warnings = append(f.warnings, fmt.Sprintf("container %s uses hostPort %d, which limits the pods to one per node", c.Name, p.HostPort))
+			}
+		}
+		if _, ok := c.Resources.Requests["nvidia.com/gpu"]; ok {
+			gpus = true
+		} else if _, ok := c.Resources.Limits["nvidia.com/gpu"]; ok {
+			gpus = true
+		}
+		if _, ok := c.Resources.Requests["cpu"]; !ok {
+			missingRequests = true
+		} else if _, ok := c.Resources.Requests["memory"]; !ok {
+			missingRequests = true
+		}
+	}
+	if gpus && spec.NodeSelector["cloud.google.com/gke-accelerator"] == "" {
+		f.warnings = append(f.warnings, "requests GPUs without a cloud.google.com/gke-accelerator node selector, which Autopilot needs to choose the GPU type")
+	}
+	if missingRequests {
+		f.warnings = append(f.warnings, "has containers without CPU or memory requests, Autopilot sets default requests and bills them")
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(spec.NodeSelector)) {
+		switch {
+		case key == gke.NodePoolLabel:
+			f.blockers = append(f.blockers, fmt.Sprintf("selects node pool %s, Autopilot manages the nodes", spec.NodeSelector[key]))
+		case !slices.Contains(autopilotNodeSelectors, key):
+			f.warnings = append(f.warnings, fmt.Sprintf("selects nodes labeled %s, which Autopilot only provisions for workload separation, with a toleration of a matching taint", key))
+		}
+	}
+
+	if owner := controller(pod); owner != nil && owner.Kind == "DaemonSet" {
+		f.warnings = append(f.warnings, "DaemonSet, its pods run on every Autopilot node and are billed for each of them")
+	}
+	return f
+}
+
+func autopilotReport(pods []kube.Pod) string {
+	findings := map[string]autopilotFindings{}
+	for _, pod := range pods {
+		if isGKEManagedNamespace(pod.Namespace) || pod.Status.Phase == "Succeeded" || pod.Status.Phase == "Failed" {
+			continue
+		}
+		if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+			continue
+		}
+		name := workloadName(pod)
+		if _, seen := findings[name]; seen {
+			continue
+		}
+		findings[name] = podAutopilotFindings(pod)
+	}
+
+	var blocked, warned []string
+	for _, name := range slices.Sorted(maps.Keys(findings)) {
+		if f := findings[name]; len(f.blockers) > 0 {
+			blocked = append(blocked, name)
+		} else if len(f.warnings) > 0 {
+			warned = append(warned, name)
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Workloads analyzed: %d, outside of the namespaces managed by GKE\n", len(findings))
+	fmt.Fprintf(&b, "Workloads with blockers: %d\n", len(blocked))
+	fmt.Fprintf(&b, "Workloads needing changes or review: %d\n", len(warned))
+
+	writeFindings := func(title string, names []string) {
+		if len(names) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n%s:\n", title)
+		for _, name := range names {
+			f := findings[name]
+			fmt.Fprintf(&b, "- %s:\n", name)
+			for _, blocker := range f.blockers {
+				fmt.Fprintf(&b, "  - BLOCKER: %s\n", blocker)
+			}
+			for _, warning := range f.warnings {
+				fmt.Fprintf(&b, "  - %s\n", warning)
+			}
+		}
+	}
+	writeFindings("Blocked workloads", blocked)
+	writeFindings("Workloads to review", warned)
+	if len(blocked) == 0 && len(warned) == 0 {
+		b.WriteString("\nAll workloads are compatible with Autopilot as they are.\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func requests(cpu, memory string) kube.ResourceRequirements {
+	return kube.ResourceRequirements{Requests: map[string]resource.Quantity{
+		"cpu":    resource.MustParse(cpu),
+		"memory": resource.MustParse(memory),
+	}}
+}
+
+func TestPodAutopilotFindings(t *testing.T) {
+	tests := []struct {
+		name string
+		pod  kube.Pod
+		want autopilotFindings
+	}{
+		{
+			name: "compatible pod",
+			pod: kube.Pod{
+				ObjectMeta: metav1.ObjectMeta{OwnerReferences: ownedBy("ReplicaSet")},
+				Spec: kube.PodSpec{
+					NodeSelector: map[string]string{"kubernetes.io/arch": "arm64"},
+					Containers:   []kube.Container{{Name: "app", Resources: requests("500m", "1Gi")}},
+				},
+			},
+		},
+		{
+			name: "privileged agent",
+			pod: kube.Pod{
+				ObjectMeta: metav1.ObjectMeta{OwnerReferences: ownedBy("DaemonSet")},
+				Spec: kube.PodSpec{
+					HostNetwork: true,
+					Volumes: []kube.Volume{
+						{Name: "logs", HostPath: &kube.HostPathVolumeSource{Path: "/var/log"}},
+						{Name: "proc", HostPath: &kube.HostPathVolumeSource{Path: "/proc"}},
+					},
+					Containers: []kube.Container{{
+						Name:      "agent",
+						Resources: requests("100m", "128Mi"),
+						SecurityContext: &kube.SecurityContext{
+							Privileged:   ptr.To(true),
+							Capabilities: &kube.Capabilities{Add: []string{"NET_RAW", "SYS_ADMIN"}},
+						},
+						VolumeMounts: []kube.VolumeMount{
+							{Name: "logs", MountPath: "/host/logs", ReadOnly: true},
+							{Name: "proc", MountPath: "/host/proc", ReadOnly: true},
+						},
+					}},
+				},
+			},
+			want: autopilotFindings{
+				blockers: []string{
+					"uses the host network, PID or IPC namespace",
+					"container agent is privileged, only allowlisted partner workloads can be on Autopilot",
+					"container agent adds capability SYS_ADMIN",
+					"container agent mounts hostPath /proc, Autopilot only allows reading /var/log/",
+				},
+				warnings: []string{"DaemonSet, its pods run on every Autopilot node and are billed for each of them"},
+			},
+		},
+		{
+			name: "gpu workload pinned to a node pool",
+			pod: kube.Pod{
+				ObjectMeta: metav1.ObjectMeta{OwnerReferences: ownedBy("Job")},
+				Spec: kube.PodSpec{
+					NodeSelector: map[string]string{"cloud.google.com/gke-nodepool": "gpu-pool", "team": "ml"},
+					Containers: []kube.Container{{
+						Name: "train",
+						Resources: kube.ResourceRequirements{Limits: map[string]resource.Quantity{
+							"nvidia.com/gpu": resource.MustParse("1"),
+						}},
+						Ports: []kube.ContainerPort{{ContainerPort: 8080, HostPort: 8080}},
+					}},
+				},
+			},
+			want: autopilotFindings{
+				blockers: []string{"selects node pool gpu-pool, Autopilot manages the nodes"},
+				warnings: []string{
+					"container train uses hostPort 8080, which limits the pods to one per node",
+					"requests GPUs without a cloud.google.com/gke-accelerator node selector, which Autopilot needs to choose the GPU type",
+					"has containers without CPU or memory requests, Autopilot sets default requests and bills them",
+					"selects nodes labeled team, which Autopilot only provisions for workload separation, with a toleration of a matching taint",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := podAutopilotFindings(tt.pod)
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(autopilotFindings{})); diff != "" {
+				t.Errorf("podAutopilotFindings() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAutopilotReport(t *testing.T) {
+	pods := []kube.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "kube-proxy", OwnerReferences: ownedBy("DaemonSet")},
+			Spec:       kube.PodSpec{HostNetwork: true},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-1", OwnerReferences: ownedBy("ReplicaSet")},
+			Spec:       kube.PodSpec{Containers: []kube.Container{{Name: "web", Resources: requests("1", "1Gi")}}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "monitoring", Name: "node-exporter-1", OwnerReferences: ownedBy("DaemonSet")},
+			Spec:       kube.PodSpec{HostPID: true, Containers: []kube.Container{{Name: "exporter", Resources: requests("10m", "32Mi")}}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "debug"},
+			Spec:       kube.PodSpec{Containers: []kube.Container{{Name: "shell"}}},
+		},
+	}
+
+	got := autopilotReport(pods)
+	for _, want := range []string{
+		"Workloads analyzed: 3",
+		"Workloads with blockers: 1",
+		"Workloads needing changes or review: 1",
+		"- monitoring/DaemonSet owner:\n  - BLOCKER: uses the host network, PID or IPC namespace",
+		"- default/Pod debug:\n  - has containers without CPU or memory requests",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("autopilotReport() = %q, want it to contain %q", got, want)
+		}
+	}
+	if strings.Contains(got, "kube-system") {
+		t.Errorf("autopilotReport() = %q, want the namespaces managed by GKE skipped", got)
+	}
+}
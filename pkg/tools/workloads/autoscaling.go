@@ -0,0 +1,287 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/gkereleasenotes"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// autoscalingMilestone is a change of autoscaling behavior introduced in a Kubernetes minor version.
+type autoscalingMilestone struct {
+	minor       int
+	description string
+}
+
+// autoscalingMilestones are autoscaling changes which can silently alter the
+// scaling of existing workloads.
+var autoscalingMilestones = []autoscalingMilestone{
+	{minor: 25, description: "autoscaling/v2beta1 HorizontalPodAutoscaler is removed, manifests and clients must use autoscaling/v2."},
+	{minor: 26, description: "autoscaling/v2beta2 HorizontalPodAutoscaler is removed, manifests and clients must use autoscaling/v2."},
+	{minor: 27, description: "ContainerResource metrics are enabled by default. HorizontalPodAutoscalers using them, which were ignored before, start scaling on the usage of a single container."},
+	{minor: 33, description: "In-place pod resize is enabled by default. VerticalPodAutoscalers in InPlaceOrRecreate mode resize running pods instead of evicting them."},
+}
+
+var autoscalingReleaseNoteKeywords = []string{
+	"horizontal pod autoscal",
+	"horizontalpodautoscaler",
+	"vertical pod autoscal",
+	"verticalpodautoscaler",
+	"metrics-server",
+	"custom metrics",
+}
+
+// metricsAPIGroups are the aggregated API groups serving each HorizontalPodAutoscaler metric type.
+var metricsAPIGroups = map[string]string{
+	"Resource":          "metrics.k8s.io",
+	"ContainerResource": "metrics.k8s.io",
+	"Pods":              "custom.metrics.k8s.io",
+	"Object":            "custom.metrics.k8s.io",
+	"External":          "external.metrics.k8s.io",
+}
+
+type auditAutoscalingArgs struct {
+	ProjectID     string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location      string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name          string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+	TargetVersion string `json:"target_version" jsonschema:"Target GKE or Kubernetes version of the upgrade, e.g. 1.33 or 1.33.2-gke.1240000."`
+}
+
+func (h *handlers) auditAutoscaling(ctx context.Context, _ *mcp.CallToolRequest, args *auditAutoscalingArgs) (*mcp.CallToolResult, any, error) {
+	if args.TargetVersion == "" {
+		return nil, nil, fmt.Errorf("target_version argument cannot be empty")
+	}
+	cluster, err := gke.GetCluster(ctx, h.cmClient, h.c, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	releaseNoteEntries, err := gkereleasenotes.UpgradeEntries(ctx, cluster.GetCurrentMasterVersion(), args.TargetVersion, autoscalingReleaseNoteKeywords)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var hpas kube.List[kube.HorizontalPodAutoscaler]
+	if err := kube.Get(ctx, &hpas, "horizontalpodautoscalers", "--all-namespaces"); err != nil {
+		return nil, nil, err
+	}
+	var vpas kube.List[kube.VerticalPodAutoscaler]
+	vpasErr := kube.Get(ctx, &vpas, "verticalpodautoscalers.autoscaling.k8s.io", "--all-namespaces")
+	var apiServices kube.List[kube.APIService]
+	apiServicesErr := kube.Get(ctx, &apiServices, "apiservices")
+
+	report, err := autoscalingReport(cluster, args.TargetVersion, hpas.Items, vpas.Items, apiServices.Items, releaseNoteEntries)
+	if err != nil {
+		return nil, nil, err
+	}
+	if vpasErr != nil {
+		report += fmt.Sprintf("\nVerticalPodAutoscalers were not analyzed: %v\n", vpasErr)
+	}
+	if apiServicesErr != nil {
+		report += fmt.Sprintf("\nAvailability of the metrics APIs was not analyzed: %v\n", apiServicesErr)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: report},
+		},
+	}, nil, nil
+}
+
+// availableAPIGroups returns the aggregated API groups served by an available APIService.
+func availableAPIGroups(apiServices []kube.APIService) map[string]bool {
+	groups := map[string]bool{}
+	for _, s := range apiServices {
+		_, group, ok := strings.Cut(s.Name, ".")
+		if !ok {
+			continue
+		}
+		for _, c := range s.Status.Conditions {
+			if c.Type == "Available" && c.Status == "True" {
+				groups[group] = true
+			}
+		}
+	}
+	return groups
+}
+
+// describeMetric returns a short description of an HorizontalPodAutoscaler metric.
+func describeMetric(m kube.MetricSpec) string {
+	switch {
+	case m.Resource != nil:
+		return fmt.Sprintf("%s %s", m.Type, m.Resource.Name)
+	case m.ContainerResource != nil:
+		return fmt.Sprintf("%s %s of container %s", m.Type, m.ContainerResource.Name, m.ContainerResource.Container)
+	case m.Pods != nil:
+		return fmt.Sprintf("%s %s", m.Type, m.Pods.Metric.Name)
+	case m.Object != nil:
+		return fmt.Sprintf("%s %s", m.Type, m.Object.Metric.Name)
+	case m.External != nil:
+		return fmt.Sprintf("%s %s", m.Type, m.External.Metric.Name)
+	}
+	return m.Type
+}
+
+// scalesOnResources reports whether the HorizontalPodAutoscaler scales on CPU or memory usage.
+func scalesOnResources(hpa kube.HorizontalPodAutoscaler) bool {
+	if len(hpa.Spec.Metrics) == 0 {
+		// Without metrics, the HorizontalPodAutoscaler scales on CPU usage.
+		return true
+	}
+	return slices.ContainsFunc(hpa.Spec.Metrics, func(m kube.MetricSpec) bool {
+		return m.Type == "Resource" || m.Type == "ContainerResource"
+	})
+}
+
+// evictsPods reports whether the VerticalPodAutoscaler changes the resources of running pods.
+func evictsPods(vpa kube.VerticalPodAutoscaler) bool {
+	if vpa.Spec.UpdatePolicy == nil || vpa.Spec.UpdatePolicy.UpdateMode == "" {
+		return true
+	}
+	return vpa.Spec.UpdatePolicy.UpdateMode != "Off" && vpa.Spec.UpdatePolicy.UpdateMode != "Initial"
+}
+
+// hpaIssues returns the configuration problems of a HorizontalPodAutoscaler.
+// Availability of the metrics APIs is only checked when groups is not nil.
+func hpaIssues(hpa kube.HorizontalPodAutoscaler, vpas []kube.VerticalPodAutoscaler, groups map[string]bool, currentMinor int) []string {
+	var issues []string
+	if lastApplied := hpa.Annotations[lastAppliedAnnotation]; lastApplied != "" {
+		var m manifestObject
+		if err := json.Unmarshal([]byte(lastApplied), &m); err == nil {
+			for _, api := range kube.DeprecatedAPIs {
+				if api.Kind == "HorizontalPodAutoscaler" && api.APIVersion == m.APIVersion {
+					issues = append(issues, fmt.Sprintf("applied with %s, removed in 1.%d, use %s", api.APIVersion, api.RemovedIn, api.Replacement))
+				}
+			}
+		}
+	}
+	if hpa.Spec.MinReplicas != nil && *hpa.Spec.MinReplicas == hpa.Spec.MaxReplicas {
+		issues = append(issues, fmt.Sprintf("minReplicas equals maxReplicas (%d), the workload never scales", hpa.Spec.MaxReplicas))
+	}
+	if hpa.Status.CurrentReplicas > 0 && hpa.Status.CurrentReplicas >= hpa.Spec.MaxReplicas {
+		issues = append(issues, "running at maxReplicas, there is no headroom while nodes are recreated")
+	}
+	var missing []string
+	for _, m := range hpa.Spec.Metrics {
+		if m.Type == "ContainerResource" && currentMinor < 27 {
+			issues = append(issues, "uses ContainerResource metrics, which are ignored by the current version and take effect in 1.27")
+		}
+		group := metricsAPIGroups[m.Type]
+		if groups != nil && group != "" && !groups[group] && !slices.Contains(missing, group) {
+			missing = append(missing, group)
+		}
+	}
+	for _, group := range missing {
+		issues = append(issues, fmt.Sprintf("the %s API serving its metrics is not available", group))
+	}
+	for _, c := range hpa.Status.Conditions {
+		if (c.Type == "ScalingActive" || c.Type == "AbleToScale") && c.Status == "False" {
+			issues = append(issues, fmt.Sprintf("%s is False: %s %s", c.Type, c.Reason, c.Message))
+		}
+	}
+	if scalesOnResources(hpa) {
+		for _, vpa := range vpas {
+			ref := vpa.Spec.TargetRef
+			if vpa.Namespace == hpa.Namespace && ref != nil && ref.Kind == hpa.Spec.ScaleTargetRef.Kind && ref.Name == hpa.Spec.ScaleTargetRef.Name && evictsPods(vpa) {
+				issues = append(issues, fmt.Sprintf("VerticalPodAutoscaler %s also changes the CPU and memory requests the HorizontalPodAutoscaler scales on, the autoscalers fight each other", vpa.Name))
+			}
+		}
+	}
+	return issues
+}
+
+func autoscalingReport(cluster *containerpb.Cluster, targetVersion string, hpas []kube.HorizontalPodAutoscaler, vpas []kube.VerticalPodAutoscaler, apiServices []kube.APIService, releaseNoteEntries []string) (string, error) {
+	_, currentMinor, err := gke.MinorVersion(cluster.GetCurrentMasterVersion())
+	if err != nil {
+		return "", err
+	}
+	_, targetMinor, err := gke.MinorVersion(targetVersion)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cluster: %s, upgrade from %s to %s\n", cluster.GetName(), cluster.GetCurrentMasterVersion(), targetVersion)
+	fmt.Fprintf(&b, "Vertical Pod Autoscaling enabled: %t\n", cluster.GetVerticalPodAutoscaling().GetEnabled())
+
+	var groups map[string]bool
+	if len(apiServices) > 0 {
+		groups = availableAPIGroups(apiServices)
+	}
+
+	if len(hpas) == 0 {
+		b.WriteString("\nNo HorizontalPodAutoscalers found.\n")
+	} else {
+		b.WriteString("\nHorizontalPodAutoscalers:\n")
+		for _, hpa := range hpas {
+			var metrics []string
+			for _, m := range hpa.Spec.Metrics {
+				metrics = append(metrics, describeMetric(m))
+			}
+			if len(metrics) == 0 {
+				metrics = append(metrics, "default CPU utilization")
+			}
+			minReplicas := int32(1)
+			if hpa.Spec.MinReplicas != nil {
+				minReplicas = *hpa.Spec.MinReplicas
+			}
+			fmt.Fprintf(&b, "- %s/%s (%s %s, %d-%d replicas, currently %d): %s\n", hpa.Namespace, hpa.Name, hpa.Spec.ScaleTargetRef.Kind, hpa.Spec.ScaleTargetRef.Name, minReplicas, hpa.Spec.MaxReplicas, hpa.Status.CurrentReplicas, strings.Join(metrics, ", "))
+			for _, issue := range hpaIssues(hpa, vpas, groups, currentMinor) {
+				fmt.Fprintf(&b, "  - Warning: %s\n", issue)
+			}
+		}
+	}
+
+	if len(vpas) > 0 {
+		b.WriteString("\nVerticalPodAutoscalers:\n")
+		for _, vpa := range vpas {
+			mode := "Auto"
+			if vpa.Spec.UpdatePolicy != nil && vpa.Spec.UpdatePolicy.UpdateMode != "" {
+				mode = vpa.Spec.UpdatePolicy.UpdateMode
+			}
+			target := "no target"
+			if ref := vpa.Spec.TargetRef; ref != nil {
+				target = fmt.Sprintf("%s %s", ref.Kind, ref.Name)
+			}
+			fmt.Fprintf(&b, "- %s/%s (%s, update mode %s)\n", vpa.Namespace, vpa.Name, target, mode)
+		}
+		if !cluster.GetVerticalPodAutoscaling().GetEnabled() {
+			b.WriteString("Warning: VerticalPodAutoscalers exist but GKE Vertical Pod Autoscaling is disabled, check that a self-managed VPA installation supports the target version.\n")
+		}
+	}
+
+	var milestones []string
+	for _, m := range autoscalingMilestones {
+		if m.minor > currentMinor && m.minor <= targetMinor {
+			milestones = append(milestones, fmt.Sprintf("- 1.%d: %s", m.minor, m.description))
+		}
+	}
+	if len(milestones) > 0 {
+		b.WriteString("\nAutoscaling changes crossed by this upgrade:\n")
+		b.WriteString(strings.Join(milestones, "\n"))
+		b.WriteString("\n")
+	}
+
+	gkereleasenotes.WriteEntries(&b, "Autoscaling", cluster.GetCurrentMasterVersion(), targetVersion, releaseNoteEntries)
+	return b.String(), nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func hpa(name string, metrics ...kube.MetricSpec) kube.HorizontalPodAutoscaler {
+	h := kube.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: name}}
+	h.Spec.ScaleTargetRef = kube.CrossVersionObjectReference{Kind: "Deployment", Name: name}
+	h.Spec.MinReplicas = ptr.To[int32](2)
+	h.Spec.MaxReplicas = 10
+	h.Spec.Metrics = metrics
+	h.Status.CurrentReplicas = 3
+	return h
+}
+
+func TestHPAIssues(t *testing.T) {
+	cpu := kube.MetricSpec{Type: "Resource", Resource: &kube.ResourceMetricSource{Name: "cpu"}}
+	external := kube.MetricSpec{Type: "External", External: &kube.MetricIdentifierSource{}}
+	external.External.Metric.Name = "pubsub.googleapis.com|subscription|num_undelivered_messages"
+
+	legacy := hpa("legacy", cpu)
+	legacy.Annotations = map[string]string{lastAppliedAnnotation: `{"apiVersion":"autoscaling/v2beta2","kind":"HorizontalPodAutoscaler"}`}
+
+	pinned := hpa("pinned", cpu)
+	pinned.Spec.MinReplicas = ptr.To[int32](10)
+	pinned.Status.CurrentReplicas = 10
+
+	failing := hpa("failing", external)
+	failing.Status.Conditions = []kube.Condition{{Type: "ScalingActive", Status: "False", Reason: "FailedGetExternalMetric", Message: "unable to get external metric"}}
+
+	vpa := kube.VerticalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "web-vpa"}}
+	vpa.Spec.TargetRef = &kube.CrossVersionObjectReference{Kind: "Deployment", Name: "web"}
+	recommender := kube.VerticalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "worker-vpa"}}
+	recommender.Spec.TargetRef = &kube.CrossVersionObjectReference{Kind: "Deployment", Name: "worker"}
+	recommender.Spec.UpdatePolicy = &kube.VPAUpdatePolicy{UpdateMode: "Off"}
+	vpas := []kube.VerticalPodAutoscaler{vpa, recommender}
+
+	groups := map[string]bool{"metrics.k8s.io": true}
+	tests := []struct {
+		name string
+		hpa  kube.HorizontalPodAutoscaler
+		want []string
+	}{
+		{"healthy", hpa("healthy", cpu), nil},
+		{"legacy API", legacy, []string{"applied with autoscaling/v2beta2, removed in 1.26, use autoscaling/v2"}},
+		{"pinned", pinned, []string{"minReplicas equals maxReplicas (10)", "running at maxReplicas"}},
+		{"failing external metric", failing, []string{"external.metrics.k8s.io API serving its metrics is not available", "ScalingActive is False: FailedGetExternalMetric"}},
+		{"conflicting VPA", hpa("web"), []string{"VerticalPodAutoscaler web-vpa also changes"}},
+		{"recommendation only VPA", hpa("worker", cpu), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hpaIssues(tt.hpa, vpas, groups, 30)
+			if len(got) != len(tt.want) {
+				t.Fatalf("hpaIssues() = %q, want %d issues", got, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if !strings.Contains(got[i], want) {
+					t.Errorf("hpaIssues()[%d] = %q, want it to contain %q", i, got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestAvailableAPIGroups(t *testing.T) {
+	available := kube.APIService{ObjectMeta: metav1.ObjectMeta{Name: "v1beta1.metrics.k8s.io"}}
+	available.Status.Conditions = []kube.Condition{{Type: "Available", Status: "True"}}
+	unavailable := kube.APIService{ObjectMeta: metav1.ObjectMeta{Name: "v1beta1.custom.metrics.k8s.io"}}
+	unavailable.Status.Conditions = []kube.Condition{{Type: "Available", Status: "False", Reason: "FailedDiscoveryCheck"}}
+
+	got := availableAPIGroups([]kube.APIService{available, unavailable})
+	if !got["metrics.k8s.io"] || got["custom.metrics.k8s.io"] {
+		t.Errorf("availableAPIGroups() = %v, want only metrics.k8s.io", got)
+	}
+}
+
+func TestAutoscalingReport(t *testing.T) {
+	cluster := &containerpb.Cluster{Name: "my-cluster", CurrentMasterVersion: "1.25.16-gke.1000"}
+	containerCPU := kube.MetricSpec{Type: "ContainerResource", ContainerResource: &kube.ResourceMetricSource{Name: "cpu", Container: "app"}}
+	vpa := kube.VerticalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "batch-vpa"}}
+	vpa.Spec.TargetRef = &kube.CrossVersionObjectReference{Kind: "Deployment", Name: "batch"}
+
+	got, err := autoscalingReport(cluster, "1.27.3", []kube.HorizontalPodAutoscaler{hpa("web", containerCPU)}, []kube.VerticalPodAutoscaler{vpa}, nil, nil)
+	if err != nil {
+		t.Fatalf("autoscalingReport() error = %v", err)
+	}
+	for _, want := range []string{
+		"- app/web (Deployment web, 2-10 replicas, currently 3): ContainerResource cpu of container app",
+		"Warning: uses ContainerResource metrics, which are ignored by the current version",
+		"- app/batch-vpa (Deployment batch, update mode Auto)",
+		"GKE Vertical Pod Autoscaling is disabled",
+		"- 1.26: autoscaling/v2beta2",
+		"- 1.27: ContainerResource metrics are enabled by default",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("autoscalingReport() missing %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "1.25:") || strings.Contains(got, "1.33:") {
+		t.Errorf("autoscalingReport() reports changes outside of the upgrade range, got:\n%s", got)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultCriticalNamespaceSelector selects the namespaces running production-critical workloads.
+const defaultCriticalNamespaceSelector = "production-critical"
+
+type checkCriticalWorkloadsArgs struct {
+	NamespaceSelector string `json:"namespace_selector,omitempty" jsonschema:"Label selector of the namespaces running production-critical workloads. Leave this empty to use the production-critical label."`
+}
+
+// replicatedWorkload is a Deployment or a StatefulSet.
+type replicatedWorkload struct {
+	kind     string
+	meta     metav1.ObjectMeta
+	replicas int32
+	template kube.PodTemplateSpec
+}
+
+func (h *handlers) checkCriticalWorkloads(ctx context.Context, _ *mcp.CallToolRequest, args *checkCriticalWorkloadsArgs) (*mcp.CallToolResult, any, error) {
+	selector := args.NamespaceSelector
+	if selector == "" {
+		selector = defaultCriticalNamespaceSelector
+	}
+
+	var namespaces kube.List[kube.Namespace]
+	if err := kube.Get(ctx, &namespaces, "namespaces", "-l", selector); err != nil {
+		return nil, nil, err
+	}
+	var deployments kube.List[kube.Deployment]
+	if err := kube.Get(ctx, &deployments, "deployments", "--all-namespaces"); err != nil {
+		return nil, nil, err
+	}
+	var statefulSets kube.List[kube.StatefulSet]
+	if err := kube.Get(ctx, &statefulSets, "statefulsets", "--all-namespaces"); err != nil {
+		return nil, nil, err
+	}
+	var pdbs kube.List[kube.PodDisruptionBudget]
+	if err := kube.Get(ctx, &pdbs, "poddisruptionbudgets", "--all-namespaces"); err != nil {
+		return nil, nil, err
+	}
+
+	var workloads []replicatedWorkload
+	for _, d := range deployments.Items {
+		workloads = append(workloads, replicatedWorkload{kind: "Deployment", meta: d.ObjectMeta, replicas: replicas(d.Spec.Replicas), template: d.Spec.Template})
+	}
+	for _, s := range statefulSets.Items {
+		workloads = append(workloads, replicatedWorkload{kind: "StatefulSet", meta: s.ObjectMeta, replicas: replicas(s.Spec.Replicas), template: s.Spec.Template})
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: criticalWorkloadsReport(selector, namespaces.Items, workloads, pdbs.Items)},
+		},
+	}, nil, nil
+}
+
+// replicas returns the desired number of replicas, which defaults to 1.
+func replicas(r *int32) int32 {
+	if r == nil {
+		return 1
+	}
+	return *r
+}
+
+// criticalWorkloadIssues returns the reasons node upgrades cause downtime of the workload.
+func criticalWorkloadIssues(w replicatedWorkload, pdbs []kube.PodDisruptionBudget) []string {
+	var issues []string
+	if w.replicas == 0 {
+		return nil
+	}
+	if w.replicas == 1 {
+		issues = append(issues, "runs a single replica, it is unavailable while its pod is rescheduled")
+	}
+	pod := kube.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: w.meta.Namespace, Labels: w.template.Labels}}
+	matching := matchingPDBs(pod, pdbs)
+	if len(matching) == 0 {
+		issues = append(issues, "has no PodDisruptionBudget, node upgrades can evict all its replicas at once")
+	}
+	for _, pdb := range matching {
+		if pdb.Status.DisruptionsAllowed == 0 {
+			issues = append(issues, fmt.Sprintf("PodDisruptionBudget %s allows no disruptions, node drains are blocked until GKE force-evicts the pods after 1 hour", pdb.Name))
+		}
+	}
+	return issues
+}
+
+func criticalWorkloadsReport(selector string, namespaces []kube.Namespace, workloads []replicatedWorkload, pdbs []kube.PodDisruptionBudget) string {
+	var b strings.Builder
+	if len(namespaces) == 0 {
+		fmt.Fprintf(&b, "No namespaces match the label selector %q. Label the namespaces running production-critical workloads, or provide their label selector.\n", selector)
+		return b.String()
+	}
+
+	critical := map[string]bool{}
+	var names []string
+	for _, ns := range namespaces {
+		critical[ns.Name] = true
+		names = append(names, ns.Name)
+	}
+	fmt.Fprintf(&b, "Production-critical namespaces (%s): %s\n", selector, strings.Join(names, ", "))
+
+	var risks []string
+	for _, w := range workloads {
+		if !critical[w.meta.Namespace] {
+			continue
+		}
+		if issues := criticalWorkloadIssues(w, pdbs); len(issues) > 0 {
+			risks = append(risks, fmt.Sprintf("- %s/%s %s (%d replicas): %s", w.meta.Namespace, w.kind, w.meta.Name, w.replicas, strings.Join(issues, "; ")))
+		}
+	}
+	if len(risks) == 0 {
+		b.WriteString("\nAll Deployments and StatefulSets in these namespaces run multiple replicas protected by PodDisruptionBudgets.\n")
+		return b.String()
+	}
+	b.WriteString("\nWorkloads which incur downtime during node upgrades. Run at least 2 replicas spread across nodes and add a PodDisruptionBudget allowing at least one disruption:\n")
+	b.WriteString(strings.Join(risks, "\n"))
+	b.WriteString("\n")
+	return b.String()
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCriticalWorkloadsReport(t *testing.T) {
+	workload := func(kind, namespace, name string, replicas int32) replicatedWorkload {
+		return replicatedWorkload{
+			kind:     kind,
+			meta:     metav1.ObjectMeta{Namespace: namespace, Name: name},
+			replicas: replicas,
+			template: kube.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": name}}},
+		}
+	}
+	pdb := func(app string, disruptionsAllowed int32) kube.PodDisruptionBudget {
+		p := kube.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "payments", Name: app + "-pdb"},
+			Spec:       kube.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": app}}},
+		}
+		p.Status.DisruptionsAllowed = disruptionsAllowed
+		return p
+	}
+	namespaces := []kube.Namespace{{ObjectMeta: metav1.ObjectMeta{Name: "payments"}}}
+	workloads := []replicatedWorkload{
+		workload("Deployment", "payments", "api", 3),
+		workload("Deployment", "payments", "cron-leader", 1),
+		workload("StatefulSet", "payments", "ledger", 3),
+		workload("Deployment", "payments", "scaled-down", 0),
+		workload("Deployment", "dev", "sandbox", 1),
+	}
+	pdbs := []kube.PodDisruptionBudget{pdb("api", 1), pdb("ledger", 0)}
+
+	got := criticalWorkloadsReport("production-critical", namespaces, workloads, pdbs)
+	for _, want := range []string{
+		"Production-critical namespaces (production-critical): payments",
+		"- payments/Deployment cron-leader (1 replicas): runs a single replica, it is unavailable while its pod is rescheduled; has no PodDisruptionBudget",
+		"- payments/StatefulSet ledger (3 replicas): PodDisruptionBudget ledger-pdb allows no disruptions",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("criticalWorkloadsReport() missing %q, got:\n%s", want, got)
+		}
+	}
+	for _, unwanted := range []string{"Deployment api", "scaled-down", "sandbox"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("criticalWorkloadsReport() should not report %s, got:\n%s", unwanted, got)
+		}
+	}
+}
+
+func TestCriticalWorkloadsReport_NoNamespaces(t *testing.T) {
+	got := criticalWorkloadsReport("tier=critical", nil, nil, nil)
+	if !strings.Contains(got, `No namespaces match the label selector "tier=critical"`) {
+		t.Errorf("criticalWorkloadsReport() = %q", got)
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	konnectivityAgent = "konnectivity-agent"
+	// maxProbedNodes is the number of nodes, from different zones where
+	// possible, whose kubelet is reached through the API server.
+	maxProbedNodes = 3
+	// probeTimeout bounds each probe, so that a broken tunnel doesn't hang the tool.
+	probeTimeout = 10 * time.Second
+)
+
+// nodeProbe is the result of a request from the API server to the kubelet of a node.
+type nodeProbe struct {
+	node string
+	zone string
+	// err is empty when the kubelet answered.
+	err string
+}
+
+// tunneledWebhook is an admission webhook served by a service of the cluster.
+type tunneledWebhook struct {
+	// name is kind/configuration/webhook.
+	name          string
+	service       string
+	failurePolicy string
+}
+
+type checkControlPlaneConnectivityArgs struct{}
+
+func (h *handlers) checkControlPlaneConnectivity(ctx context.Context, _ *mcp.CallToolRequest, _ *checkControlPlaneConnectivityArgs) (*mcp.CallToolResult, any, error) {
+	var deployments kube.List[kube.Deployment]
+	if err := kube.Get(ctx, &deployments, "deployments", "--namespace", systemNamespace); err != nil {
+		return nil, nil, err
+	}
+	var pods kube.List[kube.Pod]
+	if err := kube.Get(ctx, &pods, "pods", "--namespace", systemNamespace); err != nil {
+		return nil, nil, err
+	}
+	var events kube.List[kube.Event]
+	if err := kube.Get(ctx, &events, "events", "--namespace", systemNamespace, "--field-selector=type=Warning"); err != nil {
+		return nil, nil, err
+	}
+	var nodes kube.List[kube.Node]
+	if err := kube.Get(ctx, &nodes, "nodes"); err != nil {
+		return nil, nil, err
+	}
+	var mutating, validating kube.List[kube.WebhookConfiguration]
+	if err := kube.Get(ctx, &mutating, "mutatingwebhookconfigurations"); err != nil {
+		return nil, nil, err
+	}
+	if err := kube.Get(ctx, &validating, "validatingwebhookconfigurations"); err != nil {
+		return nil, nil, err
+	}
+
+	var agent componentHealth
+	for _, health := range systemComponentsHealth(deployments.Items, nil, pods.Items, events.Items, time.Now()) {
+		if health.component.name == konnectivityAgent {
+			agent = health
+		}
+	}
+
+	var probes []nodeProbe
+	for _, node := range probedNodes(nodes.Items) {
+		probe := nodeProbe{node: node.Name, zone: node.Labels[zoneTopologyKey]}
+		probeCtx, cancel := context.WithTimeout(ctx, probeTimeout)
+		if _, err := kube.Run(probeCtx, "get", "--raw", "/api/v1/nodes/"+node.Name+"/proxy/healthz"); err != nil {
+			probe.err = err.Error()
+		}
+		cancel()
+		probes = append(probes, probe)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: connectivityReport(agent, probes, inClusterWebhooks(mutating.Items, validating.Items))},
+		},
+	}, nil, nil
+}
+
+// nodeReady reports whether the Ready condition of a node is True.
+func nodeReady(node kube.Node) bool {
+	for _, c := range node.Status.Conditions {
+		if c.Type == "Ready" {
+			return c.Status == "True"
+		}
+	}
+	return false
+}
+
+// probedNodes returns up to maxProbedNodes ready nodes, one per zone first,
+// so that a tunnel broken in a single zone is found.
+func probedNodes(nodes []kube.Node) []kube.Node {
+	var probed, others []kube.Node
+	zones := map[string]bool{}
+	for _, node := range nodes {
+		if !nodeReady(node) {
+			continue
+		}
+		zone := node.Labels[zoneTopologyKey]
+		if zones[zone] {
+			others = append(others, node)
+			continue
+		}
+		zones[zone] = true
+		probed = append(probed, node)
+	}
+	probed = append(probed, others...)
+	return probed[:min(len(probed), maxProbedNodes)]
+}
+
+// inClusterWebhooks returns the admission webhooks served by a service of the
+// cluster, which the API server calls through the konnectivity tunnel.
+func inClusterWebhooks(mutating, validating []kube.WebhookConfiguration) []tunneledWebhook {
+	var webhooks []tunneledWebhook
+	for _, kind := range []struct {
+		name    string
+		configs []kube.WebhookConfiguration
+	}{{"mutating", mutating}, {"validating", validating}} {
+		for _, config := range kind.configs {
+			for _, w := range config.Webhooks {
+				service := w.ClientConfig.Service
+				if service == nil {
+					continue
+				}
+				failurePolicy := w.FailurePolicy
+				if failurePolicy == "" {
+					failurePolicy = "Fail"
+				}
+				webhooks = append(webhooks, tunneledWebhook{
+					name:          fmt.Sprintf("%s/%s/%s", kind.name, config.Name, w.Name),
+					service:       service.Namespace + "/" + service.Name,
+					failurePolicy: failurePolicy,
+				})
+			}
+		}
+	}
+	return webhooks
+}
+
+func connectivityReport(agent componentHealth, probes []nodeProbe, webhooks []tunneledWebhook) string {
+	var b strings.Builder
+	broken := false
+	if len(agent.workloads) == 0 {
+		b.WriteString("Konnectivity agent: not found in kube-system, the probes below still test the connectivity from the control plane to the nodes.\n")
+	} else {
+		fmt.Fprintf(&b, "Konnectivity agent: %s\n", agent.status())
+		if len(agent.unhealthyPods) > 0 {
+			fmt.Fprintf(&b, "  Pods not ready: %s\n", strings.Join(agent.unhealthyPods, ", "))
+		}
+		broken = !agent.healthy()
+	}
+
+	b.WriteString("\nControl plane to node probes (API server proxy to the kubelet /healthz):\n")
+	if len(probes) == 0 {
+		b.WriteString("- no ready node to probe\n")
+	}
+	for _, probe := range probes {
+		zone := probe.zone
+		if zone == "" {
+			zone = "unknown zone"
+		}
+		if probe.err == "" {
+			fmt.Fprintf(&b, "- %s (%s): ok\n", probe.node, zone)
+			continue
+		}
+		fmt.Fprintf(&b, "- %s (%s): FAILED: %s\n", probe.node, zone, probe.err)
+		broken = true
+	}
+
+	b.WriteString("\nAdmission webhooks served in the cluster, called through the tunnel:\n")
+	if len(webhooks) == 0 {
+		b.WriteString("- none\n")
+	}
+	for _, w := range webhooks {
+		fmt.Fprintf(&b, "- %s (service %s, failurePolicy %s)\n", w.name, w.service, w.failurePolicy)
+	}
+
+	if !broken {
+		b.WriteString("\nControl plane to node connectivity works: admission webhooks, kubectl logs, exec and port-forward can reach the nodes.\n")
+		return b.String()
+	}
+	b.WriteString("\nControl plane to node connectivity is BROKEN or degraded: admission webhooks served in the cluster, kubectl logs, exec and port-forward, and the resource metrics fail. ")
+	b.WriteString("Check the konnectivity-agent pods and their logs, and that the firewall rules allow the nodes to reach the control plane with the validate_firewall_rules tool, e.g. after a change of the node network tags or of the authorized networks.\n")
+	failing := 0
+	for _, w := range webhooks {
+		if w.failurePolicy == "Fail" {
+			failing++
+		}
+	}
+	if failing > 0 {
+		fmt.Fprintf(&b, "%d webhooks with failurePolicy Fail reject the API requests they intercept while the tunnel is down.\n", failing)
+	}
+	return b.String()
+}
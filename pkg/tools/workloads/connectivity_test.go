@@ -0,0 +1,100 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestProbedNodes(t *testing.T) {
+	node := func(name, zone string, ready bool) kube.Node {
+		n := kube.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{zoneTopologyKey: zone}}}
+		status := "False"
+		if ready {
+			status = "True"
+		}
+		n.Status.Conditions = []kube.Condition{{Type: "Ready", Status: status}}
+		return n
+	}
+	nodes := []kube.Node{
+		node("a-1", "us-central1-a", true),
+		node("a-2", "us-central1-a", true),
+		node("b-1", "us-central1-b", false),
+		node("b-2", "us-central1-b", true),
+		node("c-1", "us-central1-c", true),
+	}
+
+	var got []string
+	for _, n := range probedNodes(nodes) {
+		got = append(got, n.Name)
+	}
+	if want := "a-1,b-2,c-1"; strings.Join(got, ",") != want {
+		t.Errorf("probedNodes() = %v, want %s", got, want)
+	}
+}
+
+func TestConnectivityReport(t *testing.T) {
+	mutating := []kube.WebhookConfiguration{{
+		ObjectMeta: metav1.ObjectMeta{Name: "istio-sidecar-injector"},
+		Webhooks:   []kube.Webhook{{Name: "sidecar-injector.istio.io", FailurePolicy: "Fail"}},
+	}}
+	mutating[0].Webhooks[0].ClientConfig.Service = &kube.ServiceReference{Namespace: "istio-system", Name: "istiod"}
+	url := "https://example.com/validate"
+	validating := []kube.WebhookConfiguration{{
+		ObjectMeta: metav1.ObjectMeta{Name: "external"},
+		Webhooks:   []kube.Webhook{{Name: "external.example.com"}},
+	}}
+	validating[0].Webhooks[0].ClientConfig.URL = &url
+	webhooks := inClusterWebhooks(mutating, validating)
+
+	agent := componentHealth{component: systemComponents[0], workloads: []string{konnectivityAgent}, ready: 1, desired: 2, unhealthyPods: []string{"konnectivity-agent-abc-2 (CrashLoopBackOff)"}}
+	probes := []nodeProbe{
+		{node: "a-1", zone: "us-central1-a"},
+		{node: "b-2", zone: "us-central1-b", err: "the server is currently unable to handle the request"},
+	}
+
+	got := connectivityReport(agent, probes, webhooks)
+	for _, want := range []string{
+		"Konnectivity agent: DEGRADED, 1/2 ready",
+		"  Pods not ready: konnectivity-agent-abc-2 (CrashLoopBackOff)\n",
+		"- a-1 (us-central1-a): ok\n",
+		"- b-2 (us-central1-b): FAILED: the server is currently unable to handle the request\n",
+		"- mutating/istio-sidecar-injector/sidecar-injector.istio.io (service istio-system/istiod, failurePolicy Fail)\n",
+		"Control plane to node connectivity is BROKEN",
+		"1 webhooks with failurePolicy Fail reject",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("connectivityReport() missing %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "external.example.com") {
+		t.Errorf("connectivityReport() lists a webhook served outside of the cluster, got:\n%s", got)
+	}
+}
+
+func TestConnectivityReport_Healthy(t *testing.T) {
+	agent := componentHealth{component: systemComponents[0], workloads: []string{konnectivityAgent}, ready: 2, desired: 2}
+
+	got := connectivityReport(agent, []nodeProbe{{node: "a-1"}}, nil)
+	for _, want := range []string{"- a-1 (unknown zone): ok\n", "Control plane to node connectivity works"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("connectivityReport() missing %q, got:\n%s", want, got)
+		}
+	}
+}
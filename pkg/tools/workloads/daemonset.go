@@ -0,0 +1,133 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type checkDaemonSetCompatibilityArgs struct {
+	ProjectID string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location  string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name      string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+}
+
+func (h *handlers) checkDaemonSetCompatibility(ctx context.Context, _ *mcp.CallToolRequest, args *checkDaemonSetCompatibilityArgs) (*mcp.CallToolResult, any, error) {
+	cluster, err := gke.GetCluster(ctx, h.cmClient, h.c, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	var daemonSets kube.List[kube.DaemonSet]
+	if err := kube.Get(ctx, &daemonSets, "daemonsets", "--all-namespaces"); err != nil {
+		return nil, nil, err
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: daemonSetReport(cluster.GetName(), cluster.GetNodePools(), daemonSets.Items)},
+		},
+	}, nil, nil
+}
+
+// selectsNodePool reports whether the node selector of the pod template
+// matches the labels of the nodes of the node pool.
+func selectsNodePool(spec kube.PodSpec, pool *containerpb.NodePool) bool {
+	labels := gke.NodePoolLabels(pool)
+	for k, v := range spec.NodeSelector {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// untoleratedTaints returns the scheduling taints of the node pool that the pod template does not tolerate.
+func untoleratedTaints(spec kube.PodSpec, pool *containerpb.NodePool) []kube.Taint {
+	var untolerated []kube.Taint
+	for _, taint := range gke.NodePoolTaints(pool) {
+		if taint.Effect == "PreferNoSchedule" {
+			continue
+		}
+		tolerated := false
+		for _, t := range spec.Tolerations {
+			if t.Tolerates(taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			untolerated = append(untolerated, taint)
+		}
+	}
+	return untolerated
+}
+
+func formatTaint(t kube.Taint) string {
+	if t.Value == "" {
+		return fmt.Sprintf("%s:%s", t.Key, t.Effect)
+	}
+	return fmt.Sprintf("%s=%s:%s", t.Key, t.Value, t.Effect)
+}
+
+func daemonSetReport(clusterName string, pools []*containerpb.NodePool, daemonSets []kube.DaemonSet) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cluster: %s\n", clusterName)
+	fmt.Fprintf(&b, "DaemonSets: %d\n", len(daemonSets))
+
+	var taintIssues, priorityIssues []string
+	for _, ds := range daemonSets {
+		name := ds.Namespace + "/" + ds.Name
+		spec := ds.Spec.Template.Spec
+		for _, pool := range pools {
+			if !selectsNodePool(spec, pool) {
+				continue
+			}
+			var taints []string
+			for _, t := range untoleratedTaints(spec, pool) {
+				taints = append(taints, formatTaint(t))
+			}
+			if len(taints) > 0 {
+				taintIssues = append(taintIssues, fmt.Sprintf("- %s does not tolerate %s of node pool %s", name, strings.Join(taints, ", "), pool.GetName()))
+			}
+		}
+		if spec.PriorityClassName == "" {
+			priorityIssues = append(priorityIssues, "- "+name)
+		}
+	}
+
+	if len(taintIssues) > 0 {
+		b.WriteString("\nDaemonSets whose pods will not run on the nodes of some node pools. Add tolerations if the DaemonSet, e.g. a monitoring or logging agent, must run on these nodes:\n")
+		b.WriteString(strings.Join(taintIssues, "\n"))
+		b.WriteString("\n")
+	} else {
+		b.WriteString("\nAll DaemonSets tolerate the taints of the node pools they select.\n")
+	}
+
+	if len(priorityIssues) > 0 {
+		b.WriteString("\nDaemonSets without a priorityClassName. Their pods can stay pending on upgraded nodes that are already full; set a high priority class such as system-node-critical for critical agents:\n")
+		b.WriteString(strings.Join(priorityIssues, "\n"))
+		b.WriteString("\n")
+	} else {
+		b.WriteString("\nAll DaemonSets have a priorityClassName.\n")
+	}
+	return b.String()
+}
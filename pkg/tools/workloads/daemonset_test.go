@@ -0,0 +1,101 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"strings"
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestUntoleratedTaints(t *testing.T) {
+	gpuPool := &containerpb.NodePool{Name: "gpu-pool", Config: &containerpb.NodeConfig{
+		Taints:       []*containerpb.NodeTaint{{Key: "dedicated", Value: "ml", Effect: containerpb.NodeTaint_NO_SCHEDULE}, {Key: "soft", Effect: containerpb.NodeTaint_PREFER_NO_SCHEDULE}},
+		Accelerators: []*containerpb.AcceleratorConfig{{AcceleratorCount: 1}},
+	}}
+
+	tests := []struct {
+		name        string
+		tolerations []kube.Toleration
+		want        []string
+	}{
+		{
+			name: "no tolerations",
+			want: []string{"dedicated=ml:NoSchedule", "nvidia.com/gpu=present:NoSchedule"},
+		},
+		{
+			name: "tolerate everything",
+			tolerations: []kube.Toleration{
+				{Operator: "Exists"},
+			},
+		},
+		{
+			name: "tolerate by key and value",
+			tolerations: []kube.Toleration{
+				{Key: "dedicated", Operator: "Equal", Value: "ml", Effect: "NoSchedule"},
+				{Key: "nvidia.com/gpu", Operator: "Exists", Effect: "NoExecute"},
+			},
+			want: []string{"nvidia.com/gpu=present:NoSchedule"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, taint := range untoleratedTaints(kube.PodSpec{Tolerations: tt.tolerations}, gpuPool) {
+				got = append(got, formatTaint(taint))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("untoleratedTaints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDaemonSetReport(t *testing.T) {
+	pools := []*containerpb.NodePool{
+		{Name: "default-pool", Config: &containerpb.NodeConfig{MachineType: "e2-standard-4"}},
+		{Name: "arm-pool", Config: &containerpb.NodeConfig{MachineType: "t2a-standard-4"}},
+	}
+	daemonSets := []kube.DaemonSet{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "monitoring", Name: "node-exporter"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "amd64-only"},
+			Spec: kube.DaemonSetSpec{Template: kube.PodTemplateSpec{Spec: kube.PodSpec{
+				NodeSelector:      map[string]string{"kubernetes.io/arch": "amd64"},
+				PriorityClassName: "system-node-critical",
+			}}},
+		},
+	}
+
+	report := daemonSetReport("my-cluster", pools, daemonSets)
+	for _, want := range []string{
+		"- monitoring/node-exporter does not tolerate kubernetes.io/arch=arm64:NoSchedule of node pool arm-pool",
+		"DaemonSets without a priorityClassName.",
+		"\n- monitoring/node-exporter\n",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("daemonSetReport() missing %q in:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "amd64-only") {
+		t.Errorf("daemonSetReport() reports a DaemonSet without issues:\n%s", report)
+	}
+}
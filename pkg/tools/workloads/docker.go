@@ -0,0 +1,138 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/registry"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// dockerHostPaths are host paths which only exist on nodes running Docker or cri-dockerd.
+var dockerHostPaths = []string{
+	"/var/run/docker.sock",
+	"/run/docker.sock",
+	"/var/run/dockershim.sock",
+	"/var/run/cri-dockerd.sock",
+	"/run/cri-dockerd.sock",
+	"/var/lib/docker",
+}
+
+type checkDockerDependenciesArgs struct{}
+
+func (h *handlers) checkDockerDependencies(ctx context.Context, _ *mcp.CallToolRequest, _ *checkDockerDependenciesArgs) (*mcp.CallToolResult, any, error) {
+	var nodes kube.List[kube.Node]
+	if err := kube.Get(ctx, &nodes, "nodes"); err != nil {
+		return nil, nil, err
+	}
+	var pods kube.List[kube.Pod]
+	if err := kube.Get(ctx, &pods, "pods", "--all-namespaces"); err != nil {
+		return nil, nil, err
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: dockerDependenciesReport(nodes.Items, pods.Items)},
+		},
+	}, nil, nil
+}
+
+// workloadName returns the namespaced name of the controller of the pod, or
+// of the pod itself for bare pods, so that replicas are reported once.
+func workloadName(pod kube.Pod) string {
+	if owner := controller(pod); owner != nil {
+		return fmt.Sprintf("%s/%s %s", pod.Namespace, owner.Kind, owner.Name)
+	}
+	return fmt.Sprintf("%s/Pod %s", pod.Namespace, pod.Name)
+}
+
+// isDockerImage reports whether the image runs Docker itself, e.g. Docker in Docker or cri-dockerd.
+func isDockerImage(image string) bool {
+	ref := registry.ParseReference(image)
+	return ref.Repository == "library/docker" || strings.Contains(ref.Reference, "dind") || strings.Contains(ref.Repository, "dind") || strings.Contains(ref.Repository, "cri-dockerd")
+}
+
+// podDockerDependencies returns the Docker-specific dependencies of a pod.
+func podDockerDependencies(pod kube.Pod) []string {
+	var deps []string
+	for _, v := range pod.Spec.Volumes {
+		if v.HostPath == nil {
+			continue
+		}
+		path := strings.TrimSuffix(v.HostPath.Path, "/")
+		for _, dockerPath := range dockerHostPaths {
+			if path == dockerPath || strings.HasPrefix(path, dockerPath+"/") {
+				deps = append(deps, fmt.Sprintf("mounts hostPath %s", v.HostPath.Path))
+				break
+			}
+		}
+	}
+	for _, c := range slices.Concat(pod.Spec.InitContainers, pod.Spec.Containers) {
+		if isDockerImage(c.Image) {
+			deps = append(deps, fmt.Sprintf("container %s runs Docker (image %s)", c.Name, c.Image))
+		}
+	}
+	return deps
+}
+
+func dockerDependenciesReport(nodes []kube.Node, pods []kube.Pod) string {
+	var b strings.Builder
+
+	var dockerNodes []string
+	for _, node := range nodes {
+		if runtime := node.Status.NodeInfo.ContainerRuntimeVersion; strings.HasPrefix(runtime, "docker://") {
+			dockerNodes = append(dockerNodes, fmt.Sprintf("- %s (%s)", node.Name, runtime))
+		}
+	}
+	if len(dockerNodes) > 0 {
+		sort.Strings(dockerNodes)
+		b.WriteString("Nodes running Docker as the container runtime. Docker-based node images are not supported since GKE 1.24, migrate these node pools to containerd image types:\n")
+		b.WriteString(strings.Join(dockerNodes, "\n"))
+		b.WriteString("\n")
+	} else {
+		b.WriteString("All nodes use containerd or another CRI runtime.\n")
+	}
+
+	workloads := map[string][]string{}
+	for _, pod := range pods {
+		name := workloadName(pod)
+		for _, dep := range podDockerDependencies(pod) {
+			if !slices.Contains(workloads[name], dep) {
+				workloads[name] = append(workloads[name], dep)
+			}
+		}
+	}
+	if len(workloads) == 0 {
+		b.WriteString("\nNo workloads depend on the Docker socket, Docker in Docker or cri-dockerd.\n")
+		return b.String()
+	}
+
+	names := make([]string, 0, len(workloads))
+	for name := range workloads {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	b.WriteString("\nWorkloads depending on Docker. They break on containerd nodes, use the containerd socket, crictl or a daemonless image builder such as Kaniko or Cloud Build instead:\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "- %s: %s\n", name, strings.Join(workloads[name], "; "))
+	}
+	return b.String()
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIsDockerImage(t *testing.T) {
+	for image, want := range map[string]bool{
+		"docker:27-dind":                   true,
+		"docker:cli":                       true,
+		"docker.io/library/docker:24":      true,
+		"gcr.io/project/dind-runner:v1":    true,
+		"mirantis/cri-dockerd:0.3.15":      true,
+		"nginx:1.27":                       false,
+		"gcr.io/kaniko-project/executor":   false,
+		"docker.io/bitnami/dockerize:0.1":  false,
+		"registry.k8s.io/pause:3.10":       false,
+		"us-docker.pkg.dev/project/app:v1": false,
+	} {
+		if got := isDockerImage(image); got != want {
+			t.Errorf("isDockerImage(%q) = %v, want %v", image, got, want)
+		}
+	}
+}
+
+func TestDockerDependenciesReport(t *testing.T) {
+	nodes := []kube.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "old-node"}, Status: kube.NodeStatus{NodeInfo: kube.NodeSystemInfo{ContainerRuntimeVersion: "docker://20.10.23"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "new-node"}, Status: kube.NodeStatus{NodeInfo: kube.NodeSystemInfo{ContainerRuntimeVersion: "containerd://1.7.24"}}},
+	}
+	socket := kube.Volume{Name: "docker", HostPath: &kube.HostPathVolumeSource{Path: "/var/run/docker.sock"}}
+	pods := []kube.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ci", Name: "runner-abc", OwnerReferences: ownedBy("ReplicaSet")},
+			Spec:       kube.PodSpec{Volumes: []kube.Volume{socket}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ci", Name: "runner-def", OwnerReferences: ownedBy("ReplicaSet")},
+			Spec:       kube.PodSpec{Volumes: []kube.Volume{socket}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ci", Name: "build"},
+			Spec:       kube.PodSpec{Containers: []kube.Container{{Name: "dind", Image: "docker:27-dind"}}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "logs"},
+			Spec:       kube.PodSpec{Volumes: []kube.Volume{{Name: "logs", HostPath: &kube.HostPathVolumeSource{Path: "/var/log"}}}},
+		},
+	}
+
+	report := dockerDependenciesReport(nodes, pods)
+	for _, want := range []string{
+		"- old-node (docker://20.10.23)",
+		"- ci/Pod build: container dind runs Docker (image docker:27-dind)\n",
+		"- ci/ReplicaSet owner: mounts hostPath /var/run/docker.sock\n",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("dockerDependenciesReport() missing %q in:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "new-node") || strings.Contains(report, "logs") {
+		t.Errorf("dockerDependenciesReport() reports nodes or workloads without Docker dependencies:\n%s", report)
+	}
+
+	if report := dockerDependenciesReport(nil, nil); !strings.Contains(report, "No workloads depend on") {
+		t.Errorf("dockerDependenciesReport() without workloads = %q", report)
+	}
+}
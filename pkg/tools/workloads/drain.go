@@ -0,0 +1,172 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// mirrorPodAnnotation marks static pods managed by the kubelet.
+const mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+type simulateNodeDrainArgs struct {
+	NodePool string `json:"node_pool" jsonschema:"Name of the node pool whose nodes are drained."`
+}
+
+// drainIssue is a reason why evicting a pod blocks or loses data.
+type drainIssue struct {
+	pod    string
+	reason string
+}
+
+func (h *handlers) simulateNodeDrain(ctx context.Context, _ *mcp.CallToolRequest, args *simulateNodeDrainArgs) (*mcp.CallToolResult, any, error) {
+	if args.NodePool == "" {
+		return nil, nil, fmt.Errorf("node_pool argument cannot be empty")
+	}
+
+	var nodes kube.List[kube.Node]
+	if err := kube.Get(ctx, &nodes, "nodes", "-l", gke.NodePoolLabel+"="+args.NodePool); err != nil {
+		return nil, nil, err
+	}
+	var pods kube.List[kube.Pod]
+	if err := kube.Get(ctx, &pods, "pods", "--all-namespaces"); err != nil {
+		return nil, nil, err
+	}
+	var pdbs kube.List[kube.PodDisruptionBudget]
+	if err := kube.Get(ctx, &pdbs, "poddisruptionbudgets", "--all-namespaces"); err != nil {
+		return nil, nil, err
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: drainReport(args.NodePool, nodes.Items, pods.Items, pdbs.Items)},
+		},
+	}, nil, nil
+}
+
+// controller returns the owner reference of the controller managing the pod, if any.
+func controller(pod kube.Pod) *metav1.OwnerReference {
+	for i, ref := range pod.OwnerReferences {
+		if ref.Controller != nil && *ref.Controller {
+			return &pod.OwnerReferences[i]
+		}
+	}
+	return nil
+}
+
+// matchingPDBs returns the PodDisruptionBudgets selecting the pod.
+func matchingPDBs(pod kube.Pod, pdbs []kube.PodDisruptionBudget) []kube.PodDisruptionBudget {
+	var matching []kube.PodDisruptionBudget
+	for _, pdb := range pdbs {
+		if pdb.Namespace != pod.Namespace || pdb.Spec.Selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			continue
+		}
+		// An empty selector selects all pods of the namespace.
+		if selector.Matches(labels.Set(pod.Labels)) {
+			matching = append(matching, pdb)
+		}
+	}
+	return matching
+}
+
+// podDrainIssues returns the issues evicting pods from a node would hit.
+// DaemonSet, mirror and completed pods are skipped like kubectl drain does.
+func podDrainIssues(nodePods []kube.Pod, pdbs []kube.PodDisruptionBudget) []drainIssue {
+	var issues []drainIssue
+	// pdbEvictions counts the pods of the node each PodDisruptionBudget selects.
+	pdbEvictions := map[string]int32{}
+	for _, pod := range nodePods {
+		if pod.Status.Phase == "Succeeded" || pod.Status.Phase == "Failed" {
+			continue
+		}
+		if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+			continue
+		}
+		owner := controller(pod)
+		if owner != nil && owner.Kind == "DaemonSet" {
+			continue
+		}
+
+		name := pod.Namespace + "/" + pod.Name
+		if owner == nil {
+			issues = append(issues, drainIssue{pod: name, reason: "not managed by a controller, it is deleted and not recreated"})
+		}
+		for _, v := range pod.Spec.Volumes {
+			if v.EmptyDir != nil {
+				issues = append(issues, drainIssue{pod: name, reason: fmt.Sprintf("uses emptyDir volume %q, its data is lost", v.Name)})
+			}
+			if v.HostPath != nil {
+				issues = append(issues, drainIssue{pod: name, reason: fmt.Sprintf("uses hostPath volume %q (%s), local data stays on the old node", v.Name, v.HostPath.Path)})
+			}
+		}
+		for _, pdb := range matchingPDBs(pod, pdbs) {
+			pdbName := pdb.Namespace + "/" + pdb.Name
+			pdbEvictions[pdbName]++
+			if pdbEvictions[pdbName] > pdb.Status.DisruptionsAllowed {
+				issues = append(issues, drainIssue{pod: name, reason: fmt.Sprintf("eviction blocked by PodDisruptionBudget %s (%d disruptions allowed)", pdbName, pdb.Status.DisruptionsAllowed)})
+			}
+		}
+	}
+	return issues
+}
+
+func drainReport(nodePool string, nodes []kube.Node, pods []kube.Pod, pdbs []kube.PodDisruptionBudget) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Node pool: %s\n", nodePool)
+	if len(nodes) == 0 {
+		b.WriteString("No nodes found for the node pool in the current kubectl context.\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "Nodes: %d\n", len(nodes))
+
+	podsByNode := map[string][]kube.Pod{}
+	for _, pod := range pods {
+		podsByNode[pod.Spec.NodeName] = append(podsByNode[pod.Spec.NodeName], pod)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	total := 0
+	for _, node := range nodes {
+		issues := podDrainIssues(podsByNode[node.Name], pdbs)
+		if len(issues) == 0 {
+			continue
+		}
+		total += len(issues)
+		fmt.Fprintf(&b, "\nNode %s:\n", node.Name)
+		for _, issue := range issues {
+			fmt.Fprintf(&b, "- %s: %s\n", issue.pod, issue.reason)
+		}
+	}
+
+	if total == 0 {
+		b.WriteString("\nNo pods would block or lose data when draining the node pool.\n")
+	} else {
+		fmt.Fprintf(&b, "\nFound %d drain issues. During upgrades GKE waits up to 1 hour for PodDisruptionBudgets before evicting pods anyway.\n", total)
+	}
+	return b.String()
+}
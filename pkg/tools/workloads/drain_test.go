@@ -0,0 +1,137 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func ownedBy(kind string) []metav1.OwnerReference {
+	return []metav1.OwnerReference{{Kind: kind, Name: "owner", Controller: ptr.To(true)}}
+}
+
+func TestPodDrainIssues(t *testing.T) {
+	pdbs := []kube.PodDisruptionBudget{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "db", Name: "postgres"},
+			Spec:       kube.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "postgres"}}},
+			Status:     kube.PodDisruptionBudgetStatus{DisruptionsAllowed: 0},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+			Spec:       kube.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+			Status:     kube.PodDisruptionBudgetStatus{DisruptionsAllowed: 1},
+		},
+	}
+
+	tests := []struct {
+		name string
+		pods []kube.Pod
+		want []drainIssue
+	}{
+		{
+			name: "replicated pod without issues",
+			pods: []kube.Pod{
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "app", OwnerReferences: ownedBy("ReplicaSet")}},
+			},
+		},
+		{
+			name: "daemonset, mirror and completed pods are skipped",
+			pods: []kube.Pod{
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "fluentbit", OwnerReferences: ownedBy("DaemonSet")}},
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "kube-proxy", Annotations: map[string]string{mirrorPodAnnotation: "hash"}}},
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "job"}, Status: kube.PodStatus{Phase: "Succeeded"}},
+			},
+		},
+		{
+			name: "bare pod with local storage",
+			pods: []kube.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "debug"},
+					Spec: kube.PodSpec{Volumes: []kube.Volume{
+						{Name: "cache", EmptyDir: &kube.EmptyDirVolumeSource{}},
+						{Name: "logs", HostPath: &kube.HostPathVolumeSource{Path: "/var/log"}},
+					}},
+				},
+			},
+			want: []drainIssue{
+				{pod: "default/debug", reason: "not managed by a controller, it is deleted and not recreated"},
+				{pod: "default/debug", reason: `uses emptyDir volume "cache", its data is lost`},
+				{pod: "default/debug", reason: `uses hostPath volume "logs" (/var/log), local data stays on the old node`},
+			},
+		},
+		{
+			name: "pod disruption budgets",
+			pods: []kube.Pod{
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "db", Name: "postgres-0", Labels: map[string]string{"app": "postgres"}, OwnerReferences: ownedBy("StatefulSet")}},
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-1", Labels: map[string]string{"app": "web"}, OwnerReferences: ownedBy("ReplicaSet")}},
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-2", Labels: map[string]string{"app": "web"}, OwnerReferences: ownedBy("ReplicaSet")}},
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "web-3", Labels: map[string]string{"app": "web"}, OwnerReferences: ownedBy("ReplicaSet")}},
+			},
+			want: []drainIssue{
+				{pod: "db/postgres-0", reason: "eviction blocked by PodDisruptionBudget db/postgres (0 disruptions allowed)"},
+				{pod: "default/web-2", reason: "eviction blocked by PodDisruptionBudget default/web (1 disruptions allowed)"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := podDrainIssues(tt.pods, pdbs)
+			if len(got) != len(tt.want) {
+				t.Fatalf("podDrainIssues() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("podDrainIssues()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDrainReport(t *testing.T) {
+	nodes := []kube.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-b"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}},
+	}
+	pods := []kube.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "bare"}, Spec: kube.PodSpec{NodeName: "node-b"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "elsewhere"}, Spec: kube.PodSpec{NodeName: "node-c"}},
+	}
+
+	report := drainReport("default-pool", nodes, pods, nil)
+	for _, want := range []string{
+		"Node pool: default-pool\nNodes: 2\n",
+		"Node node-b:\n- default/bare: not managed by a controller",
+		"Found 1 drain issues.",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("drainReport() missing %q in:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "elsewhere") || strings.Contains(report, "node-a") {
+		t.Errorf("drainReport() reports pods or nodes without issues:\n%s", report)
+	}
+
+	if report := drainReport("empty-pool", nil, pods, nil); !strings.Contains(report, "No nodes found") {
+		t.Errorf("drainReport() for an empty node pool = %q", report)
+	}
+}
@@ -0,0 +1,342 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	gatewayAPIGroup = "gateway.networking.k8s.io"
+	// ingressClassAnnotation is the deprecated annotation selecting the
+	// controller of an Ingress, still used by the GKE Ingress controller.
+	ingressClassAnnotation = "kubernetes.io/ingress.class"
+	// gatewayBundleVersionAnnotation and gatewayChannelAnnotation are set on
+	// the Gateway API CRDs by their release bundle.
+	gatewayBundleVersionAnnotation = "gateway.networking.k8s.io/bundle-version"
+	gatewayChannelAnnotation       = "gateway.networking.k8s.io/channel"
+)
+
+// deprecatedAnnotation is an annotation of Services replaced by another setting.
+type deprecatedAnnotation struct {
+	key         string
+	replacement string
+}
+
+var deprecatedServiceAnnotations = []deprecatedAnnotation{
+	{key: "cloud.google.com/load-balancer-type", replacement: "the networking.gke.io/load-balancer-type annotation"},
+	{key: "beta.cloud.google.com/backend-config", replacement: "the cloud.google.com/backend-config annotation"},
+	{key: "alpha.cloud.google.com/load-balancer-neg", replacement: "the cloud.google.com/neg annotation"},
+	{key: "service.kubernetes.io/topology-aware-hints", replacement: "the service.kubernetes.io/topology-mode annotation or spec.trafficDistribution"},
+	{key: "service.alpha.kubernetes.io/tolerate-unready-endpoints", replacement: "spec.publishNotReadyAddresses"},
+}
+
+// exposureAnnotationPrefixes are the prefixes of the annotations configuring
+// the load balancers, listed in the inventory.
+var exposureAnnotationPrefixes = []string{
+	"cloud.google.com/",
+	"networking.gke.io/",
+	"service.kubernetes.io/",
+	"ingress.gcp.kubernetes.io/",
+	"ingress.kubernetes.io/",
+	"kubernetes.io/ingress",
+	"beta.cloud.google.com/",
+	"alpha.cloud.google.com/",
+}
+
+type auditServiceExposureArgs struct {
+	ProjectID     string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location      string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name          string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+	TargetVersion string `json:"target_version,omitempty" jsonschema:"Target GKE or Kubernetes version of the upgrade, e.g. 1.33 or 1.33.2-gke.1240000. If empty, the Ingress APIs removed up to the current version are checked."`
+}
+
+// exposureInventory are the objects exposing the workloads of a cluster.
+type exposureInventory struct {
+	// services are the Services of type LoadBalancer.
+	services  []kube.Service
+	ingresses []kube.Ingress
+	// gatewayCRDs are the CRDs of the Gateway API, empty when it isn't installed.
+	gatewayCRDs []kube.CustomResourceDefinition
+	gateways    []kube.Gateway
+	// routes are the Gateway API objects other than Gateways and GatewayClasses.
+	routes []kube.Object
+}
+
+func (h *handlers) auditServiceExposure(ctx context.Context, _ *mcp.CallToolRequest, args *auditServiceExposureArgs) (*mcp.CallToolResult, any, error) {
+	cluster, err := gke.GetCluster(ctx, h.cmClient, h.c, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	targetVersion := args.TargetVersion
+	if targetVersion == "" {
+		targetVersion = cluster.GetCurrentMasterVersion()
+	}
+
+	var inv exposureInventory
+	var services kube.List[kube.Service]
+	if err := kube.Get(ctx, &services, "services", "--all-namespaces"); err != nil {
+		return nil, nil, err
+	}
+	for _, s := range services.Items {
+		if s.Spec.Type == "LoadBalancer" {
+			inv.services = append(inv.services, s)
+		}
+	}
+	var ingresses kube.List[kube.Ingress]
+	if err := kube.Get(ctx, &ingresses, "ingresses", "--all-namespaces"); err != nil {
+		return nil, nil, err
+	}
+	inv.ingresses = ingresses.Items
+
+	var crds kube.List[kube.CustomResourceDefinition]
+	if err := kube.Get(ctx, &crds, "customresourcedefinitions"); err != nil {
+		return nil, nil, err
+	}
+	for _, crd := range crds.Items {
+		if crd.Spec.Group != gatewayAPIGroup {
+			continue
+		}
+		inv.gatewayCRDs = append(inv.gatewayCRDs, crd)
+		resource := crd.Spec.Names.Plural + "." + gatewayAPIGroup
+		switch crd.Spec.Names.Kind {
+		case "Gateway":
+			var gateways kube.List[kube.Gateway]
+			if err := kube.Get(ctx, &gateways, resource, "--all-namespaces"); err != nil {
+				return nil, nil, err
+			}
+			inv.gateways = gateways.Items
+		case "GatewayClass":
+		default:
+			var objects kube.List[kube.Object]
+			if err := kube.Get(ctx, &objects, resource, "--all-namespaces"); err != nil {
+				return nil, nil, err
+			}
+			inv.routes = append(inv.routes, objects.Items...)
+		}
+	}
+
+	report, err := exposureReport(cluster, targetVersion, inv)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: report},
+		},
+	}, nil, nil
+}
+
+// appliedAPIVersion returns the apiVersion of the manifest last applied to the
+// object with kubectl apply, empty if there is none.
+func appliedAPIVersion(meta metav1.ObjectMeta) string {
+	var m manifestObject
+	if err := json.Unmarshal([]byte(meta.Annotations[lastAppliedAnnotation]), &m); err != nil {
+		return ""
+	}
+	return m.APIVersion
+}
+
+// exposureAnnotations returns the annotations of the object configuring its
+// load balancer, as key=value.
+func exposureAnnotations(meta metav1.ObjectMeta) []string {
+	var annotations []string
+	for _, key := range slices.Sorted(maps.Keys(meta.Annotations)) {
+		for _, prefix := range exposureAnnotationPrefixes {
+			if strings.HasPrefix(key, prefix) {
+				annotations = append(annotations, key+"="+meta.Annotations[key])
+				break
+			}
+		}
+	}
+	return annotations
+}
+
+// removedAPIIssue returns the issue of an object last applied with an API
+// version of its kind removed up to the target minor version.
+func removedAPIIssue(kind, apiVersion string, targetMinor int) string {
+	for _, api := range kube.DeprecatedAPIs {
+		if api.Kind == kind && api.APIVersion == apiVersion && api.RemovedIn <= targetMinor {
+			issue := fmt.Sprintf("applied with %s, removed in 1.%d, so reapplying its manifest fails; use %s.", api.APIVersion, api.RemovedIn, api.Replacement)
+			if api.Notes != "" {
+				issue += " " + api.Notes
+			}
+			return issue
+		}
+	}
+	return ""
+}
+
+// serviceExposureIssues returns the deprecated settings of a LoadBalancer Service.
+func serviceExposureIssues(s kube.Service) []string {
+	var issues []string
+	for _, a := range deprecatedServiceAnnotations {
+		if _, ok := s.Annotations[a.key]; ok {
+			issues = append(issues, fmt.Sprintf("the %s annotation is deprecated, use %s", a.key, a.replacement))
+		}
+	}
+	if s.Spec.LoadBalancerIP != "" {
+		issues = append(issues, "spec.loadBalancerIP is deprecated, use the networking.gke.io/load-balancer-ip-addresses annotation")
+	}
+	return issues
+}
+
+// ingressExposureIssues returns the deprecated settings and APIs of an Ingress.
+func ingressExposureIssues(ing kube.Ingress, targetMinor int) []string {
+	var issues []string
+	// The GKE Ingress controller only reads the annotation, so it's only
+	// replaced by spec.ingressClassName for the other controllers.
+	if class, ok := ing.Annotations[ingressClassAnnotation]; ok && class != "gce" && class != "gce-internal" {
+		issues = append(issues, fmt.Sprintf("the %s annotation is deprecated, use spec.ingressClassName: %s", ingressClassAnnotation, class))
+	}
+	if issue := removedAPIIssue("Ingress", appliedAPIVersion(ing.ObjectMeta), targetMinor); issue != "" {
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// gatewayAPIIssue returns the issue of a Gateway API object applied with a
+// version its CRD deprecates or no longer serves.
+func gatewayAPIIssue(meta metav1.ObjectMeta, kind string, crds []kube.CustomResourceDefinition) string {
+	apiVersion := appliedAPIVersion(meta)
+	group, version, found := strings.Cut(apiVersion, "/")
+	if !found || group != gatewayAPIGroup {
+		return ""
+	}
+	for _, crd := range crds {
+		if crd.Spec.Names.Kind != kind {
+			continue
+		}
+		for _, v := range crd.Spec.Versions {
+			if v.Name != version {
+				continue
+			}
+			switch {
+			case !v.Served:
+				return fmt.Sprintf("applied with %s, which the installed CRD no longer serves, so reapplying its manifest fails", apiVersion)
+			case v.Deprecated:
+				return fmt.Sprintf("applied with %s, which the installed CRD deprecates; use the version stored by the CRD", apiVersion)
+			}
+			return ""
+		}
+		return fmt.Sprintf("applied with %s, which the installed CRD doesn't define, so reapplying its manifest fails", apiVersion)
+	}
+	return ""
+}
+
+func exposureReport(cluster *containerpb.Cluster, targetVersion string, inv exposureInventory) (string, error) {
+	_, targetMinor, err := gke.MinorVersion(targetVersion)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cluster: %s, version %s, checked up to %s\n", cluster.GetName(), cluster.GetCurrentMasterVersion(), targetVersion)
+	issues := 0
+	writeIssues := func(list []string) {
+		for _, issue := range list {
+			fmt.Fprintf(&b, "  ISSUE: %s\n", issue)
+		}
+		issues += len(list)
+	}
+	writeAnnotations := func(meta metav1.ObjectMeta) {
+		if annotations := exposureAnnotations(meta); len(annotations) > 0 {
+			fmt.Fprintf(&b, "  Annotations: %s\n", strings.Join(annotations, ", "))
+		}
+	}
+
+	fmt.Fprintf(&b, "\nLoadBalancer Services (%d):\n", len(inv.services))
+	for _, s := range inv.services {
+		scheme := "external"
+		if strings.EqualFold(s.Annotations["networking.gke.io/load-balancer-type"], "Internal") || strings.EqualFold(s.Annotations["cloud.google.com/load-balancer-type"], "Internal") {
+			scheme = "internal"
+		}
+		address := "pending"
+		if ingress := s.Status.LoadBalancer.Ingress; len(ingress) > 0 {
+			address = ingress[0].IP + ingress[0].Hostname
+		}
+		fmt.Fprintf(&b, "- %s/%s: %s, address %s\n", s.Namespace, s.Name, scheme, address)
+		writeAnnotations(s.ObjectMeta)
+		writeIssues(serviceExposureIssues(s))
+	}
+
+	fmt.Fprintf(&b, "\nIngresses (%d):\n", len(inv.ingresses))
+	for _, ing := range inv.ingresses {
+		class := ing.Annotations[ingressClassAnnotation]
+		if ing.Spec.IngressClassName != nil {
+			class = *ing.Spec.IngressClassName
+		}
+		if class == "" {
+			class = "default"
+		}
+		fmt.Fprintf(&b, "- %s/%s: class %s\n", ing.Namespace, ing.Name, class)
+		writeAnnotations(ing.ObjectMeta)
+		writeIssues(ingressExposureIssues(ing, targetMinor))
+	}
+
+	b.WriteString("\nGateway API:")
+	if channel := cluster.GetNetworkConfig().GetGatewayApiConfig().GetChannel(); channel != containerpb.GatewayAPIConfig_CHANNEL_UNSPECIFIED {
+		fmt.Fprintf(&b, " GKE channel %s,", channel)
+	}
+	if len(inv.gatewayCRDs) == 0 {
+		b.WriteString(" not installed\n")
+	} else {
+		crd := inv.gatewayCRDs[0]
+		fmt.Fprintf(&b, " bundle %s, %s channel\n", crd.Annotations[gatewayBundleVersionAnnotation], crd.Annotations[gatewayChannelAnnotation])
+		for _, crd := range inv.gatewayCRDs {
+			var deprecated []string
+			for _, v := range crd.Spec.Versions {
+				if v.Served && v.Deprecated {
+					deprecated = append(deprecated, v.Name)
+				}
+			}
+			if len(deprecated) > 0 {
+				fmt.Fprintf(&b, "- CRD %s serves the deprecated versions %s, which a later bundle can stop serving\n", crd.Name, strings.Join(deprecated, ", "))
+			}
+		}
+		for _, g := range inv.gateways {
+			fmt.Fprintf(&b, "- Gateway %s/%s: class %s\n", g.Namespace, g.Name, g.Spec.GatewayClassName)
+			writeAnnotations(g.ObjectMeta)
+			if issue := gatewayAPIIssue(g.ObjectMeta, "Gateway", inv.gatewayCRDs); issue != "" {
+				writeIssues([]string{issue})
+			}
+		}
+		for _, r := range inv.routes {
+			fmt.Fprintf(&b, "- %s %s/%s\n", r.Kind, r.Namespace, r.Name)
+			if issue := gatewayAPIIssue(r.ObjectMeta, r.Kind, inv.gatewayCRDs); issue != "" {
+				writeIssues([]string{issue})
+			}
+		}
+	}
+
+	if issues == 0 {
+		b.WriteString("\nNo deprecated annotations or removed APIs were found.\n")
+	} else {
+		fmt.Fprintf(&b, "\n%d issues found. Update the manifests, Helm charts or operators creating these objects before the upgrade.\n", issues)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"strings"
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestExposureReport(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		Name:                 "prod",
+		CurrentMasterVersion: "1.21.14-gke.100",
+		NetworkConfig:        &containerpb.NetworkConfig{GatewayApiConfig: &containerpb.GatewayAPIConfig{Channel: containerpb.GatewayAPIConfig_CHANNEL_STANDARD}},
+	}
+
+	internal := kube.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "api", Annotations: map[string]string{
+		"cloud.google.com/load-balancer-type":  "Internal",
+		"beta.cloud.google.com/backend-config": `{"default":"api"}`,
+		"team":                                 "shop",
+	}}}
+	internal.Spec.Type = "LoadBalancer"
+	internal.Spec.LoadBalancerIP = "10.0.0.10"
+	external := kube.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web"}}
+	external.Spec.Type = "LoadBalancer"
+	external.Status.LoadBalancer.Ingress = append(external.Status.LoadBalancer.Ingress, struct {
+		IP       string `json:"ip,omitempty"`
+		Hostname string `json:"hostname,omitempty"`
+	}{IP: "34.1.2.3"})
+
+	nginx := kube.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "legacy", Annotations: map[string]string{
+		ingressClassAnnotation: "nginx",
+		lastAppliedAnnotation:  `{"apiVersion":"networking.k8s.io/v1beta1","kind":"Ingress"}`,
+	}}}
+	gce := kube.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "public", Annotations: map[string]string{ingressClassAnnotation: "gce"}}}
+
+	gatewayCRD := kube.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "gateways.gateway.networking.k8s.io", Annotations: map[string]string{
+		gatewayBundleVersionAnnotation: "v1.0.0",
+		gatewayChannelAnnotation:       "standard",
+	}}}
+	gatewayCRD.Spec.Group = gatewayAPIGroup
+	gatewayCRD.Spec.Names.Kind = "Gateway"
+	gatewayCRD.Spec.Versions = append(gatewayCRD.Spec.Versions,
+		struct {
+			Name       string `json:"name"`
+			Served     bool   `json:"served"`
+			Storage    bool   `json:"storage"`
+			Deprecated bool   `json:"deprecated,omitempty"`
+		}{Name: "v1", Served: true, Storage: true},
+		struct {
+			Name       string `json:"name"`
+			Served     bool   `json:"served"`
+			Storage    bool   `json:"storage"`
+			Deprecated bool   `json:"deprecated,omitempty"`
+		}{Name: "v1beta1", Served: true, Deprecated: true},
+	)
+	routeCRD := kube.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "httproutes.gateway.networking.k8s.io"}}
+	routeCRD.Spec.Group = gatewayAPIGroup
+	routeCRD.Spec.Names.Kind = "HTTPRoute"
+	routeCRD.Spec.Versions = gatewayCRD.Spec.Versions[:1]
+
+	gateway := kube.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "infra", Name: "external", Annotations: map[string]string{
+		lastAppliedAnnotation: `{"apiVersion":"gateway.networking.k8s.io/v1beta1","kind":"Gateway"}`,
+	}}}
+	gateway.Spec.GatewayClassName = "gke-l7-global-external-managed"
+	route := kube.Object{Kind: "HTTPRoute", ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "store", Annotations: map[string]string{
+		lastAppliedAnnotation: `{"apiVersion":"gateway.networking.k8s.io/v1alpha2","kind":"HTTPRoute"}`,
+	}}}
+
+	got, err := exposureReport(cluster, "1.22", exposureInventory{
+		services:    []kube.Service{internal, external},
+		ingresses:   []kube.Ingress{nginx, gce},
+		gatewayCRDs: []kube.CustomResourceDefinition{gatewayCRD, routeCRD},
+		gateways:    []kube.Gateway{gateway},
+		routes:      []kube.Object{route},
+	})
+	if err != nil {
+		t.Fatalf("exposureReport() error = %v", err)
+	}
+	for _, want := range []string{
+		"LoadBalancer Services (2):\n- shop/api: internal, address pending\n",
+		"  Annotations: beta.cloud.google.com/backend-config={\"default\":\"api\"}, cloud.google.com/load-balancer-type=Internal\n",
+		"  ISSUE: the cloud.google.com/load-balancer-type annotation is deprecated, use the networking.gke.io/load-balancer-type annotation\n",
+		"  ISSUE: the beta.cloud.google.com/backend-config annotation is deprecated, use the cloud.google.com/backend-config annotation\n",
+		"  ISSUE: spec.loadBalancerIP is deprecated",
+		"- shop/web: external, address 34.1.2.3\n",
+		"- shop/legacy: class nginx\n",
+		"  ISSUE: the kubernetes.io/ingress.class annotation is deprecated, use spec.ingressClassName: nginx\n",
+		"  ISSUE: applied with networking.k8s.io/v1beta1, removed in 1.22, so reapplying its manifest fails; use networking.k8s.io/v1.",
+		"- shop/public: class gce\n",
+		"Gateway API: GKE channel CHANNEL_STANDARD, bundle v1.0.0, standard channel\n",
+		"- CRD gateways.gateway.networking.k8s.io serves the deprecated versions v1beta1",
+		"- Gateway infra/external: class gke-l7-global-external-managed\n  ISSUE: applied with gateway.networking.k8s.io/v1beta1, which the installed CRD deprecates",
+		"- HTTPRoute shop/store\n  ISSUE: applied with gateway.networking.k8s.io/v1alpha2, which the installed CRD doesn't define",
+		"7 issues found.",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("exposureReport() missing %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "team=shop") || strings.Contains(got, "class gce\n  ISSUE") {
+		t.Errorf("exposureReport() reports unrelated annotations or the GKE Ingress class, got:\n%s", got)
+	}
+}
+
+func TestExposureReport_NoGatewayAPI(t *testing.T) {
+	got, err := exposureReport(&containerpb.Cluster{Name: "dev", CurrentMasterVersion: "1.30.1-gke.100"}, "1.30.1-gke.100", exposureInventory{})
+	if err != nil {
+		t.Fatalf("exposureReport() error = %v", err)
+	}
+	for _, want := range []string{"Gateway API: not installed\n", "No deprecated annotations or removed APIs were found."} {
+		if !strings.Contains(got, want) {
+			t.Errorf("exposureReport() missing %q, got:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// quotaPressureRatio is the ratio of a ResourceQuota used at which the
+// namespace may fail to create the surge pods of an upgrade.
+const quotaPressureRatio = 0.9
+
+// inventoryResources are the workload resources counted per namespace.
+var inventoryResources = []string{"deployments", "statefulsets", "daemonsets", "jobs", "cronjobs", "pods"}
+
+var (
+	// ownerKeyWords identify the labels and annotations naming the owners of
+	// a namespace, e.g. team, owner or contact.
+	ownerKeyWords = []string{"owner", "team", "contact", "maintainer", "oncall"}
+	// criticalityKeyWords identify the labels and annotations giving the
+	// criticality of a namespace, e.g. production-critical, tier or environment.
+	criticalityKeyWords = []string{"critical", "criticality", "tier", "priority", "environment", "env"}
+)
+
+type getNamespaceInventoryArgs struct {
+	NamespaceSelector string `json:"namespace_selector,omitempty" jsonschema:"Label selector of the namespaces to list. Leave this empty to list all namespaces."`
+}
+
+// namespaceInventory is a namespace with its workloads, quotas, owners and
+// criticality.
+type namespaceInventory struct {
+	ns kube.Namespace
+	// counts are the numbers of objects of each of the inventoryResources.
+	counts map[string]int
+	quotas []kube.ResourceQuota
+}
+
+func (h *handlers) getNamespaceInventory(ctx context.Context, _ *mcp.CallToolRequest, args *getNamespaceInventoryArgs) (*mcp.CallToolResult, any, error) {
+	namespaceArgs := []string{"namespaces"}
+	if args.NamespaceSelector != "" {
+		namespaceArgs = append(namespaceArgs, "-l", args.NamespaceSelector)
+	}
+	var namespaces kube.List[kube.Namespace]
+	if err := kube.Get(ctx, &namespaces, namespaceArgs...); err != nil {
+		return nil, nil, err
+	}
+
+	inventory := map[string]*namespaceInventory{}
+	for _, ns := range namespaces.Items {
+		inventory[ns.Name] = &namespaceInventory{ns: ns, counts: map[string]int{}}
+	}
+	for _, resource := range inventoryResources {
+		var list kube.List[kube.Object]
+		if err := kube.Get(ctx, &list, resource, "--all-namespaces"); err != nil {
+			return nil, nil, err
+		}
+		for _, item := range list.Items {
+			if ni, ok := inventory[item.Namespace]; ok {
+				ni.counts[resource]++
+			}
+		}
+	}
+	var quotas kube.List[kube.ResourceQuota]
+	if err := kube.Get(ctx, &quotas, "resourcequotas", "--all-namespaces"); err != nil {
+		return nil, nil, err
+	}
+	for _, quota := range quotas.Items {
+		if ni, ok := inventory[quota.Namespace]; ok {
+			ni.quotas = append(ni.quotas, quota)
+		}
+	}
+
+	var items []namespaceInventory
+	for _, ns := range namespaces.Items {
+		items = append(items, *inventory[ns.Name])
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: namespaceInventoryReport(items)},
+		},
+	}, nil, nil
+}
+
+// matchingMetadata returns the labels and annotations whose key contains one
+// of the words, as key=value.
+func matchingMetadata(ns kube.Namespace, words []string) []string {
+	var matches []string
+	for _, metadata := range []map[string]string{ns.Labels, ns.Annotations} {
+		for _, key := range slices.Sorted(maps.Keys(metadata)) {
+			if keyMatches(key, words) {
+				matches = append(matches, fmt.Sprintf("%s=%s", key, metadata[key]))
+			}
+		}
+	}
+	return matches
+}
+
+// keyMatches reports whether the name of a label or annotation key, without
+// its prefix, is made of one of the words, e.g. example.com/team-owner.
+func keyMatches(key string, words []string) bool {
+	if i := strings.LastIndex(key, "/"); i >= 0 {
+		key = key[i+1:]
+	}
+	for _, part := range strings.FieldsFunc(strings.ToLower(key), func(r rune) bool { return r == '-' || r == '_' || r == '.' }) {
+		if slices.Contains(words, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// quotaUsage describes the resources of a ResourceQuota as used/hard,
+// flagging those close to their limit.
+func quotaUsage(quota kube.ResourceQuota) string {
+	var usage []string
+	for _, name := range slices.Sorted(maps.Keys(quota.Status.Hard)) {
+		hard := quota.Status.Hard[name]
+		used := quota.Status.Used[name]
+		u := fmt.Sprintf("%s %s/%s", name, used.String(), hard.String())
+		if !hard.IsZero() && used.AsApproximateFloat64() >= quotaPressureRatio*hard.AsApproximateFloat64() {
+			u += " (near limit)"
+		}
+		usage = append(usage, u)
+	}
+	return fmt.Sprintf("%s: %s", quota.Name, strings.Join(usage, ", "))
+}
+
+func namespaceInventoryReport(items []namespaceInventory) string {
+	var b strings.Builder
+	if len(items) == 0 {
+		b.WriteString("No namespaces found.\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "Namespaces (%d):\n", len(items))
+
+	var unowned []string
+	for _, ni := range items {
+		fmt.Fprintf(&b, "\n%s\n", ni.ns.Name)
+		var counts []string
+		for _, resource := range inventoryResources {
+			if count := ni.counts[resource]; count > 0 {
+				counts = append(counts, fmt.Sprintf("%s: %d", resource, count))
+			}
+		}
+		if len(counts) == 0 {
+			counts = append(counts, "none")
+		}
+		fmt.Fprintf(&b, "  Workloads: %s\n", strings.Join(counts, ", "))
+		for _, quota := range ni.quotas {
+			fmt.Fprintf(&b, "  Quota %s\n", quotaUsage(quota))
+		}
+		owners := matchingMetadata(ni.ns, ownerKeyWords)
+		if len(owners) > 0 {
+			fmt.Fprintf(&b, "  Owners: %s\n", strings.Join(owners, ", "))
+		} else if !isGKEManagedNamespace(ni.ns.Name) && ni.ns.Name != "default" {
+			unowned = append(unowned, ni.ns.Name)
+		}
+		if criticality := matchingMetadata(ni.ns, criticalityKeyWords); len(criticality) > 0 {
+			fmt.Fprintf(&b, "  Criticality: %s\n", strings.Join(criticality, ", "))
+		}
+	}
+
+	if len(unowned) > 0 {
+		fmt.Fprintf(&b, "\nNamespaces without owner labels or annotations, add e.g. a team label so findings can be routed: %s\n", strings.Join(unowned, ", "))
+	}
+	return b.String()
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestKeyMatches(t *testing.T) {
+	for _, tc := range []struct {
+		key  string
+		want bool
+	}{
+		{"team", true},
+		{"example.com/team-owner", true},
+		{"owner_email", true},
+		{"app.kubernetes.io/managed-by", false},
+		{"steam", false},
+	} {
+		if got := keyMatches(tc.key, ownerKeyWords); got != tc.want {
+			t.Errorf("keyMatches(%q) = %t, want %t", tc.key, got, tc.want)
+		}
+	}
+}
+
+func TestNamespaceInventoryReport(t *testing.T) {
+	items := []namespaceInventory{
+		{
+			ns: kube.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:        "payments",
+				Labels:      map[string]string{"team": "payments", "production-critical": "true"},
+				Annotations: map[string]string{"example.com/oncall": "payments-oncall@example.com"},
+			}},
+			counts: map[string]int{"deployments": 3, "pods": 9},
+			quotas: []kube.ResourceQuota{{
+				ObjectMeta: metav1.ObjectMeta{Name: "compute"},
+				Status: kube.ResourceQuotaStatus{
+					Hard: map[string]resource.Quantity{"pods": resource.MustParse("10"), "requests.cpu": resource.MustParse("8")},
+					Used: map[string]resource.Quantity{"pods": resource.MustParse("9"), "requests.cpu": resource.MustParse("2")},
+				},
+			}},
+		},
+		{ns: kube.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "scratch"}}, counts: map[string]int{}},
+		{ns: kube.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}}, counts: map[string]int{"daemonsets": 4}},
+	}
+
+	got := namespaceInventoryReport(items)
+	for _, want := range []string{
+		"Namespaces (3):",
+		"payments\n  Workloads: deployments: 3, pods: 9\n",
+		"  Quota compute: pods 9/10 (near limit), requests.cpu 2/8\n",
+		"  Owners: team=payments, example.com/oncall=payments-oncall@example.com\n",
+		"  Criticality: production-critical=true\n",
+		"scratch\n  Workloads: none\n",
+		"Namespaces without owner labels or annotations, add e.g. a team label so findings can be routed: scratch\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("namespaceInventoryReport() missing %q, got:\n%s", want, got)
+		}
+	}
+
+	if got := namespaceInventoryReport(nil); got != "No namespaces found.\n" {
+		t.Errorf("namespaceInventoryReport(nil) = %q", got)
+	}
+}
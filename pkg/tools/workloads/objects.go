@@ -0,0 +1,224 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// etcdSizeLimit is the size of the etcd database of a GKE cluster above
+	// which it stops accepting writes.
+	etcdSizeLimit = 6 << 30
+	// largeResourceCount is the number of objects of a resource type above
+	// which listing it, and filling the watch cache of the API server when
+	// it restarts, e.g. during a control plane upgrade, becomes slow.
+	largeResourceCount = 50000
+	// limitWarningRatio is the share of a scalability limit above which a
+	// resource type is reported as approaching it.
+	limitWarningRatio = 0.8
+	// maxListedResources bounds the resource types listed by object count.
+	maxListedResources = 25
+)
+
+var (
+	// storageObjectsRegexp matches the object count of a resource type in the
+	// metrics of the API server.
+	storageObjectsRegexp = regexp.MustCompile(`^apiserver_storage_objects\{[^}]*resource="([^"]+)"[^}]*\} (\S+)$`)
+	// storageSizeRegexp matches the size of the etcd database in the metrics
+	// of the API server.
+	storageSizeRegexp = regexp.MustCompile(`^apiserver_storage_(?:size_bytes|db_total_size_in_bytes)\{[^}]*\} (\S+)$`)
+)
+
+// scalabilityLimits are the Kubernetes scalability thresholds and GKE limits
+// of the number of objects of a resource type.
+var scalabilityLimits = map[string]int64{
+	"pods":       150000,
+	"services":   10000,
+	"namespaces": 10000,
+	"nodes":      15000,
+}
+
+// objectCountHints are the usual ways to reduce the objects of a resource
+// type.
+var objectCountHints = map[string]string{
+	"events":                     "events are kept for an hour, a high count means churn, e.g. crash looping pods or failing probes",
+	"secrets":                    "Helm keeps a Secret per release revision, limit it with --history-max",
+	"configmaps":                 "ConfigMaps generated per rollout, e.g. by Kustomize, accumulate unless they are pruned",
+	"replicasets.apps":           "lower the revisionHistoryLimit of the Deployments",
+	"jobs.batch":                 "set ttlSecondsAfterFinished on the Jobs, and the history limits of the CronJobs",
+	"pods":                       "completed pods of Jobs are kept until their Job is deleted",
+	"leases.coordination.k8s.io": "leases are created per node and per leader election, a high count means stale leases of removed components",
+}
+
+// builtinGroups are the API groups served by the API server itself, the
+// other groups are served through CustomResourceDefinitions or aggregation.
+var builtinGroups = map[string]bool{
+	"": true, "admissionregistration.k8s.io": true, "apiextensions.k8s.io": true, "apiregistration.k8s.io": true,
+	"apps": true, "authentication.k8s.io": true, "authorization.k8s.io": true, "autoscaling": true, "batch": true,
+	"certificates.k8s.io": true, "coordination.k8s.io": true, "discovery.k8s.io": true, "events.k8s.io": true,
+	"flowcontrol.apiserver.k8s.io": true, "internal.apiserver.k8s.io": true, "networking.k8s.io": true,
+	"node.k8s.io": true, "policy": true, "rbac.authorization.k8s.io": true, "resource.k8s.io": true,
+	"scheduling.k8s.io": true, "storage.k8s.io": true, "storagemigration.k8s.io": true,
+}
+
+// countedResources are the resource types counted one by one when the
+// metrics of the API server cannot be read.
+var countedResources = []string{"pods", "services", "namespaces", "nodes", "secrets", "configmaps", "events", "endpointslices.discovery.k8s.io", "replicasets.apps", "jobs.batch", "leases.coordination.k8s.io"}
+
+type getAPIObjectCountsArgs struct{}
+
+// objectCounts is the number of objects of each resource type stored in
+// etcd, and the size of the etcd database when known.
+type objectCounts struct {
+	source    string
+	counts    map[string]int64
+	sizeBytes int64
+}
+
+func (h *handlers) getAPIObjectCounts(ctx context.Context, _ *mcp.CallToolRequest, _ *getAPIObjectCountsArgs) (*mcp.CallToolResult, any, error) {
+	var oc objectCounts
+	metrics, err := kube.Run(ctx, "get", "--raw", "/metrics")
+	if err == nil {
+		oc = parseStorageMetrics(string(metrics))
+	}
+	var note string
+	if err != nil || len(oc.counts) == 0 {
+		if err != nil {
+			note = fmt.Sprintf("\nThe metrics of the API server were not read: %v\n", err)
+		} else {
+			note = "\nThe metrics of the API server have no object counts.\n"
+		}
+		oc = objectCounts{source: "objects listed with kubectl, custom resources not counted", counts: map[string]int64{}, sizeBytes: -1}
+		for _, resource := range countedResources {
+			out, err := kube.Run(ctx, "get", resource, "--all-namespaces", "--output", "name")
+			if err != nil {
+				return nil, nil, err
+			}
+			oc.counts[resource] = int64(strings.Count(string(out), "\n"))
+		}
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: objectCountsReport(oc) + note},
+		},
+	}, nil, nil
+}
+
+// parseStorageMetrics reads the object counts and the etcd database size
+// from the metrics of the API server. Unknown counts are reported as -1.
+func parseStorageMetrics(metrics string) objectCounts {
+	oc := objectCounts{source: "API server storage metrics", counts: map[string]int64{}, sizeBytes: -1}
+	for _, line := range strings.Split(metrics, "\n") {
+		if m := storageObjectsRegexp.FindStringSubmatch(line); m != nil {
+			if v, err := strconv.ParseFloat(m[2], 64); err == nil && v >= 0 {
+				oc.counts[m[1]] = int64(v)
+			}
+			continue
+		}
+		if m := storageSizeRegexp.FindStringSubmatch(line); m != nil {
+			// The database size is reported per etcd member, keep the largest.
+			if v, err := strconv.ParseFloat(m[1], 64); err == nil && int64(v) > oc.sizeBytes {
+				oc.sizeBytes = int64(v)
+			}
+		}
+	}
+	return oc
+}
+
+// isCustomResource reports whether a resource type, named <resource>.<group>
+// in the metrics, is served outside the built-in API groups.
+func isCustomResource(resource string) bool {
+	_, group, _ := strings.Cut(resource, ".")
+	return !builtinGroups[group]
+}
+
+func objectCountsReport(oc objectCounts) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Source: %s\n", oc.source)
+	if oc.sizeBytes >= 0 {
+		fmt.Fprintf(&b, "etcd database size: %.2f GiB, %d%% of the %d GiB limit\n", float64(oc.sizeBytes)/(1<<30), percent(int(oc.sizeBytes>>20), etcdSizeLimit>>20), etcdSizeLimit>>30)
+	}
+
+	var resources []string
+	var total, customTotal int64
+	customTypes := 0
+	for resource, count := range oc.counts {
+		resources = append(resources, resource)
+		total += count
+		if isCustomResource(resource) {
+			customTotal += count
+			customTypes++
+		}
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		if oc.counts[resources[i]] != oc.counts[resources[j]] {
+			return oc.counts[resources[i]] > oc.counts[resources[j]]
+		}
+		return resources[i] < resources[j]
+	})
+	fmt.Fprintf(&b, "Total objects: %d in %d resource types\n", total, len(resources))
+	fmt.Fprintf(&b, "Custom resources: %d objects in %d resource types\n", customTotal, customTypes)
+
+	b.WriteString("\nObjects per resource type, largest first:\n")
+	for i, resource := range resources {
+		if i == maxListedResources {
+			fmt.Fprintf(&b, "... and %d more resource types\n", len(resources)-maxListedResources)
+			break
+		}
+		fmt.Fprintf(&b, "- %s: %d\n", resource, oc.counts[resource])
+	}
+
+	var findings []string
+	if float64(oc.sizeBytes) > limitWarningRatio*etcdSizeLimit {
+		findings = append(findings, fmt.Sprintf("The etcd database is close to its %d GiB limit, above which the API server rejects writes. Delete unused objects of the largest resource types.", etcdSizeLimit>>30))
+	}
+	for _, resource := range resources {
+		count := oc.counts[resource]
+		var finding string
+		if limit, ok := scalabilityLimits[resource]; ok && float64(count) > limitWarningRatio*float64(limit) {
+			finding = fmt.Sprintf("%s: %d objects, %d%% of the scalability limit of %d.", resource, count, percent(int(count), int(limit)), limit)
+		} else if count > largeResourceCount {
+			finding = fmt.Sprintf("%s: %d objects slow down LIST calls and the restarts of the API server during control plane upgrades, which can time out.", resource, count)
+		} else {
+			continue
+		}
+		if hint, ok := objectCountHints[resource]; ok {
+			finding += " Hint: " + hint + "."
+		} else if isCustomResource(resource) {
+			finding += " Hint: find the controller creating these custom resources and delete the stale ones."
+		}
+		findings = append(findings, finding)
+	}
+
+	if len(findings) == 0 {
+		b.WriteString("\nNo resource type is approaching a scalability limit.\n")
+		return b.String()
+	}
+	b.WriteString("\nScalability findings:\n")
+	for _, f := range findings {
+		fmt.Fprintf(&b, "- %s\n", f)
+	}
+	return b.String()
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseStorageMetrics(t *testing.T) {
+	metrics := `# HELP apiserver_storage_objects [STABLE] Number of stored objects at the time of last check split by kind.
+# TYPE apiserver_storage_objects gauge
+apiserver_storage_objects{resource="pods"} 1200
+apiserver_storage_objects{resource="deployments.apps"} 80
+apiserver_storage_objects{resource="leases.coordination.k8s.io"} -1
+apiserver_storage_size_bytes{storage_cluster_id="etcd-0"} 1.073741824e+09
+apiserver_storage_size_bytes{storage_cluster_id="etcd-1"} 5.36870912e+08
+apiserver_request_total{resource="pods"} 42
+`
+
+	oc := parseStorageMetrics(metrics)
+	if len(oc.counts) != 2 || oc.counts["pods"] != 1200 || oc.counts["deployments.apps"] != 80 {
+		t.Errorf("parseStorageMetrics() counts = %v, want pods and deployments.apps", oc.counts)
+	}
+	if oc.sizeBytes != 1<<30 {
+		t.Errorf("parseStorageMetrics() sizeBytes = %d, want %d", oc.sizeBytes, 1<<30)
+	}
+
+	if oc := parseStorageMetrics("apiserver_request_total 1\n"); len(oc.counts) != 0 || oc.sizeBytes != -1 {
+		t.Errorf("parseStorageMetrics() = %+v, want no counts and an unknown size", oc)
+	}
+}
+
+func TestIsCustomResource(t *testing.T) {
+	for resource, want := range map[string]bool{
+		"pods":                                     false,
+		"deployments.apps":                         false,
+		"leases.coordination.k8s.io":               false,
+		"certificates.cert-manager.io":             true,
+		"httproutes.gateway.networking.k8s.io":     true,
+		"podmonitorings.monitoring.googleapis.com": true,
+	} {
+		if got := isCustomResource(resource); got != want {
+			t.Errorf("isCustomResource(%q) = %t, want %t", resource, got, want)
+		}
+	}
+}
+
+func TestObjectCountsReport(t *testing.T) {
+	oc := objectCounts{
+		source: "API server storage metrics",
+		counts: map[string]int64{
+			"pods":                         130000,
+			"events":                       60000,
+			"reports.wgpolicyk8s.io":       70000,
+			"services":                     500,
+			"certificates.cert-manager.io": 20,
+		},
+		sizeBytes: 5 << 30,
+	}
+
+	got := objectCountsReport(oc)
+	for _, want := range []string{
+		"etcd database size: 5.00 GiB, 83% of the 6 GiB limit\n",
+		"Total objects: 260520 in 5 resource types\n",
+		"Custom resources: 70020 objects in 2 resource types\n",
+		"- pods: 130000\n- reports.wgpolicyk8s.io: 70000\n- events: 60000\n- services: 500\n- certificates.cert-manager.io: 20\n",
+		"- The etcd database is close to its 6 GiB limit",
+		"- pods: 130000 objects, 87% of the scalability limit of 150000. Hint: completed pods",
+		"- reports.wgpolicyk8s.io: 70000 objects slow down LIST calls and the restarts of the API server during control plane upgrades, which can time out. Hint: find the controller",
+		"- events: 60000 objects slow down LIST calls",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("objectCountsReport() missing %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "- services: 500 objects") {
+		t.Errorf("objectCountsReport() reports services, got:\n%s", got)
+	}
+
+	got = objectCountsReport(objectCounts{source: "objects listed with kubectl", counts: map[string]int64{"pods": 10}, sizeBytes: -1})
+	if strings.Contains(got, "etcd database size") || !strings.Contains(got, "No resource type is approaching a scalability limit.") {
+		t.Errorf("objectCountsReport() = %q", got)
+	}
+}
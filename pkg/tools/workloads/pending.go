@@ -0,0 +1,208 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// maxPendingPodsListed bounds the number of pods listed per cause.
+const maxPendingPodsListed = 10
+
+// schedulerReasonRegexp matches a reason of the scheduler, e.g. "2 Insufficient
+// cpu" or "1 node(s) had untolerated taint {dedicated: gpu}", in the message
+// of the PodScheduled condition of an unschedulable pod.
+var schedulerReasonRegexp = regexp.MustCompile(`^(\d+) (.+)$`)
+
+// schedulingCause is a cause of unschedulable pods, with the advice to fix
+// it.
+type schedulingCause struct {
+	name   string
+	advice string
+	// matches reports whether a reason of the scheduler is of this cause.
+	matches func(reason string) bool
+}
+
+// reasonContains matches the reasons containing one of the substrings.
+func reasonContains(substrings ...string) func(string) bool {
+	return func(reason string) bool {
+		reason = strings.ToLower(reason)
+		for _, s := range substrings {
+			if strings.Contains(reason, s) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// schedulingCauses are the known causes of unschedulable pods, in the order
+// they are matched.
+var schedulingCauses = []schedulingCause{
+	{name: "Insufficient CPU", advice: "Lower the CPU requests, or add capacity: raise the autoscaler maximum of the node pools or use larger machine types.", matches: reasonContains("insufficient cpu")},
+	{name: "Insufficient memory", advice: "Lower the memory requests, or add capacity: raise the autoscaler maximum of the node pools or use larger machine types.", matches: reasonContains("insufficient memory")},
+	{name: "Insufficient other resources", advice: "Add nodes offering the requested extended resources (e.g. GPUs) or ephemeral storage.", matches: reasonContains("insufficient ")},
+	{name: "Taint mismatch", advice: "Add tolerations for the taints of the nodes the pods should run on, or schedule them on node pools without these taints.", matches: reasonContains("taint")},
+	{name: "Volume zone conflict", advice: "The PersistentVolumes are in zones without eligible nodes; add nodes in the zones of the volumes, or use regional persistent disks.", matches: reasonContains("volume node affinity conflict", "volume zone conflict")},
+	{name: "Unbound PersistentVolumeClaims", advice: "Check the StorageClass and the provisioner of the PersistentVolumeClaims, and their events.", matches: reasonContains("unbound immediate persistentvolumeclaims", "unbound persistentvolumeclaims")},
+	{name: "Volume attach limit", advice: "The nodes reached their maximum number of attached volumes; add nodes or use machine types allowing more volumes.", matches: reasonContains("max volume count")},
+	{name: "Node selector or affinity mismatch", advice: "No node has the labels the node selector or node affinity of the pods require; fix the selector or add a node pool with these labels.", matches: reasonContains("node affinity/selector", "node selector", "node affinity")},
+	{name: "Pod affinity or anti-affinity", advice: "The pod affinity or anti-affinity rules can't be satisfied by the current nodes; use preferred rules or add nodes in more domains.", matches: reasonContains("anti-affinity", "pod affinity")},
+	{name: "Topology spread constraints", advice: "The topology spread constraints can't be satisfied; use maxSkew 2 or more, or whenUnsatisfiable: ScheduleAnyway.", matches: reasonContains("topology spread")},
+	{name: "Cordoned nodes", advice: "The nodes are unschedulable, e.g. cordoned for a drain or an upgrade; wait for the operation to complete or uncordon them.", matches: reasonContains("unschedulable")},
+	{name: "Pod count limit", advice: "The nodes reached their maximum number of pods; add nodes or raise the maximum pods per node of new node pools.", matches: reasonContains("too many pods")},
+	{name: "Host port conflict", advice: "Another pod uses the host ports of the pods on every eligible node; remove the hostPort or add nodes.", matches: reasonContains("free ports")},
+}
+
+type analyzePendingPodsArgs struct {
+	Namespace string `json:"namespace,omitempty" jsonschema:"Namespace of the pods to analyze. Leave this empty to analyze all namespaces."`
+}
+
+// pendingPod is a Pending pod with the causes it is not running.
+type pendingPod struct {
+	name    string
+	age     time.Duration
+	message string
+	causes  []string
+}
+
+func (h *handlers) analyzePendingPods(ctx context.Context, _ *mcp.CallToolRequest, args *analyzePendingPodsArgs) (*mcp.CallToolResult, any, error) {
+	getArgs := []string{"pods", "--all-namespaces", "--field-selector=status.phase=Pending"}
+	if args.Namespace != "" {
+		getArgs = []string{"pods", "--namespace", args.Namespace, "--field-selector=status.phase=Pending"}
+	}
+	var pods kube.List[kube.Pod]
+	if err := kube.Get(ctx, &pods, getArgs...); err != nil {
+		return nil, nil, err
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: pendingPodsReport(pods.Items, time.Now())},
+		},
+	}, nil, nil
+}
+
+// schedulerReasons splits the message of the PodScheduled condition of an
+// unschedulable pod, e.g. "0/5 nodes are available: 2 Insufficient cpu, 3
+// node(s) had untolerated taint {dedicated: gpu}. preemption: ...", into
+// the reasons of the scheduler, without the preemption details.
+func schedulerReasons(message string) []string {
+	_, reasons, found := strings.Cut(message, "nodes are available: ")
+	if !found {
+		return []string{strings.TrimSuffix(strings.TrimSpace(message), ".")}
+	}
+	if i := strings.Index(reasons, " preemption:"); i >= 0 {
+		reasons = reasons[:i]
+	}
+	var out []string
+	for _, reason := range strings.Split(strings.TrimSuffix(strings.TrimSpace(reasons), "."), ", ") {
+		if m := schedulerReasonRegexp.FindStringSubmatch(reason); m != nil {
+			reason = m[2]
+		}
+		if reason = strings.TrimSpace(reason); reason != "" {
+			out = append(out, reason)
+		}
+	}
+	return out
+}
+
+// classifyPendingPod returns why a Pending pod is not running.
+func classifyPendingPod(pod kube.Pod, now time.Time) pendingPod {
+	p := pendingPod{name: pod.Namespace + "/" + pod.Name, age: now.Sub(pod.CreationTimestamp.Time).Round(time.Second)}
+	for _, c := range pod.Status.Conditions {
+		if c.Type != "PodScheduled" {
+			continue
+		}
+		if c.Status == "True" {
+			p.causes = []string{"Scheduled, containers not started"}
+			p.message = "The pod is scheduled but its containers are not running yet, e.g. pulling images or mounting volumes."
+			return p
+		}
+		p.message = c.Message
+		for _, reason := range schedulerReasons(c.Message) {
+			cause := "Other: " + reason
+			for _, known := range schedulingCauses {
+				if known.matches(reason) {
+					cause = known.name
+					break
+				}
+			}
+			if !slices.Contains(p.causes, cause) {
+				p.causes = append(p.causes, cause)
+			}
+		}
+	}
+	if len(p.causes) == 0 {
+		p.causes = []string{"Not scheduled yet"}
+	}
+	return p
+}
+
+func pendingPodsReport(pods []kube.Pod, now time.Time) string {
+	var b strings.Builder
+	if len(pods) == 0 {
+		b.WriteString("No Pending pods.\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "Pending pods: %d\n", len(pods))
+
+	byCause := map[string][]pendingPod{}
+	for _, pod := range pods {
+		p := classifyPendingPod(pod, now)
+		for _, cause := range p.causes {
+			byCause[cause] = append(byCause[cause], p)
+		}
+	}
+	causes := make([]string, 0, len(byCause))
+	for cause := range byCause {
+		causes = append(causes, cause)
+	}
+	sort.Slice(causes, func(i, j int) bool {
+		if len(byCause[causes[i]]) != len(byCause[causes[j]]) {
+			return len(byCause[causes[i]]) > len(byCause[causes[j]])
+		}
+		return causes[i] < causes[j]
+	})
+
+	b.WriteString("\nCauses, by number of pods:\n")
+	for _, cause := range causes {
+		pending := byCause[cause]
+		sort.Slice(pending, func(i, j int) bool { return pending[i].age > pending[j].age })
+		fmt.Fprintf(&b, "\n%s: %d pods\n", cause, len(pending))
+		for _, known := range schedulingCauses {
+			if known.name == cause {
+				fmt.Fprintf(&b, "  Fix: %s\n", known.advice)
+			}
+		}
+		for i, p := range pending {
+			if i == maxPendingPodsListed {
+				fmt.Fprintf(&b, "  ... and %d more\n", len(pending)-maxPendingPodsListed)
+				break
+			}
+			fmt.Fprintf(&b, "  - %s (pending for %s): %s\n", p.name, p.age, p.message)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSchedulerReasons(t *testing.T) {
+	for _, tc := range []struct {
+		message string
+		want    []string
+	}{
+		{
+			message: "0/5 nodes are available: 2 Insufficient cpu, 3 node(s) had untolerated taint {dedicated: gpu}. preemption: 0/5 nodes are available: 5 Preemption is not helpful for scheduling.",
+			want:    []string{"Insufficient cpu", "node(s) had untolerated taint {dedicated: gpu}"},
+		},
+		{
+			message: "0/3 nodes are available: pod has unbound immediate PersistentVolumeClaims.",
+			want:    []string{"pod has unbound immediate PersistentVolumeClaims"},
+		},
+		{
+			message: "no nodes available to schedule pods",
+			want:    []string{"no nodes available to schedule pods"},
+		},
+	} {
+		if got := schedulerReasons(tc.message); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("schedulerReasons(%q) = %q, want %q", tc.message, got, tc.want)
+		}
+	}
+}
+
+func TestPendingPodsReport(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	pod := func(name, scheduled, message string, age time.Duration) kube.Pod {
+		p := kube.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, CreationTimestamp: metav1.NewTime(now.Add(-age))}}
+		p.Status.Phase = "Pending"
+		if scheduled != "" {
+			p.Status.Conditions = []kube.Condition{{Type: "PodScheduled", Status: scheduled, Reason: "Unschedulable", Message: message}}
+		}
+		return p
+	}
+	pods := []kube.Pod{
+		pod("web-1", "False", "0/5 nodes are available: 2 Insufficient cpu, 3 node(s) had untolerated taint {dedicated: gpu}. preemption: not helpful.", 10*time.Minute),
+		pod("web-2", "False", "0/5 nodes are available: 5 Insufficient cpu.", 5*time.Minute),
+		pod("db-0", "False", "0/3 nodes are available: 3 node(s) had volume node affinity conflict.", time.Hour),
+		pod("job-1", "True", "", time.Minute),
+		pod("new", "", "", time.Second),
+		pod("odd", "False", "0/1 nodes are available: 1 something unexpected.", time.Minute),
+	}
+
+	got := pendingPodsReport(pods, now)
+	for _, want := range []string{
+		"Pending pods: 6\n",
+		"\nInsufficient CPU: 2 pods\n  Fix: Lower the CPU requests",
+		"  - default/web-1 (pending for 10m0s): 0/5 nodes are available",
+		"\nTaint mismatch: 1 pods\n",
+		"\nVolume zone conflict: 1 pods\n",
+		"\nScheduled, containers not started: 1 pods\n",
+		"\nNot scheduled yet: 1 pods\n",
+		"\nOther: something unexpected: 1 pods\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("pendingPodsReport() missing %q, got:\n%s", want, got)
+		}
+	}
+	if i, j := strings.Index(got, "Insufficient CPU"), strings.Index(got, "Taint mismatch"); i > j {
+		t.Errorf("pendingPodsReport() lists the causes of fewer pods first, got:\n%s", got)
+	}
+
+	if got := pendingPodsReport(nil, now); got != "No Pending pods.\n" {
+		t.Errorf("pendingPodsReport(nil) = %q", got)
+	}
+}
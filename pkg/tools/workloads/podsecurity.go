@@ -0,0 +1,235 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	pspAnnotation      = "kubernetes.io/psp"
+	psaEnforceLabel    = "pod-security.kubernetes.io/enforce"
+	psaAuditLabel      = "pod-security.kubernetes.io/audit"
+	psaWarnLabel       = "pod-security.kubernetes.io/warn"
+	psaLevelPrivileged = "privileged"
+	psaLevelBaseline   = "baseline"
+	psaLevelRestricted = "restricted"
+)
+
+// psaLevels are the Pod Security Standards levels, from the most to the least restrictive.
+var psaLevels = []string{psaLevelRestricted, psaLevelBaseline, psaLevelPrivileged}
+
+// baselineCapabilities are the capabilities the baseline level allows to add.
+var baselineCapabilities = []string{
+	"AUDIT_WRITE", "CHOWN", "DAC_OVERRIDE", "FOWNER", "FSETID", "KILL", "MKNOD",
+	"NET_BIND_SERVICE", "SETFCAP", "SETGID", "SETPCAP", "SETUID", "SYS_CHROOT",
+}
+
+// restrictedVolumes are the volume types the restricted level allows.
+var restrictedVolumes = []string{
+	"configMap", "csi", "downwardAPI", "emptyDir", "ephemeral", "persistentVolumeClaim", "projected", "secret",
+}
+
+type checkPodSecurityMigrationArgs struct{}
+
+func (h *handlers) checkPodSecurityMigration(ctx context.Context, _ *mcp.CallToolRequest, _ *checkPodSecurityMigrationArgs) (*mcp.CallToolResult, any, error) {
+	var namespaces kube.List[kube.Namespace]
+	if err := kube.Get(ctx, &namespaces, "namespaces"); err != nil {
+		return nil, nil, err
+	}
+
+	var b strings.Builder
+	var psps kube.List[kube.PodSecurityPolicy]
+	pspErr := kube.Get(ctx, &psps, "podsecuritypolicies.policy")
+	var pods kube.List[kube.Pod]
+	podsErr := kube.Get(ctx, &pods, "pods", "--all-namespaces")
+	b.WriteString(podSecurityReport(psps.Items, pspErr == nil, pods.Items, namespaces.Items))
+	if podsErr != nil {
+		fmt.Fprintf(&b, "\nPods admitted by PodSecurityPolicies were not analyzed: %v\n", podsErr)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: b.String()},
+		},
+	}, nil, nil
+}
+
+// psaLevel returns the least permissive Pod Security Standards level which
+// admits every pod the PodSecurityPolicy admits, with the reasons it is not
+// more restrictive.
+func psaLevel(psp kube.PodSecurityPolicy) (string, []string) {
+	spec := psp.Spec
+
+	var privileged []string
+	if spec.Privileged {
+		privileged = append(privileged, "allows privileged containers")
+	}
+	if spec.HostNetwork || spec.HostPID || spec.HostIPC {
+		privileged = append(privileged, "allows host namespaces")
+	}
+	if len(spec.HostPorts) > 0 {
+		privileged = append(privileged, "allows host ports")
+	}
+	if slices.Contains(spec.Volumes, "*") || slices.Contains(spec.Volumes, "hostPath") {
+		privileged = append(privileged, "allows hostPath volumes")
+	}
+	for _, c := range spec.AllowedCapabilities {
+		if c == "*" || !slices.Contains(baselineCapabilities, strings.TrimPrefix(c, "CAP_")) {
+			privileged = append(privileged, fmt.Sprintf("allows adding capability %s", c))
+		}
+	}
+	if len(privileged) > 0 {
+		return psaLevelPrivileged, privileged
+	}
+
+	var baseline []string
+	if !slices.Contains(spec.RequiredDropCapabilities, "ALL") {
+		baseline = append(baseline, "does not require dropping ALL capabilities")
+	}
+	for _, c := range spec.AllowedCapabilities {
+		if strings.TrimPrefix(c, "CAP_") != "NET_BIND_SERVICE" {
+			baseline = append(baseline, fmt.Sprintf("allows adding capability %s", c))
+		}
+	}
+	if spec.AllowPrivilegeEscalation == nil || *spec.AllowPrivilegeEscalation {
+		baseline = append(baseline, "allows privilege escalation")
+	}
+	if spec.RunAsUser.Rule != "MustRunAsNonRoot" {
+		baseline = append(baseline, "allows running as root")
+	}
+	for _, v := range spec.Volumes {
+		if !slices.Contains(restrictedVolumes, v) {
+			baseline = append(baseline, fmt.Sprintf("allows %s volumes", v))
+		}
+	}
+	if len(baseline) > 0 {
+		return psaLevelBaseline, baseline
+	}
+	return psaLevelRestricted, nil
+}
+
+// leastRestrictive returns the least restrictive of the Pod Security Standards levels.
+func leastRestrictive(levels []string) string {
+	level := psaLevelRestricted
+	for _, l := range levels {
+		if slices.Index(psaLevels, l) > slices.Index(psaLevels, level) {
+			level = l
+		}
+	}
+	return level
+}
+
+// isGKEManagedNamespace reports whether the namespace is managed by GKE and
+// exempted from Pod Security Admission.
+func isGKEManagedNamespace(name string) bool {
+	return strings.HasPrefix(name, "kube-") || strings.HasPrefix(name, "gke-") || strings.HasPrefix(name, "gmp-")
+}
+
+func podSecurityReport(psps []kube.PodSecurityPolicy, pspServed bool, pods []kube.Pod, namespaces []kube.Namespace) string {
+	var b strings.Builder
+
+	levels := map[string]string{}
+	if !pspServed {
+		b.WriteString("The PodSecurityPolicy API is not served by the cluster (it was removed in Kubernetes 1.25), PodSecurityPolicies are not in use.\n")
+	} else if len(psps) == 0 {
+		b.WriteString("No PodSecurityPolicies found.\n")
+	} else {
+		sort.Slice(psps, func(i, j int) bool { return psps[i].Name < psps[j].Name })
+		b.WriteString("PodSecurityPolicies and the equivalent Pod Security Admission level:\n")
+		for _, psp := range psps {
+			level, reasons := psaLevel(psp)
+			levels[psp.Name] = level
+			fmt.Fprintf(&b, "- %s: %s", psp.Name, level)
+			if len(reasons) > 0 {
+				fmt.Fprintf(&b, " (%s)", strings.Join(reasons, "; "))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	// The namespaces pods were admitted in, by the PodSecurityPolicy which admitted them.
+	usage := map[string]map[string]int{}
+	for _, pod := range pods {
+		psp := pod.Annotations[pspAnnotation]
+		if psp == "" {
+			continue
+		}
+		if usage[pod.Namespace] == nil {
+			usage[pod.Namespace] = map[string]int{}
+		}
+		usage[pod.Namespace][psp]++
+	}
+	if len(usage) > 0 {
+		b.WriteString("\nPods admitted by PodSecurityPolicies, by namespace:\n")
+		for _, ns := range slices.Sorted(maps.Keys(usage)) {
+			var used []string
+			for _, psp := range slices.Sorted(maps.Keys(usage[ns])) {
+				level, ok := levels[psp]
+				if !ok {
+					level = "policy not found"
+				}
+				used = append(used, fmt.Sprintf("%s (%d pods, %s)", psp, usage[ns][psp], level))
+			}
+			fmt.Fprintf(&b, "- %s: %s\n", ns, strings.Join(used, ", "))
+		}
+	}
+
+	sort.Slice(namespaces, func(i, j int) bool { return namespaces[i].Name < namespaces[j].Name })
+	var unlabeled []string
+	for _, ns := range namespaces {
+		if isGKEManagedNamespace(ns.Name) || ns.Labels[psaEnforceLabel] != "" {
+			continue
+		}
+		line := fmt.Sprintf("- %s", ns.Name)
+		var modes []string
+		for _, label := range []string{psaAuditLabel, psaWarnLabel} {
+			if level := ns.Labels[label]; level != "" {
+				modes = append(modes, fmt.Sprintf("%s=%s", label, level))
+			}
+		}
+		if len(modes) > 0 {
+			line += fmt.Sprintf(" (has %s)", strings.Join(modes, ", "))
+		}
+		if used, ok := usage[ns.Name]; ok {
+			var nsLevels []string
+			for psp := range used {
+				if level, ok := levels[psp]; ok {
+					nsLevels = append(nsLevels, level)
+				}
+			}
+			if len(nsLevels) > 0 {
+				line += fmt.Sprintf(", suggested level: %s", leastRestrictive(nsLevels))
+			}
+		}
+		unlabeled = append(unlabeled, line)
+	}
+	if len(unlabeled) == 0 {
+		fmt.Fprintf(&b, "\nAll namespaces have the %s label.\n", psaEnforceLabel)
+		return b.String()
+	}
+	fmt.Fprintf(&b, "\nNamespaces without the %s label, pods in them are not checked against any Pod Security Standard:\n", psaEnforceLabel)
+	b.WriteString(strings.Join(unlabeled, "\n"))
+	b.WriteString("\n")
+	return b.String()
+}
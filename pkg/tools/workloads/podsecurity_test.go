@@ -0,0 +1,127 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func restrictedPSP(name string) kube.PodSecurityPolicy {
+	psp := kube.PodSecurityPolicy{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	psp.Spec.RequiredDropCapabilities = []string{"ALL"}
+	psp.Spec.AllowPrivilegeEscalation = ptr.To(false)
+	psp.Spec.RunAsUser.Rule = "MustRunAsNonRoot"
+	psp.Spec.Volumes = []string{"configMap", "secret", "emptyDir", "persistentVolumeClaim"}
+	return psp
+}
+
+func TestPSALevel(t *testing.T) {
+	privileged := restrictedPSP("privileged")
+	privileged.Spec.Privileged = true
+	privileged.Spec.Volumes = []string{"*"}
+
+	hostNetwork := restrictedPSP("host-network")
+	hostNetwork.Spec.HostNetwork = true
+
+	sysAdmin := restrictedPSP("sys-admin")
+	sysAdmin.Spec.AllowedCapabilities = []string{"SYS_ADMIN"}
+
+	root := restrictedPSP("root")
+	root.Spec.RunAsUser.Rule = "RunAsAny"
+	root.Spec.AllowedCapabilities = []string{"CHOWN"}
+
+	bindService := restrictedPSP("bind-service")
+	bindService.Spec.AllowedCapabilities = []string{"NET_BIND_SERVICE"}
+
+	tests := []struct {
+		psp         kube.PodSecurityPolicy
+		wantLevel   string
+		wantReasons []string
+	}{
+		{privileged, "privileged", []string{"allows privileged containers", "allows hostPath volumes"}},
+		{hostNetwork, "privileged", []string{"allows host namespaces"}},
+		{sysAdmin, "privileged", []string{"allows adding capability SYS_ADMIN"}},
+		{root, "baseline", []string{"allows adding capability CHOWN", "allows running as root"}},
+		{bindService, "restricted", nil},
+		{restrictedPSP("restricted"), "restricted", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.psp.Name, func(t *testing.T) {
+			level, reasons := psaLevel(tt.psp)
+			if level != tt.wantLevel {
+				t.Errorf("psaLevel() level = %q, want %q", level, tt.wantLevel)
+			}
+			if !slices.Equal(reasons, tt.wantReasons) {
+				t.Errorf("psaLevel() reasons = %q, want %q", reasons, tt.wantReasons)
+			}
+		})
+	}
+}
+
+func TestPodSecurityReport(t *testing.T) {
+	privileged := restrictedPSP("privileged")
+	privileged.Spec.Privileged = true
+	psps := []kube.PodSecurityPolicy{restrictedPSP("restricted"), privileged}
+	pods := []kube.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "web", Annotations: map[string]string{pspAnnotation: "restricted"}}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "monitoring", Name: "agent-1", Annotations: map[string]string{pspAnnotation: "privileged"}}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "monitoring", Name: "agent-2", Annotations: map[string]string{pspAnnotation: "privileged"}}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "monitoring", Name: "exporter", Annotations: map[string]string{pspAnnotation: "restricted"}}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "unannotated"}},
+	}
+	namespaces := []kube.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "app"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "monitoring", Labels: map[string]string{psaWarnLabel: "baseline"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "secured", Labels: map[string]string{psaEnforceLabel: "restricted"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+	}
+
+	got := podSecurityReport(psps, true, pods, namespaces)
+	for _, want := range []string{
+		"- privileged: privileged (allows privileged containers)",
+		"- restricted: restricted\n",
+		"- monitoring: privileged (2 pods, privileged), restricted (1 pods, restricted)",
+		"- app, suggested level: restricted",
+		"- monitoring (has pod-security.kubernetes.io/warn=baseline), suggested level: privileged",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("podSecurityReport() missing %q, got:\n%s", want, got)
+		}
+	}
+	for _, unwanted := range []string{"secured", "kube-system"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("podSecurityReport() should not report namespace %s, got:\n%s", unwanted, got)
+		}
+	}
+}
+
+func TestPodSecurityReport_PSPNotServed(t *testing.T) {
+	namespaces := []kube.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "app", Labels: map[string]string{psaEnforceLabel: "baseline"}}},
+	}
+
+	got := podSecurityReport(nil, false, nil, namespaces)
+	for _, want := range []string{"PodSecurityPolicy API is not served", "All namespaces have the pod-security.kubernetes.io/enforce label."} {
+		if !strings.Contains(got, want) {
+			t.Errorf("podSecurityReport() missing %q, got:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// preemptNever is the preemption policy of the PriorityClasses whose pods
+// never preempt other pods.
+const preemptNever = "Never"
+
+type auditPriorityClassesArgs struct {
+	NamespaceSelector string `json:"namespace_selector,omitempty" jsonschema:"Label selector of the namespaces running production-critical workloads. Leave this empty to use the production-critical label."`
+}
+
+func (h *handlers) auditPriorityClasses(ctx context.Context, _ *mcp.CallToolRequest, args *auditPriorityClassesArgs) (*mcp.CallToolResult, any, error) {
+	selector := args.NamespaceSelector
+	if selector == "" {
+		selector = defaultCriticalNamespaceSelector
+	}
+
+	var classes kube.List[kube.PriorityClass]
+	if err := kube.Get(ctx, &classes, "priorityclasses"); err != nil {
+		return nil, nil, err
+	}
+	var namespaces kube.List[kube.Namespace]
+	if err := kube.Get(ctx, &namespaces, "namespaces", "-l", selector); err != nil {
+		return nil, nil, err
+	}
+	var deployments kube.List[kube.Deployment]
+	if err := kube.Get(ctx, &deployments, "deployments", "--all-namespaces"); err != nil {
+		return nil, nil, err
+	}
+	var statefulSets kube.List[kube.StatefulSet]
+	if err := kube.Get(ctx, &statefulSets, "statefulsets", "--all-namespaces"); err != nil {
+		return nil, nil, err
+	}
+
+	var workloads []replicatedWorkload
+	for _, d := range deployments.Items {
+		workloads = append(workloads, replicatedWorkload{kind: "Deployment", meta: d.ObjectMeta, replicas: replicas(d.Spec.Replicas), template: d.Spec.Template})
+	}
+	for _, s := range statefulSets.Items {
+		workloads = append(workloads, replicatedWorkload{kind: "StatefulSet", meta: s.ObjectMeta, replicas: replicas(s.Spec.Replicas), template: s.Spec.Template})
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: priorityClassesReport(selector, classes.Items, namespaces.Items, workloads)},
+		},
+	}, nil, nil
+}
+
+// preempts reports whether the pods of a PriorityClass preempt lower
+// priority pods, which is the default.
+func preempts(class kube.PriorityClass) bool {
+	return class.PreemptionPolicy != preemptNever
+}
+
+// workloadPriority returns the priority of the pods of a workload and the
+// PriorityClass giving it, if any. Pods without a priorityClassName get the
+// global default PriorityClass, or priority 0.
+func workloadPriority(w replicatedWorkload, classes map[string]kube.PriorityClass, globalDefault *kube.PriorityClass) (int32, *kube.PriorityClass) {
+	if name := w.template.Spec.PriorityClassName; name != "" {
+		if class, ok := classes[name]; ok {
+			return class.Value, &class
+		}
+		return 0, nil
+	}
+	if globalDefault != nil {
+		return globalDefault.Value, globalDefault
+	}
+	return 0, nil
+}
+
+func priorityClassesReport(selector string, classList []kube.PriorityClass, namespaces []kube.Namespace, workloads []replicatedWorkload) string {
+	var b strings.Builder
+
+	sort.Slice(classList, func(i, j int) bool { return classList[i].Value > classList[j].Value })
+	classes := map[string]kube.PriorityClass{}
+	var globalDefaults []kube.PriorityClass
+	b.WriteString("PriorityClasses, from the highest priority:\n")
+	for _, class := range classList {
+		classes[class.Name] = class
+		policy := class.PreemptionPolicy
+		if policy == "" {
+			policy = "PreemptLowerPriority"
+		}
+		fmt.Fprintf(&b, "- %s: value %d, preemption policy %s", class.Name, class.Value, policy)
+		if class.GlobalDefault {
+			b.WriteString(", global default")
+			globalDefaults = append(globalDefaults, class)
+		}
+		b.WriteString("\n")
+	}
+	// With several global defaults, the one with the lowest value applies.
+	var globalDefault *kube.PriorityClass
+	if len(globalDefaults) > 0 {
+		globalDefault = &globalDefaults[len(globalDefaults)-1]
+	}
+
+	var findings []string
+	if len(globalDefaults) > 1 {
+		findings = append(findings, fmt.Sprintf("- %d PriorityClasses are global defaults, only %s applies to the pods without a priorityClassName.", len(globalDefaults), globalDefault.Name))
+	}
+	if globalDefault != nil && globalDefault.Value > 0 && preempts(*globalDefault) {
+		findings = append(findings, fmt.Sprintf("- The global default PriorityClass %s preempts lower priority pods. Every pod without a priorityClassName can evict pods of the lower PriorityClasses when upgraded nodes are full, cascading evictions during node churn. Set its preemptionPolicy to Never.", globalDefault.Name))
+	}
+
+	critical := map[string]bool{}
+	for _, ns := range namespaces {
+		critical[ns.Name] = true
+	}
+	// lowestCritical is the lowest priority of the critical workloads.
+	var lowestCritical *int32
+	var criticalFindings []string
+	for _, w := range workloads {
+		if !critical[w.meta.Namespace] || w.replicas == 0 {
+			continue
+		}
+		name := w.template.Spec.PriorityClassName
+		priority, class := workloadPriority(w, classes, globalDefault)
+		switch {
+		case name != "" && class == nil:
+			criticalFindings = append(criticalFindings, fmt.Sprintf("- %s/%s %s: PriorityClass %s does not exist, its pods are rejected when they are recreated.", w.meta.Namespace, w.kind, w.meta.Name, name))
+		case name == "":
+			criticalFindings = append(criticalFindings, fmt.Sprintf("- %s/%s %s: no priorityClassName, its pods run at priority %d and can be preempted by higher priority pods, or stay Pending behind them, when node upgrades reschedule them.", w.meta.Namespace, w.kind, w.meta.Name, priority))
+		}
+		if lowestCritical == nil || priority < *lowestCritical {
+			lowestCritical = &priority
+		}
+	}
+
+	// Non-critical workloads preempting critical ones turn the node churn
+	// of an upgrade into evictions of the critical workloads.
+	var preemptors []string
+	if lowestCritical != nil {
+		for _, w := range workloads {
+			if critical[w.meta.Namespace] || w.replicas == 0 {
+				continue
+			}
+			priority, class := workloadPriority(w, classes, globalDefault)
+			if class != nil && preempts(*class) && priority > *lowestCritical {
+				preemptors = append(preemptors, fmt.Sprintf("- %s/%s %s: PriorityClass %s (value %d) can preempt production-critical pods of priority %d.", w.meta.Namespace, w.kind, w.meta.Name, class.Name, priority, *lowestCritical))
+			}
+		}
+	}
+
+	if len(namespaces) == 0 {
+		fmt.Fprintf(&b, "\nNo namespaces match the label selector %q. Label the namespaces running production-critical workloads, or provide their label selector.\n", selector)
+	} else if len(criticalFindings) > 0 {
+		fmt.Fprintf(&b, "\nProduction-critical workloads (%s) at risk during node churn. Give them a PriorityClass higher than the other workloads:\n", selector)
+		b.WriteString(strings.Join(criticalFindings, "\n"))
+		b.WriteString("\n")
+	}
+	if len(preemptors) > 0 {
+		b.WriteString("\nWorkloads outside of the production-critical namespaces which preempt production-critical workloads. Lower their priority or set the preemptionPolicy of their PriorityClass to Never:\n")
+		b.WriteString(strings.Join(preemptors, "\n"))
+		b.WriteString("\n")
+	}
+	if len(findings) > 0 {
+		b.WriteString("\nPriorityClass configuration issues:\n")
+		b.WriteString(strings.Join(findings, "\n"))
+		b.WriteString("\n")
+	}
+	if len(namespaces) > 0 && len(criticalFindings) == 0 && len(preemptors) == 0 && len(findings) == 0 {
+		b.WriteString("\nProduction-critical workloads have a PriorityClass, and no other workload can preempt them.\n")
+	}
+	return b.String()
+}
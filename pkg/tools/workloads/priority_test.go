@@ -0,0 +1,88 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPriorityClassesReport(t *testing.T) {
+	class := func(name string, value int32, globalDefault bool, policy string) kube.PriorityClass {
+		return kube.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: name}, Value: value, GlobalDefault: globalDefault, PreemptionPolicy: policy}
+	}
+	workload := func(namespace, name, priorityClassName string) replicatedWorkload {
+		return replicatedWorkload{
+			kind:     "Deployment",
+			meta:     metav1.ObjectMeta{Namespace: namespace, Name: name},
+			replicas: 2,
+			template: kube.PodTemplateSpec{Spec: kube.PodSpec{PriorityClassName: priorityClassName}},
+		}
+	}
+	classes := []kube.PriorityClass{
+		class("system-cluster-critical", 2000000000, false, ""),
+		class("batch-high", 100000, false, ""),
+		class("batch-never", 100000, false, "Never"),
+		class("business-critical", 50000, false, ""),
+		class("default-priority", 1000, true, ""),
+	}
+	namespaces := []kube.Namespace{{ObjectMeta: metav1.ObjectMeta{Name: "payments"}}}
+	workloads := []replicatedWorkload{
+		workload("payments", "api", "business-critical"),
+		workload("payments", "worker", ""),
+		workload("payments", "ledger", "missing-class"),
+		workload("batch", "etl", "batch-high"),
+		workload("batch", "reports", "batch-never"),
+	}
+
+	got := priorityClassesReport("production-critical", classes, namespaces, workloads)
+	for _, want := range []string{
+		"- system-cluster-critical: value 2000000000, preemption policy PreemptLowerPriority\n",
+		"- batch-never: value 100000, preemption policy Never\n",
+		"- default-priority: value 1000, preemption policy PreemptLowerPriority, global default\n",
+		"- payments/Deployment worker: no priorityClassName, its pods run at priority 1000",
+		"- payments/Deployment ledger: PriorityClass missing-class does not exist",
+		"- batch/Deployment etl: PriorityClass batch-high (value 100000) can preempt production-critical pods of priority 0.",
+		"- The global default PriorityClass default-priority preempts lower priority pods.",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("priorityClassesReport() missing %q, got:\n%s", want, got)
+		}
+	}
+	for _, unwanted := range []string{"payments/Deployment api", "batch/Deployment reports"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("priorityClassesReport() contains %q, got:\n%s", unwanted, got)
+		}
+	}
+}
+
+func TestPriorityClassesReport_NoIssues(t *testing.T) {
+	classes := []kube.PriorityClass{{ObjectMeta: metav1.ObjectMeta{Name: "business-critical"}, Value: 50000}}
+	namespaces := []kube.Namespace{{ObjectMeta: metav1.ObjectMeta{Name: "payments"}}}
+	workloads := []replicatedWorkload{{
+		kind:     "StatefulSet",
+		meta:     metav1.ObjectMeta{Namespace: "payments", Name: "db"},
+		replicas: 3,
+		template: kube.PodTemplateSpec{Spec: kube.PodSpec{PriorityClassName: "business-critical"}},
+	}}
+
+	got := priorityClassesReport("production-critical", classes, namespaces, workloads)
+	if !strings.Contains(got, "Production-critical workloads have a PriorityClass, and no other workload can preempt them.") {
+		t.Errorf("priorityClassesReport() = %q, want no issues", got)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// maxLimitRequestRatio is the limit to request ratio above which a container
+// is likely to be throttled or OOM-killed when its node becomes busy.
+const maxLimitRequestRatio = 4
+
+type checkResourceRequestsArgs struct{}
+
+func (h *handlers) checkResourceRequests(ctx context.Context, _ *mcp.CallToolRequest, _ *checkResourceRequestsArgs) (*mcp.CallToolResult, any, error) {
+	var pods kube.List[kube.Pod]
+	if err := kube.Get(ctx, &pods, "pods", "--all-namespaces"); err != nil {
+		return nil, nil, err
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: resourceRequestsReport(pods.Items)},
+		},
+	}, nil, nil
+}
+
+// containerResourceIssues returns the problems of the resource requests and limits of a container.
+func containerResourceIssues(c kube.Container) []string {
+	var issues []string
+	for _, name := range []string{"cpu", "memory"} {
+		request, hasRequest := c.Resources.Requests[name]
+		limit, hasLimit := c.Resources.Limits[name]
+		if !hasRequest && !hasLimit {
+			// A limit without a request sets the request to the limit.
+			issues = append(issues, fmt.Sprintf("container %s has no %s request", c.Name, name))
+			continue
+		}
+		if !hasRequest || !hasLimit || request.IsZero() {
+			continue
+		}
+		if ratio := limit.AsApproximateFloat64() / request.AsApproximateFloat64(); ratio > maxLimitRequestRatio {
+			issues = append(issues, fmt.Sprintf("container %s %s limit is %.0fx its request (%s/%s)", c.Name, name, ratio, limit.String(), request.String()))
+		}
+	}
+	return issues
+}
+
+func resourceRequestsReport(pods []kube.Pod) string {
+	issues := map[string][]string{}
+	qosClasses := map[string]map[string]bool{}
+	for _, pod := range pods {
+		if isGKEManagedNamespace(pod.Namespace) || pod.Status.Phase == "Succeeded" || pod.Status.Phase == "Failed" {
+			continue
+		}
+		name := workloadName(pod)
+		for _, c := range slices.Concat(pod.Spec.InitContainers, pod.Spec.Containers) {
+			for _, issue := range containerResourceIssues(c) {
+				if !slices.Contains(issues[name], issue) {
+					issues[name] = append(issues[name], issue)
+				}
+			}
+		}
+		if qos := pod.Status.QOSClass; qos != "" {
+			if qosClasses[qos] == nil {
+				qosClasses[qos] = map[string]bool{}
+			}
+			qosClasses[qos][name] = true
+		}
+	}
+
+	var b strings.Builder
+	if len(issues) == 0 {
+		b.WriteString("All workloads request CPU and memory, with limits at most 4x their requests.\n")
+	} else {
+		b.WriteString("Workloads with missing requests or large limit to request ratios. The scheduler cannot place them reliably when nodes are recreated, and they are throttled, OOM-killed or evicted first when the remaining nodes are busy:\n")
+		for _, name := range slices.Sorted(maps.Keys(issues)) {
+			fmt.Fprintf(&b, "- %s: %s\n", name, strings.Join(issues[name], "; "))
+		}
+	}
+
+	if bestEffort := qosClasses["BestEffort"]; len(bestEffort) > 0 {
+		b.WriteString("\nBestEffort workloads, evicted first under node pressure during surge upgrades:\n")
+		for _, name := range slices.Sorted(maps.Keys(bestEffort)) {
+			fmt.Fprintf(&b, "- %s\n", name)
+		}
+	}
+	if guaranteed := qosClasses["Guaranteed"]; len(guaranteed) > 0 {
+		b.WriteString("\nGuaranteed workloads, their pods stay Pending until a node with their full requests free is available. Check the node pools have enough surge capacity:\n")
+		for _, name := range slices.Sorted(maps.Keys(guaranteed)) {
+			fmt.Fprintf(&b, "- %s\n", name)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func resources(requests, limits map[string]string) kube.ResourceRequirements {
+	r := kube.ResourceRequirements{Requests: map[string]resource.Quantity{}, Limits: map[string]resource.Quantity{}}
+	for name, q := range requests {
+		r.Requests[name] = resource.MustParse(q)
+	}
+	for name, q := range limits {
+		r.Limits[name] = resource.MustParse(q)
+	}
+	return r
+}
+
+func TestContainerResourceIssues(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources kube.ResourceRequirements
+		want      []string
+	}{
+		{
+			name:      "requests and limits",
+			resources: resources(map[string]string{"cpu": "500m", "memory": "1Gi"}, map[string]string{"cpu": "1", "memory": "2Gi"}),
+		},
+		{
+			name:      "limits only",
+			resources: resources(nil, map[string]string{"cpu": "1", "memory": "2Gi"}),
+		},
+		{
+			name:      "no resources",
+			resources: resources(nil, nil),
+			want:      []string{"container app has no cpu request", "container app has no memory request"},
+		},
+		{
+			name:      "large ratio",
+			resources: resources(map[string]string{"cpu": "100m", "memory": "256Mi"}, map[string]string{"cpu": "2", "memory": "512Mi"}),
+			want:      []string{"container app cpu limit is 20x its request (2/100m)"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := containerResourceIssues(kube.Container{Name: "app", Resources: tt.resources})
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("containerResourceIssues() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceRequestsReport(t *testing.T) {
+	pod := func(namespace, name, qos string, r kube.ResourceRequirements) kube.Pod {
+		return kube.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, OwnerReferences: ownedBy("ReplicaSet")},
+			Spec:       kube.PodSpec{Containers: []kube.Container{{Name: "app", Resources: r}}},
+			Status:     kube.PodStatus{Phase: "Running", QOSClass: qos},
+		}
+	}
+	guaranteed := resources(map[string]string{"cpu": "1", "memory": "1Gi"}, map[string]string{"cpu": "1", "memory": "1Gi"})
+	pods := []kube.Pod{
+		pod("app", "web-1", "BestEffort", resources(nil, nil)),
+		pod("app", "web-2", "BestEffort", resources(nil, nil)),
+		pod("app", "db-1", "Guaranteed", guaranteed),
+		pod("kube-system", "kube-dns-1", "BestEffort", resources(nil, nil)),
+	}
+	pods[0].OwnerReferences[0].Name = "web"
+	pods[1].OwnerReferences[0].Name = "web"
+	pods[2].OwnerReferences[0].Name = "db"
+
+	got := resourceRequestsReport(pods)
+	for _, want := range []string{
+		"- app/ReplicaSet web: container app has no cpu request; container app has no memory request\n",
+		"BestEffort workloads, evicted first under node pressure during surge upgrades:\n- app/ReplicaSet web\n",
+		"Guaranteed workloads",
+		"- app/ReplicaSet db\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("resourceRequestsReport() missing %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "kube-system") {
+		t.Errorf("resourceRequestsReport() should skip GKE managed namespaces, got:\n%s", got)
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// spotLabel and preemptibleLabel mark the Spot and preemptible nodes.
+	spotLabel        = "cloud.google.com/gke-spot"
+	preemptibleLabel = "cloud.google.com/gke-preemptible"
+	// safeToEvictAnnotation set to false marks pods which must not be
+	// interrupted.
+	safeToEvictAnnotation = "cluster-autoscaler.kubernetes.io/safe-to-evict"
+)
+
+type auditSpotPlacementArgs struct{}
+
+// placement is a workload whose placement on Spot or on-demand nodes is
+// questionable, with the reasons.
+type placement struct {
+	workload string
+	nodePool string
+	reasons  []string
+}
+
+func (h *handlers) auditSpotPlacement(ctx context.Context, _ *mcp.CallToolRequest, _ *auditSpotPlacementArgs) (*mcp.CallToolResult, any, error) {
+	var nodes kube.List[kube.Node]
+	if err := kube.Get(ctx, &nodes, "nodes"); err != nil {
+		return nil, nil, err
+	}
+	var pods kube.List[kube.Pod]
+	if err := kube.Get(ctx, &pods, "pods", "--all-namespaces"); err != nil {
+		return nil, nil, err
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: spotPlacementReport(nodes.Items, pods.Items)},
+		},
+	}, nil, nil
+}
+
+// isSpotNode reports whether the node is a Spot or preemptible VM.
+func isSpotNode(node kube.Node) bool {
+	return node.Labels[spotLabel] == "true" || node.Labels[preemptibleLabel] == "true"
+}
+
+// interruptionRisks returns why a pod should not run on nodes which can be
+// reclaimed at any time.
+func interruptionRisks(pod kube.Pod) []string {
+	var risks []string
+	owner := controller(pod)
+	switch {
+	case owner == nil:
+		risks = append(risks, "bare pod, not recreated when its node is reclaimed")
+	case owner.Kind == "StatefulSet":
+		risks = append(risks, "StatefulSet pod, with a stable identity and usually stateful")
+	case owner.Kind == "Job" && pod.Spec.RestartPolicy == "Never":
+		// The backoff limit of the Job isn't known from the pod, so it is only
+		// a risk when the pod can't restart in place either.
+		risks = append(risks, "Job pod with restartPolicy Never, retried only within the backoff limit of the Job")
+	}
+	for _, v := range pod.Spec.Volumes {
+		if v.PersistentVolumeClaim != nil {
+			risks = append(risks, fmt.Sprintf("mounts PersistentVolumeClaim %s, detached and reattached elsewhere on preemption", v.PersistentVolumeClaim.ClaimName))
+		}
+	}
+	if pod.Annotations[safeToEvictAnnotation] == "false" {
+		risks = append(risks, fmt.Sprintf("annotated %s=false, it must not be interrupted", safeToEvictAnnotation))
+	}
+	return risks
+}
+
+// isStatelessBatch reports whether a pod is a retryable batch pod, which
+// could run on cheaper Spot nodes.
+func isStatelessBatch(pod kube.Pod) bool {
+	owner := controller(pod)
+	if owner == nil || owner.Kind != "Job" || pod.Annotations[safeToEvictAnnotation] == "false" {
+		return false
+	}
+	for _, v := range pod.Spec.Volumes {
+		if v.PersistentVolumeClaim != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func spotPlacementReport(nodes []kube.Node, pods []kube.Pod) string {
+	spot := map[string]bool{}
+	spotPools := map[string]bool{}
+	nodePools := map[string]string{}
+	for _, node := range nodes {
+		pool := node.Labels[gke.NodePoolLabel]
+		nodePools[node.Name] = pool
+		if isSpotNode(node) {
+			spot[node.Name] = true
+			spotPools[pool] = true
+		}
+	}
+
+	risky := map[string]*placement{}
+	batch := map[string]*placement{}
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" || pod.Status.Phase == "Succeeded" || pod.Status.Phase == "Failed" {
+			continue
+		}
+		if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+			continue
+		}
+		if owner := controller(pod); owner != nil && owner.Kind == "DaemonSet" {
+			continue
+		}
+		name := workloadName(pod)
+		pool := nodePools[pod.Spec.NodeName]
+		if spot[pod.Spec.NodeName] {
+			if risks := interruptionRisks(pod); len(risks) > 0 && risky[name] == nil {
+				risky[name] = &placement{workload: name, nodePool: pool, reasons: risks}
+			}
+		} else if isStatelessBatch(pod) && batch[name] == nil {
+			batch[name] = &placement{workload: name, nodePool: pool}
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Nodes: %d, of which %d Spot or preemptible\n", len(nodes), len(spot))
+	if len(spotPools) > 0 {
+		fmt.Fprintf(&b, "Spot node pools: %s\n", strings.Join(slices.Sorted(maps.Keys(spotPools)), ", "))
+	}
+
+	b.WriteString("\nStateful or un-retryable workloads on Spot nodes:\n")
+	if len(risky) == 0 {
+		b.WriteString("None.\n")
+	}
+	for _, p := range sortedPlacements(risky) {
+		fmt.Fprintf(&b, "- %s (node pool %s):\n", p.workload, p.nodePool)
+		for _, reason := range p.reasons {
+			fmt.Fprintf(&b, "  - %s\n", reason)
+		}
+	}
+	if len(risky) > 0 {
+		fmt.Fprintf(&b, "Spot nodes can be reclaimed at any time with a 30 seconds notice. Move these workloads to on-demand node pools, e.g. with a nodeSelector %s: \"false\" or a node affinity excluding Spot nodes.\n", spotLabel)
+	}
+
+	b.WriteString("\nStateless batch workloads on on-demand nodes:\n")
+	if len(batch) == 0 {
+		b.WriteString("None.\n")
+	}
+	for _, p := range sortedPlacements(batch) {
+		fmt.Fprintf(&b, "- %s (node pool %s)\n", p.workload, p.nodePool)
+	}
+	if len(batch) > 0 {
+		if len(spotPools) > 0 {
+			fmt.Fprintf(&b, "These Jobs are retried when interrupted and could run on the Spot node pools, at a 60-91%% discount, with a nodeSelector %s: \"true\" and a toleration of the taints of the Spot node pools.\n", spotLabel)
+		} else {
+			b.WriteString("These Jobs are retried when interrupted and could run on Spot VMs, at a 60-91% discount, in a Spot node pool or with Autopilot Spot Pods.\n")
+		}
+	}
+	return b.String()
+}
+
+func sortedPlacements(m map[string]*placement) []*placement {
+	placements := make([]*placement, 0, len(m))
+	for _, p := range m {
+		placements = append(placements, p)
+	}
+	sort.Slice(placements, func(i, j int) bool { return placements[i].workload < placements[j].workload })
+	return placements
+}
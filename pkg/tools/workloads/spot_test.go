@@ -0,0 +1,139 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestInterruptionRisks(t *testing.T) {
+	tests := []struct {
+		name string
+		pod  kube.Pod
+		want []string
+	}{
+		{
+			name: "stateless replica",
+			pod:  kube.Pod{ObjectMeta: metav1.ObjectMeta{OwnerReferences: ownedBy("ReplicaSet")}},
+		},
+		{
+			name: "bare pod",
+			pod:  kube.Pod{},
+			want: []string{"bare pod, not recreated when its node is reclaimed"},
+		},
+		{
+			name: "statefulset with a volume",
+			pod: kube.Pod{
+				ObjectMeta: metav1.ObjectMeta{OwnerReferences: ownedBy("StatefulSet")},
+				Spec: kube.PodSpec{Volumes: []kube.Volume{
+					{Name: "data", PersistentVolumeClaim: &kube.PersistentVolumeClaimVolumeSource{ClaimName: "data-db-0"}},
+				}},
+			},
+			want: []string{
+				"StatefulSet pod, with a stable identity and usually stateful",
+				"mounts PersistentVolumeClaim data-db-0, detached and reattached elsewhere on preemption",
+			},
+		},
+		{
+			name: "job without restarts",
+			pod: kube.Pod{
+				ObjectMeta: metav1.ObjectMeta{OwnerReferences: ownedBy("Job")},
+				Spec:       kube.PodSpec{RestartPolicy: "Never"},
+			},
+			want: []string{"Job pod with restartPolicy Never, retried only within the backoff limit of the Job"},
+		},
+		{
+			name: "not safe to evict",
+			pod: kube.Pod{ObjectMeta: metav1.ObjectMeta{
+				OwnerReferences: ownedBy("ReplicaSet"),
+				Annotations:     map[string]string{safeToEvictAnnotation: "false"},
+			}},
+			want: []string{"annotated cluster-autoscaler.kubernetes.io/safe-to-evict=false, it must not be interrupted"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, interruptionRisks(tt.pod)); diff != "" {
+				t.Errorf("interruptionRisks() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSpotPlacementReport(t *testing.T) {
+	nodes := []kube.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "spot-1", Labels: map[string]string{"cloud.google.com/gke-nodepool": "spot-pool", spotLabel: "true"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "std-1", Labels: map[string]string{"cloud.google.com/gke-nodepool": "default-pool"}}},
+	}
+	pod := func(namespace, name, node, ownerKind string) kube.Pod {
+		p := kube.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}, Spec: kube.PodSpec{NodeName: node}}
+		if ownerKind != "" {
+			p.OwnerReferences = ownedBy(ownerKind)
+		}
+		return p
+	}
+	pods := []kube.Pod{
+		pod("db", "postgres-0", "spot-1", "StatefulSet"),
+		pod("default", "debug", "spot-1", ""),
+		pod("default", "web-abc", "spot-1", "ReplicaSet"),
+		pod("kube-system", "fluentbit-x", "spot-1", "DaemonSet"),
+		pod("batch", "report-1", "std-1", "Job"),
+		pod("batch", "report-2", "std-1", "Job"),
+		pod("batch", "cleanup-1", "spot-1", "Job"),
+	}
+
+	got := spotPlacementReport(nodes, pods)
+	for _, want := range []string{
+		"Nodes: 2, of which 1 Spot or preemptible",
+		"Spot node pools: spot-pool",
+		"- db/StatefulSet owner (node pool spot-pool):",
+		"- default/Pod debug (node pool spot-pool):",
+		"- batch/Job owner (node pool default-pool)",
+		"could run on the Spot node pools",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("spotPlacementReport() = %q, want it to contain %q", got, want)
+		}
+	}
+	for _, unwanted := range []string{"ReplicaSet", "DaemonSet", "node pool spot-pool)\n"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("spotPlacementReport() = %q, want it not to contain %q", got, unwanted)
+		}
+	}
+	if n := strings.Count(got, "batch/Job owner"); n != 1 {
+		t.Errorf("spotPlacementReport() reports the batch Job %d times, want once", n)
+	}
+}
+
+func TestSpotPlacementReport_NoSpotNodes(t *testing.T) {
+	nodes := []kube.Node{{ObjectMeta: metav1.ObjectMeta{Name: "std-1", Labels: map[string]string{"cloud.google.com/gke-nodepool": "default-pool"}}}}
+	pods := []kube.Pod{{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "batch", Name: "etl-1", OwnerReferences: ownedBy("Job")},
+		Spec:       kube.PodSpec{NodeName: "std-1"},
+	}}
+
+	got := spotPlacementReport(nodes, pods)
+	if !strings.Contains(got, "Stateful or un-retryable workloads on Spot nodes:\nNone.") {
+		t.Errorf("spotPlacementReport() = %q, want no workloads on Spot nodes", got)
+	}
+	if !strings.Contains(got, "in a Spot node pool or with Autopilot Spot Pods") {
+		t.Errorf("spotPlacementReport() = %q, want it to suggest Spot VMs", got)
+	}
+}
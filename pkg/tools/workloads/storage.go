@@ -0,0 +1,260 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const defaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+
+// attachmentLimitWarningRatio is the share of the volume attach limit of a
+// node above which pods evicted from drained nodes may not fit on it.
+const attachmentLimitWarningRatio = 0.8
+
+type auditStorageArgs struct {
+	ProjectID     string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location      string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name          string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+	TargetVersion string `json:"target_version" jsonschema:"Target GKE or Kubernetes version of the upgrade, e.g. 1.33 or 1.33.2-gke.1240000."`
+}
+
+// storageInventory is the storage configuration of a cluster.
+type storageInventory struct {
+	statefulSets   []kube.StatefulSet
+	storageClasses []kube.StorageClass
+	volumes        []kube.PersistentVolume
+	csiNodes       []kube.CSINode
+	attachments    []kube.VolumeAttachment
+}
+
+func (h *handlers) auditStorage(ctx context.Context, _ *mcp.CallToolRequest, args *auditStorageArgs) (*mcp.CallToolResult, any, error) {
+	if args.TargetVersion == "" {
+		return nil, nil, fmt.Errorf("target_version argument cannot be empty")
+	}
+	cluster, err := gke.GetCluster(ctx, h.cmClient, h.c, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var inv storageInventory
+	var statefulSets kube.List[kube.StatefulSet]
+	if err := kube.Get(ctx, &statefulSets, "statefulsets", "--all-namespaces"); err != nil {
+		return nil, nil, err
+	}
+	inv.statefulSets = statefulSets.Items
+	var storageClasses kube.List[kube.StorageClass]
+	if err := kube.Get(ctx, &storageClasses, "storageclasses"); err != nil {
+		return nil, nil, err
+	}
+	inv.storageClasses = storageClasses.Items
+	var volumes kube.List[kube.PersistentVolume]
+	if err := kube.Get(ctx, &volumes, "persistentvolumes"); err != nil {
+		return nil, nil, err
+	}
+	inv.volumes = volumes.Items
+
+	var b strings.Builder
+	var csiNodes kube.List[kube.CSINode]
+	if err := kube.Get(ctx, &csiNodes, "csinodes"); err != nil {
+		fmt.Fprintf(&b, "\nVolume attach limits of the nodes were not analyzed: %v\n", err)
+	}
+	inv.csiNodes = csiNodes.Items
+	var attachments kube.List[kube.VolumeAttachment]
+	if err := kube.Get(ctx, &attachments, "volumeattachments"); err != nil {
+		fmt.Fprintf(&b, "\nVolume attachments were not analyzed: %v\n", err)
+	}
+	inv.attachments = attachments.Items
+
+	report, err := storageReport(cluster, args.TargetVersion, inv)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: report + b.String()},
+		},
+	}, nil, nil
+}
+
+// inTreePlugin returns the in-tree volume plugin of the PersistentVolume, or of the StorageClass provisioner.
+func inTreePlugin(plugin, provisioner string) (kube.InTreeVolumePlugin, bool) {
+	for _, p := range kube.InTreeVolumePlugins {
+		if (plugin != "" && p.Name == plugin) || (provisioner != "" && p.Provisioner == provisioner) {
+			return p, true
+		}
+	}
+	return kube.InTreeVolumePlugin{}, false
+}
+
+// inTreePluginImpact describes what happens to volumes of an in-tree plugin
+// once the cluster runs a version without it.
+func inTreePluginImpact(p kube.InTreeVolumePlugin, csiDrivers map[string]bool) string {
+	if !p.Migrated {
+		return "the volumes stop working, move the data to volumes of a CSI driver"
+	}
+	if !csiDrivers[p.CSIDriver] {
+		return fmt.Sprintf("the volumes are served through CSI migration by the %s CSI driver, which is not installed", p.CSIDriver)
+	}
+	return fmt.Sprintf("the volumes are served through CSI migration by the %s CSI driver", p.CSIDriver)
+}
+
+func storageReport(cluster *containerpb.Cluster, targetVersion string, inv storageInventory) (string, error) {
+	_, currentMinor, err := gke.MinorVersion(cluster.GetCurrentMasterVersion())
+	if err != nil {
+		return "", err
+	}
+	_, targetMinor, err := gke.MinorVersion(targetVersion)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cluster: %s, upgrade from %s to %s\n", cluster.GetName(), cluster.GetCurrentMasterVersion(), targetVersion)
+	fmt.Fprintf(&b, "Compute Engine persistent disk CSI driver enabled: %t\n", cluster.GetAddonsConfig().GetGcePersistentDiskCsiDriverConfig().GetEnabled())
+	fmt.Fprintf(&b, "Filestore CSI driver enabled: %t\n", cluster.GetAddonsConfig().GetGcpFilestoreCsiDriverConfig().GetEnabled())
+
+	// csiDrivers are the CSI drivers registered on at least one node.
+	csiDrivers := map[string]bool{}
+	for _, n := range inv.csiNodes {
+		for _, d := range n.Spec.Drivers {
+			csiDrivers[d.Name] = true
+		}
+	}
+	if cluster.GetAddonsConfig().GetGcePersistentDiskCsiDriverConfig().GetEnabled() {
+		csiDrivers["pd.csi.storage.gke.io"] = true
+	}
+
+	defaultClass := ""
+	b.WriteString("\nStorageClasses:\n")
+	for _, sc := range inv.storageClasses {
+		fmt.Fprintf(&b, "- %s: %s", sc.Name, sc.Provisioner)
+		if sc.Annotations[defaultStorageClassAnnotation] == "true" {
+			defaultClass = sc.Name
+			b.WriteString(" (default)")
+		}
+		b.WriteString("\n")
+		if p, ok := inTreePlugin("", sc.Provisioner); ok {
+			fmt.Fprintf(&b, "  - Warning: uses the in-tree %s plugin removed in 1.%d, new volumes should use a StorageClass of the %s CSI driver\n", p.Name, p.RemovedIn, cmp.Or(p.CSIDriver, "replacement"))
+		}
+	}
+
+	if len(inv.statefulSets) > 0 {
+		b.WriteString("\nStatefulSets:\n")
+		for _, s := range inv.statefulSets {
+			var claims []string
+			for _, c := range s.Spec.VolumeClaimTemplates {
+				class := defaultClass + " (default)"
+				if c.Spec.StorageClassName != nil {
+					class = *c.Spec.StorageClassName
+				}
+				claims = append(claims, fmt.Sprintf("%s on %s", c.Name, class))
+			}
+			if len(claims) == 0 {
+				claims = append(claims, "no volume claim templates")
+			}
+			fmt.Fprintf(&b, "- %s/%s (%d replicas): %s\n", s.Namespace, s.Name, replicas(s.Spec.Replicas), strings.Join(claims, ", "))
+		}
+	}
+
+	drivers := map[string]int{}
+	inTree := map[string][]string{}
+	for _, pv := range inv.volumes {
+		if pv.Spec.CSI != nil {
+			drivers[pv.Spec.CSI.Driver]++
+		}
+		if p, ok := inTreePlugin(pv.Spec.Plugin, ""); ok {
+			name := pv.Name
+			if ref := pv.Spec.ClaimRef; ref != nil {
+				name = fmt.Sprintf("%s (claim %s/%s)", pv.Name, ref.Namespace, ref.Name)
+			}
+			inTree[p.Name] = append(inTree[p.Name], name)
+		}
+	}
+	if len(drivers) > 0 {
+		b.WriteString("\nCSI drivers in use by PersistentVolumes:\n")
+		for _, d := range slices.Sorted(maps.Keys(drivers)) {
+			fmt.Fprintf(&b, "- %s: %d volumes\n", d, drivers[d])
+		}
+	}
+	for _, p := range kube.InTreeVolumePlugins {
+		pvs := inTree[p.Name]
+		if len(pvs) == 0 {
+			continue
+		}
+		when := fmt.Sprintf("removed in 1.%d, before the current version", p.RemovedIn)
+		if p.RemovedIn > currentMinor && p.RemovedIn <= targetMinor {
+			when = fmt.Sprintf("removed in 1.%d by this upgrade", p.RemovedIn)
+		} else if p.RemovedIn > targetMinor {
+			when = fmt.Sprintf("removed in 1.%d, after the target version", p.RemovedIn)
+		}
+		fmt.Fprintf(&b, "\nPersistentVolumes using the in-tree %s plugin (%s), %s:\n", p.Name, when, inTreePluginImpact(p, csiDrivers))
+		for _, pv := range pvs {
+			fmt.Fprintf(&b, "- %s\n", pv)
+		}
+	}
+
+	b.WriteString(volumeAttachmentsReport(inv.csiNodes, inv.attachments))
+	return b.String(), nil
+}
+
+// volumeAttachmentsReport lists the volumes attached to each node, and the
+// nodes close to their volume attach limit.
+func volumeAttachmentsReport(csiNodes []kube.CSINode, attachments []kube.VolumeAttachment) string {
+	if len(attachments) == 0 {
+		return ""
+	}
+	type nodeDriver struct{ node, driver string }
+	counts := map[nodeDriver]int{}
+	for _, a := range attachments {
+		counts[nodeDriver{a.Spec.NodeName, a.Spec.Attacher}]++
+	}
+	limits := map[nodeDriver]int32{}
+	for _, n := range csiNodes {
+		for _, d := range n.Spec.Drivers {
+			if d.Allocatable != nil && d.Allocatable.Count != nil {
+				limits[nodeDriver{n.Name, d.Name}] = *d.Allocatable.Count
+			}
+		}
+	}
+
+	keys := slices.SortedFunc(maps.Keys(counts), func(a, b nodeDriver) int {
+		return cmp.Or(cmp.Compare(a.node, b.node), cmp.Compare(a.driver, b.driver))
+	})
+	var b strings.Builder
+	b.WriteString("\nVolume attachments per node:\n")
+	for _, k := range keys {
+		fmt.Fprintf(&b, "- %s: %d %s volumes", k.node, counts[k], k.driver)
+		if limit, ok := limits[k]; ok {
+			fmt.Fprintf(&b, " (limit %d)", limit)
+			if float64(counts[k]) >= attachmentLimitWarningRatio*float64(limit) {
+				b.WriteString(", Warning: close to the attach limit, pods with volumes evicted from drained nodes may not fit")
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestStorageReport(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		Name:                 "my-cluster",
+		CurrentMasterVersion: "1.27.8-gke.1000",
+		AddonsConfig: &containerpb.AddonsConfig{
+			GcePersistentDiskCsiDriverConfig: &containerpb.GcePersistentDiskCsiDriverConfig{Enabled: true},
+		},
+	}
+
+	var inv storageInventory
+	if err := json.Unmarshal([]byte(`[
+		{"metadata": {"name": "standard"}, "provisioner": "kubernetes.io/gce-pd"},
+		{"metadata": {"name": "standard-rwo", "annotations": {"storageclass.kubernetes.io/is-default-class": "true"}}, "provisioner": "pd.csi.storage.gke.io"}
+	]`), &inv.storageClasses); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`[
+		{"metadata": {"name": "pv-legacy"}, "spec": {"gcePersistentDisk": {"pdName": "legacy"}, "claimRef": {"namespace": "db", "name": "data-postgres-0"}}},
+		{"metadata": {"name": "pv-csi-1"}, "spec": {"csi": {"driver": "pd.csi.storage.gke.io"}}},
+		{"metadata": {"name": "pv-csi-2"}, "spec": {"csi": {"driver": "pd.csi.storage.gke.io"}}},
+		{"metadata": {"name": "pv-gluster"}, "spec": {"glusterfs": {"path": "vol"}}}
+	]`), &inv.volumes); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`[
+		{"metadata": {"name": "node-1"}, "spec": {"drivers": [{"name": "pd.csi.storage.gke.io", "allocatable": {"count": 5}}]}}
+	]`), &inv.csiNodes); err != nil {
+		t.Fatal(err)
+	}
+	for range 4 {
+		var a kube.VolumeAttachment
+		a.Spec.NodeName = "node-1"
+		a.Spec.Attacher = "pd.csi.storage.gke.io"
+		inv.attachments = append(inv.attachments, a)
+	}
+	postgres := kube.StatefulSet{ObjectMeta: metav1.ObjectMeta{Namespace: "db", Name: "postgres"}}
+	postgres.Spec.Replicas = ptr.To[int32](3)
+	postgres.Spec.VolumeClaimTemplates = []kube.PersistentVolumeClaim{{ObjectMeta: metav1.ObjectMeta{Name: "data"}}}
+	inv.statefulSets = []kube.StatefulSet{postgres}
+
+	got, err := storageReport(cluster, "1.29.1", inv)
+	if err != nil {
+		t.Fatalf("storageReport() error = %v", err)
+	}
+	for _, want := range []string{
+		"- standard: kubernetes.io/gce-pd\n  - Warning: uses the in-tree gcePersistentDisk plugin removed in 1.28",
+		"- standard-rwo: pd.csi.storage.gke.io (default)",
+		"- db/postgres (3 replicas): data on standard-rwo (default)",
+		"- pd.csi.storage.gke.io: 2 volumes",
+		"in-tree gcePersistentDisk plugin (removed in 1.28 by this upgrade), the volumes are served through CSI migration by the pd.csi.storage.gke.io CSI driver:\n- pv-legacy (claim db/data-postgres-0)",
+		"in-tree glusterfs plugin (removed in 1.26, before the current version), the volumes stop working",
+		"- node-1: 4 pd.csi.storage.gke.io volumes (limit 5), Warning: close to the attach limit",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("storageReport() missing %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestInTreePluginImpact(t *testing.T) {
+	gcePD, _ := inTreePlugin("gcePersistentDisk", "")
+	if got := inTreePluginImpact(gcePD, map[string]bool{}); !strings.Contains(got, "which is not installed") {
+		t.Errorf("inTreePluginImpact() = %q, want the missing CSI driver reported", got)
+	}
+	if _, ok := inTreePlugin("", "pd.csi.storage.gke.io"); ok {
+		t.Error("inTreePlugin() matched the CSI provisioner")
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	systemNamespace = "kube-system"
+	// systemEventsWindow is the time window of the Warning events counted as
+	// errors of the system components.
+	systemEventsWindow = time.Hour
+)
+
+// systemComponent is a workload of kube-system managed by GKE.
+type systemComponent struct {
+	name    string
+	kind    string
+	purpose string
+	// optional components only run when a feature is enabled.
+	optional bool
+	// matches reports whether a workload of kube-system is the component.
+	matches func(name string) bool
+}
+
+// named matches the workloads with one of the names.
+func named(names ...string) func(string) bool {
+	return func(name string) bool {
+		return slices.Contains(names, name)
+	}
+}
+
+// systemComponents are the GKE-managed workloads of kube-system whose
+// failures break the workloads of the cluster.
+var systemComponents = []systemComponent{
+	{name: "konnectivity-agent", kind: "Deployment", purpose: "control plane to node connectivity, used by admission webhooks, logs, exec and port-forward", matches: named("konnectivity-agent")},
+	{name: "kube-dns", kind: "Deployment", purpose: "cluster DNS, unless Cloud DNS is used", optional: true, matches: named("kube-dns", "coredns")},
+	{name: "metrics-server", kind: "Deployment", purpose: "resource metrics of the HorizontalPodAutoscalers and kubectl top", matches: func(name string) bool {
+		return name == "metrics-server" || strings.HasPrefix(name, "metrics-server-v")
+	}},
+	{name: "fluentbit-gke", kind: "DaemonSet", purpose: "collection of the container and system logs", optional: true, matches: named("fluentbit-gke", "fluentbit-gke-big", "fluentbit-gke-max")},
+	{name: "netd", kind: "DaemonSet", purpose: "pod networking setup of the nodes", optional: true, matches: named("netd")},
+	{name: "anetd", kind: "DaemonSet", purpose: "GKE Dataplane V2 networking and network policies", optional: true, matches: named("anetd")},
+	{name: "gke-metadata-server", kind: "DaemonSet", purpose: "Workload Identity Federation credentials of the pods", optional: true, matches: named("gke-metadata-server")},
+}
+
+// componentHealth is the health of the workloads of a system component.
+type componentHealth struct {
+	component systemComponent
+	// workloads are the names of the matching workloads.
+	workloads []string
+	ready     int32
+	desired   int32
+	restarts  int32
+	// unhealthyPods are the pods not ready, with the reason.
+	unhealthyPods []string
+	// warnings is the number of Warning events in the systemEventsWindow.
+	warnings int
+}
+
+func (h componentHealth) healthy() bool {
+	return h.ready >= h.desired && len(h.unhealthyPods) == 0
+}
+
+// status summarizes the readiness, restarts and Warning events of the component.
+func (h componentHealth) status() string {
+	status := "healthy"
+	if !h.healthy() {
+		status = "DEGRADED"
+	}
+	return fmt.Sprintf("%s, %d/%d ready, %d container restarts, %d Warning events in the past hour", status, h.ready, h.desired, h.restarts, h.warnings)
+}
+
+type checkSystemComponentsArgs struct{}
+
+func (h *handlers) checkSystemComponents(ctx context.Context, _ *mcp.CallToolRequest, _ *checkSystemComponentsArgs) (*mcp.CallToolResult, any, error) {
+	var deployments kube.List[kube.Deployment]
+	if err := kube.Get(ctx, &deployments, "deployments", "--namespace", systemNamespace); err != nil {
+		return nil, nil, err
+	}
+	var daemonSets kube.List[kube.DaemonSet]
+	if err := kube.Get(ctx, &daemonSets, "daemonsets", "--namespace", systemNamespace); err != nil {
+		return nil, nil, err
+	}
+	var pods kube.List[kube.Pod]
+	if err := kube.Get(ctx, &pods, "pods", "--namespace", systemNamespace); err != nil {
+		return nil, nil, err
+	}
+	var events kube.List[kube.Event]
+	if err := kube.Get(ctx, &events, "events", "--namespace", systemNamespace, "--field-selector=type=Warning"); err != nil {
+		return nil, nil, err
+	}
+
+	health := systemComponentsHealth(deployments.Items, daemonSets.Items, pods.Items, events.Items, time.Now())
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: systemComponentsReport(health)},
+		},
+	}, nil, nil
+}
+
+// belongsTo reports whether a pod, or an object named after it, belongs to a
+// workload, from the names the controllers generate.
+func belongsTo(name, workload string) bool {
+	return strings.HasPrefix(name, workload+"-")
+}
+
+// systemComponentsHealth returns the health of the system components found
+// in kube-system, and of the missing non-optional ones.
+func systemComponentsHealth(deployments []kube.Deployment, daemonSets []kube.DaemonSet, pods []kube.Pod, events []kube.Event, now time.Time) []componentHealth {
+	var health []componentHealth
+	for _, component := range systemComponents {
+		h := componentHealth{component: component}
+		switch component.kind {
+		case "Deployment":
+			for _, d := range deployments {
+				if component.matches(d.Name) {
+					h.workloads = append(h.workloads, d.Name)
+					h.desired += replicas(d.Spec.Replicas)
+					h.ready += d.Status.ReadyReplicas
+				}
+			}
+		case "DaemonSet":
+			for _, ds := range daemonSets {
+				if component.matches(ds.Name) {
+					h.workloads = append(h.workloads, ds.Name)
+					h.desired += ds.Status.DesiredNumberScheduled
+					h.ready += ds.Status.NumberReady
+				}
+			}
+		}
+		if len(h.workloads) == 0 {
+			if !component.optional {
+				health = append(health, h)
+			}
+			continue
+		}
+
+		for _, pod := range pods {
+			owned := false
+			for _, w := range h.workloads {
+				owned = owned || belongsTo(pod.Name, w)
+			}
+			if !owned {
+				continue
+			}
+			ready := pod.Status.Phase == "Running"
+			reason := pod.Status.Phase
+			for _, c := range pod.Status.ContainerStatuses {
+				h.restarts += c.RestartCount
+				if !c.Ready {
+					ready = false
+					if c.State.Waiting != nil && c.State.Waiting.Reason != "" {
+						reason = c.State.Waiting.Reason
+					}
+				}
+			}
+			if !ready {
+				h.unhealthyPods = append(h.unhealthyPods, fmt.Sprintf("%s (%s)", pod.Name, reason))
+			}
+		}
+
+		for _, event := range events {
+			last := event.LastTimestamp.Time
+			if last.IsZero() {
+				last = event.EventTime.Time
+			}
+			if now.Sub(last) > systemEventsWindow {
+				continue
+			}
+			for _, w := range h.workloads {
+				if event.InvolvedObject.Name == w || belongsTo(event.InvolvedObject.Name, w) {
+					h.warnings += max(int(event.Count), 1)
+					break
+				}
+			}
+		}
+		health = append(health, h)
+	}
+	return health
+}
+
+func systemComponentsReport(health []componentHealth) string {
+	var b strings.Builder
+	b.WriteString("GKE system components in kube-system:\n")
+	var degraded []string
+	for _, h := range health {
+		c := h.component
+		if len(h.workloads) == 0 {
+			fmt.Fprintf(&b, "- %s (%s): MISSING, %s is unavailable\n", c.name, c.purpose, c.purpose)
+			degraded = append(degraded, c.name)
+			continue
+		}
+		if !h.healthy() {
+			degraded = append(degraded, c.name)
+		}
+		fmt.Fprintf(&b, "- %s (%s): %s\n", c.name, c.purpose, h.status())
+		if len(h.unhealthyPods) > 0 {
+			fmt.Fprintf(&b, "  Pods not ready: %s\n", strings.Join(h.unhealthyPods, ", "))
+		}
+	}
+
+	if len(degraded) == 0 {
+		b.WriteString("\nAll GKE system components are healthy, problems of the workloads are unlikely to come from the platform.\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "\nDegraded GKE system components: %s. Problems of the workloads depending on them are likely platform problems; check the nodes and the events of kube-system before the workloads.\n", strings.Join(degraded, ", "))
+	return b.String()
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSystemComponentsReport(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	two := int32(2)
+	deployment := func(name string, ready int32) kube.Deployment {
+		d := kube.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		d.Spec.Replicas = &two
+		d.Status.ReadyReplicas = ready
+		return d
+	}
+	daemonSet := func(name string, desired, ready int32) kube.DaemonSet {
+		ds := kube.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		ds.Status.DesiredNumberScheduled = desired
+		ds.Status.NumberReady = ready
+		return ds
+	}
+	pod := func(name string, ready bool, restarts int32, waiting string) kube.Pod {
+		p := kube.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		p.Status.Phase = "Running"
+		c := kube.ContainerStatus{Name: "main", Ready: ready, RestartCount: restarts}
+		if waiting != "" {
+			c.State.Waiting = &struct {
+				Reason string `json:"reason,omitempty"`
+			}{Reason: waiting}
+		}
+		p.Status.ContainerStatuses = []kube.ContainerStatus{c}
+		return p
+	}
+	event := func(object string, count int32, age time.Duration) kube.Event {
+		return kube.Event{InvolvedObject: kube.ObjectReference{Name: object}, Type: "Warning", Count: count, LastTimestamp: metav1.NewTime(now.Add(-age))}
+	}
+
+	deployments := []kube.Deployment{deployment("konnectivity-agent", 1), deployment("kube-dns", 2), deployment("kube-dns-autoscaler", 1)}
+	daemonSets := []kube.DaemonSet{daemonSet("fluentbit-gke", 3, 3), daemonSet("netd", 3, 3)}
+	pods := []kube.Pod{
+		pod("konnectivity-agent-abc-1", true, 0, ""),
+		pod("konnectivity-agent-abc-2", false, 7, "CrashLoopBackOff"),
+		pod("kube-dns-xyz-1", true, 1, ""),
+		pod("kube-dns-xyz-2", true, 0, ""),
+	}
+	events := []kube.Event{
+		event("konnectivity-agent-abc-2", 5, 10*time.Minute),
+		event("konnectivity-agent-abc-2", 50, 2*time.Hour),
+		event("kube-dns-xyz-1", 0, time.Minute),
+	}
+
+	got := systemComponentsReport(systemComponentsHealth(deployments, daemonSets, pods, events, now))
+	for _, want := range []string{
+		"- konnectivity-agent (control plane to node connectivity, used by admission webhooks, logs, exec and port-forward): DEGRADED, 1/2 ready, 7 container restarts, 5 Warning events in the past hour\n",
+		"  Pods not ready: konnectivity-agent-abc-2 (CrashLoopBackOff)\n",
+		"- kube-dns (cluster DNS, unless Cloud DNS is used): healthy, 2/2 ready, 1 container restarts, 1 Warning events in the past hour\n",
+		"- metrics-server (resource metrics of the HorizontalPodAutoscalers and kubectl top): MISSING",
+		"- fluentbit-gke (collection of the container and system logs): healthy, 3/3 ready",
+		"Degraded GKE system components: konnectivity-agent, metrics-server.",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("systemComponentsReport() missing %q, got:\n%s", want, got)
+		}
+	}
+	for _, unwanted := range []string{"anetd", "gke-metadata-server", "kube-dns-autoscaler"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("systemComponentsReport() contains %q, got:\n%s", unwanted, got)
+		}
+	}
+}
+
+func TestSystemComponentsReport_Healthy(t *testing.T) {
+	deployments := []kube.Deployment{
+		{ObjectMeta: metav1.ObjectMeta{Name: "konnectivity-agent"}, Status: kube.DeploymentStatus{ReadyReplicas: 1}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "metrics-server-v1.31.0"}, Status: kube.DeploymentStatus{ReadyReplicas: 1}},
+	}
+
+	got := systemComponentsReport(systemComponentsHealth(deployments, nil, nil, nil, time.Now()))
+	if !strings.Contains(got, "All GKE system components are healthy") {
+		t.Errorf("systemComponentsReport() = %q, want all healthy", got)
+	}
+}
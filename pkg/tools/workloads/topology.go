@@ -0,0 +1,209 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	zoneTopologyKey     = "topology.kubernetes.io/zone"
+	hostnameTopologyKey = "kubernetes.io/hostname"
+)
+
+type checkTopologyConstraintsArgs struct {
+	Namespace string `json:"namespace,omitempty" jsonschema:"Namespace of the workloads to check. Leave this empty to check all namespaces."`
+}
+
+func (h *handlers) checkTopologyConstraints(ctx context.Context, _ *mcp.CallToolRequest, args *checkTopologyConstraintsArgs) (*mcp.CallToolResult, any, error) {
+	namespaceArgs := []string{"--all-namespaces"}
+	if args.Namespace != "" {
+		namespaceArgs = []string{"--namespace", args.Namespace}
+	}
+
+	var nodes kube.List[kube.Node]
+	if err := kube.Get(ctx, &nodes, "nodes"); err != nil {
+		return nil, nil, err
+	}
+	var deployments kube.List[kube.Deployment]
+	if err := kube.Get(ctx, &deployments, append([]string{"deployments"}, namespaceArgs...)...); err != nil {
+		return nil, nil, err
+	}
+	var statefulSets kube.List[kube.StatefulSet]
+	if err := kube.Get(ctx, &statefulSets, append([]string{"statefulsets"}, namespaceArgs...)...); err != nil {
+		return nil, nil, err
+	}
+
+	var workloads []replicatedWorkload
+	for _, d := range deployments.Items {
+		workloads = append(workloads, replicatedWorkload{kind: "Deployment", meta: d.ObjectMeta, replicas: replicas(d.Spec.Replicas), template: d.Spec.Template})
+	}
+	for _, s := range statefulSets.Items {
+		workloads = append(workloads, replicatedWorkload{kind: "StatefulSet", meta: s.ObjectMeta, replicas: replicas(s.Spec.Replicas), template: s.Spec.Template})
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: topologyConstraintsReport(nodes.Items, workloads)},
+		},
+	}, nil, nil
+}
+
+// selectsOwnPods reports whether a label selector of a constraint selects the
+// pods of the workload itself, the constraints this check reasons about.
+func selectsOwnPods(selector *metav1.LabelSelector, template kube.PodTemplateSpec) bool {
+	if selector == nil {
+		return false
+	}
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil || s.Empty() {
+		return false
+	}
+	return s.Matches(labels.Set(template.Labels))
+}
+
+// topologyDomains returns the number of nodes of each domain of a topology
+// key among the schedulable nodes matching the node selector of a workload.
+func topologyDomains(nodes []kube.Node, spec kube.PodSpec, key string) map[string]int {
+	domains := map[string]int{}
+	for _, node := range nodes {
+		if node.Spec.Unschedulable {
+			continue
+		}
+		eligible := true
+		for k, v := range spec.NodeSelector {
+			if node.Labels[k] != v {
+				eligible = false
+				break
+			}
+		}
+		if domain, ok := node.Labels[key]; ok && eligible {
+			domains[domain]++
+		}
+	}
+	return domains
+}
+
+// singleNodeDomains returns the domains made of a single node, sorted.
+func singleNodeDomains(domains map[string]int) []string {
+	var single []string
+	for domain, nodes := range domains {
+		if nodes == 1 {
+			single = append(single, domain)
+		}
+	}
+	sort.Strings(single)
+	return single
+}
+
+// topologyIssues returns the reasons the topology spread constraints and the
+// required pod anti-affinity of a workload can leave its pods Pending while
+// node upgrades drain and recreate nodes.
+func topologyIssues(w replicatedWorkload, nodes []kube.Node) []string {
+	var issues []string
+	spec := w.template.Spec
+
+	if spec.Affinity != nil && spec.Affinity.PodAntiAffinity != nil {
+		for _, term := range spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+			if !selectsOwnPods(term.LabelSelector, w.template) {
+				continue
+			}
+			domains := topologyDomains(nodes, spec, term.TopologyKey)
+			switch {
+			case int(w.replicas) > len(domains):
+				issues = append(issues, fmt.Sprintf("required pod anti-affinity on %s needs %d domains but only %d exist, %d pods can't be scheduled even before the upgrade", term.TopologyKey, w.replicas, len(domains), int(w.replicas)-len(domains)))
+			case term.TopologyKey == hostnameTopologyKey && int(w.replicas) == len(domains):
+				issues = append(issues, fmt.Sprintf("required pod anti-affinity on %s uses all %d eligible nodes, a pod evicted by a node drain stays Pending unless a surge node is added (maxSurge > 0); use preferred anti-affinity or a topology spread constraint instead", term.TopologyKey, len(domains)))
+			case int(w.replicas) == len(domains):
+				if single := singleNodeDomains(domains); len(single) > 0 {
+					issues = append(issues, fmt.Sprintf("required pod anti-affinity on %s uses all %d domains, with a single eligible node in %s, a pod evicted from such a node stays Pending until the node is recreated", term.TopologyKey, len(domains), strings.Join(single, ", ")))
+				}
+			}
+		}
+	}
+
+	for _, c := range spec.TopologySpreadConstraints {
+		if c.WhenUnsatisfiable != "DoNotSchedule" || !selectsOwnPods(c.LabelSelector, w.template) {
+			continue
+		}
+		domains := topologyDomains(nodes, spec, c.TopologyKey)
+		if c.MinDomains != nil && int(*c.MinDomains) > len(domains) {
+			issues = append(issues, fmt.Sprintf("topology spread constraint on %s requires minDomains %d but only %d domains exist, at most %d pods fit per domain", c.TopologyKey, *c.MinDomains, len(domains), c.MaxSkew))
+			continue
+		}
+		if len(domains) < 2 {
+			continue
+		}
+		switch {
+		case c.TopologyKey == zoneTopologyKey && c.MaxSkew == 1:
+			issues = append(issues, fmt.Sprintf("strict topology spread constraint on %s (maxSkew 1, DoNotSchedule): while the nodes of a zone are drained and recreated, pods evicted from it can only go back to that zone, and stay Pending if it lacks spare capacity; use maxSkew 2 or more, or ScheduleAnyway", c.TopologyKey))
+		case c.TopologyKey == hostnameTopologyKey && int(w.replicas) > len(domains)*int(c.MaxSkew):
+			issues = append(issues, fmt.Sprintf("strict topology spread constraint on %s (maxSkew %d, DoNotSchedule) with %d replicas on %d eligible nodes: a cordoned node still counts as an empty domain, so pods evicted from it can't be placed on the other nodes until a surge node is ready", c.TopologyKey, c.MaxSkew, w.replicas, len(domains)))
+		}
+	}
+	return issues
+}
+
+func topologyConstraintsReport(nodes []kube.Node, workloads []replicatedWorkload) string {
+	var b strings.Builder
+	zones := topologyDomains(nodes, kube.PodSpec{}, zoneTopologyKey)
+	var zoneNodes []string
+	for zone, count := range zones {
+		zoneNodes = append(zoneNodes, fmt.Sprintf("%s: %d", zone, count))
+	}
+	sort.Strings(zoneNodes)
+	fmt.Fprintf(&b, "Schedulable nodes per zone: %s\n", joinOrNone(zoneNodes))
+
+	var risks []string
+	checked := 0
+	for _, w := range workloads {
+		if w.replicas == 0 {
+			continue
+		}
+		spec := w.template.Spec
+		if len(spec.TopologySpreadConstraints) == 0 && (spec.Affinity == nil || spec.Affinity.PodAntiAffinity == nil) {
+			continue
+		}
+		checked++
+		if issues := topologyIssues(w, nodes); len(issues) > 0 {
+			risks = append(risks, fmt.Sprintf("- %s/%s %s (%d replicas): %s", w.meta.Namespace, w.kind, w.meta.Name, w.replicas, strings.Join(issues, "; ")))
+		}
+	}
+	fmt.Fprintf(&b, "Workloads with topology spread constraints or required pod anti-affinity: %d\n", checked)
+	if len(risks) == 0 {
+		b.WriteString("\nThe topology constraints of all workloads remain satisfiable while node upgrades drain nodes.\n")
+		return b.String()
+	}
+	b.WriteString("\nWorkloads whose pods can stay Pending during surge upgrades:\n")
+	b.WriteString(strings.Join(risks, "\n"))
+	b.WriteString("\n")
+	return b.String()
+}
+
+// joinOrNone joins items, or returns none for an empty list.
+func joinOrNone(items []string) string {
+	if len(items) == 0 {
+		return "none"
+	}
+	return strings.Join(items, ", ")
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTopologyConstraintsReport(t *testing.T) {
+	node := func(name, zone string) kube.Node {
+		return kube.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{zoneTopologyKey: zone, hostnameTopologyKey: name}}}
+	}
+	nodes := []kube.Node{node("a-1", "us-central1-a"), node("a-2", "us-central1-a"), node("b-1", "us-central1-b"), node("c-1", "us-central1-c")}
+	cordoned := node("c-2", "us-central1-c")
+	cordoned.Spec.Unschedulable = true
+	nodes = append(nodes, cordoned)
+
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+	workload := func(name string, replicas int32, spec kube.PodSpec) replicatedWorkload {
+		return replicatedWorkload{
+			kind:     "Deployment",
+			meta:     metav1.ObjectMeta{Namespace: "default", Name: name},
+			replicas: replicas,
+			template: kube.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}}, Spec: spec},
+		}
+	}
+	antiAffinity := func(key string) *kube.Affinity {
+		return &kube.Affinity{PodAntiAffinity: &kube.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []kube.PodAffinityTerm{{LabelSelector: selector, TopologyKey: key}},
+		}}
+	}
+	spread := func(key string, maxSkew int32, whenUnsatisfiable string) []kube.TopologySpreadConstraint {
+		return []kube.TopologySpreadConstraint{{MaxSkew: maxSkew, TopologyKey: key, WhenUnsatisfiable: whenUnsatisfiable, LabelSelector: selector}}
+	}
+	minDomains := int32(4)
+
+	workloads := []replicatedWorkload{
+		workload("too-many", 5, kube.PodSpec{Affinity: antiAffinity(hostnameTopologyKey)}),
+		workload("all-nodes", 4, kube.PodSpec{Affinity: antiAffinity(hostnameTopologyKey)}),
+		workload("all-zones", 3, kube.PodSpec{Affinity: antiAffinity(zoneTopologyKey)}),
+		workload("strict-zones", 6, kube.PodSpec{TopologySpreadConstraints: spread(zoneTopologyKey, 1, "DoNotSchedule")}),
+		workload("strict-nodes", 9, kube.PodSpec{TopologySpreadConstraints: spread(hostnameTopologyKey, 2, "DoNotSchedule")}),
+		workload("min-domains", 3, kube.PodSpec{TopologySpreadConstraints: []kube.TopologySpreadConstraint{{MaxSkew: 1, TopologyKey: zoneTopologyKey, WhenUnsatisfiable: "DoNotSchedule", LabelSelector: selector, MinDomains: &minDomains}}}),
+		workload("relaxed", 6, kube.PodSpec{TopologySpreadConstraints: spread(zoneTopologyKey, 1, "ScheduleAnyway")}),
+		workload("few-replicas", 2, kube.PodSpec{Affinity: antiAffinity(hostnameTopologyKey)}),
+	}
+
+	got := topologyConstraintsReport(nodes, workloads)
+	for _, want := range []string{
+		"Schedulable nodes per zone: us-central1-a: 2, us-central1-b: 1, us-central1-c: 1\n",
+		"Workloads with topology spread constraints or required pod anti-affinity: 8\n",
+		"- default/Deployment too-many (5 replicas): required pod anti-affinity on kubernetes.io/hostname needs 5 domains but only 4 exist",
+		"- default/Deployment all-nodes (4 replicas): required pod anti-affinity on kubernetes.io/hostname uses all 4 eligible nodes",
+		"- default/Deployment all-zones (3 replicas): required pod anti-affinity on topology.kubernetes.io/zone uses all 3 domains, with a single eligible node in us-central1-b, us-central1-c,",
+		"- default/Deployment strict-zones (6 replicas): strict topology spread constraint on topology.kubernetes.io/zone (maxSkew 1, DoNotSchedule)",
+		"- default/Deployment strict-nodes (9 replicas): strict topology spread constraint on kubernetes.io/hostname (maxSkew 2, DoNotSchedule) with 9 replicas on 4 eligible nodes",
+		"- default/Deployment min-domains (3 replicas): topology spread constraint on topology.kubernetes.io/zone requires minDomains 4 but only 3 domains exist",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("topologyConstraintsReport() missing %q, got:\n%s", want, got)
+		}
+	}
+	for _, unwanted := range []string{"relaxed", "few-replicas"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("topologyConstraintsReport() contains %q, got:\n%s", unwanted, got)
+		}
+	}
+}
+
+func TestTopologyConstraintsReport_NoConstraints(t *testing.T) {
+	got := topologyConstraintsReport(nil, []replicatedWorkload{{kind: "Deployment", meta: metav1.ObjectMeta{Name: "web"}, replicas: 2}})
+	if !strings.Contains(got, "The topology constraints of all workloads remain satisfiable") {
+		t.Errorf("topologyConstraintsReport() = %q, want no risks", got)
+	}
+}
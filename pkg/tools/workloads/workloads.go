@@ -0,0 +1,206 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package workloads provides MCP tools for inspecting workloads running in the
+// cluster of the current kubectl context.
+package workloads
+
+import (
+	"context"
+	"fmt"
+
+	container "cloud.google.com/go/container/apiv1"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type handlers struct {
+	c        *config.Config
+	cmClient *container.ClusterManagerClient
+}
+
+// Install registers workload inspection tools with the MCP server.
+func Install(ctx context.Context, s *mcp.Server, c *config.Config) error {
+	cmClient, err := container.NewClusterManagerClient(ctx, c.ClientOptions()...)
+	if err != nil {
+		return fmt.Errorf("failed to create cluster manager client: %w", err)
+	}
+
+	h := &handlers{
+		c:        c,
+		cmClient: cmClient,
+	}
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "simulate_node_drain",
+		Description: "Simulate draining the nodes of a GKE node pool without evicting anything, and list pods that would block or be lost on eviction: pods covered by PodDisruptionBudgets allowing no disruptions, pods using emptyDir or hostPath storage, and bare pods without a controller. Uses the current kubectl context, run get_kubeconfig for the cluster first.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.simulateNodeDrain)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "check_daemonset_compatibility",
+		Description: "Check that DaemonSets in the current kubectl context tolerate the taints GKE applies to the nodes of each node pool of a GKE cluster (configured taints, GPU, GKE Sandbox, Arm and Windows taints), and that DaemonSets have a priorityClass so their pods are scheduled on upgraded nodes.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.checkDaemonSetCompatibility)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "check_docker_dependencies",
+		Description: "Detect nodes still running Docker and workloads depending on Docker-specific behavior, such as Docker socket hostPath mounts, Docker in Docker or cri-dockerd, which break after the dockershim removal. Uses the current kubectl context.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.checkDockerDependencies)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "find_deprecated_apis",
+		Description: "List objects of the current kubectl context applied with Kubernetes API versions removed between the current version of a GKE cluster and a target version (e.g. extensions/v1beta1 Ingress, policy/v1beta1 PodSecurityPolicy, batch/v1beta1 CronJob), with the suggested replacement API. Reads kubectl last-applied manifests, and deployed Helm releases only with include_helm_releases, since their Secrets hold the chart values, which can include credentials.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.findDeprecatedAPIs)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "check_pod_security_migration",
+		Description: "Check the migration from PodSecurityPolicy to Pod Security Admission in the current kubectl context: list PodSecurityPolicies with the equivalent Pod Security Standards level (privileged, baseline or restricted), the namespaces of pods admitted by each policy, and namespaces lacking the pod-security.kubernetes.io/enforce label.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.checkPodSecurityMigration)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "audit_autoscaling",
+		Description: "Audit HorizontalPodAutoscalers and VerticalPodAutoscalers in the current kubectl context before upgrading a GKE cluster to a target version: applied HPA API versions, metric sources and availability of the metrics APIs serving them, failing or saturated HPAs, HPA/VPA conflicts, and autoscaling behavior changes between the current and the target version.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.auditAutoscaling)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "check_resource_requests",
+		Description: "Report workloads in the current kubectl context missing CPU or memory requests, with limits much larger than their requests, or in the BestEffort and Guaranteed QoS classes. Such workloads are the first to fail when node upgrades recreate nodes and reshuffle pods.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.checkResourceRequests)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "check_critical_workloads",
+		Description: "Find Deployments and StatefulSets in production-critical namespaces of the current kubectl context which run a single replica, have no PodDisruptionBudget, or have a PodDisruptionBudget allowing no disruptions, and so incur downtime or block node upgrades. Namespaces are selected with the production-critical label by default.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.checkCriticalWorkloads)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "get_namespace_inventory",
+		Description: "List the namespaces of the current kubectl context with their workload counts, ResourceQuota usage, owner labels and annotations (e.g. team, owner, contact) and criticality labels and annotations (e.g. production-critical, tier, environment), so that findings can be attributed to the teams owning the affected namespaces.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.getNamespaceInventory)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "audit_priority_classes",
+		Description: "Audit the PriorityClasses of the current kubectl context and flag the production-critical Deployments and StatefulSets without a priority, with a missing PriorityClass, or preemptable by other workloads, and the preemption policies which cascade evictions when node upgrades recreate nodes. Namespaces are selected with the production-critical label by default.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.auditPriorityClasses)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "check_topology_constraints",
+		Description: "Check whether the topology spread constraints and required pod anti-affinity of the Deployments and StatefulSets of the current kubectl context remain satisfiable while surge upgrades drain and recreate nodes, given the schedulable nodes per zone. Strict constraints commonly leave pods Pending mid-upgrade during temporary zone imbalance.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.checkTopologyConstraints)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "check_zonal_topology",
+		Description: "Check the zonal topology of a GKE cluster: a zonal control plane, node pools in a single zone and StatefulSets pinned to a zone by their zonal persistent disks, and quantify the blast radius of the loss of each zone, e.g. during zone maintenance or upgrades, as the share of nodes and running pods in the zone and the workloads running only in it. Uses the current kubectl context, run get_kubeconfig for the cluster first.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.checkZonalTopology)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "analyze_pending_pods",
+		Description: "List the Pending pods of the current kubectl context aggregated by cause, parsed from the scheduler messages (insufficient CPU or memory, taint mismatch, volume zone conflict, node selector mismatch, affinity or topology spread constraints, cordoned nodes...), with the fix of each cause. Use it for health checks, triage and to validate a cluster after an upgrade.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.analyzePendingPods)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "check_system_components",
+		Description: "Check the health of the GKE-managed system workloads of kube-system in the current kubectl context (konnectivity-agent, kube-dns, metrics-server, fluentbit-gke, netd, anetd, gke-metadata-server): ready replicas, pods not ready, container restarts and Warning events of the past hour, so that platform problems can be told apart from workload problems.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.checkSystemComponents)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "check_control_plane_connectivity",
+		Description: "Check the connectivity from the control plane to the nodes in the current kubectl context: health of the konnectivity-agent pods, requests from the API server to the kubelet of ready nodes of different zones, and the admission webhooks served in the cluster which fail when the konnectivity tunnel is broken, as happens after upgrades with misconfigured firewall rules.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.checkControlPlaneConnectivity)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "get_api_object_counts",
+		Description: "Report the number of objects stored in etcd per resource type (Secrets, ConfigMaps, events, custom resources, ...) and the etcd database size, from the metrics of the API server, and flag resource types approaching Kubernetes and GKE scalability limits. Huge object counts slow down control plane upgrades and can make them time out. Uses the current kubectl context, run get_kubeconfig for the cluster first.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.getAPIObjectCounts)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "audit_service_exposure",
+		Description: "Inventory the LoadBalancer Services, Ingresses and Gateway API resources of a GKE cluster, using the current kubectl context, with their load balancer annotations, and flag deprecated annotations and fields, Ingresses applied with APIs removed up to the target version, and Gateway API objects applied with versions the installed CRDs deprecate or no longer serve.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.auditServiceExposure)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "audit_storage",
+		Description: "Audit the storage of a GKE cluster before an upgrade to a target version, using the current kubectl context: StatefulSets and their storage classes, CSI drivers in use, volume attachments per node against the attach limits, and StorageClasses and PersistentVolumes using in-tree volume plugins removed or migrated to CSI in the target version range.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.auditStorage)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "audit_spot_placement",
+		Description: "Audit the placement of workloads on Spot and preemptible nodes in the current kubectl context: stateful or un-retryable workloads running on Spot nodes (StatefulSets, bare pods, Jobs not restarting their pods, pods with PersistentVolumeClaims or not safe to evict), and stateless batch Jobs running on more expensive on-demand nodes, so that cost recommendations only suggest safe moves.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.auditSpotPlacement)
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "check_autopilot_compatibility",
+		Description: "Check whether the workloads of the current kubectl context can run on GKE Autopilot: list the blockers (privileged containers, added capabilities, host namespaces, writable or non-log hostPath volumes, node pool selectors) and the changes to review (DaemonSets, GPUs, custom node selectors, host ports, missing resource requests) of each workload outside of the namespaces managed by GKE.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.checkAutopilotCompatibility)
+
+	return nil
+}
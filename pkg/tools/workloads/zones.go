@@ -0,0 +1,297 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/gke"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// maxZoneWorkloads bounds the workloads listed as running entirely in a zone.
+const maxZoneWorkloads = 20
+
+// volumeZoneKeys are the node labels the node affinity of persistent disk
+// volumes pins them to zones with.
+var volumeZoneKeys = map[string]bool{
+	"topology.gke.io/zone":                   true,
+	zoneTopologyKey:                          true,
+	"failure-domain.beta.kubernetes.io/zone": true,
+}
+
+type checkZonalTopologyArgs struct {
+	ProjectID string `json:"project_id,omitempty" jsonschema:"GCP project ID. Use the default if the user doesn't provide it."`
+	Location  string `json:"location" jsonschema:"GKE cluster location. Leave this empty if the user doesn't provide it."`
+	Name      string `json:"name" jsonschema:"GKE cluster name. Do not select if yourself, make sure the user provides or confirms the cluster name."`
+}
+
+// zonalInventory is the in-cluster data the zonal topology check reasons
+// about.
+type zonalInventory struct {
+	nodes        []kube.Node
+	pods         []kube.Pod
+	workloads    []replicatedWorkload
+	statefulSets []kube.StatefulSet
+	volumes      []kube.PersistentVolume
+}
+
+func (h *handlers) checkZonalTopology(ctx context.Context, _ *mcp.CallToolRequest, args *checkZonalTopologyArgs) (*mcp.CallToolResult, any, error) {
+	cluster, err := gke.GetCluster(ctx, h.cmClient, h.c, args.ProjectID, args.Location, args.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var inv zonalInventory
+	var nodes kube.List[kube.Node]
+	if err := kube.Get(ctx, &nodes, "nodes"); err != nil {
+		return nil, nil, err
+	}
+	inv.nodes = nodes.Items
+	var pods kube.List[kube.Pod]
+	if err := kube.Get(ctx, &pods, "pods", "--all-namespaces", "--field-selector=status.phase=Running"); err != nil {
+		return nil, nil, err
+	}
+	inv.pods = pods.Items
+	var deployments kube.List[kube.Deployment]
+	if err := kube.Get(ctx, &deployments, "deployments", "--all-namespaces"); err != nil {
+		return nil, nil, err
+	}
+	var statefulSets kube.List[kube.StatefulSet]
+	if err := kube.Get(ctx, &statefulSets, "statefulsets", "--all-namespaces"); err != nil {
+		return nil, nil, err
+	}
+	inv.statefulSets = statefulSets.Items
+	for _, d := range deployments.Items {
+		inv.workloads = append(inv.workloads, replicatedWorkload{kind: "Deployment", meta: d.ObjectMeta, replicas: replicas(d.Spec.Replicas), template: d.Spec.Template})
+	}
+	for _, s := range statefulSets.Items {
+		inv.workloads = append(inv.workloads, replicatedWorkload{kind: "StatefulSet", meta: s.ObjectMeta, replicas: replicas(s.Spec.Replicas), template: s.Spec.Template})
+	}
+	var volumes kube.List[kube.PersistentVolume]
+	if err := kube.Get(ctx, &volumes, "persistentvolumes"); err != nil {
+		return nil, nil, err
+	}
+	inv.volumes = volumes.Items
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: zonalTopologyReport(cluster, inv)},
+		},
+	}, nil, nil
+}
+
+// nodePoolZones returns the zones of the nodes of a node pool, the default
+// node locations of the cluster when the node pool does not set its own.
+func nodePoolZones(cluster *containerpb.Cluster, pool *containerpb.NodePool) []string {
+	if zones := pool.GetLocations(); len(zones) > 0 {
+		return zones
+	}
+	return cluster.GetLocations()
+}
+
+// volumeZones returns the zones the node affinity of a volume pins it to,
+// sorted. A zonal persistent disk has one zone, a regional one has two.
+func volumeZones(pv kube.PersistentVolume) []string {
+	if pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+		return nil
+	}
+	seen := map[string]bool{}
+	var zones []string
+	for _, term := range pv.Spec.NodeAffinity.Required.NodeSelectorTerms {
+		for _, expr := range term.MatchExpressions {
+			if !volumeZoneKeys[expr.Key] || expr.Operator != "In" {
+				continue
+			}
+			for _, zone := range expr.Values {
+				if !seen[zone] {
+					seen[zone] = true
+					zones = append(zones, zone)
+				}
+			}
+		}
+	}
+	sort.Strings(zones)
+	return zones
+}
+
+// statefulSetVolumeZones returns the number of single-zone volumes claimed
+// by the replicas of a StatefulSet, and their distinct zones.
+func statefulSetVolumeZones(sts kube.StatefulSet, volumes []kube.PersistentVolume) (int, []string) {
+	count := 0
+	seen := map[string]bool{}
+	var zones []string
+	for _, pv := range volumes {
+		ref := pv.Spec.ClaimRef
+		if ref == nil || ref.Namespace != sts.Namespace {
+			continue
+		}
+		claimed := false
+		for _, t := range sts.Spec.VolumeClaimTemplates {
+			// The claims of the replicas are named <template>-<statefulset>-<ordinal>.
+			if strings.HasPrefix(ref.Name, t.Name+"-"+sts.Name+"-") {
+				claimed = true
+				break
+			}
+		}
+		if z := volumeZones(pv); claimed && len(z) == 1 {
+			count++
+			if !seen[z[0]] {
+				seen[z[0]] = true
+				zones = append(zones, z[0])
+			}
+		}
+	}
+	sort.Strings(zones)
+	return count, zones
+}
+
+// workloadPodZones returns the number of running pods of the workload in
+// each zone.
+func workloadPodZones(w replicatedWorkload, pods []kube.Pod, nodeZones map[string]string) map[string]int {
+	zones := map[string]int{}
+	if len(w.template.Labels) == 0 {
+		return zones
+	}
+	selector := labels.SelectorFromSet(w.template.Labels)
+	for _, pod := range pods {
+		if pod.Namespace != w.meta.Namespace || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		if zone, ok := nodeZones[pod.Spec.NodeName]; ok {
+			zones[zone]++
+		}
+	}
+	return zones
+}
+
+// percent returns part as a rounded percentage of total.
+func percent(part, total int) int {
+	if total == 0 {
+		return 0
+	}
+	return (part*100 + total/2) / total
+}
+
+func zonalTopologyReport(cluster *containerpb.Cluster, inv zonalInventory) string {
+	var b strings.Builder
+	var risks []string
+	fmt.Fprintf(&b, "Cluster: %s (location %s)\n", cluster.GetName(), cluster.GetLocation())
+
+	if gke.Region(cluster.GetLocation()) != cluster.GetLocation() {
+		fmt.Fprintf(&b, "Control plane: zonal, in %s\n", cluster.GetLocation())
+		risks = append(risks, fmt.Sprintf("The control plane is zonal: while %s is unavailable, and during every control plane upgrade, the Kubernetes API is unavailable. Running workloads keep serving, but cannot be scaled, rescheduled or updated. Only a new regional cluster has a replicated control plane.", cluster.GetLocation()))
+	} else {
+		fmt.Fprintf(&b, "Control plane: regional, replicated across the zones of %s\n", cluster.GetLocation())
+	}
+
+	b.WriteString("\nNode pools:\n")
+	for _, pool := range cluster.GetNodePools() {
+		zones := nodePoolZones(cluster, pool)
+		fmt.Fprintf(&b, "- %s: %s\n", pool.GetName(), joinOrNone(zones))
+		if len(zones) == 1 {
+			risks = append(risks, fmt.Sprintf("Node pool %s only has nodes in %s, its workloads have no capacity left while the zone is unavailable. Add zones with gcloud container node-pools update %s --cluster %s --location %s --node-locations ZONES.", pool.GetName(), zones[0], pool.GetName(), cluster.GetName(), cluster.GetLocation()))
+		}
+	}
+
+	var pinned []string
+	for _, sts := range inv.statefulSets {
+		count, zones := statefulSetVolumeZones(sts, inv.volumes)
+		switch {
+		case count == 0:
+			continue
+		case len(zones) == 1:
+			pinned = append(pinned, fmt.Sprintf("- %s/%s: its %d zonal volumes are all in %s, the StatefulSet is down while the zone is unavailable", sts.Namespace, sts.Name, count, zones[0]))
+			risks = append(risks, fmt.Sprintf("StatefulSet %s/%s keeps all its data in %s. Spread its replicas across zones, or use a StorageClass provisioning regional persistent disks.", sts.Namespace, sts.Name, zones[0]))
+		default:
+			pinned = append(pinned, fmt.Sprintf("- %s/%s: its %d zonal volumes are in %s, each replica can only run in the zone of its volume", sts.Namespace, sts.Name, count, strings.Join(zones, ", ")))
+		}
+	}
+	sort.Strings(pinned)
+	b.WriteString("\nZone-pinned StatefulSets:\n")
+	if len(pinned) == 0 {
+		b.WriteString("none\n")
+	} else {
+		b.WriteString(strings.Join(pinned, "\n"))
+		b.WriteString("\n")
+	}
+
+	nodeZones := map[string]string{}
+	nodesPerZone := map[string]int{}
+	for _, node := range inv.nodes {
+		if zone, ok := node.Labels[zoneTopologyKey]; ok {
+			nodeZones[node.Name] = zone
+			nodesPerZone[zone]++
+		}
+	}
+	podsPerZone := map[string]int{}
+	for _, pod := range inv.pods {
+		if zone, ok := nodeZones[pod.Spec.NodeName]; ok {
+			podsPerZone[zone]++
+		}
+	}
+	zoneWorkloads := map[string][]string{}
+	for _, w := range inv.workloads {
+		zones := workloadPodZones(w, inv.pods, nodeZones)
+		if len(zones) != 1 {
+			continue
+		}
+		for zone, count := range zones {
+			zoneWorkloads[zone] = append(zoneWorkloads[zone], fmt.Sprintf("%s/%s %s (%d/%d replicas)", w.meta.Namespace, w.kind, w.meta.Name, count, w.replicas))
+		}
+	}
+
+	var zones []string
+	for zone := range nodesPerZone {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+	b.WriteString("\nBlast radius of the loss of each zone:\n")
+	if len(zones) == 0 {
+		b.WriteString("none, no node has a zone label\n")
+	}
+	for _, zone := range zones {
+		fmt.Fprintf(&b, "- %s: %d nodes (%d%%), %d running pods (%d%%)\n", zone, nodesPerZone[zone], percent(nodesPerZone[zone], len(nodeZones)), podsPerZone[zone], percent(podsPerZone[zone], len(inv.pods)))
+		workloads := zoneWorkloads[zone]
+		if len(workloads) == 0 {
+			continue
+		}
+		sort.Strings(workloads)
+		fmt.Fprintf(&b, "  Workloads running only in %s: %d\n", zone, len(workloads))
+		for i, w := range workloads {
+			if i == maxZoneWorkloads {
+				fmt.Fprintf(&b, "  ... and %d more\n", len(workloads)-maxZoneWorkloads)
+				break
+			}
+			fmt.Fprintf(&b, "  - %s\n", w)
+		}
+	}
+
+	if len(risks) == 0 {
+		b.WriteString("\nThe control plane, node pools and StatefulSet volumes of the cluster span several zones.\n")
+		return b.String()
+	}
+	b.WriteString("\nZonal risks:\n")
+	for _, risk := range risks {
+		fmt.Fprintf(&b, "- %s\n", risk)
+	}
+	return b.String()
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestVolumeZones(t *testing.T) {
+	var volumes []kube.PersistentVolume
+	if err := json.Unmarshal([]byte(`[
+		{"metadata": {"name": "zonal"}, "spec": {"nodeAffinity": {"required": {"nodeSelectorTerms": [{"matchExpressions": [{"key": "topology.gke.io/zone", "operator": "In", "values": ["us-central1-a"]}]}]}}}},
+		{"metadata": {"name": "regional"}, "spec": {"nodeAffinity": {"required": {"nodeSelectorTerms": [{"matchExpressions": [{"key": "topology.gke.io/zone", "operator": "In", "values": ["us-central1-c", "us-central1-a"]}]}]}}}},
+		{"metadata": {"name": "other-key"}, "spec": {"nodeAffinity": {"required": {"nodeSelectorTerms": [{"matchExpressions": [{"key": "kubernetes.io/hostname", "operator": "In", "values": ["node-1"]}]}]}}}},
+		{"metadata": {"name": "none"}, "spec": {}}
+	]`), &volumes); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{"zonal": "us-central1-a", "regional": "us-central1-a,us-central1-c", "other-key": "", "none": ""}
+	for _, pv := range volumes {
+		if got := strings.Join(volumeZones(pv), ","); got != want[pv.Name] {
+			t.Errorf("volumeZones(%s) = %q, want %q", pv.Name, got, want[pv.Name])
+		}
+	}
+}
+
+func TestZonalTopologyReport(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		Name:      "prod",
+		Location:  "us-central1-a",
+		Locations: []string{"us-central1-a", "us-central1-b"},
+		NodePools: []*containerpb.NodePool{
+			{Name: "default-pool"},
+			{Name: "db", Locations: []string{"us-central1-a"}},
+		},
+	}
+
+	var inv zonalInventory
+	if err := json.Unmarshal([]byte(`[
+		{"metadata": {"name": "node-a1", "labels": {"topology.kubernetes.io/zone": "us-central1-a"}}},
+		{"metadata": {"name": "node-a2", "labels": {"topology.kubernetes.io/zone": "us-central1-a"}}},
+		{"metadata": {"name": "node-b1", "labels": {"topology.kubernetes.io/zone": "us-central1-b"}}}
+	]`), &inv.nodes); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`[
+		{"metadata": {"name": "web-1", "namespace": "shop", "labels": {"app": "web"}}, "spec": {"nodeName": "node-a1"}},
+		{"metadata": {"name": "web-2", "namespace": "shop", "labels": {"app": "web"}}, "spec": {"nodeName": "node-b1"}},
+		{"metadata": {"name": "cart-1", "namespace": "shop", "labels": {"app": "cart"}}, "spec": {"nodeName": "node-a1"}},
+		{"metadata": {"name": "cart-2", "namespace": "shop", "labels": {"app": "cart"}}, "spec": {"nodeName": "node-a2"}},
+		{"metadata": {"name": "postgres-0", "namespace": "db", "labels": {"app": "postgres"}}, "spec": {"nodeName": "node-a2"}}
+	]`), &inv.pods); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`[
+		{"metadata": {"name": "postgres", "namespace": "db"}, "spec": {"replicas": 2, "template": {"metadata": {"labels": {"app": "postgres"}}}, "volumeClaimTemplates": [{"metadata": {"name": "data"}}]}},
+		{"metadata": {"name": "kafka", "namespace": "db"}, "spec": {"replicas": 2, "template": {"metadata": {"labels": {"app": "kafka"}}}, "volumeClaimTemplates": [{"metadata": {"name": "logs"}}]}}
+	]`), &inv.statefulSets); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`[
+		{"metadata": {"name": "pv-1"}, "spec": {"claimRef": {"namespace": "db", "name": "data-postgres-0"}, "nodeAffinity": {"required": {"nodeSelectorTerms": [{"matchExpressions": [{"key": "topology.gke.io/zone", "operator": "In", "values": ["us-central1-a"]}]}]}}}},
+		{"metadata": {"name": "pv-2"}, "spec": {"claimRef": {"namespace": "db", "name": "data-postgres-1"}, "nodeAffinity": {"required": {"nodeSelectorTerms": [{"matchExpressions": [{"key": "topology.gke.io/zone", "operator": "In", "values": ["us-central1-a"]}]}]}}}},
+		{"metadata": {"name": "pv-3"}, "spec": {"claimRef": {"namespace": "db", "name": "logs-kafka-0"}, "nodeAffinity": {"required": {"nodeSelectorTerms": [{"matchExpressions": [{"key": "topology.gke.io/zone", "operator": "In", "values": ["us-central1-a"]}]}]}}}},
+		{"metadata": {"name": "pv-4"}, "spec": {"claimRef": {"namespace": "db", "name": "logs-kafka-1"}, "nodeAffinity": {"required": {"nodeSelectorTerms": [{"matchExpressions": [{"key": "topology.gke.io/zone", "operator": "In", "values": ["us-central1-b"]}]}]}}}},
+		{"metadata": {"name": "pv-5"}, "spec": {"claimRef": {"namespace": "shop", "name": "data-postgres-0"}, "nodeAffinity": {"required": {"nodeSelectorTerms": [{"matchExpressions": [{"key": "topology.gke.io/zone", "operator": "In", "values": ["us-central1-b"]}]}]}}}}
+	]`), &inv.volumes); err != nil {
+		t.Fatal(err)
+	}
+	inv.workloads = []replicatedWorkload{
+		{kind: "Deployment", meta: metav1.ObjectMeta{Namespace: "shop", Name: "web"}, replicas: 2, template: kube.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}}}},
+		{kind: "Deployment", meta: metav1.ObjectMeta{Namespace: "shop", Name: "cart"}, replicas: 2, template: kube.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "cart"}}}},
+		{kind: "StatefulSet", meta: metav1.ObjectMeta{Namespace: "db", Name: "postgres"}, replicas: 2, template: kube.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "postgres"}}}},
+	}
+
+	got := zonalTopologyReport(cluster, inv)
+	for _, want := range []string{
+		"Control plane: zonal, in us-central1-a\n",
+		"- default-pool: us-central1-a, us-central1-b\n- db: us-central1-a\n",
+		"- db/kafka: its 2 zonal volumes are in us-central1-a, us-central1-b, each replica can only run in the zone of its volume\n",
+		"- db/postgres: its 2 zonal volumes are all in us-central1-a, the StatefulSet is down while the zone is unavailable\n",
+		"- us-central1-a: 2 nodes (67%), 4 running pods (80%)\n  Workloads running only in us-central1-a: 2\n  - db/StatefulSet postgres (1/2 replicas)\n  - shop/Deployment cart (2/2 replicas)\n",
+		"- us-central1-b: 1 nodes (33%), 1 running pods (20%)\n",
+		"- The control plane is zonal:",
+		"- Node pool db only has nodes in us-central1-a,",
+		"--node-locations ZONES.\n",
+		"- StatefulSet db/postgres keeps all its data in us-central1-a.",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("zonalTopologyReport() missing %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Node pool default-pool only") || strings.Contains(got, "StatefulSet db/kafka keeps") {
+		t.Errorf("zonalTopologyReport() reports a multi-zone resource as a risk, got:\n%s", got)
+	}
+}
+
+func TestZonalTopologyReport_Regional(t *testing.T) {
+	cluster := &containerpb.Cluster{
+		Name:      "prod",
+		Location:  "us-central1",
+		Locations: []string{"us-central1-a", "us-central1-b", "us-central1-c"},
+		NodePools: []*containerpb.NodePool{{Name: "default-pool"}},
+	}
+
+	got := zonalTopologyReport(cluster, zonalInventory{})
+	for _, want := range []string{
+		"Control plane: regional, replicated across the zones of us-central1\n",
+		"Zone-pinned StatefulSets:\nnone\n",
+		"The control plane, node pools and StatefulSet volumes of the cluster span several zones.",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("zonalTopologyReport() missing %q, got:\n%s", want, got)
+		}
+	}
+}
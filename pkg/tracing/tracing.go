@@ -0,0 +1,177 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing configures OpenTelemetry tracing of the server and creates
+// the spans of the MCP tool calls, prompts and external commands.
+package tracing
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/oauth2"
+)
+
+// tracerName is the name of the tracer of the server spans.
+const tracerName = "github.com/GoogleCloudPlatform/gke-mcp"
+
+// cloudTraceEndpoint is the OTLP endpoint of Cloud Trace.
+const cloudTraceEndpoint = "https://telemetry.googleapis.com"
+
+// Options configures the export of the traces.
+type Options struct {
+	// Exporter is the trace exporter: otlp, cloudtrace, or none when empty.
+	Exporter string `json:"exporter,omitempty"`
+	// Endpoint is the URL of the OTLP endpoint, read from the
+	// OTEL_EXPORTER_OTLP_ENDPOINT environment variable when empty.
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// Merge returns the options of o overridden by the non-empty fields of other.
+func (o Options) Merge(other Options) Options {
+	if other.Exporter != "" {
+		o.Exporter = other.Exporter
+	}
+	if other.Endpoint != "" {
+		o.Endpoint = other.Endpoint
+	}
+	return o
+}
+
+// TokenSourceFunc returns the source of the OAuth tokens of Google APIs, see config.Config.TokenSource.
+type TokenSourceFunc func(ctx context.Context) (oauth2.TokenSource, error)
+
+// Setup installs the global tracer provider exporting the traces as
+// configured, and returns the function flushing and stopping it. The traces
+// exported to Cloud Trace are billed to projectID, and authenticated with
+// tokenSource. Without exporter, the spans are not recorded.
+func Setup(ctx context.Context, opts Options, version, projectID string, tokenSource TokenSourceFunc) (func(context.Context) error, error) {
+	var exporterOpts []otlptracehttp.Option
+	switch strings.ToLower(opts.Exporter) {
+	case "", "none":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		if opts.Endpoint != "" {
+			exporterOpts = append(exporterOpts, otlptracehttp.WithEndpointURL(opts.Endpoint))
+		}
+	case "cloudtrace":
+		// Cloud Trace ingests OTLP, authenticated with the Google credentials of the server.
+		if projectID == "" {
+			return nil, fmt.Errorf("the cloudtrace exporter requires a default project")
+		}
+		ts, err := tokenSource(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get credentials for Cloud Trace: %w", err)
+		}
+		endpoint := opts.Endpoint
+		if endpoint == "" {
+			endpoint = cloudTraceEndpoint
+		}
+		exporterOpts = append(exporterOpts,
+			otlptracehttp.WithEndpointURL(endpoint+"/v1/traces"),
+			otlptracehttp.WithHTTPClient(oauth2.NewClient(ctx, ts)),
+			otlptracehttp.WithHeaders(map[string]string{"x-goog-user-project": projectID}),
+		)
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q, use otlp or cloudtrace", opts.Exporter)
+	}
+
+	exporter, err := otlptracehttp.New(ctx, exporterOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace exporter: %w", err)
+	}
+	res := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName("gke-mcp"),
+		semconv.ServiceVersion(version),
+	)
+	if projectID != "" {
+		res, _ = resource.Merge(res, resource.NewSchemaless(attribute.String("gcp.project_id", projectID)))
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	// Trace the HTTP requests of the tools fetching release notes and changelogs.
+	http.DefaultTransport = otelhttp.NewTransport(http.DefaultTransport)
+	return tp.Shutdown, nil
+}
+
+// Start starts a span with the tracer of the server.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records the error, if any, on the span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Middleware creates a span for each tool call and prompt of the server, with
+// the tool or prompt name, the hash of the arguments and the outcome.
+func Middleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		var name string
+		var attrs []attribute.KeyValue
+		switch params := req.GetParams().(type) {
+		case *mcp.CallToolParamsRaw:
+			name = params.Name
+			attrs = append(attrs, attribute.String("mcp.tool.name", name), attribute.String("mcp.tool.arguments_hash", hash(params.Arguments)))
+		case *mcp.GetPromptParams:
+			name = params.Name
+			attrs = append(attrs, attribute.String("mcp.prompt.name", name))
+		default:
+			return next(ctx, method, req)
+		}
+		if ss, ok := req.GetSession().(*mcp.ServerSession); ok && ss != nil && ss.ID() != "" {
+			attrs = append(attrs, attribute.String("mcp.session.id", ss.ID()))
+		}
+
+		ctx, span := Start(ctx, method+" "+name, attrs...)
+		result, err := next(ctx, method, req)
+		if r, ok := result.(*mcp.CallToolResult); ok && r != nil && r.IsError {
+			span.SetAttributes(attribute.Bool("mcp.tool.is_error", true))
+			span.SetStatus(codes.Error, "tool returned an error")
+		}
+		End(span, err)
+		return result, err
+	}
+}
+
+// hash returns a short hash of the arguments, identifying identical calls
+// without exporting the arguments, which can be sensitive.
+func hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) string {
+	for _, attr := range span.Attributes() {
+		if attr.Key == key {
+			return attr.Value.Emit()
+		}
+	}
+	return ""
+}
+
+func TestMiddleware(t *testing.T) {
+	recorder := recordSpans(t)
+	req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "list_clusters", Arguments: json.RawMessage(`{"project_id":"my-project"}`)}}
+
+	next := func(context.Context, string, mcp.Request) (mcp.Result, error) {
+		return &mcp.CallToolResult{}, nil
+	}
+	if _, err := Middleware(next)(context.Background(), "tools/call", req); err != nil {
+		t.Fatalf("Middleware() error = %v", err)
+	}
+	failing := func(context.Context, string, mcp.Request) (mcp.Result, error) {
+		return nil, errors.New("boom")
+	}
+	if _, err := Middleware(failing)(context.Background(), "tools/call", req); err == nil {
+		t.Fatalf("Middleware() returned no error")
+	}
+	if _, err := Middleware(next)(context.Background(), "tools/list", &mcp.ListToolsRequest{}); err != nil {
+		t.Fatalf("Middleware() error = %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("Middleware() recorded %d spans, want one per tool call", len(spans))
+	}
+	if got := spans[0].Name(); got != "tools/call list_clusters" {
+		t.Errorf("span name = %q, want tools/call list_clusters", got)
+	}
+	if got := spanAttribute(spans[0], "mcp.tool.name"); got != "list_clusters" {
+		t.Errorf("mcp.tool.name = %q, want list_clusters", got)
+	}
+	if got := spanAttribute(spans[0], "mcp.tool.arguments_hash"); got == "" || got == "my-project" {
+		t.Errorf("mcp.tool.arguments_hash = %q, want a hash of the arguments", got)
+	}
+	if spans[0].Status().Code == codes.Error || spans[1].Status().Code != codes.Error {
+		t.Errorf("span statuses = %v, %v, want the failed call only in error", spans[0].Status(), spans[1].Status())
+	}
+}
+
+func TestSetup(t *testing.T) {
+	shutdown, err := Setup(context.Background(), Options{}, "test", "", nil)
+	if err != nil {
+		t.Fatalf("Setup() without exporter error = %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("shutdown() error = %v", err)
+	}
+	if _, err := Setup(context.Background(), Options{Exporter: "zipkin"}, "test", "", nil); err == nil {
+		t.Errorf("Setup() with an unknown exporter returned no error")
+	}
+	if _, err := Setup(context.Background(), Options{Exporter: "cloudtrace"}, "test", "", nil); err == nil {
+		t.Errorf("Setup() of Cloud Trace without project returned no error")
+	}
+}